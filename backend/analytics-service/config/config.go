@@ -46,6 +46,7 @@ type (
 		Brokers []string `env-required:"true" yaml:"brokers" env:"KAFKA_BROKERS"`
 		Topics  struct {
 			BookingEvents string `env-required:"true" yaml:"booking_events" env:"KAFKA_BOOKING_EVENTS"`
+			AuthEvents    string `env-required:"true" yaml:"auth_events" env:"KAFKA_AUTH_EVENTS"`
 		} `env-required:"true" yaml:"topics" env:"KAFKA_TOPICS"`
 		Consumer KafkaConsumer `yaml:"consumer"`
 	}
@@ -56,6 +57,11 @@ type (
 		SessionTimeout    time.Duration `yaml:"session_timeout" env:"KAFKA_CONSUMER_SESSION_TIMEOUT"`
 		HeartbeatInterval time.Duration `yaml:"heartbeat_interval" env:"KAFKA_CONSUMER_HEARTBEAT_INTERVAL"`
 		CommitInterval    time.Duration `yaml:"commit_interval" env:"KAFKA_CONSUMER_COMMIT_INTERVAL"`
+
+		// Повторы через <topic>.<group>.retry, после исчерпания — <topic>.<group>.dlq
+		RetryMaxAttempts int           `yaml:"retry_max_attempts" env:"KAFKA_CONSUMER_RETRY_MAX_ATTEMPTS" env-default:"5"`
+		RetryBackoff     time.Duration `yaml:"retry_backoff" env:"KAFKA_CONSUMER_RETRY_BACKOFF" env-default:"5s"`
+		RetryMaxBackoff  time.Duration `yaml:"retry_max_backoff" env:"KAFKA_CONSUMER_RETRY_MAX_BACKOFF" env-default:"5m"`
 	}
 
 	BatchBuffer struct {
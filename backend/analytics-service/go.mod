@@ -59,3 +59,6 @@ require (
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
 	golang.org/x/sys v0.41.0 // indirect
 )
+
+// auth-service собирается из этого же репозитория
+replace github.com/4udiwe/coworking/auth-service => ../auth-service
@@ -0,0 +1,12 @@
+package get_internal_user_export
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/analytics-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type AnalyticsService interface {
+	ExportUserEvents(ctx context.Context, userID uuid.UUID) ([]entity.BookingEvent, error)
+}
@@ -0,0 +1,68 @@
+package get_internal_user_export
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/coworking/analytics-service/internal/api"
+	"github.com/4udiwe/coworking/analytics-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s AnalyticsService
+}
+
+// Выгрузка событий бронирований пользователя для auth-service (GET /users/me/export).
+// Доступна только сервисному токену со scope users.export.
+func New(analyticsService AnalyticsService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: analyticsService})
+}
+
+type Request struct {
+	UserID uuid.UUID `param:"userId" validate:"required"`
+}
+
+type Event struct {
+	EventID     uuid.UUID `json:"eventId"`
+	EventType   string    `json:"eventType"`
+	BookingID   uuid.UUID `json:"bookingId"`
+	CoworkingID uuid.UUID `json:"coworkingId"`
+	PlaceID     uuid.UUID `json:"placeId"`
+	StartTime   time.Time `json:"startTime"`
+	EndTime     time.Time `json:"endTime"`
+	Status      string    `json:"status"`
+	OccurredAt  time.Time `json:"occurredAt"`
+}
+
+type Response struct {
+	BookingEvents []Event `json:"bookingEvents"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+
+	events, err := h.s.ExportUserEvents(ctx.Request().Context(), in.UserID)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, Response{
+		BookingEvents: lo.Map(events, func(e entity.BookingEvent, _ int) Event {
+			return Event{
+				EventID:     e.EventID,
+				EventType:   e.EventType,
+				BookingID:   e.BookingID,
+				CoworkingID: e.CoworkingID,
+				PlaceID:     e.PlaceID,
+				StartTime:   e.StartTime,
+				EndTime:     e.EndTime,
+				Status:      e.BookingStatus,
+				OccurredAt:  e.Occurred,
+			}
+		}),
+	})
+}
@@ -9,14 +9,16 @@ import (
 	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
 	"github.com/4udiwe/coworking/analytics-service/config"
 	"github.com/4udiwe/coworking/analytics-service/internal/api"
-	"github.com/4udiwe/coworking/analytics-service/internal/api/middleware"
 	batch_buffer "github.com/4udiwe/coworking/analytics-service/internal/buffer"
+	consumer_auth "github.com/4udiwe/coworking/analytics-service/internal/consumer/auth"
 	consumer_booking "github.com/4udiwe/coworking/analytics-service/internal/consumer/booking"
 	"github.com/4udiwe/coworking/analytics-service/internal/database"
 	analytics_repository "github.com/4udiwe/coworking/analytics-service/internal/repository/analytics"
 	analytics_service "github.com/4udiwe/coworking/analytics-service/internal/service/analytics"
 	"github.com/4udiwe/coworking/analytics-service/pkg/clickhouse"
 	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/4udiwe/coworking/auth-service/pkg/retry"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/log"
 )
@@ -43,8 +45,11 @@ type App struct {
 	getHourlyLoadedHandler    api.Handler
 	getWeekdayLoadedHandler   api.Handler
 
+	getInternalUserExportHandler api.Handler
+
 	// Consumer
 	bookingConsumer *consumer_booking.Consumer
+	authConsumer    *consumer_auth.Consumer
 
 	// Batch buffer
 	buffer *batch_buffer.BatchBuffer
@@ -53,8 +58,10 @@ type App struct {
 	authMW *middleware.AuthMiddleware
 
 	// Auth
-	PublicKey    *rsa.PublicKey
-	jwtValidator *jwt_validator.Validator
+	PublicKey          *rsa.PublicKey
+	jwtValidator       *jwt_validator.Validator
+	denylist           *jwt_validator.Denylist
+	revocationListener *jwt_validator.RevocationListener
 }
 
 func New(configPath string) *App {
@@ -113,8 +120,16 @@ func (app *App) Start() {
 		app.AnalyticsService(),
 	)
 
-	// Consumers
-	bookingKafkaConsumer := kafka.NewConsumer(app.cfg.Kafka.Brokers)
+	// Consumers: упавшие сообщения перекладываются в retry-топик группы,
+	// исчерпавшие попытки — в DLQ
+	retryPolicy := retry.Policy{
+		MaxAttempts: app.cfg.Kafka.Consumer.RetryMaxAttempts,
+		Backoff:     app.cfg.Kafka.Consumer.RetryBackoff,
+		MaxBackoff:  app.cfg.Kafka.Consumer.RetryMaxBackoff,
+	}
+	retryPublisher := kafka.NewKafkaPublisher(app.cfg.Kafka.Brokers)
+
+	bookingKafkaConsumer := retry.NewConsumer(app.cfg.Kafka.Brokers, retryPublisher, retryPolicy)
 
 	app.bookingConsumer = consumer_booking.New(
 		app.buffer,
@@ -123,6 +138,23 @@ func (app *App) Start() {
 		app.cfg.Kafka.Consumer.GroupID,
 	)
 
+	authKafkaConsumer := retry.NewConsumer(app.cfg.Kafka.Brokers, retryPublisher, retryPolicy)
+
+	app.authConsumer = consumer_auth.New(
+		app.AnalyticsService(),
+		authKafkaConsumer,
+		app.cfg.Kafka.Topics.AuthEvents,
+		app.cfg.Kafka.Consumer.GroupID,
+	)
+
+	// Token revocation: auth.events -> local denylist
+	app.revocationListener = jwt_validator.NewRevocationListener(
+		app.Denylist(),
+		kafka.NewConsumer(app.cfg.Kafka.Brokers),
+		app.cfg.Kafka.Topics.AuthEvents,
+		app.cfg.Kafka.Consumer.GroupID,
+	)
+
 	// App server
 	log.Info("Starting app server...")
 	httpServer := httpserver.New(app.EchoHandler(), httpserver.Port(app.cfg.HTTP.Port))
@@ -131,6 +163,8 @@ func (app *App) Start() {
 
 	// Run consumers
 	app.bookingConsumer.Run(ctx)
+	app.authConsumer.Run(ctx)
+	app.revocationListener.Run(ctx)
 
 	select {
 	case s := <-app.interrupt:
@@ -4,6 +4,7 @@ import (
 	"github.com/4udiwe/coworking/analytics-service/internal/api"
 	"github.com/4udiwe/coworking/analytics-service/internal/api/get_coworking_heatmap"
 	"github.com/4udiwe/coworking/analytics-service/internal/api/get_hourly_loaded"
+	"github.com/4udiwe/coworking/analytics-service/internal/api/get_internal_user_export"
 	"github.com/4udiwe/coworking/analytics-service/internal/api/get_place_heatmap"
 	"github.com/4udiwe/coworking/analytics-service/internal/api/get_weekday_loaded"
 )
@@ -38,4 +39,12 @@ func (app *App) GetWeekdayLoadedHandler() api.Handler {
 	}
 	app.getWeekdayLoadedHandler = get_weekday_loaded.New(app.AnalyticsService())
 	return app.getWeekdayLoadedHandler
-}
\ No newline at end of file
+}
+
+func (app *App) GetInternalUserExportHandler() api.Handler {
+	if app.getInternalUserExportHandler != nil {
+		return app.getInternalUserExportHandler
+	}
+	app.getInternalUserExportHandler = get_internal_user_export.New(app.AnalyticsService())
+	return app.getInternalUserExportHandler
+}
@@ -1,8 +1,8 @@
 package app
 
 import (
-	"github.com/4udiwe/coworking/analytics-service/internal/api/middleware"
 	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/sirupsen/logrus"
 )
 
@@ -22,6 +22,17 @@ func (app *App) JwtValidator() *jwt_validator.Validator {
 	if err != nil {
 		logrus.WithError(err).Fatal("Failed to load public key")
 	}
-	app.jwtValidator = jwt_validator.NewValidator(publicKey)
+	app.jwtValidator = jwt_validator.NewValidator(
+		publicKey,
+		jwt_validator.WithDenylist(app.Denylist()),
+	)
 	return app.jwtValidator
 }
+
+func (app *App) Denylist() *jwt_validator.Denylist {
+	if app.denylist != nil {
+		return app.denylist
+	}
+	app.denylist = jwt_validator.NewDenylist()
+	return app.denylist
+}
@@ -3,8 +3,10 @@ package app
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/4udiwe/avito-pvz/pkg/validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
 	"github.com/labstack/echo/v4"
 )
 
@@ -27,7 +29,16 @@ func (app *App) EchoHandler() *echo.Echo {
 }
 
 func (app *App) configureRouter(handler *echo.Echo) {
-	handler.Use(app.AuthMiddleware().Middleware)
+	authMiddleware := app.AuthMiddleware()
+	handler.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// Внутренние маршруты проверяют сервисный токен сами
+			if strings.HasPrefix(c.Request().URL.Path, "/internal/") {
+				return next(c)
+			}
+			return authMiddleware.Middleware(next)(c)
+		}
+	})
 
 	// Public coworking and layout endpoints
 	coworkingGroup := handler.Group("/analytics")
@@ -38,5 +49,12 @@ func (app *App) configureRouter(handler *echo.Echo) {
 		coworkingGroup.GET("/weekday/:coworkingId", app.GetWeekdayLoadedHandler().Handle)
 	}
 
+	// Вызываются другими сервисами с сервисным токеном, через gateway не проксируются.
+	internalGroup := handler.Group("/internal")
+	{
+		internalGroup.GET("/users/:userId/export", app.GetInternalUserExportHandler().Handle,
+			authMiddleware.ServiceOnly(jwt_validator.ScopeUsersExport))
+	}
+
 	handler.GET("/health", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
 }
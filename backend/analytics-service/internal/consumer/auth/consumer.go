@@ -0,0 +1,61 @@
+package consumer_auth
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/4udiwe/coworking/analytics-service/internal/consumer"
+	analytics_service "github.com/4udiwe/coworking/analytics-service/internal/service/analytics"
+	"github.com/4udiwe/coworking/auth-service/pkg/retry"
+)
+
+// Обработчик событий жизненного цикла пользователей из топика auth.
+// Отзыв токенов по тем же событиям делает jwt_validator.RevocationListener.
+type Consumer struct {
+	service  *analytics_service.AnalyticsService
+	consumer *retry.Consumer
+	topic    string
+	groupID  string
+}
+
+func New(
+	service *analytics_service.AnalyticsService,
+	consumer *retry.Consumer,
+	topic string,
+	groupID string,
+) *Consumer {
+	return &Consumer{
+		service:  service,
+		consumer: consumer,
+		topic:    topic,
+		groupID:  groupID,
+	}
+}
+
+func (c *Consumer) Run(ctx context.Context) error {
+	logrus.Infof("AuthConsumer: subscribing to topic=%s group=%s", c.topic, c.groupID)
+
+	return c.consumer.Subscribe(ctx, c.topic, c.groupID, func(ctx context.Context, key, value []byte) error {
+		event, err := consumer.ParseOrderEvent(value)
+		if err != nil {
+			logrus.Errorf("AuthConsumer: failed to parse event: %v", err)
+			return retry.Permanent(err)
+		}
+
+		switch event.Type {
+
+		case consumer.UserDeleted:
+			err = c.service.AnonymizeUser(ctx, event.Payload.UserID, event.Payload.AnonymousID)
+			if err != nil {
+				logrus.Errorf("AuthConsumer: AnonymizeUser failed: %v", err)
+			}
+
+		default:
+			// Остальные события топика analytics-service не нужны
+			return nil
+		}
+
+		return err
+	})
+}
@@ -6,23 +6,23 @@ import (
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
-	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
 	batch_buffer "github.com/4udiwe/coworking/analytics-service/internal/buffer"
 	"github.com/4udiwe/coworking/analytics-service/internal/consumer"
 	"github.com/4udiwe/coworking/analytics-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/retry"
 )
 
 // Обработчик событий для топика booking
 type Consumer struct {
 	buffer   *batch_buffer.BatchBuffer
-	consumer *kafka.KafkaConsumer
+	consumer *retry.Consumer
 	topic    string
 	groupID  string
 }
 
 func New(
 	buffer *batch_buffer.BatchBuffer,
-	consumer *kafka.KafkaConsumer,
+	consumer *retry.Consumer,
 	topic string,
 	groupID string,
 ) *Consumer {
@@ -41,11 +41,18 @@ func (c *Consumer) Run(ctx context.Context) error {
 		event, err := consumer.ParseOrderEvent(value)
 		if err != nil {
 			logrus.Errorf("BookingConsumer: failed to parse event: %v", err)
-			return nil
+			return retry.Permanent(err)
+		}
+
+		// event_id из конверта делает повторную доставку распознаваемой
+		// при вставке; у событий без него дедупликации нет
+		eventID := event.EventID
+		if eventID == uuid.Nil {
+			eventID = uuid.New()
 		}
 
 		bookingEvent := entity.BookingEvent{
-			EventID:     uuid.New(),
+			EventID:     eventID,
 			EventType:   string(event.Type),
 			BookingID:   event.Payload.BookingID,
 			CoworkingID: event.Payload.CoworkingID,
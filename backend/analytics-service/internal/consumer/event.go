@@ -13,10 +13,14 @@ const (
 	BookingCreated   EventType = "booking.created"
 	BookingCancelled EventType = "booking.cancelled"
 	BookingCompleted EventType = "booking.completed"
+
+	UserDeleted EventType = "auth.user.deleted"
 )
 
 // Тип для обработки входящего события
 type IncomingEvent struct {
+	// Идентификатор из kafka.Envelope, ключ дедупликации
+	EventID    uuid.UUID
 	Type       EventType
 	OccurredAt time.Time
 	Payload    Payload
@@ -31,4 +35,5 @@ type Payload struct {
 	PlaceID     uuid.UUID `json:"placeId,omitempty"`
 	StartTime   time.Time `json:"startTime,omitzero"`
 	EndTime     time.Time `json:"endTime,omitzero"`
+	AnonymousID uuid.UUID `json:"anonymousId,omitempty"`
 }
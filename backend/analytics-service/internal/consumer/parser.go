@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
+	"github.com/4udiwe/coworking/auth-service/pkg/eventid"
 )
 
 var ErrUnknownEventType = errors.New("unknown event.type")
@@ -30,6 +31,7 @@ func ParseOrderEvent(data []byte) (*IncomingEvent, error) {
 	}
 
 	return &IncomingEvent{
+		EventID:    eventid.FromEnvelope(env),
 		Type:       EventType(env.EventType),
 		OccurredAt: env.OccurredAt,
 		Payload:    p,
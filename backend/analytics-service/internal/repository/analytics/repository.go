@@ -251,3 +251,111 @@ func (r *AnalyticsRepository) GetPlaceHeatmap(
 
 	return result, nil
 }
+
+// Возвращает event_id из переданного набора, которые уже есть в booking_events.
+// Нужен для дедупликации повторно доставленных событий перед вставкой:
+// материализованные представления агрегатов повторы не схлопывают.
+func (r *AnalyticsRepository) GetExistingEventIDs(
+	ctx context.Context,
+	eventIDs []uuid.UUID,
+) (map[uuid.UUID]struct{}, error) {
+
+	result := make(map[uuid.UUID]struct{})
+	if len(eventIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.ch.Conn().Query(ctx,
+		`
+        SELECT DISTINCT event_id
+        FROM booking_events
+        WHERE event_id IN (?)
+        `,
+		eventIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+
+		var id uuid.UUID
+
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		result[id] = struct{}{}
+	}
+
+	return result, nil
+}
+
+// Сырые события бронирований пользователя — для выгрузки его данных.
+func (r *AnalyticsRepository) GetUserEvents(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]entity.BookingEvent, error) {
+
+	rows, err := r.ch.Conn().Query(ctx,
+		`
+        SELECT event_id, event_type, booking_id, coworking_id, place_id,
+               start_time, end_time, status, occurred_at
+        FROM booking_events
+        WHERE user_id = ?
+        ORDER BY occurred_at
+        `,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []entity.BookingEvent
+
+	for rows.Next() {
+
+		e := entity.BookingEvent{UserID: userID}
+
+		if err := rows.Scan(
+			&e.EventID,
+			&e.EventType,
+			&e.BookingID,
+			&e.CoworkingID,
+			&e.PlaceID,
+			&e.StartTime,
+			&e.EndTime,
+			&e.BookingStatus,
+			&e.Occurred,
+		); err != nil {
+			return nil, err
+		}
+
+		result = append(result, e)
+	}
+
+	return result, nil
+}
+
+// Заменяет user_id удалённого пользователя на анонимный идентификатор
+// в сырых событиях и состоянии бронирований. Агрегаты user_id не содержат,
+// поэтому статистика не меняется.
+// ALTER TABLE ... UPDATE в ClickHouse — асинхронная мутация.
+func (r *AnalyticsRepository) AnonymizeUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	anonymousID uuid.UUID,
+) error {
+
+	for _, table := range []string{"booking_events", "booking_state"} {
+		if err := r.ch.Conn().Exec(ctx,
+			"ALTER TABLE "+table+" UPDATE user_id = ? WHERE user_id = ?",
+			anonymousID,
+			userID,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
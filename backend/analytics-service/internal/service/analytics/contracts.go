@@ -14,6 +14,9 @@ type AnalyticsRepository interface {
 	GetCoworkingWeekdayLoad(ctx context.Context, coworkingID uuid.UUID) (map[int]int, error)
 	GetCoworkingHeatmap(ctx context.Context, coworkingID uuid.UUID) ([]entity.HeatmapCell, error)
 	GetPlaceHeatmap(ctx context.Context, placeID uuid.UUID) ([]entity.HeatmapCell, error)
+	GetExistingEventIDs(ctx context.Context, eventIDs []uuid.UUID) (map[uuid.UUID]struct{}, error)
 	InsertEvents(ctx context.Context, events []entity.BookingEvent) error
 	InsertBookingState(ctx context.Context, events []entity.BookingEvent) error
+	GetUserEvents(ctx context.Context, userID uuid.UUID) ([]entity.BookingEvent, error)
+	AnonymizeUser(ctx context.Context, userID uuid.UUID, anonymousID uuid.UUID) error
 }
@@ -5,4 +5,5 @@ import "errors"
 var (
 	ErrCannotInsertEvents = errors.New("cannot insert events")
 	ErrCannotFetchInfo    = errors.New("cannot fetch info")
+	ErrCannotAnonymize    = errors.New("cannot anonymize user")
 )
@@ -21,10 +21,22 @@ func New(bookingRepo AnalyticsRepository) *AnalyticsService {
 
 // InsertEvents вставляет пакет событий бронирования в аналитическую базу данных.
 // Принимает контекст и срез сущностей BookingEvent.
+// События, уже записанные ранее или повторяющиеся внутри пакета (повторная
+// доставка из Kafka), отбрасываются по event_id.
 // В случае ошибки возвращает ErrCannotInsertEvents.
 func (s *AnalyticsService) InsertEvents(ctx context.Context, events []entity.BookingEvent) error {
+	events, err := s.dropDuplicates(ctx, events)
+	if err != nil {
+		logrus.WithError(err).Error("Check duplicate events failed")
+		return ErrCannotInsertEvents
+	}
+	if len(events) == 0 {
+		logrus.Info("All events are duplicates, nothing to insert")
+		return nil
+	}
+
 	logrus.Infof("Inserting events amount: %v", len(events))
-	err := s.repo.InsertEvents(ctx, events)
+	err = s.repo.InsertEvents(ctx, events)
 	if err != nil {
 		logrus.WithError(err).Error("Insert events failed")
 		return ErrCannotInsertEvents
@@ -41,6 +53,31 @@ func (s *AnalyticsService) InsertEvents(ctx context.Context, events []entity.Boo
 	return nil
 }
 
+// dropDuplicates убирает из пакета события с уже известным event_id.
+func (s *AnalyticsService) dropDuplicates(ctx context.Context, events []entity.BookingEvent) ([]entity.BookingEvent, error) {
+	ids := make([]uuid.UUID, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.EventID)
+	}
+
+	seen, err := s.repo.GetExistingEventIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]entity.BookingEvent, 0, len(events))
+	for _, e := range events {
+		if _, ok := seen[e.EventID]; ok {
+			logrus.Infof("Duplicate event skipped: %s", e.EventID)
+			continue
+		}
+		seen[e.EventID] = struct{}{}
+		result = append(result, e)
+	}
+
+	return result, nil
+}
+
 // GetHourlyLoad возвращает распределение количества бронирований по часам дня для коворкинга.
 // Если weekday не указан (nil), возвращает усреднённые часы по всем дням недели.
 // Если weekday указан (1-7), возвращает часы только для конкретного дня недели.
@@ -111,3 +148,31 @@ func (s *AnalyticsService) GetPlaceHeatmap(ctx context.Context, placeID uuid.UUI
 
 	return result, nil
 }
+
+// ExportUserEvents возвращает события бронирований пользователя
+// для выгрузки его данных (GDPR export в auth-service).
+// В случае ошибки возвращает ErrCannotFetchInfo.
+func (s *AnalyticsService) ExportUserEvents(ctx context.Context, userID uuid.UUID) ([]entity.BookingEvent, error) {
+	logrus.Infof("Exporting events of user: %s", userID)
+
+	result, err := s.repo.GetUserEvents(ctx, userID)
+	if err != nil {
+		logrus.Errorf("Failed to get user events: %v", err)
+		return nil, ErrCannotFetchInfo
+	}
+
+	return result, nil
+}
+
+// AnonymizeUser заменяет user_id удалённого пользователя на анонимный идентификатор.
+// В случае ошибки возвращает ErrCannotAnonymize.
+func (s *AnalyticsService) AnonymizeUser(ctx context.Context, userID uuid.UUID, anonymousID uuid.UUID) error {
+	logrus.Infof("Anonymizing events of user: %s", userID)
+
+	if err := s.repo.AnonymizeUser(ctx, userID, anonymousID); err != nil {
+		logrus.Errorf("Failed to anonymize user: %v", err)
+		return ErrCannotAnonymize
+	}
+
+	return nil
+}
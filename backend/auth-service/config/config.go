@@ -16,6 +16,16 @@ type (
 		Auth     Auth     `yaml:"auth"`
 		Hasher   Hasher   `yaml:"hasher"`
 		Kafka    Kafka    `yaml:"kafka"`
+		Outbox   Outbox   `yaml:"outbox"`
+		OIDC     OIDC     `yaml:"oidc"`
+		Clients  []Client `yaml:"clients"`
+		PAT      PAT      `yaml:"personal_tokens"`
+		Account  Account  `yaml:"account"`
+		Roster   Roster   `yaml:"roster"`
+
+		Impersonation Impersonation `yaml:"impersonation"`
+		Sessions      Sessions      `yaml:"sessions"`
+		GeoIP         GeoIP         `yaml:"geoip"`
 	}
 
 	App struct {
@@ -39,6 +49,7 @@ type (
 		PrivateKeyPath  string        `env-required:"true" yaml:"private_key_path" env:"AUTH_PRIVATE_KEY_PATH"`
 		AccessTokenTTL  time.Duration `env-required:"true" yaml:"access_token_ttl" env:"AUTH_ACCESS_TOKEN_TTL"`
 		RefreshTokenTTL time.Duration `env-required:"true" yaml:"refresh_token_ttl" env:"AUTH_REFRESH_TOKEN_TTL"`
+		ServiceTokenTTL time.Duration `yaml:"service_token_ttl" env:"AUTH_SERVICE_TOKEN_TTL" env-default:"5m"`
 	}
 	Hasher struct {
 		Cost int `env-required:"true" yaml:"cost" env:"HASHER_COST"`
@@ -54,6 +65,86 @@ type (
 		HeartbeatInterval time.Duration `yaml:"heartbeat_interval" env:"KAFKA_CONSUMER_HEARTBEAT_INTERVAL"`
 		CommitInterval    time.Duration `yaml:"commit_interval" env:"KAFKA_CONSUMER_COMMIT_INTERVAL"`
 	}
+	Outbox struct {
+		Topic           string        `env-required:"true" yaml:"topic" env:"OUTBOX_PUB_TOPIC"`
+		BatchLimit      int           `env-required:"true" yaml:"batch_limit" env:"OUTBOX_BATCH_LIMIT"`
+		Interval        time.Duration `env-required:"true" yaml:"interval" env:"OUTBOX_INTERVAL"`
+		RequeBatchLimit int           `env-required:"true" yaml:"reque_batch_limit" env:"OUTBOX_REQUE_BATCH_LIMIT"`
+		RequeInterval   time.Duration `env-required:"true" yaml:"reque_interval" env:"OUTBOX_REQUE_INTERVAL"`
+		// Письма пользователям с одноразовыми ссылками: читает только notification-service
+		MailTopic string `yaml:"mail_topic" env:"OUTBOX_MAIL_TOPIC" env-default:"auth.mail"`
+	}
+	OIDC struct {
+		Enabled      bool     `yaml:"enabled" env:"OIDC_ENABLED"`
+		IssuerURL    string   `yaml:"issuer_url" env:"OIDC_ISSUER_URL"`
+		ClientID     string   `yaml:"client_id" env:"OIDC_CLIENT_ID"`
+		ClientSecret string   `yaml:"client_secret" env:"OIDC_CLIENT_SECRET"`
+		RedirectURL  string   `yaml:"redirect_url" env:"OIDC_REDIRECT_URL"`
+		Scopes       []string `yaml:"scopes" env:"OIDC_SCOPES" env-default:"openid,email,profile"`
+		// Claim ID token со списком групп пользователя в IdP
+		GroupsClaim string `yaml:"groups_claim" env:"OIDC_GROUPS_CLAIM" env-default:"groups"`
+		// Группа IdP -> код роли (student | teacher | admin)
+		RoleMapping map[string]string `yaml:"role_mapping" env:"OIDC_ROLE_MAPPING"`
+		DefaultRole string            `yaml:"default_role" env:"OIDC_DEFAULT_ROLE" env-default:"student"`
+		// Перезаписывать роли по группам IdP при каждом SSO-входе
+		SyncRoles bool `yaml:"sync_roles" env:"OIDC_SYNC_ROLES"`
+		// Автоматически привязывать SSO к существующему аккаунту с тем же email (только email_verified)
+		LinkByEmail bool          `yaml:"link_by_email" env:"OIDC_LINK_BY_EMAIL"`
+		StateTTL    time.Duration `yaml:"state_ttl" env:"OIDC_STATE_TTL" env-default:"10m"`
+	}
+	PAT struct {
+		// Максимальный срок жизни персонального токена
+		MaxTTL time.Duration `yaml:"max_ttl" env:"PAT_MAX_TTL" env-default:"8760h"`
+	}
+	Account struct {
+		EmailChangeTTL time.Duration `yaml:"email_change_ttl" env:"ACCOUNT_EMAIL_CHANGE_TTL" env-default:"24h"`
+		// Страница приложения из письма: получает ?token= и вызывает POST /auth/email/confirm
+		EmailConfirmURL string         `yaml:"email_confirm_url" env:"ACCOUNT_EMAIL_CONFIRM_URL"`
+		ExportTimeout   time.Duration  `yaml:"export_timeout" env:"ACCOUNT_EXPORT_TIMEOUT" env-default:"10s"`
+		ExportSources   []ExportSource `yaml:"export_sources"`
+	}
+	// Roster — массовое заведение пользователей (POST /admin/users/import).
+	Roster struct {
+		InviteTTL time.Duration `yaml:"invite_ttl" env:"ROSTER_INVITE_TTL" env-default:"168h"`
+		// Страница приложения из приглашения: получает ?token= и вызывает POST /auth/invite/accept
+		InviteURL   string `yaml:"invite_url" env:"ROSTER_INVITE_URL"`
+		DefaultRole string `yaml:"default_role" env:"ROSTER_DEFAULT_ROLE" env-default:"student"`
+		MaxRows     int    `yaml:"max_rows" env:"ROSTER_MAX_ROWS" env-default:"5000"`
+	}
+	// Impersonation — вход поддержки от имени пользователя (POST /admin/users/:userId/impersonate).
+	Impersonation struct {
+		// Срок жизни токена; продлить нельзя, только выдать заново
+		TTL time.Duration `yaml:"ttl" env:"IMPERSONATION_TTL" env-default:"15m"`
+	}
+	// Sessions — лимит одновременных активных сессий пользователя.
+	Sessions struct {
+		// Лимит по умолчанию; 0 — без ограничений
+		MaxActive int `yaml:"max_active" env:"SESSIONS_MAX_ACTIVE" env-default:"5"`
+		// Лимит по коду роли; при нескольких ролях действует наибольший
+		MaxActiveByRole map[string]int `yaml:"max_active_by_role" env:"SESSIONS_MAX_ACTIVE_BY_ROLE"`
+		// true — отказать во входе при достижении лимита, false — отозвать самые старые сессии
+		RejectOnLimit bool `yaml:"reject_on_limit" env:"SESSIONS_REJECT_ON_LIMIT"`
+	}
+	// GeoIP — локальная MMDB база для определения местоположения сессий.
+	GeoIP struct {
+		// Пустой путь отключает определение местоположения
+		DatabasePath string `yaml:"database_path" env:"GEOIP_DATABASE_PATH"`
+		Locale       string `yaml:"locale" env:"GEOIP_LOCALE" env-default:"en"`
+	}
+	// ExportSource — сервис, отдающий свою часть экспорта данных пользователя
+	// на GET /internal/users/:userId/export.
+	ExportSource struct {
+		Name string `yaml:"name"`
+		URL  string `yaml:"url"`
+	}
+	// Client — сервис, регистрируемый при старте (client credentials).
+	// Секрет обновляется при каждом запуске, поэтому его можно ротировать через конфиг.
+	Client struct {
+		ClientID string   `yaml:"client_id"`
+		Name     string   `yaml:"name"`
+		Secret   string   `yaml:"secret"`
+		Scopes   []string `yaml:"scopes"`
+	}
 )
 
 func New(configPath string) (*Config, error) {
@@ -4,4 +4,4 @@ import "github.com/labstack/echo/v4"
 
 type Handler interface {
 	Handle(c echo.Context) error
-}
\ No newline at end of file
+}
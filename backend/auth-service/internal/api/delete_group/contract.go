@@ -0,0 +1,11 @@
+package delete_group
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type GroupService interface {
+	DeleteGroup(ctx context.Context, groupID uuid.UUID) error
+}
@@ -0,0 +1,34 @@
+package delete_group
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	group_service "github.com/4udiwe/coworking/auth-service/internal/service/group"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s GroupService
+}
+
+func New(s GroupService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.GroupByIDRequest
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	err := h.s.DeleteGroup(ctx.Request().Context(), in.GroupID)
+	if err != nil {
+		if errors.Is(err, group_service.ErrGroupNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,11 @@
+package delete_group_member
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type GroupService interface {
+	RemoveMember(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) error
+}
@@ -0,0 +1,37 @@
+package delete_group_member
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	group_service "github.com/4udiwe/coworking/auth-service/internal/service/group"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s GroupService
+}
+
+func New(s GroupService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct {
+	GroupID uuid.UUID `param:"groupId" validate:"required"`
+	UserID  uuid.UUID `param:"userId" validate:"required"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	err := h.s.RemoveMember(ctx.Request().Context(), in.GroupID, in.UserID)
+	if err != nil {
+		if errors.Is(err, group_service.ErrMemberNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
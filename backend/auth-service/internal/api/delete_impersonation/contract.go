@@ -0,0 +1,11 @@
+package delete_impersonation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ImpersonationService interface {
+	End(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,35 @@
+package delete_impersonation
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	impersonation_service "github.com/4udiwe/coworking/auth-service/internal/service/impersonation"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s ImpersonationService
+}
+
+func New(s ImpersonationService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct {
+	ImpersonationID uuid.UUID `param:"impersonationId" validate:"required"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	if err := h.s.End(ctx.Request().Context(), in.ImpersonationID); err != nil {
+		if errors.Is(err, impersonation_service.ErrImpersonationNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
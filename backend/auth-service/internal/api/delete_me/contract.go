@@ -0,0 +1,11 @@
+package delete_me
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type AccountService interface {
+	DeleteAccount(ctx context.Context, userID uuid.UUID, password string) error
+}
@@ -0,0 +1,45 @@
+package delete_me
+
+import (
+	"errors"
+	"net/http"
+
+	api "github.com/4udiwe/coworking/auth-service/internal/api"
+	account_service "github.com/4udiwe/coworking/auth-service/internal/service/account"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s AccountService
+}
+
+func New(accountService AccountService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: accountService})
+}
+
+type Request struct {
+	// Не нужен для аккаунтов без пароля (SSO)
+	Password string `json:"password"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	err = h.s.DeleteAccount(ctx.Request().Context(), claims.UserID, in.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, account_service.ErrInvalidPassword):
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		case errors.Is(err, account_service.ErrUserNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,11 @@
+package delete_personal_token
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type PersonalTokenService interface {
+	RevokeToken(ctx context.Context, userID uuid.UUID, tokenID uuid.UUID) error
+}
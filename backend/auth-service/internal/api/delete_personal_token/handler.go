@@ -0,0 +1,41 @@
+package delete_personal_token
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	pat_service "github.com/4udiwe/coworking/auth-service/internal/service/pat"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s PersonalTokenService
+}
+
+func New(s PersonalTokenService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct {
+	TokenID uuid.UUID `param:"tokenId" validate:"required"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	if err := h.s.RevokeToken(ctx.Request().Context(), claims.UserID, in.TokenID); err != nil {
+		if errors.Is(err, pat_service.ErrTokenNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,11 @@
+package delete_user_coworking_role
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type UserService interface {
+	RevokeCoworkingRole(ctx context.Context, userID uuid.UUID, roleCode string, coworkingID uuid.UUID) error
+}
@@ -0,0 +1,39 @@
+package delete_user_coworking_role
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	user_service "github.com/4udiwe/coworking/auth-service/internal/service/user"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s UserService
+}
+
+func New(s UserService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct {
+	UserID      uuid.UUID `param:"userId" validate:"required"`
+	CoworkingID uuid.UUID `param:"coworkingId" validate:"required"`
+	RoleCode    string    `param:"roleCode" validate:"required"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	err := h.s.RevokeCoworkingRole(ctx.Request().Context(), in.UserID, in.RoleCode, in.CoworkingID)
+
+	if err != nil {
+		if errors.Is(err, user_service.ErrCoworkingRoleNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -42,3 +42,94 @@ type Role struct {
 	RoleCode string    `json:"roleCode"`
 	Name     string    `json:"name"`
 }
+
+type RoleWithPermissions struct {
+	ID          uuid.UUID `json:"id"`
+	RoleCode    string    `json:"roleCode"`
+	Name        string    `json:"name"`
+	Permissions []string  `json:"permissions"`
+}
+
+type CoworkingRoleRequest struct {
+	UserID      uuid.UUID `param:"userId" validate:"required"`
+	CoworkingID uuid.UUID `json:"coworkingId" validate:"required"`
+	RoleCode    string    `json:"roleCode" validate:"required"`
+}
+
+type CoworkingRole struct {
+	RoleCode    string    `json:"roleCode"`
+	Name        string    `json:"name"`
+	CoworkingID uuid.UUID `json:"coworkingId"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type ServiceClient struct {
+	ClientID   string     `json:"clientId"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	IsActive   bool       `json:"isActive"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+type InternalUser struct {
+	ID        uuid.UUID `json:"id"`
+	FirstName string    `json:"firstName"`
+	LastName  string    `json:"lastName"`
+	Email     string    `json:"email"`
+	IsActive  bool      `json:"isActive"`
+}
+
+// InternalUserIDsResponse — страница ID пользователей; nextCursor отсутствует
+// на последней странице
+type InternalUserIDsResponse struct {
+	IDs        []uuid.UUID `json:"ids"`
+	NextCursor *uuid.UUID  `json:"nextCursor,omitempty"`
+}
+
+type PersonalToken struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Prefix      string     `json:"prefix"`
+	Permissions []string   `json:"permissions"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty"`
+}
+
+type GroupByIDRequest struct {
+	GroupID uuid.UUID `param:"groupId" validate:"required"`
+}
+
+type Group struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	MemberCount int       `json:"memberCount"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type Impersonation struct {
+	ID         uuid.UUID  `json:"id"`
+	ActorID    uuid.UUID  `json:"actorId"`
+	UserID     uuid.UUID  `json:"userId"`
+	Reason     string     `json:"reason"`
+	AllowWrite bool       `json:"allowWrite"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	EndedAt    *time.Time `json:"endedAt,omitempty"`
+}
+
+type Location struct {
+	Country string `json:"country"`
+	City    string `json:"city,omitempty"`
+}
+
+type SessionIP struct {
+	IPAddress   string    `json:"ipAddress"`
+	Location    *Location `json:"location,omitempty"`
+	FirstSeenAt time.Time `json:"firstSeenAt"`
+	LastSeenAt  time.Time `json:"lastSeenAt"`
+}
@@ -5,10 +5,11 @@ import (
 	"net/http"
 
 	api "github.com/4udiwe/coworking/auth-service/internal/api"
-	"github.com/4udiwe/coworking/auth-service/internal/api/middleware"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
 	"github.com/4udiwe/coworking/auth-service/internal/entity"
 	auth_service "github.com/4udiwe/coworking/auth-service/internal/service/auth"
 	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/lo"
 )
@@ -26,16 +27,20 @@ func New(userService UserService) api.Handler {
 type Request struct{}
 
 type ResponseSession struct {
-	ID         string `json:"id"`
-	UserID     string `json:"userId"`
-	UserAgent  string `json:"userAgent"`
-	Device     string `json:"device,omitempty"`
-	IPAddress  string `json:"ipAddress"`
-	Current    bool   `json:"current"`
-	Revoked    bool   `json:"revoked"`
-	CreatedAt  string `json:"createdAt"`
-	ExpiresAt  string `json:"expiresAt"`
-	LastUsedAt string `json:"lastUsedAt"`
+	ID         string        `json:"id"`
+	UserID     string        `json:"userId"`
+	UserAgent  string        `json:"userAgent"`
+	Device     string        `json:"device,omitempty"`
+	Browser    string        `json:"browser"`
+	OS         string        `json:"os"`
+	DeviceType string        `json:"deviceType"`
+	IPAddress  string        `json:"ipAddress"`
+	Location   *dto.Location `json:"location,omitempty"`
+	Current    bool          `json:"current"`
+	Revoked    bool          `json:"revoked"`
+	CreatedAt  string        `json:"createdAt"`
+	ExpiresAt  string        `json:"expiresAt"`
+	LastUsedAt string        `json:"lastUsedAt"`
 }
 
 func (h *handler) Handle(ctx echo.Context, in Request) error {
@@ -59,12 +64,23 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 	return ctx.JSON(http.StatusOK, lo.Map(sessions, func(session entity.Session, _ int) ResponseSession {
+		ua := api.ParseUserAgent(session.UserAgent)
+
+		var location *dto.Location
+		if session.Location != nil {
+			location = &dto.Location{Country: session.Location.Country, City: session.Location.City}
+		}
+
 		return ResponseSession{
 			ID:         session.ID.String(),
 			UserID:     session.UserID.String(),
 			UserAgent:  session.UserAgent,
 			Device:     lo.FromPtr(session.DeviceName),
+			Browser:    ua.Browser,
+			OS:         ua.OS,
+			DeviceType: ua.DeviceType,
 			IPAddress:  session.IPAddress,
+			Location:   location,
 			Current:    session.ID == claims.SessionID,
 			Revoked:    session.Revoked,
 			CreatedAt:  session.CreatedAt.Format("2006-01-02T15:04:05Z"),
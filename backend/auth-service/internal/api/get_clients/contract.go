@@ -0,0 +1,11 @@
+package get_clients
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+)
+
+type ClientService interface {
+	ListClients(ctx context.Context) ([]entity.ServiceClient, error)
+}
@@ -0,0 +1,40 @@
+package get_clients
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s ClientService
+}
+
+func New(s ClientService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct{}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	clients, err := h.s.ListClients(ctx.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, lo.Map(clients, func(c entity.ServiceClient, _ int) dto.ServiceClient {
+		return dto.ServiceClient{
+			ClientID:   c.ClientID,
+			Name:       c.Name,
+			Scopes:     c.Scopes,
+			IsActive:   c.IsActive,
+			CreatedAt:  c.CreatedAt,
+			LastUsedAt: c.LastUsedAt,
+		}
+	}))
+}
@@ -0,0 +1,12 @@
+package get_group_members
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type GroupService interface {
+	ListMembers(ctx context.Context, groupID uuid.UUID) ([]entity.User, error)
+}
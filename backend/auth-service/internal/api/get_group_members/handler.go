@@ -0,0 +1,44 @@
+package get_group_members
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	group_service "github.com/4udiwe/coworking/auth-service/internal/service/group"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s GroupService
+}
+
+func New(s GroupService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.GroupByIDRequest
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	members, err := h.s.ListMembers(ctx.Request().Context(), in.GroupID)
+	if err != nil {
+		if errors.Is(err, group_service.ErrGroupNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, lo.Map(members, func(u entity.User, _ int) dto.InternalUser {
+		return dto.InternalUser{
+			ID:        u.ID,
+			FirstName: u.FirstName,
+			LastName:  u.LastName,
+			Email:     u.Email,
+			IsActive:  u.IsActive,
+		}
+	}))
+}
@@ -0,0 +1,11 @@
+package get_groups
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+)
+
+type GroupService interface {
+	ListGroups(ctx context.Context) ([]entity.Group, error)
+}
@@ -0,0 +1,40 @@
+package get_groups
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s GroupService
+}
+
+func New(s GroupService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct{}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	groups, err := h.s.ListGroups(ctx.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, lo.Map(groups, func(g entity.Group, _ int) dto.Group {
+		return dto.Group{
+			ID:          g.ID,
+			Name:        g.Name,
+			Description: g.Description,
+			MemberCount: g.MemberCount,
+			CreatedAt:   g.CreatedAt,
+			UpdatedAt:   g.UpdatedAt,
+		}
+	}))
+}
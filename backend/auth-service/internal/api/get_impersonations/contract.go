@@ -0,0 +1,12 @@
+package get_impersonations
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type ImpersonationService interface {
+	List(ctx context.Context, actorID *uuid.UUID, userID *uuid.UUID) ([]entity.Impersonation, error)
+}
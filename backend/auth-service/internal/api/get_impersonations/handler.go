@@ -0,0 +1,52 @@
+package get_impersonations
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s ImpersonationService
+}
+
+func New(s ImpersonationService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct {
+	ActorID *uuid.UUID `query:"actorId"`
+	UserID  *uuid.UUID `query:"userId"`
+}
+
+type Response struct {
+	Impersonations []dto.Impersonation `json:"impersonations"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	imps, err := h.s.List(ctx.Request().Context(), in.ActorID, in.UserID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, Response{
+		Impersonations: lo.Map(imps, func(i entity.Impersonation, _ int) dto.Impersonation {
+			return dto.Impersonation{
+				ID:         i.ID,
+				ActorID:    i.ActorID,
+				UserID:     i.UserID,
+				Reason:     i.Reason,
+				AllowWrite: i.AllowWrite,
+				ExpiresAt:  i.ExpiresAt,
+				CreatedAt:  i.CreatedAt,
+				EndedAt:    i.EndedAt,
+			}
+		}),
+	})
+}
@@ -0,0 +1,11 @@
+package get_internal_user_ids
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type UserService interface {
+	ListActiveUserIDs(ctx context.Context, role string, after uuid.UUID, limit int) ([]uuid.UUID, error)
+}
@@ -0,0 +1,41 @@
+package get_internal_user_ids
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s UserService
+}
+
+func New(s UserService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+// Request — GET /internal/users/ids?role=<code>&after=<uuid>&limit=<n>
+// Следующая страница запрашивается с after = nextCursor.
+type Request struct {
+	Role  string    `query:"role"`
+	After uuid.UUID `query:"after"`
+	Limit int       `query:"limit" validate:"required,min=1,max=1000"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	ids, err := h.s.ListActiveUserIDs(ctx.Request().Context(), in.Role, in.After, in.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	response := dto.InternalUserIDsResponse{IDs: ids}
+	if len(ids) == in.Limit {
+		response.NextCursor = &ids[len(ids)-1]
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
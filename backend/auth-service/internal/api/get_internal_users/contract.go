@@ -0,0 +1,12 @@
+package get_internal_users
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type UserService interface {
+	GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]entity.User, error)
+}
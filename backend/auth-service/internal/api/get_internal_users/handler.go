@@ -0,0 +1,47 @@
+package get_internal_users
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s UserService
+}
+
+func New(s UserService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+// Request — GET /internal/users?ids=<uuid>&ids=<uuid>
+type Request struct {
+	IDs []string `query:"ids" validate:"required,min=1,max=100,dive,uuid"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	ids := lo.Map(in.IDs, func(id string, _ int) uuid.UUID {
+		return uuid.MustParse(id)
+	})
+
+	users, err := h.s.GetUsersByIDs(ctx.Request().Context(), lo.Uniq(ids))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, lo.Map(users, func(u entity.User, _ int) dto.InternalUser {
+		return dto.InternalUser{
+			ID:        u.ID,
+			FirstName: u.FirstName,
+			LastName:  u.LastName,
+			Email:     u.Email,
+			IsActive:  u.IsActive,
+		}
+	}))
+}
@@ -5,10 +5,12 @@ import (
 	"net/http"
 
 	api "github.com/4udiwe/coworking/auth-service/internal/api"
-	"github.com/4udiwe/coworking/auth-service/internal/api/middleware"
 	"github.com/4udiwe/coworking/auth-service/internal/entity"
 	auth_service "github.com/4udiwe/coworking/auth-service/internal/service/auth"
 	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/lo"
 )
@@ -32,6 +34,10 @@ type ResponseUser struct {
 	CreatedAt string         `json:"createdAt"`
 	UpdatedAt string         `json:"updatedAt"`
 	Roles     []ResponseRole `json:"roles"`
+	// Эффективные права, в том же формате, что и в access token
+	Permissions []string `json:"permissions"`
+	// Группы пользователя, как в claim groups
+	Groups []uuid.UUID `json:"groups"`
 }
 
 type ResponseRole struct {
@@ -75,5 +81,9 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 				Name:     role.Name,
 			}
 		}),
+		Permissions: lo.Map(user.Permissions, func(p entity.Permission, _ int) string {
+			return jwt_validator.FormatPermission(p.Code, p.CoworkingID)
+		}),
+		Groups: lo.Ternary(user.GroupIDs != nil, user.GroupIDs, []uuid.UUID{}),
 	})
 }
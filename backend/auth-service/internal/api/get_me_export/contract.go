@@ -0,0 +1,12 @@
+package get_me_export
+
+import (
+	"context"
+
+	account_service "github.com/4udiwe/coworking/auth-service/internal/service/account"
+	"github.com/google/uuid"
+)
+
+type AccountService interface {
+	ExportData(ctx context.Context, userID uuid.UUID) (account_service.Export, error)
+}
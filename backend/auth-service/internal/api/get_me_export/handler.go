@@ -0,0 +1,127 @@
+package get_me_export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	api "github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	account_service "github.com/4udiwe/coworking/auth-service/internal/service/account"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s AccountService
+}
+
+func New(accountService AccountService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: accountService})
+}
+
+type Request struct{}
+
+type Response struct {
+	ExportedAt     time.Time           `json:"exportedAt"`
+	Profile        dto.User            `json:"profile"`
+	Sessions       []Session           `json:"sessions"`
+	SSOIdentities  []Identity          `json:"ssoIdentities"`
+	PersonalTokens []dto.PersonalToken `json:"personalTokens"`
+	// Данные остальных сервисов (booking, notification, analytics) как есть
+	Services    map[string]json.RawMessage `json:"services"`
+	Unavailable []string                   `json:"unavailable"`
+}
+
+type Session struct {
+	ID         uuid.UUID `json:"id"`
+	UserAgent  string    `json:"userAgent"`
+	Device     string    `json:"device,omitempty"`
+	IPAddress  string    `json:"ipAddress"`
+	Revoked    bool      `json:"revoked"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+}
+
+type Identity struct {
+	Issuer      string    `json:"issuer"`
+	Email       *string   `json:"email,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	LastLoginAt time.Time `json:"lastLoginAt"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	export, err := h.s.ExportData(ctx.Request().Context(), claims.UserID)
+	if err != nil {
+		if errors.Is(err, account_service.ErrUserNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	user := export.User
+	ctx.Response().Header().Set(
+		echo.HeaderContentDisposition,
+		fmt.Sprintf(`attachment; filename="coworking-export-%s.json"`, user.ID),
+	)
+
+	return ctx.JSON(http.StatusOK, Response{
+		ExportedAt: time.Now(),
+		Profile: dto.User{
+			ID:        user.ID,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			Email:     user.Email,
+			IsActive:  user.IsActive,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+			Roles: lo.Map(user.Roles, func(r entity.Role, _ int) dto.Role {
+				return dto.Role{ID: r.ID, RoleCode: string(r.Code), Name: r.Name}
+			}),
+		},
+		Sessions: lo.Map(export.Sessions, func(s entity.Session, _ int) Session {
+			return Session{
+				ID:         s.ID,
+				UserAgent:  s.UserAgent,
+				Device:     lo.FromPtr(s.DeviceName),
+				IPAddress:  s.IPAddress,
+				Revoked:    s.Revoked,
+				CreatedAt:  s.CreatedAt,
+				LastUsedAt: s.LastUsedAt,
+			}
+		}),
+		SSOIdentities: lo.Map(export.Identities, func(i entity.UserIdentity, _ int) Identity {
+			return Identity{
+				Issuer:      i.Issuer,
+				Email:       i.Email,
+				CreatedAt:   i.CreatedAt,
+				LastLoginAt: i.LastLoginAt,
+			}
+		}),
+		PersonalTokens: lo.Map(export.PersonalTokens, func(t entity.PersonalToken, _ int) dto.PersonalToken {
+			return dto.PersonalToken{
+				ID:          t.ID,
+				Name:        t.Name,
+				Prefix:      t.Prefix,
+				Permissions: t.Permissions,
+				ExpiresAt:   t.ExpiresAt,
+				CreatedAt:   t.CreatedAt,
+				LastUsedAt:  t.LastUsedAt,
+				RevokedAt:   t.RevokedAt,
+			}
+		}),
+		Services:    export.Services,
+		Unavailable: export.Unavailable,
+	})
+}
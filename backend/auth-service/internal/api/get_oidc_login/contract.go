@@ -0,0 +1,11 @@
+package get_oidc_login
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type SSOService interface {
+	StartLogin(ctx context.Context, linkUserID *uuid.UUID) (string, string, error)
+}
@@ -0,0 +1,32 @@
+package get_oidc_login
+
+import (
+	"net/http"
+
+	api "github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s SSOService
+}
+
+func New(ssoService SSOService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: ssoService})
+}
+
+type Request struct{}
+
+type Response struct {
+	AuthorizationURL string `json:"authorizationUrl"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	authURL, browserKey, err := h.s.StartLogin(ctx.Request().Context(), nil)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	api.SetOIDCBrowserCookie(ctx, browserKey)
+	return ctx.JSON(http.StatusOK, Response{AuthorizationURL: authURL})
+}
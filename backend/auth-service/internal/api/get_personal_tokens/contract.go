@@ -0,0 +1,12 @@
+package get_personal_tokens
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type PersonalTokenService interface {
+	ListTokens(ctx context.Context, userID uuid.UUID) ([]entity.PersonalToken, error)
+}
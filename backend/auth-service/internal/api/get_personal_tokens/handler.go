@@ -0,0 +1,48 @@
+package get_personal_tokens
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s PersonalTokenService
+}
+
+func New(s PersonalTokenService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct{}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	tokens, err := h.s.ListTokens(ctx.Request().Context(), claims.UserID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, lo.Map(tokens, func(t entity.PersonalToken, _ int) dto.PersonalToken {
+		return dto.PersonalToken{
+			ID:          t.ID,
+			Name:        t.Name,
+			Prefix:      t.Prefix,
+			Permissions: t.Permissions,
+			ExpiresAt:   t.ExpiresAt,
+			CreatedAt:   t.CreatedAt,
+			LastUsedAt:  t.LastUsedAt,
+			RevokedAt:   t.RevokedAt,
+		}
+	}))
+}
@@ -0,0 +1,11 @@
+package get_roles
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+)
+
+type UserService interface {
+	ListRoles(ctx context.Context) ([]entity.Role, error)
+}
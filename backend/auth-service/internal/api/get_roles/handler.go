@@ -0,0 +1,38 @@
+package get_roles
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s UserService
+}
+
+func New(s UserService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct{}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	roles, err := h.s.ListRoles(ctx.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, lo.Map(roles, func(r entity.Role, _ int) dto.RoleWithPermissions {
+		return dto.RoleWithPermissions{
+			ID:          r.ID,
+			RoleCode:    string(r.Code),
+			Name:        r.Name,
+			Permissions: r.Permissions,
+		}
+	}))
+}
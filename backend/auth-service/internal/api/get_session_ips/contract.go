@@ -0,0 +1,12 @@
+package get_session_ips
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type AuthService interface {
+	GetSessionIPs(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) ([]entity.SessionIP, error)
+}
@@ -0,0 +1,57 @@
+package get_session_ips
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	auth_service "github.com/4udiwe/coworking/auth-service/internal/service/auth"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s AuthService
+}
+
+func New(s AuthService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct {
+	SessionID uuid.UUID `param:"sessionId" validate:"required"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	ips, err := h.s.GetSessionIPs(ctx.Request().Context(), claims.UserID, in.SessionID)
+	if err != nil {
+		if errors.Is(err, auth_service.ErrSessionNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, lo.Map(ips, func(ip entity.SessionIP, _ int) dto.SessionIP {
+		var location *dto.Location
+		if ip.Location != nil {
+			location = &dto.Location{Country: ip.Location.Country, City: ip.Location.City}
+		}
+
+		return dto.SessionIP{
+			IPAddress:   ip.IPAddress,
+			Location:    location,
+			FirstSeenAt: ip.FirstSeenAt,
+			LastSeenAt:  ip.LastSeenAt,
+		}
+	}))
+}
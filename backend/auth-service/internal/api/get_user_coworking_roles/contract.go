@@ -0,0 +1,12 @@
+package get_user_coworking_roles
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type UserService interface {
+	GetCoworkingRoles(ctx context.Context, userID uuid.UUID) ([]entity.CoworkingRole, error)
+}
@@ -0,0 +1,44 @@
+package get_user_coworking_roles
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	user_service "github.com/4udiwe/coworking/auth-service/internal/service/user"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s UserService
+}
+
+func New(s UserService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.UserByIDRequest
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	roles, err := h.s.GetCoworkingRoles(ctx.Request().Context(), in.UserID)
+
+	if err != nil {
+		if errors.Is(err, user_service.ErrUserNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, lo.Map(roles, func(r entity.CoworkingRole, _ int) dto.CoworkingRole {
+		return dto.CoworkingRole{
+			RoleCode:    string(r.Role.Code),
+			Name:        r.Role.Name,
+			CoworkingID: r.CoworkingID,
+			CreatedAt:   r.CreatedAt,
+		}
+	}))
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// OIDCBrowserCookie связывает SSO-вход с браузером, который его начал.
+// Cookie отправляется только на /auth/oidc, недоступен из JS и не уходит
+// с кросс-сайтовыми POST-запросами.
+const (
+	OIDCBrowserCookie     = "oidc_browser_key"
+	oidcBrowserCookiePath = "/auth/oidc"
+)
+
+func SetOIDCBrowserCookie(c echo.Context, key string) {
+	c.SetCookie(&http.Cookie{
+		Name:     OIDCBrowserCookie,
+		Value:    key,
+		Path:     oidcBrowserCookiePath,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// PopOIDCBrowserCookie возвращает ключ браузера и удаляет cookie:
+// state одноразовый, ключ после callback больше не нужен.
+func PopOIDCBrowserCookie(c echo.Context) string {
+	cookie, err := c.Cookie(OIDCBrowserCookie)
+	if err != nil {
+		return ""
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     OIDCBrowserCookie,
+		Path:     oidcBrowserCookiePath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return cookie.Value
+}
@@ -14,3 +14,34 @@ func ExtractDeviceName(uaString string) string {
 
 	return fmt.Sprintf("%s %s (%s)", name, version, os)
 }
+
+// UserAgentInfo — разобранный User-Agent сессии для отображения в списке устройств.
+type UserAgentInfo struct {
+	Browser    string
+	OS         string
+	DeviceType string // desktop | mobile | bot
+}
+
+func ParseUserAgent(uaString string) UserAgentInfo {
+	ua := user_agent.New(uaString)
+
+	name, version := ua.Browser()
+	browser := name
+	if version != "" {
+		browser = name + " " + version
+	}
+
+	deviceType := "desktop"
+	switch {
+	case ua.Bot():
+		deviceType = "bot"
+	case ua.Mobile():
+		deviceType = "mobile"
+	}
+
+	return UserAgentInfo{
+		Browser:    browser,
+		OS:         ua.OS(),
+		DeviceType: deviceType,
+	}
+}
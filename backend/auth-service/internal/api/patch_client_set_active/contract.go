@@ -0,0 +1,7 @@
+package patch_client_set_active
+
+import "context"
+
+type ClientService interface {
+	SetClientActive(ctx context.Context, clientID string, active bool) error
+}
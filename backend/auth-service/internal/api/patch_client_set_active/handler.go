@@ -0,0 +1,34 @@
+package patch_client_set_active
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	client_service "github.com/4udiwe/coworking/auth-service/internal/service/client"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s ClientService
+}
+
+func New(s ClientService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct {
+	ClientID string `param:"clientId" validate:"required"`
+	Active   *bool  `json:"active" validate:"required"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	if err := h.s.SetClientActive(ctx.Request().Context(), in.ClientID, *in.Active); err != nil {
+		if errors.Is(err, client_service.ErrClientNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return ctx.NoContent(http.StatusOK)
+}
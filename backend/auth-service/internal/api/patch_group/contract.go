@@ -0,0 +1,11 @@
+package patch_group
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type GroupService interface {
+	UpdateGroup(ctx context.Context, groupID uuid.UUID, name, description string) error
+}
@@ -0,0 +1,12 @@
+package patch_me
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type AccountService interface {
+	UpdateProfile(ctx context.Context, userID uuid.UUID, firstName string, lastName string) (entity.User, error)
+}
@@ -0,0 +1,56 @@
+package patch_me
+
+import (
+	"errors"
+	"net/http"
+
+	api "github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	account_service "github.com/4udiwe/coworking/auth-service/internal/service/account"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s AccountService
+}
+
+func New(accountService AccountService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: accountService})
+}
+
+type Request struct {
+	FirstName string `json:"first_name" validate:"required,max=100"`
+	LastName  string `json:"last_name" validate:"required,max=100"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	user, err := h.s.UpdateProfile(ctx.Request().Context(), claims.UserID, in.FirstName, in.LastName)
+	if err != nil {
+		if errors.Is(err, account_service.ErrUserNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, dto.User{
+		ID:        user.ID,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		IsActive:  user.IsActive,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+		Roles: lo.Map(user.Roles, func(r entity.Role, _ int) dto.Role {
+			return dto.Role{ID: r.ID, RoleCode: string(r.Code), Name: r.Name}
+		}),
+	})
+}
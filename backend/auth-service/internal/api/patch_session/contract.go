@@ -0,0 +1,11 @@
+package patch_session
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type AuthService interface {
+	RenameSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID, deviceName string) error
+}
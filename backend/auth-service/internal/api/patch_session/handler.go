@@ -0,0 +1,46 @@
+package patch_session
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	auth_service "github.com/4udiwe/coworking/auth-service/internal/service/auth"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s AuthService
+}
+
+func New(s AuthService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct {
+	SessionID  uuid.UUID `param:"sessionId" validate:"required"`
+	DeviceName string    `json:"deviceName" validate:"required,max=100"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	err = h.s.RenameSession(ctx.Request().Context(), claims.UserID, in.SessionID, in.DeviceName)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth_service.ErrEmptyDeviceName):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, auth_service.ErrSessionNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,11 @@
+package post_client
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+)
+
+type ClientService interface {
+	CreateClient(ctx context.Context, clientID, name string, scopes []string) (entity.ServiceClient, string, error)
+}
@@ -0,0 +1,56 @@
+package post_client
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	client_service "github.com/4udiwe/coworking/auth-service/internal/service/client"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s ClientService
+}
+
+func New(s ClientService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct {
+	ClientID string   `json:"clientId" validate:"required,max=64"`
+	Name     string   `json:"name" validate:"required"`
+	Scopes   []string `json:"scopes" validate:"required,min=1"`
+}
+
+// Response содержит секрет — он возвращается только при создании.
+type Response struct {
+	dto.ServiceClient
+	ClientSecret string `json:"clientSecret"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	client, secret, err := h.s.CreateClient(ctx.Request().Context(), in.ClientID, in.Name, in.Scopes)
+	if err != nil {
+		if errors.Is(err, client_service.ErrClientAlreadyExists) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		if errors.Is(err, client_service.ErrEmptyScopes) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusCreated, Response{
+		ServiceClient: dto.ServiceClient{
+			ClientID:  client.ClientID,
+			Name:      client.Name,
+			Scopes:    client.Scopes,
+			IsActive:  client.IsActive,
+			CreatedAt: client.CreatedAt,
+		},
+		ClientSecret: secret,
+	})
+}
@@ -0,0 +1,7 @@
+package post_email_confirm
+
+import "context"
+
+type AccountService interface {
+	ConfirmEmailChange(ctx context.Context, token string) error
+}
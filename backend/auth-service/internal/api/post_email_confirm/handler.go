@@ -0,0 +1,40 @@
+package post_email_confirm
+
+import (
+	"errors"
+	"net/http"
+
+	api "github.com/4udiwe/coworking/auth-service/internal/api"
+	account_service "github.com/4udiwe/coworking/auth-service/internal/service/account"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s AccountService
+}
+
+func New(accountService AccountService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: accountService})
+}
+
+type Request struct {
+	Token string `json:"token" validate:"required"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	err := h.s.ConfirmEmailChange(ctx.Request().Context(), in.Token)
+	if err != nil {
+		switch {
+		case errors.Is(err, account_service.ErrInvalidEmailToken):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, account_service.ErrEmailTaken):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		case errors.Is(err, account_service.ErrUserNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
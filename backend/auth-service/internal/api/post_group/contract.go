@@ -0,0 +1,11 @@
+package post_group
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+)
+
+type GroupService interface {
+	CreateGroup(ctx context.Context, name, description string) (entity.Group, error)
+}
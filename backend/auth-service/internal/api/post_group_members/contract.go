@@ -0,0 +1,11 @@
+package post_group_members
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type GroupService interface {
+	AddMembers(ctx context.Context, groupID uuid.UUID, userIDs []uuid.UUID) error
+}
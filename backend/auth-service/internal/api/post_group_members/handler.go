@@ -0,0 +1,41 @@
+package post_group_members
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	group_service "github.com/4udiwe/coworking/auth-service/internal/service/group"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s GroupService
+}
+
+func New(s GroupService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct {
+	GroupID uuid.UUID   `param:"groupId" validate:"required"`
+	UserIDs []uuid.UUID `json:"userIds" validate:"required,min=1,max=1000"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	err := h.s.AddMembers(ctx.Request().Context(), in.GroupID, in.UserIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, group_service.ErrGroupNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, group_service.ErrUserNotFound),
+			errors.Is(err, group_service.ErrEmptyMembers):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
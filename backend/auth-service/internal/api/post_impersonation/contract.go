@@ -0,0 +1,19 @@
+package post_impersonation
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type ImpersonationService interface {
+	Start(
+		ctx context.Context,
+		actorID uuid.UUID,
+		userID uuid.UUID,
+		reason string,
+		allowWrite bool,
+	) (entity.Impersonation, *auth.ExchangedToken, error)
+}
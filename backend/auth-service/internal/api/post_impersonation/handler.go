@@ -0,0 +1,73 @@
+package post_impersonation
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	impersonation_service "github.com/4udiwe/coworking/auth-service/internal/service/impersonation"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s ImpersonationService
+}
+
+func New(s ImpersonationService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct {
+	UserID uuid.UUID `param:"userId" validate:"required"`
+	// Причина попадает в журнал выдач, например номер обращения
+	Reason string `json:"reason" validate:"required,min=3,max=500"`
+	// Разрешить изменяющие запросы (по умолчанию токен только для чтения)
+	AllowWrite bool `json:"allowWrite"`
+}
+
+// Response — access token пользователя, refresh token не выдаётся.
+type Response struct {
+	Impersonation dto.Impersonation `json:"impersonation"`
+	AccessToken   string            `json:"accessToken"`
+	ExpiresIn     int64             `json:"expiresIn"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	imp, token, err := h.s.Start(ctx.Request().Context(), claims.UserID, in.UserID, in.Reason, in.AllowWrite)
+	if err != nil {
+		if errors.Is(err, impersonation_service.ErrUserNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, impersonation_service.ErrSelfImpersonation) ||
+			errors.Is(err, impersonation_service.ErrUserInactive) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, impersonation_service.ErrPrivilegedUser) {
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusCreated, Response{
+		Impersonation: dto.Impersonation{
+			ID:         imp.ID,
+			ActorID:    imp.ActorID,
+			UserID:     imp.UserID,
+			Reason:     imp.Reason,
+			AllowWrite: imp.AllowWrite,
+			ExpiresAt:  imp.ExpiresAt,
+			CreatedAt:  imp.CreatedAt,
+		},
+		AccessToken: token.AccessToken,
+		ExpiresIn:   token.ExpiresIn,
+	})
+}
@@ -0,0 +1,7 @@
+package post_invite_accept
+
+import "context"
+
+type RosterService interface {
+	AcceptInvitation(ctx context.Context, token string, password string) error
+}
@@ -0,0 +1,39 @@
+package post_invite_accept
+
+import (
+	"errors"
+	"net/http"
+
+	api "github.com/4udiwe/coworking/auth-service/internal/api"
+	roster_service "github.com/4udiwe/coworking/auth-service/internal/service/roster"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s RosterService
+}
+
+func New(rosterService RosterService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: rosterService})
+}
+
+type Request struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8,max=64"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	err := h.s.AcceptInvitation(ctx.Request().Context(), in.Token, in.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, roster_service.ErrInvalidInvitation):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, roster_service.ErrUserInactive):
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
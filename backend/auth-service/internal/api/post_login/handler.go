@@ -42,6 +42,9 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 			errors.Is(err, auth_service.ErrUserNotFound) {
 			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
 		}
+		if errors.Is(err, auth_service.ErrSessionLimitReached) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
 		// Any other error is internal server error
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
@@ -0,0 +1,11 @@
+package post_me_email
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type AccountService interface {
+	RequestEmailChange(ctx context.Context, userID uuid.UUID, password string, newEmail string) error
+}
@@ -0,0 +1,51 @@
+package post_me_email
+
+import (
+	"errors"
+	"net/http"
+
+	api "github.com/4udiwe/coworking/auth-service/internal/api"
+	account_service "github.com/4udiwe/coworking/auth-service/internal/service/account"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s AccountService
+}
+
+func New(accountService AccountService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: accountService})
+}
+
+type Request struct {
+	Email string `json:"email" validate:"required,email"`
+	// Не нужен для аккаунтов без пароля (SSO)
+	Password string `json:"password"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	err = h.s.RequestEmailChange(ctx.Request().Context(), claims.UserID, in.Password, in.Email)
+	if err != nil {
+		switch {
+		case errors.Is(err, account_service.ErrInvalidPassword):
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		case errors.Is(err, account_service.ErrSameEmail):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, account_service.ErrEmailTaken):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		case errors.Is(err, account_service.ErrUserNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Email изменится после перехода по ссылке из письма
+	return ctx.NoContent(http.StatusAccepted)
+}
@@ -0,0 +1,17 @@
+package post_me_password
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type AccountService interface {
+	ChangePassword(
+		ctx context.Context,
+		userID uuid.UUID,
+		currentSessionID uuid.UUID,
+		currentPassword string,
+		newPassword string,
+	) error
+}
@@ -0,0 +1,53 @@
+package post_me_password
+
+import (
+	"errors"
+	"net/http"
+
+	api "github.com/4udiwe/coworking/auth-service/internal/api"
+	account_service "github.com/4udiwe/coworking/auth-service/internal/service/account"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s AccountService
+}
+
+func New(accountService AccountService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: accountService})
+}
+
+type Request struct {
+	CurrentPassword string `json:"currentPassword" validate:"required"`
+	NewPassword     string `json:"newPassword" validate:"required,min=8,max=64,nefield=CurrentPassword"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	err = h.s.ChangePassword(
+		ctx.Request().Context(),
+		claims.UserID,
+		claims.SessionID,
+		in.CurrentPassword,
+		in.NewPassword,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, account_service.ErrInvalidPassword):
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		case errors.Is(err, account_service.ErrPasswordNotSet):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		case errors.Is(err, account_service.ErrUserNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,21 @@
+package post_oidc_callback
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/google/uuid"
+)
+
+type SSOService interface {
+	Callback(
+		ctx context.Context,
+		code string,
+		state string,
+		browserKey string,
+		callerID *uuid.UUID,
+		userAgent string,
+		deviceInfo string,
+		ip string,
+	) (*auth.Tokens, error)
+}
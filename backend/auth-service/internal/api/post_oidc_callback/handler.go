@@ -0,0 +1,72 @@
+package post_oidc_callback
+
+import (
+	"errors"
+	"net/http"
+
+	api "github.com/4udiwe/coworking/auth-service/internal/api"
+	sso_service "github.com/4udiwe/coworking/auth-service/internal/service/sso"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s SSOService
+}
+
+func New(ssoService SSOService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: ssoService})
+}
+
+type Request struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+}
+
+// Handle завершает SSO-вход. Запрос должен прийти из того же браузера
+// (cookie из /auth/oidc/login или /users/me/oidc/link), а для привязки —
+// ещё и с access token пользователя, который её начал.
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	userAgent := ctx.Request().UserAgent()
+	ip := ctx.RealIP()
+
+	deviceName := api.ExtractDeviceName(userAgent)
+	browserKey := api.PopOIDCBrowserCookie(ctx)
+
+	// Персональный токен и выдача поддержки не могут завершить привязку
+	var callerID *uuid.UUID
+	if claims, err := middleware.GetUserFromContext(ctx); err == nil &&
+		!claims.IsPersonalToken() && !claims.IsImpersonated() {
+		callerID = &claims.UserID
+	}
+
+	tokens, err := h.s.Callback(ctx.Request().Context(), in.Code, in.State, browserKey, callerID, userAgent, deviceName, ip)
+
+	if err != nil {
+		// Validation errors
+		if errors.Is(err, sso_service.ErrInvalidState) ||
+			errors.Is(err, sso_service.ErrEmailRequired) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		// Authentication errors
+		if errors.Is(err, sso_service.ErrProviderRejected) ||
+			errors.Is(err, sso_service.ErrUserNotFound) {
+			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		}
+		if errors.Is(err, sso_service.ErrUserInactive) ||
+			errors.Is(err, sso_service.ErrLinkUserMismatch) {
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		}
+		// Account conflicts
+		if errors.Is(err, sso_service.ErrAccountExists) ||
+			errors.Is(err, sso_service.ErrIdentityLinkedToOther) ||
+			errors.Is(err, sso_service.ErrSessionLimitReached) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		// Any other error is internal server error
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return ctx.JSON(http.StatusOK, tokens)
+}
@@ -0,0 +1,41 @@
+package post_oidc_link
+
+import (
+	"net/http"
+
+	api "github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s SSOService
+}
+
+func New(ssoService SSOService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: ssoService})
+}
+
+type Request struct{}
+
+type Response struct {
+	AuthorizationURL string `json:"authorizationUrl"`
+}
+
+// Handle начинает SSO-вход, по завершении которого внешний аккаунт
+// привязывается к текущему пользователю. Callback для привязки
+// вызывается с access token этого же пользователя.
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	authURL, browserKey, err := h.s.StartLogin(ctx.Request().Context(), &claims.UserID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	api.SetOIDCBrowserCookie(ctx, browserKey)
+	return ctx.JSON(http.StatusOK, Response{AuthorizationURL: authURL})
+}
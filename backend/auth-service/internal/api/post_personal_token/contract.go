@@ -0,0 +1,19 @@
+package post_personal_token
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type PersonalTokenService interface {
+	CreateToken(
+		ctx context.Context,
+		userID uuid.UUID,
+		name string,
+		permissions []string,
+		ttl time.Duration,
+	) (entity.PersonalToken, string, error)
+}
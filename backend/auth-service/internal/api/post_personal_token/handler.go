@@ -0,0 +1,74 @@
+package post_personal_token
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	pat_service "github.com/4udiwe/coworking/auth-service/internal/service/pat"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s PersonalTokenService
+}
+
+func New(s PersonalTokenService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct {
+	Name          string   `json:"name" validate:"required,max=100"`
+	Permissions   []string `json:"permissions" validate:"required,min=1"`
+	ExpiresInDays int      `json:"expiresInDays" validate:"required,min=1"`
+}
+
+// Response содержит значение токена — оно возвращается только при создании.
+type Response struct {
+	dto.PersonalToken
+	Token string `json:"token"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	token, raw, err := h.s.CreateToken(
+		ctx.Request().Context(),
+		claims.UserID,
+		in.Name,
+		in.Permissions,
+		time.Duration(in.ExpiresInDays)*24*time.Hour,
+	)
+	if err != nil {
+		if errors.Is(err, pat_service.ErrInvalidExpiry) ||
+			errors.Is(err, pat_service.ErrEmptyPermissions) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, pat_service.ErrPermissionNotGranted) {
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		}
+		if errors.Is(err, pat_service.ErrUserNotFound) {
+			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusCreated, Response{
+		PersonalToken: dto.PersonalToken{
+			ID:          token.ID,
+			Name:        token.Name,
+			Prefix:      token.Prefix,
+			Permissions: token.Permissions,
+			ExpiresAt:   token.ExpiresAt,
+			CreatedAt:   token.CreatedAt,
+		},
+		Token: raw,
+	})
+}
@@ -0,0 +1,11 @@
+package post_revoke_other_sessions
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type AuthService interface {
+	RevokeOtherSessions(ctx context.Context, userID uuid.UUID, currentSessionID uuid.UUID) (int, error)
+}
@@ -0,0 +1,39 @@
+package post_revoke_other_sessions
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s AuthService
+}
+
+func New(s AuthService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct{}
+
+type Response struct {
+	Revoked int `json:"revoked"`
+}
+
+// Handle отзывает все сессии пользователя, кроме той, которой выдан текущий access token.
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	revoked, err := h.s.RevokeOtherSessions(ctx.Request().Context(), claims.UserID, claims.SessionID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, Response{Revoked: revoked})
+}
@@ -0,0 +1,15 @@
+package post_token
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+)
+
+type ClientService interface {
+	IssueToken(ctx context.Context, clientID, clientSecret, scope string) (*auth.ServiceToken, error)
+}
+
+type PersonalTokenService interface {
+	Exchange(ctx context.Context, raw string) (*auth.ExchangedToken, error)
+}
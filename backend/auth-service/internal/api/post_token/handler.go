@@ -0,0 +1,104 @@
+package post_token
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	client_service "github.com/4udiwe/coworking/auth-service/internal/service/client"
+	pat_service "github.com/4udiwe/coworking/auth-service/internal/service/pat"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	grantClientCredentials = "client_credentials"
+	grantTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+	// subject_token_type персонального токена
+	tokenTypePersonalToken = "urn:coworking:params:oauth:token-type:personal_access_token"
+)
+
+type handler struct {
+	clients   ClientService
+	personals PersonalTokenService
+}
+
+func New(clients ClientService, personals PersonalTokenService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{clients: clients, personals: personals})
+}
+
+/*
+Request — token endpoint, application/x-www-form-urlencoded.
+
+  - client_credentials (RFC 6749, 4.4): учётные данные клиента
+    в HTTP Basic или в теле запроса;
+  - token-exchange (RFC 8693): обмен персонального токена на access token,
+    используется gateway.
+*/
+type Request struct {
+	GrantType    string `form:"grant_type" validate:"required"`
+	Scope        string `form:"scope"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+
+	SubjectToken     string `form:"subject_token"`
+	SubjectTokenType string `form:"subject_token_type"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	switch in.GrantType {
+	case grantClientCredentials:
+		return h.clientCredentials(ctx, in)
+	case grantTokenExchange:
+		return h.tokenExchange(ctx, in)
+	default:
+		return oauthError(ctx, http.StatusBadRequest, "unsupported_grant_type")
+	}
+}
+
+func (h *handler) tokenExchange(ctx echo.Context, in Request) error {
+	if in.SubjectToken == "" || in.SubjectTokenType != tokenTypePersonalToken {
+		return oauthError(ctx, http.StatusBadRequest, "invalid_request")
+	}
+
+	token, err := h.personals.Exchange(ctx.Request().Context(), in.SubjectToken)
+	if err != nil {
+		if errors.Is(err, pat_service.ErrInvalidToken) {
+			return oauthError(ctx, http.StatusBadRequest, "invalid_grant")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, token)
+}
+
+func (h *handler) clientCredentials(ctx echo.Context, in Request) error {
+
+	clientID, clientSecret, ok := ctx.Request().BasicAuth()
+	if !ok {
+		clientID, clientSecret = in.ClientID, in.ClientSecret
+	}
+	if clientID == "" || clientSecret == "" {
+		return oauthError(ctx, http.StatusUnauthorized, "invalid_client")
+	}
+
+	token, err := h.clients.IssueToken(ctx.Request().Context(), clientID, clientSecret, in.Scope)
+	if err != nil {
+		if errors.Is(err, client_service.ErrInvalidClient) {
+			return oauthError(ctx, http.StatusUnauthorized, "invalid_client")
+		}
+		if errors.Is(err, client_service.ErrInvalidScope) {
+			return oauthError(ctx, http.StatusBadRequest, "invalid_scope")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, token)
+}
+
+func oauthError(ctx echo.Context, status int, code string) error {
+	return ctx.JSON(status, map[string]string{"error": code})
+}
@@ -0,0 +1,38 @@
+package post_user_coworking_role
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/dto"
+	user_service "github.com/4udiwe/coworking/auth-service/internal/service/user"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s UserService
+}
+
+func New(s UserService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.CoworkingRoleRequest
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	err := h.s.GrantCoworkingRole(ctx.Request().Context(), in.UserID, in.RoleCode, in.CoworkingID)
+
+	if err != nil {
+		if errors.Is(err, user_service.ErrUserNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, user_service.ErrRoleNotFound) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.NoContent(http.StatusCreated)
+}
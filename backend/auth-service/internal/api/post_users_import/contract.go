@@ -0,0 +1,12 @@
+package post_users_import
+
+import (
+	"context"
+
+	roster_service "github.com/4udiwe/coworking/auth-service/internal/service/roster"
+	"github.com/google/uuid"
+)
+
+type RosterService interface {
+	Import(ctx context.Context, actorID uuid.UUID, rows []roster_service.Row, opts roster_service.Options) (roster_service.Report, error)
+}
@@ -0,0 +1,111 @@
+package post_users_import
+
+import (
+	"errors"
+	"net/http"
+
+	api "github.com/4udiwe/coworking/auth-service/internal/api"
+	roster_service "github.com/4udiwe/coworking/auth-service/internal/service/roster"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s RosterService
+}
+
+func New(rosterService RosterService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: rosterService})
+}
+
+// Request — multipart/form-data, файл списка передаётся в поле "file".
+type Request struct {
+	DryRun            bool `form:"dryRun"`
+	SendInvites       bool `form:"sendInvites"`
+	DeactivateMissing bool `form:"deactivateMissing"`
+}
+
+type Response struct {
+	DryRun      bool  `json:"dryRun"`
+	Created     int   `json:"created"`
+	Updated     int   `json:"updated"`
+	Unchanged   int   `json:"unchanged"`
+	Deactivated int   `json:"deactivated"`
+	Failed      int   `json:"failed"`
+	Invited     int   `json:"invited"`
+	Rows        []Row `json:"rows"`
+}
+
+type Row struct {
+	// Отсутствует у деактивированных пользователей, которых нет в списке
+	Line    int        `json:"line,omitempty"`
+	Email   string     `json:"email"`
+	UserID  *uuid.UUID `json:"userId,omitempty"`
+	Action  string     `json:"action"`
+	Invited bool       `json:"invited"`
+	Error   string     `json:"error,omitempty"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "file is required")
+	}
+	src, err := file.Open()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	defer src.Close()
+
+	rows, err := parseRoster(file.Filename, src)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	report, err := h.s.Import(ctx.Request().Context(), claims.UserID, rows, roster_service.Options{
+		DryRun:            in.DryRun,
+		SendInvites:       in.SendInvites,
+		DeactivateMissing: in.DeactivateMissing,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, roster_service.ErrEmptyRoster),
+			errors.Is(err, roster_service.ErrTooManyRows):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, Response{
+		DryRun:      report.DryRun,
+		Created:     report.Count(roster_service.ActionCreated),
+		Updated:     report.Count(roster_service.ActionUpdated),
+		Unchanged:   report.Count(roster_service.ActionUnchanged),
+		Deactivated: report.Count(roster_service.ActionDeactivated),
+		Failed:      report.Count(roster_service.ActionFailed),
+		Invited:     report.Invited(),
+		Rows: lo.Map(report.Rows, func(r roster_service.RowResult, _ int) Row {
+			row := Row{
+				Line:    r.Line,
+				Email:   r.Email,
+				Action:  string(r.Action),
+				Invited: r.Invited,
+			}
+			if r.UserID != uuid.Nil {
+				row.UserID = &r.UserID
+			}
+			if r.Err != nil {
+				row.Error = r.Err.Error()
+			}
+			return row
+		}),
+	})
+}
@@ -0,0 +1,110 @@
+package post_users_import
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	roster_service "github.com/4udiwe/coworking/auth-service/internal/service/roster"
+	"github.com/samber/lo"
+)
+
+var errUnsupportedFormat = errors.New("unsupported file format, expected .csv or .json")
+
+// Колонки CSV. Роли перечисляются через ";", пустая ячейка — роль по умолчанию.
+const (
+	colEmail     = "email"
+	colFirstName = "first_name"
+	colLastName  = "last_name"
+	colRoles     = "roles"
+)
+
+type jsonRow struct {
+	Email     string   `json:"email"`
+	FirstName string   `json:"firstName"`
+	LastName  string   `json:"lastName"`
+	Roles     []string `json:"roles"`
+}
+
+func parseRoster(filename string, r io.Reader) ([]roster_service.Row, error) {
+	switch {
+	case strings.HasSuffix(strings.ToLower(filename), ".csv"):
+		return parseCSV(r)
+	case strings.HasSuffix(strings.ToLower(filename), ".json"):
+		return parseJSON(r)
+	}
+	return nil, errUnsupportedFormat
+}
+
+func parseCSV(r io.Reader) ([]roster_service.Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Excel сохраняет UTF-8 с BOM
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{colEmail, colFirstName, colLastName} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing column %q", required)
+		}
+	}
+
+	cell := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	var rows []roster_service.Row
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, roster_service.Row{
+			Line:      line,
+			Email:     cell(record, colEmail),
+			FirstName: cell(record, colFirstName),
+			LastName:  cell(record, colLastName),
+			Roles:     strings.Split(cell(record, colRoles), ";"),
+		})
+	}
+
+	return rows, nil
+}
+
+func parseJSON(r io.Reader) ([]roster_service.Row, error) {
+	var in []jsonRow
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+
+	return lo.Map(in, func(row jsonRow, i int) roster_service.Row {
+		return roster_service.Row{
+			Line:      i + 1,
+			Email:     row.Email,
+			FirstName: row.FirstName,
+			LastName:  row.LastName,
+			Roles:     row.Roles,
+		}
+	}), nil
+}
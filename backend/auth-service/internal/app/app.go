@@ -8,18 +8,36 @@ import (
 	"github.com/4udiwe/avito-pvz/pkg/httpserver"
 	"github.com/4udiwe/avito-pvz/pkg/postgres"
 	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
+	"github.com/4udiwe/big-bob-pizza/order-service/pkg/outbox"
 	"github.com/4udiwe/coworking/auth-service/config"
 	"github.com/4udiwe/coworking/auth-service/internal/api"
-	"github.com/4udiwe/coworking/auth-service/internal/api/middleware"
 	"github.com/4udiwe/coworking/auth-service/internal/auth"
 	"github.com/4udiwe/coworking/auth-service/internal/consumer/cleanup"
 	"github.com/4udiwe/coworking/auth-service/internal/database"
+	"github.com/4udiwe/coworking/auth-service/internal/geoip"
 	"github.com/4udiwe/coworking/auth-service/internal/hasher"
+	"github.com/4udiwe/coworking/auth-service/internal/oidc"
 	auth_repository "github.com/4udiwe/coworking/auth-service/internal/repository/auth"
+	client_repository "github.com/4udiwe/coworking/auth-service/internal/repository/client"
+	email_change_repository "github.com/4udiwe/coworking/auth-service/internal/repository/email_change"
+	group_repository "github.com/4udiwe/coworking/auth-service/internal/repository/group"
+	identity_repository "github.com/4udiwe/coworking/auth-service/internal/repository/identity"
+	impersonation_repository "github.com/4udiwe/coworking/auth-service/internal/repository/impersonation"
+	invitation_repository "github.com/4udiwe/coworking/auth-service/internal/repository/invitation"
+	outbox_repository "github.com/4udiwe/coworking/auth-service/internal/repository/outbox"
+	pat_repository "github.com/4udiwe/coworking/auth-service/internal/repository/pat"
 	user_repository "github.com/4udiwe/coworking/auth-service/internal/repository/user"
+	account_service "github.com/4udiwe/coworking/auth-service/internal/service/account"
 	auth_service "github.com/4udiwe/coworking/auth-service/internal/service/auth"
+	client_service "github.com/4udiwe/coworking/auth-service/internal/service/client"
+	group_service "github.com/4udiwe/coworking/auth-service/internal/service/group"
+	impersonation_service "github.com/4udiwe/coworking/auth-service/internal/service/impersonation"
+	pat_service "github.com/4udiwe/coworking/auth-service/internal/service/pat"
+	roster_service "github.com/4udiwe/coworking/auth-service/internal/service/roster"
+	sso_service "github.com/4udiwe/coworking/auth-service/internal/service/sso"
 	user_service "github.com/4udiwe/coworking/auth-service/internal/service/user"
 	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/log"
 )
@@ -35,12 +53,30 @@ type App struct {
 	echoHandler *echo.Echo
 
 	// Repositories
-	authRepo *auth_repository.AuthRepository
-	userRepo *user_repository.UserRepository
+	authRepo     *auth_repository.AuthRepository
+	userRepo     *user_repository.UserRepository
+	outboxRepo   *outbox_repository.Repository
+	mailOutbox   *outbox_repository.Repository
+	identityRepo *identity_repository.IdentityRepository
+	clientRepo   *client_repository.ClientRepository
+
+	personalTokenRepo *pat_repository.PersonalTokenRepository
+	emailChangeRepo   *email_change_repository.EmailChangeRepository
+	invitationRepo    *invitation_repository.InvitationRepository
+	groupRepo         *group_repository.GroupRepository
+	impersonationRepo *impersonation_repository.ImpersonationRepository
 
 	// Services
 	authService *auth_service.Service
 	userService *user_service.Service
+	ssoService  *sso_service.Service
+
+	clientService        *client_service.Service
+	personalTokenService *pat_service.Service
+	accountService       *account_service.Service
+	rosterService        *roster_service.Service
+	groupService         *group_service.Service
+	impersonationService *impersonation_service.Service
 
 	// Handlers
 	postLoginHandler         api.Handler
@@ -48,27 +84,83 @@ type App struct {
 	postRefreshHandler       api.Handler
 	postRegisterHandler      api.Handler
 	postRevokeSessionHandler api.Handler
+	postOIDCCallbackHandler  api.Handler
+	postOIDCLinkHandler      api.Handler
 
 	getMeHandler             api.Handler
 	getAllSessionsHandler    api.Handler
 	getActiveSessionsHandler api.Handler
 	getUserByIdHandler       api.Handler
 	getUsersHandler          api.Handler
+	getOIDCLoginHandler      api.Handler
 
 	patchUserSetActiveHandler api.Handler
 
 	putUserRolesHanlder api.Handler
 
+	getRolesHandler                api.Handler
+	getUserCoworkingRolesHandler   api.Handler
+	postUserCoworkingRoleHandler   api.Handler
+	deleteUserCoworkingRoleHandler api.Handler
+
+	postTokenHandler            api.Handler
+	getClientsHandler           api.Handler
+	postClientHandler           api.Handler
+	patchClientSetActiveHandler api.Handler
+	getInternalUsersHandler     api.Handler
+	getInternalUserIDsHandler   api.Handler
+
+	getPersonalTokensHandler   api.Handler
+	postPersonalTokenHandler   api.Handler
+	deletePersonalTokenHandler api.Handler
+
+	patchMeHandler          api.Handler
+	postMePasswordHandler   api.Handler
+	postMeEmailHandler      api.Handler
+	postEmailConfirmHandler api.Handler
+	getMeExportHandler      api.Handler
+	deleteMeHandler         api.Handler
+
+	postUsersImportHandler  api.Handler
+	postInviteAcceptHandler api.Handler
+
+	getGroupsHandler         api.Handler
+	postGroupHandler         api.Handler
+	patchGroupHandler        api.Handler
+	deleteGroupHandler       api.Handler
+	getGroupMembersHandler   api.Handler
+	postGroupMembersHandler  api.Handler
+	deleteGroupMemberHandler api.Handler
+
+	postImpersonationHandler   api.Handler
+	getImpersonationsHandler   api.Handler
+	deleteImpersonationHandler api.Handler
+
+	patchSessionHandler            api.Handler
+	getSessionIPsHandler           api.Handler
+	postRevokeOtherSessionsHandler api.Handler
+
 	// Auth
 	auth         *auth.Auth
 	privateKey   *rsa.PrivateKey
 	jwtValidator *jwt_validator.Validator
+	denylist     *jwt_validator.Denylist
+
+	// OIDC
+	oidcProvider *oidc.Provider
+
+	// GeoIP
+	geoIP *geoip.Reader
 
 	// Hasher
 	hasher *hasher.BcryptHasher
 
 	// Middleware
 	authMW *middleware.AuthMiddleware
+
+	// Outbox
+	OutboxWorker     *outbox.Worker
+	MailOutboxWorker *outbox.Worker
 }
 
 func New(configPath string) *App {
@@ -102,6 +194,13 @@ func (app *App) Start() {
 		log.Errorf("app - Start - Migrations failed: %v", err)
 	}
 
+	// Service clients from config
+	for _, c := range app.cfg.Clients {
+		if err := app.ClientService().RegisterClient(context.Background(), c.ClientID, c.Name, c.Secret, c.Scopes); err != nil {
+			log.Errorf("app - Start - RegisterClient %s failed: %v", c.ClientID, err)
+		}
+	}
+
 	// App server
 	log.Info("Starting app server...")
 	httpServer := httpserver.New(app.EchoHandler(), httpserver.Port(app.cfg.HTTP.Port))
@@ -113,7 +212,15 @@ func (app *App) Start() {
 	sessionCleanupConsumer := cleanup.New(
 		app.AuthService(),
 		kafkaConsumer,
-		"auth.events",
+		app.cfg.Outbox.Topic,
+		app.cfg.Kafka.Consumer.GroupID,
+	)
+
+	// Token revocation listener: keeps local denylist in sync with auth.events
+	revocationListener := jwt_validator.NewRevocationListener(
+		app.Denylist(),
+		kafka.NewConsumer(app.cfg.Kafka.Brokers),
+		app.cfg.Outbox.Topic,
 		app.cfg.Kafka.Consumer.GroupID,
 	)
 
@@ -125,6 +232,35 @@ func (app *App) Start() {
 			log.Errorf("app - Start - SessionCleanupConsumer failed: %v", err)
 		}
 	}()
+	go func() {
+		if err := revocationListener.Run(consumerCtx); err != nil {
+			log.Errorf("app - Start - RevocationListener failed: %v", err)
+		}
+	}()
+
+	// Outbox publisher
+	app.OutboxWorker = outbox.NewWorker(
+		app.OutboxRepo(),
+		kafka.NewKafkaPublisher(app.cfg.Kafka.Brokers),
+		app.cfg.Outbox.Topic,
+		app.cfg.Outbox.BatchLimit,
+		app.cfg.Outbox.RequeBatchLimit,
+		app.cfg.Outbox.Interval,
+		app.cfg.Outbox.RequeInterval,
+	)
+
+	// Письма с одноразовыми ссылками (auth.mail)
+	app.MailOutboxWorker = outbox.NewWorker(
+		app.MailOutboxRepo(),
+		kafka.NewKafkaPublisher(app.cfg.Kafka.Brokers),
+		app.cfg.Outbox.MailTopic,
+		app.cfg.Outbox.BatchLimit,
+		app.cfg.Outbox.RequeBatchLimit,
+		app.cfg.Outbox.Interval,
+		app.cfg.Outbox.RequeInterval,
+	)
+	app.OutboxWorker.Run(consumerCtx)
+	app.MailOutboxWorker.Run(consumerCtx)
 
 	select {
 	case s := <-app.interrupt:
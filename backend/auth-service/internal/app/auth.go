@@ -1,9 +1,12 @@
 package app
 
 import (
+	"context"
 	"crypto/rsa"
 
 	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/4udiwe/coworking/auth-service/internal/geoip"
+	"github.com/4udiwe/coworking/auth-service/internal/oidc"
 	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
 	"github.com/sirupsen/logrus"
 )
@@ -29,6 +32,7 @@ func (app *App) Auth() *auth.Auth {
 		app.cfg.App.Name,
 		app.cfg.Auth.AccessTokenTTL,
 		app.cfg.Auth.RefreshTokenTTL,
+		app.cfg.Auth.ServiceTokenTTL,
 	)
 	return app.auth
 }
@@ -37,6 +41,49 @@ func (app *App) JwtValidator() *jwt_validator.Validator {
 	if app.jwtValidator != nil {
 		return app.jwtValidator
 	}
-	app.jwtValidator = jwt_validator.NewValidator(app.Auth().PublicKey)
+	app.jwtValidator = jwt_validator.NewValidator(
+		app.Auth().PublicKey,
+		jwt_validator.WithDenylist(app.Denylist()),
+	)
 	return app.jwtValidator
 }
+
+func (app *App) Denylist() *jwt_validator.Denylist {
+	if app.denylist != nil {
+		return app.denylist
+	}
+	app.denylist = jwt_validator.NewDenylist()
+	return app.denylist
+}
+
+func (app *App) OIDCProvider() *oidc.Provider {
+	if app.oidcProvider != nil {
+		return app.oidcProvider
+	}
+	provider, err := oidc.New(
+		context.Background(),
+		app.cfg.OIDC.IssuerURL,
+		app.cfg.OIDC.ClientID,
+		app.cfg.OIDC.ClientSecret,
+		app.cfg.OIDC.RedirectURL,
+		app.cfg.OIDC.Scopes,
+		app.cfg.OIDC.GroupsClaim,
+	)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to initialize OIDC provider")
+	}
+	app.oidcProvider = provider
+	return app.oidcProvider
+}
+
+func (app *App) GeoIP() *geoip.Reader {
+	if app.geoIP != nil {
+		return app.geoIP
+	}
+	reader, err := geoip.Open(app.cfg.GeoIP.DatabasePath, app.cfg.GeoIP.Locale)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to open GeoIP database")
+	}
+	app.geoIP = reader
+	return app.geoIP
+}
@@ -3,6 +3,14 @@ package app
 import (
 	"github.com/4udiwe/avito-pvz/pkg/postgres"
 	auth_repository "github.com/4udiwe/coworking/auth-service/internal/repository/auth"
+	client_repository "github.com/4udiwe/coworking/auth-service/internal/repository/client"
+	email_change_repository "github.com/4udiwe/coworking/auth-service/internal/repository/email_change"
+	group_repository "github.com/4udiwe/coworking/auth-service/internal/repository/group"
+	identity_repository "github.com/4udiwe/coworking/auth-service/internal/repository/identity"
+	impersonation_repository "github.com/4udiwe/coworking/auth-service/internal/repository/impersonation"
+	invitation_repository "github.com/4udiwe/coworking/auth-service/internal/repository/invitation"
+	outbox_repository "github.com/4udiwe/coworking/auth-service/internal/repository/outbox"
+	pat_repository "github.com/4udiwe/coworking/auth-service/internal/repository/pat"
 	user_repository "github.com/4udiwe/coworking/auth-service/internal/repository/user"
 )
 
@@ -25,3 +33,76 @@ func (app *App) UserRepo() *user_repository.UserRepository {
 	app.userRepo = user_repository.New(app.Postgres())
 	return app.userRepo
 }
+
+func (app *App) OutboxRepo() *outbox_repository.Repository {
+	if app.outboxRepo != nil {
+		return app.outboxRepo
+	}
+	app.outboxRepo = outbox_repository.New(app.Postgres())
+	return app.outboxRepo
+}
+
+// MailOutboxRepo — события outbox для auth.mail, их публикует отдельный воркер
+func (app *App) MailOutboxRepo() *outbox_repository.Repository {
+	if app.mailOutbox != nil {
+		return app.mailOutbox
+	}
+	app.mailOutbox = outbox_repository.NewForTopic(app.Postgres(), app.cfg.Outbox.MailTopic)
+	return app.mailOutbox
+}
+
+func (app *App) IdentityRepo() *identity_repository.IdentityRepository {
+	if app.identityRepo != nil {
+		return app.identityRepo
+	}
+	app.identityRepo = identity_repository.New(app.Postgres())
+	return app.identityRepo
+}
+
+func (app *App) ClientRepo() *client_repository.ClientRepository {
+	if app.clientRepo != nil {
+		return app.clientRepo
+	}
+	app.clientRepo = client_repository.New(app.Postgres())
+	return app.clientRepo
+}
+
+func (app *App) PersonalTokenRepo() *pat_repository.PersonalTokenRepository {
+	if app.personalTokenRepo != nil {
+		return app.personalTokenRepo
+	}
+	app.personalTokenRepo = pat_repository.New(app.Postgres())
+	return app.personalTokenRepo
+}
+
+func (app *App) EmailChangeRepo() *email_change_repository.EmailChangeRepository {
+	if app.emailChangeRepo != nil {
+		return app.emailChangeRepo
+	}
+	app.emailChangeRepo = email_change_repository.New(app.Postgres())
+	return app.emailChangeRepo
+}
+
+func (app *App) InvitationRepo() *invitation_repository.InvitationRepository {
+	if app.invitationRepo != nil {
+		return app.invitationRepo
+	}
+	app.invitationRepo = invitation_repository.New(app.Postgres())
+	return app.invitationRepo
+}
+
+func (app *App) GroupRepo() *group_repository.GroupRepository {
+	if app.groupRepo != nil {
+		return app.groupRepo
+	}
+	app.groupRepo = group_repository.New(app.Postgres())
+	return app.groupRepo
+}
+
+func (app *App) ImpersonationRepo() *impersonation_repository.ImpersonationRepository {
+	if app.impersonationRepo != nil {
+		return app.impersonationRepo
+	}
+	app.impersonationRepo = impersonation_repository.New(app.Postgres())
+	return app.impersonationRepo
+}
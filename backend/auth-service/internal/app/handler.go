@@ -2,17 +2,54 @@ package app
 
 import (
 	"github.com/4udiwe/coworking/auth-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/internal/api/delete_group"
+	"github.com/4udiwe/coworking/auth-service/internal/api/delete_group_member"
+	"github.com/4udiwe/coworking/auth-service/internal/api/delete_impersonation"
+	"github.com/4udiwe/coworking/auth-service/internal/api/delete_me"
+	"github.com/4udiwe/coworking/auth-service/internal/api/delete_personal_token"
+	"github.com/4udiwe/coworking/auth-service/internal/api/delete_user_coworking_role"
 	"github.com/4udiwe/coworking/auth-service/internal/api/get_active_sessions"
 	"github.com/4udiwe/coworking/auth-service/internal/api/get_all_sessions"
+	"github.com/4udiwe/coworking/auth-service/internal/api/get_clients"
+	"github.com/4udiwe/coworking/auth-service/internal/api/get_group_members"
+	"github.com/4udiwe/coworking/auth-service/internal/api/get_groups"
+	"github.com/4udiwe/coworking/auth-service/internal/api/get_impersonations"
+	"github.com/4udiwe/coworking/auth-service/internal/api/get_internal_user_ids"
+	"github.com/4udiwe/coworking/auth-service/internal/api/get_internal_users"
 	"github.com/4udiwe/coworking/auth-service/internal/api/get_me"
+	"github.com/4udiwe/coworking/auth-service/internal/api/get_me_export"
+	"github.com/4udiwe/coworking/auth-service/internal/api/get_oidc_login"
+	"github.com/4udiwe/coworking/auth-service/internal/api/get_personal_tokens"
+	"github.com/4udiwe/coworking/auth-service/internal/api/get_roles"
+	"github.com/4udiwe/coworking/auth-service/internal/api/get_session_ips"
 	"github.com/4udiwe/coworking/auth-service/internal/api/get_user_by_id"
+	"github.com/4udiwe/coworking/auth-service/internal/api/get_user_coworking_roles"
 	"github.com/4udiwe/coworking/auth-service/internal/api/get_users"
+	"github.com/4udiwe/coworking/auth-service/internal/api/patch_client_set_active"
+	"github.com/4udiwe/coworking/auth-service/internal/api/patch_group"
+	"github.com/4udiwe/coworking/auth-service/internal/api/patch_me"
+	"github.com/4udiwe/coworking/auth-service/internal/api/patch_session"
 	"github.com/4udiwe/coworking/auth-service/internal/api/patch_user_set_active"
+	"github.com/4udiwe/coworking/auth-service/internal/api/post_client"
+	"github.com/4udiwe/coworking/auth-service/internal/api/post_email_confirm"
+	"github.com/4udiwe/coworking/auth-service/internal/api/post_group"
+	"github.com/4udiwe/coworking/auth-service/internal/api/post_group_members"
+	"github.com/4udiwe/coworking/auth-service/internal/api/post_impersonation"
+	"github.com/4udiwe/coworking/auth-service/internal/api/post_invite_accept"
 	"github.com/4udiwe/coworking/auth-service/internal/api/post_login"
 	"github.com/4udiwe/coworking/auth-service/internal/api/post_logout"
+	"github.com/4udiwe/coworking/auth-service/internal/api/post_me_email"
+	"github.com/4udiwe/coworking/auth-service/internal/api/post_me_password"
+	"github.com/4udiwe/coworking/auth-service/internal/api/post_oidc_callback"
+	"github.com/4udiwe/coworking/auth-service/internal/api/post_oidc_link"
+	"github.com/4udiwe/coworking/auth-service/internal/api/post_personal_token"
 	"github.com/4udiwe/coworking/auth-service/internal/api/post_refresh"
 	"github.com/4udiwe/coworking/auth-service/internal/api/post_register"
+	"github.com/4udiwe/coworking/auth-service/internal/api/post_revoke_other_sessions"
 	"github.com/4udiwe/coworking/auth-service/internal/api/post_revoke_session"
+	"github.com/4udiwe/coworking/auth-service/internal/api/post_token"
+	"github.com/4udiwe/coworking/auth-service/internal/api/post_user_coworking_role"
+	"github.com/4udiwe/coworking/auth-service/internal/api/post_users_import"
 	"github.com/4udiwe/coworking/auth-service/internal/api/put_user_roles"
 )
 
@@ -111,3 +148,299 @@ func (app *App) PutUserRolesHandler() api.Handler {
 	app.putUserRolesHanlder = put_user_roles.New(app.UserService())
 	return app.putUserRolesHanlder
 }
+
+func (app *App) GetOIDCLoginHandler() api.Handler {
+	if app.getOIDCLoginHandler != nil {
+		return app.getOIDCLoginHandler
+	}
+	app.getOIDCLoginHandler = get_oidc_login.New(app.SSOService())
+	return app.getOIDCLoginHandler
+}
+
+func (app *App) PostOIDCCallbackHandler() api.Handler {
+	if app.postOIDCCallbackHandler != nil {
+		return app.postOIDCCallbackHandler
+	}
+	app.postOIDCCallbackHandler = post_oidc_callback.New(app.SSOService())
+	return app.postOIDCCallbackHandler
+}
+
+func (app *App) PostOIDCLinkHandler() api.Handler {
+	if app.postOIDCLinkHandler != nil {
+		return app.postOIDCLinkHandler
+	}
+	app.postOIDCLinkHandler = post_oidc_link.New(app.SSOService())
+	return app.postOIDCLinkHandler
+}
+
+func (app *App) GetRolesHandler() api.Handler {
+	if app.getRolesHandler != nil {
+		return app.getRolesHandler
+	}
+	app.getRolesHandler = get_roles.New(app.UserService())
+	return app.getRolesHandler
+}
+
+func (app *App) GetUserCoworkingRolesHandler() api.Handler {
+	if app.getUserCoworkingRolesHandler != nil {
+		return app.getUserCoworkingRolesHandler
+	}
+	app.getUserCoworkingRolesHandler = get_user_coworking_roles.New(app.UserService())
+	return app.getUserCoworkingRolesHandler
+}
+
+func (app *App) PostUserCoworkingRoleHandler() api.Handler {
+	if app.postUserCoworkingRoleHandler != nil {
+		return app.postUserCoworkingRoleHandler
+	}
+	app.postUserCoworkingRoleHandler = post_user_coworking_role.New(app.UserService())
+	return app.postUserCoworkingRoleHandler
+}
+
+func (app *App) DeleteUserCoworkingRoleHandler() api.Handler {
+	if app.deleteUserCoworkingRoleHandler != nil {
+		return app.deleteUserCoworkingRoleHandler
+	}
+	app.deleteUserCoworkingRoleHandler = delete_user_coworking_role.New(app.UserService())
+	return app.deleteUserCoworkingRoleHandler
+}
+
+func (app *App) PostTokenHandler() api.Handler {
+	if app.postTokenHandler != nil {
+		return app.postTokenHandler
+	}
+	app.postTokenHandler = post_token.New(app.ClientService(), app.PersonalTokenService())
+	return app.postTokenHandler
+}
+
+func (app *App) GetClientsHandler() api.Handler {
+	if app.getClientsHandler != nil {
+		return app.getClientsHandler
+	}
+	app.getClientsHandler = get_clients.New(app.ClientService())
+	return app.getClientsHandler
+}
+
+func (app *App) PostClientHandler() api.Handler {
+	if app.postClientHandler != nil {
+		return app.postClientHandler
+	}
+	app.postClientHandler = post_client.New(app.ClientService())
+	return app.postClientHandler
+}
+
+func (app *App) PatchClientSetActiveHandler() api.Handler {
+	if app.patchClientSetActiveHandler != nil {
+		return app.patchClientSetActiveHandler
+	}
+	app.patchClientSetActiveHandler = patch_client_set_active.New(app.ClientService())
+	return app.patchClientSetActiveHandler
+}
+
+func (app *App) GetInternalUsersHandler() api.Handler {
+	if app.getInternalUsersHandler != nil {
+		return app.getInternalUsersHandler
+	}
+	app.getInternalUsersHandler = get_internal_users.New(app.UserService())
+	return app.getInternalUsersHandler
+}
+
+func (app *App) GetInternalUserIDsHandler() api.Handler {
+	if app.getInternalUserIDsHandler != nil {
+		return app.getInternalUserIDsHandler
+	}
+	app.getInternalUserIDsHandler = get_internal_user_ids.New(app.UserService())
+	return app.getInternalUserIDsHandler
+}
+
+func (app *App) GetPersonalTokensHandler() api.Handler {
+	if app.getPersonalTokensHandler != nil {
+		return app.getPersonalTokensHandler
+	}
+	app.getPersonalTokensHandler = get_personal_tokens.New(app.PersonalTokenService())
+	return app.getPersonalTokensHandler
+}
+
+func (app *App) PostPersonalTokenHandler() api.Handler {
+	if app.postPersonalTokenHandler != nil {
+		return app.postPersonalTokenHandler
+	}
+	app.postPersonalTokenHandler = post_personal_token.New(app.PersonalTokenService())
+	return app.postPersonalTokenHandler
+}
+
+func (app *App) DeletePersonalTokenHandler() api.Handler {
+	if app.deletePersonalTokenHandler != nil {
+		return app.deletePersonalTokenHandler
+	}
+	app.deletePersonalTokenHandler = delete_personal_token.New(app.PersonalTokenService())
+	return app.deletePersonalTokenHandler
+}
+
+func (app *App) PatchMeHandler() api.Handler {
+	if app.patchMeHandler != nil {
+		return app.patchMeHandler
+	}
+	app.patchMeHandler = patch_me.New(app.AccountService())
+	return app.patchMeHandler
+}
+
+func (app *App) PostMePasswordHandler() api.Handler {
+	if app.postMePasswordHandler != nil {
+		return app.postMePasswordHandler
+	}
+	app.postMePasswordHandler = post_me_password.New(app.AccountService())
+	return app.postMePasswordHandler
+}
+
+func (app *App) PostMeEmailHandler() api.Handler {
+	if app.postMeEmailHandler != nil {
+		return app.postMeEmailHandler
+	}
+	app.postMeEmailHandler = post_me_email.New(app.AccountService())
+	return app.postMeEmailHandler
+}
+
+func (app *App) PostEmailConfirmHandler() api.Handler {
+	if app.postEmailConfirmHandler != nil {
+		return app.postEmailConfirmHandler
+	}
+	app.postEmailConfirmHandler = post_email_confirm.New(app.AccountService())
+	return app.postEmailConfirmHandler
+}
+
+func (app *App) GetMeExportHandler() api.Handler {
+	if app.getMeExportHandler != nil {
+		return app.getMeExportHandler
+	}
+	app.getMeExportHandler = get_me_export.New(app.AccountService())
+	return app.getMeExportHandler
+}
+
+func (app *App) DeleteMeHandler() api.Handler {
+	if app.deleteMeHandler != nil {
+		return app.deleteMeHandler
+	}
+	app.deleteMeHandler = delete_me.New(app.AccountService())
+	return app.deleteMeHandler
+}
+
+func (app *App) PostUsersImportHandler() api.Handler {
+	if app.postUsersImportHandler != nil {
+		return app.postUsersImportHandler
+	}
+	app.postUsersImportHandler = post_users_import.New(app.RosterService())
+	return app.postUsersImportHandler
+}
+
+func (app *App) PostInviteAcceptHandler() api.Handler {
+	if app.postInviteAcceptHandler != nil {
+		return app.postInviteAcceptHandler
+	}
+	app.postInviteAcceptHandler = post_invite_accept.New(app.RosterService())
+	return app.postInviteAcceptHandler
+}
+
+func (app *App) GetGroupsHandler() api.Handler {
+	if app.getGroupsHandler != nil {
+		return app.getGroupsHandler
+	}
+	app.getGroupsHandler = get_groups.New(app.GroupService())
+	return app.getGroupsHandler
+}
+
+func (app *App) PostGroupHandler() api.Handler {
+	if app.postGroupHandler != nil {
+		return app.postGroupHandler
+	}
+	app.postGroupHandler = post_group.New(app.GroupService())
+	return app.postGroupHandler
+}
+
+func (app *App) PatchGroupHandler() api.Handler {
+	if app.patchGroupHandler != nil {
+		return app.patchGroupHandler
+	}
+	app.patchGroupHandler = patch_group.New(app.GroupService())
+	return app.patchGroupHandler
+}
+
+func (app *App) DeleteGroupHandler() api.Handler {
+	if app.deleteGroupHandler != nil {
+		return app.deleteGroupHandler
+	}
+	app.deleteGroupHandler = delete_group.New(app.GroupService())
+	return app.deleteGroupHandler
+}
+
+func (app *App) GetGroupMembersHandler() api.Handler {
+	if app.getGroupMembersHandler != nil {
+		return app.getGroupMembersHandler
+	}
+	app.getGroupMembersHandler = get_group_members.New(app.GroupService())
+	return app.getGroupMembersHandler
+}
+
+func (app *App) PostGroupMembersHandler() api.Handler {
+	if app.postGroupMembersHandler != nil {
+		return app.postGroupMembersHandler
+	}
+	app.postGroupMembersHandler = post_group_members.New(app.GroupService())
+	return app.postGroupMembersHandler
+}
+
+func (app *App) DeleteGroupMemberHandler() api.Handler {
+	if app.deleteGroupMemberHandler != nil {
+		return app.deleteGroupMemberHandler
+	}
+	app.deleteGroupMemberHandler = delete_group_member.New(app.GroupService())
+	return app.deleteGroupMemberHandler
+}
+
+func (app *App) PostImpersonationHandler() api.Handler {
+	if app.postImpersonationHandler != nil {
+		return app.postImpersonationHandler
+	}
+	app.postImpersonationHandler = post_impersonation.New(app.ImpersonationService())
+	return app.postImpersonationHandler
+}
+
+func (app *App) GetImpersonationsHandler() api.Handler {
+	if app.getImpersonationsHandler != nil {
+		return app.getImpersonationsHandler
+	}
+	app.getImpersonationsHandler = get_impersonations.New(app.ImpersonationService())
+	return app.getImpersonationsHandler
+}
+
+func (app *App) DeleteImpersonationHandler() api.Handler {
+	if app.deleteImpersonationHandler != nil {
+		return app.deleteImpersonationHandler
+	}
+	app.deleteImpersonationHandler = delete_impersonation.New(app.ImpersonationService())
+	return app.deleteImpersonationHandler
+}
+
+func (app *App) PatchSessionHandler() api.Handler {
+	if app.patchSessionHandler != nil {
+		return app.patchSessionHandler
+	}
+	app.patchSessionHandler = patch_session.New(app.AuthService())
+	return app.patchSessionHandler
+}
+
+func (app *App) GetSessionIPsHandler() api.Handler {
+	if app.getSessionIPsHandler != nil {
+		return app.getSessionIPsHandler
+	}
+	app.getSessionIPsHandler = get_session_ips.New(app.AuthService())
+	return app.getSessionIPsHandler
+}
+
+func (app *App) PostRevokeOtherSessionsHandler() api.Handler {
+	if app.postRevokeOtherSessionsHandler != nil {
+		return app.postRevokeOtherSessionsHandler
+	}
+	app.postRevokeOtherSessionsHandler = post_revoke_other_sessions.New(app.AuthService())
+	return app.postRevokeOtherSessionsHandler
+}
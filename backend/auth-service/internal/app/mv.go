@@ -1,6 +1,6 @@
 package app
 
-import "github.com/4udiwe/coworking/auth-service/internal/api/middleware"
+import "github.com/4udiwe/coworking/auth-service/pkg/middleware"
 
 func (app *App) AuthMiddleware() *middleware.AuthMiddleware {
 	if app.authMW != nil {
@@ -4,7 +4,8 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/4udiwe/coworking/auth-service/internal/api/middleware"
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/4udiwe/subscription-service/pkg/validator"
 	"github.com/labstack/echo/v4"
 )
@@ -32,25 +33,88 @@ func (app *App) configureRouter(handler *echo.Echo) {
 	authGroup := handler.Group("auth")
 	{
 		authGroup.POST("/login", app.PostLoginHandler().Handle)
-		authGroup.POST("/logout", app.PostLogoutHandler().Handle, app.AuthMiddleware().Middleware)
+		authGroup.POST("/logout", app.PostLogoutHandler().Handle, app.AuthMiddleware().Middleware, middleware.InteractiveOnly)
 		authGroup.POST("/refresh", app.PostRefreshHandler().Handle)
 		authGroup.POST("/register", app.PostRegisterHandler().Handle)
+		authGroup.POST("/token", app.PostTokenHandler().Handle)
+		// Переход по ссылке из письма: пользователь может быть не залогинен
+		authGroup.POST("/email/confirm", app.PostEmailConfirmHandler().Handle)
+		// Установка пароля по ссылке из приглашения
+		authGroup.POST("/invite/accept", app.PostInviteAcceptHandler().Handle)
 	}
 
 	userGroup := handler.Group("users", app.AuthMiddleware().Middleware)
 	{
 		userGroup.GET("/me", app.GetMeHandler().Handle)
-		userGroup.GET("/sessions/active", app.GetActiveSessionsHandler().Handle)
-		userGroup.GET("/sessions/all", app.GetAllSessionsHandler().Handle)
-		userGroup.POST("/sessions/revoke", app.PostRevokeSessionHandler().Handle)
+		userGroup.GET("/sessions/active", app.GetActiveSessionsHandler().Handle, middleware.InteractiveOnly)
+		userGroup.GET("/sessions/all", app.GetAllSessionsHandler().Handle, middleware.InteractiveOnly)
+		userGroup.POST("/sessions/revoke", app.PostRevokeSessionHandler().Handle, middleware.InteractiveOnly)
+		userGroup.POST("/sessions/revoke_others", app.PostRevokeOtherSessionsHandler().Handle, middleware.InteractiveOnly)
+		userGroup.PATCH("/sessions/:sessionId", app.PatchSessionHandler().Handle, middleware.InteractiveOnly)
+		userGroup.GET("/sessions/:sessionId/ips", app.GetSessionIPsHandler().Handle, middleware.InteractiveOnly)
+
+		// Персональные токены доступа
+		userGroup.GET("/me/tokens", app.GetPersonalTokensHandler().Handle, middleware.InteractiveOnly)
+		userGroup.POST("/me/tokens", app.PostPersonalTokenHandler().Handle, middleware.InteractiveOnly)
+		userGroup.DELETE("/me/tokens/:tokenId", app.DeletePersonalTokenHandler().Handle, middleware.InteractiveOnly)
+
+		// Управление аккаунтом
+		userGroup.PATCH("/me", app.PatchMeHandler().Handle, middleware.InteractiveOnly)
+		userGroup.POST("/me/password", app.PostMePasswordHandler().Handle, middleware.InteractiveOnly)
+		userGroup.POST("/me/email", app.PostMeEmailHandler().Handle, middleware.InteractiveOnly)
+		userGroup.GET("/me/export", app.GetMeExportHandler().Handle, middleware.InteractiveOnly)
+		userGroup.DELETE("/me", app.DeleteMeHandler().Handle, middleware.InteractiveOnly)
+	}
+
+	adminGroup := handler.Group("admin", app.AuthMiddleware().Middleware)
+	{
+		canRead := middleware.RequirePermission(jwt_validator.PermUsersRead)
+		canManage := middleware.RequirePermission(jwt_validator.PermUsersManage)
+
+		adminGroup.GET("/users", app.GetUsersHandler().Handle, canRead)
+		adminGroup.GET("/users/:userId", app.GetUserByIdHandler().Handle, canRead)
+		adminGroup.PATCH("/users/:userId/set_active", app.PatchUserSetActiveHandler().Handle, canManage)
+		adminGroup.PUT("/users/:userId/roles", app.PutUserRolesHandler().Handle, canManage)
+		adminGroup.POST("/users/import", app.PostUsersImportHandler().Handle, canManage)
+
+		adminGroup.GET("/roles", app.GetRolesHandler().Handle, canRead)
+		adminGroup.GET("/users/:userId/coworking_roles", app.GetUserCoworkingRolesHandler().Handle, canRead)
+		adminGroup.POST("/users/:userId/coworking_roles", app.PostUserCoworkingRoleHandler().Handle, canManage)
+		adminGroup.DELETE("/users/:userId/coworking_roles/:coworkingId/:roleCode", app.DeleteUserCoworkingRoleHandler().Handle, canManage)
+
+		adminGroup.GET("/groups", app.GetGroupsHandler().Handle, canRead)
+		adminGroup.POST("/groups", app.PostGroupHandler().Handle, canManage)
+		adminGroup.PATCH("/groups/:groupId", app.PatchGroupHandler().Handle, canManage)
+		adminGroup.DELETE("/groups/:groupId", app.DeleteGroupHandler().Handle, canManage)
+		adminGroup.GET("/groups/:groupId/members", app.GetGroupMembersHandler().Handle, canRead)
+		adminGroup.POST("/groups/:groupId/members", app.PostGroupMembersHandler().Handle, canManage)
+		adminGroup.DELETE("/groups/:groupId/members/:userId", app.DeleteGroupMemberHandler().Handle, canManage)
+
+		// Вход от имени пользователя: только из обычной сессии сотрудника,
+		// не по персональному токену и не из другой такой выдачи
+		canImpersonate := middleware.RequirePermission(jwt_validator.PermUsersImpersonate)
+		adminGroup.POST("/users/:userId/impersonate", app.PostImpersonationHandler().Handle, middleware.InteractiveOnly, canImpersonate)
+		adminGroup.GET("/impersonations", app.GetImpersonationsHandler().Handle, canImpersonate)
+		adminGroup.DELETE("/impersonations/:impersonationId", app.DeleteImpersonationHandler().Handle, middleware.InteractiveOnly, canImpersonate)
+
+		adminGroup.GET("/clients", app.GetClientsHandler().Handle, canManage)
+		adminGroup.POST("/clients", app.PostClientHandler().Handle, canManage)
+		adminGroup.PATCH("/clients/:clientId/set_active", app.PatchClientSetActiveHandler().Handle, canManage)
 	}
 
-	adminGroup := handler.Group("admin", app.AuthMiddleware().Middleware, middleware.AdminOnly)
+	// Межсервисные вызовы: только токены client credentials
+	internalGroup := handler.Group("internal")
 	{
-		adminGroup.GET("/users", app.GetUsersHandler().Handle)
-		adminGroup.GET("/users/:userId", app.GetUserByIdHandler().Handle)
-		adminGroup.PATCH("/users/:userId/set_active", app.PatchUserSetActiveHandler().Handle)
-		adminGroup.PUT("/users/:userId/roles", app.PutUserRolesHandler().Handle)
+		internalGroup.GET("/users", app.GetInternalUsersHandler().Handle, app.AuthMiddleware().ServiceOnly(jwt_validator.ScopeUsersLookup))
+		internalGroup.GET("/users/ids", app.GetInternalUserIDsHandler().Handle, app.AuthMiddleware().ServiceOnly(jwt_validator.ScopeUsersLookup))
+	}
+
+	// University SSO (OpenID Connect)
+	if app.cfg.OIDC.Enabled {
+		authGroup.GET("/oidc/login", app.GetOIDCLoginHandler().Handle)
+		// Токен нужен только для завершения привязки из профиля
+		authGroup.POST("/oidc/callback", app.PostOIDCCallbackHandler().Handle, app.AuthMiddleware().Optional)
+		userGroup.POST("/me/oidc/link", app.PostOIDCLinkHandler().Handle, middleware.InteractiveOnly)
 	}
 
 	handler.GET("/health", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
@@ -1,8 +1,16 @@
 package app
 
 import (
+	"github.com/4udiwe/coworking/auth-service/internal/exporter"
 	"github.com/4udiwe/coworking/auth-service/internal/hasher"
+	account_service "github.com/4udiwe/coworking/auth-service/internal/service/account"
 	auth_service "github.com/4udiwe/coworking/auth-service/internal/service/auth"
+	client_service "github.com/4udiwe/coworking/auth-service/internal/service/client"
+	group_service "github.com/4udiwe/coworking/auth-service/internal/service/group"
+	impersonation_service "github.com/4udiwe/coworking/auth-service/internal/service/impersonation"
+	pat_service "github.com/4udiwe/coworking/auth-service/internal/service/pat"
+	roster_service "github.com/4udiwe/coworking/auth-service/internal/service/roster"
+	sso_service "github.com/4udiwe/coworking/auth-service/internal/service/sso"
 	user_service "github.com/4udiwe/coworking/auth-service/internal/service/user"
 )
 
@@ -21,10 +29,18 @@ func (app *App) AuthService() *auth_service.Service {
 	app.authService = auth_service.New(
 		app.UserRepo(),
 		app.AuthRepo(),
+		app.OutboxRepo(),
 		app.Postgres(),
 		app.Auth(),
 		app.Hasher(),
+		app.GeoIP(),
+		app.cfg.Auth.AccessTokenTTL,
 		app.cfg.Auth.RefreshTokenTTL,
+		auth_service.SessionPolicy{
+			MaxActive:       app.cfg.Sessions.MaxActive,
+			MaxActiveByRole: app.cfg.Sessions.MaxActiveByRole,
+			RejectOnLimit:   app.cfg.Sessions.RejectOnLimit,
+		},
 	)
 	return app.authService
 }
@@ -35,7 +51,135 @@ func (app *App) UserService() *user_service.Service {
 	}
 	app.userService = user_service.New(
 		app.UserRepo(),
+		app.OutboxRepo(),
 		app.Postgres(),
+		app.cfg.Auth.AccessTokenTTL,
 	)
 	return app.userService
 }
+
+func (app *App) SSOService() *sso_service.Service {
+	if app.ssoService != nil {
+		return app.ssoService
+	}
+	app.ssoService = sso_service.New(
+		app.UserRepo(),
+		app.IdentityRepo(),
+		app.OutboxRepo(),
+		app.OIDCProvider(),
+		app.AuthService(),
+		app.Postgres(),
+		app.cfg.OIDC.RoleMapping,
+		app.cfg.OIDC.DefaultRole,
+		app.cfg.OIDC.SyncRoles,
+		app.cfg.OIDC.LinkByEmail,
+		app.cfg.OIDC.StateTTL,
+	)
+	return app.ssoService
+}
+
+func (app *App) ClientService() *client_service.Service {
+	if app.clientService != nil {
+		return app.clientService
+	}
+	app.clientService = client_service.New(
+		app.ClientRepo(),
+		app.Hasher(),
+		app.Auth(),
+	)
+	return app.clientService
+}
+
+func (app *App) PersonalTokenService() *pat_service.Service {
+	if app.personalTokenService != nil {
+		return app.personalTokenService
+	}
+	app.personalTokenService = pat_service.New(
+		app.PersonalTokenRepo(),
+		app.UserRepo(),
+		app.OutboxRepo(),
+		app.Auth(),
+		app.Postgres(),
+		app.cfg.Auth.AccessTokenTTL,
+		app.cfg.PAT.MaxTTL,
+	)
+	return app.personalTokenService
+}
+
+func (app *App) AccountService() *account_service.Service {
+	if app.accountService != nil {
+		return app.accountService
+	}
+
+	sources := make([]account_service.DataSource, 0, len(app.cfg.Account.ExportSources))
+	for _, src := range app.cfg.Account.ExportSources {
+		sources = append(sources, exporter.New(src.Name, src.URL, app.Auth(), app.cfg.Account.ExportTimeout))
+	}
+
+	app.accountService = account_service.New(
+		app.UserRepo(),
+		app.AuthRepo(),
+		app.EmailChangeRepo(),
+		app.IdentityRepo(),
+		app.PersonalTokenRepo(),
+		app.OutboxRepo(),
+		app.Hasher(),
+		app.Auth(),
+		sources,
+		app.Postgres(),
+		app.cfg.Auth.AccessTokenTTL,
+		app.cfg.Account.EmailChangeTTL,
+		app.cfg.Account.EmailConfirmURL,
+		app.cfg.Outbox.MailTopic,
+	)
+	return app.accountService
+}
+
+func (app *App) RosterService() *roster_service.Service {
+	if app.rosterService != nil {
+		return app.rosterService
+	}
+	app.rosterService = roster_service.New(
+		app.UserRepo(),
+		app.InvitationRepo(),
+		app.OutboxRepo(),
+		app.Hasher(),
+		app.Auth(),
+		app.Postgres(),
+		app.cfg.Auth.AccessTokenTTL,
+		app.cfg.Roster.InviteTTL,
+		app.cfg.Roster.InviteURL,
+		app.cfg.Roster.DefaultRole,
+		app.cfg.Roster.MaxRows,
+		app.cfg.Outbox.MailTopic,
+	)
+	return app.rosterService
+}
+
+func (app *App) GroupService() *group_service.Service {
+	if app.groupService != nil {
+		return app.groupService
+	}
+	app.groupService = group_service.New(
+		app.GroupRepo(),
+		app.OutboxRepo(),
+		app.Postgres(),
+		app.cfg.Auth.AccessTokenTTL,
+	)
+	return app.groupService
+}
+
+func (app *App) ImpersonationService() *impersonation_service.Service {
+	if app.impersonationService != nil {
+		return app.impersonationService
+	}
+	app.impersonationService = impersonation_service.New(
+		app.ImpersonationRepo(),
+		app.UserRepo(),
+		app.OutboxRepo(),
+		app.Auth(),
+		app.Postgres(),
+		app.cfg.Impersonation.TTL,
+	)
+	return app.impersonationService
+}
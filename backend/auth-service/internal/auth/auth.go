@@ -8,9 +8,11 @@ import (
 	"encoding/pem"
 	"errors"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
 	"github.com/samber/lo"
@@ -29,6 +31,7 @@ type Auth struct {
 
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
+	serviceTokenTTL time.Duration
 }
 
 /*
@@ -42,6 +45,7 @@ func New(
 	issuer string,
 	accessTokenTTL time.Duration,
 	refreshTokenTTL time.Duration,
+	serviceTokenTTL time.Duration,
 ) *Auth {
 
 	return &Auth{
@@ -50,6 +54,7 @@ func New(
 		issuer:          issuer,
 		accessTokenTTL:  accessTokenTTL,
 		refreshTokenTTL: refreshTokenTTL,
+		serviceTokenTTL: serviceTokenTTL,
 	}
 }
 
@@ -70,6 +75,10 @@ func (a *Auth) GenerateTokens(
 		Roles: lo.Map(user.Roles, func(r entity.Role, _ int) string {
 			return string(r.Code)
 		}),
+		Permissions: lo.Map(user.Permissions, func(p entity.Permission, _ int) string {
+			return jwt_validator.FormatPermission(p.Code, p.CoworkingID)
+		}),
+		Groups: user.GroupIDs,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    a.issuer,
 			Subject:   user.ID.String(),
@@ -114,6 +123,149 @@ func (a *Auth) GenerateTokens(
 	}, nil
 }
 
+/*
+GeneratePersonalAccessToken выпускает access token в обмен на персональный токен.
+
+Права ограничены permissions токена, sessionId = tokenID.
+ttl вычисляет вызывающий: не дольше обычного access token и срока PAT.
+*/
+func (a *Auth) GeneratePersonalAccessToken(
+	user entity.User,
+	tokenID uuid.UUID,
+	permissions []string,
+	ttl time.Duration,
+) (*ExchangedToken, error) {
+
+	now := time.Now()
+
+	claims := AccessClaims{
+		UserID:    user.ID,
+		SessionID: tokenID,
+		UserName:  user.FirstName + " " + user.LastName,
+		Email:     user.Email,
+		Roles: lo.Map(user.Roles, func(r entity.Role, _ int) string {
+			return string(r.Code)
+		}),
+		Permissions:     permissions,
+		Groups:          user.GroupIDs,
+		PersonalTokenID: &tokenID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    a.issuer,
+			Subject:   user.ID.String(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.NewString(),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(a.privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExchangedToken{
+		AccessToken:     token,
+		IssuedTokenType: "urn:ietf:params:oauth:token-type:access_token",
+		TokenType:       "Bearer",
+		ExpiresIn:       int64(ttl.Seconds()),
+	}, nil
+}
+
+/*
+GenerateImpersonationToken выпускает access token пользователя для сотрудника поддержки.
+
+Права и группы — как у пользователя, в claim act — сотрудник.
+sessionID = ID записи impersonations. Refresh token не выдаётся:
+по истечении нужно начать новую выдачу (и оставить новую запись аудита).
+*/
+func (a *Auth) GenerateImpersonationToken(
+	user entity.User,
+	actor entity.User,
+	sessionID uuid.UUID,
+	allowWrite bool,
+	ttl time.Duration,
+) (*ExchangedToken, error) {
+
+	now := time.Now()
+
+	claims := AccessClaims{
+		UserID:    user.ID,
+		SessionID: sessionID,
+		UserName:  user.FirstName + " " + user.LastName,
+		Email:     user.Email,
+		Roles: lo.Map(user.Roles, func(r entity.Role, _ int) string {
+			return string(r.Code)
+		}),
+		Permissions: lo.Map(user.Permissions, func(p entity.Permission, _ int) string {
+			return jwt_validator.FormatPermission(p.Code, p.CoworkingID)
+		}),
+		Groups: user.GroupIDs,
+		Act: &ActorClaims{
+			UserID:     actor.ID,
+			Email:      actor.Email,
+			AllowWrite: allowWrite,
+		},
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    a.issuer,
+			Subject:   user.ID.String(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.NewString(),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(a.privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExchangedToken{
+		AccessToken:     token,
+		IssuedTokenType: "urn:ietf:params:oauth:token-type:access_token",
+		TokenType:       "Bearer",
+		ExpiresIn:       int64(ttl.Seconds()),
+	}, nil
+}
+
+/*
+GenerateServiceToken выпускает access token сервиса (client credentials).
+
+Refresh token не выдаётся — сервис просто запрашивает новый токен.
+scopes уже должны быть проверены по списку, разрешённому клиенту.
+*/
+func (a *Auth) GenerateServiceToken(
+	clientID string,
+	scopes []string,
+) (*ServiceToken, error) {
+
+	now := time.Now()
+	scope := strings.Join(scopes, " ")
+
+	claims := ServiceClaims{
+		ClientID: clientID,
+		Scope:    scope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    a.issuer,
+			Subject:   clientID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(a.serviceTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.NewString(),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(a.privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServiceToken{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(a.serviceTokenTTL.Seconds()),
+		Scope:       scope,
+	}, nil
+}
+
 func (a *Auth) ParseRefreshToken(
 	tokenString string,
 ) (*RefreshClaims, error) {
@@ -18,9 +18,47 @@ type AccessClaims struct {
 	UserName  string    `json:"userName"`
 	Email     string    `json:"email"`
 	Roles     []string  `json:"roles"`
+	// Формат — jwt_validator.FormatPermission
+	Permissions []string `json:"permissions,omitempty"`
+	// ID групп пользователя (ограничения бронирования в booking-service)
+	Groups []uuid.UUID `json:"groups,omitempty"`
+	// Заполнен, если токен получен обменом персонального токена
+	PersonalTokenID *uuid.UUID `json:"patId,omitempty"`
+	// Заполнен, если токен выдан сотруднику поддержки от имени пользователя
+	Act *ActorClaims `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// Actor claim (RFC 8693, 4.1): кто действует от имени пользователя
+type ActorClaims struct {
+	UserID     uuid.UUID `json:"sub"`
+	Email      string    `json:"email"`
+	AllowWrite bool      `json:"allowWrite,omitempty"`
+}
+
+// Access token сервиса (client credentials)
+type ServiceClaims struct {
+	ClientID string `json:"client_id"`
+	Scope    string `json:"scope,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Ответ token endpoint на token exchange (RFC 8693, 2.2.1)
+type ExchangedToken struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int64  `json:"expires_in"`
+}
+
+// Ответ token endpoint (RFC 6749, 5.1)
+type ServiceToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
 // Refresh token claims
 type RefreshClaims struct {
 	UserID    uuid.UUID `json:"userId"`
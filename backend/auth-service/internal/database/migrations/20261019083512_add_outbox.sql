@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ============================================
+-- Outbox for events published by auth-service
+-- ============================================
+-- Resource services (booking, notification, analytics, media) validate
+-- access tokens locally, so they never see RevokeSession / SetUserActive.
+-- auth-service records auth.session.revoked and auth.user.deactivated
+-- in the same transaction as the state change and the outbox worker
+-- delivers them to Kafka.
+-- ============================================
+CREATE TABLE outbox_status (
+    id SMALLSERIAL PRIMARY KEY,
+    name VARCHAR(32) NOT NULL UNIQUE
+);
+
+INSERT INTO outbox_status (name) VALUES
+('pending'), ('processed'), ('failed');
+
+CREATE TABLE outbox (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    aggregate_type VARCHAR(64) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    event_type VARCHAR(128) NOT NULL,
+    payload JSONB NOT NULL,
+    status_id SMALLINT NOT NULL REFERENCES outbox_status(id) DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX idx_outbox_status_id ON outbox (status_id);
+CREATE INDEX idx_outbox_created_at ON outbox (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS outbox CASCADE;
+DROP TABLE IF EXISTS outbox_status CASCADE;
+-- +goose StatementEnd
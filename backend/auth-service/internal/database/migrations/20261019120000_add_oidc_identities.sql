@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ============================================
+-- OIDC (university SSO)
+-- ============================================
+-- user_identities: привязка внешнего аккаунта IdP (iss + sub) к пользователю.
+-- Один пользователь может войти и по паролю, и через SSO.
+--
+-- oidc_login_states: одноразовое состояние authorization code flow
+-- (state, PKCE code_verifier, nonce). Удаляется при обработке callback.
+-- link_user_id заполнен, если пользователь привязывает SSO к уже
+-- существующему аккаунту.
+-- ============================================
+CREATE TABLE user_identities (
+    id            UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id       UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    issuer        TEXT NOT NULL,
+    subject       TEXT NOT NULL,
+    email         TEXT,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_login_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (issuer, subject)
+);
+
+CREATE INDEX idx_user_identities_user ON user_identities(user_id);
+
+CREATE TABLE oidc_login_states (
+    state         TEXT PRIMARY KEY,
+    code_verifier TEXT NOT NULL,
+    nonce         TEXT NOT NULL,
+    link_user_id  UUID REFERENCES users(id) ON DELETE CASCADE,
+    expires_at    TIMESTAMPTZ NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_oidc_login_states_expires ON oidc_login_states(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS oidc_login_states;
+DROP TABLE IF EXISTS user_identities;
+-- +goose StatementEnd
@@ -0,0 +1,70 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ============================================
+-- Permissions
+-- ============================================
+-- Роль — набор прав (role_permissions). Роль выдаётся пользователю
+-- либо глобально (user_roles), либо в пределах одного коворкинга
+-- (user_coworking_roles) — например, менеджер стойки регистрации,
+-- который управляет бронированиями только в своём коворкинге.
+--
+-- Эффективные права попадают в access token (claim permissions):
+--   bookings.manage                — во всех коворкингах
+--   bookings.manage:<coworking_id> — только в указанном
+-- ============================================
+CREATE TABLE permissions (
+    code        TEXT PRIMARY KEY,
+    description TEXT NOT NULL
+);
+
+CREATE TABLE role_permissions (
+    role_id         UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission_code TEXT NOT NULL REFERENCES permissions(code) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_code)
+);
+
+-- coworking_id ссылается на booking-service, поэтому без FK
+CREATE TABLE user_coworking_roles (
+    user_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id      UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    coworking_id UUID NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, role_id, coworking_id)
+);
+
+CREATE INDEX idx_user_coworking_roles_coworking ON user_coworking_roles(coworking_id);
+
+INSERT INTO permissions (code, description) VALUES
+    ('coworkings.manage', 'Создание и редактирование коворкингов'),
+    ('layouts.manage',    'Управление схемами коворкинга'),
+    ('places.manage',     'Управление местами'),
+    ('bookings.manage',   'Просмотр и отмена бронирований'),
+    ('media.manage',      'Загрузка и удаление медиа'),
+    ('users.read',        'Просмотр пользователей'),
+    ('users.manage',      'Управление пользователями и ролями');
+
+INSERT INTO roles (code, name) VALUES
+    ('manager', 'Менеджер коворкинга');
+
+-- admin — все права
+INSERT INTO role_permissions (role_id, permission_code)
+SELECT r.id, p.code
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.code = 'admin';
+
+-- manager — бронирования (обычно выдаётся в пределах коворкинга)
+INSERT INTO role_permissions (role_id, permission_code)
+SELECT r.id, p.code
+FROM roles r
+JOIN permissions p ON p.code IN ('bookings.manage')
+WHERE r.code = 'manager';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_coworking_roles;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS permissions;
+DELETE FROM roles WHERE code = 'manager';
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ============================================
+-- SERVICE CLIENTS (OAuth2 client credentials)
+-- ============================================
+-- Внутренние сервисы получают access token по client_id / client_secret.
+-- Секрет хранится только в виде bcrypt-хеша.
+-- scopes — права, которые клиент может запросить (коды из permissions
+-- или специальные внутренние scope).
+-- ============================================
+CREATE TABLE service_clients (
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    client_id    TEXT NOT NULL UNIQUE,
+    name         TEXT NOT NULL,
+    secret_hash  TEXT NOT NULL,
+    scopes       TEXT[] NOT NULL DEFAULT '{}',
+    is_active    BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at TIMESTAMPTZ
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS service_clients;
+-- +goose StatementEnd
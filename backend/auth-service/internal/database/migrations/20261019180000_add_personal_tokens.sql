@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ============================================
+-- PERSONAL ACCESS TOKENS
+-- ============================================
+-- Долгоживущие токены для скриптов и интеграций. Пользователь выбирает
+-- подмножество своих прав (permissions в формате FormatPermission)
+-- и срок действия. Хранится только SHA-256 хеш, prefix — для отображения.
+-- Токен обменивается на короткий JWT (token exchange), id токена
+-- используется как sessionId этого JWT.
+-- ============================================
+CREATE TABLE personal_access_tokens (
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name         TEXT NOT NULL,
+    token_hash   TEXT NOT NULL UNIQUE,
+    prefix       TEXT NOT NULL,
+    permissions  TEXT[] NOT NULL DEFAULT '{}',
+    expires_at   TIMESTAMPTZ NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at TIMESTAMPTZ,
+    revoked_at   TIMESTAMPTZ
+);
+
+CREATE INDEX idx_personal_access_tokens_user ON personal_access_tokens(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS personal_access_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ============================================
+-- ACCOUNT MANAGEMENT
+-- ============================================
+-- deleted_at — аккаунт удалён по запросу пользователя. Строка остаётся
+-- (на неё ссылается outbox и журналы), но персональные данные затёрты.
+-- ============================================
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- ============================================
+-- EMAIL CHANGE REQUESTS
+-- ============================================
+-- Новый email применяется только после перехода по ссылке из письма
+-- на новый адрес. Хранится SHA-256 хеш одноразового токена.
+-- ============================================
+CREATE TABLE email_change_requests (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    new_email   TEXT NOT NULL,
+    token_hash  TEXT NOT NULL UNIQUE,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_email_change_requests_user ON email_change_requests(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS email_change_requests;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ============================================
+-- USER INVITATIONS
+-- ============================================
+-- Приглашение пользователю, созданному массовым импортом: по ссылке
+-- он задаёт начальный пароль. Хранится SHA-256 хеш одноразового токена,
+-- у пользователя действует только последнее приглашение.
+-- ============================================
+CREATE TABLE user_invitations (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash  TEXT NOT NULL UNIQUE,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_user_invitations_user ON user_invitations(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_invitations;
+-- +goose StatementEnd
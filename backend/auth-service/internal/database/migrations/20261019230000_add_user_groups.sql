@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ============================================
+-- USER GROUPS
+-- ============================================
+-- Группы пользователей (поток, кафедра, лаборатория). В отличие от ролей
+-- не дают прав: ID групп попадают в claim groups access token, и
+-- booking-service по ним ограничивает, кто может бронировать коворкинг
+-- или конкретное место.
+-- ============================================
+CREATE TABLE user_groups (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name        TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE user_group_members (
+    group_id   UUID NOT NULL REFERENCES user_groups(id) ON DELETE CASCADE,
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX idx_user_group_members_user ON user_group_members(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_group_members;
+DROP TABLE IF EXISTS user_groups;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ============================================
+-- IMPERSONATION ("войти как пользователь")
+-- ============================================
+-- Сотрудник поддержки получает короткий access token от имени пользователя,
+-- в claim act — кто действует на самом деле. Каждая выдача сохраняется
+-- для аудита; id записи используется как sessionId токена, поэтому
+-- досрочное завершение работает через denylist сессий.
+-- ============================================
+INSERT INTO permissions (code, description) VALUES
+    ('users.impersonate', 'Вход от имени пользователя (поддержка)');
+
+INSERT INTO role_permissions (role_id, permission_code)
+SELECT r.id, 'users.impersonate'
+FROM roles r
+WHERE r.code = 'admin';
+
+CREATE TABLE impersonations (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason      TEXT NOT NULL,
+    allow_write BOOLEAN NOT NULL DEFAULT false,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ended_at    TIMESTAMPTZ
+);
+
+CREATE INDEX idx_impersonations_actor ON impersonations(actor_id, created_at DESC);
+CREATE INDEX idx_impersonations_user ON impersonations(user_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS impersonations;
+DELETE FROM role_permissions WHERE permission_code = 'users.impersonate';
+DELETE FROM permissions WHERE code = 'users.impersonate';
+-- +goose StatementEnd
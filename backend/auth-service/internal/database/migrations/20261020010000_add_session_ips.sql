@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- История адресов сессии: адрес входа и каждого refresh.
+-- refresh_tokens.ip_address хранит только последний адрес.
+CREATE TABLE session_ips (
+    session_id    UUID NOT NULL REFERENCES refresh_tokens(id) ON DELETE CASCADE,
+    ip_address    TEXT NOT NULL,
+    first_seen_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_seen_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (session_id, ip_address)
+);
+
+INSERT INTO session_ips (session_id, ip_address, first_seen_at, last_seen_at)
+SELECT id, ip_address, created_at, last_used_at
+FROM refresh_tokens;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS session_ips;
+-- +goose StatementEnd
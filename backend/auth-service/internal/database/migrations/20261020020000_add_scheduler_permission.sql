@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Управление периодическими задачами scheduler-service (/admin/scheduler)
+INSERT INTO permissions (code, description) VALUES
+    ('scheduler.manage', 'Управление периодическими задачами планировщика');
+
+INSERT INTO role_permissions (role_id, permission_code)
+SELECT r.id, 'scheduler.manage'
+FROM roles r
+WHERE r.code = 'admin';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM permissions WHERE code = 'scheduler.manage';
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Управление текстами уведомлений notification-service (/admin/notifications)
+INSERT INTO permissions (code, description) VALUES
+    ('notifications.manage', 'Управление шаблонами уведомлений');
+
+INSERT INTO role_permissions (role_id, permission_code)
+SELECT r.id, 'notifications.manage'
+FROM roles r
+WHERE r.code = 'admin';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM permissions WHERE code = 'notifications.manage';
+-- +goose StatementEnd
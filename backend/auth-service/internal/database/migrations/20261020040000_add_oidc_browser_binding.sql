@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- browser_key_hash: SHA-256 ключа из httpOnly cookie браузера, начавшего вход.
+-- Callback принимает state только вместе с этим cookie.
+-- Незавершённые попытки входа без ключа становятся недействительными.
+DELETE FROM oidc_login_states;
+
+ALTER TABLE oidc_login_states
+    ADD COLUMN browser_key_hash TEXT NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE oidc_login_states DROP COLUMN IF EXISTS browser_key_hash;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Топик события; NULL — основной топик сервиса (auth.events).
+-- Письма с одноразовыми ссылками уходят в auth.mail отдельным воркером,
+-- чтобы токены не читали все подписчики auth.events.
+ALTER TABLE outbox
+    ADD COLUMN topic VARCHAR(128) NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE outbox DROP COLUMN IF EXISTS topic;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailChangeRequest — смена email, ожидающая подтверждения с нового адреса.
+type EmailChangeRequest struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	NewEmail  string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Group — группа пользователей (поток, кафедра). Права не даёт,
+// используется для ограничения доступа к коворкингам и местам.
+type Group struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	MemberCount int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
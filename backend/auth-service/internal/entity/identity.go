@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserIdentity — внешний аккаунт IdP (OIDC), привязанный к пользователю.
+// Уникально определяется парой Issuer + Subject.
+type UserIdentity struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Issuer      string
+	Subject     string
+	Email       *string
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+// OIDCLoginState — одноразовое состояние authorization code flow.
+type OIDCLoginState struct {
+	State          string
+	CodeVerifier   string
+	Nonce          string
+	BrowserKeyHash string // SHA-256 ключа из cookie браузера, начавшего вход
+	LinkUserID     *uuid.UUID
+	ExpiresAt      time.Time
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Impersonation — выдача access token от имени пользователя сотруднику поддержки.
+type Impersonation struct {
+	ID         uuid.UUID
+	ActorID    uuid.UUID
+	UserID     uuid.UUID
+	Reason     string
+	AllowWrite bool
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	EndedAt    *time.Time
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxStatusName string
+
+const (
+	OutboxStatusPending   OutboxStatusName = "pending"
+	OutboxStatusFailed    OutboxStatusName = "failed"
+	OutboxStatusProcessed OutboxStatusName = "processed"
+)
+
+type OutboxStatus struct {
+	ID   int
+	Name OutboxStatusName
+}
+
+type OutboxEvent struct {
+	ID            uuid.UUID
+	AggregateType string
+	AggregateID   uuid.UUID
+	EventType     string
+	Payload       map[string]any
+	Status        OutboxStatus
+	CreatedAt     time.Time
+	ProcessedAt   *time.Time
+
+	// Пустой — основной топик сервиса (outbox.topic в конфиге)
+	Topic string
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PersonalToken — персональный токен доступа (PAT) для скриптов и интеграций.
+type PersonalToken struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	Prefix      string
+	Permissions []string
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+	LastUsedAt  *time.Time
+	RevokedAt   *time.Time
+}
+
+// IsUsable — токен не отозван и не истёк.
+func (t PersonalToken) IsUsable(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -11,12 +11,28 @@ type RoleCode string
 const (
 	RoleStudent RoleCode = "student"
 	RoleTeacher RoleCode = "teacher"
+	RoleManager RoleCode = "manager"
 	RoleAdmin   RoleCode = "admin"
 )
 
 type Role struct {
-	ID        uuid.UUID
-	Code      RoleCode
-	Name      string
-	CreatedAt time.Time
+	ID          uuid.UUID
+	Code        RoleCode
+	Name        string
+	CreatedAt   time.Time
+	Permissions []string
+}
+
+// CoworkingRole — роль, выданная пользователю в пределах одного коворкинга.
+type CoworkingRole struct {
+	Role        Role
+	CoworkingID uuid.UUID
+	CreatedAt   time.Time
+}
+
+// Permission — эффективное право пользователя.
+// CoworkingID == nil — право действует во всех коворкингах.
+type Permission struct {
+	Code        string
+	CoworkingID *uuid.UUID
 }
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceClient — внутренний сервис, получающий токены по client credentials.
+type ServiceClient struct {
+	ID         uuid.UUID
+	ClientID   string
+	Name       string
+	SecretHash string
+	Scopes     []string
+	IsActive   bool
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
@@ -17,4 +17,21 @@ type Session struct {
 	LastUsedAt        time.Time
 	Revoked           bool
 	CreatedAt         time.Time
+	// Местоположение по IPAddress; nil, если GeoIP база не подключена
+	Location *Location
+}
+
+// SessionIP — адрес, с которого использовалась сессия (история входов и refresh).
+type SessionIP struct {
+	SessionID   uuid.UUID
+	IPAddress   string
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+	Location    *Location
+}
+
+// Location — результат поиска IP в локальной GeoIP базе.
+type Location struct {
+	Country string
+	City    string
 }
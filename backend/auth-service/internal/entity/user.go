@@ -16,4 +16,8 @@ type User struct {
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	Roles        []Role
+	// Эффективные права с учётом ролей в коворкингах
+	Permissions []Permission
+	// Группы пользователя (claim groups)
+	GroupIDs []uuid.UUID
 }
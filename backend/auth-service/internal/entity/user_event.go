@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// События жизненного цикла пользователя. Outbox worker публикует их
+// в auth.events с префиксом "auth." (auth.user.registered и т.д.).
+const (
+	UserEventRegistered         = "user.registered"
+	UserEventUpdated            = "user.updated"
+	UserEventDeactivated        = "user.deactivated"
+	UserEventRolesChanged       = "user.roles_changed"
+	UserEventPermissionsChanged = "user.permissions_changed"
+)
+
+func NewUserEvent(userID uuid.UUID, eventType string, payload map[string]any) OutboxEvent {
+	return OutboxEvent{
+		AggregateType: "auth",
+		AggregateID:   userID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        OutboxStatus{ID: 1, Name: OutboxStatusPending},
+		CreatedAt:     time.Now(),
+	}
+}
+
+// UserProfilePayload — снимок профиля для user.registered и user.updated,
+// чтобы потребителям не приходилось ходить в auth-service за деталями.
+func UserProfilePayload(user User) map[string]any {
+	return map[string]any{
+		"userId":    user.ID,
+		"email":     user.Email,
+		"firstName": user.FirstName,
+		"lastName":  user.LastName,
+		"isActive":  user.IsActive,
+		"roles":     RoleCodes(user.Roles),
+	}
+}
+
+func RoleCodes(roles []Role) []string {
+	codes := make([]string, 0, len(roles))
+	for _, r := range roles {
+		codes = append(codes, string(r.Code))
+	}
+	return codes
+}
@@ -0,0 +1,90 @@
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/google/uuid"
+)
+
+// ClientID, с которым auth-service подписывает свои сервисные токены.
+const ClientID = "auth-service"
+
+// максимальный размер ответа одного сервиса
+const maxResponseSize = 16 << 20
+
+type TokenIssuer interface {
+	GenerateServiceToken(clientID string, scopes []string) (*auth.ServiceToken, error)
+}
+
+/*
+Source — часть экспорта данных пользователя, хранящаяся в другом сервисе.
+
+Сервис отдаёт её на GET <baseURL>/internal/users/<userId>/export под
+ServiceOnly(users.export). Токен auth-service выпускает сам себе: он же
+издатель, поэтому регистрировать клиента не нужно.
+*/
+type Source struct {
+	name    string
+	baseURL string
+	issuer  TokenIssuer
+	client  *http.Client
+}
+
+func New(name string, baseURL string, issuer TokenIssuer, timeout time.Duration) *Source {
+	return &Source{
+		name:    name,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		issuer:  issuer,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *Source) Name() string {
+	return s.name
+}
+
+func (s *Source) ExportUserData(ctx context.Context, userID uuid.UUID) (json.RawMessage, error) {
+	token, err := s.issuer.GenerateServiceToken(ClientID, []string{jwt_validator.ScopeUsersExport})
+	if err != nil {
+		return nil, fmt.Errorf("issue service token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/internal/users/%s/export", s.baseURL, userID),
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s export: %w", s.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s export: unexpected status %d", s.name, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("%s export: read body: %w", s.name, err)
+	}
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("%s export: invalid json", s.name)
+	}
+
+	return body, nil
+}
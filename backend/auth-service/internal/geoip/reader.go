@@ -0,0 +1,76 @@
+package geoip
+
+import (
+	"net"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/oschwald/geoip2-golang"
+)
+
+/*
+Reader — поиск местоположения по IP в локальной MMDB базе (GeoLite2-City и совместимые).
+
+База читается с диска один раз при старте, сетевых запросов нет. Если путь к базе
+не задан, Reader работает в пустом режиме и Lookup всегда возвращает nil.
+*/
+type Reader struct {
+	db     *geoip2.Reader
+	locale string
+}
+
+func Open(path string, locale string) (*Reader, error) {
+	if path == "" {
+		return &Reader{}, nil
+	}
+
+	db, err := geoip2.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if locale == "" {
+		locale = "en"
+	}
+
+	return &Reader{db: db, locale: locale}, nil
+}
+
+// Lookup возвращает страну и город для IP; nil, если база не подключена
+// или адрес в ней не найден (приватные сети, localhost).
+func (r *Reader) Lookup(ip string) *entity.Location {
+	if r == nil || r.db == nil {
+		return nil
+	}
+
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil
+	}
+
+	record, err := r.db.City(parsed)
+	if err != nil || record.Country.IsoCode == "" {
+		return nil
+	}
+
+	return &entity.Location{
+		Country: name(record.Country.Names, r.locale, record.Country.IsoCode),
+		City:    name(record.City.Names, r.locale, ""),
+	}
+}
+
+func (r *Reader) Close() error {
+	if r == nil || r.db == nil {
+		return nil
+	}
+	return r.db.Close()
+}
+
+func name(names map[string]string, locale string, fallback string) string {
+	if n, ok := names[locale]; ok {
+		return n
+	}
+	if n, ok := names["en"]; ok {
+		return n
+	}
+	return fallback
+}
@@ -0,0 +1,165 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrMissingIDToken = errors.New("token response has no id_token")
+	ErrInvalidIDToken = errors.New("invalid id_token")
+	ErrNonceMismatch  = errors.New("id_token nonce mismatch")
+	ErrCodeExchange   = errors.New("authorization code exchange failed")
+)
+
+// Identity — данные пользователя из проверенного ID token.
+type Identity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+	Groups        []string
+}
+
+/*
+Provider — OIDC relying party (authorization code flow + PKCE).
+
+При создании выполняет discovery (/.well-known/openid-configuration),
+ключи подписи ID token загружаются из jwks_uri и кешируются go-oidc.
+*/
+type Provider struct {
+	oauth2      oauth2.Config
+	verifier    *gooidc.IDTokenVerifier
+	groupsClaim string
+}
+
+func New(
+	ctx context.Context,
+	issuerURL string,
+	clientID string,
+	clientSecret string,
+	redirectURL string,
+	scopes []string,
+	groupsClaim string,
+) (*Provider, error) {
+
+	provider, err := gooidc.NewProvider(ctx, issuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+
+	if len(scopes) == 0 {
+		scopes = []string{gooidc.ScopeOpenID, "email", "profile"}
+	}
+
+	return &Provider{
+		oauth2: oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       scopes,
+		},
+		verifier:    provider.Verifier(&gooidc.Config{ClientID: clientID}),
+		groupsClaim: groupsClaim,
+	}, nil
+}
+
+// AuthCodeURL возвращает URL страницы входа IdP.
+// codeVerifier передаётся в IdP только как S256 challenge.
+func (p *Provider) AuthCodeURL(state, nonce, codeVerifier string) string {
+	return p.oauth2.AuthCodeURL(
+		state,
+		gooidc.Nonce(nonce),
+		oauth2.S256ChallengeOption(codeVerifier),
+	)
+}
+
+// Exchange обменивает authorization code на токены и проверяет ID token:
+// подпись (JWKS), iss, aud, exp и nonce.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Identity, error) {
+	token, err := p.oauth2.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCodeExchange, err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, ErrMissingIDToken
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if idToken.Nonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		GivenName     string `json:"given_name"`
+		FamilyName    string `json:"family_name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	var raw map[string]any
+	if err := idToken.Claims(&raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	return &Identity{
+		Issuer:        idToken.Issuer,
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		FirstName:     claims.GivenName,
+		LastName:      claims.FamilyName,
+		Groups:        stringList(raw[p.groupsClaim]),
+	}, nil
+}
+
+// stringList приводит claim групп к []string.
+// IdP отдают его либо массивом, либо одной строкой.
+func stringList(v any) []string {
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		out := make([]string, 0, len(t))
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// GenerateVerifier возвращает PKCE code_verifier (RFC 7636).
+func GenerateVerifier() string {
+	return oauth2.GenerateVerifier()
+}
+
+// RandomString возвращает криптографически случайную строку для state и nonce.
+func RandomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
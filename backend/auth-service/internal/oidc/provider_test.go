@@ -0,0 +1,110 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/4udiwe/coworking/auth-service/internal/oidc"
+	"github.com/4udiwe/coworking/auth-service/internal/oidc/oidctest"
+)
+
+const redirectURL = "http://localhost:3000/auth/sso/callback"
+
+// authorize проходит authorize endpoint мок-провайдера и возвращает выданный code.
+func authorize(t *testing.T, authURL string) (code, state string) {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	user := oidctest.User{
+		Subject:       "s12345",
+		Email:         "student@university.edu",
+		EmailVerified: true,
+		GivenName:     "Ivan",
+		FamilyName:    "Petrov",
+		Groups:        []string{"students", "library"},
+	}
+
+	idp := oidctest.NewServer("coworking", "secret", user)
+	defer idp.Close()
+
+	ctx := context.Background()
+
+	provider, err := oidc.New(ctx, idp.Issuer(), "coworking", "secret", redirectURL, nil, "groups")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		verifier    func(original string) string
+		nonce       func(original string) string
+		expectedErr error
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:        "wrong pkce verifier",
+			verifier:    func(string) string { return oidc.GenerateVerifier() },
+			expectedErr: oidc.ErrCodeExchange,
+		},
+		{
+			name:        "nonce mismatch",
+			nonce:       func(string) string { return "other" },
+			expectedErr: oidc.ErrNonceMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := oidc.RandomString()
+			require.NoError(t, err)
+			nonce, err := oidc.RandomString()
+			require.NoError(t, err)
+			verifier := oidc.GenerateVerifier()
+
+			code, returnedState := authorize(t, provider.AuthCodeURL(state, nonce, verifier))
+			require.Equal(t, state, returnedState)
+
+			if tt.verifier != nil {
+				verifier = tt.verifier(verifier)
+			}
+			if tt.nonce != nil {
+				nonce = tt.nonce(nonce)
+			}
+
+			identity, err := provider.Exchange(ctx, code, verifier, nonce)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, idp.Issuer(), identity.Issuer)
+			require.Equal(t, user.Subject, identity.Subject)
+			require.Equal(t, user.Email, identity.Email)
+			require.True(t, identity.EmailVerified)
+			require.Equal(t, user.GivenName, identity.FirstName)
+			require.Equal(t, user.FamilyName, identity.LastName)
+			require.Equal(t, user.Groups, identity.Groups)
+		})
+	}
+}
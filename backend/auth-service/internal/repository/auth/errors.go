@@ -4,4 +4,5 @@ import "errors"
 
 var (
 	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrSessionNotFound     = errors.New("session not found")
 )
@@ -47,8 +47,11 @@ func (r *AuthRepository) CreateSession(
 		).
 		ToSql()
 
-	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
-	return err
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		return err
+	}
+
+	return r.touchSessionIP(ctx, session.ID, session.IPAddress)
 }
 
 func (r *AuthRepository) GetSessionByID(
@@ -184,59 +187,149 @@ func (r *AuthRepository) GetUserSessions(
 	return sessions, nil
 }
 
-// DeleteOldestSessionByUser revokes the oldest active (non-revoked) session for a user
-//
-// PURPOSE:
-// Enforces MAX_SESSIONS_PER_USER limit. When a user exceeds the limit,
-// this method finds and revokes their oldest session to make room for a new one.
-//
-// HOW IT WORKS:
-// 1. Find the oldest non-revoked session (WHERE revoked = false)
-// 2. Sort by created_at ASC (oldest first)
-// 3. Revoke that session (SET revoked = true)
-//
-// EXAMPLE:
-// User has 5 active sessions (at limit):
-//   [NEW]    iPhone 2026-03-30 10:00  ← oldest
-//   Web      2026-03-25 14:30
-//   iPad     2026-03-28 09:15
-//   MacBook  2026-03-28 16:45
-//   Android  2026-03-29 12:00        ← newest
-//
-// When user logins on 6th device (Windows):
-//   → This method finds iPhone session
-//   → Revokes it (created_at = 2026-03-30)
-//   → Now there are 4 active + 1 new = 5 total
+// RevokeOldestSessions отзывает n самых старых активных сессий пользователя
+// и возвращает их ID, чтобы вызывающий опубликовал auth.session.revoked.
 //
-// IMPORTANT: Returns nil if no active sessions found (no error!)
-func (r *AuthRepository) DeleteOldestSessionByUser(
+// Используется политикой лимита сессий: при входе сверх лимита освобождается
+// ровно столько мест, сколько нужно для новой сессии.
+func (r *AuthRepository) RevokeOldestSessions(
 	ctx context.Context,
 	userID uuid.UUID,
-) error {
-	// Step 1: Find the oldest non-revoked session
+	n int,
+) ([]uuid.UUID, error) {
+
 	query, args, _ := r.Builder.
-		Select("id").
-		From("refresh_tokens").
+		Update("refresh_tokens").
+		Set("revoked", true).
+		Where(`id IN (
+			SELECT id FROM refresh_tokens
+			WHERE user_id = ? AND revoked = false AND expires_at > now()
+			ORDER BY created_at ASC
+			LIMIT ?
+		)`, userID, n).
+		Suffix("RETURNING id").
+		ToSql()
+
+	return r.collectIDs(ctx, query, args...)
+}
+
+// RevokeUserSessionsExcept отзывает все активные сессии пользователя, кроме keepID
+// ("выйти на всех остальных устройствах"), и возвращает ID отозванных.
+func (r *AuthRepository) RevokeUserSessionsExcept(
+	ctx context.Context,
+	userID uuid.UUID,
+	keepID uuid.UUID,
+) ([]uuid.UUID, error) {
+
+	query, args, _ := r.Builder.
+		Update("refresh_tokens").
+		Set("revoked", true).
 		Where("user_id = ?", userID).
-		Where("revoked = false").  // Only active sessions
-		OrderBy("created_at ASC"). // Order by oldest first
-		Limit(1).                  // Get only the first one
+		Where("id <> ?", keepID).
+		Where("revoked = false").
+		Where("expires_at > now()").
+		Suffix("RETURNING id").
 		ToSql()
 
-	// Step 2: Execute query to get session ID
-	var oldestSessionID uuid.UUID
-	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&oldestSessionID)
+	return r.collectIDs(ctx, query, args...)
+}
+
+func (r *AuthRepository) collectIDs(ctx context.Context, query string, args ...any) ([]uuid.UUID, error) {
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
 	if err != nil {
-		// If no rows found = no active sessions = OK (not an error)
-		if err.Error() == "no rows in result set" {
-			return nil
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
 		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// UpdateDeviceName задаёт пользовательское название устройства сессии.
+// Чужая или несуществующая сессия — ErrSessionNotFound.
+func (r *AuthRepository) UpdateDeviceName(
+	ctx context.Context,
+	userID uuid.UUID,
+	sessionID uuid.UUID,
+	deviceName string,
+) error {
+
+	query, args, _ := r.Builder.
+		Update("refresh_tokens").
+		Set("device_name", deviceName).
+		Where("id = ?", sessionID).
+		Where("user_id = ?", userID).
+		ToSql()
+
+	result, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
 		return err
 	}
+	if result.RowsAffected() == 0 {
+		return ErrSessionNotFound
+	}
 
-	// Step 3: Revoke the oldest session
-	// This just calls RevokeSession() which sets revoked = true
-	return r.RevokeSession(ctx, oldestSessionID)
+	return nil
+}
+
+// touchSessionIP добавляет адрес в историю сессии или обновляет время
+// последнего использования, если адрес уже встречался.
+func (r *AuthRepository) touchSessionIP(
+	ctx context.Context,
+	sessionID uuid.UUID,
+	ip string,
+) error {
+
+	now := time.Now()
+
+	query, args, _ := r.Builder.
+		Insert("session_ips").
+		Columns("session_id", "ip_address", "first_seen_at", "last_seen_at").
+		Values(sessionID, ip, now, now).
+		Suffix("ON CONFLICT (session_id, ip_address) DO UPDATE SET last_seen_at = EXCLUDED.last_seen_at").
+		ToSql()
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	return err
+}
+
+// GetSessionIPs возвращает историю адресов сессии, последние — первыми.
+func (r *AuthRepository) GetSessionIPs(
+	ctx context.Context,
+	sessionID uuid.UUID,
+) ([]entity.SessionIP, error) {
+
+	query, args, _ := r.Builder.
+		Select("session_id", "ip_address", "first_seen_at", "last_seen_at").
+		From("session_ips").
+		Where("session_id = ?", sessionID).
+		OrderBy("last_seen_at DESC").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ips []entity.SessionIP
+	for rows.Next() {
+		var ip entity.SessionIP
+		if err := rows.Scan(&ip.SessionID, &ip.IPAddress, &ip.FirstSeenAt, &ip.LastSeenAt); err != nil {
+			return nil, err
+		}
+		ips = append(ips, ip)
+	}
+
+	return ips, rows.Err()
 }
 
 // GetSessionByDeviceFingerprint finds an active session by device fingerprint and user ID
@@ -324,6 +417,7 @@ func (r *AuthRepository) GetSessionByDeviceFingerprint(
 // 1. Update token_hash to the new token value (old token becomes invalid)
 // 2. Update expires_at to extend expiration
 // 3. Update last_used_at to now() (shows when last used)
+//    and ip_address to the current address (previous ones stay in session_ips)
 // 4. Keep id and created_at unchanged (maintains session identity and original creation time)
 //
 // IMPORTANT:
@@ -353,6 +447,7 @@ func (r *AuthRepository) UpdateSessionRefresh(
 	sessionID uuid.UUID,
 	newTokenHash string,
 	newExpiresAt time.Time,
+	ip string,
 ) error {
 
 	query, args, _ := r.Builder.
@@ -360,11 +455,15 @@ func (r *AuthRepository) UpdateSessionRefresh(
 		Set("token_hash", newTokenHash).
 		Set("expires_at", newExpiresAt).
 		Set("last_used_at", time.Now()).
+		Set("ip_address", ip).
 		Where("id = ?", sessionID).
 		ToSql()
 
-	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
-	return err
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		return err
+	}
+
+	return r.touchSessionIP(ctx, sessionID, ip)
 }
 
 // DeleteOldRevokedSessions удаляет revoked сессии старше заданного количества дней
@@ -0,0 +1,8 @@
+package client_repository
+
+import "errors"
+
+var (
+	ErrClientNotFound      = errors.New("service client not found")
+	ErrClientAlreadyExists = errors.New("service client already exists")
+)
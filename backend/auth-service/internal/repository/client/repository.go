@@ -0,0 +1,182 @@
+package client_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+)
+
+type ClientRepository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *ClientRepository {
+	return &ClientRepository{pg}
+}
+
+var clientColumns = []string{
+	"id", "client_id", "name", "secret_hash", "scopes", "is_active", "created_at", "last_used_at",
+}
+
+func (r *ClientRepository) Create(
+	ctx context.Context,
+	client entity.ServiceClient,
+) (entity.ServiceClient, error) {
+
+	logrus.Infof("Creating service client %s", client.ClientID)
+
+	query, args, _ := r.Builder.
+		Insert("service_clients").
+		Columns("client_id", "name", "secret_hash", "scopes", "is_active").
+		Values(client.ClientID, client.Name, client.SecretHash, client.Scopes, client.IsActive).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
+		&client.ID,
+		&client.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return entity.ServiceClient{}, ErrClientAlreadyExists
+		}
+		logrus.WithError(err).WithField("client_id", client.ClientID).Error("Create client: query failed")
+		return entity.ServiceClient{}, fmt.Errorf("create service client: %w", err)
+	}
+
+	return client, nil
+}
+
+// Upsert создаёт клиента или обновляет секрет, scopes и активность существующего.
+// Используется для клиентов, описанных в конфиге.
+func (r *ClientRepository) Upsert(
+	ctx context.Context,
+	client entity.ServiceClient,
+) error {
+
+	query, args, _ := r.Builder.
+		Insert("service_clients").
+		Columns("client_id", "name", "secret_hash", "scopes", "is_active").
+		Values(client.ClientID, client.Name, client.SecretHash, client.Scopes, client.IsActive).
+		Suffix(`ON CONFLICT (client_id) DO UPDATE SET
+			name = EXCLUDED.name,
+			secret_hash = EXCLUDED.secret_hash,
+			scopes = EXCLUDED.scopes,
+			is_active = EXCLUDED.is_active`).
+		ToSql()
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		logrus.WithError(err).WithField("client_id", client.ClientID).Error("Upsert client: query failed")
+		return fmt.Errorf("upsert service client: %w", err)
+	}
+	return nil
+}
+
+func (r *ClientRepository) GetByClientID(
+	ctx context.Context,
+	clientID string,
+) (entity.ServiceClient, error) {
+
+	query, args, _ := r.Builder.
+		Select(clientColumns...).
+		From("service_clients").
+		Where("client_id = ?", clientID).
+		ToSql()
+
+	var c entity.ServiceClient
+	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
+		&c.ID,
+		&c.ClientID,
+		&c.Name,
+		&c.SecretHash,
+		&c.Scopes,
+		&c.IsActive,
+		&c.CreatedAt,
+		&c.LastUsedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ServiceClient{}, ErrClientNotFound
+		}
+		logrus.WithError(err).WithField("client_id", clientID).Error("GetByClientID: query failed")
+		return entity.ServiceClient{}, fmt.Errorf("get service client: %w", err)
+	}
+
+	return c, nil
+}
+
+func (r *ClientRepository) List(ctx context.Context) ([]entity.ServiceClient, error) {
+	query, args, _ := r.Builder.
+		Select(clientColumns...).
+		From("service_clients").
+		OrderBy("client_id").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).Error("List clients: query failed")
+		return nil, fmt.Errorf("list service clients: %w", err)
+	}
+	defer rows.Close()
+
+	var clients []entity.ServiceClient
+	for rows.Next() {
+		var c entity.ServiceClient
+		if err := rows.Scan(
+			&c.ID,
+			&c.ClientID,
+			&c.Name,
+			&c.SecretHash,
+			&c.Scopes,
+			&c.IsActive,
+			&c.CreatedAt,
+			&c.LastUsedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan service client: %w", err)
+		}
+		clients = append(clients, c)
+	}
+
+	return clients, rows.Err()
+}
+
+func (r *ClientRepository) SetActive(ctx context.Context, clientID string, active bool) error {
+	query, args, _ := r.Builder.
+		Update("service_clients").
+		Set("is_active", active).
+		Where("client_id = ?", clientID).
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("client_id", clientID).Error("SetActive client: query failed")
+		return fmt.Errorf("set service client active: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrClientNotFound
+	}
+	return nil
+}
+
+func (r *ClientRepository) TouchLastUsed(ctx context.Context, clientID string) error {
+	query, args, _ := r.Builder.
+		Update("service_clients").
+		Set("last_used_at", time.Now()).
+		Where("client_id = ?", clientID).
+		ToSql()
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		logrus.WithError(err).WithField("client_id", clientID).Error("TouchLastUsed: query failed")
+		return fmt.Errorf("touch service client: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,5 @@
+package email_change_repository
+
+import "errors"
+
+var ErrRequestNotFound = errors.New("email change request not found")
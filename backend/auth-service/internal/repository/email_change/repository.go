@@ -0,0 +1,86 @@
+package email_change_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type EmailChangeRepository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *EmailChangeRepository {
+	return &EmailChangeRepository{pg}
+}
+
+// Create сохраняет запрос на смену email. Предыдущие незавершённые запросы
+// пользователя удаляются: действует только последняя ссылка.
+func (r *EmailChangeRepository) Create(
+	ctx context.Context,
+	req entity.EmailChangeRequest,
+	tokenHash string,
+) (entity.EmailChangeRequest, error) {
+
+	cleanup, cleanupArgs, _ := r.Builder.
+		Delete("email_change_requests").
+		Where("user_id = ?", req.UserID).
+		ToSql()
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, cleanup, cleanupArgs...); err != nil {
+		logrus.WithError(err).WithField("user_id", req.UserID).Error("Create email change: cleanup failed")
+		return entity.EmailChangeRequest{}, fmt.Errorf("delete previous email change: %w", err)
+	}
+
+	query, args, _ := r.Builder.
+		Insert("email_change_requests").
+		Columns("user_id", "new_email", "token_hash", "expires_at").
+		Values(req.UserID, req.NewEmail, tokenHash, req.ExpiresAt).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	if err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&req.ID, &req.CreatedAt); err != nil {
+		logrus.WithError(err).WithField("user_id", req.UserID).Error("Create email change: query failed")
+		return entity.EmailChangeRequest{}, fmt.Errorf("create email change: %w", err)
+	}
+
+	return req, nil
+}
+
+// Consume атомарно удаляет и возвращает непросроченный запрос по хешу токена.
+func (r *EmailChangeRepository) Consume(
+	ctx context.Context,
+	tokenHash string,
+) (entity.EmailChangeRequest, error) {
+
+	query, args, _ := r.Builder.
+		Delete("email_change_requests").
+		Where("token_hash = ?", tokenHash).
+		Where("expires_at > ?", time.Now()).
+		Suffix("RETURNING id, user_id, new_email, expires_at, created_at").
+		ToSql()
+
+	var req entity.EmailChangeRequest
+	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
+		&req.ID,
+		&req.UserID,
+		&req.NewEmail,
+		&req.ExpiresAt,
+		&req.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.EmailChangeRequest{}, ErrRequestNotFound
+		}
+		logrus.WithError(err).Error("Consume email change: query failed")
+		return entity.EmailChangeRequest{}, fmt.Errorf("consume email change: %w", err)
+	}
+
+	return req, nil
+}
@@ -0,0 +1,10 @@
+package group_repository
+
+import "errors"
+
+var (
+	ErrGroupNotFound      = errors.New("group not found")
+	ErrGroupAlreadyExists = errors.New("group already exists")
+	ErrMemberNotFound     = errors.New("group member not found")
+	ErrUserNotFound       = errors.New("user not found")
+)
@@ -0,0 +1,270 @@
+package group_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+)
+
+type GroupRepository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *GroupRepository {
+	return &GroupRepository{pg}
+}
+
+var groupColumns = []string{
+	"g.id",
+	"g.name",
+	"g.description",
+	"(SELECT count(*) FROM user_group_members m WHERE m.group_id = g.id)",
+	"g.created_at",
+	"g.updated_at",
+}
+
+func (r *GroupRepository) Create(ctx context.Context, group entity.Group) (entity.Group, error) {
+	logrus.Infof("Creating group %s", group.Name)
+
+	query, args, _ := r.Builder.
+		Insert("user_groups").
+		Columns("name", "description").
+		Values(group.Name, group.Description).
+		Suffix("RETURNING id, created_at, updated_at").
+		ToSql()
+
+	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
+		&group.ID,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.Group{}, ErrGroupAlreadyExists
+		}
+		logrus.WithError(err).WithField("name", group.Name).Error("Create group: query failed")
+		return entity.Group{}, fmt.Errorf("create group: %w", err)
+	}
+
+	return group, nil
+}
+
+func (r *GroupRepository) GetByID(ctx context.Context, groupID uuid.UUID) (entity.Group, error) {
+	query, args, _ := r.Builder.
+		Select(groupColumns...).
+		From("user_groups g").
+		Where("g.id = ?", groupID).
+		ToSql()
+
+	var g entity.Group
+	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
+		&g.ID,
+		&g.Name,
+		&g.Description,
+		&g.MemberCount,
+		&g.CreatedAt,
+		&g.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Group{}, ErrGroupNotFound
+		}
+		logrus.WithError(err).WithField("group_id", groupID).Error("GetByID group: query failed")
+		return entity.Group{}, fmt.Errorf("get group: %w", err)
+	}
+
+	return g, nil
+}
+
+func (r *GroupRepository) List(ctx context.Context) ([]entity.Group, error) {
+	query, args, _ := r.Builder.
+		Select(groupColumns...).
+		From("user_groups g").
+		OrderBy("g.name").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).Error("List groups: query failed")
+		return nil, fmt.Errorf("list groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []entity.Group
+	for rows.Next() {
+		var g entity.Group
+		if err := rows.Scan(
+			&g.ID,
+			&g.Name,
+			&g.Description,
+			&g.MemberCount,
+			&g.CreatedAt,
+			&g.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan group: %w", err)
+		}
+		groups = append(groups, g)
+	}
+
+	return groups, rows.Err()
+}
+
+func (r *GroupRepository) Update(ctx context.Context, group entity.Group) error {
+	query, args, _ := r.Builder.
+		Update("user_groups").
+		Set("name", group.Name).
+		Set("description", group.Description).
+		Set("updated_at", time.Now()).
+		Where("id = ?", group.ID).
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrGroupAlreadyExists
+		}
+		logrus.WithError(err).WithField("group_id", group.ID).Error("Update group: query failed")
+		return fmt.Errorf("update group: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrGroupNotFound
+	}
+	return nil
+}
+
+// Delete удаляет группу вместе с членством (ON DELETE CASCADE).
+func (r *GroupRepository) Delete(ctx context.Context, groupID uuid.UUID) error {
+	query, args, _ := r.Builder.
+		Delete("user_groups").
+		Where("id = ?", groupID).
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("group_id", groupID).Error("Delete group: query failed")
+		return fmt.Errorf("delete group: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrGroupNotFound
+	}
+	return nil
+}
+
+// AddMembers добавляет пользователей в группу и возвращает тех, кого в ней
+// ещё не было. Неизвестный пользователь — ErrUserNotFound.
+func (r *GroupRepository) AddMembers(
+	ctx context.Context,
+	groupID uuid.UUID,
+	userIDs []uuid.UUID,
+) ([]uuid.UUID, error) {
+
+	logrus.Infof("Adding %d members to group %s", len(userIDs), groupID)
+
+	const query = `
+		INSERT INTO user_group_members (group_id, user_id)
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT DO NOTHING
+		RETURNING user_id
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, groupID, userIDs)
+	if err != nil {
+		logrus.WithError(err).WithField("group_id", groupID).Error("AddMembers: query failed")
+		return nil, fmt.Errorf("add group members: %w", err)
+	}
+	defer rows.Close()
+
+	var added []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan group member: %w", err)
+		}
+		added = append(added, id)
+	}
+
+	// Нарушение внешнего ключа приходит при чтении результата
+	if err := rows.Err(); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			if pgErr.ConstraintName == "user_group_members_group_id_fkey" {
+				return nil, ErrGroupNotFound
+			}
+			return nil, ErrUserNotFound
+		}
+		logrus.WithError(err).WithField("group_id", groupID).Error("AddMembers: query failed")
+		return nil, fmt.Errorf("add group members: %w", err)
+	}
+
+	return added, nil
+}
+
+func (r *GroupRepository) RemoveMember(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) error {
+	query, args, _ := r.Builder.
+		Delete("user_group_members").
+		Where("group_id = ? AND user_id = ?", groupID, userID).
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).
+			WithFields(logrus.Fields{"group_id": groupID, "user_id": userID}).
+			Error("RemoveMember: query failed")
+		return fmt.Errorf("remove group member: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrMemberNotFound
+	}
+	return nil
+}
+
+// ListMembers возвращает участников группы без ролей и прав.
+func (r *GroupRepository) ListMembers(ctx context.Context, groupID uuid.UUID) ([]entity.User, error) {
+	query, args, _ := r.Builder.
+		Select("u.id", "u.first_name", "u.last_name", "u.email", "u.is_active", "u.created_at", "u.updated_at").
+		From("user_group_members m").
+		Join("users u ON u.id = m.user_id").
+		Where("m.group_id = ?", groupID).
+		OrderBy("u.last_name", "u.first_name").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("group_id", groupID).Error("ListMembers: query failed")
+		return nil, fmt.Errorf("list group members: %w", err)
+	}
+	defer rows.Close()
+
+	var users []entity.User
+	for rows.Next() {
+		var u entity.User
+		if err := rows.Scan(
+			&u.ID,
+			&u.FirstName,
+			&u.LastName,
+			&u.Email,
+			&u.IsActive,
+			&u.CreatedAt,
+			&u.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan group member: %w", err)
+		}
+		users = append(users, u)
+	}
+
+	return users, rows.Err()
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation
+}
@@ -0,0 +1,9 @@
+package identity_repository
+
+import "errors"
+
+var (
+	ErrIdentityNotFound      = errors.New("identity not found")
+	ErrIdentityAlreadyExists = errors.New("identity already linked")
+	ErrStateNotFound         = errors.New("login state not found or expired")
+)
@@ -0,0 +1,218 @@
+package identity_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+)
+
+type IdentityRepository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *IdentityRepository {
+	return &IdentityRepository{pg}
+}
+
+func (r *IdentityRepository) SaveState(
+	ctx context.Context,
+	state entity.OIDCLoginState,
+) error {
+
+	query, args, _ := r.Builder.
+		Insert("oidc_login_states").
+		Columns("state", "code_verifier", "nonce", "browser_key_hash", "link_user_id", "expires_at").
+		Values(state.State, state.CodeVerifier, state.Nonce, state.BrowserKeyHash, state.LinkUserID, state.ExpiresAt).
+		ToSql()
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		logrus.WithError(err).Error("SaveState: query failed")
+		return fmt.Errorf("save oidc state: %w", err)
+	}
+
+	// Заодно чистим брошенные попытки входа
+	cleanup, cleanupArgs, _ := r.Builder.
+		Delete("oidc_login_states").
+		Where("expires_at <= ?", time.Now()).
+		ToSql()
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, cleanup, cleanupArgs...); err != nil {
+		logrus.WithError(err).Warn("SaveState: failed to purge expired states")
+	}
+
+	return nil
+}
+
+// ConsumeState атомарно удаляет и возвращает непросроченное состояние.
+// Повторный callback с тем же state получит ErrStateNotFound.
+func (r *IdentityRepository) ConsumeState(
+	ctx context.Context,
+	state string,
+) (entity.OIDCLoginState, error) {
+
+	query, args, _ := r.Builder.
+		Delete("oidc_login_states").
+		Where("state = ?", state).
+		Where("expires_at > ?", time.Now()).
+		Suffix("RETURNING state, code_verifier, nonce, browser_key_hash, link_user_id, expires_at").
+		ToSql()
+
+	var s entity.OIDCLoginState
+	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
+		&s.State,
+		&s.CodeVerifier,
+		&s.Nonce,
+		&s.BrowserKeyHash,
+		&s.LinkUserID,
+		&s.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.OIDCLoginState{}, ErrStateNotFound
+		}
+		logrus.WithError(err).Error("ConsumeState: query failed")
+		return entity.OIDCLoginState{}, fmt.Errorf("consume oidc state: %w", err)
+	}
+
+	return s, nil
+}
+
+func (r *IdentityRepository) GetBySubject(
+	ctx context.Context,
+	issuer string,
+	subject string,
+) (entity.UserIdentity, error) {
+
+	query, args, _ := r.Builder.
+		Select("id", "user_id", "issuer", "subject", "email", "created_at", "last_login_at").
+		From("user_identities").
+		Where("issuer = ?", issuer).
+		Where("subject = ?", subject).
+		ToSql()
+
+	var i entity.UserIdentity
+	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Issuer,
+		&i.Subject,
+		&i.Email,
+		&i.CreatedAt,
+		&i.LastLoginAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.UserIdentity{}, ErrIdentityNotFound
+		}
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"issuer":  issuer,
+			"subject": subject,
+		}).Error("GetBySubject: query failed")
+		return entity.UserIdentity{}, fmt.Errorf("get identity: %w", err)
+	}
+
+	return i, nil
+}
+
+func (r *IdentityRepository) Create(
+	ctx context.Context,
+	identity entity.UserIdentity,
+) (entity.UserIdentity, error) {
+
+	logrus.Infof("Linking identity %s/%s to user %s", identity.Issuer, identity.Subject, identity.UserID)
+
+	query, args, _ := r.Builder.
+		Insert("user_identities").
+		Columns("user_id", "issuer", "subject", "email").
+		Values(identity.UserID, identity.Issuer, identity.Subject, identity.Email).
+		Suffix("RETURNING id, created_at, last_login_at").
+		ToSql()
+
+	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
+		&identity.ID,
+		&identity.CreatedAt,
+		&identity.LastLoginAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return entity.UserIdentity{}, ErrIdentityAlreadyExists
+		}
+		logrus.WithError(err).WithField("user_id", identity.UserID).Error("Create identity: query failed")
+		return entity.UserIdentity{}, fmt.Errorf("create identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+func (r *IdentityRepository) TouchLastLogin(
+	ctx context.Context,
+	id uuid.UUID,
+	email *string,
+) error {
+
+	query, args, _ := r.Builder.
+		Update("user_identities").
+		Set("last_login_at", time.Now()).
+		Set("email", email).
+		Where("id = ?", id).
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("identity_id", id).Error("TouchLastLogin: query failed")
+		return fmt.Errorf("touch identity: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrIdentityNotFound
+	}
+	return nil
+}
+
+func (r *IdentityRepository) ListByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]entity.UserIdentity, error) {
+
+	query, args, _ := r.Builder.
+		Select("id", "user_id", "issuer", "subject", "email", "created_at", "last_login_at").
+		From("user_identities").
+		Where("user_id = ?", userID).
+		OrderBy("created_at").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("ListByUser: query failed")
+		return nil, fmt.Errorf("list identities: %w", err)
+	}
+	defer rows.Close()
+
+	var identities []entity.UserIdentity
+	for rows.Next() {
+		var i entity.UserIdentity
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Issuer,
+			&i.Subject,
+			&i.Email,
+			&i.CreatedAt,
+			&i.LastLoginAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan identity: %w", err)
+		}
+		identities = append(identities, i)
+	}
+
+	return identities, rows.Err()
+}
@@ -0,0 +1,7 @@
+package impersonation_repository
+
+import "errors"
+
+var (
+	ErrImpersonationNotFound = errors.New("impersonation not found")
+)
@@ -0,0 +1,136 @@
+package impersonation_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type ImpersonationRepository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *ImpersonationRepository {
+	return &ImpersonationRepository{pg}
+}
+
+var impersonationColumns = []string{
+	"id", "actor_id", "user_id", "reason", "allow_write", "expires_at", "created_at", "ended_at",
+}
+
+func scanImpersonation(row pgx.Row) (entity.Impersonation, error) {
+	var i entity.Impersonation
+	err := row.Scan(
+		&i.ID,
+		&i.ActorID,
+		&i.UserID,
+		&i.Reason,
+		&i.AllowWrite,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.EndedAt,
+	)
+	return i, err
+}
+
+func (r *ImpersonationRepository) Create(
+	ctx context.Context,
+	imp entity.Impersonation,
+) (entity.Impersonation, error) {
+
+	logrus.Infof("Creating impersonation of user %s by %s", imp.UserID, imp.ActorID)
+
+	query, args, _ := r.Builder.
+		Insert("impersonations").
+		Columns("actor_id", "user_id", "reason", "allow_write", "expires_at").
+		Values(imp.ActorID, imp.UserID, imp.Reason, imp.AllowWrite, imp.ExpiresAt).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	if err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&imp.ID, &imp.CreatedAt); err != nil {
+		logrus.WithError(err).WithField("user_id", imp.UserID).Error("Create impersonation: query failed")
+		return entity.Impersonation{}, fmt.Errorf("create impersonation: %w", err)
+	}
+
+	return imp, nil
+}
+
+// List возвращает последние выдачи, новые первыми.
+// Фильтры actorID и userID необязательны.
+func (r *ImpersonationRepository) List(
+	ctx context.Context,
+	actorID *uuid.UUID,
+	userID *uuid.UUID,
+	limit int,
+) ([]entity.Impersonation, error) {
+
+	builder := r.Builder.
+		Select(impersonationColumns...).
+		From("impersonations").
+		OrderBy("created_at DESC").
+		Limit(uint64(limit))
+
+	if actorID != nil {
+		builder = builder.Where("actor_id = ?", *actorID)
+	}
+	if userID != nil {
+		builder = builder.Where("user_id = ?", *userID)
+	}
+
+	query, args, _ := builder.ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).Error("List impersonations: query failed")
+		return nil, fmt.Errorf("list impersonations: %w", err)
+	}
+	defer rows.Close()
+
+	var result []entity.Impersonation
+	for rows.Next() {
+		i, err := scanImpersonation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan impersonation: %w", err)
+		}
+		result = append(result, i)
+	}
+
+	return result, rows.Err()
+}
+
+// End досрочно завершает выдачу. Завершённая или истёкшая считается ненайденной.
+func (r *ImpersonationRepository) End(
+	ctx context.Context,
+	id uuid.UUID,
+) (entity.Impersonation, error) {
+
+	now := time.Now()
+
+	query, args, _ := r.Builder.
+		Update("impersonations").
+		Set("ended_at", now).
+		Where("id = ?", id).
+		Where("ended_at IS NULL").
+		Where("expires_at > ?", now).
+		Suffix("RETURNING " + strings.Join(impersonationColumns, ", ")).
+		ToSql()
+
+	i, err := scanImpersonation(r.GetTxManager(ctx).QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Impersonation{}, ErrImpersonationNotFound
+		}
+		logrus.WithError(err).WithField("impersonation_id", id).Error("End impersonation: query failed")
+		return entity.Impersonation{}, fmt.Errorf("end impersonation: %w", err)
+	}
+
+	return i, nil
+}
@@ -0,0 +1,5 @@
+package invitation_repository
+
+import "errors"
+
+var ErrInvitationNotFound = errors.New("invitation not found")
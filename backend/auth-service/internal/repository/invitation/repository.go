@@ -0,0 +1,85 @@
+package invitation_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type InvitationRepository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *InvitationRepository {
+	return &InvitationRepository{pg}
+}
+
+// Create сохраняет приглашение. Предыдущие приглашения пользователя
+// удаляются: действует только последняя ссылка.
+func (r *InvitationRepository) Create(
+	ctx context.Context,
+	inv entity.UserInvitation,
+	tokenHash string,
+) (entity.UserInvitation, error) {
+
+	cleanup, cleanupArgs, _ := r.Builder.
+		Delete("user_invitations").
+		Where("user_id = ?", inv.UserID).
+		ToSql()
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, cleanup, cleanupArgs...); err != nil {
+		logrus.WithError(err).WithField("user_id", inv.UserID).Error("Create invitation: cleanup failed")
+		return entity.UserInvitation{}, fmt.Errorf("delete previous invitation: %w", err)
+	}
+
+	query, args, _ := r.Builder.
+		Insert("user_invitations").
+		Columns("user_id", "token_hash", "expires_at").
+		Values(inv.UserID, tokenHash, inv.ExpiresAt).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	if err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&inv.ID, &inv.CreatedAt); err != nil {
+		logrus.WithError(err).WithField("user_id", inv.UserID).Error("Create invitation: query failed")
+		return entity.UserInvitation{}, fmt.Errorf("create invitation: %w", err)
+	}
+
+	return inv, nil
+}
+
+// Consume атомарно удаляет и возвращает непросроченное приглашение по хешу токена.
+func (r *InvitationRepository) Consume(
+	ctx context.Context,
+	tokenHash string,
+) (entity.UserInvitation, error) {
+
+	query, args, _ := r.Builder.
+		Delete("user_invitations").
+		Where("token_hash = ?", tokenHash).
+		Where("expires_at > ?", time.Now()).
+		Suffix("RETURNING id, user_id, expires_at, created_at").
+		ToSql()
+
+	var inv entity.UserInvitation
+	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
+		&inv.ID,
+		&inv.UserID,
+		&inv.ExpiresAt,
+		&inv.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.UserInvitation{}, ErrInvitationNotFound
+		}
+		logrus.WithError(err).Error("Consume invitation: query failed")
+		return entity.UserInvitation{}, fmt.Errorf("consume invitation: %w", err)
+	}
+
+	return inv, nil
+}
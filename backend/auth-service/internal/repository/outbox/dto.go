@@ -0,0 +1,55 @@
+package outbox_repository
+
+import (
+	"encoding/json"
+	"maps"
+	"time"
+
+	"github.com/4udiwe/big-bob-pizza/order-service/pkg/outbox"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/eventid"
+	"github.com/google/uuid"
+)
+
+type RowOutbox struct {
+	ID            uuid.UUID      `db:"id"`
+	AggregateType string         `db:"aggregate_type"`
+	AggregateID   uuid.UUID      `db:"aggregate_id"`
+	EventType     string         `db:"event_type"`
+	Payload       map[string]any `db:"payload"`
+	StatusID      int            `db:"status_id"`
+	StatusName    string         `db:"status_name"`
+	CreatedAt     time.Time      `db:"created_at"`
+	ProcessedAt   *time.Time     `db:"processed_at"`
+}
+
+func (r RowOutbox) ToEntity() entity.OutboxEvent {
+	return entity.OutboxEvent{
+		ID:            r.ID,
+		AggregateType: r.AggregateType,
+		AggregateID:   r.AggregateID,
+		EventType:     r.EventType,
+		Payload:       r.Payload,
+		Status:        entity.OutboxStatus{ID: r.StatusID, Name: entity.OutboxStatusName(r.StatusName)},
+		CreatedAt:     r.CreatedAt,
+		ProcessedAt:   r.ProcessedAt,
+	}
+}
+
+func (r RowOutbox) ToEvent() outbox.Event {
+	// ID строки — стабильный eventId: по нему потребители отбрасывают
+	// повторные публикации той же записи
+	payload := make(map[string]any, len(r.Payload)+1)
+	maps.Copy(payload, r.Payload)
+	payload[eventid.Field] = r.ID
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		payloadBytes = []byte("{}")
+	}
+	return outbox.Event{
+		ID:        r.ID,
+		EventType: r.AggregateType + "." + r.EventType,
+		Payload:   payloadBytes,
+	}
+}
@@ -0,0 +1,7 @@
+package pat_repository
+
+import "errors"
+
+var (
+	ErrTokenNotFound = errors.New("personal token not found")
+)
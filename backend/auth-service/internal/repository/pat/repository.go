@@ -0,0 +1,162 @@
+package pat_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type PersonalTokenRepository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *PersonalTokenRepository {
+	return &PersonalTokenRepository{pg}
+}
+
+var tokenColumns = []string{
+	"id", "user_id", "name", "prefix", "permissions", "expires_at", "created_at", "last_used_at", "revoked_at",
+}
+
+func scanToken(row pgx.Row) (entity.PersonalToken, error) {
+	var t entity.PersonalToken
+	err := row.Scan(
+		&t.ID,
+		&t.UserID,
+		&t.Name,
+		&t.Prefix,
+		&t.Permissions,
+		&t.ExpiresAt,
+		&t.CreatedAt,
+		&t.LastUsedAt,
+		&t.RevokedAt,
+	)
+	return t, err
+}
+
+func (r *PersonalTokenRepository) Create(
+	ctx context.Context,
+	token entity.PersonalToken,
+	tokenHash string,
+) (entity.PersonalToken, error) {
+
+	logrus.Infof("Creating personal token %q for user %s", token.Name, token.UserID)
+
+	query, args, _ := r.Builder.
+		Insert("personal_access_tokens").
+		Columns("user_id", "name", "token_hash", "prefix", "permissions", "expires_at").
+		Values(token.UserID, token.Name, tokenHash, token.Prefix, token.Permissions, token.ExpiresAt).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	if err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&token.ID, &token.CreatedAt); err != nil {
+		logrus.WithError(err).WithField("user_id", token.UserID).Error("Create personal token: query failed")
+		return entity.PersonalToken{}, fmt.Errorf("create personal token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (r *PersonalTokenRepository) GetByHash(
+	ctx context.Context,
+	tokenHash string,
+) (entity.PersonalToken, error) {
+
+	query, args, _ := r.Builder.
+		Select(tokenColumns...).
+		From("personal_access_tokens").
+		Where("token_hash = ?", tokenHash).
+		ToSql()
+
+	t, err := scanToken(r.GetTxManager(ctx).QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.PersonalToken{}, ErrTokenNotFound
+		}
+		logrus.WithError(err).Error("GetByHash: query failed")
+		return entity.PersonalToken{}, fmt.Errorf("get personal token: %w", err)
+	}
+
+	return t, nil
+}
+
+func (r *PersonalTokenRepository) ListByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]entity.PersonalToken, error) {
+
+	query, args, _ := r.Builder.
+		Select(tokenColumns...).
+		From("personal_access_tokens").
+		Where("user_id = ?", userID).
+		OrderBy("created_at DESC").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("ListByUser: query failed")
+		return nil, fmt.Errorf("list personal tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []entity.PersonalToken
+	for rows.Next() {
+		t, err := scanToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan personal token: %w", err)
+		}
+		tokens = append(tokens, t)
+	}
+
+	return tokens, rows.Err()
+}
+
+// Revoke отзывает токен пользователя. Уже отозванный токен считается ненайденным.
+func (r *PersonalTokenRepository) Revoke(
+	ctx context.Context,
+	userID uuid.UUID,
+	tokenID uuid.UUID,
+) (entity.PersonalToken, error) {
+
+	query, args, _ := r.Builder.
+		Update("personal_access_tokens").
+		Set("revoked_at", time.Now()).
+		Where("id = ?", tokenID).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Suffix("RETURNING " + strings.Join(tokenColumns, ", ")).
+		ToSql()
+
+	t, err := scanToken(r.GetTxManager(ctx).QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.PersonalToken{}, ErrTokenNotFound
+		}
+		logrus.WithError(err).WithField("token_id", tokenID).Error("Revoke personal token: query failed")
+		return entity.PersonalToken{}, fmt.Errorf("revoke personal token: %w", err)
+	}
+
+	return t, nil
+}
+
+func (r *PersonalTokenRepository) TouchLastUsed(ctx context.Context, tokenID uuid.UUID) error {
+	query, args, _ := r.Builder.
+		Update("personal_access_tokens").
+		Set("last_used_at", time.Now()).
+		Where("id = ?", tokenID).
+		ToSql()
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		logrus.WithError(err).WithField("token_id", tokenID).Error("TouchLastUsed: query failed")
+		return fmt.Errorf("touch personal token: %w", err)
+	}
+	return nil
+}
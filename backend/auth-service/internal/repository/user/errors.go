@@ -6,4 +6,6 @@ var (
 	ErrUserAlreadyExists = errors.New("user already exists")
 	ErrRoleNotFound      = errors.New("role not found")
 	ErrUserNotFound      = errors.New("user not found")
+
+	ErrCoworkingRoleNotFound = errors.New("coworking role not found")
 )
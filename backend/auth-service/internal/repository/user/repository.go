@@ -168,6 +168,14 @@ func (r *UserRepository) GetByEmail(
 	}
 
 	user.Roles = roles
+
+	if user.Permissions, err = r.getPermissions(ctx, user.ID); err != nil {
+		return entity.User{}, err
+	}
+	if user.GroupIDs, err = r.getGroupIDs(ctx, user.ID); err != nil {
+		return entity.User{}, err
+	}
+
 	logrus.WithFields(logrus.Fields{"user_id": user.ID, "email": user.Email}).Info("User fetched by email")
 	return user, nil
 }
@@ -261,6 +269,13 @@ func (r *UserRepository) GetByID(
 		return entity.User{}, ErrUserNotFound
 	}
 
+	if user.Permissions, err = r.getPermissions(ctx, user.ID); err != nil {
+		return entity.User{}, err
+	}
+	if user.GroupIDs, err = r.getGroupIDs(ctx, user.ID); err != nil {
+		return entity.User{}, err
+	}
+
 	return user, nil
 }
 
@@ -407,6 +422,97 @@ func (r *UserRepository) GetUsers(
 	return users, total, nil
 }
 
+// GetByIDs возвращает профили пользователей без ролей.
+// Отсутствующие ID пропускаются.
+func (r *UserRepository) GetByIDs(ctx context.Context, userIDs []uuid.UUID) ([]entity.User, error) {
+	query, args, _ := r.Builder.
+		Select(
+			"id",
+			"COALESCE(first_name, '')",
+			"COALESCE(last_name, '')",
+			"email",
+			"is_active",
+			"created_at",
+			"updated_at",
+		).
+		From("users").
+		Where("id = ANY(?)", userIDs).
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).Error("GetByIDs: query failed")
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]entity.User, 0, len(userIDs))
+	for rows.Next() {
+		var u entity.User
+		if err := rows.Scan(
+			&u.ID,
+			&u.FirstName,
+			&u.LastName,
+			&u.Email,
+			&u.IsActive,
+			&u.CreatedAt,
+			&u.UpdatedAt,
+		); err != nil {
+			logrus.WithError(err).Error("GetByIDs: row scan failed")
+			return nil, err
+		}
+		users = append(users, u)
+	}
+
+	return users, rows.Err()
+}
+
+// ListActiveIDs возвращает ID активных пользователей по возрастанию, начиная
+// после after (uuid.Nil — с начала). Пустой role — все пользователи, иначе
+// только с этой ролью. Используется рассылками notification-service.
+func (r *UserRepository) ListActiveIDs(
+	ctx context.Context,
+	role string,
+	after uuid.UUID,
+	limit int,
+) ([]uuid.UUID, error) {
+	q := r.Builder.
+		Select("u.id").
+		From("users u").
+		Where("u.is_active").
+		Where("u.id > ?", after).
+		OrderBy("u.id").
+		Limit(uint64(limit))
+
+	if role != "" {
+		q = q.Where(`EXISTS (
+			SELECT 1 FROM user_roles ur JOIN roles r ON r.id = ur.role_id
+			WHERE ur.user_id = u.id AND r.code = ?
+		)`, role)
+	}
+
+	query, args, _ := q.ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).Error("ListActiveIDs: query failed")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0, limit)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			logrus.WithError(err).Error("ListActiveIDs: row scan failed")
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
 func (r *UserRepository) SetActive(ctx context.Context, userID uuid.UUID, active bool) error {
 	query, args, _ := r.Builder.
 		Update("users").
@@ -443,3 +549,399 @@ func (r *UserRepository) ClearRoles(ctx context.Context, userID uuid.UUID) error
 	}
 	return nil
 }
+
+// getPermissions возвращает эффективные права пользователя:
+// глобальные (user_roles) и ограниченные коворкингом (user_coworking_roles).
+// Точечное право не возвращается, если такое же есть глобально.
+func (r *UserRepository) getPermissions(ctx context.Context, userID uuid.UUID) ([]entity.Permission, error) {
+	const query = `
+		WITH global AS (
+			SELECT DISTINCT rp.permission_code
+			FROM user_roles ur
+			JOIN role_permissions rp ON rp.role_id = ur.role_id
+			WHERE ur.user_id = $1
+		)
+		SELECT permission_code, NULL::uuid FROM global
+		UNION
+		SELECT rp.permission_code, ucr.coworking_id
+		FROM user_coworking_roles ucr
+		JOIN role_permissions rp ON rp.role_id = ucr.role_id
+		WHERE ucr.user_id = $1
+		  AND rp.permission_code NOT IN (SELECT permission_code FROM global)
+		ORDER BY 1, 2 NULLS FIRST
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, userID)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("getPermissions: query failed")
+		return nil, fmt.Errorf("get permissions: %w", err)
+	}
+	defer rows.Close()
+
+	var permissions []entity.Permission
+	for rows.Next() {
+		var p entity.Permission
+		if err := rows.Scan(&p.Code, &p.CoworkingID); err != nil {
+			return nil, fmt.Errorf("scan permission: %w", err)
+		}
+		permissions = append(permissions, p)
+	}
+
+	return permissions, rows.Err()
+}
+
+// getGroupIDs возвращает группы пользователя для claim groups.
+func (r *UserRepository) getGroupIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	query, args, _ := r.Builder.
+		Select("group_id").
+		From("user_group_members").
+		Where("user_id = ?", userID).
+		OrderBy("group_id").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("getGroupIDs: query failed")
+		return nil, fmt.Errorf("get groups: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan group: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func (r *UserRepository) AttachCoworkingRole(
+	ctx context.Context,
+	userID uuid.UUID,
+	roleCode string,
+	coworkingID uuid.UUID,
+) error {
+
+	logrus.Infof("Attaching role %s in coworking %s to user %s", roleCode, coworkingID, userID)
+
+	const query = `
+		INSERT INTO user_coworking_roles (user_id, role_id, coworking_id)
+		SELECT $1, r.id, $3
+		FROM roles r
+		WHERE r.code = $2
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, userID, roleCode, coworkingID); err != nil {
+		logrus.WithError(err).
+			WithFields(logrus.Fields{
+				"user_id":      userID,
+				"role":         roleCode,
+				"coworking_id": coworkingID,
+			}).
+			Error("AttachCoworkingRole failed")
+		return fmt.Errorf("attach coworking role: %w", err)
+	}
+
+	// ON CONFLICT DO NOTHING не отличает «уже выдана» от «нет такой роли»
+	var exists bool
+	if err := r.GetTxManager(ctx).QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM roles WHERE code = $1)`, roleCode,
+	).Scan(&exists); err != nil {
+		return fmt.Errorf("check role: %w", err)
+	}
+	if !exists {
+		logrus.WithField("role", roleCode).Warn("AttachCoworkingRole: role not found")
+		return ErrRoleNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) DetachCoworkingRole(
+	ctx context.Context,
+	userID uuid.UUID,
+	roleCode string,
+	coworkingID uuid.UUID,
+) error {
+
+	const query = `
+		DELETE FROM user_coworking_roles ucr
+		USING roles r
+		WHERE ucr.role_id = r.id
+		  AND ucr.user_id = $1
+		  AND r.code = $2
+		  AND ucr.coworking_id = $3
+	`
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, userID, roleCode, coworkingID)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("DetachCoworkingRole: query failed")
+		return fmt.Errorf("detach coworking role: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrCoworkingRoleNotFound
+	}
+	return nil
+}
+
+func (r *UserRepository) GetCoworkingRoles(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]entity.CoworkingRole, error) {
+
+	query, args, _ := r.Builder.
+		Select(
+			"r.id",
+			"r.code",
+			"r.name",
+			"r.created_at",
+			"ucr.coworking_id",
+			"ucr.created_at",
+		).
+		From("user_coworking_roles ucr").
+		Join("roles r ON r.id = ucr.role_id").
+		Where("ucr.user_id = ?", userID).
+		OrderBy("ucr.created_at").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("GetCoworkingRoles: query failed")
+		return nil, fmt.Errorf("get coworking roles: %w", err)
+	}
+	defer rows.Close()
+
+	var roles []entity.CoworkingRole
+	for rows.Next() {
+		var cr entity.CoworkingRole
+		if err := rows.Scan(
+			&cr.Role.ID,
+			&cr.Role.Code,
+			&cr.Role.Name,
+			&cr.Role.CreatedAt,
+			&cr.CoworkingID,
+			&cr.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan coworking role: %w", err)
+		}
+		roles = append(roles, cr)
+	}
+
+	return roles, rows.Err()
+}
+
+// ListRoles возвращает все роли вместе с их правами.
+func (r *UserRepository) ListRoles(ctx context.Context) ([]entity.Role, error) {
+	const query = `
+		SELECT
+			r.id,
+			r.code,
+			r.name,
+			r.created_at,
+			COALESCE(
+				array_agg(rp.permission_code ORDER BY rp.permission_code)
+					FILTER (WHERE rp.permission_code IS NOT NULL),
+				'{}'
+			)
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role_id = r.id
+		GROUP BY r.id
+		ORDER BY r.code
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query)
+	if err != nil {
+		logrus.WithError(err).Error("ListRoles: query failed")
+		return nil, fmt.Errorf("list roles: %w", err)
+	}
+	defer rows.Close()
+
+	var roles []entity.Role
+	for rows.Next() {
+		var role entity.Role
+		if err := rows.Scan(&role.ID, &role.Code, &role.Name, &role.CreatedAt, &role.Permissions); err != nil {
+			return nil, fmt.Errorf("scan role: %w", err)
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
+
+func (r *UserRepository) UpdateProfile(
+	ctx context.Context,
+	userID uuid.UUID,
+	firstName string,
+	lastName string,
+) error {
+
+	query, args, _ := r.Builder.
+		Update("users").
+		Set("first_name", firstName).
+		Set("last_name", lastName).
+		Set("updated_at", time.Now()).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("UpdateProfile: query failed")
+		return fmt.Errorf("update profile: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	query, args, _ := r.Builder.
+		Update("users").
+		Set("password_hash", passwordHash).
+		Set("updated_at", time.Now()).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("UpdatePassword: query failed")
+		return fmt.Errorf("update password: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	query, args, _ := r.Builder.
+		Update("users").
+		Set("email", email).
+		Set("updated_at", time.Now()).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return ErrUserAlreadyExists
+		}
+		logrus.WithError(err).WithField("user_id", userID).Error("UpdateEmail: query failed")
+		return fmt.Errorf("update email: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// Anonymize затирает персональные данные удаляемого аккаунта: email и имя
+// заменяются заглушкой, пароль, роли, группы, SSO-привязки, персональные токены и
+// незавершённые смены email и приглашения удаляются, сессии отзываются. Сама строка users
+// остаётся, чтобы ID не мог достаться другому пользователю.
+func (r *UserRepository) Anonymize(ctx context.Context, userID uuid.UUID) error {
+	logrus.WithField("user_id", userID).Info("Anonymizing user")
+
+	now := time.Now()
+	query, args, _ := r.Builder.
+		Update("users").
+		Set("email", fmt.Sprintf("deleted-%s@deleted.invalid", userID)).
+		Set("first_name", "").
+		Set("last_name", "").
+		Set("password_hash", "").
+		Set("is_active", false).
+		Set("deleted_at", now).
+		Set("updated_at", now).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Anonymize: query failed")
+		return fmt.Errorf("anonymize user: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+
+	for _, table := range []string{
+		"user_roles",
+		"user_coworking_roles",
+		"user_group_members",
+		"user_identities",
+		"personal_access_tokens",
+		"email_change_requests",
+		"user_invitations",
+	} {
+		query, args, _ := r.Builder.
+			Delete(table).
+			Where("user_id = ?", userID).
+			ToSql()
+
+		if _, err := r.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+			logrus.WithError(err).WithField("table", table).Error("Anonymize: cleanup failed")
+			return fmt.Errorf("anonymize %s: %w", table, err)
+		}
+	}
+
+	query, args, _ = r.Builder.
+		Update("refresh_tokens").
+		Set("revoked", true).
+		Where("user_id = ?", userID).
+		ToSql()
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		logrus.WithError(err).Error("Anonymize: failed to revoke sessions")
+		return fmt.Errorf("anonymize sessions: %w", err)
+	}
+
+	return nil
+}
+
+// ListActiveWithinRoles возвращает активных пользователей, у которых есть хотя бы
+// одна из ролей roles и нет ролей вне этого набора. Используется импортом списка:
+// студенческий список не должен деактивировать преподавателя или администратора,
+// у которого вдобавок есть роль student.
+func (r *UserRepository) ListActiveWithinRoles(ctx context.Context, roles []string) ([]entity.User, error) {
+	const query = `
+		SELECT u.id, u.email, u.first_name, u.last_name
+		FROM users u
+		WHERE u.is_active
+			AND EXISTS (
+				SELECT 1 FROM user_roles ur JOIN roles r ON r.id = ur.role_id
+				WHERE ur.user_id = u.id AND r.code = ANY($1)
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM user_roles ur JOIN roles r ON r.id = ur.role_id
+				WHERE ur.user_id = u.id AND r.code <> ALL($1)
+			)
+		ORDER BY u.email
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, roles)
+	if err != nil {
+		logrus.WithError(err).Error("ListActiveWithinRoles: query failed")
+		return nil, fmt.Errorf("list users within roles: %w", err)
+	}
+	defer rows.Close()
+
+	var users []entity.User
+	for rows.Next() {
+		user := entity.User{IsActive: true}
+		if err := rows.Scan(&user.ID, &user.Email, &user.FirstName, &user.LastName); err != nil {
+			return nil, fmt.Errorf("scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
@@ -0,0 +1,57 @@
+package account_service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
+type UserRepository interface {
+	GetByID(ctx context.Context, userID uuid.UUID) (entity.User, error)
+	GetByEmail(ctx context.Context, email string) (entity.User, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, firstName string, lastName string) error
+	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
+	UpdateEmail(ctx context.Context, userID uuid.UUID, email string) error
+	Anonymize(ctx context.Context, userID uuid.UUID) error
+}
+
+type SessionRepository interface {
+	GetUserSessions(ctx context.Context, userID uuid.UUID, onlyActive bool) ([]entity.Session, error)
+	RevokeSession(ctx context.Context, id uuid.UUID) error
+}
+
+type EmailChangeRepository interface {
+	Create(ctx context.Context, req entity.EmailChangeRequest, tokenHash string) (entity.EmailChangeRequest, error)
+	Consume(ctx context.Context, tokenHash string) (entity.EmailChangeRequest, error)
+}
+
+type IdentityRepository interface {
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]entity.UserIdentity, error)
+}
+
+type PersonalTokenRepository interface {
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]entity.PersonalToken, error)
+}
+
+type OutboxRepository interface {
+	Create(ctx context.Context, ev entity.OutboxEvent) error
+}
+
+type Hasher interface {
+	HashPassword(password string) (string, error)
+	CheckPasswordHash(password, hash string) bool
+}
+
+type Auth interface {
+	HashToken(tokenString string) string
+}
+
+// DataSource — часть экспорта, которая хранится в другом сервисе.
+type DataSource interface {
+	Name() string
+	ExportUserData(ctx context.Context, userID uuid.UUID) (json.RawMessage, error)
+}
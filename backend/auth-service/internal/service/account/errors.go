@@ -0,0 +1,18 @@
+package account_service
+
+import "errors"
+
+var (
+	ErrUserNotFound      = errors.New("user not found")
+	ErrInvalidPassword   = errors.New("invalid password")
+	ErrPasswordNotSet    = errors.New("password is not set for this account")
+	ErrSameEmail         = errors.New("new email matches the current one")
+	ErrEmailTaken        = errors.New("email is already in use")
+	ErrInvalidEmailToken = errors.New("invalid or expired email confirmation token")
+
+	ErrCannotUpdateProfile  = errors.New("cannot update profile")
+	ErrCannotChangePassword = errors.New("cannot change password")
+	ErrCannotChangeEmail    = errors.New("cannot change email")
+	ErrCannotDeleteAccount  = errors.New("cannot delete account")
+	ErrCannotExportData     = errors.New("cannot export user data")
+)
@@ -0,0 +1,452 @@
+package account_service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/transactor"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	email_change_repository "github.com/4udiwe/coworking/auth-service/internal/repository/email_change"
+	user_repository "github.com/4udiwe/coworking/auth-service/internal/repository/user"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// События самообслуживания аккаунта (префикс "auth."). Запрос смены email
+// несёт одноразовую ссылку и публикуется в отдельный топик писем (mailTopic),
+// остальные — в auth.events.
+const (
+	EventEmailChangeRequested = "user.email_change_requested"
+	EventUserDeleted          = "user.deleted"
+)
+
+/*
+Service — самообслуживание аккаунта: профиль, пароль, email, экспорт
+и удаление.
+
+Смена пароля отзывает все сессии, кроме текущей. Новый email
+применяется только после подтверждения по ссылке, отправленной на него
+(событие auth.user.email_change_requested в топике auth.mail). Удаление затирает
+персональные данные в auth-service и публикует auth.user.deleted с
+anonymousId — остальные сервисы заменяют им user_id в своих данных.
+*/
+type Service struct {
+	userRepo        UserRepository
+	sessionRepo     SessionRepository
+	emailChangeRepo EmailChangeRepository
+	identityRepo    IdentityRepository
+	tokenRepo       PersonalTokenRepository
+	outboxRepo      OutboxRepository
+	hasher          Hasher
+	auth            Auth
+	sources         []DataSource
+	tx              transactor.Transactor
+
+	accessTokenTTL  time.Duration
+	emailChangeTTL  time.Duration
+	emailConfirmURL string
+	mailTopic       string
+}
+
+func New(
+	userRepo UserRepository,
+	sessionRepo SessionRepository,
+	emailChangeRepo EmailChangeRepository,
+	identityRepo IdentityRepository,
+	tokenRepo PersonalTokenRepository,
+	outboxRepo OutboxRepository,
+	hasher Hasher,
+	auth Auth,
+	sources []DataSource,
+	tx transactor.Transactor,
+	accessTokenTTL time.Duration,
+	emailChangeTTL time.Duration,
+	emailConfirmURL string,
+	mailTopic string,
+) *Service {
+	return &Service{
+		userRepo:        userRepo,
+		sessionRepo:     sessionRepo,
+		emailChangeRepo: emailChangeRepo,
+		identityRepo:    identityRepo,
+		tokenRepo:       tokenRepo,
+		outboxRepo:      outboxRepo,
+		hasher:          hasher,
+		auth:            auth,
+		sources:         sources,
+		tx:              tx,
+		accessTokenTTL:  accessTokenTTL,
+		emailChangeTTL:  emailChangeTTL,
+		emailConfirmURL: emailConfirmURL,
+		mailTopic:       mailTopic,
+	}
+}
+
+func (s *Service) UpdateProfile(
+	ctx context.Context,
+	userID uuid.UUID,
+	firstName string,
+	lastName string,
+) (entity.User, error) {
+
+	logrus.WithField("user_id", userID).Info("UpdateProfile called")
+
+	var user entity.User
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.UpdateProfile(ctx, userID, firstName, lastName); err != nil {
+			return err
+		}
+
+		var err error
+		user, err = s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		return s.outboxRepo.Create(ctx, entity.NewUserEvent(
+			userID,
+			entity.UserEventUpdated,
+			entity.UserProfilePayload(user),
+		))
+	})
+	if err != nil {
+		if errors.Is(err, user_repository.ErrUserNotFound) {
+			return entity.User{}, ErrUserNotFound
+		}
+		logrus.WithError(err).WithField("user_id", userID).Error("UpdateProfile failed")
+		return entity.User{}, ErrCannotUpdateProfile
+	}
+
+	return user, nil
+}
+
+// ChangePassword меняет пароль и отзывает все сессии пользователя,
+// кроме текущей (currentSessionID).
+func (s *Service) ChangePassword(
+	ctx context.Context,
+	userID uuid.UUID,
+	currentSessionID uuid.UUID,
+	currentPassword string,
+	newPassword string,
+) error {
+
+	logrus.WithField("user_id", userID).Info("ChangePassword called")
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Аккаунт, созданный через SSO, пароля не имеет
+	if user.PasswordHash == "" {
+		return ErrPasswordNotSet
+	}
+	if !s.hasher.CheckPasswordHash(currentPassword, user.PasswordHash) {
+		logrus.WithField("user_id", userID).Warn("ChangePassword: invalid current password")
+		return ErrInvalidPassword
+	}
+
+	hash, err := s.hasher.HashPassword(newPassword)
+	if err != nil {
+		logrus.WithError(err).Error("ChangePassword: hashing failed")
+		return ErrCannotChangePassword
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.UpdatePassword(ctx, userID, hash); err != nil {
+			return fmt.Errorf("update password: %w", err)
+		}
+
+		sessions, err := s.sessionRepo.GetUserSessions(ctx, userID, true)
+		if err != nil {
+			return fmt.Errorf("get sessions: %w", err)
+		}
+
+		now := time.Now()
+		for _, session := range sessions {
+			if session.ID == currentSessionID {
+				continue
+			}
+			if err := s.sessionRepo.RevokeSession(ctx, session.ID); err != nil {
+				return fmt.Errorf("revoke session: %w", err)
+			}
+			if err := s.outboxRepo.Create(ctx, entity.OutboxEvent{
+				AggregateType: "auth",
+				AggregateID:   session.ID,
+				EventType:     "session.revoked",
+				Payload: map[string]any{
+					"sessionId": session.ID,
+					"revokedAt": now,
+					"expiresAt": now.Add(s.accessTokenTTL),
+				},
+				Status:    entity.OutboxStatus{ID: 1, Name: entity.OutboxStatusPending},
+				CreatedAt: now,
+			}); err != nil {
+				return fmt.Errorf("create outbox event: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("ChangePassword failed")
+		return ErrCannotChangePassword
+	}
+
+	logrus.WithField("user_id", userID).Info("Password changed")
+	return nil
+}
+
+// RequestEmailChange создаёт одноразовую ссылку подтверждения и публикует
+// auth.user.email_change_requested в топик писем — письмо на новый адрес
+// отправляет notification-service. Для аккаунтов с паролем нужен текущий пароль.
+func (s *Service) RequestEmailChange(
+	ctx context.Context,
+	userID uuid.UUID,
+	password string,
+	newEmail string,
+) error {
+
+	logrus.WithField("user_id", userID).Info("RequestEmailChange called")
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.PasswordHash != "" && !s.hasher.CheckPasswordHash(password, user.PasswordHash) {
+		return ErrInvalidPassword
+	}
+	if strings.EqualFold(user.Email, newEmail) {
+		return ErrSameEmail
+	}
+
+	_, err = s.userRepo.GetByEmail(ctx, newEmail)
+	switch {
+	case err == nil:
+		return ErrEmailTaken
+	case !errors.Is(err, user_repository.ErrUserNotFound):
+		logrus.WithError(err).Error("RequestEmailChange: failed to check email")
+		return ErrCannotChangeEmail
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		logrus.WithError(err).Error("RequestEmailChange: failed to generate token")
+		return ErrCannotChangeEmail
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		req, err := s.emailChangeRepo.Create(ctx, entity.EmailChangeRequest{
+			UserID:    userID,
+			NewEmail:  newEmail,
+			ExpiresAt: time.Now().Add(s.emailChangeTTL),
+		}, s.auth.HashToken(token))
+		if err != nil {
+			return err
+		}
+
+		// Токен живёт недолго и одноразовый; в базе auth-service хранится только хеш
+		event := entity.NewUserEvent(userID, EventEmailChangeRequested, map[string]any{
+			"userId":     userID,
+			"email":      newEmail,
+			"firstName":  user.FirstName,
+			"confirmUrl": s.confirmURL(token),
+			"expiresAt":  req.ExpiresAt,
+		})
+		event.Topic = s.mailTopic
+		return s.outboxRepo.Create(ctx, event)
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("RequestEmailChange failed")
+		return ErrCannotChangeEmail
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange применяет новый email по токену из письма.
+func (s *Service) ConfirmEmailChange(ctx context.Context, token string) error {
+	logrus.Info("ConfirmEmailChange called")
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		req, err := s.emailChangeRepo.Consume(ctx, s.auth.HashToken(token))
+		if err != nil {
+			return err
+		}
+
+		if err := s.userRepo.UpdateEmail(ctx, req.UserID, req.NewEmail); err != nil {
+			return err
+		}
+
+		user, err := s.userRepo.GetByID(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+
+		logrus.WithField("user_id", user.ID).Info("Email changed")
+
+		return s.outboxRepo.Create(ctx, entity.NewUserEvent(
+			user.ID,
+			entity.UserEventUpdated,
+			entity.UserProfilePayload(user),
+		))
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, email_change_repository.ErrRequestNotFound):
+			return ErrInvalidEmailToken
+		case errors.Is(err, user_repository.ErrUserAlreadyExists):
+			return ErrEmailTaken
+		case errors.Is(err, user_repository.ErrUserNotFound):
+			return ErrUserNotFound
+		}
+		logrus.WithError(err).Error("ConfirmEmailChange failed")
+		return ErrCannotChangeEmail
+	}
+
+	return nil
+}
+
+// DeleteAccount удаляет аккаунт по запросу пользователя. Для аккаунтов
+// с паролем нужен текущий пароль.
+func (s *Service) DeleteAccount(ctx context.Context, userID uuid.UUID, password string) error {
+	logrus.WithField("user_id", userID).Info("DeleteAccount called")
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.PasswordHash != "" && !s.hasher.CheckPasswordHash(password, user.PasswordHash) {
+		return ErrInvalidPassword
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Anonymize(ctx, userID); err != nil {
+			return err
+		}
+
+		// anonymousId случайный: по нему нельзя восстановить исходный user_id,
+		// но записи одного пользователя в других сервисах остаются связанными
+		now := time.Now()
+		return s.outboxRepo.Create(ctx, entity.NewUserEvent(userID, EventUserDeleted, map[string]any{
+			"userId":      userID,
+			"anonymousId": uuid.New(),
+			"revokedAt":   now,
+			"expiresAt":   now.Add(s.accessTokenTTL),
+		}))
+	})
+	if err != nil {
+		if errors.Is(err, user_repository.ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		logrus.WithError(err).WithField("user_id", userID).Error("DeleteAccount failed")
+		return ErrCannotDeleteAccount
+	}
+
+	logrus.WithField("user_id", userID).Info("Account deleted")
+	return nil
+}
+
+// Export — все данные пользователя: из auth-service и из остальных сервисов.
+type Export struct {
+	User           entity.User
+	Sessions       []entity.Session
+	Identities     []entity.UserIdentity
+	PersonalTokens []entity.PersonalToken
+	// Имя сервиса -> его часть экспорта
+	Services map[string]json.RawMessage
+	// Сервисы, не ответившие на запрос экспорта
+	Unavailable []string
+}
+
+// ExportData собирает данные пользователя. Недоступность одного из
+// сервисов не прерывает экспорт: он попадает в Unavailable.
+func (s *Service) ExportData(ctx context.Context, userID uuid.UUID) (Export, error) {
+	logrus.WithField("user_id", userID).Info("ExportData called")
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return Export{}, err
+	}
+
+	sessions, err := s.sessionRepo.GetUserSessions(ctx, userID, false)
+	if err != nil {
+		logrus.WithError(err).Error("ExportData: failed to get sessions")
+		return Export{}, ErrCannotExportData
+	}
+	identities, err := s.identityRepo.ListByUser(ctx, userID)
+	if err != nil {
+		logrus.WithError(err).Error("ExportData: failed to get identities")
+		return Export{}, ErrCannotExportData
+	}
+	tokens, err := s.tokenRepo.ListByUser(ctx, userID)
+	if err != nil {
+		logrus.WithError(err).Error("ExportData: failed to get personal tokens")
+		return Export{}, ErrCannotExportData
+	}
+
+	export := Export{
+		User:           user,
+		Sessions:       sessions,
+		Identities:     identities,
+		PersonalTokens: tokens,
+		Services:       make(map[string]json.RawMessage, len(s.sources)),
+		Unavailable:    []string{},
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, source := range s.sources {
+		wg.Add(1)
+		go func(source DataSource) {
+			defer wg.Done()
+
+			data, err := source.ExportUserData(ctx, userID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				logrus.WithError(err).WithField("source", source.Name()).Warn("ExportData: source unavailable")
+				export.Unavailable = append(export.Unavailable, source.Name())
+				return
+			}
+			export.Services[source.Name()] = data
+		}(source)
+	}
+	wg.Wait()
+
+	return export, nil
+}
+
+func (s *Service) getUser(ctx context.Context, userID uuid.UUID) (entity.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, user_repository.ErrUserNotFound) {
+			return entity.User{}, ErrUserNotFound
+		}
+		logrus.WithError(err).WithField("user_id", userID).Error("failed to get user")
+		return entity.User{}, fmt.Errorf("get user: %w", err)
+	}
+	return user, nil
+}
+
+func (s *Service) confirmURL(token string) string {
+	return s.emailConfirmURL + "?token=" + url.QueryEscape(token)
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,388 @@
+package account_service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	email_change_repository "github.com/4udiwe/coworking/auth-service/internal/repository/email_change"
+	user_repository "github.com/4udiwe/coworking/auth-service/internal/repository/user"
+	service "github.com/4udiwe/coworking/auth-service/internal/service/account"
+
+	mock_tx "github.com/4udiwe/coworking/auth-service/internal/mocks"
+	m "github.com/4udiwe/coworking/auth-service/internal/service/account/mocks"
+)
+
+type mocks struct {
+	ur *m.MockUserRepository
+	sr *m.MockSessionRepository
+	er *m.MockEmailChangeRepository
+	ir *m.MockIdentityRepository
+	tr *m.MockPersonalTokenRepository
+	or *m.MockOutboxRepository
+	h  *m.MockHasher
+	a  *m.MockAuth
+	ds *m.MockDataSource
+	tx *mock_tx.MockTransactor
+}
+
+func newMocks(ctrl *gomock.Controller) mocks {
+	return mocks{
+		ur: m.NewMockUserRepository(ctrl),
+		sr: m.NewMockSessionRepository(ctrl),
+		er: m.NewMockEmailChangeRepository(ctrl),
+		ir: m.NewMockIdentityRepository(ctrl),
+		tr: m.NewMockPersonalTokenRepository(ctrl),
+		or: m.NewMockOutboxRepository(ctrl),
+		h:  m.NewMockHasher(ctrl),
+		a:  m.NewMockAuth(ctrl),
+		ds: m.NewMockDataSource(ctrl),
+		tx: mock_tx.NewMockTransactor(ctrl),
+	}
+}
+
+func newService(mm mocks) *service.Service {
+	return service.New(
+		mm.ur, mm.sr, mm.er, mm.ir, mm.tr, mm.or, mm.h, mm.a,
+		[]service.DataSource{mm.ds},
+		mm.tx,
+		2*time.Minute, 24*time.Hour, "coworking://account/confirm-email", "auth.mail",
+	)
+}
+
+func withTx(mm mocks) {
+	mm.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func eventType(t string) gomock.Matcher {
+	return gomock.Cond(func(ev entity.OutboxEvent) bool { return ev.EventType == t })
+}
+
+func TestService_ChangePassword(t *testing.T) {
+	userID := uuid.New()
+	currentID := uuid.New()
+	otherID := uuid.New()
+	user := entity.User{ID: userID, PasswordHash: "old-hash", IsActive: true}
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m mocks)
+		expectedErr  error
+	}{
+		{
+			name: "other sessions revoked",
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+				m.h.EXPECT().CheckPasswordHash("old-pass", "old-hash").Return(true)
+				m.h.EXPECT().HashPassword("new-pass").Return("new-hash", nil)
+				withTx(m)
+				m.ur.EXPECT().UpdatePassword(gomock.Any(), userID, "new-hash").Return(nil)
+				m.sr.EXPECT().GetUserSessions(gomock.Any(), userID, true).
+					Return([]entity.Session{{ID: currentID}, {ID: otherID}}, nil)
+				m.sr.EXPECT().RevokeSession(gomock.Any(), otherID).Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Cond(func(ev entity.OutboxEvent) bool {
+					return ev.EventType == "session.revoked" && ev.AggregateID == otherID
+				})).Return(nil)
+			},
+		},
+		{
+			name: "wrong current password",
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+				m.h.EXPECT().CheckPasswordHash("old-pass", "old-hash").Return(false)
+			},
+			expectedErr: service.ErrInvalidPassword,
+		},
+		{
+			name: "sso account without password",
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(entity.User{ID: userID}, nil)
+			},
+			expectedErr: service.ErrPasswordNotSet,
+		},
+		{
+			name: "revoke fails",
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+				m.h.EXPECT().CheckPasswordHash("old-pass", "old-hash").Return(true)
+				m.h.EXPECT().HashPassword("new-pass").Return("new-hash", nil)
+				withTx(m)
+				m.ur.EXPECT().UpdatePassword(gomock.Any(), userID, "new-hash").Return(nil)
+				m.sr.EXPECT().GetUserSessions(gomock.Any(), userID, true).
+					Return([]entity.Session{{ID: otherID}}, nil)
+				m.sr.EXPECT().RevokeSession(gomock.Any(), otherID).Return(errors.New("fail"))
+			},
+			expectedErr: service.ErrCannotChangePassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mm := newMocks(ctrl)
+			tt.mockBehavior(mm)
+
+			err := newService(mm).ChangePassword(context.Background(), userID, currentID, "old-pass", "new-pass")
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_RequestEmailChange(t *testing.T) {
+	userID := uuid.New()
+	user := entity.User{ID: userID, Email: "old@university.edu", PasswordHash: "hash"}
+
+	tests := []struct {
+		name         string
+		email        string
+		mockBehavior func(m mocks)
+		expectedErr  error
+	}{
+		{
+			name:  "confirmation requested",
+			email: "new@university.edu",
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+				m.h.EXPECT().CheckPasswordHash("pass", "hash").Return(true)
+				m.ur.EXPECT().GetByEmail(gomock.Any(), "new@university.edu").
+					Return(entity.User{}, user_repository.ErrUserNotFound)
+				withTx(m)
+				m.a.EXPECT().HashToken(gomock.Any()).Return("token-hash")
+				m.er.EXPECT().Create(gomock.Any(), gomock.Cond(func(r entity.EmailChangeRequest) bool {
+					return r.UserID == userID && r.NewEmail == "new@university.edu"
+				}), "token-hash").Return(entity.EmailChangeRequest{UserID: userID}, nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Cond(func(ev entity.OutboxEvent) bool {
+					return ev.EventType == service.EventEmailChangeRequested &&
+						ev.Topic == "auth.mail" &&
+						ev.Payload["email"] == "new@university.edu"
+				})).Return(nil)
+			},
+		},
+		{
+			name:  "same email",
+			email: "OLD@university.edu",
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+				m.h.EXPECT().CheckPasswordHash("pass", "hash").Return(true)
+			},
+			expectedErr: service.ErrSameEmail,
+		},
+		{
+			name:  "email taken",
+			email: "taken@university.edu",
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+				m.h.EXPECT().CheckPasswordHash("pass", "hash").Return(true)
+				m.ur.EXPECT().GetByEmail(gomock.Any(), "taken@university.edu").
+					Return(entity.User{ID: uuid.New()}, nil)
+			},
+			expectedErr: service.ErrEmailTaken,
+		},
+		{
+			name:  "wrong password",
+			email: "new@university.edu",
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+				m.h.EXPECT().CheckPasswordHash("pass", "hash").Return(false)
+			},
+			expectedErr: service.ErrInvalidPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mm := newMocks(ctrl)
+			tt.mockBehavior(mm)
+
+			err := newService(mm).RequestEmailChange(context.Background(), userID, "pass", tt.email)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_ConfirmEmailChange(t *testing.T) {
+	userID := uuid.New()
+	req := entity.EmailChangeRequest{UserID: userID, NewEmail: "new@university.edu"}
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m mocks)
+		expectedErr  error
+	}{
+		{
+			name: "email updated",
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.a.EXPECT().HashToken("token").Return("token-hash")
+				m.er.EXPECT().Consume(gomock.Any(), "token-hash").Return(req, nil)
+				m.ur.EXPECT().UpdateEmail(gomock.Any(), userID, "new@university.edu").Return(nil)
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).
+					Return(entity.User{ID: userID, Email: "new@university.edu"}, nil)
+				m.or.EXPECT().Create(gomock.Any(), eventType(entity.UserEventUpdated)).Return(nil)
+			},
+		},
+		{
+			name: "unknown or expired token",
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.a.EXPECT().HashToken("token").Return("token-hash")
+				m.er.EXPECT().Consume(gomock.Any(), "token-hash").
+					Return(entity.EmailChangeRequest{}, email_change_repository.ErrRequestNotFound)
+			},
+			expectedErr: service.ErrInvalidEmailToken,
+		},
+		{
+			name: "email taken meanwhile",
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.a.EXPECT().HashToken("token").Return("token-hash")
+				m.er.EXPECT().Consume(gomock.Any(), "token-hash").Return(req, nil)
+				m.ur.EXPECT().UpdateEmail(gomock.Any(), userID, "new@university.edu").
+					Return(user_repository.ErrUserAlreadyExists)
+			},
+			expectedErr: service.ErrEmailTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mm := newMocks(ctrl)
+			tt.mockBehavior(mm)
+
+			err := newService(mm).ConfirmEmailChange(context.Background(), "token")
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_DeleteAccount(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name         string
+		user         entity.User
+		mockBehavior func(m mocks)
+		expectedErr  error
+	}{
+		{
+			name: "password account",
+			user: entity.User{ID: userID, PasswordHash: "hash"},
+			mockBehavior: func(m mocks) {
+				m.h.EXPECT().CheckPasswordHash("pass", "hash").Return(true)
+				withTx(m)
+				m.ur.EXPECT().Anonymize(gomock.Any(), userID).Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Cond(func(ev entity.OutboxEvent) bool {
+					anonymousID, ok := ev.Payload["anonymousId"].(uuid.UUID)
+					return ev.EventType == service.EventUserDeleted &&
+						ok && anonymousID != uuid.Nil && anonymousID != userID
+				})).Return(nil)
+			},
+		},
+		{
+			name: "sso account without password",
+			user: entity.User{ID: userID},
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.ur.EXPECT().Anonymize(gomock.Any(), userID).Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), eventType(service.EventUserDeleted)).Return(nil)
+			},
+		},
+		{
+			name: "wrong password",
+			user: entity.User{ID: userID, PasswordHash: "hash"},
+			mockBehavior: func(m mocks) {
+				m.h.EXPECT().CheckPasswordHash("pass", "hash").Return(false)
+			},
+			expectedErr: service.ErrInvalidPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mm := newMocks(ctrl)
+			mm.ur.EXPECT().GetByID(gomock.Any(), userID).Return(tt.user, nil)
+			tt.mockBehavior(mm)
+
+			err := newService(mm).DeleteAccount(context.Background(), userID, "pass")
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_ExportData(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name            string
+		sourceErr       error
+		wantServices    int
+		wantUnavailable []string
+	}{
+		{
+			name:            "all sources available",
+			wantServices:    1,
+			wantUnavailable: []string{},
+		},
+		{
+			name:            "source unavailable",
+			sourceErr:       errors.New("timeout"),
+			wantServices:    0,
+			wantUnavailable: []string{"booking"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mm := newMocks(ctrl)
+
+			mm.ur.EXPECT().GetByID(gomock.Any(), userID).Return(entity.User{ID: userID}, nil)
+			mm.sr.EXPECT().GetUserSessions(gomock.Any(), userID, false).Return(nil, nil)
+			mm.ir.EXPECT().ListByUser(gomock.Any(), userID).Return(nil, nil)
+			mm.tr.EXPECT().ListByUser(gomock.Any(), userID).Return(nil, nil)
+			mm.ds.EXPECT().Name().Return("booking").AnyTimes()
+			mm.ds.EXPECT().ExportUserData(gomock.Any(), userID).
+				Return(json.RawMessage(`{"bookings":[]}`), tt.sourceErr)
+
+			export, err := newService(mm).ExportData(context.Background(), userID)
+
+			require.NoError(t, err)
+			require.Equal(t, userID, export.User.ID)
+			require.Len(t, export.Services, tt.wantServices)
+			require.Equal(t, tt.wantUnavailable, export.Unavailable)
+		})
+	}
+}
@@ -24,18 +24,30 @@ type AuthRepository interface {
 	UpdateLastUsedAt(ctx context.Context, id uuid.UUID) error
 	RevokeSession(ctx context.Context, id uuid.UUID) error
 	GetUserSessions(ctx context.Context, userID uuid.UUID, onlyActive bool) ([]entity.Session, error)
-	DeleteOldestSessionByUser(ctx context.Context, userID uuid.UUID) error
+	RevokeOldestSessions(ctx context.Context, userID uuid.UUID, n int) ([]uuid.UUID, error)
+	RevokeUserSessionsExcept(ctx context.Context, userID uuid.UUID, keepID uuid.UUID) ([]uuid.UUID, error)
+	UpdateDeviceName(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID, deviceName string) error
+	GetSessionIPs(ctx context.Context, sessionID uuid.UUID) ([]entity.SessionIP, error)
 	GetSessionByDeviceFingerprint(ctx context.Context, userID uuid.UUID, deviceFingerprint string) (entity.Session, error)
-	UpdateSessionRefresh(ctx context.Context, sessionID uuid.UUID, newTokenHash string, newExpiresAt time.Time) error
+	UpdateSessionRefresh(ctx context.Context, sessionID uuid.UUID, newTokenHash string, newExpiresAt time.Time, ip string) error
 	DeleteOldRevokedSessions(ctx context.Context, retentionDays int) (int64, error)
 }
 
+type OutboxRepository interface {
+	Create(ctx context.Context, ev entity.OutboxEvent) error
+}
+
 type Auth interface {
 	GenerateTokens(user entity.User, sessionID uuid.UUID) (*auth.Tokens, error)
 	ParseRefreshToken(tokenString string) (*auth.RefreshClaims, error)
 	HashToken(tokenString string) string
 }
 
+// GeoLocator — поиск местоположения по IP; nil-результат означает "неизвестно".
+type GeoLocator interface {
+	Lookup(ip string) *entity.Location
+}
+
 type Hasher interface {
 	HashPassword(password string) (string, error)
 	CheckPasswordHash(password, hash string) bool
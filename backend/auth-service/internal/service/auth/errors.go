@@ -18,6 +18,8 @@ var (
 	ErrSessionExpired      = errors.New("session is expired")
 	ErrCannotUpdateSession = errors.New("cannot update session")
 	ErrCannotRevokeSession = errors.New("cannot revoke session")
+	ErrSessionLimitReached = errors.New("active session limit reached, sign out on another device")
+	ErrEmptyDeviceName     = errors.New("device name cannot be empty")
 
 	// Input validation errors
 	ErrEmptyEmail    = errors.New("email cannot be empty")
@@ -156,20 +156,6 @@ func (mr *MockAuthRepositoryMockRecorder) DeleteOldRevokedSessions(ctx, retentio
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldRevokedSessions", reflect.TypeOf((*MockAuthRepository)(nil).DeleteOldRevokedSessions), ctx, retentionDays)
 }
 
-// DeleteOldestSessionByUser mocks base method.
-func (m *MockAuthRepository) DeleteOldestSessionByUser(ctx context.Context, userID uuid.UUID) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOldestSessionByUser", ctx, userID)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// DeleteOldestSessionByUser indicates an expected call of DeleteOldestSessionByUser.
-func (mr *MockAuthRepositoryMockRecorder) DeleteOldestSessionByUser(ctx, userID any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldestSessionByUser", reflect.TypeOf((*MockAuthRepository)(nil).DeleteOldestSessionByUser), ctx, userID)
-}
-
 // GetSessionByDeviceFingerprint mocks base method.
 func (m *MockAuthRepository) GetSessionByDeviceFingerprint(ctx context.Context, userID uuid.UUID, deviceFingerprint string) (entity.Session, error) {
 	m.ctrl.T.Helper()
@@ -200,6 +186,21 @@ func (mr *MockAuthRepositoryMockRecorder) GetSessionByID(ctx, id any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByID", reflect.TypeOf((*MockAuthRepository)(nil).GetSessionByID), ctx, id)
 }
 
+// GetSessionIPs mocks base method.
+func (m *MockAuthRepository) GetSessionIPs(ctx context.Context, sessionID uuid.UUID) ([]entity.SessionIP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionIPs", ctx, sessionID)
+	ret0, _ := ret[0].([]entity.SessionIP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionIPs indicates an expected call of GetSessionIPs.
+func (mr *MockAuthRepositoryMockRecorder) GetSessionIPs(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionIPs", reflect.TypeOf((*MockAuthRepository)(nil).GetSessionIPs), ctx, sessionID)
+}
+
 // GetUserSessions mocks base method.
 func (m *MockAuthRepository) GetUserSessions(ctx context.Context, userID uuid.UUID, onlyActive bool) ([]entity.Session, error) {
 	m.ctrl.T.Helper()
@@ -215,6 +216,21 @@ func (mr *MockAuthRepositoryMockRecorder) GetUserSessions(ctx, userID, onlyActiv
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessions", reflect.TypeOf((*MockAuthRepository)(nil).GetUserSessions), ctx, userID, onlyActive)
 }
 
+// RevokeOldestSessions mocks base method.
+func (m *MockAuthRepository) RevokeOldestSessions(ctx context.Context, userID uuid.UUID, n int) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOldestSessions", ctx, userID, n)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeOldestSessions indicates an expected call of RevokeOldestSessions.
+func (mr *MockAuthRepositoryMockRecorder) RevokeOldestSessions(ctx, userID, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOldestSessions", reflect.TypeOf((*MockAuthRepository)(nil).RevokeOldestSessions), ctx, userID, n)
+}
+
 // RevokeSession mocks base method.
 func (m *MockAuthRepository) RevokeSession(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -229,6 +245,35 @@ func (mr *MockAuthRepositoryMockRecorder) RevokeSession(ctx, id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthRepository)(nil).RevokeSession), ctx, id)
 }
 
+// RevokeUserSessionsExcept mocks base method.
+func (m *MockAuthRepository) RevokeUserSessionsExcept(ctx context.Context, userID, keepID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessionsExcept", ctx, userID, keepID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeUserSessionsExcept indicates an expected call of RevokeUserSessionsExcept.
+func (mr *MockAuthRepositoryMockRecorder) RevokeUserSessionsExcept(ctx, userID, keepID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessionsExcept", reflect.TypeOf((*MockAuthRepository)(nil).RevokeUserSessionsExcept), ctx, userID, keepID)
+}
+
+// UpdateDeviceName mocks base method.
+func (m *MockAuthRepository) UpdateDeviceName(ctx context.Context, userID, sessionID uuid.UUID, deviceName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeviceName", ctx, userID, sessionID, deviceName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDeviceName indicates an expected call of UpdateDeviceName.
+func (mr *MockAuthRepositoryMockRecorder) UpdateDeviceName(ctx, userID, sessionID, deviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeviceName", reflect.TypeOf((*MockAuthRepository)(nil).UpdateDeviceName), ctx, userID, sessionID, deviceName)
+}
+
 // UpdateLastUsedAt mocks base method.
 func (m *MockAuthRepository) UpdateLastUsedAt(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -244,17 +289,55 @@ func (mr *MockAuthRepositoryMockRecorder) UpdateLastUsedAt(ctx, id any) *gomock.
 }
 
 // UpdateSessionRefresh mocks base method.
-func (m *MockAuthRepository) UpdateSessionRefresh(ctx context.Context, sessionID uuid.UUID, newTokenHash string, newExpiresAt time.Time) error {
+func (m *MockAuthRepository) UpdateSessionRefresh(ctx context.Context, sessionID uuid.UUID, newTokenHash string, newExpiresAt time.Time, ip string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSessionRefresh", ctx, sessionID, newTokenHash, newExpiresAt)
+	ret := m.ctrl.Call(m, "UpdateSessionRefresh", ctx, sessionID, newTokenHash, newExpiresAt, ip)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateSessionRefresh indicates an expected call of UpdateSessionRefresh.
-func (mr *MockAuthRepositoryMockRecorder) UpdateSessionRefresh(ctx, sessionID, newTokenHash, newExpiresAt any) *gomock.Call {
+func (mr *MockAuthRepositoryMockRecorder) UpdateSessionRefresh(ctx, sessionID, newTokenHash, newExpiresAt, ip any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSessionRefresh", reflect.TypeOf((*MockAuthRepository)(nil).UpdateSessionRefresh), ctx, sessionID, newTokenHash, newExpiresAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSessionRefresh", reflect.TypeOf((*MockAuthRepository)(nil).UpdateSessionRefresh), ctx, sessionID, newTokenHash, newExpiresAt, ip)
+}
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOutboxRepository) Create(ctx context.Context, ev entity.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, ev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOutboxRepositoryMockRecorder) Create(ctx, ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOutboxRepository)(nil).Create), ctx, ev)
 }
 
 // MockAuth is a mock of Auth interface.
@@ -325,6 +408,44 @@ func (mr *MockAuthMockRecorder) ParseRefreshToken(tokenString any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseRefreshToken", reflect.TypeOf((*MockAuth)(nil).ParseRefreshToken), tokenString)
 }
 
+// MockGeoLocator is a mock of GeoLocator interface.
+type MockGeoLocator struct {
+	ctrl     *gomock.Controller
+	recorder *MockGeoLocatorMockRecorder
+	isgomock struct{}
+}
+
+// MockGeoLocatorMockRecorder is the mock recorder for MockGeoLocator.
+type MockGeoLocatorMockRecorder struct {
+	mock *MockGeoLocator
+}
+
+// NewMockGeoLocator creates a new mock instance.
+func NewMockGeoLocator(ctrl *gomock.Controller) *MockGeoLocator {
+	mock := &MockGeoLocator{ctrl: ctrl}
+	mock.recorder = &MockGeoLocatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGeoLocator) EXPECT() *MockGeoLocatorMockRecorder {
+	return m.recorder
+}
+
+// Lookup mocks base method.
+func (m *MockGeoLocator) Lookup(ip string) *entity.Location {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", ip)
+	ret0, _ := ret[0].(*entity.Location)
+	return ret0
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockGeoLocatorMockRecorder) Lookup(ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockGeoLocator)(nil).Lookup), ip)
+}
+
 // MockHasher is a mock of Hasher interface.
 type MockHasher struct {
 	ctrl     *gomock.Controller
@@ -6,53 +6,84 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/4udiwe/avito-pvz/pkg/transactor"
 	"github.com/4udiwe/coworking/auth-service/internal/auth"
 	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	auth_repository "github.com/4udiwe/coworking/auth-service/internal/repository/auth"
 	user_repository "github.com/4udiwe/coworking/auth-service/internal/repository/user"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
-// MAX_ACTIVE_SESSIONS_PER_USER defines the maximum number of concurrent active sessions
-// for a single user at any given time.
-//
-// WHAT HAPPENS:
-// When user logs in on 6th device while already having 5 sessions:
-// 1. System detects limit exceeded
-// 2. Automatically revokes the oldest session
-// 3. New login succeeds, user has 5 sessions again
-// 4. Event logged for monitoring
-const MAX_ACTIVE_SESSIONS_PER_USER = 5
-
 type Service struct {
-	userRepo UserRepository
-	authRepo AuthRepository
-	tx       transactor.Transactor
-	auth     Auth
-	hasher   Hasher
-
+	userRepo   UserRepository
+	authRepo   AuthRepository
+	outboxRepo OutboxRepository
+	tx         transactor.Transactor
+	auth       Auth
+	hasher     Hasher
+	geo        GeoLocator
+
+	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
+	sessionPolicy   SessionPolicy
 }
 
 func New(
 	userRepo UserRepository,
 	authRepo AuthRepository,
+	outboxRepo OutboxRepository,
 	tx transactor.Transactor,
 	auth Auth,
 	hasher Hasher,
+	geo GeoLocator,
+	accessTokenTTL time.Duration,
 	refreshTokenTTL time.Duration,
+	sessionPolicy SessionPolicy,
 ) *Service {
 	return &Service{
 		userRepo:        userRepo,
 		authRepo:        authRepo,
+		outboxRepo:      outboxRepo,
 		tx:              tx,
 		auth:            auth,
 		hasher:          hasher,
+		geo:             geo,
+		accessTokenTTL:  accessTokenTTL,
 		refreshTokenTTL: refreshTokenTTL,
+		sessionPolicy:   sessionPolicy,
+	}
+}
+
+// revokeSession отзывает сессию и в той же транзакции пишет в outbox
+// событие auth.session.revoked. Resource-сервисы по нему перестают принимать
+// уже выданные access token этой сессии, не дожидаясь их exp.
+func (s *Service) revokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	if err := s.authRepo.RevokeSession(ctx, sessionID); err != nil {
+		return err
 	}
+
+	return s.publishSessionRevoked(ctx, sessionID)
+}
+
+func (s *Service) publishSessionRevoked(ctx context.Context, sessionID uuid.UUID) error {
+	now := time.Now()
+
+	return s.outboxRepo.Create(ctx, entity.OutboxEvent{
+		AggregateType: "auth",
+		AggregateID:   sessionID,
+		EventType:     "session.revoked",
+		Payload: map[string]any{
+			"sessionId": sessionID,
+			"revokedAt": now,
+			"expiresAt": now.Add(s.accessTokenTTL),
+		},
+		Status:    entity.OutboxStatus{ID: 1, Name: entity.OutboxStatusPending},
+		CreatedAt: now,
+	})
 }
 
 // generateDeviceFingerprint creates a unique identifier for a device based on userAgent and deviceInfo
@@ -149,6 +180,15 @@ func (s *Service) Register(
 			return fmt.Errorf("attach role: %w", err)
 		}
 
+		user.Roles = []entity.Role{{Code: entity.RoleCode(roleCode)}}
+		if err := s.outboxRepo.Create(
+			ctx,
+			entity.NewUserEvent(user.ID, entity.UserEventRegistered, entity.UserProfilePayload(user)),
+		); err != nil {
+			logrus.WithError(err).WithField("userID", user.ID).Error("Failed to create outbox event")
+			return fmt.Errorf("create outbox event: %w", err)
+		}
+
 		sessionID := uuid.New()
 
 		// Generate tokens
@@ -229,78 +269,126 @@ func (s *Service) Login(
 			return ErrInvalidCredentials
 		}
 
-		// ============================================
-		// NEW: Enforce session limit per user (MAX_ACTIVE_SESSIONS_PER_USER)
-		// ============================================
-		// WHAT THIS DOES:
-		// 1. Get all ACTIVE (non-revoked) sessions for this user
-		// 2. If user already has 5+ sessions, revoke the oldest one
-		// 3. This allows new login to proceed
-		//
-		// WHY:
-		// - Prevents unlimited session accumulation
-		// - User with 8 devices gets limited to 5 active sessions
-		// - When login on 6th device → oldest (1st) auto-revoked
-		// - User maintains their 5 most recent/active sessions
-		// ============================================
-		activeSessions, err := s.authRepo.GetUserSessions(ctx, user.ID, true)
-		if err != nil {
-			// If we can't fetch sessions, log but allow login anyway
-			// (better to have login succeed than fail due to DB issue)
-			logrus.WithError(err).WithField("user_id", user.ID).
-				Warn("Failed to check session limit during login, proceeding anyway")
-		} else if len(activeSessions) >= MAX_ACTIVE_SESSIONS_PER_USER {
-			// User has reached session limit
-			// Revoke the oldest session to make room for new one
-			if err := s.authRepo.DeleteOldestSessionByUser(ctx, user.ID); err != nil {
-				logrus.WithError(err).WithField("user_id", user.ID).
-					Warn("Failed to revoke oldest session")
-				// Don't fail login - new session creation may still succeed
-			} else {
-				// Log successful auto-revocation for monitoring and debugging
-				logrus.WithFields(logrus.Fields{
-					"user_id":         user.ID,
-					"email":           email,
-					"active_sessions": len(activeSessions),
-					"max_sessions":    MAX_ACTIVE_SESSIONS_PER_USER,
-					"user_agent":      userAgent,
-					"device_info":     deviceInfo,
-					"action":          "auto_revoke_oldest_session",
-				}).Info("User reached session limit, auto-revoked oldest session")
-			}
+		tokens, err = s.StartSession(ctx, user, userAgent, deviceInfo, ip)
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, ErrSessionLimitReached) {
+			return nil, err
 		}
+		return nil, ErrInvalidCredentials
+	}
 
-		sessionID := uuid.New()
+	return tokens, nil
+}
 
-		tokens, err = s.auth.GenerateTokens(user, sessionID)
-		if err != nil {
-			return ErrCannotGenerateTokens
-		}
+// StartSession открывает новую сессию пользователя, уже прошедшего
+// аутентификацию (пароль или SSO): соблюдает лимит активных сессий (SessionPolicy),
+// выпускает токены и сохраняет refresh token.
+//
+// Должен вызываться внутри транзакции вызывающего.
+func (s *Service) StartSession(
+	ctx context.Context,
+	user entity.User,
+	userAgent string,
+	deviceInfo string,
+	ip string,
+) (*auth.Tokens, error) {
 
-		deviceFingerprint := generateDeviceFingerprint(userAgent, deviceInfo)
+	if err := s.enforceSessionLimit(ctx, user, userAgent, deviceInfo); err != nil {
+		return nil, err
+	}
 
-		return s.authRepo.CreateSession(
-			ctx,
-			entity.Session{
-				ID:                sessionID,
-				UserID:            user.ID,
-				UserAgent:         userAgent,
-				IPAddress:         ip,
-				DeviceName:        &deviceInfo,
-				DeviceFingerprint: &deviceFingerprint,
-				ExpiresAt:         time.Now().Add(s.refreshTokenTTL),
-			},
-			s.auth.HashToken(tokens.RefreshToken),
-		)
-	})
+	sessionID := uuid.New()
 
+	tokens, err := s.auth.GenerateTokens(user, sessionID)
 	if err != nil {
-		return nil, ErrInvalidCredentials
+		return nil, ErrCannotGenerateTokens
+	}
+
+	deviceFingerprint := generateDeviceFingerprint(userAgent, deviceInfo)
+
+	if err := s.authRepo.CreateSession(
+		ctx,
+		entity.Session{
+			ID:                sessionID,
+			UserID:            user.ID,
+			UserAgent:         userAgent,
+			IPAddress:         ip,
+			DeviceName:        &deviceInfo,
+			DeviceFingerprint: &deviceFingerprint,
+			ExpiresAt:         time.Now().Add(s.refreshTokenTTL),
+		},
+		s.auth.HashToken(tokens.RefreshToken),
+	); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCannotSaveRefreshToken, err)
 	}
 
 	return tokens, nil
 }
 
+// enforceSessionLimit применяет SessionPolicy перед созданием новой сессии:
+// при достижении лимита либо отзывает самые старые сессии, освобождая
+// место для новой, либо отклоняет вход с ErrSessionLimitReached.
+//
+// Если список сессий получить не удалось, вход не блокируется: лучше
+// временно превысить лимит, чем не пустить пользователя из-за сбоя БД.
+func (s *Service) enforceSessionLimit(
+	ctx context.Context,
+	user entity.User,
+	userAgent string,
+	deviceInfo string,
+) error {
+	limit := s.sessionPolicy.Limit(user.Roles)
+	if limit == 0 {
+		return nil
+	}
+
+	activeSessions, err := s.authRepo.GetUserSessions(ctx, user.ID, true)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", user.ID).
+			Warn("Failed to check session limit during login, proceeding anyway")
+		return nil
+	}
+	if len(activeSessions) < limit {
+		return nil
+	}
+
+	fields := logrus.Fields{
+		"user_id":         user.ID,
+		"email":           user.Email,
+		"active_sessions": len(activeSessions),
+		"max_sessions":    limit,
+		"user_agent":      userAgent,
+		"device_info":     deviceInfo,
+	}
+
+	if s.sessionPolicy.RejectOnLimit {
+		logrus.WithFields(fields).Info("User reached session limit, login rejected")
+		return ErrSessionLimitReached
+	}
+
+	evicted, err := s.authRepo.RevokeOldestSessions(ctx, user.ID, len(activeSessions)-limit+1)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", user.ID).
+			Warn("Failed to revoke oldest sessions")
+		// Don't fail login - new session creation may still succeed
+		return nil
+	}
+
+	for _, sessionID := range evicted {
+		if err := s.publishSessionRevoked(ctx, sessionID); err != nil {
+			return ErrCannotRevokeSession
+		}
+	}
+
+	logrus.WithFields(fields).WithField("revoked", len(evicted)).
+		Info("User reached session limit, auto-revoked oldest sessions")
+
+	return nil
+}
+
 func (s *Service) Refresh(
 	ctx context.Context,
 	refreshToken string,
@@ -382,6 +470,7 @@ func (s *Service) Refresh(
 				session.ID,
 				s.auth.HashToken(tokens.RefreshToken),
 				newExpiresAt,
+				ip,
 			); err != nil {
 				logrus.WithError(err).WithField("session_id", session.ID).
 					Error("Failed to update session on refresh")
@@ -405,6 +494,10 @@ func (s *Service) Refresh(
 			logrus.WithError(err).WithField("session_id", session.ID).
 				Warn("Failed to revoke old session during refresh")
 			// Don't fail - new session may still be created
+		} else if err := s.publishSessionRevoked(ctx, session.ID); err != nil {
+			logrus.WithError(err).WithField("session_id", session.ID).
+				Error("Failed to publish session revocation during refresh")
+			return ErrCannotRevokeSession
 		}
 
 		newSessionID := uuid.New()
@@ -469,7 +562,9 @@ func (s *Service) Logout(
 		return ErrInvalidRefreshToken
 	}
 
-	return s.authRepo.RevokeSession(ctx, claims.SessionID)
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.revokeSession(ctx, claims.SessionID)
+	})
 }
 
 func (s *Service) GetUserSessions(
@@ -498,16 +593,115 @@ func (s *Service) GetUserSessions(
 		return nil, ErrCannotFetchSessions
 	}
 
+	for i := range sessions {
+		sessions[i].Location = s.locate(sessions[i].IPAddress)
+	}
+
 	return sessions, nil
 }
 
+func (s *Service) locate(ip string) *entity.Location {
+	if s.geo == nil {
+		return nil
+	}
+	return s.geo.Lookup(ip)
+}
+
+// GetSessionIPs возвращает историю адресов сессии пользователя с местоположением.
+// Чужая сессия неотличима от несуществующей — ErrSessionNotFound.
+func (s *Service) GetSessionIPs(
+	ctx context.Context,
+	userID uuid.UUID,
+	sessionID uuid.UUID,
+) ([]entity.SessionIP, error) {
+
+	session, err := s.authRepo.GetSessionByID(ctx, sessionID)
+	if err != nil || session.UserID != userID {
+		return nil, ErrSessionNotFound
+	}
+
+	ips, err := s.authRepo.GetSessionIPs(ctx, sessionID)
+	if err != nil {
+		logrus.WithError(err).WithField("session_id", sessionID).Error("Failed to fetch session IPs")
+		return nil, ErrCannotFetchSessions
+	}
+
+	for i := range ips {
+		ips[i].Location = s.locate(ips[i].IPAddress)
+	}
+
+	return ips, nil
+}
+
+// RenameSession задаёт пользовательское название устройства сессии.
+func (s *Service) RenameSession(
+	ctx context.Context,
+	userID uuid.UUID,
+	sessionID uuid.UUID,
+	deviceName string,
+) error {
+	deviceName = strings.TrimSpace(deviceName)
+	if deviceName == "" {
+		return ErrEmptyDeviceName
+	}
+
+	err := s.authRepo.UpdateDeviceName(ctx, userID, sessionID, deviceName)
+	if err != nil {
+		if errors.Is(err, auth_repository.ErrSessionNotFound) {
+			return ErrSessionNotFound
+		}
+		logrus.WithError(err).WithField("session_id", sessionID).Error("Failed to rename session")
+		return ErrCannotUpdateSession
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions отзывает все активные сессии пользователя, кроме текущей
+// (sessionId из access token), и возвращает количество отозванных.
+func (s *Service) RevokeOtherSessions(
+	ctx context.Context,
+	userID uuid.UUID,
+	currentSessionID uuid.UUID,
+) (int, error) {
+	logrus.WithFields(logrus.Fields{
+		"user_id":    userID,
+		"session_id": currentSessionID,
+	}).Info("RevokeOtherSessions attempt")
+
+	var revoked []uuid.UUID
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		revoked, err = s.authRepo.RevokeUserSessionsExcept(ctx, userID, currentSessionID)
+		if err != nil {
+			return err
+		}
+
+		for _, sessionID := range revoked {
+			if err := s.publishSessionRevoked(ctx, sessionID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Error("Failed to revoke other sessions")
+		return 0, ErrCannotRevokeSession
+	}
+
+	return len(revoked), nil
+}
+
 func (s *Service) RevokeSession(
 	ctx context.Context,
 	sessionID uuid.UUID,
 ) error {
 	logrus.WithField("session_id", sessionID).Info("RevokeSession attempt")
 
-	err := s.authRepo.RevokeSession(ctx, sessionID)
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.revokeSession(ctx, sessionID)
+	})
 	if err != nil {
 		logrus.WithError(err).WithField("session_id", sessionID).Error("Failed to revoke session")
 		return ErrCannotRevokeSession
@@ -12,6 +12,7 @@ import (
 
 	"github.com/4udiwe/coworking/auth-service/internal/auth"
 	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	auth_repository "github.com/4udiwe/coworking/auth-service/internal/repository/auth"
 	service "github.com/4udiwe/coworking/auth-service/internal/service/auth"
 	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
 
@@ -19,10 +20,13 @@ import (
 	m "github.com/4udiwe/coworking/auth-service/internal/service/auth/mocks"
 )
 
+var testSessionPolicy = service.SessionPolicy{MaxActive: 5}
+
 func TestService_Register(t *testing.T) {
 	type mocks struct {
 		ur           *m.MockUserRepository
 		ar           *m.MockAuthRepository
+		or           *m.MockOutboxRepository
 		tx           *mock_tx.MockTransactor
 		a            *m.MockAuth
 		h            *m.MockHasher
@@ -51,6 +55,12 @@ func TestService_Register(t *testing.T) {
 
 				m.ur.EXPECT().AttachRole(gomock.Any(), userID, "student").Return(nil)
 
+				m.or.EXPECT().
+					Create(gomock.Any(), gomock.Cond(func(ev entity.OutboxEvent) bool {
+						return ev.EventType == entity.UserEventRegistered && ev.AggregateID == userID
+					})).
+					Return(nil)
+
 				m.a.EXPECT().
 					GenerateTokens(gomock.Any(), gomock.Any()).
 					Return(&auth.Tokens{RefreshToken: "rt"}, nil)
@@ -101,6 +111,22 @@ func TestService_Register(t *testing.T) {
 			},
 			expectedErr: errors.New("fail"),
 		},
+		{
+			name: "outbox fail",
+			mockBehavior: func(m mocks) {
+				userID := uuid.New()
+				m.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				m.h.EXPECT().HashPassword("pass").Return("hash", nil)
+				m.ur.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return(entity.User{ID: userID}, nil)
+				m.ur.EXPECT().AttachRole(gomock.Any(), userID, "student").Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("fail"))
+			},
+			expectedErr: errors.New("fail"),
+		},
 		{
 			name: "transaction fail",
 			mockBehavior: func(m mocks) {
@@ -119,12 +145,13 @@ func TestService_Register(t *testing.T) {
 			m := mocks{
 				ur: m.NewMockUserRepository(ctrl),
 				ar: m.NewMockAuthRepository(ctrl),
+				or: m.NewMockOutboxRepository(ctrl),
 				tx: mock_tx.NewMockTransactor(ctrl),
 				a:  m.NewMockAuth(ctrl),
 				h:  m.NewMockHasher(ctrl),
 			}
 
-			s := service.New(m.ur, m.ar, m.tx, m.a, m.h, 7*24*time.Hour)
+			s := service.New(m.ur, m.ar, m.or, m.tx, m.a, m.h, nil, 2*time.Minute, 7*24*time.Hour, testSessionPolicy)
 
 			if tt.mockBehavior != nil {
 				tt.mockBehavior(m)
@@ -145,6 +172,7 @@ func TestService_Login(t *testing.T) {
 	type mocks struct {
 		ur *m.MockUserRepository
 		ar *m.MockAuthRepository
+		or *m.MockOutboxRepository
 		tx *mock_tx.MockTransactor
 		a  *m.MockAuth
 		h  *m.MockHasher
@@ -160,11 +188,117 @@ func TestService_Login(t *testing.T) {
 
 	tests := []struct {
 		name         string
+		policy       *service.SessionPolicy
 		mockBehavior func(m mocks)
 		expectedErr  error
 	}{
 		{
 			name: "success",
+			mockBehavior: func(m mocks) {
+
+				m.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				m.ur.EXPECT().
+					GetByEmail(gomock.Any(), "mail").
+					Return(user, nil)
+
+				m.h.EXPECT().
+					CheckPasswordHash("pass", "hash").
+					Return(true)
+
+				m.ar.EXPECT().
+					GetUserSessions(gomock.Any(), userID, true).
+					Return(nil, nil)
+
+				m.a.EXPECT().
+					GenerateTokens(user, gomock.Any()).
+					Return(&auth.Tokens{RefreshToken: "rt"}, nil)
+
+				m.a.EXPECT().
+					HashToken("rt").
+					Return("hashRT")
+
+				m.ar.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any(), "hashRT").
+					Return(nil)
+			},
+		},
+		{
+			name: "session limit reached revokes oldest session",
+			mockBehavior: func(m mocks) {
+
+				m.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				m.ur.EXPECT().
+					GetByEmail(gomock.Any(), "mail").
+					Return(user, nil)
+
+				m.h.EXPECT().
+					CheckPasswordHash("pass", "hash").
+					Return(true)
+
+				m.ar.EXPECT().
+					GetUserSessions(gomock.Any(), userID, true).
+					Return(make([]entity.Session, testSessionPolicy.MaxActive), nil)
+
+				evictedID := uuid.New()
+
+				m.ar.EXPECT().
+					RevokeOldestSessions(gomock.Any(), userID, 1).
+					Return([]uuid.UUID{evictedID}, nil)
+
+				m.or.EXPECT().
+					Create(gomock.Any(), gomock.Cond(func(ev entity.OutboxEvent) bool {
+						return ev.EventType == "session.revoked" && ev.AggregateID == evictedID
+					})).
+					Return(nil)
+
+				m.a.EXPECT().
+					GenerateTokens(user, gomock.Any()).
+					Return(&auth.Tokens{RefreshToken: "rt"}, nil)
+
+				m.a.EXPECT().
+					HashToken("rt").
+					Return("hashRT")
+
+				m.ar.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any(), "hashRT").
+					Return(nil)
+			},
+		},
+		{
+			name:   "session limit reached rejects login",
+			policy: &service.SessionPolicy{MaxActive: 2, RejectOnLimit: true},
+			mockBehavior: func(m mocks) {
+
+				m.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				m.ur.EXPECT().
+					GetByEmail(gomock.Any(), "mail").
+					Return(user, nil)
+
+				m.h.EXPECT().
+					CheckPasswordHash("pass", "hash").
+					Return(true)
+
+				m.ar.EXPECT().
+					GetUserSessions(gomock.Any(), userID, true).
+					Return(make([]entity.Session, 2), nil)
+			},
+			expectedErr: service.ErrSessionLimitReached,
+		},
+		{
+			name:   "unlimited policy skips session check",
+			policy: &service.SessionPolicy{},
 			mockBehavior: func(m mocks) {
 
 				m.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
@@ -244,6 +378,10 @@ func TestService_Login(t *testing.T) {
 					CheckPasswordHash("pass", "hash").
 					Return(true)
 
+				m.ar.EXPECT().
+					GetUserSessions(gomock.Any(), userID, true).
+					Return(nil, nil)
+
 				m.a.EXPECT().
 					GenerateTokens(user, gomock.Any()).
 					Return(nil, errors.New("fail"))
@@ -267,6 +405,10 @@ func TestService_Login(t *testing.T) {
 					CheckPasswordHash("pass", "hash").
 					Return(true)
 
+				m.ar.EXPECT().
+					GetUserSessions(gomock.Any(), userID, true).
+					Return(nil, nil)
+
 				m.a.EXPECT().
 					GenerateTokens(user, gomock.Any()).
 					Return(&auth.Tokens{RefreshToken: "rt"}, nil)
@@ -301,12 +443,18 @@ func TestService_Login(t *testing.T) {
 			m := mocks{
 				ur: m.NewMockUserRepository(ctrl),
 				ar: m.NewMockAuthRepository(ctrl),
+				or: m.NewMockOutboxRepository(ctrl),
 				tx: mock_tx.NewMockTransactor(ctrl),
 				a:  m.NewMockAuth(ctrl),
 				h:  m.NewMockHasher(ctrl),
 			}
 
-			s := service.New(m.ur, m.ar, m.tx, m.a, m.h, 7*24*time.Hour)
+			policy := testSessionPolicy
+			if tt.policy != nil {
+				policy = *tt.policy
+			}
+
+			s := service.New(m.ur, m.ar, m.or, m.tx, m.a, m.h, nil, 2*time.Minute, 7*24*time.Hour, policy)
 
 			if tt.mockBehavior != nil {
 				tt.mockBehavior(m)
@@ -328,6 +476,7 @@ func TestService_Refresh(t *testing.T) {
 	type mocks struct {
 		ur *m.MockUserRepository
 		ar *m.MockAuthRepository
+		or *m.MockOutboxRepository
 		tx *mock_tx.MockTransactor
 		a  *m.MockAuth
 		h  *m.MockHasher
@@ -351,8 +500,8 @@ func TestService_Refresh(t *testing.T) {
 
 				m.ar.EXPECT().GetSessionByID(gomock.Any(), sessionID).Return(validSession, nil)
 				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
-				m.ar.EXPECT().UpdateLastUsedAt(gomock.Any(), sessionID).Return(nil)
 				m.ar.EXPECT().RevokeSession(gomock.Any(), sessionID).Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 
 				m.a.EXPECT().GenerateTokens(user, gomock.Any()).Return(&auth.Tokens{RefreshToken: "newRT"}, nil)
 				m.a.EXPECT().HashToken("newRT").Return("hashNew")
@@ -434,7 +583,7 @@ func TestService_Refresh(t *testing.T) {
 			expectedErr: service.ErrUserInactive,
 		},
 		{
-			name: "update last used fail",
+			name: "publish revocation fail",
 			mockBehavior: func(m mocks, sessionID, userID uuid.UUID, user entity.User, validSession entity.Session) {
 				m.a.EXPECT().ParseRefreshToken("rt").Return(&auth.RefreshClaims{SessionID: sessionID}, nil)
 				m.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
@@ -442,12 +591,13 @@ func TestService_Refresh(t *testing.T) {
 				)
 				m.ar.EXPECT().GetSessionByID(gomock.Any(), sessionID).Return(validSession, nil)
 				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
-				m.ar.EXPECT().UpdateLastUsedAt(gomock.Any(), sessionID).Return(errors.New("fail"))
+				m.ar.EXPECT().RevokeSession(gomock.Any(), sessionID).Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("fail"))
 			},
-			expectedErr: service.ErrCannotUpdateSession,
+			expectedErr: service.ErrCannotRevokeSession,
 		},
 		{
-			name: "revoke session fail",
+			name: "revoke old session fail is not fatal",
 			mockBehavior: func(m mocks, sessionID, userID uuid.UUID, user entity.User, validSession entity.Session) {
 				m.a.EXPECT().ParseRefreshToken("rt").Return(&auth.RefreshClaims{SessionID: sessionID}, nil)
 				m.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
@@ -455,10 +605,11 @@ func TestService_Refresh(t *testing.T) {
 				)
 				m.ar.EXPECT().GetSessionByID(gomock.Any(), sessionID).Return(validSession, nil)
 				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
-				m.ar.EXPECT().UpdateLastUsedAt(gomock.Any(), sessionID).Return(nil)
 				m.ar.EXPECT().RevokeSession(gomock.Any(), sessionID).Return(errors.New("fail"))
+				m.a.EXPECT().GenerateTokens(user, gomock.Any()).Return(&auth.Tokens{RefreshToken: "newRT"}, nil)
+				m.a.EXPECT().HashToken("newRT").Return("hashNew")
+				m.ar.EXPECT().CreateSession(gomock.Any(), gomock.Any(), "hashNew").Return(nil)
 			},
-			expectedErr: service.ErrCannotRevokeSession,
 		},
 		{
 			name: "generate tokens fail",
@@ -469,8 +620,8 @@ func TestService_Refresh(t *testing.T) {
 				)
 				m.ar.EXPECT().GetSessionByID(gomock.Any(), sessionID).Return(validSession, nil)
 				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
-				m.ar.EXPECT().UpdateLastUsedAt(gomock.Any(), sessionID).Return(nil)
 				m.ar.EXPECT().RevokeSession(gomock.Any(), sessionID).Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 				m.a.EXPECT().GenerateTokens(user, gomock.Any()).Return(nil, errors.New("fail"))
 			},
 			expectedErr: service.ErrCannotGenerateTokens,
@@ -494,8 +645,8 @@ func TestService_Refresh(t *testing.T) {
 				)
 				m.ar.EXPECT().GetSessionByID(gomock.Any(), sessionID).Return(validSession, nil)
 				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
-				m.ar.EXPECT().UpdateLastUsedAt(gomock.Any(), sessionID).Return(nil)
 				m.ar.EXPECT().RevokeSession(gomock.Any(), sessionID).Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 				m.a.EXPECT().GenerateTokens(user, gomock.Any()).Return(&auth.Tokens{RefreshToken: "newRT"}, nil)
 				m.a.EXPECT().HashToken("newRT").Return("hashNew")
 				m.ar.EXPECT().CreateSession(gomock.Any(), gomock.Any(), "hashNew").Return(errors.New("fail"))
@@ -512,6 +663,7 @@ func TestService_Refresh(t *testing.T) {
 			m := mocks{
 				ur: m.NewMockUserRepository(ctrl),
 				ar: m.NewMockAuthRepository(ctrl),
+				or: m.NewMockOutboxRepository(ctrl),
 				tx: mock_tx.NewMockTransactor(ctrl),
 				a:  m.NewMockAuth(ctrl),
 				h:  m.NewMockHasher(ctrl),
@@ -531,7 +683,7 @@ func TestService_Refresh(t *testing.T) {
 				tt.mockBehavior(m, sessionID, userID, user, validSession)
 			}
 
-			s := service.New(m.ur, m.ar, m.tx, m.a, m.h, 7*24*time.Hour)
+			s := service.New(m.ur, m.ar, m.or, m.tx, m.a, m.h, nil, 2*time.Minute, 7*24*time.Hour, testSessionPolicy)
 			_, err := s.Refresh(context.Background(), "rt", "ua", "device", "ip")
 
 			if tt.expectedErr != nil {
@@ -547,6 +699,7 @@ func TestService_Refresh(t *testing.T) {
 func TestService_Logout(t *testing.T) {
 	type mocks struct {
 		ar *m.MockAuthRepository
+		or *m.MockOutboxRepository
 		a  *m.MockAuth
 		tx *mock_tx.MockTransactor
 	}
@@ -560,7 +713,13 @@ func TestService_Logout(t *testing.T) {
 			name: "success",
 			mockBehavior: func(m mocks) {
 				m.a.EXPECT().ParseRefreshToken("rt").Return(&auth.RefreshClaims{SessionID: uuid.New()}, nil)
+				m.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
 				m.ar.EXPECT().RevokeSession(gomock.Any(), gomock.Any()).Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Cond(func(ev entity.OutboxEvent) bool {
+					return ev.AggregateType == "auth" && ev.EventType == "session.revoked"
+				})).Return(nil)
 			},
 		},
 		{
@@ -574,6 +733,9 @@ func TestService_Logout(t *testing.T) {
 			name: "revoke session fail",
 			mockBehavior: func(m mocks) {
 				m.a.EXPECT().ParseRefreshToken("rt").Return(&auth.RefreshClaims{SessionID: uuid.New()}, nil)
+				m.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
 				m.ar.EXPECT().RevokeSession(gomock.Any(), gomock.Any()).Return(errors.New("fail"))
 			},
 			expectedErr: errors.New("fail"),
@@ -587,11 +749,12 @@ func TestService_Logout(t *testing.T) {
 
 			m := mocks{
 				ar: m.NewMockAuthRepository(ctrl),
+				or: m.NewMockOutboxRepository(ctrl),
 				a:  m.NewMockAuth(ctrl),
 				tx: mock_tx.NewMockTransactor(ctrl),
 			}
 
-			s := service.New(nil, m.ar, m.tx, m.a, nil, 7*24*time.Hour)
+			s := service.New(nil, m.ar, m.or, m.tx, m.a, nil, nil, 2*time.Minute, 7*24*time.Hour, testSessionPolicy)
 
 			if tt.mockBehavior != nil {
 				tt.mockBehavior(m)
@@ -614,7 +777,7 @@ func TestService_Logout(t *testing.T) {
 }
 
 func TestService_Register_Validation(t *testing.T) {
-	s := service.New(nil, nil, nil, nil, nil, 7*24*time.Hour)
+	s := service.New(nil, nil, nil, nil, nil, nil, nil, 2*time.Minute, 7*24*time.Hour, testSessionPolicy)
 	_, err := s.Register(context.Background(), "", "pass", "first", "last", "student", "ua", "device", "ip")
 	require.ErrorIs(t, err, service.ErrEmptyEmail)
 	_, err = s.Register(context.Background(), "mail", "", "first", "last", "student", "ua", "device", "ip")
@@ -624,7 +787,240 @@ func TestService_Register_Validation(t *testing.T) {
 }
 
 func TestService_Refresh_EmptyToken(t *testing.T) {
-	s := service.New(nil, nil, nil, nil, nil, 7*24*time.Hour)
+	s := service.New(nil, nil, nil, nil, nil, nil, nil, 2*time.Minute, 7*24*time.Hour, testSessionPolicy)
 	_, err := s.Refresh(context.Background(), "", "ua", "device", "ip")
 	require.ErrorIs(t, err, service.ErrEmptyToken)
 }
+
+func TestSessionPolicy_Limit(t *testing.T) {
+	policy := service.SessionPolicy{
+		MaxActive:       5,
+		MaxActiveByRole: map[string]int{"admin": 10, "teacher": 0, "student": 3},
+	}
+
+	require.Equal(t, 5, policy.Limit(nil))
+	require.Equal(t, 3, policy.Limit([]entity.Role{{Code: entity.RoleStudent}}))
+	require.Equal(t, 5, policy.Limit([]entity.Role{{Code: entity.RoleStudent}, {Code: entity.RoleManager}}))
+	require.Equal(t, 10, policy.Limit([]entity.Role{{Code: entity.RoleStudent}, {Code: entity.RoleAdmin}}))
+	require.Equal(t, 0, policy.Limit([]entity.Role{{Code: entity.RoleAdmin}, {Code: entity.RoleTeacher}}))
+}
+
+func TestService_RevokeOtherSessions(t *testing.T) {
+	type mocks struct {
+		ar *m.MockAuthRepository
+		or *m.MockOutboxRepository
+		tx *mock_tx.MockTransactor
+	}
+
+	userID := uuid.New()
+	currentID := uuid.New()
+	otherIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m mocks)
+		expected     int
+		expectedErr  error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(m mocks) {
+				m.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
+				m.ar.EXPECT().RevokeUserSessionsExcept(gomock.Any(), userID, currentID).Return(otherIDs, nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Cond(func(ev entity.OutboxEvent) bool {
+					return ev.EventType == "session.revoked" && ev.AggregateID != currentID
+				})).Return(nil).Times(len(otherIDs))
+			},
+			expected: len(otherIDs),
+		},
+		{
+			name: "no other sessions",
+			mockBehavior: func(m mocks) {
+				m.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
+				m.ar.EXPECT().RevokeUserSessionsExcept(gomock.Any(), userID, currentID).Return(nil, nil)
+			},
+			expected: 0,
+		},
+		{
+			name: "revoke fail",
+			mockBehavior: func(m mocks) {
+				m.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
+				m.ar.EXPECT().RevokeUserSessionsExcept(gomock.Any(), userID, currentID).Return(nil, errors.New("fail"))
+			},
+			expectedErr: service.ErrCannotRevokeSession,
+		},
+		{
+			name: "outbox fail",
+			mockBehavior: func(m mocks) {
+				m.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
+				m.ar.EXPECT().RevokeUserSessionsExcept(gomock.Any(), userID, currentID).Return(otherIDs, nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("fail"))
+			},
+			expectedErr: service.ErrCannotRevokeSession,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks{
+				ar: m.NewMockAuthRepository(ctrl),
+				or: m.NewMockOutboxRepository(ctrl),
+				tx: mock_tx.NewMockTransactor(ctrl),
+			}
+
+			s := service.New(nil, m.ar, m.or, m.tx, nil, nil, nil, 2*time.Minute, 7*24*time.Hour, testSessionPolicy)
+
+			tt.mockBehavior(m)
+
+			revoked, err := s.RevokeOtherSessions(context.Background(), userID, currentID)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, revoked)
+			}
+		})
+	}
+}
+
+func TestService_RenameSession(t *testing.T) {
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	tests := []struct {
+		name         string
+		deviceName   string
+		mockBehavior func(ar *m.MockAuthRepository)
+		expectedErr  error
+	}{
+		{
+			name:       "success",
+			deviceName: "  Рабочий ноутбук ",
+			mockBehavior: func(ar *m.MockAuthRepository) {
+				ar.EXPECT().UpdateDeviceName(gomock.Any(), userID, sessionID, "Рабочий ноутбук").Return(nil)
+			},
+		},
+		{
+			name:        "empty name",
+			deviceName:  "   ",
+			expectedErr: service.ErrEmptyDeviceName,
+		},
+		{
+			name:       "foreign or missing session",
+			deviceName: "Phone",
+			mockBehavior: func(ar *m.MockAuthRepository) {
+				ar.EXPECT().UpdateDeviceName(gomock.Any(), userID, sessionID, "Phone").
+					Return(auth_repository.ErrSessionNotFound)
+			},
+			expectedErr: service.ErrSessionNotFound,
+		},
+		{
+			name:       "repository fail",
+			deviceName: "Phone",
+			mockBehavior: func(ar *m.MockAuthRepository) {
+				ar.EXPECT().UpdateDeviceName(gomock.Any(), userID, sessionID, "Phone").Return(errors.New("fail"))
+			},
+			expectedErr: service.ErrCannotUpdateSession,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ar := m.NewMockAuthRepository(ctrl)
+			if tt.mockBehavior != nil {
+				tt.mockBehavior(ar)
+			}
+
+			s := service.New(nil, ar, nil, nil, nil, nil, nil, 2*time.Minute, 7*24*time.Hour, testSessionPolicy)
+
+			err := s.RenameSession(context.Background(), userID, sessionID, tt.deviceName)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_GetSessionIPs(t *testing.T) {
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	tests := []struct {
+		name         string
+		mockBehavior func(ar *m.MockAuthRepository, geo *m.MockGeoLocator)
+		expected     []entity.SessionIP
+		expectedErr  error
+	}{
+		{
+			name: "success with location",
+			mockBehavior: func(ar *m.MockAuthRepository, geo *m.MockGeoLocator) {
+				ar.EXPECT().GetSessionByID(gomock.Any(), sessionID).
+					Return(entity.Session{ID: sessionID, UserID: userID}, nil)
+				ar.EXPECT().GetSessionIPs(gomock.Any(), sessionID).
+					Return([]entity.SessionIP{{SessionID: sessionID, IPAddress: "1.2.3.4"}, {SessionID: sessionID, IPAddress: "10.0.0.1"}}, nil)
+				geo.EXPECT().Lookup("1.2.3.4").Return(&entity.Location{Country: "Россия", City: "Москва"})
+				geo.EXPECT().Lookup("10.0.0.1").Return(nil)
+			},
+			expected: []entity.SessionIP{
+				{SessionID: sessionID, IPAddress: "1.2.3.4", Location: &entity.Location{Country: "Россия", City: "Москва"}},
+				{SessionID: sessionID, IPAddress: "10.0.0.1"},
+			},
+		},
+		{
+			name: "foreign session",
+			mockBehavior: func(ar *m.MockAuthRepository, geo *m.MockGeoLocator) {
+				ar.EXPECT().GetSessionByID(gomock.Any(), sessionID).
+					Return(entity.Session{ID: sessionID, UserID: uuid.New()}, nil)
+			},
+			expectedErr: service.ErrSessionNotFound,
+		},
+		{
+			name: "session not found",
+			mockBehavior: func(ar *m.MockAuthRepository, geo *m.MockGeoLocator) {
+				ar.EXPECT().GetSessionByID(gomock.Any(), sessionID).
+					Return(entity.Session{}, errors.New("no rows"))
+			},
+			expectedErr: service.ErrSessionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ar := m.NewMockAuthRepository(ctrl)
+			geo := m.NewMockGeoLocator(ctrl)
+			tt.mockBehavior(ar, geo)
+
+			s := service.New(nil, ar, nil, nil, nil, nil, geo, 2*time.Minute, 7*24*time.Hour, testSessionPolicy)
+
+			ips, err := s.GetSessionIPs(context.Background(), userID, sessionID)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, ips)
+			}
+		})
+	}
+}
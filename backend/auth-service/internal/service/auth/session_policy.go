@@ -0,0 +1,43 @@
+package auth_service
+
+import "github.com/4udiwe/coworking/auth-service/internal/entity"
+
+/*
+SessionPolicy — лимит одновременных активных сессий пользователя.
+
+Лимит считается по ролям пользователя: для роли из MaxActiveByRole берётся её
+значение, для остальных — MaxActive; из них выбирается наибольший. 0 означает
+отсутствие ограничения.
+
+При достижении лимита новый вход либо отзывает самые старые сессии
+(по last_used_at), либо отклоняется с ErrSessionLimitReached, если
+RejectOnLimit = true.
+*/
+type SessionPolicy struct {
+	MaxActive       int
+	MaxActiveByRole map[string]int
+	RejectOnLimit   bool
+}
+
+// Limit возвращает лимит активных сессий для набора ролей; 0 — без ограничения.
+func (p SessionPolicy) Limit(roles []entity.Role) int {
+	if len(roles) == 0 {
+		return p.MaxActive
+	}
+
+	limit := -1
+	for _, role := range roles {
+		l, ok := p.MaxActiveByRole[string(role.Code)]
+		if !ok {
+			l = p.MaxActive
+		}
+		if l == 0 {
+			return 0
+		}
+		if l > limit {
+			limit = l
+		}
+	}
+
+	return limit
+}
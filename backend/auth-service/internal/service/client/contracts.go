@@ -0,0 +1,28 @@
+package client_service
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+)
+
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
+type ClientRepository interface {
+	Create(ctx context.Context, client entity.ServiceClient) (entity.ServiceClient, error)
+	Upsert(ctx context.Context, client entity.ServiceClient) error
+	GetByClientID(ctx context.Context, clientID string) (entity.ServiceClient, error)
+	List(ctx context.Context) ([]entity.ServiceClient, error)
+	SetActive(ctx context.Context, clientID string, active bool) error
+	TouchLastUsed(ctx context.Context, clientID string) error
+}
+
+type Hasher interface {
+	HashPassword(password string) (string, error)
+	CheckPasswordHash(password, hash string) bool
+}
+
+type TokenIssuer interface {
+	GenerateServiceToken(clientID string, scopes []string) (*auth.ServiceToken, error)
+}
@@ -0,0 +1,16 @@
+package client_service
+
+import "errors"
+
+var (
+	// Ошибки token endpoint (коды RFC 6749, 5.2)
+	ErrInvalidClient = errors.New("invalid_client")
+	ErrInvalidScope  = errors.New("invalid_scope")
+
+	ErrClientNotFound      = errors.New("service client not found")
+	ErrClientAlreadyExists = errors.New("service client already exists")
+	ErrEmptyScopes         = errors.New("scopes cannot be empty")
+	ErrCannotIssueToken    = errors.New("cannot issue service token")
+	ErrCannotCreateClient  = errors.New("cannot create service client")
+	ErrCannotFetchClients  = errors.New("cannot fetch service clients")
+)
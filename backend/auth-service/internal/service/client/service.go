@@ -0,0 +1,200 @@
+package client_service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	client_repository "github.com/4udiwe/coworking/auth-service/internal/repository/client"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+Service — OAuth2 client credentials для межсервисных вызовов.
+
+Сервис предъявляет client_id / client_secret и получает короткоживущий
+access token с claim client_id и запрошенными scope. Запросить можно
+только scope, выданные клиенту; без параметра scope выдаются все.
+*/
+type Service struct {
+	clientRepo ClientRepository
+	hasher     Hasher
+	issuer     TokenIssuer
+}
+
+func New(
+	clientRepo ClientRepository,
+	hasher Hasher,
+	issuer TokenIssuer,
+) *Service {
+	return &Service{
+		clientRepo: clientRepo,
+		hasher:     hasher,
+		issuer:     issuer,
+	}
+}
+
+// IssueToken проверяет учётные данные клиента и выпускает токен.
+// scope — список через пробел, как в запросе к token endpoint.
+func (s *Service) IssueToken(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+	scope string,
+) (*auth.ServiceToken, error) {
+
+	client, err := s.clientRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, client_repository.ErrClientNotFound) {
+			logrus.WithField("client_id", clientID).Warn("IssueToken: unknown client")
+			return nil, ErrInvalidClient
+		}
+		logrus.WithError(err).WithField("client_id", clientID).Error("IssueToken: failed to get client")
+		return nil, ErrCannotIssueToken
+	}
+
+	if !client.IsActive || !s.hasher.CheckPasswordHash(clientSecret, client.SecretHash) {
+		logrus.WithField("client_id", clientID).Warn("IssueToken: invalid client credentials")
+		return nil, ErrInvalidClient
+	}
+
+	scopes := strings.Fields(scope)
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+	for _, sc := range scopes {
+		if !slices.Contains(client.Scopes, sc) {
+			logrus.WithFields(logrus.Fields{
+				"client_id": clientID,
+				"scope":     sc,
+			}).Warn("IssueToken: scope not allowed")
+			return nil, ErrInvalidScope
+		}
+	}
+
+	token, err := s.issuer.GenerateServiceToken(client.ClientID, scopes)
+	if err != nil {
+		logrus.WithError(err).WithField("client_id", clientID).Error("IssueToken: failed to sign token")
+		return nil, ErrCannotIssueToken
+	}
+
+	if err := s.clientRepo.TouchLastUsed(ctx, client.ClientID); err != nil {
+		logrus.WithError(err).WithField("client_id", clientID).Warn("IssueToken: failed to update last_used_at")
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"client_id": clientID,
+		"scopes":    scopes,
+	}).Info("Service token issued")
+
+	return token, nil
+}
+
+// CreateClient регистрирует клиента и возвращает его секрет.
+// Секрет показывается один раз — в базе хранится только хеш.
+func (s *Service) CreateClient(
+	ctx context.Context,
+	clientID string,
+	name string,
+	scopes []string,
+) (entity.ServiceClient, string, error) {
+
+	if len(scopes) == 0 {
+		return entity.ServiceClient{}, "", ErrEmptyScopes
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		logrus.WithError(err).Error("CreateClient: failed to generate secret")
+		return entity.ServiceClient{}, "", ErrCannotCreateClient
+	}
+
+	hash, err := s.hasher.HashPassword(secret)
+	if err != nil {
+		logrus.WithError(err).Error("CreateClient: failed to hash secret")
+		return entity.ServiceClient{}, "", ErrCannotCreateClient
+	}
+
+	client, err := s.clientRepo.Create(ctx, entity.ServiceClient{
+		ClientID:   clientID,
+		Name:       name,
+		SecretHash: hash,
+		Scopes:     scopes,
+		IsActive:   true,
+	})
+	if err != nil {
+		if errors.Is(err, client_repository.ErrClientAlreadyExists) {
+			return entity.ServiceClient{}, "", ErrClientAlreadyExists
+		}
+		return entity.ServiceClient{}, "", ErrCannotCreateClient
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"client_id": clientID,
+		"scopes":    scopes,
+	}).Info("Service client created")
+
+	return client, secret, nil
+}
+
+// RegisterClient создаёт или обновляет клиента с заданным секретом.
+// Нужен для клиентов из конфига, секрет которых известен заранее.
+func (s *Service) RegisterClient(
+	ctx context.Context,
+	clientID string,
+	name string,
+	secret string,
+	scopes []string,
+) error {
+
+	hash, err := s.hasher.HashPassword(secret)
+	if err != nil {
+		return err
+	}
+
+	return s.clientRepo.Upsert(ctx, entity.ServiceClient{
+		ClientID:   clientID,
+		Name:       name,
+		SecretHash: hash,
+		Scopes:     scopes,
+		IsActive:   true,
+	})
+}
+
+func (s *Service) ListClients(ctx context.Context) ([]entity.ServiceClient, error) {
+	clients, err := s.clientRepo.List(ctx)
+	if err != nil {
+		return nil, ErrCannotFetchClients
+	}
+	return clients, nil
+}
+
+// SetClientActive отключает или включает клиента.
+// Уже выданные токены действуют до exp, поэтому TTL сервисных токенов короткий.
+func (s *Service) SetClientActive(ctx context.Context, clientID string, active bool) error {
+	logrus.WithFields(logrus.Fields{
+		"client_id": clientID,
+		"active":    active,
+	}).Info("SetClientActive called")
+
+	if err := s.clientRepo.SetActive(ctx, clientID, active); err != nil {
+		if errors.Is(err, client_repository.ErrClientNotFound) {
+			return ErrClientNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,152 @@
+package client_service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	client_repository "github.com/4udiwe/coworking/auth-service/internal/repository/client"
+	service "github.com/4udiwe/coworking/auth-service/internal/service/client"
+
+	m "github.com/4udiwe/coworking/auth-service/internal/service/client/mocks"
+)
+
+func TestService_IssueToken(t *testing.T) {
+	type mocks struct {
+		cr *m.MockClientRepository
+		h  *m.MockHasher
+		ti *m.MockTokenIssuer
+	}
+
+	client := entity.ServiceClient{
+		ClientID:   "booking-service",
+		SecretHash: "hash",
+		Scopes:     []string{"users.read", "bookings.manage"},
+		IsActive:   true,
+	}
+
+	tests := []struct {
+		name         string
+		scope        string
+		mockBehavior func(m mocks)
+		expectedErr  error
+	}{
+		{
+			name: "all client scopes by default",
+			mockBehavior: func(m mocks) {
+				m.cr.EXPECT().GetByClientID(gomock.Any(), "booking-service").Return(client, nil)
+				m.h.EXPECT().CheckPasswordHash("secret", "hash").Return(true)
+				m.ti.EXPECT().GenerateServiceToken("booking-service", client.Scopes).
+					Return(&auth.ServiceToken{AccessToken: "token"}, nil)
+				m.cr.EXPECT().TouchLastUsed(gomock.Any(), "booking-service").Return(nil)
+			},
+		},
+		{
+			name:  "requested subset of scopes",
+			scope: "users.read",
+			mockBehavior: func(m mocks) {
+				m.cr.EXPECT().GetByClientID(gomock.Any(), "booking-service").Return(client, nil)
+				m.h.EXPECT().CheckPasswordHash("secret", "hash").Return(true)
+				m.ti.EXPECT().GenerateServiceToken("booking-service", []string{"users.read"}).
+					Return(&auth.ServiceToken{AccessToken: "token"}, nil)
+				m.cr.EXPECT().TouchLastUsed(gomock.Any(), "booking-service").Return(errors.New("db down"))
+			},
+		},
+		{
+			name:  "scope not granted to client",
+			scope: "users.read users.manage",
+			mockBehavior: func(m mocks) {
+				m.cr.EXPECT().GetByClientID(gomock.Any(), "booking-service").Return(client, nil)
+				m.h.EXPECT().CheckPasswordHash("secret", "hash").Return(true)
+			},
+			expectedErr: service.ErrInvalidScope,
+		},
+		{
+			name: "unknown client",
+			mockBehavior: func(m mocks) {
+				m.cr.EXPECT().GetByClientID(gomock.Any(), "booking-service").
+					Return(entity.ServiceClient{}, client_repository.ErrClientNotFound)
+			},
+			expectedErr: service.ErrInvalidClient,
+		},
+		{
+			name: "wrong secret",
+			mockBehavior: func(m mocks) {
+				m.cr.EXPECT().GetByClientID(gomock.Any(), "booking-service").Return(client, nil)
+				m.h.EXPECT().CheckPasswordHash("secret", "hash").Return(false)
+			},
+			expectedErr: service.ErrInvalidClient,
+		},
+		{
+			name: "inactive client",
+			mockBehavior: func(m mocks) {
+				inactive := client
+				inactive.IsActive = false
+				m.cr.EXPECT().GetByClientID(gomock.Any(), "booking-service").Return(inactive, nil)
+			},
+			expectedErr: service.ErrInvalidClient,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mm := mocks{
+				cr: m.NewMockClientRepository(ctrl),
+				h:  m.NewMockHasher(ctrl),
+				ti: m.NewMockTokenIssuer(ctrl),
+			}
+
+			tt.mockBehavior(mm)
+
+			s := service.New(mm.cr, mm.h, mm.ti)
+
+			token, err := s.IssueToken(context.Background(), "booking-service", "secret", tt.scope)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "token", token.AccessToken)
+		})
+	}
+}
+
+func TestService_CreateClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	cr := m.NewMockClientRepository(ctrl)
+	h := m.NewMockHasher(ctrl)
+
+	var secret string
+	h.EXPECT().HashPassword(gomock.Any()).DoAndReturn(func(s string) (string, error) {
+		secret = s
+		return "hash", nil
+	})
+	cr.EXPECT().Create(gomock.Any(), gomock.Cond(func(c entity.ServiceClient) bool {
+		return c.ClientID == "booking-service" && c.SecretHash == "hash" && c.IsActive
+	})).DoAndReturn(func(_ context.Context, c entity.ServiceClient) (entity.ServiceClient, error) {
+		return c, nil
+	})
+
+	s := service.New(cr, h, nil)
+
+	client, returned, err := s.CreateClient(context.Background(), "booking-service", "Booking", []string{"users.read"})
+
+	require.NoError(t, err)
+	require.Equal(t, secret, returned)
+	require.NotEmpty(t, returned)
+	require.NotEqual(t, "hash", returned)
+	require.Equal(t, []string{"users.read"}, client.Scopes)
+
+	_, _, err = s.CreateClient(context.Background(), "other", "Other", nil)
+	require.ErrorIs(t, err, service.ErrEmptyScopes)
+}
@@ -0,0 +1,25 @@
+package group_service
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
+type GroupRepository interface {
+	Create(ctx context.Context, group entity.Group) (entity.Group, error)
+	GetByID(ctx context.Context, groupID uuid.UUID) (entity.Group, error)
+	List(ctx context.Context) ([]entity.Group, error)
+	Update(ctx context.Context, group entity.Group) error
+	Delete(ctx context.Context, groupID uuid.UUID) error
+	AddMembers(ctx context.Context, groupID uuid.UUID, userIDs []uuid.UUID) ([]uuid.UUID, error)
+	RemoveMember(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) error
+	ListMembers(ctx context.Context, groupID uuid.UUID) ([]entity.User, error)
+}
+
+type OutboxRepository interface {
+	Create(ctx context.Context, ev entity.OutboxEvent) error
+}
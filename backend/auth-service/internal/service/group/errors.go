@@ -0,0 +1,16 @@
+package group_service
+
+import "errors"
+
+var (
+	ErrGroupNotFound      = errors.New("group not found")
+	ErrGroupAlreadyExists = errors.New("group with this name already exists")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrMemberNotFound     = errors.New("user is not a member of the group")
+	ErrEmptyMembers       = errors.New("user ids cannot be empty")
+
+	ErrCannotFetchGroups   = errors.New("cannot fetch groups")
+	ErrCannotSaveGroup     = errors.New("cannot save group")
+	ErrCannotDeleteGroup   = errors.New("cannot delete group")
+	ErrCannotUpdateMembers = errors.New("cannot update group members")
+)
@@ -0,0 +1,199 @@
+package group_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/transactor"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	group_repository "github.com/4udiwe/coworking/auth-service/internal/repository/group"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+Service — группы пользователей (потоки, кафедры, лаборатории).
+
+Группы не дают прав: их ID попадают в claim groups access token,
+и booking-service по ним ограничивает бронирование коворкингов и мест.
+Любое изменение состава группы отзывает access token затронутых
+пользователей (auth.user.permissions_changed), чтобы новый набор групп
+применился сразу, а не после истечения токена.
+*/
+type Service struct {
+	groupRepo  GroupRepository
+	outboxRepo OutboxRepository
+	tx         transactor.Transactor
+
+	accessTokenTTL time.Duration
+}
+
+func New(
+	groupRepo GroupRepository,
+	outboxRepo OutboxRepository,
+	tx transactor.Transactor,
+	accessTokenTTL time.Duration,
+) *Service {
+	return &Service{
+		groupRepo:      groupRepo,
+		outboxRepo:     outboxRepo,
+		tx:             tx,
+		accessTokenTTL: accessTokenTTL,
+	}
+}
+
+func (s *Service) CreateGroup(ctx context.Context, name, description string) (entity.Group, error) {
+	logrus.WithField("name", name).Info("CreateGroup called")
+
+	group, err := s.groupRepo.Create(ctx, entity.Group{Name: name, Description: description})
+	if err != nil {
+		if errors.Is(err, group_repository.ErrGroupAlreadyExists) {
+			return entity.Group{}, ErrGroupAlreadyExists
+		}
+		logrus.WithError(err).Error("failed to create group")
+		return entity.Group{}, ErrCannotSaveGroup
+	}
+
+	return group, nil
+}
+
+func (s *Service) ListGroups(ctx context.Context) ([]entity.Group, error) {
+	groups, err := s.groupRepo.List(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("failed to list groups")
+		return nil, ErrCannotFetchGroups
+	}
+	return groups, nil
+}
+
+func (s *Service) UpdateGroup(ctx context.Context, groupID uuid.UUID, name, description string) error {
+	logrus.WithFields(logrus.Fields{"groupID": groupID, "name": name}).Info("UpdateGroup called")
+
+	err := s.groupRepo.Update(ctx, entity.Group{ID: groupID, Name: name, Description: description})
+	if err != nil {
+		switch {
+		case errors.Is(err, group_repository.ErrGroupNotFound):
+			return ErrGroupNotFound
+		case errors.Is(err, group_repository.ErrGroupAlreadyExists):
+			return ErrGroupAlreadyExists
+		}
+		logrus.WithError(err).Error("failed to update group")
+		return ErrCannotSaveGroup
+	}
+
+	return nil
+}
+
+// DeleteGroup удаляет группу. Коворкинги, ограниченные только этой группой,
+// становятся недоступны обычным пользователям, пока ограничение не изменят.
+func (s *Service) DeleteGroup(ctx context.Context, groupID uuid.UUID) error {
+	logrus.WithField("groupID", groupID).Info("DeleteGroup called")
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		members, err := s.groupRepo.ListMembers(ctx, groupID)
+		if err != nil {
+			return fmt.Errorf("list members: %w", err)
+		}
+
+		if err := s.groupRepo.Delete(ctx, groupID); err != nil {
+			return err
+		}
+
+		return s.publishUsersRevoked(ctx, lo.Map(members, func(u entity.User, _ int) uuid.UUID { return u.ID }))
+	})
+	if err != nil {
+		if errors.Is(err, group_repository.ErrGroupNotFound) {
+			return ErrGroupNotFound
+		}
+		logrus.WithError(err).Error("failed to delete group")
+		return ErrCannotDeleteGroup
+	}
+
+	return nil
+}
+
+func (s *Service) ListMembers(ctx context.Context, groupID uuid.UUID) ([]entity.User, error) {
+	if _, err := s.groupRepo.GetByID(ctx, groupID); err != nil {
+		if errors.Is(err, group_repository.ErrGroupNotFound) {
+			return nil, ErrGroupNotFound
+		}
+		logrus.WithError(err).Error("failed to get group")
+		return nil, ErrCannotFetchGroups
+	}
+
+	members, err := s.groupRepo.ListMembers(ctx, groupID)
+	if err != nil {
+		logrus.WithError(err).Error("failed to list group members")
+		return nil, ErrCannotFetchGroups
+	}
+	return members, nil
+}
+
+// AddMembers добавляет пользователей в группу. Уже состоящие в ней пропускаются.
+func (s *Service) AddMembers(ctx context.Context, groupID uuid.UUID, userIDs []uuid.UUID) error {
+	logrus.WithFields(logrus.Fields{"groupID": groupID, "users": len(userIDs)}).Info("AddMembers called")
+
+	if len(userIDs) == 0 {
+		return ErrEmptyMembers
+	}
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		added, err := s.groupRepo.AddMembers(ctx, groupID, lo.Uniq(userIDs))
+		if err != nil {
+			return err
+		}
+		return s.publishUsersRevoked(ctx, added)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, group_repository.ErrGroupNotFound):
+			return ErrGroupNotFound
+		case errors.Is(err, group_repository.ErrUserNotFound):
+			return ErrUserNotFound
+		}
+		logrus.WithError(err).Error("failed to add group members")
+		return ErrCannotUpdateMembers
+	}
+
+	return nil
+}
+
+func (s *Service) RemoveMember(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) error {
+	logrus.WithFields(logrus.Fields{"groupID": groupID, "userID": userID}).Info("RemoveMember called")
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.groupRepo.RemoveMember(ctx, groupID, userID); err != nil {
+			return err
+		}
+		return s.publishUsersRevoked(ctx, []uuid.UUID{userID})
+	})
+	if err != nil {
+		if errors.Is(err, group_repository.ErrMemberNotFound) {
+			return ErrMemberNotFound
+		}
+		logrus.WithError(err).Error("failed to remove group member")
+		return ErrCannotUpdateMembers
+	}
+
+	return nil
+}
+
+// publishUsersRevoked — то же событие, что при смене ролей: access token
+// с устаревшим claim groups перестают приниматься.
+func (s *Service) publishUsersRevoked(ctx context.Context, userIDs []uuid.UUID) error {
+	now := time.Now()
+	for _, userID := range userIDs {
+		ev := entity.NewUserEvent(userID, entity.UserEventPermissionsChanged, map[string]any{
+			"userId":    userID,
+			"revokedAt": now,
+			"expiresAt": now.Add(s.accessTokenTTL),
+		})
+		if err := s.outboxRepo.Create(ctx, ev); err != nil {
+			return fmt.Errorf("create outbox event: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package group_service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	group_repository "github.com/4udiwe/coworking/auth-service/internal/repository/group"
+	service "github.com/4udiwe/coworking/auth-service/internal/service/group"
+
+	mock_tx "github.com/4udiwe/coworking/auth-service/internal/mocks"
+	m "github.com/4udiwe/coworking/auth-service/internal/service/group/mocks"
+)
+
+type mocks struct {
+	gr *m.MockGroupRepository
+	or *m.MockOutboxRepository
+	tx *mock_tx.MockTransactor
+}
+
+func newMocks(ctrl *gomock.Controller) mocks {
+	return mocks{
+		gr: m.NewMockGroupRepository(ctrl),
+		or: m.NewMockOutboxRepository(ctrl),
+		tx: mock_tx.NewMockTransactor(ctrl),
+	}
+}
+
+func newService(mm mocks) *service.Service {
+	return service.New(mm.gr, mm.or, mm.tx, 2*time.Minute)
+}
+
+func withTx(mm mocks) {
+	mm.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func revokedFor(userID uuid.UUID) gomock.Matcher {
+	return gomock.Cond(func(ev entity.OutboxEvent) bool {
+		return ev.EventType == entity.UserEventPermissionsChanged && ev.AggregateID == userID
+	})
+}
+
+func TestService_AddMembers(t *testing.T) {
+	groupID := uuid.New()
+	newUser := uuid.New()
+	existingMember := uuid.New()
+
+	tests := []struct {
+		name         string
+		userIDs      []uuid.UUID
+		mockBehavior func(m mocks)
+		expectedErr  error
+	}{
+		{
+			name:    "only added users revoked",
+			userIDs: []uuid.UUID{newUser, existingMember, newUser},
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.gr.EXPECT().AddMembers(gomock.Any(), groupID, []uuid.UUID{newUser, existingMember}).
+					Return([]uuid.UUID{newUser}, nil)
+				m.or.EXPECT().Create(gomock.Any(), revokedFor(newUser)).Return(nil)
+			},
+		},
+		{
+			name:         "empty list",
+			mockBehavior: func(m mocks) {},
+			expectedErr:  service.ErrEmptyMembers,
+		},
+		{
+			name:    "unknown user",
+			userIDs: []uuid.UUID{newUser},
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.gr.EXPECT().AddMembers(gomock.Any(), groupID, gomock.Any()).
+					Return(nil, group_repository.ErrUserNotFound)
+			},
+			expectedErr: service.ErrUserNotFound,
+		},
+		{
+			name:    "outbox fails",
+			userIDs: []uuid.UUID{newUser},
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.gr.EXPECT().AddMembers(gomock.Any(), groupID, gomock.Any()).
+					Return([]uuid.UUID{newUser}, nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("fail"))
+			},
+			expectedErr: service.ErrCannotUpdateMembers,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mm := newMocks(ctrl)
+			tt.mockBehavior(mm)
+
+			err := newService(mm).AddMembers(context.Background(), groupID, tt.userIDs)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_DeleteGroup(t *testing.T) {
+	groupID := uuid.New()
+	first := uuid.New()
+	second := uuid.New()
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m mocks)
+		expectedErr  error
+	}{
+		{
+			name: "members revoked",
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.gr.EXPECT().ListMembers(gomock.Any(), groupID).
+					Return([]entity.User{{ID: first}, {ID: second}}, nil)
+				m.gr.EXPECT().Delete(gomock.Any(), groupID).Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), revokedFor(first)).Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), revokedFor(second)).Return(nil)
+			},
+		},
+		{
+			name: "group not found",
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.gr.EXPECT().ListMembers(gomock.Any(), groupID).Return(nil, nil)
+				m.gr.EXPECT().Delete(gomock.Any(), groupID).Return(group_repository.ErrGroupNotFound)
+			},
+			expectedErr: service.ErrGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mm := newMocks(ctrl)
+			tt.mockBehavior(mm)
+
+			err := newService(mm).DeleteGroup(context.Background(), groupID)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_RemoveMember(t *testing.T) {
+	groupID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m mocks)
+		expectedErr  error
+	}{
+		{
+			name: "member removed and revoked",
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.gr.EXPECT().RemoveMember(gomock.Any(), groupID, userID).Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), revokedFor(userID)).Return(nil)
+			},
+		},
+		{
+			name: "not a member",
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.gr.EXPECT().RemoveMember(gomock.Any(), groupID, userID).Return(group_repository.ErrMemberNotFound)
+			},
+			expectedErr: service.ErrMemberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mm := newMocks(ctrl)
+			tt.mockBehavior(mm)
+
+			err := newService(mm).RemoveMember(context.Background(), groupID, userID)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package impersonation_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
+type ImpersonationRepository interface {
+	Create(ctx context.Context, imp entity.Impersonation) (entity.Impersonation, error)
+	List(ctx context.Context, actorID *uuid.UUID, userID *uuid.UUID, limit int) ([]entity.Impersonation, error)
+	End(ctx context.Context, id uuid.UUID) (entity.Impersonation, error)
+}
+
+type UserRepository interface {
+	GetByID(ctx context.Context, userID uuid.UUID) (entity.User, error)
+}
+
+type OutboxRepository interface {
+	Create(ctx context.Context, ev entity.OutboxEvent) error
+}
+
+type Auth interface {
+	GenerateImpersonationToken(user entity.User, actor entity.User, sessionID uuid.UUID, allowWrite bool, ttl time.Duration) (*auth.ExchangedToken, error)
+}
@@ -0,0 +1,15 @@
+package impersonation_service
+
+import "errors"
+
+var (
+	ErrUserNotFound          = errors.New("user not found")
+	ErrUserInactive          = errors.New("user is inactive")
+	ErrSelfImpersonation     = errors.New("cannot impersonate yourself")
+	ErrPrivilegedUser        = errors.New("user has permissions the actor does not have")
+	ErrImpersonationNotFound = errors.New("impersonation not found or already ended")
+
+	ErrCannotImpersonate         = errors.New("cannot impersonate user")
+	ErrCannotEndImpersonation    = errors.New("cannot end impersonation")
+	ErrCannotFetchImpersonations = errors.New("cannot fetch impersonations")
+)
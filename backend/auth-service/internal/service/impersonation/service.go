@@ -0,0 +1,215 @@
+package impersonation_service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/transactor"
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	impersonation_repository "github.com/4udiwe/coworking/auth-service/internal/repository/impersonation"
+	user_repository "github.com/4udiwe/coworking/auth-service/internal/repository/user"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// EventUserImpersonated — сотрудник поддержки вошёл от имени пользователя
+// (auth.events, префикс "auth.").
+const EventUserImpersonated = "user.impersonated"
+
+// Сколько записей аудита отдаёт List.
+const listLimit = 100
+
+/*
+Service — вход сотрудника поддержки от имени пользователя ("view as user").
+
+Выдаётся только короткий access token без refresh: права и группы
+пользователя, в claim act — сотрудник. Без allowWrite сервисы пропускают
+только чтение (middleware.AuthMiddleware), каждый запрос по такому токену
+попадает в их аудит-лог. Сама выдача сохраняется в impersonations.
+
+Нельзя войти от имени пользователя с правами, которых нет у сотрудника,
+иначе выдача стала бы способом повысить себе права.
+*/
+type Service struct {
+	impersonationRepo ImpersonationRepository
+	userRepo          UserRepository
+	outboxRepo        OutboxRepository
+	auth              Auth
+	tx                transactor.Transactor
+
+	ttl time.Duration
+}
+
+func New(
+	impersonationRepo ImpersonationRepository,
+	userRepo UserRepository,
+	outboxRepo OutboxRepository,
+	auth Auth,
+	tx transactor.Transactor,
+	ttl time.Duration,
+) *Service {
+	return &Service{
+		impersonationRepo: impersonationRepo,
+		userRepo:          userRepo,
+		outboxRepo:        outboxRepo,
+		auth:              auth,
+		tx:                tx,
+		ttl:               ttl,
+	}
+}
+
+// Start выдаёт сотруднику actorID access token пользователя userID.
+func (s *Service) Start(
+	ctx context.Context,
+	actorID uuid.UUID,
+	userID uuid.UUID,
+	reason string,
+	allowWrite bool,
+) (entity.Impersonation, *auth.ExchangedToken, error) {
+
+	logrus.WithFields(logrus.Fields{
+		"actor_id":    actorID,
+		"user_id":     userID,
+		"allow_write": allowWrite,
+	}).Info("Start impersonation called")
+
+	if actorID == userID {
+		return entity.Impersonation{}, nil, ErrSelfImpersonation
+	}
+
+	var (
+		imp   entity.Impersonation
+		token *auth.ExchangedToken
+	)
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		actor, err := s.userRepo.GetByID(ctx, actorID)
+		if err != nil {
+			return err
+		}
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, user_repository.ErrUserNotFound) {
+				return ErrUserNotFound
+			}
+			return err
+		}
+		if !user.IsActive {
+			return ErrUserInactive
+		}
+		if !covers(actor.Permissions, user.Permissions) {
+			logrus.WithFields(logrus.Fields{
+				"actor_id": actorID,
+				"user_id":  userID,
+			}).Warn("Start impersonation: user is more privileged than actor")
+			return ErrPrivilegedUser
+		}
+
+		imp, err = s.impersonationRepo.Create(ctx, entity.Impersonation{
+			ActorID:    actorID,
+			UserID:     userID,
+			Reason:     reason,
+			AllowWrite: allowWrite,
+			ExpiresAt:  time.Now().Add(s.ttl),
+		})
+		if err != nil {
+			return err
+		}
+
+		token, err = s.auth.GenerateImpersonationToken(user, actor, imp.ID, allowWrite, s.ttl)
+		if err != nil {
+			return err
+		}
+
+		return s.outboxRepo.Create(ctx, entity.NewUserEvent(userID, EventUserImpersonated, map[string]any{
+			"userId":          userID,
+			"actorId":         actorID,
+			"impersonationId": imp.ID,
+			"reason":          reason,
+			"allowWrite":      allowWrite,
+			"expiresAt":       imp.ExpiresAt,
+		}))
+	})
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) ||
+			errors.Is(err, ErrUserInactive) ||
+			errors.Is(err, ErrPrivilegedUser) {
+			return entity.Impersonation{}, nil, err
+		}
+		logrus.WithError(err).Error("Start impersonation failed")
+		return entity.Impersonation{}, nil, ErrCannotImpersonate
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"actor_id":         actorID,
+		"user_id":          userID,
+		"impersonation_id": imp.ID,
+	}).Info("Impersonation started")
+
+	return imp, token, nil
+}
+
+// End досрочно завершает выдачу и публикует auth.session.revoked с её ID,
+// чтобы сервисы сразу отклонили выданный токен.
+func (s *Service) End(ctx context.Context, id uuid.UUID) error {
+	logrus.WithField("impersonation_id", id).Info("End impersonation called")
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		imp, err := s.impersonationRepo.End(ctx, id)
+		if err != nil {
+			if errors.Is(err, impersonation_repository.ErrImpersonationNotFound) {
+				return ErrImpersonationNotFound
+			}
+			return err
+		}
+
+		now := time.Now()
+		return s.outboxRepo.Create(ctx, entity.OutboxEvent{
+			AggregateType: "auth",
+			AggregateID:   imp.ID,
+			EventType:     "session.revoked",
+			Payload: map[string]any{
+				"sessionId": imp.ID,
+				"revokedAt": now,
+				"expiresAt": imp.ExpiresAt,
+			},
+			Status:    entity.OutboxStatus{ID: 1, Name: entity.OutboxStatusPending},
+			CreatedAt: now,
+		})
+	})
+	if err != nil {
+		if errors.Is(err, ErrImpersonationNotFound) {
+			return err
+		}
+		logrus.WithError(err).Error("End impersonation failed")
+		return ErrCannotEndImpersonation
+	}
+
+	return nil
+}
+
+// List возвращает журнал выдач, фильтры необязательны.
+func (s *Service) List(ctx context.Context, actorID *uuid.UUID, userID *uuid.UUID) ([]entity.Impersonation, error) {
+	imps, err := s.impersonationRepo.List(ctx, actorID, userID, listLimit)
+	if err != nil {
+		return nil, ErrCannotFetchImpersonations
+	}
+	return imps, nil
+}
+
+// covers — есть ли у сотрудника все права пользователя.
+// Глобальное право покрывает и его ограничение любым коворкингом.
+func covers(actor []entity.Permission, user []entity.Permission) bool {
+	return lo.EveryBy(user, func(p entity.Permission) bool {
+		return lo.ContainsBy(actor, func(a entity.Permission) bool {
+			if a.Code != p.Code {
+				return false
+			}
+			return a.CoworkingID == nil ||
+				(p.CoworkingID != nil && *a.CoworkingID == *p.CoworkingID)
+		})
+	})
+}
@@ -0,0 +1,209 @@
+package impersonation_service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	impersonation_repository "github.com/4udiwe/coworking/auth-service/internal/repository/impersonation"
+	user_repository "github.com/4udiwe/coworking/auth-service/internal/repository/user"
+	service "github.com/4udiwe/coworking/auth-service/internal/service/impersonation"
+
+	mock_tx "github.com/4udiwe/coworking/auth-service/internal/mocks"
+	m "github.com/4udiwe/coworking/auth-service/internal/service/impersonation/mocks"
+)
+
+type mocks struct {
+	ir *m.MockImpersonationRepository
+	ur *m.MockUserRepository
+	or *m.MockOutboxRepository
+	a  *m.MockAuth
+	tx *mock_tx.MockTransactor
+}
+
+func newMocks(ctrl *gomock.Controller) mocks {
+	return mocks{
+		ir: m.NewMockImpersonationRepository(ctrl),
+		ur: m.NewMockUserRepository(ctrl),
+		or: m.NewMockOutboxRepository(ctrl),
+		a:  m.NewMockAuth(ctrl),
+		tx: mock_tx.NewMockTransactor(ctrl),
+	}
+}
+
+func newService(mm mocks) *service.Service {
+	return service.New(mm.ir, mm.ur, mm.or, mm.a, mm.tx, 15*time.Minute)
+}
+
+func withTx(mm mocks) {
+	mm.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func eventType(t string) gomock.Matcher {
+	return gomock.Cond(func(ev entity.OutboxEvent) bool { return ev.EventType == t })
+}
+
+func TestService_Start(t *testing.T) {
+	actorID := uuid.New()
+	userID := uuid.New()
+	impID := uuid.New()
+	coworkingID := uuid.New()
+	otherCoworkingID := uuid.New()
+
+	actor := entity.User{ID: actorID, IsActive: true, Permissions: []entity.Permission{
+		{Code: "users.impersonate"},
+		{Code: "bookings.manage", CoworkingID: &coworkingID},
+	}}
+	student := entity.User{ID: userID, IsActive: true}
+
+	tests := []struct {
+		name         string
+		userID       uuid.UUID
+		mockBehavior func(m mocks)
+		expectedErr  error
+	}{
+		{
+			name:   "token issued and audited",
+			userID: userID,
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.ur.EXPECT().GetByID(gomock.Any(), actorID).Return(actor, nil)
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(student, nil)
+				m.ir.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, imp entity.Impersonation) (entity.Impersonation, error) {
+						require.Equal(t, actorID, imp.ActorID)
+						require.False(t, imp.AllowWrite)
+						imp.ID = impID
+						return imp, nil
+					})
+				m.a.EXPECT().GenerateImpersonationToken(student, actor, impID, false, 15*time.Minute).
+					Return(&auth.ExchangedToken{AccessToken: "jwt"}, nil)
+				m.or.EXPECT().Create(gomock.Any(), eventType(service.EventUserImpersonated)).Return(nil)
+			},
+		},
+		{
+			name:         "self",
+			userID:       actorID,
+			mockBehavior: func(m mocks) {},
+			expectedErr:  service.ErrSelfImpersonation,
+		},
+		{
+			name:   "user not found",
+			userID: userID,
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.ur.EXPECT().GetByID(gomock.Any(), actorID).Return(actor, nil)
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(entity.User{}, user_repository.ErrUserNotFound)
+			},
+			expectedErr: service.ErrUserNotFound,
+		},
+		{
+			name:   "inactive user",
+			userID: userID,
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.ur.EXPECT().GetByID(gomock.Any(), actorID).Return(actor, nil)
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(entity.User{ID: userID}, nil)
+			},
+			expectedErr: service.ErrUserInactive,
+		},
+		{
+			name:   "user with permission in another coworking",
+			userID: userID,
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.ur.EXPECT().GetByID(gomock.Any(), actorID).Return(actor, nil)
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(entity.User{ID: userID, IsActive: true, Permissions: []entity.Permission{
+					{Code: "bookings.manage", CoworkingID: &otherCoworkingID},
+				}}, nil)
+			},
+			expectedErr: service.ErrPrivilegedUser,
+		},
+		{
+			name:   "user with global permission",
+			userID: userID,
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.ur.EXPECT().GetByID(gomock.Any(), actorID).Return(actor, nil)
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(entity.User{ID: userID, IsActive: true, Permissions: []entity.Permission{
+					{Code: "bookings.manage"},
+				}}, nil)
+			},
+			expectedErr: service.ErrPrivilegedUser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mm := newMocks(ctrl)
+			tt.mockBehavior(mm)
+
+			imp, token, err := newService(mm).Start(context.Background(), actorID, tt.userID, "ticket #42", false)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, impID, imp.ID)
+			require.Equal(t, "jwt", token.AccessToken)
+		})
+	}
+}
+
+func TestService_End(t *testing.T) {
+	impID := uuid.New()
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m mocks)
+		expectedErr  error
+	}{
+		{
+			name: "session revoked",
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.ir.EXPECT().End(gomock.Any(), impID).
+					Return(entity.Impersonation{ID: impID, ExpiresAt: time.Now().Add(time.Minute)}, nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Cond(func(ev entity.OutboxEvent) bool {
+					return ev.EventType == "session.revoked" && ev.Payload["sessionId"] == impID
+				})).Return(nil)
+			},
+		},
+		{
+			name: "already ended",
+			mockBehavior: func(m mocks) {
+				withTx(m)
+				m.ir.EXPECT().End(gomock.Any(), impID).
+					Return(entity.Impersonation{}, impersonation_repository.ErrImpersonationNotFound)
+			},
+			expectedErr: service.ErrImpersonationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mm := newMocks(ctrl)
+			tt.mockBehavior(mm)
+
+			err := newService(mm).End(context.Background(), impID)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
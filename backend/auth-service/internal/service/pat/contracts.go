@@ -0,0 +1,33 @@
+package pat_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
+type TokenRepository interface {
+	Create(ctx context.Context, token entity.PersonalToken, tokenHash string) (entity.PersonalToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (entity.PersonalToken, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]entity.PersonalToken, error)
+	Revoke(ctx context.Context, userID uuid.UUID, tokenID uuid.UUID) (entity.PersonalToken, error)
+	TouchLastUsed(ctx context.Context, tokenID uuid.UUID) error
+}
+
+type UserRepository interface {
+	GetByID(ctx context.Context, userID uuid.UUID) (entity.User, error)
+}
+
+type OutboxRepository interface {
+	Create(ctx context.Context, ev entity.OutboxEvent) error
+}
+
+type Auth interface {
+	GeneratePersonalAccessToken(user entity.User, tokenID uuid.UUID, permissions []string, ttl time.Duration) (*auth.ExchangedToken, error)
+	HashToken(tokenString string) string
+}
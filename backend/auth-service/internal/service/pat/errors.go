@@ -0,0 +1,15 @@
+package pat_service
+
+import "errors"
+
+var (
+	ErrInvalidToken         = errors.New("invalid or expired personal token")
+	ErrTokenNotFound        = errors.New("personal token not found")
+	ErrEmptyPermissions     = errors.New("permissions cannot be empty")
+	ErrPermissionNotGranted = errors.New("permission is not granted to user")
+	ErrInvalidExpiry        = errors.New("invalid token expiry")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrCannotCreateToken    = errors.New("cannot create personal token")
+	ErrCannotFetchTokens    = errors.New("cannot fetch personal tokens")
+	ErrCannotExchangeToken  = errors.New("cannot exchange personal token")
+)
@@ -0,0 +1,259 @@
+package pat_service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/transactor"
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	pat_repository "github.com/4udiwe/coworking/auth-service/internal/repository/pat"
+	user_repository "github.com/4udiwe/coworking/auth-service/internal/repository/user"
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// TokenPrefix отличает персональный токен от JWT в заголовке Authorization.
+const TokenPrefix = "cwk_pat_"
+
+// Сколько символов токена сохраняется для отображения в списке.
+const displayPrefixLen = len(TokenPrefix) + 6
+
+/*
+Service — персональные токены доступа (PAT).
+
+Токен создаёт сам пользователь: выбирает подмножество своих прав и срок.
+Значение показывается один раз, в базе хранится SHA-256 хеш.
+
+Сервисы PAT напрямую не проверяют: gateway обменивает его на обычный
+короткоживущий JWT (Exchange). При обмене права токена пересекаются с
+текущими правами пользователя, так что снятая роль сразу сужает и PAT.
+*/
+type Service struct {
+	tokenRepo  TokenRepository
+	userRepo   UserRepository
+	outboxRepo OutboxRepository
+	auth       Auth
+	tx         transactor.Transactor
+
+	accessTokenTTL time.Duration
+	maxTTL         time.Duration
+}
+
+func New(
+	tokenRepo TokenRepository,
+	userRepo UserRepository,
+	outboxRepo OutboxRepository,
+	auth Auth,
+	tx transactor.Transactor,
+	accessTokenTTL time.Duration,
+	maxTTL time.Duration,
+) *Service {
+	return &Service{
+		tokenRepo:      tokenRepo,
+		userRepo:       userRepo,
+		outboxRepo:     outboxRepo,
+		auth:           auth,
+		tx:             tx,
+		accessTokenTTL: accessTokenTTL,
+		maxTTL:         maxTTL,
+	}
+}
+
+// CreateToken создаёт токен и возвращает его значение (единственный раз).
+func (s *Service) CreateToken(
+	ctx context.Context,
+	userID uuid.UUID,
+	name string,
+	permissions []string,
+	ttl time.Duration,
+) (entity.PersonalToken, string, error) {
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":     userID,
+		"name":        name,
+		"permissions": permissions,
+		"ttl":         ttl,
+	}).Info("CreateToken called")
+
+	if ttl <= 0 || ttl > s.maxTTL {
+		return entity.PersonalToken{}, "", ErrInvalidExpiry
+	}
+	if len(permissions) == 0 {
+		return entity.PersonalToken{}, "", ErrEmptyPermissions
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, user_repository.ErrUserNotFound) {
+			return entity.PersonalToken{}, "", ErrUserNotFound
+		}
+		logrus.WithError(err).Error("CreateToken: failed to get user")
+		return entity.PersonalToken{}, "", ErrCannotCreateToken
+	}
+
+	granted := formatPermissions(user.Permissions)
+	for _, p := range permissions {
+		if !covers(granted, p) {
+			logrus.WithFields(logrus.Fields{
+				"user_id":    userID,
+				"permission": p,
+			}).Warn("CreateToken: permission not granted")
+			return entity.PersonalToken{}, "", fmt.Errorf("%w: %s", ErrPermissionNotGranted, p)
+		}
+	}
+
+	raw, err := generateToken()
+	if err != nil {
+		logrus.WithError(err).Error("CreateToken: failed to generate token")
+		return entity.PersonalToken{}, "", ErrCannotCreateToken
+	}
+
+	token, err := s.tokenRepo.Create(ctx, entity.PersonalToken{
+		UserID:      userID,
+		Name:        name,
+		Prefix:      raw[:displayPrefixLen],
+		Permissions: lo.Uniq(permissions),
+		ExpiresAt:   time.Now().Add(ttl),
+	}, s.auth.HashToken(raw))
+	if err != nil {
+		return entity.PersonalToken{}, "", ErrCannotCreateToken
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":  userID,
+		"token_id": token.ID,
+	}).Info("Personal token created")
+
+	return token, raw, nil
+}
+
+func (s *Service) ListTokens(ctx context.Context, userID uuid.UUID) ([]entity.PersonalToken, error) {
+	tokens, err := s.tokenRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, ErrCannotFetchTokens
+	}
+	return tokens, nil
+}
+
+// RevokeToken отзывает токен и публикует auth.session.revoked с его ID,
+// чтобы сервисы отклонили уже выданные по нему JWT.
+func (s *Service) RevokeToken(ctx context.Context, userID uuid.UUID, tokenID uuid.UUID) error {
+	logrus.WithFields(logrus.Fields{
+		"user_id":  userID,
+		"token_id": tokenID,
+	}).Info("RevokeToken called")
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.tokenRepo.Revoke(ctx, userID, tokenID); err != nil {
+			if errors.Is(err, pat_repository.ErrTokenNotFound) {
+				return ErrTokenNotFound
+			}
+			return err
+		}
+
+		now := time.Now()
+		return s.outboxRepo.Create(ctx, entity.OutboxEvent{
+			AggregateType: "auth",
+			AggregateID:   tokenID,
+			EventType:     "session.revoked",
+			Payload: map[string]any{
+				"sessionId": tokenID,
+				"revokedAt": now,
+				"expiresAt": now.Add(s.accessTokenTTL),
+			},
+			Status:    entity.OutboxStatus{ID: 1, Name: entity.OutboxStatusPending},
+			CreatedAt: now,
+		})
+	})
+}
+
+// Exchange обменивает персональный токен на короткоживущий access token.
+func (s *Service) Exchange(ctx context.Context, raw string) (*auth.ExchangedToken, error) {
+	if !strings.HasPrefix(raw, TokenPrefix) {
+		return nil, ErrInvalidToken
+	}
+
+	token, err := s.tokenRepo.GetByHash(ctx, s.auth.HashToken(raw))
+	if err != nil {
+		if errors.Is(err, pat_repository.ErrTokenNotFound) {
+			logrus.Warn("Exchange: unknown personal token")
+			return nil, ErrInvalidToken
+		}
+		logrus.WithError(err).Error("Exchange: failed to get token")
+		return nil, ErrCannotExchangeToken
+	}
+
+	now := time.Now()
+	if !token.IsUsable(now) {
+		logrus.WithField("token_id", token.ID).Warn("Exchange: token revoked or expired")
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, user_repository.ErrUserNotFound) {
+			return nil, ErrInvalidToken
+		}
+		logrus.WithError(err).Error("Exchange: failed to get user")
+		return nil, ErrCannotExchangeToken
+	}
+	if !user.IsActive {
+		logrus.WithField("user_id", user.ID).Warn("Exchange: user is inactive")
+		return nil, ErrInvalidToken
+	}
+
+	granted := formatPermissions(user.Permissions)
+	permissions := lo.Filter(token.Permissions, func(p string, _ int) bool {
+		return covers(granted, p)
+	})
+
+	ttl := min(s.accessTokenTTL, token.ExpiresAt.Sub(now))
+
+	exchanged, err := s.auth.GeneratePersonalAccessToken(user, token.ID, permissions, ttl)
+	if err != nil {
+		logrus.WithError(err).Error("Exchange: failed to sign token")
+		return nil, ErrCannotExchangeToken
+	}
+
+	if err := s.tokenRepo.TouchLastUsed(ctx, token.ID); err != nil {
+		logrus.WithError(err).WithField("token_id", token.ID).Warn("Exchange: failed to update last_used_at")
+	}
+
+	return exchanged, nil
+}
+
+func formatPermissions(permissions []entity.Permission) []string {
+	return lo.Map(permissions, func(p entity.Permission, _ int) string {
+		return jwt_validator.FormatPermission(p.Code, p.CoworkingID)
+	})
+}
+
+// covers — покрывает ли набор прав пользователя запрошенное право.
+// Глобальное право покрывает и его ограничение любым коворкингом.
+func covers(granted []string, permission string) bool {
+	code, coworkingID := jwt_validator.ParsePermission(permission)
+	if code == "" {
+		return false
+	}
+	if slices.Contains(granted, code) {
+		return true
+	}
+	return coworkingID != nil && slices.Contains(granted, permission)
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return TokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,261 @@
+package pat_service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	pat_repository "github.com/4udiwe/coworking/auth-service/internal/repository/pat"
+	service "github.com/4udiwe/coworking/auth-service/internal/service/pat"
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+
+	mock_tx "github.com/4udiwe/coworking/auth-service/internal/mocks"
+	m "github.com/4udiwe/coworking/auth-service/internal/service/pat/mocks"
+)
+
+const (
+	accessTTL = 2 * time.Minute
+	maxTTL    = 365 * 24 * time.Hour
+)
+
+type mocks struct {
+	tr *m.MockTokenRepository
+	ur *m.MockUserRepository
+	or *m.MockOutboxRepository
+	a  *m.MockAuth
+	tx *mock_tx.MockTransactor
+}
+
+func newMocks(ctrl *gomock.Controller) mocks {
+	return mocks{
+		tr: m.NewMockTokenRepository(ctrl),
+		ur: m.NewMockUserRepository(ctrl),
+		or: m.NewMockOutboxRepository(ctrl),
+		a:  m.NewMockAuth(ctrl),
+		tx: mock_tx.NewMockTransactor(ctrl),
+	}
+}
+
+func newService(mm mocks) *service.Service {
+	return service.New(mm.tr, mm.ur, mm.or, mm.a, mm.tx, accessTTL, maxTTL)
+}
+
+func TestService_CreateToken(t *testing.T) {
+	userID := uuid.New()
+	coworkingID := uuid.New()
+	otherCoworkingID := uuid.New()
+
+	user := entity.User{
+		ID: userID,
+		Permissions: []entity.Permission{
+			{Code: jwt_validator.PermUsersRead},
+			{Code: jwt_validator.PermBookingsManage, CoworkingID: &coworkingID},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		permissions  []string
+		ttl          time.Duration
+		mockBehavior func(m mocks)
+		expectedErr  error
+	}{
+		{
+			name: "global and scoped permissions",
+			permissions: []string{
+				jwt_validator.PermUsersRead,
+				jwt_validator.FormatPermission(jwt_validator.PermUsersRead, &otherCoworkingID),
+				jwt_validator.FormatPermission(jwt_validator.PermBookingsManage, &coworkingID),
+			},
+			ttl: 30 * 24 * time.Hour,
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+				m.a.EXPECT().HashToken(gomock.Any()).Return("hash")
+				m.tr.EXPECT().Create(gomock.Any(), gomock.Cond(func(t entity.PersonalToken) bool {
+					return t.UserID == userID && len(t.Permissions) == 3 &&
+						strings.HasPrefix(t.Prefix, service.TokenPrefix)
+				}), "hash").DoAndReturn(func(_ context.Context, t entity.PersonalToken, _ string) (entity.PersonalToken, error) {
+					t.ID = uuid.New()
+					return t, nil
+				})
+			},
+		},
+		{
+			name:        "scoped permission does not grant global",
+			permissions: []string{jwt_validator.PermBookingsManage},
+			ttl:         time.Hour,
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+			},
+			expectedErr: service.ErrPermissionNotGranted,
+		},
+		{
+			name:        "permission in another coworking",
+			permissions: []string{jwt_validator.FormatPermission(jwt_validator.PermBookingsManage, &otherCoworkingID)},
+			ttl:         time.Hour,
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+			},
+			expectedErr: service.ErrPermissionNotGranted,
+		},
+		{
+			name:         "expiry above limit",
+			permissions:  []string{jwt_validator.PermUsersRead},
+			ttl:          maxTTL + time.Hour,
+			mockBehavior: func(m mocks) {},
+			expectedErr:  service.ErrInvalidExpiry,
+		},
+		{
+			name:         "empty permissions",
+			ttl:          time.Hour,
+			mockBehavior: func(m mocks) {},
+			expectedErr:  service.ErrEmptyPermissions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mm := newMocks(ctrl)
+			tt.mockBehavior(mm)
+
+			token, raw, err := newService(mm).CreateToken(context.Background(), userID, "export", tt.permissions, tt.ttl)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(raw, token.Prefix))
+		})
+	}
+}
+
+func TestService_Exchange(t *testing.T) {
+	userID := uuid.New()
+	tokenID := uuid.New()
+	raw := service.TokenPrefix + "secret"
+
+	token := entity.PersonalToken{
+		ID:          tokenID,
+		UserID:      userID,
+		Permissions: []string{jwt_validator.PermUsersRead, jwt_validator.PermUsersManage},
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	// users.manage у пользователя уже отобрали
+	user := entity.User{
+		ID:          userID,
+		IsActive:    true,
+		Permissions: []entity.Permission{{Code: jwt_validator.PermUsersRead}},
+	}
+
+	revoked := token
+	revokedAt := time.Now()
+	revoked.RevokedAt = &revokedAt
+
+	tests := []struct {
+		name         string
+		raw          string
+		mockBehavior func(m mocks)
+		expectedErr  error
+	}{
+		{
+			name: "permissions narrowed to current",
+			raw:  raw,
+			mockBehavior: func(m mocks) {
+				m.a.EXPECT().HashToken(raw).Return("hash")
+				m.tr.EXPECT().GetByHash(gomock.Any(), "hash").Return(token, nil)
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+				m.a.EXPECT().GeneratePersonalAccessToken(user, tokenID, []string{jwt_validator.PermUsersRead}, accessTTL).
+					Return(&auth.ExchangedToken{AccessToken: "jwt"}, nil)
+				m.tr.EXPECT().TouchLastUsed(gomock.Any(), tokenID).Return(nil)
+			},
+		},
+		{
+			name: "revoked token",
+			raw:  raw,
+			mockBehavior: func(m mocks) {
+				m.a.EXPECT().HashToken(raw).Return("hash")
+				m.tr.EXPECT().GetByHash(gomock.Any(), "hash").Return(revoked, nil)
+			},
+			expectedErr: service.ErrInvalidToken,
+		},
+		{
+			name: "unknown token",
+			raw:  raw,
+			mockBehavior: func(m mocks) {
+				m.a.EXPECT().HashToken(raw).Return("hash")
+				m.tr.EXPECT().GetByHash(gomock.Any(), "hash").Return(entity.PersonalToken{}, pat_repository.ErrTokenNotFound)
+			},
+			expectedErr: service.ErrInvalidToken,
+		},
+		{
+			name: "inactive user",
+			raw:  raw,
+			mockBehavior: func(m mocks) {
+				inactive := user
+				inactive.IsActive = false
+				m.a.EXPECT().HashToken(raw).Return("hash")
+				m.tr.EXPECT().GetByHash(gomock.Any(), "hash").Return(token, nil)
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(inactive, nil)
+			},
+			expectedErr: service.ErrInvalidToken,
+		},
+		{
+			name:         "not a personal token",
+			raw:          "eyJhbGciOi...",
+			mockBehavior: func(m mocks) {},
+			expectedErr:  service.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mm := newMocks(ctrl)
+			tt.mockBehavior(mm)
+
+			exchanged, err := newService(mm).Exchange(context.Background(), tt.raw)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "jwt", exchanged.AccessToken)
+		})
+	}
+}
+
+func TestService_RevokeToken(t *testing.T) {
+	userID := uuid.New()
+	tokenID := uuid.New()
+
+	ctrl := gomock.NewController(t)
+	mm := newMocks(ctrl)
+
+	mm.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).Times(2)
+	mm.tr.EXPECT().Revoke(gomock.Any(), userID, tokenID).Return(entity.PersonalToken{ID: tokenID}, nil)
+	mm.or.EXPECT().Create(gomock.Any(), gomock.Cond(func(ev entity.OutboxEvent) bool {
+		return ev.EventType == "session.revoked" && ev.Payload["sessionId"] == tokenID
+	})).Return(nil)
+	mm.tr.EXPECT().Revoke(gomock.Any(), userID, tokenID).Return(entity.PersonalToken{}, pat_repository.ErrTokenNotFound)
+
+	s := newService(mm)
+
+	require.NoError(t, s.RevokeToken(context.Background(), userID, tokenID))
+	require.ErrorIs(t, s.RevokeToken(context.Background(), userID, tokenID), service.ErrTokenNotFound)
+}
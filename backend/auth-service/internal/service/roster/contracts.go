@@ -0,0 +1,40 @@
+package roster_service
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
+type UserRepository interface {
+	Create(ctx context.Context, user entity.User) (entity.User, error)
+	AttachRole(ctx context.Context, userID uuid.UUID, roleCode string) error
+	ClearRoles(ctx context.Context, userID uuid.UUID) error
+	GetByEmail(ctx context.Context, email string) (entity.User, error)
+	GetByID(ctx context.Context, userID uuid.UUID) (entity.User, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, firstName string, lastName string) error
+	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
+	SetActive(ctx context.Context, userID uuid.UUID, active bool) error
+	ListRoles(ctx context.Context) ([]entity.Role, error)
+	ListActiveWithinRoles(ctx context.Context, roles []string) ([]entity.User, error)
+}
+
+type InvitationRepository interface {
+	Create(ctx context.Context, inv entity.UserInvitation, tokenHash string) (entity.UserInvitation, error)
+	Consume(ctx context.Context, tokenHash string) (entity.UserInvitation, error)
+}
+
+type OutboxRepository interface {
+	Create(ctx context.Context, ev entity.OutboxEvent) error
+}
+
+type Hasher interface {
+	HashPassword(password string) (string, error)
+}
+
+type Auth interface {
+	HashToken(tokenString string) string
+}
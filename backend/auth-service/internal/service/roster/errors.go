@@ -0,0 +1,20 @@
+package roster_service
+
+import "errors"
+
+var (
+	ErrEmptyRoster        = errors.New("roster is empty")
+	ErrTooManyRows        = errors.New("roster has too many rows")
+	ErrInvalidInvitation  = errors.New("invitation is invalid or expired")
+	ErrUserInactive       = errors.New("user is inactive")
+	ErrCannotImport       = errors.New("cannot import roster")
+	ErrCannotAcceptInvite = errors.New("cannot accept invitation")
+)
+
+// Ошибки отдельных строк: попадают в отчёт, импорт остальных строк продолжается.
+var (
+	ErrRowInvalidEmail   = errors.New("invalid email")
+	ErrRowEmptyName      = errors.New("first and last name are required")
+	ErrRowUnknownRole    = errors.New("unknown role")
+	ErrRowDuplicateEmail = errors.New("email is listed more than once")
+)
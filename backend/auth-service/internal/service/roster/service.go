@@ -0,0 +1,539 @@
+package roster_service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/transactor"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	invitation_repository "github.com/4udiwe/coworking/auth-service/internal/repository/invitation"
+	user_repository "github.com/4udiwe/coworking/auth-service/internal/repository/user"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// EventUserInvited — ссылку для установки пароля нужно доставить пользователю
+// (топик писем mailTopic, префикс "auth.").
+const EventUserInvited = "user.invited"
+
+// errDryRun откатывает транзакцию пробного импорта.
+var errDryRun = errors.New("dry run")
+
+// Row — строка списка пользователей (CSV или JSON).
+type Row struct {
+	// Номер строки в исходном файле, для отчёта
+	Line      int
+	Email     string
+	FirstName string
+	LastName  string
+	// Пустой список — роль по умолчанию
+	Roles []string
+}
+
+type Options struct {
+	// Выполнить импорт и откатить транзакцию: отчёт показывает, что изменится
+	DryRun bool
+	// Отправить приглашения пользователям без пароля
+	SendInvites bool
+	// Деактивировать пользователей, которых нет в списке (см. Import)
+	DeactivateMissing bool
+}
+
+type Action string
+
+const (
+	ActionCreated     Action = "created"
+	ActionUpdated     Action = "updated"
+	ActionUnchanged   Action = "unchanged"
+	ActionDeactivated Action = "deactivated"
+	ActionFailed      Action = "failed"
+)
+
+type RowResult struct {
+	// 0 — пользователь не из списка (деактивирован)
+	Line    int
+	Email   string
+	UserID  uuid.UUID
+	Action  Action
+	Invited bool
+	Err     error
+}
+
+type Report struct {
+	DryRun bool
+	Rows   []RowResult
+}
+
+func (r Report) Count(action Action) int {
+	return lo.CountBy(r.Rows, func(row RowResult) bool { return row.Action == action })
+}
+
+func (r Report) Invited() int {
+	return lo.CountBy(r.Rows, func(row RowResult) bool { return row.Invited })
+}
+
+/*
+Service — массовое заведение пользователей по списку на семестр.
+
+Каждая строка создаёт пользователя без пароля или обновляет существующего
+(по email): имя, глобальные роли, повторная активация. Строки с ошибками
+(email, пустое имя, неизвестная роль, повтор email) попадают в отчёт и
+пропускаются, остальные применяются в одной транзакции — сбой базы
+откатывает весь импорт.
+
+При DeactivateMissing деактивируются активные пользователи, чьи роли целиком
+входят в набор ролей из списка, но которых в списке нет. Так список студентов
+не затрагивает преподавателей и администраторов; импортирующий администратор
+не деактивируется никогда.
+
+Приглашение — одноразовая ссылка для установки начального пароля
+(событие auth.user.invited в топике auth.mail, затем POST /auth/invite/accept).
+*/
+type Service struct {
+	userRepo       UserRepository
+	invitationRepo InvitationRepository
+	outboxRepo     OutboxRepository
+	hasher         Hasher
+	auth           Auth
+	tx             transactor.Transactor
+
+	accessTokenTTL time.Duration
+	inviteTTL      time.Duration
+	inviteURL      string
+	defaultRole    string
+	maxRows        int
+	mailTopic      string
+}
+
+func New(
+	userRepo UserRepository,
+	invitationRepo InvitationRepository,
+	outboxRepo OutboxRepository,
+	hasher Hasher,
+	auth Auth,
+	tx transactor.Transactor,
+	accessTokenTTL time.Duration,
+	inviteTTL time.Duration,
+	inviteURL string,
+	defaultRole string,
+	maxRows int,
+	mailTopic string,
+) *Service {
+	return &Service{
+		userRepo:       userRepo,
+		invitationRepo: invitationRepo,
+		outboxRepo:     outboxRepo,
+		hasher:         hasher,
+		auth:           auth,
+		tx:             tx,
+		accessTokenTTL: accessTokenTTL,
+		inviteTTL:      inviteTTL,
+		inviteURL:      inviteURL,
+		defaultRole:    defaultRole,
+		maxRows:        maxRows,
+		mailTopic:      mailTopic,
+	}
+}
+
+func (s *Service) Import(
+	ctx context.Context,
+	actorID uuid.UUID,
+	rows []Row,
+	opts Options,
+) (Report, error) {
+
+	logrus.WithFields(logrus.Fields{
+		"actor_id":           actorID,
+		"rows":               len(rows),
+		"dry_run":            opts.DryRun,
+		"send_invites":       opts.SendInvites,
+		"deactivate_missing": opts.DeactivateMissing,
+	}).Info("Import called")
+
+	if len(rows) == 0 {
+		return Report{}, ErrEmptyRoster
+	}
+	if len(rows) > s.maxRows {
+		return Report{}, ErrTooManyRows
+	}
+
+	roles, err := s.userRepo.ListRoles(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Import: failed to list roles")
+		return Report{}, ErrCannotImport
+	}
+	known := lo.SliceToMap(roles, func(r entity.Role) (string, bool) { return string(r.Code), true })
+
+	report := Report{DryRun: opts.DryRun, Rows: make([]RowResult, len(rows))}
+
+	// Email всех строк, включая ошибочные: опечатка в строке
+	// не должна приводить к деактивации этого пользователя
+	listed := make(map[string]bool)
+	scope := make(map[string]bool)
+	var pending []int
+
+	for i, row := range rows {
+		row = s.normalize(row)
+		rows[i] = row
+
+		err := validate(row, known)
+		if err == nil && listed[row.Email] {
+			err = ErrRowDuplicateEmail
+		}
+		if row.Email != "" {
+			listed[row.Email] = true
+		}
+
+		if err != nil {
+			report.Rows[i] = RowResult{Line: row.Line, Email: row.Email, Action: ActionFailed, Err: err}
+			continue
+		}
+
+		for _, role := range row.Roles {
+			scope[role] = true
+		}
+		pending = append(pending, i)
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, i := range pending {
+			res, err := s.applyRow(ctx, rows[i], opts.SendInvites)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", rows[i].Line, err)
+			}
+			report.Rows[i] = res
+		}
+
+		if opts.DeactivateMissing && len(scope) > 0 {
+			scopeRoles := lo.Keys(scope)
+			slices.Sort(scopeRoles)
+
+			deactivated, err := s.deactivateMissing(ctx, actorID, scopeRoles, listed)
+			if err != nil {
+				return err
+			}
+			report.Rows = append(report.Rows, deactivated...)
+		}
+
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		logrus.WithError(err).Error("Import failed")
+		return Report{}, ErrCannotImport
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"dry_run":     opts.DryRun,
+		"created":     report.Count(ActionCreated),
+		"updated":     report.Count(ActionUpdated),
+		"deactivated": report.Count(ActionDeactivated),
+		"failed":      report.Count(ActionFailed),
+		"invited":     report.Invited(),
+	}).Info("Import completed")
+
+	return report, nil
+}
+
+// AcceptInvitation задаёт начальный пароль по токену из приглашения.
+func (s *Service) AcceptInvitation(ctx context.Context, token string, password string) error {
+	hash, err := s.hasher.HashPassword(password)
+	if err != nil {
+		logrus.WithError(err).Error("AcceptInvitation: hashing failed")
+		return ErrCannotAcceptInvite
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		inv, err := s.invitationRepo.Consume(ctx, s.auth.HashToken(token))
+		if err != nil {
+			if errors.Is(err, invitation_repository.ErrInvitationNotFound) {
+				return ErrInvalidInvitation
+			}
+			return fmt.Errorf("consume invitation: %w", err)
+		}
+
+		user, err := s.userRepo.GetByID(ctx, inv.UserID)
+		if err != nil {
+			if errors.Is(err, user_repository.ErrUserNotFound) {
+				return ErrInvalidInvitation
+			}
+			return fmt.Errorf("get user: %w", err)
+		}
+		if !user.IsActive {
+			return ErrUserInactive
+		}
+
+		if err := s.userRepo.UpdatePassword(ctx, user.ID, hash); err != nil {
+			return fmt.Errorf("update password: %w", err)
+		}
+
+		logrus.WithField("user_id", user.ID).Info("Invitation accepted")
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrInvalidInvitation) || errors.Is(err, ErrUserInactive) {
+			return err
+		}
+		logrus.WithError(err).Error("AcceptInvitation failed")
+		return ErrCannotAcceptInvite
+	}
+
+	return nil
+}
+
+func (s *Service) applyRow(ctx context.Context, row Row, sendInvite bool) (RowResult, error) {
+	res := RowResult{Line: row.Line, Email: row.Email}
+
+	user, err := s.userRepo.GetByEmail(ctx, row.Email)
+	switch {
+	case errors.Is(err, user_repository.ErrUserNotFound):
+		if user, err = s.createUser(ctx, row); err != nil {
+			return res, err
+		}
+		res.Action = ActionCreated
+
+	case err != nil:
+		return res, fmt.Errorf("get user: %w", err)
+
+	default:
+		changed, err := s.updateUser(ctx, user, row)
+		if err != nil {
+			return res, err
+		}
+		res.Action = ActionUnchanged
+		if changed {
+			res.Action = ActionUpdated
+		}
+	}
+
+	res.UserID = user.ID
+
+	if sendInvite && user.PasswordHash == "" {
+		if err := s.invite(ctx, user); err != nil {
+			return res, err
+		}
+		res.Invited = true
+	}
+
+	return res, nil
+}
+
+func (s *Service) createUser(ctx context.Context, row Row) (entity.User, error) {
+	user, err := s.userRepo.Create(ctx, entity.User{
+		Email:     row.Email,
+		FirstName: row.FirstName,
+		LastName:  row.LastName,
+		IsActive:  true,
+	})
+	if err != nil {
+		return entity.User{}, fmt.Errorf("create user: %w", err)
+	}
+
+	for _, role := range row.Roles {
+		if err := s.userRepo.AttachRole(ctx, user.ID, role); err != nil {
+			return entity.User{}, fmt.Errorf("attach role %s: %w", role, err)
+		}
+	}
+
+	if user, err = s.userRepo.GetByID(ctx, user.ID); err != nil {
+		return entity.User{}, fmt.Errorf("get user: %w", err)
+	}
+
+	return user, s.publish(ctx, entity.NewUserEvent(user.ID, entity.UserEventRegistered, entity.UserProfilePayload(user)))
+}
+
+// updateUser приводит существующего пользователя к строке списка.
+// Роли в коворкингах не трогаются.
+func (s *Service) updateUser(ctx context.Context, user entity.User, row Row) (bool, error) {
+	profileChanged := user.FirstName != row.FirstName || user.LastName != row.LastName
+	rolesChanged := !sameRoles(entity.RoleCodes(user.Roles), row.Roles)
+	reactivated := !user.IsActive
+
+	if profileChanged {
+		if err := s.userRepo.UpdateProfile(ctx, user.ID, row.FirstName, row.LastName); err != nil {
+			return false, fmt.Errorf("update profile: %w", err)
+		}
+	}
+
+	if rolesChanged {
+		// ErrUserNotFound здесь означает «ролей не было»
+		if err := s.userRepo.ClearRoles(ctx, user.ID); err != nil &&
+			!errors.Is(err, user_repository.ErrUserNotFound) {
+			return false, fmt.Errorf("clear roles: %w", err)
+		}
+		for _, role := range row.Roles {
+			if err := s.userRepo.AttachRole(ctx, user.ID, role); err != nil {
+				return false, fmt.Errorf("attach role %s: %w", role, err)
+			}
+		}
+
+		if err := s.publish(ctx, entity.NewUserEvent(user.ID, entity.UserEventRolesChanged, map[string]any{
+			"userId": user.ID,
+			"roles":  row.Roles,
+		})); err != nil {
+			return false, err
+		}
+		if err := s.publishUserRevoked(ctx, user.ID, entity.UserEventPermissionsChanged); err != nil {
+			return false, err
+		}
+	}
+
+	if reactivated {
+		if err := s.userRepo.SetActive(ctx, user.ID, true); err != nil {
+			return false, fmt.Errorf("set active: %w", err)
+		}
+	}
+
+	if profileChanged || reactivated {
+		updated, err := s.userRepo.GetByID(ctx, user.ID)
+		if err != nil {
+			return false, fmt.Errorf("get user: %w", err)
+		}
+		if err := s.publish(ctx, entity.NewUserEvent(user.ID, entity.UserEventUpdated, entity.UserProfilePayload(updated))); err != nil {
+			return false, err
+		}
+	}
+
+	return profileChanged || rolesChanged || reactivated, nil
+}
+
+func (s *Service) deactivateMissing(
+	ctx context.Context,
+	actorID uuid.UUID,
+	roles []string,
+	listed map[string]bool,
+) ([]RowResult, error) {
+
+	users, err := s.userRepo.ListActiveWithinRoles(ctx, roles)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+
+	var results []RowResult
+	for _, user := range users {
+		if listed[user.Email] || user.ID == actorID {
+			continue
+		}
+
+		if err := s.userRepo.SetActive(ctx, user.ID, false); err != nil {
+			return nil, fmt.Errorf("deactivate %s: %w", user.ID, err)
+		}
+		if err := s.publishUserRevoked(ctx, user.ID, entity.UserEventDeactivated); err != nil {
+			return nil, err
+		}
+
+		results = append(results, RowResult{
+			Email:  user.Email,
+			UserID: user.ID,
+			Action: ActionDeactivated,
+		})
+	}
+
+	return results, nil
+}
+
+func (s *Service) invite(ctx context.Context, user entity.User) error {
+	token, err := generateToken()
+	if err != nil {
+		return fmt.Errorf("generate token: %w", err)
+	}
+
+	inv, err := s.invitationRepo.Create(ctx, entity.UserInvitation{
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(s.inviteTTL),
+	}, s.auth.HashToken(token))
+	if err != nil {
+		return fmt.Errorf("create invitation: %w", err)
+	}
+
+	event := entity.NewUserEvent(user.ID, EventUserInvited, map[string]any{
+		"userId":    user.ID,
+		"email":     user.Email,
+		"firstName": user.FirstName,
+		"inviteUrl": s.inviteURL + "?token=" + url.QueryEscape(token),
+		"expiresAt": inv.ExpiresAt,
+	})
+	event.Topic = s.mailTopic
+	return s.publish(ctx, event)
+}
+
+// publishUserRevoked — то же событие, что и у user_service: сервисы
+// перестают принимать access token, выпущенные до этого момента.
+func (s *Service) publishUserRevoked(ctx context.Context, userID uuid.UUID, eventType string) error {
+	now := time.Now()
+	return s.publish(ctx, entity.NewUserEvent(userID, eventType, map[string]any{
+		"userId":    userID,
+		"revokedAt": now,
+		"expiresAt": now.Add(s.accessTokenTTL),
+	}))
+}
+
+func (s *Service) publish(ctx context.Context, ev entity.OutboxEvent) error {
+	if err := s.outboxRepo.Create(ctx, ev); err != nil {
+		return fmt.Errorf("create outbox event: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) normalize(row Row) Row {
+	row.Email = strings.TrimSpace(row.Email)
+	row.FirstName = strings.TrimSpace(row.FirstName)
+	row.LastName = strings.TrimSpace(row.LastName)
+
+	roles := lo.FilterMap(row.Roles, func(r string, _ int) (string, bool) {
+		r = strings.TrimSpace(r)
+		return r, r != ""
+	})
+	row.Roles = lo.Uniq(roles)
+	if len(row.Roles) == 0 {
+		row.Roles = []string{s.defaultRole}
+	}
+	return row
+}
+
+func validate(row Row, knownRoles map[string]bool) error {
+	addr, err := mail.ParseAddress(row.Email)
+	if err != nil || addr.Address != row.Email {
+		return ErrRowInvalidEmail
+	}
+	if row.FirstName == "" || row.LastName == "" {
+		return ErrRowEmptyName
+	}
+	for _, role := range row.Roles {
+		if !knownRoles[role] {
+			return fmt.Errorf("%w: %s", ErrRowUnknownRole, role)
+		}
+	}
+	return nil
+}
+
+func sameRoles(current, wanted []string) bool {
+	if len(current) != len(wanted) {
+		return false
+	}
+	for _, role := range wanted {
+		if !slices.Contains(current, role) {
+			return false
+		}
+	}
+	return true
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
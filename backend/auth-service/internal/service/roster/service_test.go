@@ -0,0 +1,275 @@
+package roster_service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	invitation_repository "github.com/4udiwe/coworking/auth-service/internal/repository/invitation"
+	user_repository "github.com/4udiwe/coworking/auth-service/internal/repository/user"
+	service "github.com/4udiwe/coworking/auth-service/internal/service/roster"
+
+	mock_tx "github.com/4udiwe/coworking/auth-service/internal/mocks"
+	m "github.com/4udiwe/coworking/auth-service/internal/service/roster/mocks"
+)
+
+type mocks struct {
+	ur *m.MockUserRepository
+	ir *m.MockInvitationRepository
+	or *m.MockOutboxRepository
+	h  *m.MockHasher
+	a  *m.MockAuth
+	tx *mock_tx.MockTransactor
+}
+
+func newMocks(ctrl *gomock.Controller) mocks {
+	return mocks{
+		ur: m.NewMockUserRepository(ctrl),
+		ir: m.NewMockInvitationRepository(ctrl),
+		or: m.NewMockOutboxRepository(ctrl),
+		h:  m.NewMockHasher(ctrl),
+		a:  m.NewMockAuth(ctrl),
+		tx: mock_tx.NewMockTransactor(ctrl),
+	}
+}
+
+func newService(mm mocks) *service.Service {
+	return service.New(
+		mm.ur, mm.ir, mm.or, mm.h, mm.a, mm.tx,
+		2*time.Minute, 7*24*time.Hour, "coworking://account/accept-invite", "student", 3, "auth.mail",
+	)
+}
+
+func withTx(mm mocks) {
+	mm.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func eventType(t string) gomock.Matcher {
+	return gomock.Cond(func(ev entity.OutboxEvent) bool { return ev.EventType == t })
+}
+
+var roles = []entity.Role{{Code: "student"}, {Code: "teacher"}, {Code: "admin"}}
+
+func TestService_Import(t *testing.T) {
+	actorID := uuid.New()
+	newID := uuid.New()
+	existingID := uuid.New()
+	missingID := uuid.New()
+
+	existing := entity.User{
+		ID: existingID, Email: "ivan@uni.ru", FirstName: "Иван", LastName: "Петров",
+		PasswordHash: "hash", IsActive: true, Roles: []entity.Role{{Code: "student"}},
+	}
+
+	tests := []struct {
+		name         string
+		rows         []service.Row
+		opts         service.Options
+		mockBehavior func(m mocks)
+		expected     []service.Action
+		invited      int
+		expectedErr  error
+	}{
+		{
+			name: "new user created with default role and invited",
+			rows: []service.Row{{Line: 1, Email: " anna@uni.ru ", FirstName: "Анна", LastName: "Смирнова"}},
+			opts: service.Options{SendInvites: true},
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().ListRoles(gomock.Any()).Return(roles, nil)
+				withTx(m)
+				m.ur.EXPECT().GetByEmail(gomock.Any(), "anna@uni.ru").Return(entity.User{}, user_repository.ErrUserNotFound)
+				m.ur.EXPECT().Create(gomock.Any(), gomock.Any()).Return(entity.User{ID: newID}, nil)
+				m.ur.EXPECT().AttachRole(gomock.Any(), newID, "student").Return(nil)
+				m.ur.EXPECT().GetByID(gomock.Any(), newID).
+					Return(entity.User{ID: newID, Email: "anna@uni.ru", IsActive: true}, nil)
+				m.or.EXPECT().Create(gomock.Any(), eventType(entity.UserEventRegistered)).Return(nil)
+				m.a.EXPECT().HashToken(gomock.Any()).Return("token-hash")
+				m.ir.EXPECT().Create(gomock.Any(), gomock.Any(), "token-hash").
+					Return(entity.UserInvitation{UserID: newID}, nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Cond(func(ev entity.OutboxEvent) bool {
+					return ev.EventType == service.EventUserInvited && ev.Topic == "auth.mail"
+				})).Return(nil)
+			},
+			expected: []service.Action{service.ActionCreated},
+			invited:  1,
+		},
+		{
+			name: "roles of existing user replaced",
+			rows: []service.Row{{Line: 1, Email: "ivan@uni.ru", FirstName: "Иван", LastName: "Петров", Roles: []string{"teacher"}}},
+			opts: service.Options{SendInvites: true},
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().ListRoles(gomock.Any()).Return(roles, nil)
+				withTx(m)
+				m.ur.EXPECT().GetByEmail(gomock.Any(), "ivan@uni.ru").Return(existing, nil)
+				m.ur.EXPECT().ClearRoles(gomock.Any(), existingID).Return(nil)
+				m.ur.EXPECT().AttachRole(gomock.Any(), existingID, "teacher").Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), eventType(entity.UserEventRolesChanged)).Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), eventType(entity.UserEventPermissionsChanged)).Return(nil)
+			},
+			expected: []service.Action{service.ActionUpdated},
+		},
+		{
+			name: "unchanged user",
+			rows: []service.Row{{Line: 1, Email: "ivan@uni.ru", FirstName: "Иван", LastName: "Петров", Roles: []string{"student"}}},
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().ListRoles(gomock.Any()).Return(roles, nil)
+				withTx(m)
+				m.ur.EXPECT().GetByEmail(gomock.Any(), "ivan@uni.ru").Return(existing, nil)
+			},
+			expected: []service.Action{service.ActionUnchanged},
+		},
+		{
+			name: "invalid rows reported and skipped",
+			rows: []service.Row{
+				{Line: 1, Email: "not-an-email", FirstName: "А", LastName: "Б"},
+				{Line: 2, Email: "ivan@uni.ru", FirstName: "Иван", LastName: "Петров", Roles: []string{"dean"}},
+				{Line: 3, Email: "ivan@uni.ru", FirstName: "Иван", LastName: "Петров"},
+			},
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().ListRoles(gomock.Any()).Return(roles, nil)
+				withTx(m)
+			},
+			expected: []service.Action{service.ActionFailed, service.ActionFailed, service.ActionFailed},
+		},
+		{
+			name: "missing users deactivated, actor and listed kept",
+			rows: []service.Row{{Line: 1, Email: "ivan@uni.ru", FirstName: "Иван", LastName: "Петров"}},
+			opts: service.Options{DeactivateMissing: true},
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().ListRoles(gomock.Any()).Return(roles, nil)
+				withTx(m)
+				m.ur.EXPECT().GetByEmail(gomock.Any(), "ivan@uni.ru").Return(existing, nil)
+				m.ur.EXPECT().ListActiveWithinRoles(gomock.Any(), []string{"student"}).Return([]entity.User{
+					{ID: existingID, Email: "ivan@uni.ru"},
+					{ID: actorID, Email: "admin@uni.ru"},
+					{ID: missingID, Email: "gone@uni.ru"},
+				}, nil)
+				m.ur.EXPECT().SetActive(gomock.Any(), missingID, false).Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), eventType(entity.UserEventDeactivated)).Return(nil)
+			},
+			expected: []service.Action{service.ActionUnchanged, service.ActionDeactivated},
+		},
+		{
+			name: "dry run rolls back and returns report",
+			rows: []service.Row{{Line: 1, Email: "anna@uni.ru", FirstName: "Анна", LastName: "Смирнова"}},
+			opts: service.Options{DryRun: true},
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().ListRoles(gomock.Any()).Return(roles, nil)
+				withTx(m)
+				m.ur.EXPECT().GetByEmail(gomock.Any(), "anna@uni.ru").Return(entity.User{}, user_repository.ErrUserNotFound)
+				m.ur.EXPECT().Create(gomock.Any(), gomock.Any()).Return(entity.User{ID: newID}, nil)
+				m.ur.EXPECT().AttachRole(gomock.Any(), newID, "student").Return(nil)
+				m.ur.EXPECT().GetByID(gomock.Any(), newID).Return(entity.User{ID: newID}, nil)
+				m.or.EXPECT().Create(gomock.Any(), eventType(entity.UserEventRegistered)).Return(nil)
+			},
+			expected: []service.Action{service.ActionCreated},
+		},
+		{
+			name:         "too many rows",
+			rows:         make([]service.Row, 4),
+			mockBehavior: func(m mocks) {},
+			expectedErr:  service.ErrTooManyRows,
+		},
+		{
+			name: "database failure aborts import",
+			rows: []service.Row{{Line: 1, Email: "anna@uni.ru", FirstName: "Анна", LastName: "Смирнова"}},
+			mockBehavior: func(m mocks) {
+				m.ur.EXPECT().ListRoles(gomock.Any()).Return(roles, nil)
+				withTx(m)
+				m.ur.EXPECT().GetByEmail(gomock.Any(), "anna@uni.ru").Return(entity.User{}, errors.New("fail"))
+			},
+			expectedErr: service.ErrCannotImport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mm := newMocks(ctrl)
+			tt.mockBehavior(mm)
+
+			report, err := newService(mm).Import(context.Background(), actorID, tt.rows, tt.opts)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.opts.DryRun, report.DryRun)
+			require.Len(t, report.Rows, len(tt.expected))
+			for i, action := range tt.expected {
+				require.Equal(t, action, report.Rows[i].Action, "row %d", i)
+			}
+			require.Equal(t, tt.invited, report.Invited())
+		})
+	}
+}
+
+func TestService_AcceptInvitation(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m mocks)
+		expectedErr  error
+	}{
+		{
+			name: "password set",
+			mockBehavior: func(m mocks) {
+				m.h.EXPECT().HashPassword("password").Return("hash", nil)
+				withTx(m)
+				m.a.EXPECT().HashToken("token").Return("token-hash")
+				m.ir.EXPECT().Consume(gomock.Any(), "token-hash").Return(entity.UserInvitation{UserID: userID}, nil)
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(entity.User{ID: userID, IsActive: true}, nil)
+				m.ur.EXPECT().UpdatePassword(gomock.Any(), userID, "hash").Return(nil)
+			},
+		},
+		{
+			name: "expired invitation",
+			mockBehavior: func(m mocks) {
+				m.h.EXPECT().HashPassword("password").Return("hash", nil)
+				withTx(m)
+				m.a.EXPECT().HashToken("token").Return("token-hash")
+				m.ir.EXPECT().Consume(gomock.Any(), "token-hash").
+					Return(entity.UserInvitation{}, invitation_repository.ErrInvitationNotFound)
+			},
+			expectedErr: service.ErrInvalidInvitation,
+		},
+		{
+			name: "deactivated user",
+			mockBehavior: func(m mocks) {
+				m.h.EXPECT().HashPassword("password").Return("hash", nil)
+				withTx(m)
+				m.a.EXPECT().HashToken("token").Return("token-hash")
+				m.ir.EXPECT().Consume(gomock.Any(), "token-hash").Return(entity.UserInvitation{UserID: userID}, nil)
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).Return(entity.User{ID: userID}, nil)
+			},
+			expectedErr: service.ErrUserInactive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mm := newMocks(ctrl)
+			tt.mockBehavior(mm)
+
+			err := newService(mm).AcceptInvitation(context.Background(), "token", "password")
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package sso_service
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/internal/oidc"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
+type UserRepository interface {
+	Create(ctx context.Context, user entity.User) (entity.User, error)
+	AttachRole(ctx context.Context, userID uuid.UUID, roleCode string) error
+	ClearRoles(ctx context.Context, userID uuid.UUID) error
+	GetByEmail(ctx context.Context, email string) (entity.User, error)
+	GetByID(ctx context.Context, userID uuid.UUID) (entity.User, error)
+}
+
+type OutboxRepository interface {
+	Create(ctx context.Context, ev entity.OutboxEvent) error
+}
+
+type IdentityRepository interface {
+	SaveState(ctx context.Context, state entity.OIDCLoginState) error
+	ConsumeState(ctx context.Context, state string) (entity.OIDCLoginState, error)
+	GetBySubject(ctx context.Context, issuer string, subject string) (entity.UserIdentity, error)
+	Create(ctx context.Context, identity entity.UserIdentity) (entity.UserIdentity, error)
+	TouchLastLogin(ctx context.Context, id uuid.UUID, email *string) error
+}
+
+type Provider interface {
+	AuthCodeURL(state, nonce, codeVerifier string) string
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*oidc.Identity, error)
+}
+
+// SessionStarter — выдача сессии и токенов (auth_service.Service).
+type SessionStarter interface {
+	StartSession(
+		ctx context.Context,
+		user entity.User,
+		userAgent string,
+		deviceInfo string,
+		ip string,
+	) (*auth.Tokens, error)
+}
@@ -0,0 +1,21 @@
+package sso_service
+
+import "errors"
+
+var (
+	// Flow errors
+	ErrInvalidState        = errors.New("invalid or expired sso state")
+	ErrProviderRejected    = errors.New("identity provider rejected the login")
+	ErrCannotStartLogin    = errors.New("cannot start sso login")
+	ErrCannotCompleteLogin = errors.New("cannot complete sso login")
+	ErrLinkUserMismatch    = errors.New("sso link must be completed by the user who started it")
+
+	// Account errors
+	ErrUserNotFound             = errors.New("user not found")
+	ErrUserInactive             = errors.New("user is inactive")
+	ErrEmailRequired            = errors.New("identity provider did not return an email")
+	ErrAccountExists            = errors.New("account with this email already exists, sign in and link sso in profile")
+	ErrIdentityLinkedToOther    = errors.New("sso account is already linked to another user")
+	ErrRoleMappingMisconfigured = errors.New("sso role mapping references unknown role")
+	ErrSessionLimitReached      = errors.New("active session limit reached, sign out on another device")
+)
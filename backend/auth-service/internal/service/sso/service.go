@@ -0,0 +1,415 @@
+package sso_service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/transactor"
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/internal/oidc"
+	identity_repository "github.com/4udiwe/coworking/auth-service/internal/repository/identity"
+	user_repository "github.com/4udiwe/coworking/auth-service/internal/repository/user"
+	auth_service "github.com/4udiwe/coworking/auth-service/internal/service/auth"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+Service — вход через университетский SSO (OpenID Connect).
+
+StartLogin сохраняет одноразовые state / nonce / PKCE code_verifier и
+возвращает URL IdP вместе с ключом браузера. Handler отдаёт ключ в
+httpOnly cookie, а в state хранится только его хэш: callback из другого
+браузера (подброшенная ссылка с чужим code и state) отклоняется.
+Привязку из профиля может завершить только тот же пользователь.
+
+Callback обменивает code на ID token и находит пользователя:
+ 1. по привязке (issuer, subject);
+ 2. если login начат из профиля (linkUserID) — привязывает SSO к этому аккаунту;
+ 3. при LinkByEmail — к аккаунту с тем же подтверждённым email;
+ 4. иначе создаёт пользователя (JIT provisioning) с ролями по группам IdP.
+
+Роли берутся из RoleMapping (группа IdP -> код роли). Если ни одна группа
+не сопоставлена, новому пользователю выдаётся DefaultRole. При SyncRoles
+роли перезаписываются при каждом повторном входе по уже привязанному
+аккаунту, но только когда группы IdP дают хотя бы одну роль — пустой claim
+не лишает пользователя доступа. Роли аккаунтов, привязанных вручную или по
+email, при самой привязке не меняются.
+*/
+type Service struct {
+	userRepo     UserRepository
+	identityRepo IdentityRepository
+	outboxRepo   OutboxRepository
+	provider     Provider
+	sessions     SessionStarter
+	tx           transactor.Transactor
+
+	roleMapping map[string]string
+	defaultRole string
+	syncRoles   bool
+	linkByEmail bool
+	stateTTL    time.Duration
+}
+
+func New(
+	userRepo UserRepository,
+	identityRepo IdentityRepository,
+	outboxRepo OutboxRepository,
+	provider Provider,
+	sessions SessionStarter,
+	tx transactor.Transactor,
+	roleMapping map[string]string,
+	defaultRole string,
+	syncRoles bool,
+	linkByEmail bool,
+	stateTTL time.Duration,
+) *Service {
+	return &Service{
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		outboxRepo:   outboxRepo,
+		provider:     provider,
+		sessions:     sessions,
+		tx:           tx,
+		roleMapping:  roleMapping,
+		defaultRole:  defaultRole,
+		syncRoles:    syncRoles,
+		linkByEmail:  linkByEmail,
+		stateTTL:     stateTTL,
+	}
+}
+
+// StartLogin возвращает URL авторизации IdP и ключ браузера для cookie.
+// linkUserID != nil — привязка SSO к уже вошедшему пользователю.
+func (s *Service) StartLogin(ctx context.Context, linkUserID *uuid.UUID) (string, string, error) {
+	state, err := oidc.RandomString()
+	if err != nil {
+		return "", "", ErrCannotStartLogin
+	}
+	nonce, err := oidc.RandomString()
+	if err != nil {
+		return "", "", ErrCannotStartLogin
+	}
+	browserKey, err := oidc.RandomString()
+	if err != nil {
+		return "", "", ErrCannotStartLogin
+	}
+	verifier := oidc.GenerateVerifier()
+
+	if err := s.identityRepo.SaveState(ctx, entity.OIDCLoginState{
+		State:          state,
+		CodeVerifier:   verifier,
+		Nonce:          nonce,
+		BrowserKeyHash: hashBrowserKey(browserKey),
+		LinkUserID:     linkUserID,
+		ExpiresAt:      time.Now().Add(s.stateTTL),
+	}); err != nil {
+		logrus.WithError(err).Error("SSO: failed to save login state")
+		return "", "", ErrCannotStartLogin
+	}
+
+	logrus.WithField("link_user_id", linkUserID).Info("SSO login started")
+
+	return s.provider.AuthCodeURL(state, nonce, verifier), browserKey, nil
+}
+
+// Callback завершает вход.
+// browserKey — значение cookie, выданного в StartLogin;
+// callerID — пользователь из access token запроса (nil, если токена нет).
+func (s *Service) Callback(
+	ctx context.Context,
+	code string,
+	state string,
+	browserKey string,
+	callerID *uuid.UUID,
+	userAgent string,
+	deviceInfo string,
+	ip string,
+) (*auth.Tokens, error) {
+
+	loginState, err := s.identityRepo.ConsumeState(ctx, state)
+	if err != nil {
+		if errors.Is(err, identity_repository.ErrStateNotFound) {
+			logrus.Warn("SSO callback with unknown or expired state")
+			return nil, ErrInvalidState
+		}
+		logrus.WithError(err).Error("SSO: failed to consume login state")
+		return nil, ErrCannotCompleteLogin
+	}
+
+	// State уже удалён: подобранный или перехваченный state больше не сработает
+	if subtle.ConstantTimeCompare([]byte(hashBrowserKey(browserKey)), []byte(loginState.BrowserKeyHash)) != 1 {
+		logrus.Warn("SSO callback from a browser that did not start the login")
+		return nil, ErrInvalidState
+	}
+
+	if loginState.LinkUserID != nil && (callerID == nil || *callerID != *loginState.LinkUserID) {
+		logrus.WithFields(logrus.Fields{
+			"link_user_id": loginState.LinkUserID,
+			"caller_id":    callerID,
+		}).Warn("SSO link completed by another user")
+		return nil, ErrLinkUserMismatch
+	}
+
+	identity, err := s.provider.Exchange(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		logrus.WithError(err).Warn("SSO: code exchange failed")
+		return nil, ErrProviderRejected
+	}
+
+	var tokens *auth.Tokens
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := s.resolveUser(ctx, identity, loginState.LinkUserID)
+		if err != nil {
+			return err
+		}
+
+		if !user.IsActive {
+			return ErrUserInactive
+		}
+
+		tokens, err = s.sessions.StartSession(ctx, user, userAgent, deviceInfo, ip)
+		if errors.Is(err, auth_service.ErrSessionLimitReached) {
+			return ErrSessionLimitReached
+		}
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, ErrUserInactive) ||
+			errors.Is(err, ErrUserNotFound) ||
+			errors.Is(err, ErrEmailRequired) ||
+			errors.Is(err, ErrAccountExists) ||
+			errors.Is(err, ErrIdentityLinkedToOther) ||
+			errors.Is(err, ErrRoleMappingMisconfigured) ||
+			errors.Is(err, ErrSessionLimitReached) {
+			return nil, err
+		}
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"issuer":  identity.Issuer,
+			"subject": identity.Subject,
+		}).Error("SSO login failed")
+		return nil, ErrCannotCompleteLogin
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"issuer":  identity.Issuer,
+		"subject": identity.Subject,
+	}).Info("SSO login completed")
+
+	return tokens, nil
+}
+
+// resolveUser находит или создаёт пользователя для внешнего аккаунта.
+func (s *Service) resolveUser(
+	ctx context.Context,
+	identity *oidc.Identity,
+	linkUserID *uuid.UUID,
+) (entity.User, error) {
+
+	email := optionalEmail(identity.Email)
+
+	linked, err := s.identityRepo.GetBySubject(ctx, identity.Issuer, identity.Subject)
+	switch {
+	case err == nil:
+		if linkUserID != nil && *linkUserID != linked.UserID {
+			return entity.User{}, ErrIdentityLinkedToOther
+		}
+		if err := s.identityRepo.TouchLastLogin(ctx, linked.ID, email); err != nil {
+			return entity.User{}, fmt.Errorf("touch identity: %w", err)
+		}
+		if s.syncRoles && linkUserID == nil {
+			if roles := s.mapRoles(identity.Groups); len(roles) > 0 {
+				if err := s.replaceRoles(ctx, linked.UserID, roles); err != nil {
+					return entity.User{}, err
+				}
+			}
+		}
+		return s.getUser(ctx, linked.UserID)
+
+	case !errors.Is(err, identity_repository.ErrIdentityNotFound):
+		return entity.User{}, fmt.Errorf("get identity: %w", err)
+	}
+
+	// Привязка из профиля
+	if linkUserID != nil {
+		user, err := s.getUser(ctx, *linkUserID)
+		if err != nil {
+			return entity.User{}, err
+		}
+		return user, s.link(ctx, user.ID, identity, email)
+	}
+
+	if identity.Email == "" {
+		return entity.User{}, ErrEmailRequired
+	}
+
+	// Привязка по email — только если IdP подтвердил адрес
+	if s.linkByEmail && identity.EmailVerified {
+		user, err := s.userRepo.GetByEmail(ctx, identity.Email)
+		if err == nil {
+			logrus.WithField("user_id", user.ID).Info("SSO: linking identity by verified email")
+			return user, s.link(ctx, user.ID, identity, email)
+		}
+		if !errors.Is(err, user_repository.ErrUserNotFound) {
+			return entity.User{}, fmt.Errorf("get user by email: %w", err)
+		}
+	}
+
+	return s.provision(ctx, identity, email)
+}
+
+// provision создаёт пользователя без пароля (вход только через SSO).
+func (s *Service) provision(
+	ctx context.Context,
+	identity *oidc.Identity,
+	email *string,
+) (entity.User, error) {
+
+	user, err := s.userRepo.Create(ctx, entity.User{
+		FirstName: identity.FirstName,
+		LastName:  identity.LastName,
+		Email:     identity.Email,
+		IsActive:  true,
+	})
+	if err != nil {
+		if errors.Is(err, user_repository.ErrUserAlreadyExists) {
+			return entity.User{}, ErrAccountExists
+		}
+		return entity.User{}, fmt.Errorf("create user: %w", err)
+	}
+
+	roles := s.mapRoles(identity.Groups)
+	if len(roles) == 0 {
+		roles = []string{s.defaultRole}
+	}
+	for _, role := range roles {
+		if err := s.attachRole(ctx, user.ID, role); err != nil {
+			return entity.User{}, err
+		}
+	}
+
+	if err := s.link(ctx, user.ID, identity, email); err != nil {
+		return entity.User{}, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id": user.ID,
+		"email":   user.Email,
+		"roles":   roles,
+	}).Info("SSO: user provisioned")
+
+	user, err = s.getUser(ctx, user.ID)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	if err := s.outboxRepo.Create(
+		ctx,
+		entity.NewUserEvent(user.ID, entity.UserEventRegistered, entity.UserProfilePayload(user)),
+	); err != nil {
+		return entity.User{}, fmt.Errorf("create outbox event: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *Service) link(
+	ctx context.Context,
+	userID uuid.UUID,
+	identity *oidc.Identity,
+	email *string,
+) error {
+
+	_, err := s.identityRepo.Create(ctx, entity.UserIdentity{
+		UserID:  userID,
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+		Email:   email,
+	})
+	if err != nil {
+		if errors.Is(err, identity_repository.ErrIdentityAlreadyExists) {
+			return ErrIdentityLinkedToOther
+		}
+		return fmt.Errorf("link identity: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) replaceRoles(ctx context.Context, userID uuid.UUID, roles []string) error {
+	// ErrUserNotFound здесь означает «ролей не было»
+	if err := s.userRepo.ClearRoles(ctx, userID); err != nil &&
+		!errors.Is(err, user_repository.ErrUserNotFound) {
+		return fmt.Errorf("clear roles: %w", err)
+	}
+	for _, role := range roles {
+		if err := s.attachRole(ctx, userID, role); err != nil {
+			return err
+		}
+	}
+
+	if err := s.outboxRepo.Create(ctx, entity.NewUserEvent(userID, entity.UserEventRolesChanged, map[string]any{
+		"userId": userID,
+		"roles":  roles,
+	})); err != nil {
+		return fmt.Errorf("create outbox event: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) attachRole(ctx context.Context, userID uuid.UUID, role string) error {
+	if err := s.userRepo.AttachRole(ctx, userID, role); err != nil {
+		if errors.Is(err, user_repository.ErrRoleNotFound) {
+			logrus.WithField("role", role).Error("SSO: role mapping references unknown role")
+			return ErrRoleMappingMisconfigured
+		}
+		return fmt.Errorf("attach role: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) getUser(ctx context.Context, userID uuid.UUID) (entity.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, user_repository.ErrUserNotFound) {
+			return entity.User{}, ErrUserNotFound
+		}
+		return entity.User{}, fmt.Errorf("get user: %w", err)
+	}
+	return user, nil
+}
+
+// mapRoles переводит группы IdP в коды ролей без повторов, сохраняя порядок.
+func (s *Service) mapRoles(groups []string) []string {
+	var roles []string
+	seen := make(map[string]bool)
+
+	for _, group := range groups {
+		role, ok := s.roleMapping[group]
+		if !ok || seen[role] {
+			continue
+		}
+		seen[role] = true
+		roles = append(roles, role)
+	}
+	return roles
+}
+
+func hashBrowserKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func optionalEmail(email string) *string {
+	if email == "" {
+		return nil
+	}
+	return &email
+}
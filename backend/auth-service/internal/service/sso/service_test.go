@@ -0,0 +1,292 @@
+package sso_service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/4udiwe/coworking/auth-service/internal/auth"
+	"github.com/4udiwe/coworking/auth-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/internal/oidc"
+	identity_repository "github.com/4udiwe/coworking/auth-service/internal/repository/identity"
+	service "github.com/4udiwe/coworking/auth-service/internal/service/sso"
+
+	mock_tx "github.com/4udiwe/coworking/auth-service/internal/mocks"
+	m "github.com/4udiwe/coworking/auth-service/internal/service/sso/mocks"
+)
+
+var roleMapping = map[string]string{
+	"students": "student",
+	"staff":    "teacher",
+}
+
+func keyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestService_StartLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ir := m.NewMockIdentityRepository(ctrl)
+	p := m.NewMockProvider(ctrl)
+
+	linkUserID := uuid.New()
+
+	var saved entity.OIDCLoginState
+	ir.EXPECT().SaveState(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, st entity.OIDCLoginState) error {
+			saved = st
+			return nil
+		})
+	p.EXPECT().AuthCodeURL(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(state, nonce, verifier string) string {
+			require.Equal(t, saved.State, state)
+			require.Equal(t, saved.Nonce, nonce)
+			require.Equal(t, saved.CodeVerifier, verifier)
+			return "https://idp/authorize"
+		})
+
+	s := service.New(nil, ir, nil, p, nil, nil, roleMapping, "student", true, false, 10*time.Minute)
+
+	url, browserKey, err := s.StartLogin(context.Background(), &linkUserID)
+
+	require.NoError(t, err)
+	require.Equal(t, "https://idp/authorize", url)
+	require.NotEmpty(t, browserKey)
+	require.Equal(t, keyHash(browserKey), saved.BrowserKeyHash)
+	require.NotEmpty(t, saved.State)
+	require.NotEqual(t, saved.State, saved.Nonce)
+	require.Equal(t, &linkUserID, saved.LinkUserID)
+	require.True(t, saved.ExpiresAt.After(time.Now()))
+}
+
+func TestService_Callback(t *testing.T) {
+	type mocks struct {
+		ur *m.MockUserRepository
+		ir *m.MockIdentityRepository
+		or *m.MockOutboxRepository
+		p  *m.MockProvider
+		ss *m.MockSessionStarter
+		tx *mock_tx.MockTransactor
+	}
+
+	userID := uuid.New()
+	identityID := uuid.New()
+
+	identity := &oidc.Identity{
+		Issuer:        "https://idp",
+		Subject:       "s1",
+		Email:         "student@university.edu",
+		EmailVerified: true,
+		FirstName:     "Ivan",
+		LastName:      "Petrov",
+		Groups:        []string{"staff", "library"},
+	}
+
+	withTx := func(m mocks) {
+		m.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+
+	exchange := func(m mocks, linkUserID *uuid.UUID) {
+		m.ir.EXPECT().ConsumeState(gomock.Any(), "state").
+			Return(entity.OIDCLoginState{
+				State:          "state",
+				CodeVerifier:   "v",
+				Nonce:          "n",
+				BrowserKeyHash: keyHash("key"),
+				LinkUserID:     linkUserID,
+			}, nil)
+		m.p.EXPECT().Exchange(gomock.Any(), "code", "v", "n").Return(identity, nil)
+	}
+
+	otherUserID := uuid.New()
+
+	tests := []struct {
+		name         string
+		browserKey   string
+		callerID     *uuid.UUID
+		mockBehavior func(m mocks)
+		expectedErr  error
+	}{
+		{
+			name: "existing identity, roles synced",
+			mockBehavior: func(m mocks) {
+				exchange(m, nil)
+				withTx(m)
+
+				m.ir.EXPECT().GetBySubject(gomock.Any(), "https://idp", "s1").
+					Return(entity.UserIdentity{ID: identityID, UserID: userID}, nil)
+				m.ir.EXPECT().TouchLastLogin(gomock.Any(), identityID, gomock.Any()).Return(nil)
+				m.ur.EXPECT().ClearRoles(gomock.Any(), userID).Return(nil)
+				m.ur.EXPECT().AttachRole(gomock.Any(), userID, "teacher").Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Cond(func(ev entity.OutboxEvent) bool {
+					return ev.EventType == entity.UserEventRolesChanged && ev.AggregateID == userID
+				})).Return(nil)
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).
+					Return(entity.User{ID: userID, IsActive: true}, nil)
+				m.ss.EXPECT().StartSession(gomock.Any(), gomock.Any(), "ua", "device", "ip").
+					Return(&auth.Tokens{}, nil)
+			},
+		},
+		{
+			name: "jit provisioning",
+			mockBehavior: func(m mocks) {
+				exchange(m, nil)
+				withTx(m)
+
+				m.ir.EXPECT().GetBySubject(gomock.Any(), "https://idp", "s1").
+					Return(entity.UserIdentity{}, identity_repository.ErrIdentityNotFound)
+				m.ur.EXPECT().Create(gomock.Any(), gomock.Cond(func(u entity.User) bool {
+					return u.Email == identity.Email && u.PasswordHash == "" && u.IsActive
+				})).Return(entity.User{ID: userID, Email: identity.Email}, nil)
+				m.ur.EXPECT().AttachRole(gomock.Any(), userID, "teacher").Return(nil)
+				m.ir.EXPECT().Create(gomock.Any(), gomock.Cond(func(i entity.UserIdentity) bool {
+					return i.UserID == userID && i.Issuer == "https://idp" && i.Subject == "s1"
+				})).Return(entity.UserIdentity{}, nil)
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).
+					Return(entity.User{ID: userID, IsActive: true}, nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Cond(func(ev entity.OutboxEvent) bool {
+					return ev.EventType == entity.UserEventRegistered && ev.AggregateID == userID
+				})).Return(nil)
+				m.ss.EXPECT().StartSession(gomock.Any(), gomock.Any(), "ua", "device", "ip").
+					Return(&auth.Tokens{}, nil)
+			},
+		},
+		{
+			name:     "link to signed-in account",
+			callerID: &userID,
+			mockBehavior: func(m mocks) {
+				exchange(m, &userID)
+				withTx(m)
+
+				m.ir.EXPECT().GetBySubject(gomock.Any(), "https://idp", "s1").
+					Return(entity.UserIdentity{}, identity_repository.ErrIdentityNotFound)
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).
+					Return(entity.User{ID: userID, IsActive: true}, nil)
+				m.ir.EXPECT().Create(gomock.Any(), gomock.Any()).Return(entity.UserIdentity{}, nil)
+				m.ss.EXPECT().StartSession(gomock.Any(), gomock.Any(), "ua", "device", "ip").
+					Return(&auth.Tokens{}, nil)
+			},
+		},
+		{
+			name:     "identity linked to another user",
+			callerID: &userID,
+			mockBehavior: func(m mocks) {
+				exchange(m, &userID)
+				withTx(m)
+
+				m.ir.EXPECT().GetBySubject(gomock.Any(), "https://idp", "s1").
+					Return(entity.UserIdentity{ID: identityID, UserID: uuid.New()}, nil)
+			},
+			expectedErr: service.ErrIdentityLinkedToOther,
+		},
+		{
+			name: "inactive user",
+			mockBehavior: func(m mocks) {
+				exchange(m, nil)
+				withTx(m)
+
+				m.ir.EXPECT().GetBySubject(gomock.Any(), "https://idp", "s1").
+					Return(entity.UserIdentity{ID: identityID, UserID: userID}, nil)
+				m.ir.EXPECT().TouchLastLogin(gomock.Any(), identityID, gomock.Any()).Return(nil)
+				m.ur.EXPECT().ClearRoles(gomock.Any(), userID).Return(nil)
+				m.ur.EXPECT().AttachRole(gomock.Any(), userID, "teacher").Return(nil)
+				m.or.EXPECT().Create(gomock.Any(), gomock.Cond(func(ev entity.OutboxEvent) bool {
+					return ev.EventType == entity.UserEventRolesChanged && ev.AggregateID == userID
+				})).Return(nil)
+				m.ur.EXPECT().GetByID(gomock.Any(), userID).
+					Return(entity.User{ID: userID, IsActive: false}, nil)
+			},
+			expectedErr: service.ErrUserInactive,
+		},
+		{
+			name: "invalid state",
+			mockBehavior: func(m mocks) {
+				m.ir.EXPECT().ConsumeState(gomock.Any(), "state").
+					Return(entity.OIDCLoginState{}, identity_repository.ErrStateNotFound)
+			},
+			expectedErr: service.ErrInvalidState,
+		},
+		{
+			name:       "callback from another browser",
+			browserKey: "stolen",
+			mockBehavior: func(m mocks) {
+				m.ir.EXPECT().ConsumeState(gomock.Any(), "state").
+					Return(entity.OIDCLoginState{CodeVerifier: "v", Nonce: "n", BrowserKeyHash: keyHash("key")}, nil)
+			},
+			expectedErr: service.ErrInvalidState,
+		},
+		{
+			name: "link without caller",
+			mockBehavior: func(m mocks) {
+				m.ir.EXPECT().ConsumeState(gomock.Any(), "state").
+					Return(entity.OIDCLoginState{BrowserKeyHash: keyHash("key"), LinkUserID: &userID}, nil)
+			},
+			expectedErr: service.ErrLinkUserMismatch,
+		},
+		{
+			name:     "link completed by another user",
+			callerID: &otherUserID,
+			mockBehavior: func(m mocks) {
+				m.ir.EXPECT().ConsumeState(gomock.Any(), "state").
+					Return(entity.OIDCLoginState{BrowserKeyHash: keyHash("key"), LinkUserID: &userID}, nil)
+			},
+			expectedErr: service.ErrLinkUserMismatch,
+		},
+		{
+			name: "provider rejected",
+			mockBehavior: func(m mocks) {
+				m.ir.EXPECT().ConsumeState(gomock.Any(), "state").
+					Return(entity.OIDCLoginState{CodeVerifier: "v", Nonce: "n", BrowserKeyHash: keyHash("key")}, nil)
+				m.p.EXPECT().Exchange(gomock.Any(), "code", "v", "n").
+					Return(nil, errors.New("invalid_grant"))
+			},
+			expectedErr: service.ErrProviderRejected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mm := mocks{
+				ur: m.NewMockUserRepository(ctrl),
+				ir: m.NewMockIdentityRepository(ctrl),
+				or: m.NewMockOutboxRepository(ctrl),
+				p:  m.NewMockProvider(ctrl),
+				ss: m.NewMockSessionStarter(ctrl),
+				tx: mock_tx.NewMockTransactor(ctrl),
+			}
+
+			tt.mockBehavior(mm)
+
+			s := service.New(mm.ur, mm.ir, mm.or, mm.p, mm.ss, mm.tx, roleMapping, "student", true, false, 10*time.Minute)
+
+			browserKey := tt.browserKey
+			if browserKey == "" {
+				browserKey = "key"
+			}
+
+			tokens, err := s.Callback(context.Background(), "code", "state", browserKey, tt.callerID, "ua", "device", "ip")
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, tokens)
+		})
+	}
+}
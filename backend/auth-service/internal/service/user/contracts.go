@@ -10,6 +10,8 @@ import (
 type UserRepository interface {
 	AttachRole(ctx context.Context, userID uuid.UUID, roleCode string) error
 	GetByID(ctx context.Context, userID uuid.UUID) (entity.User, error)
+	GetByIDs(ctx context.Context, userIDs []uuid.UUID) ([]entity.User, error)
+	ListActiveIDs(ctx context.Context, role string, after uuid.UUID, limit int) ([]uuid.UUID, error)
 	GetUsers(
 		ctx context.Context,
 		page, pageSize int,
@@ -18,4 +20,12 @@ type UserRepository interface {
 	) ([]entity.User, int64, error)
 	SetActive(ctx context.Context, userID uuid.UUID, active bool) error
 	ClearRoles(ctx context.Context, userID uuid.UUID) error
+	ListRoles(ctx context.Context) ([]entity.Role, error)
+	GetCoworkingRoles(ctx context.Context, userID uuid.UUID) ([]entity.CoworkingRole, error)
+	AttachCoworkingRole(ctx context.Context, userID uuid.UUID, roleCode string, coworkingID uuid.UUID) error
+	DetachCoworkingRole(ctx context.Context, userID uuid.UUID, roleCode string, coworkingID uuid.UUID) error
+}
+
+type OutboxRepository interface {
+	Create(ctx context.Context, ev entity.OutboxEvent) error
 }
@@ -8,4 +8,7 @@ var (
 	ErrEmptyRoles       = errors.New("roles cannot be empty")
 	ErrEmptyUserID      = errors.New("empty user id")
 	ErrCannotFetchUsers = errors.New("cannot fetch users")
+	ErrCannotFetchRoles = errors.New("cannot fetch roles")
+
+	ErrCoworkingRoleNotFound = errors.New("coworking role not found")
 )
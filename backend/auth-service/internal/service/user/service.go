@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/4udiwe/avito-pvz/pkg/transactor"
 	"github.com/4udiwe/coworking/auth-service/internal/entity"
@@ -13,17 +14,24 @@ import (
 )
 
 type Service struct {
-	userRepo UserRepository
-	tx       transactor.Transactor
+	userRepo   UserRepository
+	outboxRepo OutboxRepository
+	tx         transactor.Transactor
+
+	accessTokenTTL time.Duration
 }
 
 func New(
 	userRepo UserRepository,
+	outboxRepo OutboxRepository,
 	tx transactor.Transactor,
+	accessTokenTTL time.Duration,
 ) *Service {
 	return &Service{
-		userRepo: userRepo,
-		tx:       tx,
+		userRepo:       userRepo,
+		outboxRepo:     outboxRepo,
+		tx:             tx,
+		accessTokenTTL: accessTokenTTL,
 	}
 }
 
@@ -52,6 +60,36 @@ func (s *Service) GetUsers(
 	return users, total, nil
 }
 
+// GetUsersByIDs — пакетный поиск профилей для внутренних сервисов.
+func (s *Service) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]entity.User, error) {
+	logrus.WithField("count", len(userIDs)).Info("GetUsersByIDs called")
+
+	users, err := s.userRepo.GetByIDs(ctx, userIDs)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get users by ids")
+		return nil, ErrCannotFetchUsers
+	}
+
+	return users, nil
+}
+
+// ListActiveUserIDs — постраничный (по курсору after) список активных
+// пользователей, при непустом role — только с этой ролью.
+func (s *Service) ListActiveUserIDs(ctx context.Context, role string, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	logrus.WithFields(logrus.Fields{
+		"role":  role,
+		"after": after,
+	}).Info("ListActiveUserIDs called")
+
+	ids, err := s.userRepo.ListActiveIDs(ctx, role, after, limit)
+	if err != nil {
+		logrus.WithError(err).Error("failed to list active user ids")
+		return nil, ErrCannotFetchUsers
+	}
+
+	return ids, nil
+}
+
 func (s *Service) SetUserActive(
 	ctx context.Context,
 	userID uuid.UUID,
@@ -63,17 +101,48 @@ func (s *Service) SetUserActive(
 		"active": active,
 	}).Info("SetUserActive called")
 
-	err := s.userRepo.SetActive(ctx, userID, active)
-	if err != nil {
-		if errors.Is(err, user_repository.ErrUserNotFound) {
-			logrus.WithField("userID", userID).Warn("user not found")
-			return ErrUserNotFound
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.userRepo.SetActive(ctx, userID, active)
+		if err != nil {
+			if errors.Is(err, user_repository.ErrUserNotFound) {
+				logrus.WithField("userID", userID).Warn("user not found")
+				return ErrUserNotFound
+			}
+
+			logrus.WithError(err).Error("failed to update user active status")
+			return fmt.Errorf("set active: %w", err)
 		}
 
-		logrus.WithError(err).Error("failed to update user active status")
-		return fmt.Errorf("set active: %w", err)
-	}
+		if active {
+			user, err := s.userRepo.GetByID(ctx, userID)
+			if err != nil {
+				return fmt.Errorf("get user: %w", err)
+			}
+			return s.publish(ctx, entity.NewUserEvent(userID, entity.UserEventUpdated, entity.UserProfilePayload(user)))
+		}
+
+		// Деактивация отзывает уже выданные access token во всех сервисах,
+		// booking-service по ней отменяет активные бронирования
+		return s.publishUserRevoked(ctx, userID, entity.UserEventDeactivated)
+	})
+}
+
+// publishUserRevoked пишет в outbox событие, по которому resource-сервисы
+// перестают принимать access token пользователя, выпущенные до этого момента.
+func (s *Service) publishUserRevoked(ctx context.Context, userID uuid.UUID, eventType string) error {
+	now := time.Now()
+	return s.publish(ctx, entity.NewUserEvent(userID, eventType, map[string]any{
+		"userId":    userID,
+		"revokedAt": now,
+		"expiresAt": now.Add(s.accessTokenTTL),
+	}))
+}
 
+func (s *Service) publish(ctx context.Context, ev entity.OutboxEvent) error {
+	if err := s.outboxRepo.Create(ctx, ev); err != nil {
+		logrus.WithError(err).Error("failed to create outbox event")
+		return fmt.Errorf("create outbox event: %w", err)
+	}
 	return nil
 }
 
@@ -120,7 +189,14 @@ func (s *Service) UpdateUserRoles(
 			}
 		}
 
-		return nil
+		if err := s.publish(ctx, entity.NewUserEvent(userID, entity.UserEventRolesChanged, map[string]any{
+			"userId": userID,
+			"roles":  roles,
+		})); err != nil {
+			return err
+		}
+
+		return s.publishUserRevoked(ctx, userID, entity.UserEventPermissionsChanged)
 	})
 }
 
@@ -142,3 +218,87 @@ func (s *Service) GetUserInfo(
 
 	return user, nil
 }
+
+func (s *Service) ListRoles(ctx context.Context) ([]entity.Role, error) {
+	roles, err := s.userRepo.ListRoles(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("failed to list roles")
+		return nil, ErrCannotFetchRoles
+	}
+	return roles, nil
+}
+
+func (s *Service) GetCoworkingRoles(ctx context.Context, userID uuid.UUID) ([]entity.CoworkingRole, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, user_repository.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	roles, err := s.userRepo.GetCoworkingRoles(ctx, userID)
+	if err != nil {
+		logrus.WithError(err).WithField("userID", userID).Error("failed to get coworking roles")
+		return nil, ErrCannotFetchRoles
+	}
+	return roles, nil
+}
+
+// GrantCoworkingRole выдаёт роль в пределах одного коворкинга
+// (например, manager — управление бронированиями только в нём).
+func (s *Service) GrantCoworkingRole(
+	ctx context.Context,
+	userID uuid.UUID,
+	roleCode string,
+	coworkingID uuid.UUID,
+) error {
+
+	logrus.WithFields(logrus.Fields{
+		"userID":      userID,
+		"role":        roleCode,
+		"coworkingID": coworkingID,
+	}).Info("GrantCoworkingRole called")
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+			if errors.Is(err, user_repository.ErrUserNotFound) {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("get user: %w", err)
+		}
+
+		if err := s.userRepo.AttachCoworkingRole(ctx, userID, roleCode, coworkingID); err != nil {
+			if errors.Is(err, user_repository.ErrRoleNotFound) {
+				return ErrRoleNotFound
+			}
+			return fmt.Errorf("attach coworking role: %w", err)
+		}
+
+		return s.publishUserRevoked(ctx, userID, entity.UserEventPermissionsChanged)
+	})
+}
+
+func (s *Service) RevokeCoworkingRole(
+	ctx context.Context,
+	userID uuid.UUID,
+	roleCode string,
+	coworkingID uuid.UUID,
+) error {
+
+	logrus.WithFields(logrus.Fields{
+		"userID":      userID,
+		"role":        roleCode,
+		"coworkingID": coworkingID,
+	}).Info("RevokeCoworkingRole called")
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.DetachCoworkingRole(ctx, userID, roleCode, coworkingID); err != nil {
+			if errors.Is(err, user_repository.ErrCoworkingRoleNotFound) {
+				return ErrCoworkingRoleNotFound
+			}
+			return fmt.Errorf("detach coworking role: %w", err)
+		}
+
+		return s.publishUserRevoked(ctx, userID, entity.UserEventPermissionsChanged)
+	})
+}
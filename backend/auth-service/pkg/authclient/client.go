@@ -0,0 +1,198 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnauthorized = errors.New("auth-service rejected client credentials")
+	ErrUnexpected   = errors.New("unexpected auth-service response")
+)
+
+// Запас до exp, после которого токен запрашивается заново.
+const tokenExpiryLeeway = 30 * time.Second
+
+// User — профиль пользователя из GET /internal/users.
+type User struct {
+	ID        uuid.UUID `json:"id"`
+	FirstName string    `json:"firstName"`
+	LastName  string    `json:"lastName"`
+	Email     string    `json:"email"`
+	IsActive  bool      `json:"isActive"`
+}
+
+/*
+Client — HTTP-клиент внутренних маршрутов auth-service.
+
+Получает токен по client credentials (POST /auth/token), кеширует его
+до истечения и повторяет запрос один раз, если токен отклонён
+(например, auth-service перезапущен с другим ключом).
+*/
+type Client struct {
+	baseURL      string
+	clientID     string
+	clientSecret string
+	scopes       []string
+	httpClient   *http.Client
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func New(baseURL, clientID, clientSecret string, scopes []string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		scopes:       scopes,
+		httpClient:   &http.Client{Timeout: timeout},
+	}
+}
+
+// GetUsers возвращает профили пользователей по ID. Неизвестные ID пропускаются.
+func (c *Client) GetUsers(ctx context.Context, userIDs []uuid.UUID) ([]User, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	q := url.Values{}
+	for _, id := range userIDs {
+		q.Add("ids", id.String())
+	}
+
+	var users []User
+	if err := c.get(ctx, "/internal/users?"+q.Encode(), &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// ListActiveUserIDs возвращает страницу ID активных пользователей после after
+// (uuid.Nil — первая страница); при непустом role — только с этой ролью.
+// next == nil на последней странице.
+func (c *Client) ListActiveUserIDs(
+	ctx context.Context,
+	role string,
+	after uuid.UUID,
+	limit int,
+) (ids []uuid.UUID, next *uuid.UUID, err error) {
+	q := url.Values{}
+	q.Set("limit", strconv.Itoa(limit))
+	if role != "" {
+		q.Set("role", role)
+	}
+	if after != uuid.Nil {
+		q.Set("after", after.String())
+	}
+
+	var page struct {
+		IDs        []uuid.UUID `json:"ids"`
+		NextCursor *uuid.UUID  `json:"nextCursor"`
+	}
+	if err := c.get(ctx, "/internal/users/ids?"+q.Encode(), &page); err != nil {
+		return nil, nil, err
+	}
+	return page.IDs, page.NextCursor, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, out any) error {
+	for attempt := 0; ; attempt++ {
+		token, err := c.AccessToken(ctx)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("auth-service request: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			resp.Body.Close()
+			c.resetToken()
+			continue
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("%w: %s %s", ErrUnexpected, path, resp.Status)
+		}
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+}
+
+// AccessToken возвращает сервисный токен клиента. Им же можно вызывать
+// внутренние маршруты других сервисов, если scopes клиента их покрывают.
+func (c *Client) AccessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Now().Before(c.expiresAt) {
+		return c.token, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(c.scopes) > 0 {
+		form.Set("scope", strings.Join(c.scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/auth/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(c.clientID, c.clientSecret)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("auth-service token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusBadRequest:
+		return "", ErrUnauthorized
+	default:
+		return "", fmt.Errorf("%w: token endpoint %s", ErrUnexpected, resp.Status)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnexpected, err)
+	}
+
+	c.token = body.AccessToken
+	c.expiresAt = time.Now().Add(time.Duration(body.ExpiresIn)*time.Second - tokenExpiryLeeway)
+
+	return c.token, nil
+}
+
+func (c *Client) resetToken() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = ""
+}
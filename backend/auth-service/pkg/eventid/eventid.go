@@ -0,0 +1,32 @@
+/*
+Package eventid — стабильный идентификатор события для дедупликации.
+
+kafka.KafkaPublisher генерирует eventId конверта при каждой публикации,
+поэтому повторная отправка той же записи outbox (requeue после ошибки,
+рестарт между публикацией и MarkProcessed) приходит с новым конвертом.
+Outbox-репозиторий записывает ID строки outbox в поле Field payload,
+а потребители (inbox, журнал событий аналитики) дедуплицируют по FromEnvelope.
+*/
+package eventid
+
+import (
+	"encoding/json"
+
+	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
+	"github.com/google/uuid"
+)
+
+// Field — поле payload с ID строки outbox
+const Field = "eventId"
+
+// FromEnvelope возвращает ID строки outbox из payload, а для событий,
+// опубликованных в обход outbox, — eventId конверта.
+func FromEnvelope(env kafka.Envelope) uuid.UUID {
+	var payload struct {
+		EventID uuid.UUID `json:"eventId"`
+	}
+	if err := json.Unmarshal(env.Data, &payload); err != nil || payload.EventID == uuid.Nil {
+		return env.EventID
+	}
+	return payload.EventID
+}
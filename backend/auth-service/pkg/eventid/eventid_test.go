@@ -0,0 +1,52 @@
+package eventid_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/eventid"
+)
+
+func TestFromEnvelope(t *testing.T) {
+	envelopeID := uuid.New()
+	outboxID := uuid.New()
+
+	tests := []struct {
+		name     string
+		data     string
+		expected uuid.UUID
+	}{
+		{
+			name:     "outbox id from payload",
+			data:     `{"eventId":"` + outboxID.String() + `","userId":"u1"}`,
+			expected: outboxID,
+		},
+		{
+			name:     "payload without eventId",
+			data:     `{"userId":"u1"}`,
+			expected: envelopeID,
+		},
+		{
+			name:     "malformed eventId",
+			data:     `{"eventId":"42"}`,
+			expected: envelopeID,
+		},
+		{
+			name:     "payload is not an object",
+			data:     `[1, 2]`,
+			expected: envelopeID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := kafka.Envelope{EventID: envelopeID, Data: json.RawMessage(tt.data)}
+
+			require.Equal(t, tt.expected, eventid.FromEnvelope(env))
+		})
+	}
+}
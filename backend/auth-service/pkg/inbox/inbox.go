@@ -0,0 +1,163 @@
+package inbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/coworking/auth-service/pkg/transactor"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+Inbox — журнал обработанных событий Kafka для идемпотентных consumer'ов.
+
+Отметка о событии пишется в той же транзакции, что и его обработка: если
+обработка откатилась, повторная доставка обработает событие заново, если
+закоммитилась — повтор с тем же eventId пропускается. Ключ — группа и eventId,
+поэтому одно событие независимо обрабатывают разные сервисы. eventId берётся
+из eventid.FromEnvelope: он не меняется при повторной публикации из outbox.
+
+Чтобы транзакции сервиса присоединялись к транзакции Inbox, сервис должен
+получать transactor.Joining вместо postgres.Postgres.
+
+Таблица создаётся миграцией сервиса:
+
+	CREATE TABLE processed_event (
+	    consumer_group VARCHAR(128) NOT NULL,
+	    event_id UUID NOT NULL,
+	    processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	    PRIMARY KEY (consumer_group, event_id)
+	);
+*/
+type Inbox struct {
+	pg        *postgres.Postgres
+	txManager *transactor.Joining
+}
+
+func New(pg *postgres.Postgres) *Inbox {
+	return &Inbox{
+		pg:        pg,
+		txManager: transactor.NewJoining(pg),
+	}
+}
+
+// Process выполняет fn, если событие eventID ещё не обработано группой group.
+// Событие без eventId обрабатывается всегда.
+func (i *Inbox) Process(
+	ctx context.Context,
+	group string,
+	eventID uuid.UUID,
+	fn func(ctx context.Context) error,
+) error {
+	if eventID == uuid.Nil {
+		logrus.WithField("group", group).Warn("Inbox: event without eventId, deduplication skipped")
+		return fn(ctx)
+	}
+
+	return i.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Вторая реплика с тем же событием ждёт здесь commit первой
+		tag, err := i.pg.GetTxManager(ctx).Exec(ctx, `
+			INSERT INTO processed_event (consumer_group, event_id)
+			VALUES ($1, $2)
+			ON CONFLICT (consumer_group, event_id) DO NOTHING
+		`, group, eventID)
+		if err != nil {
+			return fmt.Errorf("inbox: mark event processed: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			logrus.WithFields(logrus.Fields{
+				"group":    group,
+				"event_id": eventID,
+			}).Info("Inbox: duplicate event skipped")
+			return nil
+		}
+
+		return fn(ctx)
+	})
+}
+
+// ProcessExternal — вариант Process для обработчиков, чей результат — внешний
+// вызов (отправка push, письма), а не запись в БД. fn выполняется вне
+// транзакции Inbox, чтобы её откат не отменял записи, сделанные по ходу
+// отправки, а отметка ставится после успешного завершения. Если процесс
+// упадёт между fn и отметкой, событие будет обработано повторно.
+func (i *Inbox) ProcessExternal(
+	ctx context.Context,
+	group string,
+	eventID uuid.UUID,
+	fn func(ctx context.Context) error,
+) error {
+	if eventID == uuid.Nil {
+		logrus.WithField("group", group).Warn("Inbox: event without eventId, deduplication skipped")
+		return fn(ctx)
+	}
+
+	var processed bool
+	err := i.pg.GetTxManager(ctx).QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM processed_event
+			WHERE consumer_group = $1 AND event_id = $2
+		)
+	`, group, eventID).Scan(&processed)
+	if err != nil {
+		return fmt.Errorf("inbox: check event processed: %w", err)
+	}
+
+	if processed {
+		logrus.WithFields(logrus.Fields{
+			"group":    group,
+			"event_id": eventID,
+		}).Info("Inbox: duplicate event skipped")
+		return nil
+	}
+
+	if err := fn(ctx); err != nil {
+		return err
+	}
+
+	_, err = i.pg.GetTxManager(ctx).Exec(ctx, `
+		INSERT INTO processed_event (consumer_group, event_id)
+		VALUES ($1, $2)
+		ON CONFLICT (consumer_group, event_id) DO NOTHING
+	`, group, eventID)
+	if err != nil {
+		return fmt.Errorf("inbox: mark event processed: %w", err)
+	}
+
+	return nil
+}
+
+// Run периодически удаляет отметки старше retention. Повтор события после
+// этого срока будет обработан снова, поэтому retention должен быть больше
+// хранения сообщений в топиках.
+func (i *Inbox) Run(ctx context.Context, interval, retention time.Duration) {
+	go func() {
+		logrus.Infof("InboxCleaner started interval=%s retention=%s", interval, retention)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logrus.Info("InboxCleaner stopped")
+				return
+
+			case <-ticker.C:
+				tag, err := i.pg.GetTxManager(ctx).Exec(ctx,
+					`DELETE FROM processed_event WHERE processed_at < $1`,
+					time.Now().Add(-retention),
+				)
+				if err != nil {
+					logrus.WithError(err).Error("InboxCleaner: failed to delete old events")
+					continue
+				}
+				logrus.WithField("deleted", tag.RowsAffected()).Debug("InboxCleaner: old events deleted")
+			}
+		}
+	}()
+}
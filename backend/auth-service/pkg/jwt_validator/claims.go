@@ -1,6 +1,9 @@
 package jwt_validator
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
 )
@@ -11,5 +14,93 @@ type AccessClaims struct {
 	UserName  string    `json:"userName"`
 	Email     string    `json:"email"`
 	Roles     []string  `json:"roles"`
+	// Эффективные права: "bookings.manage" (глобально)
+	// или "bookings.manage:<coworkingId>" (только в этом коворкинге)
+	Permissions []string `json:"permissions,omitempty"`
+	// Группы пользователя (поток, кафедра). Права не дают — ими сервисы
+	// ограничивают доступ к ресурсам (например, коворкинг только для магистрантов).
+	Groups []uuid.UUID `json:"groups,omitempty"`
+	// ID персонального токена, если JWT получен его обменом.
+	// Совпадает с SessionID, поэтому отзыв PAT работает через denylist сессий.
+	PersonalTokenID *uuid.UUID `json:"patId,omitempty"`
+	// Кто действует от имени пользователя (impersonation, RFC 8693 act).
+	// SessionID такого токена — ID выдачи, досрочное завершение идёт через denylist сессий.
+	Act *Actor `json:"act,omitempty"`
+
+	// Заполнены только у токенов сервисов (client credentials, RFC 9068).
+	// У таких токенов нет UserID / SessionID, sub = client_id.
+	ClientID string `json:"client_id,omitempty"`
+	Scope    string `json:"scope,omitempty"`
 	jwt.RegisteredClaims
 }
+
+// Actor — сотрудник поддержки, вошедший от имени пользователя.
+type Actor struct {
+	UserID uuid.UUID `json:"sub"`
+	Email  string    `json:"email"`
+	// Без этого флага токен пропускает только чтение (GET, HEAD, OPTIONS)
+	AllowWrite bool `json:"allowWrite,omitempty"`
+}
+
+// IsImpersonated — токен выдан сотруднику поддержки от имени пользователя.
+func (c *AccessClaims) IsImpersonated() bool {
+	return c.Act != nil
+}
+
+// IsPersonalToken — JWT получен обменом персонального токена (скрипт, интеграция).
+func (c *AccessClaims) IsPersonalToken() bool {
+	return c.PersonalTokenID != nil
+}
+
+// IsService — токен выдан сервису, а не пользователю.
+func (c *AccessClaims) IsService() bool {
+	return c.ClientID != ""
+}
+
+// Scopes возвращает scope сервисного токена списком.
+func (c *AccessClaims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// HasScope — выдан ли сервисному токену указанный scope.
+func (c *AccessClaims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes(), scope)
+}
+
+// HasPermission — есть ли у токена право глобально (без ограничения коворкингом).
+func (c *AccessClaims) HasPermission(permission string) bool {
+	return slices.Contains(c.Permissions, permission)
+}
+
+// HasCoworkingPermission — есть ли право глобально или в указанном коворкинге.
+func (c *AccessClaims) HasCoworkingPermission(permission string, coworkingID uuid.UUID) bool {
+	return c.HasPermission(permission) ||
+		slices.Contains(c.Permissions, FormatPermission(permission, &coworkingID))
+}
+
+// HasAnyPermission — есть ли право хотя бы где-то: глобально или в любом коворкинге.
+func (c *AccessClaims) HasAnyPermission(permission string) bool {
+	for _, p := range c.Permissions {
+		code, _ := ParsePermission(p)
+		if code == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// CoworkingScopes возвращает коворкинги, в которых право выдано точечно.
+// global == true, если право есть глобально — тогда список не важен.
+func (c *AccessClaims) CoworkingScopes(permission string) (scopes []uuid.UUID, global bool) {
+	for _, p := range c.Permissions {
+		code, coworkingID := ParsePermission(p)
+		if code != permission {
+			continue
+		}
+		if coworkingID == nil {
+			return nil, true
+		}
+		scopes = append(scopes, *coworkingID)
+	}
+	return scopes, false
+}
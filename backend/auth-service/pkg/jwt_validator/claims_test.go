@@ -0,0 +1,134 @@
+package jwt_validator_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+)
+
+func TestParsePermission(t *testing.T) {
+	coworkingID := uuid.New()
+
+	tests := []struct {
+		name                string
+		input               string
+		expectedPermission  string
+		expectedCoworkingID *uuid.UUID
+	}{
+		{
+			name:               "global",
+			input:              "bookings.manage",
+			expectedPermission: "bookings.manage",
+		},
+		{
+			name:                "coworking scoped",
+			input:               "bookings.manage:" + coworkingID.String(),
+			expectedPermission:  "bookings.manage",
+			expectedCoworkingID: &coworkingID,
+		},
+		{
+			name:  "malformed coworking id",
+			input: "bookings.manage:42",
+		},
+		{
+			name:  "empty coworking id",
+			input: "bookings.manage:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permission, id := jwt_validator.ParsePermission(tt.input)
+
+			require.Equal(t, tt.expectedPermission, permission)
+			require.Equal(t, tt.expectedCoworkingID, id)
+		})
+	}
+}
+
+func TestAccessClaims_CoworkingScopes(t *testing.T) {
+	first := uuid.New()
+	second := uuid.New()
+
+	tests := []struct {
+		name           string
+		permissions    []string
+		expectedScopes []uuid.UUID
+		expectedGlobal bool
+	}{
+		{
+			name:        "no grants",
+			permissions: []string{"places.manage"},
+		},
+		{
+			name:           "global grant",
+			permissions:    []string{"bookings.manage:" + first.String(), "bookings.manage"},
+			expectedGlobal: true,
+		},
+		{
+			name: "coworking grants",
+			permissions: []string{
+				"bookings.manage:" + first.String(),
+				"places.manage:" + second.String(),
+				"bookings.manage:" + second.String(),
+			},
+			expectedScopes: []uuid.UUID{first, second},
+		},
+		{
+			name:           "malformed grant ignored",
+			permissions:    []string{"bookings.manage:oops", "bookings.manage:" + first.String()},
+			expectedScopes: []uuid.UUID{first},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := &jwt_validator.AccessClaims{Permissions: tt.permissions}
+
+			scopes, global := claims.CoworkingScopes("bookings.manage")
+
+			require.Equal(t, tt.expectedGlobal, global)
+			require.Equal(t, tt.expectedScopes, scopes)
+		})
+	}
+}
+
+func TestAccessClaims_HasCoworkingPermission(t *testing.T) {
+	coworkingID := uuid.New()
+
+	tests := []struct {
+		name        string
+		permissions []string
+		expected    bool
+	}{
+		{
+			name:        "global grant",
+			permissions: []string{"places.manage"},
+			expected:    true,
+		},
+		{
+			name:        "same coworking",
+			permissions: []string{"places.manage:" + coworkingID.String()},
+			expected:    true,
+		},
+		{
+			name:        "other coworking",
+			permissions: []string{"places.manage:" + uuid.NewString()},
+		},
+		{
+			name:        "other permission",
+			permissions: []string{"layouts.manage:" + coworkingID.String()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := &jwt_validator.AccessClaims{Permissions: tt.permissions}
+
+			require.Equal(t, tt.expected, claims.HasCoworkingPermission("places.manage", coworkingID))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package jwt_validator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+Denylist хранит в памяти отозванные сессии и деактивированных пользователей.
+
+Access token проверяется криптографически и живёт до exp, поэтому
+RevokeSession / SetUserActive(false) в auth-service сами по себе его не отменяют.
+Denylist закрывает это окно:
+  - сессия: любой токен с этим sessionId отклоняется;
+  - пользователь: отклоняются токены, выпущенные не позже момента деактивации
+    (после повторной активации новые токены снова принимаются).
+
+Запись хранится до expiresAt — момента, когда истекут все access token,
+выпущенные до отзыва. После этого она удаляется.
+*/
+type Denylist struct {
+	mu sync.RWMutex
+
+	sessions map[uuid.UUID]time.Time
+	users    map[uuid.UUID]revokedUser
+}
+
+type revokedUser struct {
+	revokedAt time.Time
+	expiresAt time.Time
+}
+
+func NewDenylist() *Denylist {
+	return &Denylist{
+		sessions: make(map[uuid.UUID]time.Time),
+		users:    make(map[uuid.UUID]revokedUser),
+	}
+}
+
+// RevokeSession помечает сессию отозванной до expiresAt.
+func (d *Denylist) RevokeSession(sessionID uuid.UUID, expiresAt time.Time) {
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if current, ok := d.sessions[sessionID]; !ok || current.Before(expiresAt) {
+		d.sessions[sessionID] = expiresAt
+	}
+	d.purge(now)
+}
+
+// RevokeUser отклоняет все токены пользователя, выпущенные до revokedAt, вплоть до expiresAt.
+func (d *Denylist) RevokeUser(userID uuid.UUID, revokedAt, expiresAt time.Time) {
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if current, ok := d.users[userID]; !ok || current.revokedAt.Before(revokedAt) {
+		d.users[userID] = revokedUser{revokedAt: revokedAt, expiresAt: expiresAt}
+	}
+	d.purge(now)
+}
+
+// IsRevoked сообщает, отозван ли токен с указанными claims.
+func (d *Denylist) IsRevoked(claims *AccessClaims) bool {
+	now := time.Now()
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if expiresAt, ok := d.sessions[claims.SessionID]; ok && expiresAt.After(now) {
+		return true
+	}
+
+	user, ok := d.users[claims.UserID]
+	if !ok || !user.expiresAt.After(now) {
+		return false
+	}
+
+	// Токен без iat считаем выпущенным до деактивации.
+	if claims.IssuedAt == nil {
+		return true
+	}
+
+	// iat хранится с точностью до секунды
+	return claims.IssuedAt.Unix() <= user.revokedAt.Unix()
+}
+
+// purge удаляет истёкшие записи. Вызывается под write-lock.
+func (d *Denylist) purge(now time.Time) {
+	for id, expiresAt := range d.sessions {
+		if !expiresAt.After(now) {
+			delete(d.sessions, id)
+		}
+	}
+	for id, user := range d.users {
+		if !user.expiresAt.After(now) {
+			delete(d.users, id)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package jwt_validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDenylist_IsRevoked(t *testing.T) {
+	now := time.Now()
+	revokedAt := now.Add(-time.Minute)
+	expiresAt := now.Add(time.Hour)
+
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	tests := []struct {
+		name     string
+		prepare  func(d *Denylist)
+		claims   AccessClaims
+		expected bool
+	}{
+		{
+			name:    "empty denylist",
+			prepare: func(d *Denylist) {},
+			claims:  claimsIssuedAt(userID, sessionID, now.Add(-time.Hour)),
+		},
+		{
+			name:     "revoked session",
+			prepare:  func(d *Denylist) { d.RevokeSession(sessionID, expiresAt) },
+			claims:   claimsIssuedAt(userID, sessionID, now),
+			expected: true,
+		},
+		{
+			name:    "other session",
+			prepare: func(d *Denylist) { d.RevokeSession(uuid.New(), expiresAt) },
+			claims:  claimsIssuedAt(userID, sessionID, now),
+		},
+		{
+			name:     "token issued before user revocation",
+			prepare:  func(d *Denylist) { d.RevokeUser(userID, revokedAt, expiresAt) },
+			claims:   claimsIssuedAt(userID, sessionID, revokedAt.Add(-time.Minute)),
+			expected: true,
+		},
+		{
+			// iat хранится с точностью до секунды: токен той же секунды отклоняется
+			name:     "token issued in the revocation second",
+			prepare:  func(d *Denylist) { d.RevokeUser(userID, revokedAt, expiresAt) },
+			claims:   claimsIssuedAt(userID, sessionID, revokedAt.Truncate(time.Second)),
+			expected: true,
+		},
+		{
+			name:    "token issued after reactivation",
+			prepare: func(d *Denylist) { d.RevokeUser(userID, revokedAt, expiresAt) },
+			claims:  claimsIssuedAt(userID, sessionID, revokedAt.Add(time.Second)),
+		},
+		{
+			name:     "token without iat",
+			prepare:  func(d *Denylist) { d.RevokeUser(userID, revokedAt, expiresAt) },
+			claims:   AccessClaims{UserID: userID, SessionID: sessionID},
+			expected: true,
+		},
+		{
+			name:    "other user",
+			prepare: func(d *Denylist) { d.RevokeUser(uuid.New(), revokedAt, expiresAt) },
+			claims:  claimsIssuedAt(userID, sessionID, revokedAt.Add(-time.Minute)),
+		},
+		{
+			// Более раннее событие не откатывает позднюю деактивацию
+			name: "later revocation wins",
+			prepare: func(d *Denylist) {
+				d.RevokeUser(userID, revokedAt, expiresAt)
+				d.RevokeUser(userID, revokedAt.Add(-time.Hour), expiresAt)
+			},
+			claims:   claimsIssuedAt(userID, sessionID, revokedAt.Add(-time.Minute)),
+			expected: true,
+		},
+		{
+			name:    "already expired revocation is ignored",
+			prepare: func(d *Denylist) { d.RevokeUser(userID, revokedAt, now.Add(-time.Second)) },
+			claims:  claimsIssuedAt(userID, sessionID, revokedAt.Add(-time.Minute)),
+		},
+		{
+			name:    "already expired session is ignored",
+			prepare: func(d *Denylist) { d.RevokeSession(sessionID, now.Add(-time.Second)) },
+			claims:  claimsIssuedAt(userID, sessionID, now),
+		},
+		{
+			// Запись истекла, но ещё не удалена purge
+			name: "expired entries are not applied",
+			prepare: func(d *Denylist) {
+				d.sessions[sessionID] = now.Add(-time.Second)
+				d.users[userID] = revokedUser{revokedAt: revokedAt, expiresAt: now.Add(-time.Second)}
+			},
+			claims: claimsIssuedAt(userID, sessionID, revokedAt.Add(-time.Minute)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDenylist()
+			tt.prepare(d)
+
+			require.Equal(t, tt.expected, d.IsRevoked(&tt.claims))
+		})
+	}
+}
+
+func TestDenylist_PurgesExpiredEntries(t *testing.T) {
+	now := time.Now()
+
+	expiredSession := uuid.New()
+	expiredUser := uuid.New()
+	activeSession := uuid.New()
+	activeUser := uuid.New()
+
+	d := NewDenylist()
+	d.sessions[expiredSession] = now.Add(-time.Second)
+	d.users[expiredUser] = revokedUser{revokedAt: now.Add(-time.Hour), expiresAt: now.Add(-time.Second)}
+
+	// Истёкшие записи удаляются при следующей записи в denylist
+	d.RevokeSession(activeSession, now.Add(time.Hour))
+	d.RevokeUser(activeUser, now, now.Add(time.Hour))
+
+	require.Equal(t, map[uuid.UUID]time.Time{activeSession: now.Add(time.Hour)}, d.sessions)
+	require.Len(t, d.users, 1)
+	require.Contains(t, d.users, activeUser)
+
+	// После expiresAt удаляются и они
+	d.purge(now.Add(2 * time.Hour))
+
+	require.Empty(t, d.sessions)
+	require.Empty(t, d.users)
+}
+
+func claimsIssuedAt(userID, sessionID uuid.UUID, issuedAt time.Time) AccessClaims {
+	return AccessClaims{
+		UserID:    userID,
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt: jwt.NewNumericDate(issuedAt),
+		},
+	}
+}
@@ -0,0 +1,57 @@
+package jwt_validator
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Права, которые auth-service выдаёт через роли.
+// Полный список и привязка к ролям — в таблицах permissions / role_permissions.
+const (
+	PermCoworkingsManage    = "coworkings.manage"
+	PermLayoutsManage       = "layouts.manage"
+	PermPlacesManage        = "places.manage"
+	PermBookingsManage      = "bookings.manage"
+	PermMediaManage         = "media.manage"
+	PermUsersRead           = "users.read"
+	PermUsersManage         = "users.manage"
+	PermUsersImpersonate    = "users.impersonate"
+	PermSchedulerManage     = "scheduler.manage"
+	PermNotificationsManage = "notifications.manage"
+)
+
+// Scope сервисных токенов, которые не выдаются пользователям через роли.
+const (
+	// Выгрузка данных пользователя для экспорта (auth-service -> остальные сервисы)
+	ScopeUsersExport = "users.export"
+	// Профили и ID пользователей из /internal/users auth-service
+	ScopeUsersLookup = "users.lookup"
+	// Пользователи с активными бронированиями коворкинга (booking-service)
+	ScopeBookingsActiveUsers = "bookings.active_users"
+)
+
+const permissionScopeSeparator = ":"
+
+// FormatPermission кодирует право для claim permissions.
+// coworkingID == nil — право действует во всех коворкингах.
+func FormatPermission(permission string, coworkingID *uuid.UUID) string {
+	if coworkingID == nil {
+		return permission
+	}
+	return permission + permissionScopeSeparator + coworkingID.String()
+}
+
+// ParsePermission — обратная операция к FormatPermission.
+// Некорректный scope трактуется как отсутствие права: возвращается пустой код.
+func ParsePermission(s string) (permission string, coworkingID *uuid.UUID) {
+	code, scope, scoped := strings.Cut(s, permissionScopeSeparator)
+	if !scoped {
+		return code, nil
+	}
+	id, err := uuid.Parse(scope)
+	if err != nil {
+		return "", nil
+	}
+	return code, &id
+}
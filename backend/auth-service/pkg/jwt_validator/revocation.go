@@ -0,0 +1,126 @@
+package jwt_validator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// События отзыва, публикуемые auth-service в топик auth.events
+const (
+	SessionRevokedEvent     = "auth.session.revoked"
+	UserDeactivatedEvent    = "auth.user.deactivated"
+	PermissionsChangedEvent = "auth.user.permissions_changed"
+	UserDeletedEvent        = "auth.user.deleted"
+)
+
+// RevocationPayload — payload событий auth.session.revoked, auth.user.deactivated,
+// auth.user.permissions_changed и auth.user.deleted.
+// ExpiresAt — момент, после которого все затронутые access token истекли сами.
+type RevocationPayload struct {
+	SessionID uuid.UUID `json:"sessionId,omitempty"`
+	UserID    uuid.UUID `json:"userId"`
+	RevokedAt time.Time `json:"revokedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+/*
+RevocationListener читает события отзыва из Kafka и наполняет Denylist.
+
+Denylist живёт в памяти, поэтому каждый процесс должен заново прочитать все
+события, которые ещё могут отклонить действующий access token. Для этого
+consumer group генерируется при каждом запуске: <groupID>-revocation-<uuid>.
+У новой группы нет закоммиченного offset, и reader начинает с FirstOffset
+(значение по умолчанию kafka-go), так что после рестарта denylist
+восстанавливается целиком. Окно ограничено временем жизни access token:
+события с истёкшим expiresAt Denylist отбрасывает сразу.
+
+Группы завершившихся процессов не переиспользуются и удаляются брокером
+по offsets.retention.minutes.
+*/
+type RevocationListener struct {
+	denylist *Denylist
+	consumer *kafka.KafkaConsumer
+	topic    string
+	groupID  string
+}
+
+func NewRevocationListener(
+	denylist *Denylist,
+	consumer *kafka.KafkaConsumer,
+	topic string,
+	groupID string,
+) *RevocationListener {
+	return &RevocationListener{
+		denylist: denylist,
+		consumer: consumer,
+		topic:    topic,
+		groupID:  instanceGroupID(groupID),
+	}
+}
+
+func (l *RevocationListener) Run(ctx context.Context) error {
+	logrus.Infof("RevocationListener: subscribing to topic=%s group=%s", l.topic, l.groupID)
+
+	return l.consumer.Subscribe(ctx, l.topic, l.groupID, func(ctx context.Context, key, value []byte) error {
+		if err := l.handle(value); err != nil {
+			logrus.WithError(err).Error("RevocationListener: failed to handle event")
+		}
+		// Битые сообщения не должны блокировать чтение топика
+		return nil
+	})
+}
+
+func (l *RevocationListener) handle(value []byte) error {
+	var env kafka.Envelope
+	if err := json.Unmarshal(value, &env); err != nil {
+		return fmt.Errorf("invalid envelope: %w", err)
+	}
+
+	switch env.EventType {
+	case SessionRevokedEvent, UserDeactivatedEvent, PermissionsChangedEvent, UserDeletedEvent:
+	default:
+		return nil
+	}
+
+	var p RevocationPayload
+	if err := json.Unmarshal(env.Data, &p); err != nil {
+		return fmt.Errorf("invalid payload for %s: %w", env.EventType, err)
+	}
+
+	switch env.EventType {
+	case SessionRevokedEvent:
+		if p.SessionID == uuid.Nil {
+			return fmt.Errorf("invalid payload for %s: empty sessionId", env.EventType)
+		}
+		l.denylist.RevokeSession(p.SessionID, p.ExpiresAt)
+
+	// Смена прав отзывает токены так же, как деактивация: клиент получит 401
+	// и выполнит Refresh, новый access token будет уже с актуальными правами.
+	case UserDeactivatedEvent, PermissionsChangedEvent, UserDeletedEvent:
+		if p.UserID == uuid.Nil {
+			return fmt.Errorf("invalid payload for %s: empty userId", env.EventType)
+		}
+		l.denylist.RevokeUser(p.UserID, p.RevokedAt, p.ExpiresAt)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"event_type": env.EventType,
+		"session_id": p.SessionID,
+		"user_id":    p.UserID,
+	}).Debug("RevocationListener: token revocation applied")
+
+	return nil
+}
+
+// instanceGroupID возвращает новую consumer group для каждого процесса.
+// Имя хоста не подходит: после рестарта группа продолжила бы с закоммиченного
+// offset, и пустой denylist не узнал бы о ранее отозванных токенах.
+func instanceGroupID(groupID string) string {
+	return fmt.Sprintf("%s-revocation-%s", groupID, uuid.NewString())
+}
@@ -0,0 +1,133 @@
+package jwt_validator
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevocationListener_Handle(t *testing.T) {
+	now := time.Now()
+	revokedAt := now.Add(-time.Minute)
+	expiresAt := now.Add(time.Hour)
+
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	// Токен выпущен до отзыва: отклоняется и по сессии, и по пользователю
+	oldToken := claimsIssuedAt(userID, sessionID, revokedAt.Add(-time.Minute))
+	// Токен другой сессии, выпущенный после повторной активации
+	newToken := claimsIssuedAt(userID, uuid.New(), revokedAt.Add(time.Second))
+
+	payload := func(p RevocationPayload) []byte {
+		data, err := json.Marshal(p)
+		require.NoError(t, err)
+		return data
+	}
+
+	tests := []struct {
+		name            string
+		eventType       string
+		data            []byte
+		rawValue        []byte
+		expectedErr     bool
+		expectedSession bool
+		expectedUser    bool
+	}{
+		{
+			name:            "session revoked",
+			eventType:       SessionRevokedEvent,
+			data:            payload(RevocationPayload{SessionID: sessionID, UserID: userID, RevokedAt: revokedAt, ExpiresAt: expiresAt}),
+			expectedSession: true,
+		},
+		{
+			name:         "user deactivated",
+			eventType:    UserDeactivatedEvent,
+			data:         payload(RevocationPayload{UserID: userID, RevokedAt: revokedAt, ExpiresAt: expiresAt}),
+			expectedUser: true,
+		},
+		{
+			name:         "user deleted",
+			eventType:    UserDeletedEvent,
+			data:         payload(RevocationPayload{UserID: userID, RevokedAt: revokedAt, ExpiresAt: expiresAt}),
+			expectedUser: true,
+		},
+		{
+			name:         "permissions changed",
+			eventType:    PermissionsChangedEvent,
+			data:         payload(RevocationPayload{UserID: userID, RevokedAt: revokedAt, ExpiresAt: expiresAt}),
+			expectedUser: true,
+		},
+		{
+			name:      "unknown event type is ignored",
+			eventType: "auth.user.registered",
+			data:      payload(RevocationPayload{SessionID: sessionID, UserID: userID, RevokedAt: revokedAt, ExpiresAt: expiresAt}),
+		},
+		{
+			// Неизвестный тип не разбирается, даже если payload битый
+			name:      "unknown event type with malformed payload",
+			eventType: "auth.user.registered",
+			data:      []byte(`"not an object"`),
+		},
+		{
+			name:        "malformed envelope",
+			rawValue:    []byte(`{not json`),
+			expectedErr: true,
+		},
+		{
+			name:        "malformed payload",
+			eventType:   UserDeactivatedEvent,
+			data:        []byte(`{"userId":"42"}`),
+			expectedErr: true,
+		},
+		{
+			name:        "session revoked without session id",
+			eventType:   SessionRevokedEvent,
+			data:        payload(RevocationPayload{UserID: userID, RevokedAt: revokedAt, ExpiresAt: expiresAt}),
+			expectedErr: true,
+		},
+		{
+			name:        "user deleted without user id",
+			eventType:   UserDeletedEvent,
+			data:        payload(RevocationPayload{RevokedAt: revokedAt, ExpiresAt: expiresAt}),
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := tt.rawValue
+			if value == nil {
+				var err error
+				value, err = json.Marshal(kafka.Envelope{
+					EventID:    uuid.New(),
+					EventType:  tt.eventType,
+					OccurredAt: now,
+					Data:       tt.data,
+				})
+				require.NoError(t, err)
+			}
+
+			denylist := NewDenylist()
+			l := &RevocationListener{denylist: denylist}
+
+			err := l.handle(value)
+			if tt.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.expectedSession || tt.expectedUser, denylist.IsRevoked(&oldToken))
+			// Отзыв сессии не задевает другие сессии пользователя,
+			// отзыв пользователя — токены, выпущенные после него
+			require.False(t, denylist.IsRevoked(&newToken))
+			require.Equal(t, tt.expectedSession, len(denylist.sessions) == 1)
+			require.Equal(t, tt.expectedUser, len(denylist.users) == 1)
+		})
+	}
+}
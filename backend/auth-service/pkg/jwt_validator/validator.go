@@ -10,16 +10,35 @@ import (
 var (
 	ErrInvalidToken = errors.New("invalid access token")
 	ErrExpiredToken = errors.New("token expired")
+	ErrRevokedToken = errors.New("token revoked")
+
+	ErrUserTokenRequired    = errors.New("user token required")
+	ErrServiceTokenRequired = errors.New("service token required")
+	ErrInsufficientScope    = errors.New("insufficient scope")
 )
 
 type Validator struct {
 	publicKey *rsa.PublicKey
+	denylist  *Denylist
+}
+
+type Option func(*Validator)
+
+// WithDenylist включает проверку отозванных сессий и пользователей.
+func WithDenylist(denylist *Denylist) Option {
+	return func(v *Validator) {
+		v.denylist = denylist
+	}
 }
 
-func NewValidator(publicKey *rsa.PublicKey) *Validator {
-	return &Validator{
+func NewValidator(publicKey *rsa.PublicKey, opts ...Option) *Validator {
+	v := &Validator{
 		publicKey: publicKey,
 	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
 }
 
 func (v *Validator) Validate(tokenString string) (*AccessClaims, error) {
@@ -46,5 +65,39 @@ func (v *Validator) Validate(tokenString string) (*AccessClaims, error) {
 		return nil, ErrInvalidToken
 	}
 
+	if v.denylist != nil && v.denylist.IsRevoked(claims) {
+		return nil, ErrRevokedToken
+	}
+
 	return claims, nil
-}
\ No newline at end of file
+}
+
+// ValidateUser принимает только токены пользователей.
+// Сервисный токен не даёт доступа к пользовательским маршрутам.
+func (v *Validator) ValidateUser(tokenString string) (*AccessClaims, error) {
+	claims, err := v.Validate(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.IsService() {
+		return nil, ErrUserTokenRequired
+	}
+	return claims, nil
+}
+
+// ValidateService принимает только токены сервисов, у которых есть все перечисленные scope.
+func (v *Validator) ValidateService(tokenString string, scopes ...string) (*AccessClaims, error) {
+	claims, err := v.Validate(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.IsService() {
+		return nil, ErrServiceTokenRequired
+	}
+	for _, scope := range scopes {
+		if !claims.HasScope(scope) {
+			return nil, ErrInsufficientScope
+		}
+	}
+	return claims, nil
+}
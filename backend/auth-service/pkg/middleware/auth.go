@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+const USER_CLAIMS_KEY = "userClaims"
+const SERVICE_CLAIMS_KEY = "serviceClaims"
+
+type AuthMiddleware struct {
+	jwtValidator *jwt_validator.Validator
+}
+
+func New(jwtValidator *jwt_validator.Validator) *AuthMiddleware {
+	return &AuthMiddleware{
+		jwtValidator: jwtValidator,
+	}
+}
+
+// Middleware пропускает только токены пользователей.
+func (m *AuthMiddleware) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token, errMsg := bearerToken(c)
+		if errMsg != "" {
+			return c.JSON(http.StatusUnauthorized, map[string]string{"error": errMsg})
+		}
+
+		claims, err := m.jwtValidator.ValidateUser(token)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, err.Error())
+		}
+
+		c.Set(USER_CLAIMS_KEY, claims)
+
+		if claims.IsImpersonated() {
+			return impersonated(c, claims, next)
+		}
+
+		return next(c)
+	}
+}
+
+// Optional проверяет токен пользователя, только если он передан.
+// Запрос без заголовка Authorization проходит анонимно.
+func (m *AuthMiddleware) Optional(next echo.HandlerFunc) echo.HandlerFunc {
+	authenticated := m.Middleware(next)
+	return func(c echo.Context) error {
+		if c.Request().Header.Get("Authorization") == "" {
+			return next(c)
+		}
+		return authenticated(c)
+	}
+}
+
+/*
+impersonated пропускает запрос сотрудника поддержки, вошедшего от имени пользователя.
+
+Без act.allowWrite разрешено только чтение. Каждый запрос пишется
+в аудит-лог сервиса вместе с итоговым статусом.
+*/
+func impersonated(c echo.Context, claims *jwt_validator.AccessClaims, next echo.HandlerFunc) error {
+	req := c.Request()
+	audit := logrus.WithFields(logrus.Fields{
+		"audit":            "impersonation",
+		"impersonation_id": claims.SessionID,
+		"actor_id":         claims.Act.UserID,
+		"actor_email":      claims.Act.Email,
+		"user_id":          claims.UserID,
+		"method":           req.Method,
+		"path":             req.URL.Path,
+	})
+
+	if !claims.Act.AllowWrite && !isSafeMethod(req.Method) {
+		audit.WithField("status", http.StatusForbidden).Warn("Impersonated write request blocked")
+		return echo.NewHTTPError(http.StatusForbidden, "Read-only impersonation")
+	}
+
+	err := next(c)
+
+	status := c.Response().Status
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		status = httpErr.Code
+	}
+	audit.WithField("status", status).Info("Impersonated request")
+
+	return err
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// InteractiveOnly закрывает маршрут для JWT, полученных обменом персонального
+// токена, и для токенов поддержки, вошедшей от имени пользователя: скрипт
+// или сотрудник не должны управлять сессиями, аккаунтом и выпускать новые токены.
+// Ставится после Middleware.
+func InteractiveOnly(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		claims, err := GetUserFromContext(c)
+		if err != nil {
+			return err
+		}
+		if claims.IsPersonalToken() {
+			return echo.NewHTTPError(http.StatusForbidden, "Not allowed with personal access token")
+		}
+		if claims.IsImpersonated() {
+			return echo.NewHTTPError(http.StatusForbidden, "Not allowed while impersonating")
+		}
+		return next(c)
+	}
+}
+
+// ServiceOnly пропускает только токены сервисов (client credentials)
+// с указанными scope. Используется для внутренних маршрутов.
+func (m *AuthMiddleware) ServiceOnly(scopes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			token, errMsg := bearerToken(c)
+			if errMsg != "" {
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": errMsg})
+			}
+
+			claims, err := m.jwtValidator.ValidateService(token, scopes...)
+			if err != nil {
+				if errors.Is(err, jwt_validator.ErrServiceTokenRequired) ||
+					errors.Is(err, jwt_validator.ErrInsufficientScope) {
+					return c.JSON(http.StatusForbidden, err.Error())
+				}
+				return c.JSON(http.StatusUnauthorized, err.Error())
+			}
+
+			c.Set(SERVICE_CLAIMS_KEY, claims)
+
+			return next(c)
+		}
+	}
+}
+
+// bearerToken достаёт токен из заголовка Authorization.
+// Вторым значением возвращается текст ошибки для ответа 401.
+func bearerToken(c echo.Context) (string, string) {
+	authHeader := c.Request().Header.Get("Authorization")
+	if authHeader == "" {
+		return "", "Authorization header required"
+	}
+
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return "", "Invalid authorization header format"
+	}
+
+	return parts[1], ""
+}
+
+func GetUserFromContext(c echo.Context) (*jwt_validator.AccessClaims, error) {
+	claims, ok := c.Get(USER_CLAIMS_KEY).(*jwt_validator.AccessClaims)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "User not found in context")
+	}
+	return claims, nil
+}
+
+func GetServiceFromContext(c echo.Context) (*jwt_validator.AccessClaims, error) {
+	claims, ok := c.Get(SERVICE_CLAIMS_KEY).(*jwt_validator.AccessClaims)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Service not found in context")
+	}
+	return claims, nil
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// ScopeFunc извлекает из запроса ID коворкинга, к которому относится действие.
+type ScopeFunc func(c echo.Context) (uuid.UUID, error)
+
+// RequirePermission пропускает запрос, если право выдано глобально.
+func RequirePermission(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, err := GetUserFromContext(c)
+			if err != nil {
+				logrus.Errorf("Permission middleware: get user from context error:%v", err)
+				return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+			}
+
+			if !claims.HasPermission(permission) {
+				return echo.NewHTTPError(http.StatusForbidden, "Access denied")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+/*
+RequireCoworkingPermission пропускает запрос, если право выдано глобально
+или в коворкинге, который scope определяет по запросу.
+
+Глобальное право проверяется первым, поэтому scope (он может ходить в БД)
+вызывается только для пользователей с точечными правами. Ошибку
+*echo.HTTPError из scope (например, 404 для несуществующего ресурса)
+middleware возвращает как есть, остальные ошибки превращаются в 403.
+*/
+func RequireCoworkingPermission(permission string, scope ScopeFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, err := GetUserFromContext(c)
+			if err != nil {
+				logrus.Errorf("Permission middleware: get user from context error:%v", err)
+				return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+			}
+
+			if claims.HasPermission(permission) {
+				return next(c)
+			}
+			if !claims.HasAnyPermission(permission) {
+				return echo.NewHTTPError(http.StatusForbidden, "Access denied")
+			}
+
+			coworkingID, err := scope(c)
+			if err != nil {
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					return httpErr
+				}
+				logrus.WithError(err).Warn("Permission middleware: cannot resolve coworking scope")
+				return echo.NewHTTPError(http.StatusForbidden, "Access denied")
+			}
+
+			if !claims.HasCoworkingPermission(permission, coworkingID) {
+				return echo.NewHTTPError(http.StatusForbidden, "Access denied")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// FromParam — ScopeFunc для ID коворкинга в path-параметре.
+func FromParam(name string) ScopeFunc {
+	return func(c echo.Context) (uuid.UUID, error) {
+		return uuid.Parse(c.Param(name))
+	}
+}
+
+// FromQuery — ScopeFunc для ID коворкинга в query-параметре.
+func FromQuery(name string) ScopeFunc {
+	return func(c echo.Context) (uuid.UUID, error) {
+		return uuid.Parse(c.QueryParam(name))
+	}
+}
@@ -0,0 +1,168 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+)
+
+func ok(c echo.Context) error {
+	return c.NoContent(http.StatusOK)
+}
+
+func TestRequireCoworkingPermission(t *testing.T) {
+	coworkingID := uuid.New()
+	otherCoworkingID := uuid.New()
+
+	scopeTo := func(id uuid.UUID) middleware.ScopeFunc {
+		return func(echo.Context) (uuid.UUID, error) { return id, nil }
+	}
+	noScope := func(echo.Context) (uuid.UUID, error) {
+		t.Fatal("scope must not be called")
+		return uuid.Nil, nil
+	}
+
+	tests := []struct {
+		name           string
+		claims         *jwt_validator.AccessClaims
+		scope          middleware.ScopeFunc
+		expectedStatus int
+	}{
+		{
+			name:           "no claims",
+			scope:          noScope,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "global grant skips scope",
+			claims:         &jwt_validator.AccessClaims{Permissions: []string{"places.manage"}},
+			scope:          noScope,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "coworking grant, same coworking",
+			claims: &jwt_validator.AccessClaims{Permissions: []string{
+				"places.manage:" + coworkingID.String(),
+			}},
+			scope:          scopeTo(coworkingID),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "coworking grant, other coworking",
+			claims: &jwt_validator.AccessClaims{Permissions: []string{
+				"places.manage:" + coworkingID.String(),
+			}},
+			scope:          scopeTo(otherCoworkingID),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "grant for another permission",
+			claims: &jwt_validator.AccessClaims{Permissions: []string{
+				"bookings.manage",
+				"layouts.manage:" + coworkingID.String(),
+			}},
+			scope:          noScope,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "malformed coworking id in grant",
+			claims:         &jwt_validator.AccessClaims{Permissions: []string{"places.manage:not-a-uuid"}},
+			scope:          noScope,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "scope error",
+			claims: &jwt_validator.AccessClaims{Permissions: []string{
+				"places.manage:" + coworkingID.String(),
+			}},
+			scope: func(echo.Context) (uuid.UUID, error) {
+				return uuid.Nil, errors.New("db is down")
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "scope not found",
+			claims: &jwt_validator.AccessClaims{Permissions: []string{
+				"places.manage:" + coworkingID.String(),
+			}},
+			scope: func(echo.Context) (uuid.UUID, error) {
+				return uuid.Nil, echo.NewHTTPError(http.StatusNotFound, "place not found")
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPatch, "/", nil), rec)
+			if tt.claims != nil {
+				c.Set(middleware.USER_CLAIMS_KEY, tt.claims)
+			}
+
+			err := middleware.RequireCoworkingPermission("places.manage", tt.scope)(ok)(c)
+
+			if tt.expectedStatus == http.StatusOK {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, rec.Code)
+				return
+			}
+
+			var httpErr *echo.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			require.Equal(t, tt.expectedStatus, httpErr.Code)
+		})
+	}
+}
+
+func TestFromQuery(t *testing.T) {
+	coworkingID := uuid.New()
+
+	tests := []struct {
+		name        string
+		query       string
+		expectedID  uuid.UUID
+		expectedErr bool
+	}{
+		{
+			name:       "valid id",
+			query:      "?coworkingId=" + coworkingID.String(),
+			expectedID: coworkingID,
+		},
+		{
+			name:        "missing",
+			query:       "",
+			expectedErr: true,
+		},
+		{
+			name:        "malformed",
+			query:       "?coworkingId=abc",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/"+tt.query, nil), httptest.NewRecorder())
+
+			id, err := middleware.FromQuery("coworkingId")(c)
+
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedID, id)
+		})
+	}
+}
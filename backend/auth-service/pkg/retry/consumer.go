@@ -0,0 +1,189 @@
+package retry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
+	"github.com/sirupsen/logrus"
+)
+
+// Типы событий в retry-топиках и DLQ
+const (
+	RetryEvent      = "consumer.retry"
+	DeadLetterEvent = "consumer.dead_letter"
+)
+
+type Publisher interface {
+	Publish(ctx context.Context, topic string, eventType string, payload any) error
+}
+
+// Policy — задержка перед n-м повтором: Backoff·2^(n-1), но не больше MaxBackoff.
+// После MaxAttempts неудачных повторов сообщение уходит в DLQ.
+type Policy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+// publishBackoff — паузы между попытками переложить сообщение в retry-топик или DLQ
+var publishBackoff = Policy{Backoff: time.Second, MaxBackoff: 30 * time.Second}
+
+func (p Policy) delay(attempt int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxBackoff)
+}
+
+// Message — исходное сообщение с историей обработки. Payload событий
+// в retry-топике и DLQ.
+type Message struct {
+	Topic string `json:"topic"`
+	Key   []byte `json:"key,omitempty"`
+	Value []byte `json:"value"`
+
+	// Сколько повторов уже запланировано
+	Attempts int       `json:"attempts"`
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failedAt"`
+	RetryAt  time.Time `json:"retryAt,omitzero"`
+}
+
+func RetryTopic(topic, groupID string) string {
+	return topic + "." + groupID + ".retry"
+}
+
+func DeadLetterTopic(topic, groupID string) string {
+	return topic + "." + groupID + ".dlq"
+}
+
+/*
+Consumer — kafka.KafkaConsumer с повторами и DLQ.
+
+Если обработчик вернул ошибку, сообщение перекладывается в
+<topic>.<group>.retry и offset основного топика коммитится, так что
+одно сообщение не задерживает остальные. Retry-топик читается той же
+группой: consumer ждёт RetryAt и снова вызывает обработчик. Ошибки,
+помеченные Permanent, и сообщения после Policy.MaxAttempts повторов
+попадают в <topic>.<group>.dlq.
+
+Повторы выполняются по порядку, поэтому сообщение с большой задержкой
+задерживает следующие за ним в той же партиции retry-топика.
+*/
+type Consumer struct {
+	main      *kafka.KafkaConsumer
+	retry     *kafka.KafkaConsumer
+	publisher Publisher
+	policy    Policy
+}
+
+func NewConsumer(brokers []string, publisher Publisher, policy Policy) *Consumer {
+	return &Consumer{
+		main:      kafka.NewConsumer(brokers),
+		retry:     kafka.NewConsumer(brokers),
+		publisher: publisher,
+		policy:    policy,
+	}
+}
+
+// Subscribe подписывает handler на topic и на его retry-топик
+func (c *Consumer) Subscribe(
+	ctx context.Context,
+	topic string,
+	groupID string,
+	handler func(context.Context, []byte, []byte) error,
+) error {
+	err := c.main.Subscribe(ctx, topic, groupID, func(ctx context.Context, key, value []byte) error {
+		err := handler(ctx, key, value)
+		if err == nil {
+			return nil
+		}
+		return c.reschedule(ctx, groupID, Message{Topic: topic, Key: key, Value: value}, err)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.retry.Subscribe(ctx, RetryTopic(topic, groupID), groupID, func(ctx context.Context, _, value []byte) error {
+		var env kafka.Envelope
+		var msg Message
+		if err := json.Unmarshal(value, &env); err != nil {
+			logrus.WithError(err).Error("RetryConsumer: invalid retry envelope, dropped")
+			return nil
+		}
+		if err := json.Unmarshal(env.Data, &msg); err != nil {
+			logrus.WithError(err).Error("RetryConsumer: invalid retry message, dropped")
+			return nil
+		}
+
+		// Без commit: после рестарта сообщение будет прочитано снова
+		if wait := time.Until(msg.RetryAt); wait > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+
+		err := handler(ctx, msg.Key, msg.Value)
+		if err == nil {
+			logrus.WithFields(logrus.Fields{
+				"topic":   msg.Topic,
+				"attempt": msg.Attempts,
+			}).Info("RetryConsumer: message processed after retry")
+			return nil
+		}
+		return c.reschedule(ctx, groupID, msg, err)
+	})
+}
+
+// reschedule перекладывает сообщение в retry-топик или DLQ.
+//
+// kafka.KafkaConsumer после ошибки обработчика читает дальше и коммитит
+// следующие offset, так что вернуть ошибку значит потерять сообщение.
+// Поэтому публикация повторяется, пока не удастся; ошибка возвращается
+// только при отмене ctx — consumer останавливается без commit, и после
+// рестарта сообщение будет прочитано снова.
+func (c *Consumer) reschedule(ctx context.Context, groupID string, msg Message, cause error) error {
+	msg.Error = cause.Error()
+	msg.FailedAt = time.Now().UTC()
+
+	log := logrus.WithFields(logrus.Fields{
+		"topic":   msg.Topic,
+		"group":   groupID,
+		"attempt": msg.Attempts,
+	}).WithError(cause)
+
+	var topic, eventType string
+
+	if IsPermanent(cause) || msg.Attempts >= c.policy.MaxAttempts {
+		topic, eventType = DeadLetterTopic(msg.Topic, groupID), DeadLetterEvent
+		msg.RetryAt = time.Time{}
+		log.Error("RetryConsumer: message moved to dead letter topic")
+	} else {
+		msg.Attempts++
+		msg.RetryAt = msg.FailedAt.Add(c.policy.delay(msg.Attempts))
+		topic, eventType = RetryTopic(msg.Topic, groupID), RetryEvent
+		log.Warnf("RetryConsumer: handler failed, retry at %s", msg.RetryAt.Format(time.RFC3339))
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.publisher.Publish(ctx, topic, eventType, msg)
+		if err == nil {
+			return nil
+		}
+
+		wait := publishBackoff.delay(attempt)
+		log.WithField("publish_error", err).Errorf("RetryConsumer: failed to publish to %s, next attempt in %s", topic, wait)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("publish to %s: %w", topic, ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type publishCall struct {
+	topic     string
+	eventType string
+	msg       Message
+}
+
+// fakePublisher возвращает ошибки из errs по очереди, затем nil
+type fakePublisher struct {
+	errs  []error
+	calls []publishCall
+}
+
+func (p *fakePublisher) Publish(_ context.Context, topic string, eventType string, payload any) error {
+	p.calls = append(p.calls, publishCall{topic: topic, eventType: eventType, msg: payload.(Message)})
+	if len(p.errs) == 0 {
+		return nil
+	}
+	err := p.errs[0]
+	p.errs = p.errs[1:]
+	return err
+}
+
+func TestPolicy_delay(t *testing.T) {
+	policy := Policy{MaxAttempts: 5, Backoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{attempt: 1, expected: time.Second},
+		{attempt: 2, expected: 2 * time.Second},
+		{attempt: 3, expected: 4 * time.Second},
+		{attempt: 4, expected: 8 * time.Second},
+		{attempt: 5, expected: 10 * time.Second},
+		{attempt: 50, expected: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, policy.delay(tt.attempt), "attempt %d", tt.attempt)
+	}
+}
+
+func TestConsumer_reschedule(t *testing.T) {
+	policy := Policy{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour}
+	cause := errors.New("db is down")
+
+	tests := []struct {
+		name              string
+		attempts          int
+		cause             error
+		expectedTopic     string
+		expectedEventType string
+		expectedAttempts  int
+		expectedDelay     time.Duration
+	}{
+		{
+			name:              "first failure goes to retry topic",
+			cause:             cause,
+			expectedTopic:     "booking.events.notification-service.retry",
+			expectedEventType: RetryEvent,
+			expectedAttempts:  1,
+			expectedDelay:     time.Minute,
+		},
+		{
+			name:              "backoff grows with attempts",
+			attempts:          2,
+			cause:             cause,
+			expectedTopic:     "booking.events.notification-service.retry",
+			expectedEventType: RetryEvent,
+			expectedAttempts:  3,
+			expectedDelay:     4 * time.Minute,
+		},
+		{
+			name:              "attempts exhausted",
+			attempts:          3,
+			cause:             cause,
+			expectedTopic:     "booking.events.notification-service.dlq",
+			expectedEventType: DeadLetterEvent,
+			expectedAttempts:  3,
+		},
+		{
+			name:              "permanent error skips retries",
+			cause:             Permanent(cause),
+			expectedTopic:     "booking.events.notification-service.dlq",
+			expectedEventType: DeadLetterEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &fakePublisher{}
+			c := &Consumer{publisher: publisher, policy: policy}
+
+			err := c.reschedule(context.Background(), "notification-service", Message{
+				Topic:    "booking.events",
+				Key:      []byte("booking.booking.created"),
+				Value:    []byte(`{"eventId":"1"}`),
+				Attempts: tt.attempts,
+			}, tt.cause)
+
+			require.NoError(t, err)
+			require.Len(t, publisher.calls, 1)
+
+			call := publisher.calls[0]
+			require.Equal(t, tt.expectedTopic, call.topic)
+			require.Equal(t, tt.expectedEventType, call.eventType)
+			require.Equal(t, tt.expectedAttempts, call.msg.Attempts)
+			require.Equal(t, "db is down", call.msg.Error)
+			require.Equal(t, []byte(`{"eventId":"1"}`), call.msg.Value)
+
+			if tt.expectedDelay == 0 {
+				require.True(t, call.msg.RetryAt.IsZero())
+			} else {
+				require.Equal(t, call.msg.FailedAt.Add(tt.expectedDelay), call.msg.RetryAt)
+			}
+		})
+	}
+}
+
+func TestConsumer_reschedule_PublishFailure(t *testing.T) {
+	defer func(b Policy) { publishBackoff = b }(publishBackoff)
+	publishBackoff = Policy{Backoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	policy := Policy{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour}
+	kafkaDown := errors.New("kafka is down")
+
+	t.Run("publish is retried until it succeeds", func(t *testing.T) {
+		publisher := &fakePublisher{errs: []error{kafkaDown, kafkaDown}}
+		c := &Consumer{publisher: publisher, policy: policy}
+
+		err := c.reschedule(context.Background(), "g", Message{Topic: "t"}, errors.New("failed"))
+
+		require.NoError(t, err)
+		require.Len(t, publisher.calls, 3)
+		// Каждая попытка публикует одно и то же сообщение
+		require.Equal(t, publisher.calls[0].msg, publisher.calls[2].msg)
+	})
+
+	t.Run("cancelled context stops the consumer", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		publisher := &fakePublisher{errs: []error{kafkaDown, kafkaDown, kafkaDown}}
+		c := &Consumer{
+			publisher: &cancelOnPublish{fakePublisher: publisher, cancel: cancel},
+			policy:    policy,
+		}
+
+		err := c.reschedule(ctx, "g", Message{Topic: "t"}, errors.New("failed"))
+
+		require.ErrorIs(t, err, context.Canceled)
+		require.Len(t, publisher.calls, 1)
+	})
+}
+
+type cancelOnPublish struct {
+	*fakePublisher
+	cancel context.CancelFunc
+}
+
+func (p *cancelOnPublish) Publish(ctx context.Context, topic string, eventType string, payload any) error {
+	p.cancel()
+	return p.fakePublisher.Publish(ctx, topic, eventType, payload)
+}
@@ -0,0 +1,27 @@
+package transactor
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+// Joining выполняет fn в транзакции, уже открытой в ctx, а если её нет —
+// открывает новую. postgres.Postgres.WithinTransaction всегда начинает
+// отдельную транзакцию, из-за чего работа сервиса не попадала бы
+// в транзакцию inbox.Inbox.
+type Joining struct {
+	pg *postgres.Postgres
+}
+
+func NewJoining(pg *postgres.Postgres) *Joining {
+	return &Joining{pg: pg}
+}
+
+func (j *Joining) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := j.pg.GetTxManager(ctx).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+	return j.pg.WithinTransaction(ctx, fn)
+}
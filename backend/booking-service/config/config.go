@@ -16,6 +16,7 @@ type (
 		Log      Log      `yaml:"logger"`
 		Kafka    Kafka    `yaml:"kafka"`
 		Outbox   Outbox   `yaml:"outbox"`
+		AuthAPI  AuthAPI  `yaml:"auth_api"`
 	}
 
 	App struct {
@@ -40,10 +41,19 @@ type (
 		PublicKeyPath string `env-required:"true" yaml:"public_key_path" env:"AUTH_PUBLIC_KEY_PATH"`
 	}
 
+	// AuthAPI — внутренние маршруты auth-service, доступ по client credentials
+	AuthAPI struct {
+		URL          string        `yaml:"url" env:"AUTH_API_URL" env-default:"http://auth-service:8080"`
+		ClientID     string        `yaml:"client_id" env:"AUTH_CLIENT_ID"`
+		ClientSecret string        `yaml:"client_secret" env:"AUTH_CLIENT_SECRET"`
+		Timeout      time.Duration `yaml:"timeout" env:"AUTH_API_TIMEOUT" env-default:"3s"`
+	}
+
 	Kafka struct {
 		Brokers []string `env-required:"true" yaml:"brokers" env:"KAFKA_BROKERS"`
 		Topics  struct {
 			SchedulerEvents string `env-required:"true" yaml:"scheduler_events" env:"KAFKA_SCHEDULER_EVENTS"`
+			AuthEvents      string `env-required:"true" yaml:"auth_events" env:"KAFKA_AUTH_EVENTS"`
 		} `env-required:"true" yaml:"topics" env:"KAFKA_TOPICS"`
 		Producer KafkaProducer `yaml:"producer"`
 		Consumer KafkaConsumer `yaml:"consumer"`
@@ -62,6 +72,13 @@ type (
 		SessionTimeout    time.Duration `yaml:"session_timeout" env:"KAFKA_CONSUMER_SESSION_TIMEOUT"`
 		HeartbeatInterval time.Duration `yaml:"heartbeat_interval" env:"KAFKA_CONSUMER_HEARTBEAT_INTERVAL"`
 		CommitInterval    time.Duration `yaml:"commit_interval" env:"KAFKA_CONSUMER_COMMIT_INTERVAL"`
+
+		// Повторы через <topic>.<group>.retry, после исчерпания — <topic>.<group>.dlq
+		RetryMaxAttempts int           `yaml:"retry_max_attempts" env:"KAFKA_CONSUMER_RETRY_MAX_ATTEMPTS" env-default:"5"`
+		RetryBackoff     time.Duration `yaml:"retry_backoff" env:"KAFKA_CONSUMER_RETRY_BACKOFF" env-default:"5s"`
+		RetryMaxBackoff  time.Duration `yaml:"retry_max_backoff" env:"KAFKA_CONSUMER_RETRY_MAX_BACKOFF" env-default:"5m"`
+		// Сколько хранить отметки об обработанных событиях
+		InboxRetention time.Duration `yaml:"inbox_retention" env:"KAFKA_CONSUMER_INBOX_RETENTION" env-default:"168h"`
 	}
 
 	Outbox struct {
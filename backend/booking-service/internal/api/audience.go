@@ -0,0 +1,15 @@
+package api
+
+import (
+	"github.com/4udiwe/cowoking/booking-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+)
+
+// AudienceFromClaims собирает ограничения по группам из access token.
+// Администраторы коворкингов видят и бронируют всё независимо от групп.
+func AudienceFromClaims(claims *jwt_validator.AccessClaims) entity.Audience {
+	return entity.Audience{
+		GroupIDs:     claims.Groups,
+		Unrestricted: claims.HasPermission(jwt_validator.PermCoworkingsManage),
+	}
+}
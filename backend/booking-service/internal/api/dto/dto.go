@@ -10,30 +10,33 @@ import (
 // Base DTOs for responses
 
 type Coworking struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	Address   string    `json:"address"`
-	IsActive  bool      `json:"isActive"`
-	MediaIDs  []string  `json:"mediaIds" validate:"required"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID              uuid.UUID   `json:"id"`
+	Name            string      `json:"name"`
+	Address         string      `json:"address"`
+	IsActive        bool        `json:"isActive"`
+	MediaIDs        []string    `json:"mediaIds" validate:"required"`
+	CreatedAt       time.Time   `json:"createdAt"`
+	UpdatedAt       time.Time   `json:"updatedAt"`
+	AllowedGroupIDs []uuid.UUID `json:"allowedGroupIds,omitempty"`
 }
 
 type Place struct {
-	ID            uuid.UUID `json:"id"`
-	CoworkingID   uuid.UUID `json:"coworkingId"`
-	CoworkingName string    `json:"coworkingName"`
-	Label         string    `json:"label"`
-	PlaceType     string    `json:"placeType"`
-	IsActive      bool      `json:"isActive"`
-	CreatedAt     time.Time `json:"createdAt"`
-	UpdatedAt     time.Time `json:"updatedAt"`
+	ID              uuid.UUID   `json:"id"`
+	CoworkingID     uuid.UUID   `json:"coworkingId"`
+	CoworkingName   string      `json:"coworkingName"`
+	Label           string      `json:"label"`
+	PlaceType       string      `json:"placeType"`
+	IsActive        bool        `json:"isActive"`
+	CreatedAt       time.Time   `json:"createdAt"`
+	UpdatedAt       time.Time   `json:"updatedAt"`
+	AllowedGroupIDs []uuid.UUID `json:"allowedGroupIds,omitempty"`
 }
 
 type Booking struct {
 	ID           uuid.UUID  `json:"id"`
 	UserID       uuid.UUID  `json:"userId"`
 	UserName     string     `json:"userName"`
+	UserEmail    string     `json:"userEmail,omitempty"`
 	Place        Place      `json:"place"`
 	StartTime    time.Time  `json:"startTime"`
 	EndTime      time.Time  `json:"endTime"`
@@ -110,6 +113,16 @@ type CreatePlaceDTO struct {
 	PlaceType string `json:"placeType" validate:"required,oneof=open_desk meeting_room private_office"`
 }
 
+type SetCoworkingAllowedGroupsRequest struct {
+	CoworkingID uuid.UUID   `param:"coworkingId" validate:"required"`
+	GroupIDs    []uuid.UUID `json:"groupIds" validate:"max=100"`
+}
+
+type SetPlaceAllowedGroupsRequest struct {
+	PlaceID  uuid.UUID   `param:"placeId" validate:"required"`
+	GroupIDs []uuid.UUID `json:"groupIds" validate:"max=100"`
+}
+
 type SetPlaceActiveRequest struct {
 	PlaceID uuid.UUID `param:"placeId" validate:"required"`
 	Active  *bool     `json:"active"`
@@ -5,9 +5,14 @@ import (
 	"time"
 
 	"github.com/4udiwe/cowoking/booking-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/authclient"
 	"github.com/google/uuid"
 )
 
 type BookingService interface {
 	ListActiveBookingsForAdmin(ctx context.Context, coworkingID uuid.UUID, page int, pageSize int, dateFrom *time.Time, dateTo *time.Time, placeType *string, sortBy *string) ([]entity.Booking, int, error)
 }
+
+type UserDirectory interface {
+	GetUsers(ctx context.Context, userIDs []uuid.UUID) ([]authclient.User, error)
+}
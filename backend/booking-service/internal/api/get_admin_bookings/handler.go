@@ -7,16 +7,19 @@ import (
 	"github.com/4udiwe/cowoking/booking-service/internal/api/dto"
 	"github.com/4udiwe/cowoking/booking-service/internal/entity"
 	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
 )
 
 type handler struct {
-	s BookingService
+	s     BookingService
+	users UserDirectory
 }
 
-func New(bookingService BookingService) api.Handler {
-	return decorator.NewBindAndValidateDerocator(&handler{s: bookingService})
+func New(bookingService BookingService, users UserDirectory) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: bookingService, users: users})
 }
 
 type Request = dto.GetAdminActiveBookingsRequest
@@ -35,12 +38,25 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 
 	totalPages := (totalCount + in.PageSize - 1) / in.PageSize
 
+	// Email подтягивается из auth-service. Если он недоступен, список
+	// отдаётся без email — это не повод ломать админку.
+	emails := make(map[uuid.UUID]string)
+	userIDs := lo.Uniq(lo.Map(bookings, func(b entity.Booking, _ int) uuid.UUID { return b.UserID }))
+	if users, err := h.users.GetUsers(ctx.Request().Context(), userIDs); err != nil {
+		logrus.WithError(err).Warn("GetAdminBookings: failed to fetch users from auth-service")
+	} else {
+		for _, u := range users {
+			emails[u.ID] = u.Email
+		}
+	}
+
 	return ctx.JSON(http.StatusOK, Response{
 		Bookings: lo.Map(bookings, func(b entity.Booking, _ int) dto.Booking {
 			return dto.Booking{
-				ID:       b.ID,
-				UserID:   b.UserID,
-				UserName: b.UserName,
+				ID:        b.ID,
+				UserID:    b.UserID,
+				UserName:  b.UserName,
+				UserEmail: emails[b.UserID],
 				Place: dto.Place{
 					ID:          b.Place.ID,
 					CoworkingID: b.Place.Coworking.ID,
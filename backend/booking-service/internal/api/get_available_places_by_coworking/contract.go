@@ -9,6 +9,6 @@ import (
 )
 
 type BookingService interface {
-	GetAvailablePlacesByCoworking(ctx context.Context, coworkingID uuid.UUID, start time.Time, end time.Time) ([]entity.Place, error)
+	GetAvailablePlacesByCoworking(ctx context.Context, coworkingID uuid.UUID, start time.Time, end time.Time, audience entity.Audience) ([]entity.Place, error)
 }
 
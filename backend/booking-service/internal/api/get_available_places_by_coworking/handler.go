@@ -9,6 +9,7 @@ import (
 	"github.com/4udiwe/cowoking/booking-service/internal/entity"
 	booking_service "github.com/4udiwe/cowoking/booking-service/internal/service/booking"
 	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/lo"
 )
@@ -28,7 +29,14 @@ type Response struct {
 }
 
 func (h *handler) Handle(ctx echo.Context, in Request) error {
-	places, err := h.s.GetAvailablePlacesByCoworking(ctx.Request().Context(), in.CoworkingID, lo.FromPtr(in.StartTime), lo.FromPtr(in.EndTime))
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	places, err := h.s.GetAvailablePlacesByCoworking(
+		ctx.Request().Context(), in.CoworkingID, lo.FromPtr(in.StartTime), lo.FromPtr(in.EndTime), api.AudienceFromClaims(claims),
+	)
 	if err != nil {
 		if errors.Is(err, booking_service.ErrCoworkingNotFound) {
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
@@ -39,13 +47,14 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 	return ctx.JSON(http.StatusOK, Response{
 		Places: lo.Map(places, func(p entity.Place, _ int) dto.Place {
 			return dto.Place{
-				ID:          p.ID,
-				CoworkingID: p.Coworking.ID,
-				Label:       p.Label,
-				PlaceType:   p.PlaceType,
-				IsActive:    p.IsActive,
-				CreatedAt:   p.CreatedAt,
-				UpdatedAt:   p.UpdatedAt,
+				ID:              p.ID,
+				CoworkingID:     p.Coworking.ID,
+				Label:           p.Label,
+				PlaceType:       p.PlaceType,
+				IsActive:        p.IsActive,
+				CreatedAt:       p.CreatedAt,
+				UpdatedAt:       p.UpdatedAt,
+				AllowedGroupIDs: p.AllowedGroupIDs,
 			}
 		}),
 	})
@@ -31,12 +31,13 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 	return ctx.JSON(http.StatusOK, dto.Coworking{
-		ID:        c.ID,
-		Name:      c.Name,
-		Address:   c.Address,
-		IsActive:  c.IsActive,
-		MediaIDs:  c.MediaIDs,
-		CreatedAt: c.CreatedAt,
-		UpdatedAt: c.UpdatedAt,
+		ID:              c.ID,
+		Name:            c.Name,
+		Address:         c.Address,
+		IsActive:        c.IsActive,
+		MediaIDs:        c.MediaIDs,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+		AllowedGroupIDs: c.AllowedGroupIDs,
 	})
 }
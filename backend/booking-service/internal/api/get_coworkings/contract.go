@@ -7,5 +7,5 @@ import (
 )
 
 type BookingService interface {
-	ListCoworkings(ctx context.Context) ([]entity.Coworking, error)
+	ListCoworkings(ctx context.Context, audience entity.Audience) ([]entity.Coworking, error)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/4udiwe/cowoking/booking-service/internal/api/dto"
 	"github.com/4udiwe/cowoking/booking-service/internal/entity"
 	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/lo"
 )
@@ -26,7 +27,12 @@ type Response struct {
 }
 
 func (h *handler) Handle(ctx echo.Context, in Request) error {
-	coworkings, err := h.s.ListCoworkings(ctx.Request().Context())
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	coworkings, err := h.s.ListCoworkings(ctx.Request().Context(), api.AudienceFromClaims(claims))
 
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
@@ -34,13 +40,14 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 	return ctx.JSON(http.StatusOK, Response{
 		Coworkings: lo.Map(coworkings, func(c entity.Coworking, _ int) dto.Coworking {
 			return dto.Coworking{
-				ID:        c.ID,
-				Name:      c.Name,
-				Address:   c.Address,
-				IsActive:  c.IsActive,
-				MediaIDs:  c.MediaIDs,
-				CreatedAt: c.CreatedAt,
-				UpdatedAt: c.UpdatedAt,
+				ID:              c.ID,
+				Name:            c.Name,
+				Address:         c.Address,
+				IsActive:        c.IsActive,
+				MediaIDs:        c.MediaIDs,
+				CreatedAt:       c.CreatedAt,
+				UpdatedAt:       c.UpdatedAt,
+				AllowedGroupIDs: c.AllowedGroupIDs,
 			}
 		}),
 	})
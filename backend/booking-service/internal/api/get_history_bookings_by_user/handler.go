@@ -5,9 +5,9 @@ import (
 
 	"github.com/4udiwe/cowoking/booking-service/internal/api"
 	"github.com/4udiwe/cowoking/booking-service/internal/api/dto"
-	"github.com/4udiwe/cowoking/booking-service/internal/api/middleware"
 	"github.com/4udiwe/cowoking/booking-service/internal/entity"
 	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/lo"
 )
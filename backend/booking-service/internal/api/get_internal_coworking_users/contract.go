@@ -0,0 +1,11 @@
+package get_internal_coworking_users
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type BookingService interface {
+	ListActiveCoworkingUsers(ctx context.Context, coworkingID uuid.UUID) ([]uuid.UUID, error)
+}
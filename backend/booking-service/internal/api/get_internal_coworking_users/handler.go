@@ -0,0 +1,37 @@
+package get_internal_coworking_users
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/cowoking/booking-service/internal/api"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s BookingService
+}
+
+// Пользователи с активными бронированиями в коворкинге — получатели
+// объявлений notification-service. Доступна только сервисному токену со scope bookings.active_users.
+func New(bookingService BookingService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: bookingService})
+}
+
+type Request struct {
+	CoworkingID uuid.UUID `param:"coworkingId" validate:"required"`
+}
+
+type Response struct {
+	UserIDs []uuid.UUID `json:"userIds"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	userIDs, err := h.s.ListActiveCoworkingUsers(ctx.Request().Context(), in.CoworkingID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, Response{UserIDs: userIDs})
+}
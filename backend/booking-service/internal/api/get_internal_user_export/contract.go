@@ -0,0 +1,12 @@
+package get_internal_user_export
+
+import (
+	"context"
+
+	"github.com/4udiwe/cowoking/booking-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type BookingService interface {
+	ExportUserBookings(ctx context.Context, userID uuid.UUID) ([]entity.Booking, error)
+}
@@ -39,13 +39,14 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 	return ctx.JSON(http.StatusOK, Response{
 		Places: lo.Map(places, func(p entity.Place, _ int) dto.Place {
 			return dto.Place{
-				ID:          p.ID,
-				CoworkingID: p.Coworking.ID,
-				Label:       p.Label,
-				PlaceType:   p.PlaceType,
-				IsActive:    p.IsActive,
-				CreatedAt:   p.CreatedAt,
-				UpdatedAt:   p.UpdatedAt,
+				ID:              p.ID,
+				CoworkingID:     p.Coworking.ID,
+				Label:           p.Label,
+				PlaceType:       p.PlaceType,
+				IsActive:        p.IsActive,
+				CreatedAt:       p.CreatedAt,
+				UpdatedAt:       p.UpdatedAt,
+				AllowedGroupIDs: p.AllowedGroupIDs,
 			}
 		}),
 	})
@@ -7,5 +7,5 @@ import (
 )
 
 type BookingService interface {
-	CreateBooking(ctx context.Context, booking entity.Booking) error
+	CreateBooking(ctx context.Context, booking entity.Booking, audience entity.Audience) error
 }
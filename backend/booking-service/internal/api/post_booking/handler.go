@@ -6,10 +6,10 @@ import (
 
 	"github.com/4udiwe/cowoking/booking-service/internal/api"
 	"github.com/4udiwe/cowoking/booking-service/internal/api/dto"
-	"github.com/4udiwe/cowoking/booking-service/internal/api/middleware"
 	"github.com/4udiwe/cowoking/booking-service/internal/entity"
 	booking_service "github.com/4udiwe/cowoking/booking-service/internal/service/booking"
 	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/labstack/echo/v4"
 )
 
@@ -39,7 +39,7 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 		EndTime:   in.EndTime,
 	}
 
-	err = h.s.CreateBooking(ctx.Request().Context(), booking)
+	err = h.s.CreateBooking(ctx.Request().Context(), booking, api.AudienceFromClaims(claims))
 
 	if err != nil {
 		if errors.Is(err, booking_service.ErrBookingStartTimeAfterEndTime) ||
@@ -55,6 +55,9 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 			errors.Is(err, booking_service.ErrBookingTimeConflict) {
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		}
+		if errors.Is(err, booking_service.ErrPlaceRestricted) {
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 	return ctx.NoContent(http.StatusCreated)
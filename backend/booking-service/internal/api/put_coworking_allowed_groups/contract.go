@@ -0,0 +1,11 @@
+package put_coworking_allowed_groups
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type BookingService interface {
+	SetCoworkingAllowedGroups(ctx context.Context, coworkingID uuid.UUID, groupIDs []uuid.UUID) error
+}
@@ -0,0 +1,34 @@
+package put_coworking_allowed_groups
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/cowoking/booking-service/internal/api"
+	"github.com/4udiwe/cowoking/booking-service/internal/api/dto"
+	booking_service "github.com/4udiwe/cowoking/booking-service/internal/service/booking"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s BookingService
+}
+
+func New(bookingService BookingService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: bookingService})
+}
+
+type Request = dto.SetCoworkingAllowedGroupsRequest
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	err := h.s.SetCoworkingAllowedGroups(ctx.Request().Context(), in.CoworkingID, in.GroupIDs)
+
+	if err != nil {
+		if errors.Is(err, booking_service.ErrCoworkingNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
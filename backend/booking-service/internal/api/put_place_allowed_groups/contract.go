@@ -0,0 +1,11 @@
+package put_place_allowed_groups
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type BookingService interface {
+	SetPlaceAllowedGroups(ctx context.Context, placeID uuid.UUID, groupIDs []uuid.UUID) error
+}
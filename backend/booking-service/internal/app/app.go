@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rsa"
 	"os"
+	"time"
 
 	"github.com/4udiwe/avito-pvz/pkg/httpserver"
 	"github.com/4udiwe/avito-pvz/pkg/postgres"
@@ -11,7 +12,7 @@ import (
 	"github.com/4udiwe/big-bob-pizza/order-service/pkg/outbox"
 	"github.com/4udiwe/cowoking/booking-service/config"
 	"github.com/4udiwe/cowoking/booking-service/internal/api"
-	"github.com/4udiwe/cowoking/booking-service/internal/api/middleware"
+	consumer_auth "github.com/4udiwe/cowoking/booking-service/internal/consumer/auth"
 	consumer_scheduler "github.com/4udiwe/cowoking/booking-service/internal/consumer/scheduler"
 	"github.com/4udiwe/cowoking/booking-service/internal/database"
 	booking_repository "github.com/4udiwe/cowoking/booking-service/internal/repository/booking"
@@ -20,7 +21,10 @@ import (
 	place_repository "github.com/4udiwe/cowoking/booking-service/internal/repository/place"
 	booking_service "github.com/4udiwe/cowoking/booking-service/internal/service/booking"
 	"github.com/4udiwe/cowoking/booking-service/pkg/json_schema_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/authclient"
+	"github.com/4udiwe/coworking/auth-service/pkg/inbox"
 	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/log"
 )
@@ -59,6 +63,8 @@ type App struct {
 	getPlacesByCoworkingHandler          api.Handler
 	getAvailablePlacesByCoworkingHandler api.Handler
 	getAdminActiveBookings               api.Handler
+	getInternalUserExportHandler         api.Handler
+	getInternalCoworkingUsersHandler     api.Handler
 
 	patchCoworkingActiveHandler api.Handler
 	patchLayoutSetActiveHandler api.Handler
@@ -69,10 +75,16 @@ type App struct {
 	postLayoutHandler    api.Handler
 	postPlacesHandler    api.Handler
 
-	putCoworkingHandler api.Handler
+	putCoworkingHandler              api.Handler
+	putCoworkingAllowedGroupsHandler api.Handler
+	putPlaceAllowedGroupsHandler     api.Handler
 
 	// Consumer
 	schedulerConsumer *consumer_scheduler.Consumer
+	authConsumer      *consumer_auth.Consumer
+
+	// Inbox
+	inbox *inbox.Inbox
 
 	// Outbox
 	OutboxWorker *outbox.Worker
@@ -84,8 +96,11 @@ type App struct {
 	authMW *middleware.AuthMiddleware
 
 	// Auth
-	PublicKey    *rsa.PublicKey
-	jwtValidator *jwt_validator.Validator
+	PublicKey          *rsa.PublicKey
+	jwtValidator       *jwt_validator.Validator
+	denylist           *jwt_validator.Denylist
+	revocationListener *jwt_validator.RevocationListener
+	authClient         *authclient.Client
 }
 
 func New(configPath string) *App {
@@ -124,19 +139,27 @@ func (app *App) Start() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Consumers
-	schedulerKafkaConsumer := kafka.NewConsumer(app.cfg.Kafka.Brokers)
+	// Публикует outbox и перекладывает сообщения в retry-топики и DLQ
+	kafkaPublisher := kafka.NewKafkaPublisher(app.cfg.Kafka.Brokers)
 
+	// Consumers
 	app.schedulerConsumer = consumer_scheduler.New(
 		app.BookingService(),
-		schedulerKafkaConsumer,
+		app.Inbox(),
+		app.RetryingConsumer(kafkaPublisher),
 		app.cfg.Kafka.Topics.SchedulerEvents,
 		app.cfg.Kafka.Consumer.GroupID,
 	)
 
-	// Outbox publisher
-	kafkaPublisher := kafka.NewKafkaPublisher(app.cfg.Kafka.Brokers)
+	app.authConsumer = consumer_auth.New(
+		app.BookingService(),
+		app.Inbox(),
+		app.RetryingConsumer(kafkaPublisher),
+		app.cfg.Kafka.Topics.AuthEvents,
+		app.cfg.Kafka.Consumer.GroupID,
+	)
 
+	// Outbox publisher
 	app.OutboxWorker = outbox.NewWorker(
 		app.OutboxRepo(),
 		kafkaPublisher,
@@ -147,6 +170,14 @@ func (app *App) Start() {
 		app.cfg.Outbox.RequeInterval,
 	)
 
+	// Token revocation: auth.events -> local denylist
+	app.revocationListener = jwt_validator.NewRevocationListener(
+		app.Denylist(),
+		kafka.NewConsumer(app.cfg.Kafka.Brokers),
+		app.cfg.Kafka.Topics.AuthEvents,
+		app.cfg.Kafka.Consumer.GroupID,
+	)
+
 	// App server
 	log.Info("Starting app server...")
 	httpServer := httpserver.New(app.EchoHandler(), httpserver.Port(app.cfg.HTTP.Port))
@@ -155,7 +186,10 @@ func (app *App) Start() {
 
 	// Run consumers and publisher
 	app.schedulerConsumer.Run(ctx)
+	app.authConsumer.Run(ctx)
 	app.OutboxWorker.Run(ctx)
+	app.revocationListener.Run(ctx)
+	app.Inbox().Run(ctx, time.Hour, app.cfg.Kafka.Consumer.InboxRetention)
 
 	select {
 	case s := <-app.interrupt:
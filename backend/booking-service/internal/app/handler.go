@@ -11,6 +11,8 @@ import (
 	"github.com/4udiwe/cowoking/booking-service/internal/api/get_coworking_by_id"
 	"github.com/4udiwe/cowoking/booking-service/internal/api/get_coworkings"
 	"github.com/4udiwe/cowoking/booking-service/internal/api/get_history_bookings_by_user"
+	"github.com/4udiwe/cowoking/booking-service/internal/api/get_internal_coworking_users"
+	"github.com/4udiwe/cowoking/booking-service/internal/api/get_internal_user_export"
 	"github.com/4udiwe/cowoking/booking-service/internal/api/get_layout"
 	"github.com/4udiwe/cowoking/booking-service/internal/api/get_layout_by_version"
 	"github.com/4udiwe/cowoking/booking-service/internal/api/get_layout_versions"
@@ -23,6 +25,8 @@ import (
 	"github.com/4udiwe/cowoking/booking-service/internal/api/post_layout"
 	"github.com/4udiwe/cowoking/booking-service/internal/api/post_places"
 	"github.com/4udiwe/cowoking/booking-service/internal/api/put_coworking"
+	"github.com/4udiwe/cowoking/booking-service/internal/api/put_coworking_allowed_groups"
+	"github.com/4udiwe/cowoking/booking-service/internal/api/put_place_allowed_groups"
 )
 
 func (app *App) DeleteBookingHandler() api.Handler {
@@ -65,6 +69,22 @@ func (app *App) GetHistoryBookingsByUserHandler() api.Handler {
 	return app.getHistoryBookingsByUserHandler
 }
 
+func (app *App) GetInternalUserExportHandler() api.Handler {
+	if app.getInternalUserExportHandler != nil {
+		return app.getInternalUserExportHandler
+	}
+	app.getInternalUserExportHandler = get_internal_user_export.New(app.BookingService())
+	return app.getInternalUserExportHandler
+}
+
+func (app *App) GetInternalCoworkingUsersHandler() api.Handler {
+	if app.getInternalCoworkingUsersHandler != nil {
+		return app.getInternalCoworkingUsersHandler
+	}
+	app.getInternalCoworkingUsersHandler = get_internal_coworking_users.New(app.BookingService())
+	return app.getInternalCoworkingUsersHandler
+}
+
 func (app *App) GetCoworkingByIdHandler() api.Handler {
 	if app.getCoworkingByIdHandler != nil {
 		return app.getCoworkingByIdHandler
@@ -125,7 +145,7 @@ func (app *App) GetActiveAdminBookingsHandler() api.Handler {
 	if app.getAdminActiveBookings != nil {
 		return app.getAdminActiveBookings
 	}
-	app.getAdminActiveBookings = get_admin_bookings.New(app.BookingService())
+	app.getAdminActiveBookings = get_admin_bookings.New(app.BookingService(), app.AuthClient())
 	return app.getAdminActiveBookings
 }
 
@@ -192,3 +212,19 @@ func (app *App) PutCoworkingHandler() api.Handler {
 	app.putCoworkingHandler = put_coworking.New(app.BookingService())
 	return app.putCoworkingHandler
 }
+
+func (app *App) PutCoworkingAllowedGroupsHandler() api.Handler {
+	if app.putCoworkingAllowedGroupsHandler != nil {
+		return app.putCoworkingAllowedGroupsHandler
+	}
+	app.putCoworkingAllowedGroupsHandler = put_coworking_allowed_groups.New(app.BookingService())
+	return app.putCoworkingAllowedGroupsHandler
+}
+
+func (app *App) PutPlaceAllowedGroupsHandler() api.Handler {
+	if app.putPlaceAllowedGroupsHandler != nil {
+		return app.putPlaceAllowedGroupsHandler
+	}
+	app.putPlaceAllowedGroupsHandler = put_place_allowed_groups.New(app.BookingService())
+	return app.putPlaceAllowedGroupsHandler
+}
@@ -6,7 +6,8 @@ import (
 	"strings"
 
 	"github.com/4udiwe/avito-pvz/pkg/validator"
-	"github.com/4udiwe/cowoking/booking-service/internal/api/middleware"
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/labstack/echo/v4"
 	//echoSwagger "github.com/swaggo/echo-swagger"
 )
@@ -33,7 +34,7 @@ func (app *App) configureRouter(handler *echo.Echo) {
 	// Health check endpoint (no auth required)
 	handler.GET("/health", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
 
-	// Auth middleware with skipper for /health
+	// Auth middleware with skipper for /health and /internal
 	authMiddleware := app.AuthMiddleware()
 	handler.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -41,6 +42,10 @@ func (app *App) configureRouter(handler *echo.Echo) {
 			if strings.HasPrefix(c.Request().URL.Path, "/health") {
 				return next(c)
 			}
+			// Внутренние маршруты проверяют сервисный токен сами
+			if strings.HasPrefix(c.Request().URL.Path, "/internal/") {
+				return next(c)
+			}
 			return authMiddleware.Middleware(next)(c)
 		}
 	})
@@ -69,33 +74,56 @@ func (app *App) configureRouter(handler *echo.Echo) {
 	}
 
 	// Admin endpoints
-	adminGroup := handler.Group("/admin", middleware.AdminOnly)
+	// Доступ по правам из access token. Права могут быть выданы как глобально,
+	// так и в пределах одного коворкинга (например, менеджер стойки регистрации).
+	adminGroup := handler.Group("/admin")
 	{
+		coworkingParam := middleware.FromParam("coworkingId")
+
 		adminCoworkingGroup := adminGroup.Group("/coworkings")
 		{
+			manageCoworking := middleware.RequireCoworkingPermission(jwt_validator.PermCoworkingsManage, coworkingParam)
+			manageLayouts := middleware.RequireCoworkingPermission(jwt_validator.PermLayoutsManage, coworkingParam)
 
-			adminCoworkingGroup.POST("", app.PostCoworkingHandler().Handle)
-			adminCoworkingGroup.PUT("/:coworkingId", app.PutCoworkingHandler().Handle)
-			adminCoworkingGroup.PATCH("/:coworkingId/set_active", app.PatchCoworkingActiveHandler().Handle)
+			adminCoworkingGroup.POST("", app.PostCoworkingHandler().Handle, middleware.RequirePermission(jwt_validator.PermCoworkingsManage))
+			adminCoworkingGroup.PUT("/:coworkingId", app.PutCoworkingHandler().Handle, manageCoworking)
+			adminCoworkingGroup.PATCH("/:coworkingId/set_active", app.PatchCoworkingActiveHandler().Handle, manageCoworking)
+			adminCoworkingGroup.PUT("/:coworkingId/allowed_groups", app.PutCoworkingAllowedGroupsHandler().Handle, manageCoworking)
 
-			adminCoworkingGroup.GET("/:coworkingId/layouts", app.GetLayoutVersionsHandler().Handle)
-			adminCoworkingGroup.POST("/:coworkingId/layouts", app.PostLayoutHandler().Handle)
-			adminCoworkingGroup.GET("/:coworkingId/layouts/:version", app.GetLayoutByVersionHandler().Handle)
-			adminCoworkingGroup.PATCH("/:coworkingId/layouts/:version", app.PatchLayoutSetActiveHandler().Handle)
-			adminCoworkingGroup.DELETE("/:coworkingId/layouts/:version", app.DeleteLayoutHandler().Handle)
+			adminCoworkingGroup.GET("/:coworkingId/layouts", app.GetLayoutVersionsHandler().Handle, manageLayouts)
+			adminCoworkingGroup.POST("/:coworkingId/layouts", app.PostLayoutHandler().Handle, manageLayouts)
+			adminCoworkingGroup.GET("/:coworkingId/layouts/:version", app.GetLayoutByVersionHandler().Handle, manageLayouts)
+			adminCoworkingGroup.PATCH("/:coworkingId/layouts/:version", app.PatchLayoutSetActiveHandler().Handle, manageLayouts)
+			adminCoworkingGroup.DELETE("/:coworkingId/layouts/:version", app.DeleteLayoutHandler().Handle, manageLayouts)
 
 		}
 
 		adminPlacesGroup := adminGroup.Group("/places")
 		{
-			adminPlacesGroup.POST("", app.PostPlacesHandler().Handle)
-			adminPlacesGroup.PATCH("/:placeId/set_active", app.PatchPlaceActiveHandler().Handle)
+			// coworkingId приходит в теле запроса — только глобальное право
+			adminPlacesGroup.POST("", app.PostPlacesHandler().Handle, middleware.RequirePermission(jwt_validator.PermPlacesManage))
+			adminPlacesGroup.PATCH("/:placeId/set_active", app.PatchPlaceActiveHandler().Handle,
+				middleware.RequireCoworkingPermission(jwt_validator.PermPlacesManage, app.PlaceScope()))
+			adminPlacesGroup.PUT("/:placeId/allowed_groups", app.PutPlaceAllowedGroupsHandler().Handle,
+				middleware.RequireCoworkingPermission(jwt_validator.PermPlacesManage, app.PlaceScope()))
 		}
 
 		adminBookingsGroup := adminGroup.Group("/bookings")
 		{
-			adminBookingsGroup.GET("", app.GetActiveAdminBookingsHandler().Handle)
-			adminBookingsGroup.DELETE("/:bookingId", app.DeleteBookingHandler().Handle)
+			adminBookingsGroup.GET("", app.GetActiveAdminBookingsHandler().Handle,
+				middleware.RequireCoworkingPermission(jwt_validator.PermBookingsManage, middleware.FromQuery("coworkingId")))
+			adminBookingsGroup.DELETE("/:bookingId", app.DeleteBookingHandler().Handle,
+				middleware.RequireCoworkingPermission(jwt_validator.PermBookingsManage, app.BookingScope()))
 		}
 	}
+
+	// Internal endpoints
+	// Вызываются другими сервисами с сервисным токеном, через gateway не проксируются.
+	internalGroup := handler.Group("/internal")
+	{
+		internalGroup.GET("/users/:userId/export", app.GetInternalUserExportHandler().Handle,
+			authMiddleware.ServiceOnly(jwt_validator.ScopeUsersExport))
+		internalGroup.GET("/coworkings/:coworkingId/active-users", app.GetInternalCoworkingUsersHandler().Handle,
+			authMiddleware.ServiceOnly(jwt_validator.ScopeBookingsActiveUsers))
+	}
 }
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	booking_service "github.com/4udiwe/cowoking/booking-service/internal/service/booking"
+	"github.com/4udiwe/cowoking/booking-service/internal/service/booking/mocks"
+	"github.com/4udiwe/cowoking/booking-service/pkg/json_schema_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/authclient"
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+// Внутренние маршруты должны проходить мимо пользовательского auth middleware
+// и проверять только сервисный токен.
+func TestRouter_InternalCoworkingUsers(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	sign := func(claims jwt_validator.AccessClaims) string {
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Minute))
+		token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+		if err != nil {
+			t.Fatalf("sign token: %v", err)
+		}
+		return token
+	}
+
+	coworkingID := uuid.New()
+
+	tests := []struct {
+		name           string
+		token          string
+		mockBehavior   func(r *mocks.MockBookingRepository)
+		expectedStatus int
+	}{
+		{
+			name: "service token with scope",
+			token: sign(jwt_validator.AccessClaims{
+				ClientID: "notification-service",
+				Scope:    jwt_validator.ScopeBookingsActiveUsers,
+			}),
+			mockBehavior: func(r *mocks.MockBookingRepository) {
+				r.EXPECT().ListActiveUserIDsByCoworking(gomock.Any(), coworkingID).
+					Return([]uuid.UUID{uuid.New()}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "service token without scope",
+			token: sign(jwt_validator.AccessClaims{
+				ClientID: "notification-service",
+				Scope:    jwt_validator.ScopeUsersExport,
+			}),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "service token with user permission as scope",
+			token: sign(jwt_validator.AccessClaims{
+				ClientID: "notification-service",
+				Scope:    jwt_validator.PermUsersRead,
+			}),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "user token",
+			token: sign(jwt_validator.AccessClaims{
+				UserID:      uuid.New(),
+				SessionID:   uuid.New(),
+				Permissions: []string{jwt_validator.PermUsersRead},
+			}),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "no token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			bookingRepo := mocks.NewMockBookingRepository(ctrl)
+			if tt.mockBehavior != nil {
+				tt.mockBehavior(bookingRepo)
+			}
+
+			app := &App{
+				jwtValidator: jwt_validator.NewValidator(&key.PublicKey),
+				bookingService: booking_service.New(
+					bookingRepo, nil, nil, nil, json_schema_validator.Validator{}, nil,
+				),
+				authClient: authclient.New("http://auth-service", "booking-service", "secret", nil, time.Second),
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/internal/coworkings/"+coworkingID.String()+"/active-users", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+
+			app.EchoHandler().ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	booking_service "github.com/4udiwe/cowoking/booking-service/internal/service/booking"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// BookingScope определяет коворкинг по :bookingId — для прав, выданных в пределах коворкинга.
+func (app *App) BookingScope() middleware.ScopeFunc {
+	return func(c echo.Context) (uuid.UUID, error) {
+		bookingID, err := uuid.Parse(c.Param("bookingId"))
+		if err != nil {
+			return uuid.Nil, err
+		}
+		booking, err := app.BookingService().GetBookingByID(c.Request().Context(), bookingID)
+		if errors.Is(err, booking_service.ErrBookingNotFound) {
+			return uuid.Nil, echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return booking.Place.Coworking.ID, nil
+	}
+}
+
+// PlaceScope определяет коворкинг по :placeId.
+func (app *App) PlaceScope() middleware.ScopeFunc {
+	return func(c echo.Context) (uuid.UUID, error) {
+		placeID, err := uuid.Parse(c.Param("placeId"))
+		if err != nil {
+			return uuid.Nil, err
+		}
+		place, err := app.BookingService().GetPlaceByID(c.Request().Context(), placeID)
+		if errors.Is(err, booking_service.ErrPlaceNotFound) {
+			return uuid.Nil, echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return place.Coworking.ID, nil
+	}
+}
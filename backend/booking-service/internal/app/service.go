@@ -12,7 +12,7 @@ func (app *App) BookingService() *booking_service.BookingService {
 		app.CoworkingRepo(),
 		app.OutboxRepo(),
 		*app.LayoutValidator(),
-		app.Postgres(),
+		app.TxManager(),
 	)
 	return app.bookingService
 }
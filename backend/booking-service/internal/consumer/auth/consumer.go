@@ -0,0 +1,97 @@
+package consumer_auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/4udiwe/cowoking/booking-service/internal/consumer"
+	booking_service "github.com/4udiwe/cowoking/booking-service/internal/service/booking"
+	"github.com/4udiwe/coworking/auth-service/pkg/inbox"
+	"github.com/4udiwe/coworking/auth-service/pkg/retry"
+)
+
+// Причины отмены бронирований деактивированного и удалённого пользователя
+const (
+	deactivatedReason = "Аккаунт пользователя деактивирован"
+	deletedReason     = "Аккаунт пользователя удалён"
+)
+
+// Обработчик событий жизненного цикла пользователей из топика auth.
+// Отзыв токенов по тем же событиям делает jwt_validator.RevocationListener.
+type Consumer struct {
+	service  *booking_service.BookingService
+	inbox    *inbox.Inbox
+	consumer *retry.Consumer
+	topic    string
+	groupID  string
+}
+
+func New(
+	service *booking_service.BookingService,
+	inbox *inbox.Inbox,
+	consumer *retry.Consumer,
+	topic string,
+	groupID string,
+) *Consumer {
+	return &Consumer{
+		service:  service,
+		inbox:    inbox,
+		consumer: consumer,
+		topic:    topic,
+		groupID:  groupID,
+	}
+}
+
+func (c *Consumer) Run(ctx context.Context) error {
+	logrus.Infof("AuthConsumer: subscribing to topic=%s group=%s", c.topic, c.groupID)
+
+	return c.consumer.Subscribe(ctx, c.topic, c.groupID, func(ctx context.Context, key, value []byte) error {
+		event, err := consumer.ParseOrderEvent(value)
+		if err != nil {
+			logrus.Errorf("AuthConsumer: failed to parse event: %v", err)
+			return retry.Permanent(err)
+		}
+
+		switch event.Type {
+
+		case consumer.UserUpdated:
+			name := strings.TrimSpace(event.Payload.FirstName + " " + event.Payload.LastName)
+			if name == "" {
+				return nil
+			}
+			return c.inbox.Process(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+				err := c.service.RenameUser(ctx, event.Payload.UserID, name)
+				if err != nil {
+					logrus.Errorf("AuthConsumer: RenameUser failed: %v", err)
+				}
+				return err
+			})
+
+		case consumer.UserDeactivated:
+			return c.inbox.Process(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+				err := c.service.CancelUserBookings(ctx, event.Payload.UserID, deactivatedReason)
+				if err != nil {
+					logrus.Errorf("AuthConsumer: CancelUserBookings failed: %v", err)
+				}
+				return err
+			})
+
+		case consumer.UserDeleted:
+			return c.inbox.Process(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+				err := c.service.DeleteUser(ctx, event.Payload.UserID, event.Payload.AnonymousID, deletedReason)
+				if err != nil {
+					logrus.Errorf("AuthConsumer: DeleteUser failed: %v", err)
+				}
+				return err
+			})
+
+		default:
+			// user.registered и user.roles_changed booking-service не нужны:
+			// у нового пользователя нет бронирований, а роли берутся из access token.
+			// Остальные события топика (сессии, права) обрабатывает RevocationListener
+			return nil
+		}
+	})
+}
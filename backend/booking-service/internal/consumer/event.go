@@ -11,10 +11,19 @@ type EventType string
 // Все типы событий, которые могут потребляться сервисом
 const (
 	BookingExpire EventType = "booking.expire"
+
+	// auth.events
+	UserRegistered   EventType = "auth.user.registered"
+	UserUpdated      EventType = "auth.user.updated"
+	UserDeactivated  EventType = "auth.user.deactivated"
+	UserRolesChanged EventType = "auth.user.roles_changed"
+	UserDeleted      EventType = "auth.user.deleted"
 )
 
 // Тип для обработки входящего события
 type IncomingEvent struct {
+	// Идентификатор из kafka.Envelope, ключ дедупликации
+	EventID    uuid.UUID
 	Type       EventType
 	OccurredAt time.Time
 	Payload    Payload
@@ -23,5 +32,11 @@ type IncomingEvent struct {
 // Тип данных входящего события. Перечисленны все поля, которые могут быть в событиию.
 // (omitempty опускает поле, если его нет)
 type Payload struct {
-	BookingID uuid.UUID `json:"bookingId"`
+	BookingID uuid.UUID `json:"bookingId,omitempty"`
+
+	UserID    uuid.UUID `json:"userId,omitempty"`
+	FirstName string    `json:"firstName,omitempty"`
+	LastName  string    `json:"lastName,omitempty"`
+
+	AnonymousID uuid.UUID `json:"anonymousId,omitempty"`
 }
@@ -2,30 +2,35 @@ package consumer_scheduler
 
 import (
 	"context"
+	"errors"
 
 	"github.com/sirupsen/logrus"
 
-	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
 	"github.com/4udiwe/cowoking/booking-service/internal/consumer"
 	booking_service "github.com/4udiwe/cowoking/booking-service/internal/service/booking"
+	"github.com/4udiwe/coworking/auth-service/pkg/inbox"
+	"github.com/4udiwe/coworking/auth-service/pkg/retry"
 )
 
 // Обработчик событий для топика scheduler
 type Consumer struct {
 	service  *booking_service.BookingService
-	consumer *kafka.KafkaConsumer
+	inbox    *inbox.Inbox
+	consumer *retry.Consumer
 	topic    string
 	groupID  string
 }
 
 func New(
 	service *booking_service.BookingService,
-	consumer *kafka.KafkaConsumer,
+	inbox *inbox.Inbox,
+	consumer *retry.Consumer,
 	topic string,
 	groupID string,
 ) *Consumer {
 	return &Consumer{
 		service:  service,
+		inbox:    inbox,
 		consumer: consumer,
 		topic:    topic,
 		groupID:  groupID,
@@ -39,22 +44,34 @@ func (c *Consumer) Run(ctx context.Context) error {
 		event, err := consumer.ParseOrderEvent(value)
 		if err != nil {
 			logrus.Errorf("SchedulerConsumer: failed to parse event: %v", err)
-			return nil
+			return retry.Permanent(err)
 		}
 
 		switch event.Type {
 
 		case consumer.BookingExpire:
-			err = c.service.CompleteBooking(ctx, event.Payload.BookingID)
-			if err != nil {
-				logrus.Errorf("SchedulerConsumer: CompleteBooking failed: %v", err)
-			}
+			return c.inbox.Process(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+				err := c.service.CompleteBooking(ctx, event.Payload.BookingID)
+
+				// Бронирование отменили раньше, чем сработал таймер
+				if errors.Is(err, booking_service.ErrBookingAlreadyCancelled) ||
+					errors.Is(err, booking_service.ErrBookingAlreadyCompleted) {
+					logrus.WithField("booking_id", event.Payload.BookingID).Infof("SchedulerConsumer: %v, skipped", err)
+					return nil
+				}
+
+				if err != nil {
+					logrus.Errorf("SchedulerConsumer: CompleteBooking failed: %v", err)
+				}
+				if errors.Is(err, booking_service.ErrBookingNotFound) {
+					return retry.Permanent(err)
+				}
+				return err
+			})
 
 		default:
 			logrus.Errorf("SchedulerConsumer: unknown event type %s", event.Type)
 			return nil
 		}
-
-		return err
 	})
 }
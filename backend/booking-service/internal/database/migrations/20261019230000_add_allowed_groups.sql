@@ -0,0 +1,15 @@
+-- +goose Up
+-- Ограничение бронирования группами пользователей из auth-service
+-- (claim groups). Пустой массив — ограничения нет.
+ALTER TABLE coworking
+ADD COLUMN allowed_group_ids uuid[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE place
+ADD COLUMN allowed_group_ids uuid[] NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE place
+DROP COLUMN allowed_group_ids;
+
+ALTER TABLE coworking
+DROP COLUMN allowed_group_ids;
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Audience — кто смотрит или бронирует: группы пользователя из access token.
+// Unrestricted — ограничения по группам не применяются (администраторы коворкингов).
+type Audience struct {
+	GroupIDs     []uuid.UUID
+	Unrestricted bool
+}
+
+// CanAccess — пустой список allowed означает отсутствие ограничения,
+// иначе пользователь должен состоять хотя бы в одной из групп.
+func (a Audience) CanAccess(allowed []uuid.UUID) bool {
+	if a.Unrestricted || len(allowed) == 0 {
+		return true
+	}
+	for _, id := range a.GroupIDs {
+		if slices.Contains(allowed, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanBook проверяет ограничения и коворкинга, и самого места.
+func (a Audience) CanBook(place Place) bool {
+	return a.CanAccess(place.Coworking.AllowedGroupIDs) && a.CanAccess(place.AllowedGroupIDs)
+}
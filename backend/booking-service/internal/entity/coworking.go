@@ -14,6 +14,8 @@ type Coworking struct {
 	IsActive bool
 
 	MediaIDs []string
+	// Группы, которым разрешено бронирование; пусто — всем
+	AllowedGroupIDs []uuid.UUID
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -12,6 +12,8 @@ type Place struct {
 	Label     string
 	PlaceType string
 	IsActive  bool
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	// Группы, которым разрешено бронирование места (в дополнение к ограничению коворкинга)
+	AllowedGroupIDs []uuid.UUID
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
@@ -375,6 +375,150 @@ func (r *BookingRepository) Cancel(
 	return nil
 }
 
+func (r *BookingRepository) ListActiveIDsByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]uuid.UUID, error) {
+
+	query, args, _ := r.Builder.
+		Select("id").
+		From("booking").
+		Where("user_id = ?", userID).
+		Where("status_id = ?", StatusActive).
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID.String()).Error("failed to list active booking ids")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID.String()).Error("failed to scan active booking ids")
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// ListActiveUserIDsByCoworking возвращает пользователей с активными
+// (ещё не закончившимися) бронированиями мест коворкинга
+func (r *BookingRepository) ListActiveUserIDsByCoworking(
+	ctx context.Context,
+	coworkingID uuid.UUID,
+) ([]uuid.UUID, error) {
+
+	query, args, _ := r.Builder.
+		Select("DISTINCT b.user_id").
+		From("booking b").
+		Join("place p ON p.id = b.place_id").
+		Where("p.coworking_id = ?", coworkingID).
+		Where("b.status_id = ?", StatusActive).
+		Where("b.end_time > NOW()").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("coworking_id", coworkingID.String()).Error("failed to list active booking users")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		logrus.WithError(err).WithField("coworking_id", coworkingID.String()).Error("failed to scan active booking users")
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *BookingRepository) UpdateUserName(
+	ctx context.Context,
+	userID uuid.UUID,
+	userName string,
+) error {
+
+	query, args, _ := r.Builder.
+		Update("booking").
+		Set("user_name", userName).
+		Where("user_id = ?", userID).
+		Where("user_name <> ?", userName).
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID.String()).Error("failed to update user name")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":  userID.String(),
+		"bookings": cmd.RowsAffected(),
+	}).Info("user name updated")
+
+	return nil
+}
+
+// Все бронирования пользователя без пагинации — для выгрузки его данных.
+func (r *BookingRepository) ListAllByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]entity.Booking, error) {
+
+	sql, args, _ := r.baseBookingQuery().
+		Where("b.user_id = ?", userID).
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID.String()).Error("failed to list all user bookings")
+		return nil, err
+	}
+	defer rows.Close()
+
+	raws, err := pgx.CollectRows(rows, pgx.RowToStructByName[rawBookingPlaceStatus])
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID.String()).Error("failed to collect booking rows")
+		return nil, err
+	}
+
+	return lo.Map(raws, func(raw rawBookingPlaceStatus, _ int) entity.Booking {
+		return raw.toEntity()
+	}), nil
+}
+
+// Заменяет user_id удалённого пользователя на анонимный идентификатор
+// и стирает его имя. Статистика по местам и коворкингам сохраняется.
+func (r *BookingRepository) AnonymizeUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	anonymousID uuid.UUID,
+) error {
+
+	query, args, _ := r.Builder.
+		Update("booking").
+		Set("user_id", anonymousID).
+		Set("user_name", "").
+		Where("user_id = ?", userID).
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID.String()).Error("failed to anonymize user bookings")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":  userID.String(),
+		"bookings": cmd.RowsAffected(),
+	}).Info("user bookings anonymized")
+
+	return nil
+}
+
 func (r *BookingRepository) MarkCompleted(
 	ctx context.Context,
 	id uuid.UUID,
@@ -8,24 +8,27 @@ import (
 )
 
 type rawCoworking struct {
-	ID        uuid.UUID `db:"id"`
-	Name      string    `db:"name"`
-	Address   string    `db:"address"`
-	IsActive  bool      `db:"is_active"`
-	MediaIDs  []string  `db:"media_ids"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID       uuid.UUID `db:"id"`
+	Name     string    `db:"name"`
+	Address  string    `db:"address"`
+	IsActive bool      `db:"is_active"`
+	MediaIDs []string  `db:"media_ids"`
+	// Группы, которым разрешено бронирование
+	AllowedGroupIDs []uuid.UUID `db:"allowed_group_ids"`
+	CreatedAt       time.Time   `db:"created_at"`
+	UpdatedAt       time.Time   `db:"updated_at"`
 }
 
 func (r *rawCoworking) toEntity() entity.Coworking {
 	return entity.Coworking{
-		ID:        r.ID,
-		Name:      r.Name,
-		Address:   r.Address,
-		IsActive:  r.IsActive,
-		MediaIDs:  r.MediaIDs,
-		CreatedAt: r.CreatedAt,
-		UpdatedAt: r.UpdatedAt}
+		ID:              r.ID,
+		Name:            r.Name,
+		Address:         r.Address,
+		IsActive:        r.IsActive,
+		MediaIDs:        r.MediaIDs,
+		AllowedGroupIDs: r.AllowedGroupIDs,
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt}
 }
 
 type rawCoworkingLayout struct {
@@ -34,12 +34,14 @@ func (r *CoworkingRepository) Create(ctx context.Context, coworking entity.Cowor
 			"address",
 			"is_active",
 			"media_ids",
+			"allowed_group_ids",
 		).
 		Values(
 			coworking.Name,
 			coworking.Address,
 			coworking.IsActive,
 			pq.Array(coworking.MediaIDs),
+			lo.Ternary(coworking.AllowedGroupIDs != nil, coworking.AllowedGroupIDs, []uuid.UUID{}),
 		).
 		Suffix("RETURNING id").
 		ToSql()
@@ -63,6 +65,7 @@ func (r *CoworkingRepository) GetByID(ctx context.Context, id uuid.UUID) (entity
 			"address",
 			"is_active",
 			"media_ids",
+			"allowed_group_ids",
 			"created_at",
 			"updated_at",
 		).
@@ -122,6 +125,7 @@ func (r *CoworkingRepository) List(ctx context.Context) ([]entity.Coworking, err
 			"address",
 			"is_active",
 			"media_ids",
+			"allowed_group_ids",
 			"created_at",
 			"updated_at",
 		).
@@ -148,6 +152,28 @@ func (r *CoworkingRepository) List(ctx context.Context) ([]entity.Coworking, err
 	}), nil
 }
 
+// SetAllowedGroups заменяет список групп, которым разрешено бронирование.
+func (r *CoworkingRepository) SetAllowedGroups(ctx context.Context, id uuid.UUID, groupIDs []uuid.UUID) error {
+	query, args, _ := r.Builder.
+		Update("coworking").
+		Set("allowed_group_ids", groupIDs).
+		Set("updated_at", time.Now()).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	cmdTag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		mapped := MapPgError(err)
+		logrus.WithField("coworking_id", id.String()).Error("failed to set coworking allowed groups: ", mapped)
+		return mapped
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return ErrCoworkingNotFound
+	}
+
+	return nil
+}
+
 func (r *CoworkingRepository) CreateLayoutVersion(ctx context.Context, layout entity.CoworkingLayout) error {
 	query, args, _ := r.Builder.
 		Insert("coworking_layout").
@@ -2,10 +2,12 @@ package outbox_repository
 
 import (
 	"encoding/json"
+	"maps"
 	"time"
 
 	"github.com/4udiwe/big-bob-pizza/order-service/pkg/outbox"
 	"github.com/4udiwe/cowoking/booking-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/eventid"
 	"github.com/google/uuid"
 )
 
@@ -35,7 +37,13 @@ func (r RowOutbox) ToEntity() entity.OutboxEvent {
 }
 
 func (r RowOutbox) ToEvent() outbox.Event {
-	payloadBytes, err := json.Marshal(r.Payload)
+	// ID строки — стабильный eventId: по нему потребители отбрасывают
+	// повторные публикации той же записи
+	payload := make(map[string]any, len(r.Payload)+1)
+	maps.Copy(payload, r.Payload)
+	payload[eventid.Field] = r.ID
+
+	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
 		payloadBytes = []byte("{}")
 	}
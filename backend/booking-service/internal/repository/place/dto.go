@@ -6,27 +6,31 @@ import (
 )
 
 type rawPlaceCoworking struct {
-	ID                uuid.UUID `db:"id"`
-	Label             string    `db:"label"`
-	PlaceType         string    `db:"place_type"`
-	IsActive          bool      `db:"is_active"`
-	CoworkingID       uuid.UUID `db:"coworking_id"`
-	CoworkingName     string    `db:"coworking_name"`
-	CoworkingAddress  string    `db:"coworking_address"`
-	CoworkingIsActive bool      `db:"coworking_is_active"`
+	ID                       uuid.UUID   `db:"id"`
+	Label                    string      `db:"label"`
+	PlaceType                string      `db:"place_type"`
+	IsActive                 bool        `db:"is_active"`
+	AllowedGroupIDs          []uuid.UUID `db:"allowed_group_ids"`
+	CoworkingID              uuid.UUID   `db:"coworking_id"`
+	CoworkingName            string      `db:"coworking_name"`
+	CoworkingAddress         string      `db:"coworking_address"`
+	CoworkingIsActive        bool        `db:"coworking_is_active"`
+	CoworkingAllowedGroupIDs []uuid.UUID `db:"coworking_allowed_group_ids"`
 }
 
 func (r *rawPlaceCoworking) toEntity() entity.Place {
 	return entity.Place{
-		ID:        r.ID,
-		Label:     r.Label,
-		PlaceType: r.PlaceType,
-		IsActive:  r.IsActive,
+		ID:              r.ID,
+		Label:           r.Label,
+		PlaceType:       r.PlaceType,
+		IsActive:        r.IsActive,
+		AllowedGroupIDs: r.AllowedGroupIDs,
 		Coworking: entity.Coworking{
-			ID:       r.CoworkingID,
-			Name:     r.CoworkingName,
-			Address:  r.CoworkingAddress,
-			IsActive: r.CoworkingIsActive,
+			ID:              r.CoworkingID,
+			Name:            r.CoworkingName,
+			Address:         r.CoworkingAddress,
+			IsActive:        r.CoworkingIsActive,
+			AllowedGroupIDs: r.CoworkingAllowedGroupIDs,
 		},
 	}
 }
@@ -73,10 +73,12 @@ func (r *PlaceRepository) GetByID(
 			"p.label",
 			"p.place_type",
 			"p.is_active",
+			"p.allowed_group_ids",
 			"c.id AS coworking_id",
 			"c.name AS coworking_name",
 			"c.address AS coworking_address",
 			"c.is_active AS coworking_is_active",
+			"c.allowed_group_ids AS coworking_allowed_group_ids",
 		).
 		From("place p").
 		Join("coworking c ON c.id = p.coworking_id").
@@ -115,10 +117,12 @@ func (r *PlaceRepository) GetByCoworking(
 			"p.label",
 			"p.place_type",
 			"p.is_active",
+			"p.allowed_group_ids",
 			"c.id AS coworking_id",
 			"c.name AS coworking_name",
 			"c.address AS coworking_address",
 			"c.is_active AS coworking_is_active",
+			"c.allowed_group_ids AS coworking_allowed_group_ids",
 		).
 		From("place p").
 		Join("coworking c ON c.id = p.coworking_id").
@@ -158,10 +162,12 @@ func (r *PlaceRepository) GetAvailableByCoworking(
 			"p.label",
 			"p.place_type",
 			"p.is_active",
+			"p.allowed_group_ids",
 			"c.id AS coworking_id",
 			"c.name AS coworking_name",
 			"c.address AS coworking_address",
 			"c.is_active AS coworking_is_active",
+			"c.allowed_group_ids AS coworking_allowed_group_ids",
 		).
 		From("place p").
 		Join("coworking c ON c.id = p.coworking_id").
@@ -215,6 +221,32 @@ func (r *PlaceRepository) SetActive(
 	return nil
 }
 
+// SetAllowedGroups заменяет список групп, которым разрешено бронирование места.
+func (r *PlaceRepository) SetAllowedGroups(
+	ctx context.Context,
+	id uuid.UUID,
+	groupIDs []uuid.UUID,
+) error {
+
+	query, args, _ := r.Builder.
+		Update("place").
+		Set("allowed_group_ids", groupIDs).
+		Set("updated_at", time.Now()).
+		Where("id = ?", id).
+		ToSql()
+
+	cmdTag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		mapped := MapPgError(err)
+		logrus.WithField("place_id", id.String()).Error("failed to set place allowed groups: ", mapped)
+		return mapped
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return ErrPlaceNotFound
+	}
+	return nil
+}
+
 func (r *PlaceRepository) CheckHasActiveBookings(
 	ctx context.Context,
 	placeID uuid.UUID,
@@ -15,6 +15,11 @@ type BookingRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (entity.Booking, error)
 	ListActiveByUser(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]entity.Booking, int, error)
 	ListHistoryByUser(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]entity.Booking, int, error)
+	ListActiveIDsByUser(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	ListActiveUserIDsByCoworking(ctx context.Context, coworkingID uuid.UUID) ([]uuid.UUID, error)
+	UpdateUserName(ctx context.Context, userID uuid.UUID, userName string) error
+	ListAllByUser(ctx context.Context, userID uuid.UUID) ([]entity.Booking, error)
+	AnonymizeUser(ctx context.Context, userID uuid.UUID, anonymousID uuid.UUID) error
 	Cancel(ctx context.Context, id uuid.UUID, reason *string) error
 	MarkCompleted(ctx context.Context, id uuid.UUID) error
 	GetAdminActiveBookings(ctx context.Context, coworkingID uuid.UUID, page int, pageSize int, dateFrom *time.Time, dateTo *time.Time, placeType *string, sortBy *string) ([]entity.Booking, int, error)
@@ -26,6 +31,7 @@ type PlaceRepository interface {
 	GetByCoworking(ctx context.Context, coworkingID uuid.UUID) ([]entity.Place, error)
 	GetAvailableByCoworking(ctx context.Context, coworkingID uuid.UUID, start time.Time, end time.Time) ([]entity.Place, error)
 	SetActive(ctx context.Context, id uuid.UUID, active bool) error
+	SetAllowedGroups(ctx context.Context, id uuid.UUID, groupIDs []uuid.UUID) error
 	CheckHasActiveBookings(ctx context.Context, placeID uuid.UUID) (bool, error)
 }
 
@@ -35,6 +41,7 @@ type CoworkingRepository interface {
 	Update(ctx context.Context, coworking entity.Coworking) error
 	List(ctx context.Context) ([]entity.Coworking, error)
 	SetActive(ctx context.Context, id uuid.UUID, active bool) error
+	SetAllowedGroups(ctx context.Context, id uuid.UUID, groupIDs []uuid.UUID) error
 	CheckHasActiveBookings(ctx context.Context, coworkingID uuid.UUID) (bool, error)
 
 	CreateLayoutVersion(ctx context.Context, layout entity.CoworkingLayout) error
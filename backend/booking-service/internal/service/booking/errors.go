@@ -35,6 +35,7 @@ var (
 	ErrBookingDurationMoreThanThreeHours = errors.New("booking duration cannot exceed three hours")
 	ErrPlaceInactive                     = errors.New("cannot book an inactive place")
 	ErrCoworkingInactive                 = errors.New("cannot book a place in an inactive coworking")
+	ErrPlaceRestricted                   = errors.New("place is restricted to other user groups")
 	ErrBookingTimeConflict               = errors.New("booking time conflicts with an existing booking")
 	ErrBookingNotFound                   = errors.New("booking not found")
 	ErrBookingAlreadyCancelled           = errors.New("booking is already cancelled")
@@ -44,4 +45,6 @@ var (
 	ErrCannotCancelBooking   = errors.New("cannot cancel booking")
 	ErrCannotCompleteBooking = errors.New("cannot complete booking")
 	ErrCannotFetchBooking    = errors.New("cannot fetch booking")
+	ErrCannotUpdateUserName  = errors.New("cannot update user name")
+	ErrCannotAnonymizeUser   = errors.New("cannot anonymize user")
 )
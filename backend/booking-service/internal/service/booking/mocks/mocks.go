@@ -43,6 +43,20 @@ func (m *MockBookingRepository) EXPECT() *MockBookingRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AnonymizeUser mocks base method.
+func (m *MockBookingRepository) AnonymizeUser(ctx context.Context, userID, anonymousID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUser", ctx, userID, anonymousID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnonymizeUser indicates an expected call of AnonymizeUser.
+func (mr *MockBookingRepositoryMockRecorder) AnonymizeUser(ctx, userID, anonymousID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUser", reflect.TypeOf((*MockBookingRepository)(nil).AnonymizeUser), ctx, userID, anonymousID)
+}
+
 // Cancel mocks base method.
 func (m *MockBookingRepository) Cancel(ctx context.Context, id uuid.UUID, reason *string) error {
 	m.ctrl.T.Helper()
@@ -119,6 +133,51 @@ func (mr *MockBookingRepositoryMockRecorder) ListActiveByUser(ctx, userID, page,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveByUser", reflect.TypeOf((*MockBookingRepository)(nil).ListActiveByUser), ctx, userID, page, pageSize)
 }
 
+// ListActiveIDsByUser mocks base method.
+func (m *MockBookingRepository) ListActiveIDsByUser(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveIDsByUser", ctx, userID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveIDsByUser indicates an expected call of ListActiveIDsByUser.
+func (mr *MockBookingRepositoryMockRecorder) ListActiveIDsByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveIDsByUser", reflect.TypeOf((*MockBookingRepository)(nil).ListActiveIDsByUser), ctx, userID)
+}
+
+// ListActiveUserIDsByCoworking mocks base method.
+func (m *MockBookingRepository) ListActiveUserIDsByCoworking(ctx context.Context, coworkingID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveUserIDsByCoworking", ctx, coworkingID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveUserIDsByCoworking indicates an expected call of ListActiveUserIDsByCoworking.
+func (mr *MockBookingRepositoryMockRecorder) ListActiveUserIDsByCoworking(ctx, coworkingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveUserIDsByCoworking", reflect.TypeOf((*MockBookingRepository)(nil).ListActiveUserIDsByCoworking), ctx, coworkingID)
+}
+
+// ListAllByUser mocks base method.
+func (m *MockBookingRepository) ListAllByUser(ctx context.Context, userID uuid.UUID) ([]entity.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllByUser", ctx, userID)
+	ret0, _ := ret[0].([]entity.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllByUser indicates an expected call of ListAllByUser.
+func (mr *MockBookingRepositoryMockRecorder) ListAllByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllByUser", reflect.TypeOf((*MockBookingRepository)(nil).ListAllByUser), ctx, userID)
+}
+
 // ListHistoryByUser mocks base method.
 func (m *MockBookingRepository) ListHistoryByUser(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]entity.Booking, int, error) {
 	m.ctrl.T.Helper()
@@ -149,6 +208,20 @@ func (mr *MockBookingRepositoryMockRecorder) MarkCompleted(ctx, id any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCompleted", reflect.TypeOf((*MockBookingRepository)(nil).MarkCompleted), ctx, id)
 }
 
+// UpdateUserName mocks base method.
+func (m *MockBookingRepository) UpdateUserName(ctx context.Context, userID uuid.UUID, userName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserName", ctx, userID, userName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserName indicates an expected call of UpdateUserName.
+func (mr *MockBookingRepositoryMockRecorder) UpdateUserName(ctx, userID, userName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserName", reflect.TypeOf((*MockBookingRepository)(nil).UpdateUserName), ctx, userID, userName)
+}
+
 // MockPlaceRepository is a mock of PlaceRepository interface.
 type MockPlaceRepository struct {
 	ctrl     *gomock.Controller
@@ -261,6 +334,20 @@ func (mr *MockPlaceRepositoryMockRecorder) SetActive(ctx, id, active any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActive", reflect.TypeOf((*MockPlaceRepository)(nil).SetActive), ctx, id, active)
 }
 
+// SetAllowedGroups mocks base method.
+func (m *MockPlaceRepository) SetAllowedGroups(ctx context.Context, id uuid.UUID, groupIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAllowedGroups", ctx, id, groupIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAllowedGroups indicates an expected call of SetAllowedGroups.
+func (mr *MockPlaceRepositoryMockRecorder) SetAllowedGroups(ctx, id, groupIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAllowedGroups", reflect.TypeOf((*MockPlaceRepository)(nil).SetAllowedGroups), ctx, id, groupIDs)
+}
+
 // MockCoworkingRepository is a mock of CoworkingRepository interface.
 type MockCoworkingRepository struct {
 	ctrl     *gomock.Controller
@@ -445,6 +532,20 @@ func (mr *MockCoworkingRepositoryMockRecorder) SetActive(ctx, id, active any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActive", reflect.TypeOf((*MockCoworkingRepository)(nil).SetActive), ctx, id, active)
 }
 
+// SetAllowedGroups mocks base method.
+func (m *MockCoworkingRepository) SetAllowedGroups(ctx context.Context, id uuid.UUID, groupIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAllowedGroups", ctx, id, groupIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAllowedGroups indicates an expected call of SetAllowedGroups.
+func (mr *MockCoworkingRepositoryMockRecorder) SetAllowedGroups(ctx, id, groupIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAllowedGroups", reflect.TypeOf((*MockCoworkingRepository)(nil).SetAllowedGroups), ctx, id, groupIDs)
+}
+
 // SetLayoutActiveByVersion mocks base method.
 func (m *MockCoworkingRepository) SetLayoutActiveByVersion(ctx context.Context, coworkingID uuid.UUID, layoutVersion int) error {
 	m.ctrl.T.Helper()
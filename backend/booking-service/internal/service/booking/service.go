@@ -12,6 +12,7 @@ import (
 	"github.com/4udiwe/cowoking/booking-service/internal/repository"
 	"github.com/4udiwe/cowoking/booking-service/pkg/json_schema_validator"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 )
 
@@ -89,7 +90,9 @@ func (s *BookingService) GetCoworking(ctx context.Context, coworkingID uuid.UUID
 	return coworking, nil
 }
 
-func (s *BookingService) ListCoworkings(ctx context.Context) ([]entity.Coworking, error) {
+// ListCoworkings возвращает коворкинги, доступные audience: закрытые
+// для групп пользователя не показываются.
+func (s *BookingService) ListCoworkings(ctx context.Context, audience entity.Audience) ([]entity.Coworking, error) {
 	logrus.Info("Listing coworkings")
 
 	coworkings, err := s.coworkingRepo.List(ctx)
@@ -98,7 +101,26 @@ func (s *BookingService) ListCoworkings(ctx context.Context) ([]entity.Coworking
 		return nil, ErrCannotFetchCoworking
 	}
 
-	return coworkings, nil
+	return lo.Filter(coworkings, func(c entity.Coworking, _ int) bool {
+		return audience.CanAccess(c.AllowedGroupIDs)
+	}), nil
+}
+
+// SetCoworkingAllowedGroups ограничивает бронирование коворкинга группами
+// пользователей. Пустой список снимает ограничение.
+func (s *BookingService) SetCoworkingAllowedGroups(ctx context.Context, coworkingID uuid.UUID, groupIDs []uuid.UUID) error {
+	logrus.Infof("Setting allowed groups for coworking ID %s: %v", coworkingID, groupIDs)
+
+	err := s.coworkingRepo.SetAllowedGroups(ctx, coworkingID, lo.Uniq(groupIDs))
+	if err != nil {
+		if errors.Is(err, repository.ErrCoworkingNotFound) {
+			return ErrCoworkingNotFound
+		}
+		logrus.Errorf("Failed to set coworking allowed groups: %v", err)
+		return ErrCannotUpdateCoworking
+	}
+
+	return nil
 }
 
 func (s *BookingService) CreateLayoutVersion(ctx context.Context, layout entity.CoworkingLayout) error {
@@ -315,6 +337,23 @@ func (s *BookingService) SetPlaceActive(ctx context.Context, placeID uuid.UUID,
 	})
 }
 
+// SetPlaceAllowedGroups ограничивает бронирование места группами пользователей
+// в дополнение к ограничению коворкинга. Пустой список снимает ограничение.
+func (s *BookingService) SetPlaceAllowedGroups(ctx context.Context, placeID uuid.UUID, groupIDs []uuid.UUID) error {
+	logrus.Infof("Setting allowed groups for place ID %s: %v", placeID, groupIDs)
+
+	err := s.placeRepo.SetAllowedGroups(ctx, placeID, lo.Uniq(groupIDs))
+	if err != nil {
+		if errors.Is(err, repository.ErrPlaceNotFound) {
+			return ErrPlaceNotFound
+		}
+		logrus.Errorf("Failed to set place allowed groups: %v", err)
+		return ErrCannotUpdatePlace
+	}
+
+	return nil
+}
+
 func (s *BookingService) GetPlacesByCoworking(ctx context.Context, coworkingID uuid.UUID) ([]entity.Place, error) {
 	logrus.Infof("Getting places for coworking ID: %v", coworkingID)
 
@@ -345,7 +384,8 @@ func (s *BookingService) GetPlaceByID(ctx context.Context, placeID uuid.UUID) (e
 	return place, nil
 }
 
-func (s *BookingService) GetAvailablePlacesByCoworking(ctx context.Context, coworkingID uuid.UUID, start, end time.Time) ([]entity.Place, error) {
+// GetAvailablePlacesByCoworking возвращает свободные места, которые audience может забронировать.
+func (s *BookingService) GetAvailablePlacesByCoworking(ctx context.Context, coworkingID uuid.UUID, start, end time.Time, audience entity.Audience) ([]entity.Place, error) {
 	logrus.Infof("Getting available places for coworking ID: %s between %s and %s", coworkingID, start.Format(time.RFC3339), end.Format(time.RFC3339))
 
 	places, err := s.placeRepo.GetAvailableByCoworking(ctx, coworkingID, start, end)
@@ -357,10 +397,12 @@ func (s *BookingService) GetAvailablePlacesByCoworking(ctx context.Context, cowo
 		return nil, ErrCannotFetchPlace
 	}
 
-	return places, nil
+	return lo.Filter(places, func(p entity.Place, _ int) bool {
+		return audience.CanBook(p)
+	}), nil
 }
 
-func (s *BookingService) CreateBooking(ctx context.Context, booking entity.Booking) error {
+func (s *BookingService) CreateBooking(ctx context.Context, booking entity.Booking, audience entity.Audience) error {
 	logrus.Infof("Creating booking for user ID: %s and place ID: %s", booking.UserID, booking.Place.ID)
 
 	if booking.StartTime.After(booking.EndTime) {
@@ -400,6 +442,11 @@ func (s *BookingService) CreateBooking(ctx context.Context, booking entity.Booki
 			return ErrCoworkingInactive
 		}
 
+		// Check group restrictions of coworking and place
+		if !audience.CanBook(place) {
+			return ErrPlaceRestricted
+		}
+
 		// Create booking
 		bookingID, err := s.bookingRepo.Create(ctx, booking)
 		if err != nil {
@@ -498,6 +545,104 @@ func (s *BookingService) CancelBooking(ctx context.Context, bookingID uuid.UUID,
 	})
 }
 
+// CancelUserBookings отменяет все активные бронирования пользователя
+// (деактивация аккаунта в auth-service). Каждое бронирование отменяется
+// в своей транзакции через CancelBooking, поэтому по каждому уходит событие
+// booking.cancelled. Повторная обработка того же события безопасна.
+func (s *BookingService) CancelUserBookings(ctx context.Context, userID uuid.UUID, reason string) error {
+	logrus.Infof("Canceling active bookings of user: %s", userID)
+
+	bookingIDs, err := s.bookingRepo.ListActiveIDsByUser(ctx, userID)
+	if err != nil {
+		logrus.Errorf("Failed to list active bookings of user: %v", err)
+		return ErrCannotCancelBooking
+	}
+
+	var failed int
+	for _, bookingID := range bookingIDs {
+		err := s.CancelBooking(ctx, bookingID, &reason)
+		switch {
+		case err == nil,
+			// Бронирование успели отменить или завершить параллельно
+			errors.Is(err, ErrBookingNotFound),
+			errors.Is(err, ErrBookingAlreadyCancelled),
+			errors.Is(err, ErrBookingAlreadyCompleted):
+		default:
+			logrus.Errorf("Failed to cancel booking %s of user %s: %v", bookingID, userID, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return ErrCannotCancelBooking
+	}
+
+	logrus.Infof("Cancelled %d bookings of user: %s", len(bookingIDs), userID)
+	return nil
+}
+
+// RenameUser обновляет денормализованное имя пользователя в его бронированиях.
+func (s *BookingService) RenameUser(ctx context.Context, userID uuid.UUID, userName string) error {
+	logrus.Infof("Updating user name in bookings of user: %s", userID)
+
+	if err := s.bookingRepo.UpdateUserName(ctx, userID, userName); err != nil {
+		logrus.Errorf("Failed to update user name: %v", err)
+		return ErrCannotUpdateUserName
+	}
+
+	return nil
+}
+
+// ExportUserBookings возвращает все бронирования пользователя
+// для выгрузки его данных (GDPR export в auth-service).
+func (s *BookingService) ExportUserBookings(ctx context.Context, userID uuid.UUID) ([]entity.Booking, error) {
+	logrus.Infof("Exporting bookings of user: %s", userID)
+
+	bookings, err := s.bookingRepo.ListAllByUser(ctx, userID)
+	if err != nil {
+		logrus.Errorf("Failed to list bookings of user: %v", err)
+		return nil, ErrCannotFetchBooking
+	}
+
+	return bookings, nil
+}
+
+// ListActiveCoworkingUsers возвращает пользователей с активными бронированиями
+// в коворкинге — для рассылок notification-service.
+func (s *BookingService) ListActiveCoworkingUsers(ctx context.Context, coworkingID uuid.UUID) ([]uuid.UUID, error) {
+	logrus.Infof("Listing users with active bookings in coworking: %s", coworkingID)
+
+	userIDs, err := s.bookingRepo.ListActiveUserIDsByCoworking(ctx, coworkingID)
+	if err != nil {
+		logrus.Errorf("Failed to list users with active bookings: %v", err)
+		return nil, ErrCannotFetchBooking
+	}
+
+	return userIDs, nil
+}
+
+// DeleteUser обрабатывает удаление аккаунта: отменяет активные бронирования
+// и заменяет user_id во всех бронированиях на анонимный идентификатор.
+func (s *BookingService) DeleteUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	anonymousID uuid.UUID,
+	reason string,
+) error {
+	if err := s.CancelUserBookings(ctx, userID, reason); err != nil {
+		return err
+	}
+
+	logrus.Infof("Anonymizing bookings of user: %s", userID)
+
+	if err := s.bookingRepo.AnonymizeUser(ctx, userID, anonymousID); err != nil {
+		logrus.Errorf("Failed to anonymize user bookings: %v", err)
+		return ErrCannotAnonymizeUser
+	}
+
+	return nil
+}
+
 func (s *BookingService) CompleteBooking(ctx context.Context, bookingID uuid.UUID) error {
 	logrus.Infof("Completing booking with ID: %s", bookingID)
 
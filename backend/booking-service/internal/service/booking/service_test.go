@@ -112,7 +112,7 @@ func TestCreateBooking_Validations(t *testing.T) {
 			err := svc.CreateBooking(context.Background(), entity.Booking{
 				StartTime: tt.start,
 				EndTime:   tt.end,
-			})
+			}, entity.Audience{})
 			if !errors.Is(err, tt.wantError) {
 				t.Errorf("CreateBooking() error = %v, wantErr %v | %s", err, tt.wantError, tt.desc)
 			}
@@ -136,10 +136,17 @@ func TestCreateBooking_BusinessLogic(t *testing.T) {
 	activePlace := entity.Place{ID: placeID, IsActive: true, Coworking: activeCoworking}
 	inactivePlace := entity.Place{ID: placeID, IsActive: false, Coworking: activeCoworking}
 
+	groupID := uuid.New()
+	restrictedPlace := entity.Place{ID: placeID, IsActive: true, Coworking: activeCoworking, AllowedGroupIDs: []uuid.UUID{groupID}}
+	restrictedCoworkingPlace := entity.Place{ID: placeID, IsActive: true, Coworking: entity.Coworking{
+		ID: coworkingID, IsActive: true, AllowedGroupIDs: []uuid.UUID{groupID},
+	}}
+
 	tests := []struct {
 		name      string
 		setup     func(*mocks.MockBookingRepository, *mocks.MockPlaceRepository, *mocks.MockOutboxRepo)
 		place     entity.Place
+		audience  entity.Audience
 		wantError error
 		wantInTx  bool
 		desc      string
@@ -198,6 +205,46 @@ func TestCreateBooking_BusinessLogic(t *testing.T) {
 			wantInTx:  true,
 			desc:      "Коворкинг неактивен",
 		},
+		{
+			name: "place_restricted_to_other_group",
+			setup: func(br *mocks.MockBookingRepository, pr *mocks.MockPlaceRepository, or *mocks.MockOutboxRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), placeID).Return(restrictedPlace, nil)
+			},
+			place:     restrictedPlace,
+			audience:  entity.Audience{GroupIDs: []uuid.UUID{uuid.New()}},
+			wantError: ErrPlaceRestricted,
+			wantInTx:  true,
+			desc:      "Место доступно только другой группе",
+		},
+		{
+			name: "coworking_restricted_for_user_without_groups",
+			setup: func(br *mocks.MockBookingRepository, pr *mocks.MockPlaceRepository, or *mocks.MockOutboxRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), placeID).Return(restrictedCoworkingPlace, nil)
+			},
+			place:     restrictedCoworkingPlace,
+			wantError: ErrPlaceRestricted,
+			wantInTx:  true,
+			desc:      "Коворкинг закрыт для пользователя без групп",
+		},
+		{
+			name: "restricted_place_booked_by_group_member",
+			setup: func(br *mocks.MockBookingRepository, pr *mocks.MockPlaceRepository, or *mocks.MockOutboxRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), placeID).Return(restrictedPlace, nil)
+				br.EXPECT().Create(gomock.Any(), gomock.Any()).Return(bookingID, nil)
+				br.EXPECT().GetByID(gomock.Any(), bookingID).Return(entity.Booking{
+					ID:     bookingID,
+					UserID: userID,
+					Place:  restrictedPlace,
+					Status: entity.BookingStatusActive,
+				}, nil)
+				or.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			place:     restrictedPlace,
+			audience:  entity.Audience{GroupIDs: []uuid.UUID{groupID}},
+			wantError: nil,
+			wantInTx:  true,
+			desc:      "Участник разрешённой группы бронирует закрытое место",
+		},
 		{
 			name: "booking_time_conflict",
 			setup: func(br *mocks.MockBookingRepository, pr *mocks.MockPlaceRepository, or *mocks.MockOutboxRepo) {
@@ -247,7 +294,7 @@ func TestCreateBooking_BusinessLogic(t *testing.T) {
 				EndTime:   now.Add(3 * time.Hour),
 			}
 
-			err := svc.CreateBooking(context.Background(), booking)
+			err := svc.CreateBooking(context.Background(), booking, tt.audience)
 			if !errors.Is(err, tt.wantError) {
 				t.Errorf("CreateBooking() error = %v, wantErr %v | %s", err, tt.wantError, tt.desc)
 			}
@@ -392,6 +439,175 @@ func TestCancelBooking(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// TESTS: CancelUserBookings
+// ============================================================================
+
+func TestCancelUserBookings(t *testing.T) {
+	userID := uuid.New()
+	firstID := uuid.New()
+	secondID := uuid.New()
+	reason := "Аккаунт пользователя деактивирован"
+
+	booking := func(id uuid.UUID, status entity.BookingStatus) entity.Booking {
+		return entity.Booking{ID: id, UserID: userID, Status: status}
+	}
+
+	tests := []struct {
+		name      string
+		setup     func(*mocks.MockBookingRepository, *mocks.MockOutboxRepo)
+		wantError error
+		desc      string
+	}{
+		{
+			name: "all_active_cancelled",
+			setup: func(br *mocks.MockBookingRepository, or *mocks.MockOutboxRepo) {
+				br.EXPECT().ListActiveIDsByUser(gomock.Any(), userID).Return([]uuid.UUID{firstID, secondID}, nil)
+				for _, id := range []uuid.UUID{firstID, secondID} {
+					br.EXPECT().GetByID(gomock.Any(), id).Return(booking(id, entity.BookingStatusActive), nil)
+					br.EXPECT().Cancel(gomock.Any(), id, &reason).Return(nil)
+				}
+				or.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+			wantError: nil,
+			desc:      "Все активные бронирования отменены с причиной",
+		},
+		{
+			name: "no_active_bookings",
+			setup: func(br *mocks.MockBookingRepository, or *mocks.MockOutboxRepo) {
+				br.EXPECT().ListActiveIDsByUser(gomock.Any(), userID).Return(nil, nil)
+			},
+			wantError: nil,
+			desc:      "Нет активных бронирований",
+		},
+		{
+			name: "already_completed_is_skipped",
+			setup: func(br *mocks.MockBookingRepository, or *mocks.MockOutboxRepo) {
+				br.EXPECT().ListActiveIDsByUser(gomock.Any(), userID).Return([]uuid.UUID{firstID}, nil)
+				br.EXPECT().GetByID(gomock.Any(), firstID).Return(booking(firstID, entity.BookingStatusCompleted), nil)
+			},
+			wantError: nil,
+			desc:      "Бронирование завершилось параллельно — не ошибка",
+		},
+		{
+			name: "cancel_error_does_not_stop_others",
+			setup: func(br *mocks.MockBookingRepository, or *mocks.MockOutboxRepo) {
+				br.EXPECT().ListActiveIDsByUser(gomock.Any(), userID).Return([]uuid.UUID{firstID, secondID}, nil)
+				br.EXPECT().GetByID(gomock.Any(), firstID).Return(booking(firstID, entity.BookingStatusActive), nil)
+				br.EXPECT().Cancel(gomock.Any(), firstID, &reason).Return(errors.New("database error"))
+				br.EXPECT().GetByID(gomock.Any(), secondID).Return(booking(secondID, entity.BookingStatusActive), nil)
+				br.EXPECT().Cancel(gomock.Any(), secondID, &reason).Return(nil)
+				or.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantError: ErrCannotCancelBooking,
+			desc:      "Ошибка по одному бронированию не мешает отменить остальные",
+		},
+		{
+			name: "list_error",
+			setup: func(br *mocks.MockBookingRepository, or *mocks.MockOutboxRepo) {
+				br.EXPECT().ListActiveIDsByUser(gomock.Any(), userID).Return(nil, errors.New("database error"))
+			},
+			wantError: ErrCannotCancelBooking,
+			desc:      "Ошибка получения списка бронирований",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBooking := mocks.NewMockBookingRepository(ctrl)
+			mockOutbox := mocks.NewMockOutboxRepo(ctrl)
+
+			tt.setup(mockBooking, mockOutbox)
+
+			svc := &BookingService{
+				bookingRepo: mockBooking,
+				outboxRepo:  mockOutbox,
+				txManager:   dummyTransactor{},
+			}
+
+			err := svc.CancelUserBookings(context.Background(), userID, reason)
+			if !errors.Is(err, tt.wantError) {
+				t.Errorf("CancelUserBookings() error = %v, wantErr %v | %s", err, tt.wantError, tt.desc)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// TESTS: DeleteUser
+// ============================================================================
+
+func TestDeleteUser(t *testing.T) {
+	userID := uuid.New()
+	anonymousID := uuid.New()
+	bookingID := uuid.New()
+	reason := "Аккаунт пользователя удалён"
+
+	tests := []struct {
+		name      string
+		setup     func(*mocks.MockBookingRepository, *mocks.MockOutboxRepo)
+		wantError error
+		desc      string
+	}{
+		{
+			name: "cancel_and_anonymize",
+			setup: func(br *mocks.MockBookingRepository, or *mocks.MockOutboxRepo) {
+				br.EXPECT().ListActiveIDsByUser(gomock.Any(), userID).Return([]uuid.UUID{bookingID}, nil)
+				br.EXPECT().GetByID(gomock.Any(), bookingID).
+					Return(entity.Booking{ID: bookingID, UserID: userID, Status: entity.BookingStatusActive}, nil)
+				br.EXPECT().Cancel(gomock.Any(), bookingID, &reason).Return(nil)
+				or.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				br.EXPECT().AnonymizeUser(gomock.Any(), userID, anonymousID).Return(nil)
+			},
+			wantError: nil,
+			desc:      "Активные бронирования отменены, user_id заменён",
+		},
+		{
+			name: "cancel_error_skips_anonymize",
+			setup: func(br *mocks.MockBookingRepository, or *mocks.MockOutboxRepo) {
+				br.EXPECT().ListActiveIDsByUser(gomock.Any(), userID).Return(nil, errors.New("database error"))
+			},
+			wantError: ErrCannotCancelBooking,
+			desc:      "Без отмены бронирований анонимизация не выполняется, событие будет обработано повторно",
+		},
+		{
+			name: "anonymize_error",
+			setup: func(br *mocks.MockBookingRepository, or *mocks.MockOutboxRepo) {
+				br.EXPECT().ListActiveIDsByUser(gomock.Any(), userID).Return(nil, nil)
+				br.EXPECT().AnonymizeUser(gomock.Any(), userID, anonymousID).Return(errors.New("database error"))
+			},
+			wantError: ErrCannotAnonymizeUser,
+			desc:      "Ошибка анонимизации",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBooking := mocks.NewMockBookingRepository(ctrl)
+			mockOutbox := mocks.NewMockOutboxRepo(ctrl)
+
+			tt.setup(mockBooking, mockOutbox)
+
+			svc := &BookingService{
+				bookingRepo: mockBooking,
+				outboxRepo:  mockOutbox,
+				txManager:   dummyTransactor{},
+			}
+
+			err := svc.DeleteUser(context.Background(), userID, anonymousID, reason)
+			if !errors.Is(err, tt.wantError) {
+				t.Errorf("DeleteUser() error = %v, wantErr %v | %s", err, tt.wantError, tt.desc)
+			}
+		})
+	}
+}
+
 // ============================================================================
 // TESTS: CompleteBooking
 // ============================================================================
@@ -1,6 +1,10 @@
 package config
 
-import "github.com/ilyakaznacheev/cleanenv"
+import (
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+)
 
 type Route struct {
 	Path     string `yaml:"path"`
@@ -24,6 +28,13 @@ type Config struct {
 		RequestsPerSecond int `yaml:"requests_per_second"`
 	} `yaml:"rate_limit"`
 
+	// Обмен персональных токенов доступа на JWT в auth-service
+	Auth struct {
+		URL                 string        `yaml:"url" env:"AUTH_URL"`
+		PersonalTokenPrefix string        `yaml:"personal_token_prefix"`
+		Timeout             time.Duration `yaml:"timeout"`
+	} `yaml:"auth"`
+
 	Routes []Route `yaml:"routes"`
 }
 
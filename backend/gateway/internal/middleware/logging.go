@@ -27,6 +27,12 @@ func (r *recorder) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Unwrap нужен http.ResponseController: через него reverse proxy сбрасывает
+// буфер для SSE и перехватывает соединение при Upgrade (WebSocket)
+func (r *recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 func Logging(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
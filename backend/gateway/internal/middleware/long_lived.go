@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LongLived снимает таймауты сервера для SSE и WebSocket. ReadTimeout и
+// WriteTimeout считаются от начала запроса, поэтому без этого поток
+// обрывается через WriteTimeout, а контекст запроса отменяется по ReadTimeout.
+// Обычные запросы по-прежнему ограничены таймаутами сервера.
+func LongLived(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isLongLived(r) {
+			rc := http.NewResponseController(w)
+			if err := rc.SetReadDeadline(time.Time{}); err != nil {
+				logrus.WithError(err).Warn("failed to clear read deadline")
+			}
+			if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+				logrus.WithError(err).Warn("failed to clear write deadline")
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isLongLived(r *http.Request) bool {
+	if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		return true
+	}
+
+	for _, v := range strings.Split(r.Header.Get("Connection"), ",") {
+		if strings.EqualFold(strings.TrimSpace(v), "upgrade") {
+			return r.Header.Get("Upgrade") != ""
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	grantTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+	tokenTypePersonalToken = "urn:coworking:params:oauth:token-type:personal_access_token"
+
+	// запас до истечения JWT, после которого обмен выполняется заново
+	exchangeLeeway = 30 * time.Second
+)
+
+type exchangedToken struct {
+	accessToken string
+	expiresAt   time.Time
+}
+
+// PersonalTokenExchange подменяет персональный токен доступа в заголовке
+// Authorization на короткоживущий JWT, полученный от auth-service
+// (RFC 8693 token exchange). Сервисы за gateway видят обычный access token.
+// Результат обмена кэшируется по хэшу токена до истечения JWT.
+func PersonalTokenExchange(authURL, prefix string, timeout time.Duration) func(http.Handler) http.Handler {
+
+	client := &http.Client{Timeout: timeout}
+	endpoint := strings.TrimRight(authURL, "/") + "/auth/token"
+
+	var (
+		mu    sync.Mutex
+		cache = make(map[string]exchangedToken)
+	)
+
+	exchange := func(raw string) (string, error) {
+		sum := sha256.Sum256([]byte(raw))
+		key := hex.EncodeToString(sum[:])
+
+		mu.Lock()
+		cached, ok := cache[key]
+		mu.Unlock()
+		if ok && time.Now().Before(cached.expiresAt) {
+			return cached.accessToken, nil
+		}
+
+		form := url.Values{
+			"grant_type":         {grantTokenExchange},
+			"subject_token":      {raw},
+			"subject_token_type": {tokenTypePersonalToken},
+		}
+		resp, err := client.PostForm(endpoint, form)
+		if err != nil {
+			return "", fmt.Errorf("exchange request: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("exchange rejected: status %d", resp.StatusCode)
+		}
+
+		var body struct {
+			AccessToken string `json:"access_token"`
+			ExpiresIn   int    `json:"expires_in"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return "", fmt.Errorf("decode exchange response: %w", err)
+		}
+
+		expiresAt := time.Now().Add(time.Duration(body.ExpiresIn)*time.Second - exchangeLeeway)
+
+		mu.Lock()
+		// Заодно выбрасываем протухшие записи, чтобы кэш не рос бесконечно
+		for k, v := range cache {
+			if time.Now().After(v.expiresAt) {
+				delete(cache, k)
+			}
+		}
+		cache[key] = exchangedToken{accessToken: body.AccessToken, expiresAt: expiresAt}
+		mu.Unlock()
+
+		return body.AccessToken, nil
+	}
+
+	return func(next http.Handler) http.Handler {
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			raw, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || !strings.HasPrefix(raw, prefix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, err := exchange(raw)
+			if err != nil {
+				logrus.WithError(err).WithField("path", r.URL.Path).Warn("personal token exchange failed")
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message":"Invalid personal access token"}`))
+				return
+			}
+
+			r.Header.Set("Authorization", "Bearer "+token)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -55,9 +55,13 @@ func New(cfg *config.Config) http.Handler {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	mainHandler := middleware.RateLimit(cfg.RateLimit.RequestsPerSecond)(
-		middleware.RequestID(
-			middleware.Logging(mainMux),
+	mainHandler := middleware.LongLived(
+		middleware.RateLimit(cfg.RateLimit.RequestsPerSecond)(
+			middleware.RequestID(
+				middleware.Logging(
+					middleware.PersonalTokenExchange(cfg.Auth.URL, cfg.Auth.PersonalTokenPrefix, cfg.Auth.Timeout)(mainMux),
+				),
+			),
 		),
 	)
 
@@ -17,6 +17,7 @@ type (
 		MinIO        MinIO        `yaml:"minio"`
 		Shutdown     Shutdown     `yaml:"shutdown"`
 		StaleChecker StaleChecker `yaml:"stale_checker"`
+		Kafka        Kafka        `yaml:"kafka"`
 	}
 
 	App struct {
@@ -57,6 +58,18 @@ type (
 		Interval time.Duration `yaml:"interval" env:"STALE_CHECKER_INTERVAL" env-default:"5m"`
 		Limit    int           `yaml:"limit" env:"STALE_CHECKER_LIMIT" env-default:"50"`
 	}
+
+	Kafka struct {
+		Brokers []string `env-required:"true" yaml:"brokers" env:"KAFKA_BROKERS"`
+		Topics  struct {
+			AuthEvents string `env-required:"true" yaml:"auth_events" env:"KAFKA_AUTH_EVENTS"`
+		} `env-required:"true" yaml:"topics" env:"KAFKA_TOPICS"`
+		Consumer KafkaConsumer `yaml:"consumer"`
+	}
+
+	KafkaConsumer struct {
+		GroupID string `yaml:"group_id" env:"KAFKA_CONSUMER_GROUP_ID"`
+	}
 )
 
 func New(configPath string) (*Config, error) {
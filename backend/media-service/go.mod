@@ -3,13 +3,12 @@ module github.com/4udiwe/coworking/backend/media-service
 go 1.25.7
 
 require (
+	github.com/4udiwe/big-bob-pizza/order-service v0.0.0-20260402174529-80484f6dd50e
 	github.com/4udiwe/coworking/auth-service v0.0.0-20260414125732-96b44f05e474
 	github.com/davidbyttow/govips/v2 v2.18.0
 	github.com/ilyakaznacheev/cleanenv v1.5.0
 	github.com/labstack/echo/v4 v4.15.0
 	go.mongodb.org/mongo-driver v1.17.9
-	google.golang.org/grpc v1.81.0
-	google.golang.org/protobuf v1.36.11
 )
 
 require (
@@ -34,7 +33,9 @@ require (
 	github.com/minio/md5-simd v1.1.2 // indirect
 	github.com/mssola/user_agent v0.6.0 // indirect
 	github.com/philhofer/fwd v1.2.0 // indirect
+	github.com/pierrec/lz4/v4 v4.1.22 // indirect
 	github.com/rs/xid v1.6.0 // indirect
+	github.com/segmentio/kafka-go v0.4.49 // indirect
 	github.com/tinylib/msgp v1.6.1 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasttemplate v1.2.2 // indirect
@@ -43,7 +44,6 @@ require (
 	golang.org/x/image v0.39.0 // indirect
 	golang.org/x/net v0.53.0 // indirect
 	golang.org/x/sys v0.43.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20260226221140-a57be14db171 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	olympos.io/encoding/edn v0.0.0-20201019073823-d3554ca0b0a3 // indirect
 )
@@ -63,3 +63,6 @@ require (
 	golang.org/x/sync v0.20.0
 	golang.org/x/text v0.36.0 // indirect
 )
+
+// auth-service собирается из этого же репозитория
+replace github.com/4udiwe/coworking/auth-service => ../auth-service
@@ -6,10 +6,11 @@ import (
 	"os"
 
 	"github.com/4udiwe/avito-pvz/pkg/httpserver"
+	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
 	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/4udiwe/coworking/backend/media-service/config"
 	api "github.com/4udiwe/coworking/backend/media-service/internal/api/http"
-	"github.com/4udiwe/coworking/backend/media-service/internal/api/http/middleware"
 	"github.com/4udiwe/coworking/backend/media-service/internal/image_processor"
 	media_repository "github.com/4udiwe/coworking/backend/media-service/internal/repository/media"
 	object_repository "github.com/4udiwe/coworking/backend/media-service/internal/repository/object"
@@ -51,8 +52,10 @@ type App struct {
 	authMW *middleware.AuthMiddleware
 
 	// Auth
-	PublicKey    *rsa.PublicKey
-	jwtValidator *jwt_validator.Validator
+	PublicKey          *rsa.PublicKey
+	jwtValidator       *jwt_validator.Validator
+	denylist           *jwt_validator.Denylist
+	revocationListener *jwt_validator.RevocationListener
 }
 
 func New(configPath string) *App {
@@ -117,6 +120,22 @@ func (app *App) Start() {
 	app.staleChecker.Start(context.Background())
 	defer app.staleChecker.Stop()
 
+	// ──────────────────────────────────────────
+	// Token revocation (auth.events -> denylist)
+	// ──────────────────────────────────────────
+	revocationCtx, stopRevocation := context.WithCancel(context.Background())
+	defer stopRevocation()
+
+	app.revocationListener = jwt_validator.NewRevocationListener(
+		app.Denylist(),
+		kafka.NewConsumer(app.cfg.Kafka.Brokers),
+		app.cfg.Kafka.Topics.AuthEvents,
+		app.cfg.Kafka.Consumer.GroupID,
+	)
+	if err := app.revocationListener.Run(revocationCtx); err != nil {
+		logrus.Fatalf("app - Start - RevocationListener failed: %v", err)
+	}
+
 	// ──────────────────────────────────────────
 	// HTTP Server (Echo)
 	// ──────────────────────────────────────────
@@ -5,7 +5,8 @@ import (
 	"strings"
 
 	"github.com/4udiwe/avito-pvz/pkg/validator"
-	"github.com/4udiwe/coworking/backend/media-service/internal/api/http/middleware"
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/labstack/echo/v4"
 )
 
@@ -39,7 +40,7 @@ func (app *App) configureRouter(handler *echo.Echo) {
 		}
 	})
 
-	mediaGroup := handler.Group("/admin/media", middleware.AdminOnly)
+	mediaGroup := handler.Group("/admin/media", middleware.RequirePermission(jwt_validator.PermMediaManage))
 	{
 		mediaGroup.POST("/upload", app.PostMediaHandler().Handle)
 		mediaGroup.DELETE("/:id", app.DeleteMediaHandler().Handle)
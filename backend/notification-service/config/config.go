@@ -16,7 +16,17 @@ type (
 		Kafka      Kafka      `yaml:"kafka"`
 		Outbox     Outbox     `yaml:"outbox"`
 		PushSender PushSender `yaml:"push_sender"`
+		Email      Email      `yaml:"email"`
+		WebPush    WebPush    `yaml:"web_push"`
+		Templates  Templates  `yaml:"templates"`
+		Stream     Stream     `yaml:"stream"`
+		Delivery   Delivery   `yaml:"delivery"`
 		Auth       Auth       `yaml:"auth"`
+
+		AuthAPI       AuthAPI       `yaml:"auth_api"`
+		BookingAPI    BookingAPI    `yaml:"booking_api"`
+		Announcements Announcements `yaml:"announcements"`
+		Digest        Digest        `yaml:"digest"`
 	}
 
 	App struct {
@@ -41,12 +51,45 @@ type (
 		PublicKeyPath string `env-required:"true" yaml:"public_key_path" env:"AUTH_PUBLIC_KEY_PATH"`
 	}
 
+	// AuthAPI — внутренние маршруты auth-service, доступ по client credentials
+	AuthAPI struct {
+		URL          string        `yaml:"url" env:"AUTH_API_URL" env-default:"http://auth-service:8080"`
+		ClientID     string        `yaml:"client_id" env:"AUTH_CLIENT_ID"`
+		ClientSecret string        `yaml:"client_secret" env:"AUTH_CLIENT_SECRET"`
+		Timeout      time.Duration `yaml:"timeout" env:"AUTH_API_TIMEOUT" env-default:"3s"`
+	}
+
+	// BookingAPI — внутренние маршруты booking-service, токен тот же, что для auth-service
+	BookingAPI struct {
+		URL     string        `yaml:"url" env:"BOOKING_API_URL" env-default:"http://booking-service:8081"`
+		Timeout time.Duration `yaml:"timeout" env:"BOOKING_API_TIMEOUT" env-default:"5s"`
+	}
+
+	Announcements struct {
+		// Сколько получателей рассылки сохраняется в одной транзакции
+		BatchSize int `yaml:"batch_size" env:"ANNOUNCEMENTS_BATCH_SIZE" env-default:"500"`
+	}
+
+	Digest struct {
+		// Час отправки дайджеста в часовом поясе пользователя
+		Hour int `yaml:"hour" env:"DIGEST_HOUR" env-default:"7"`
+		// День недели еженедельного дайджеста: 0 — воскресенье, 1 — понедельник
+		Weekday int `yaml:"weekday" env:"DIGEST_WEEKDAY" env-default:"1"`
+		// Сколько получателей выбирается из БД за раз
+		BatchSize int `yaml:"batch_size" env:"DIGEST_BATCH_SIZE" env-default:"500"`
+	}
+
 	Kafka struct {
 		Brokers []string `env-required:"true" yaml:"brokers" env:"KAFKA_BROKERS"`
 		Topics  struct {
 			SchedulerEvents    string `env-required:"true" yaml:"scheduler_events" env:"KAFKA_SCHEDULER_EVENTS"`
 			BookingEvents      string `env-required:"true" yaml:"booking_events" env:"KAFKA_BOOKING_EVENTS"`
 			NotificationEvents string `env-required:"true" yaml:"notification_events" env:"KAFKA_NOTIFICATION_EVENTS"`
+			AuthEvents         string `env-required:"true" yaml:"auth_events" env:"KAFKA_AUTH_EVENTS"`
+			// Запросы отложенных событий для scheduler-service
+			SchedulerTimers string `yaml:"scheduler_timers" env:"KAFKA_SCHEDULER_TIMERS" env-default:"scheduler.timers"`
+			// Письма аккаунта с одноразовыми ссылками от auth-service
+			AuthMail string `yaml:"auth_mail" env:"KAFKA_AUTH_MAIL" env-default:"auth.mail"`
 		} `env-required:"true" yaml:"topics" env:"KAFKA_TOPICS"`
 		Producer KafkaProducer `yaml:"producer"`
 		Consumer KafkaConsumer `yaml:"consumer"`
@@ -65,6 +108,13 @@ type (
 		SessionTimeout    time.Duration `yaml:"session_timeout" env:"KAFKA_CONSUMER_SESSION_TIMEOUT"`
 		HeartbeatInterval time.Duration `yaml:"heartbeat_interval" env:"KAFKA_CONSUMER_HEARTBEAT_INTERVAL"`
 		CommitInterval    time.Duration `yaml:"commit_interval" env:"KAFKA_CONSUMER_COMMIT_INTERVAL"`
+
+		// Повторы через <topic>.<group>.retry, после исчерпания — <topic>.<group>.dlq
+		RetryMaxAttempts int           `yaml:"retry_max_attempts" env:"KAFKA_CONSUMER_RETRY_MAX_ATTEMPTS" env-default:"5"`
+		RetryBackoff     time.Duration `yaml:"retry_backoff" env:"KAFKA_CONSUMER_RETRY_BACKOFF" env-default:"5s"`
+		RetryMaxBackoff  time.Duration `yaml:"retry_max_backoff" env:"KAFKA_CONSUMER_RETRY_MAX_BACKOFF" env-default:"5m"`
+		// Сколько хранить отметки об обработанных событиях
+		InboxRetention time.Duration `yaml:"inbox_retention" env:"KAFKA_CONSUMER_INBOX_RETENTION" env-default:"168h"`
 	}
 
 	Outbox struct {
@@ -78,6 +128,60 @@ type (
 	PushSender struct {
 		ServiceAccountPath string `env-required:"true" yaml:"service_account_path" env:"SERVICE_ACCOUNT_PATH"`
 	}
+
+	Email struct {
+		Enabled bool `yaml:"enabled" env:"EMAIL_ENABLED"`
+		// Префикс для ссылок из писем: app_url + actionUrl уведомления
+		AppURL   string    `yaml:"app_url" env:"EMAIL_APP_URL"`
+		Timezone string    `yaml:"timezone" env:"EMAIL_TIMEZONE"`
+		SMTP     EmailSMTP `yaml:"smtp"`
+	}
+
+	WebPush struct {
+		Enabled bool `yaml:"enabled" env:"WEBPUSH_ENABLED"`
+		// Пара ключей VAPID в base64url; публичный ключ отдаётся браузеру для подписки
+		VAPIDPublicKey  string `yaml:"vapid_public_key" env:"WEBPUSH_VAPID_PUBLIC_KEY"`
+		VAPIDPrivateKey string `yaml:"vapid_private_key" env:"WEBPUSH_VAPID_PRIVATE_KEY"`
+		// Контакт для push-сервисов: mailto: или https: адрес
+		Subscriber string        `yaml:"subscriber" env:"WEBPUSH_SUBSCRIBER"`
+		TTL        time.Duration `yaml:"ttl" env:"WEBPUSH_TTL" env-default:"24h"`
+		Timeout    time.Duration `yaml:"timeout" env:"WEBPUSH_TIMEOUT" env-default:"10s"`
+	}
+
+	Templates struct {
+		// Как часто перечитывать тексты, изменённые администратором на других репликах
+		RefreshInterval time.Duration `yaml:"refresh_interval" env:"TEMPLATES_REFRESH_INTERVAL" env-default:"30s"`
+	}
+
+	Delivery struct {
+		// После стольких неудачных попыток доставка переходит в dead
+		MaxAttempts int `yaml:"max_attempts" env:"DELIVERY_MAX_ATTEMPTS" env-default:"6"`
+		// Задержка перед первым повтором, дальше удваивается до MaxDelay
+		BaseDelay time.Duration `yaml:"base_delay" env:"DELIVERY_BASE_DELAY" env-default:"30s"`
+		MaxDelay  time.Duration `yaml:"max_delay" env:"DELIVERY_MAX_DELAY" env-default:"30m"`
+
+		RetryInterval   time.Duration `yaml:"retry_interval" env:"DELIVERY_RETRY_INTERVAL" env-default:"10s"`
+		RetryBatchLimit int           `yaml:"retry_batch_limit" env:"DELIVERY_RETRY_BATCH_LIMIT" env-default:"100"`
+		// Сколько доставка остаётся за репликой, которая её захватила
+		RetryLease time.Duration `yaml:"retry_lease" env:"DELIVERY_RETRY_LEASE" env-default:"2m"`
+	}
+
+	Stream struct {
+		// Комментарий в SSE-потоке, чтобы прокси не закрывали простаивающее соединение
+		HeartbeatInterval time.Duration `yaml:"heartbeat_interval" env:"STREAM_HEARTBEAT_INTERVAL" env-default:"25s"`
+		// Ограничение открытых потоков одного пользователя на реплике
+		MaxConnectionsPerUser int `yaml:"max_connections_per_user" env:"STREAM_MAX_CONNECTIONS_PER_USER" env-default:"10"`
+	}
+
+	EmailSMTP struct {
+		Host     string        `yaml:"host" env:"SMTP_HOST"`
+		Port     int           `yaml:"port" env:"SMTP_PORT"`
+		Username string        `yaml:"username" env:"SMTP_USERNAME"`
+		Password string        `yaml:"password" env:"SMTP_PASSWORD"`
+		From     string        `yaml:"from" env:"SMTP_FROM"`
+		FromName string        `yaml:"from_name" env:"SMTP_FROM_NAME"`
+		Timeout  time.Duration `yaml:"timeout" env:"SMTP_TIMEOUT"`
+	}
 )
 
 func New(configPath string) (*Config, error) {
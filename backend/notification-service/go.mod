@@ -4,9 +4,11 @@ go 1.25.7
 
 require (
 	github.com/Masterminds/squirrel v1.5.4
+	github.com/SherClockHolmes/webpush-go v1.4.0
 	github.com/ilyakaznacheev/cleanenv v1.5.0
 	github.com/labstack/gommon v0.4.2
 	github.com/sirupsen/logrus v1.9.3
+	go.uber.org/mock v0.6.0
 	google.golang.org/api v0.270.0
 )
 
@@ -38,6 +40,7 @@ require (
 	github.com/go-playground/universal-translator v0.18.1 // indirect
 	github.com/go-playground/validator/v10 v10.27.0 // indirect
 	github.com/golang-jwt/jwt/v4 v4.5.2 // indirect
+	github.com/golang-jwt/jwt/v5 v5.3.0 // indirect
 	github.com/golang/protobuf v1.5.4 // indirect
 	github.com/google/s2a-go v0.1.9 // indirect
 	github.com/googleapis/enterprise-certificate-proxy v0.3.14 // indirect
@@ -71,12 +74,14 @@ require (
 	go.opentelemetry.io/otel/trace v1.40.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/crypto v0.48.0 // indirect
+	golang.org/x/mod v0.32.0 // indirect
 	golang.org/x/net v0.51.0 // indirect
 	golang.org/x/oauth2 v0.36.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
 	golang.org/x/sys v0.41.0 // indirect
 	golang.org/x/text v0.34.0 // indirect
 	golang.org/x/time v0.14.0 // indirect
+	golang.org/x/tools v0.41.0 // indirect
 	google.golang.org/appengine/v2 v2.0.6 // indirect
 	google.golang.org/genproto v0.0.0-20260128011058-8636f8732409 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20260203192932-546029d2fa20 // indirect
@@ -88,11 +93,11 @@ require (
 require (
 	firebase.google.com/go/v4 v4.19.0
 	github.com/4udiwe/avito-pvz v0.0.0-20250909122805-a4429f441e91
-	github.com/4udiwe/big-bob-pizza/order-service v0.0.0-20251219214901-41e245f172ab
+	github.com/4udiwe/big-bob-pizza/order-service v0.0.0-20260402174529-80484f6dd50e
 	github.com/4udiwe/coworking/auth-service v0.0.0-20260309100318-5e9dc451cca2
 	github.com/BurntSushi/toml v1.3.2 // indirect
 	github.com/google/uuid v1.6.0
-	github.com/jackc/pgx/v5 v5.8.0
+	github.com/jackc/pgx/v5 v5.9.1
 	github.com/joho/godotenv v1.5.1 // indirect
 	github.com/labstack/echo/v4 v4.15.1
 	github.com/pressly/goose/v3 v3.27.0
@@ -100,3 +105,8 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	olympos.io/encoding/edn v0.0.0-20201019073823-d3554ca0b0a3 // indirect
 )
+
+// auth-service собирается из этого же репозитория
+replace github.com/4udiwe/coworking/auth-service => ../auth-service
+
+tool go.uber.org/mock/mockgen
@@ -0,0 +1,11 @@
+package delete_notifications
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type NotificationService interface {
+	DeleteNotifications(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int, error)
+}
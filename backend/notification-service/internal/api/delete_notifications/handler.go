@@ -0,0 +1,36 @@
+package delete_notifications
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/4udiwe/coworking/notification-service/internal/api"
+	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s NotificationService
+}
+
+func New(notificationService NotificationService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: notificationService})
+}
+
+type Request = dto.NotificationIDsRequest
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	deleted, err := h.s.DeleteNotifications(ctx.Request().Context(), claims.UserID, in.IDs)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, dto.NotificationsDeletedResponse{Deleted: deleted})
+}
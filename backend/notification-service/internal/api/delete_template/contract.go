@@ -0,0 +1,11 @@
+package delete_template
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+)
+
+type TemplateService interface {
+	Reset(ctx context.Context, notificationType entity.NotificationType, locale entity.Locale) error
+}
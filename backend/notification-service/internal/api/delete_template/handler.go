@@ -0,0 +1,35 @@
+package delete_template
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/notification-service/internal/api"
+	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	template_service "github.com/4udiwe/coworking/notification-service/internal/service/template"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s TemplateService
+}
+
+func New(s TemplateService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.TemplateKeyRequest
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	err := h.s.Reset(ctx.Request().Context(), entity.NotificationType(in.Type), entity.Locale(in.Locale))
+	if err != nil {
+		if errors.Is(err, template_service.ErrUnknownTemplate) || errors.Is(err, template_service.ErrTemplateNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -1,8 +1,10 @@
 package dto
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
 	"github.com/google/uuid"
 )
 
@@ -10,6 +12,8 @@ import (
 
 type NotificationsResponse struct {
 	Notifications []Notification `json:"notifications"`
+	// Курсор следующей страницы; отсутствует на последней
+	NextCursor *string `json:"nextCursor,omitempty"`
 }
 
 type UnreadCountResponse struct {
@@ -27,12 +31,31 @@ type Notification struct {
 	IsRead    bool       `json:"isRead"`
 	CreatedAt time.Time  `json:"createdAt"`
 	ReadAt    *time.Time `json:"readAt,omitempty"`
+	// Первая успешная push-доставка хотя бы на одно устройство
+	DeliveredAt *time.Time `json:"deliveredAt,omitempty"`
 }
 
 // Request DTOs
+// Для platform = web вместо deviceToken передаётся PushSubscription браузера
+// (результат subscription.toJSON())
 type RegisterDeviceRequest struct {
-	DeviceToken string `json:"deviceToken" validate:"required"`
-	Platform    string `json:"platform" validate:"required"`
+	DeviceToken  string               `json:"deviceToken" validate:"required_unless=Platform web"`
+	Platform     string               `json:"platform" validate:"required"`
+	Subscription *WebPushSubscription `json:"subscription" validate:"required_if=Platform web"`
+}
+
+type WebPushSubscription struct {
+	Endpoint string                  `json:"endpoint" validate:"required,url"`
+	Keys     WebPushSubscriptionKeys `json:"keys" validate:"required"`
+}
+
+type WebPushSubscriptionKeys struct {
+	P256dh string `json:"p256dh" validate:"required"`
+	Auth   string `json:"auth" validate:"required"`
+}
+
+type WebPushPublicKeyResponse struct {
+	PublicKey string `json:"publicKey"`
 }
 
 type MarkNotificationReadRequest struct {
@@ -45,4 +68,307 @@ type GetNotificationsQuery struct {
 	Offset int       `query:"offset" validate:"min=0"`
 	IsRead *bool     `query:"isRead"`
 	Since  *time.Time `query:"since"`
+	// Непрозрачный курсор из nextCursor; при нём offset игнорируется
+	Cursor string     `query:"cursor"`
+	Types  []string   `query:"type" validate:"omitempty,dive,oneof=booking_created booking_cancelled booking_reminder booking_expired booking_end_reminder announcement booking_digest"`
+	From   *time.Time `query:"from"`
+	To     *time.Time `query:"to"`
+}
+
+type ChannelToggles struct {
+	Push  *bool `json:"push" validate:"required"`
+	Email *bool `json:"email" validate:"required"`
+}
+
+// Время в формате "HH:MM" в часовом поясе пользователя
+type QuietHours struct {
+	Start string `json:"start" validate:"required,datetime=15:04"`
+	End   string `json:"end" validate:"required,datetime=15:04"`
+}
+
+type Preferences struct {
+	Channels   ChannelToggles             `json:"channels" validate:"required"`
+	Types      map[string]map[string]bool `json:"types"`
+	QuietHours *QuietHours                `json:"quietHours,omitempty" validate:"omitempty"`
+	Locale     string                     `json:"locale" validate:"required,oneof=ru en"`
+	Timezone   string                     `json:"timezone" validate:"required"`
+	Digest     string                     `json:"digest" validate:"required,oneof=off daily weekly"`
+}
+
+// PreferencesFromEntity отдаёт полную матрицу тип × канал с учётом значений по умолчанию
+func PreferencesFromEntity(prefs entity.NotificationPreferences) Preferences {
+	types := make(map[string]map[string]bool, len(entity.NotificationTypes))
+	for _, notificationType := range entity.NotificationTypes {
+		channels := make(map[string]bool, len(entity.Channels))
+		for _, ch := range entity.Channels {
+			enabled, ok := prefs.Types[notificationType][ch]
+			channels[string(ch)] = !ok || enabled
+		}
+		types[string(notificationType)] = channels
+	}
+
+	push, email := prefs.PushEnabled, prefs.EmailEnabled
+
+	out := Preferences{
+		Channels: ChannelToggles{Push: &push, Email: &email},
+		Types:    types,
+		Locale:   string(prefs.Locale),
+		Timezone: prefs.Timezone,
+		Digest:   string(prefs.Digest),
+	}
+
+	if prefs.QuietHours != nil {
+		out.QuietHours = &QuietHours{
+			Start: formatMinutes(prefs.QuietHours.Start),
+			End:   formatMinutes(prefs.QuietHours.End),
+		}
+	}
+
+	return out
+}
+
+// ToEntity переводит запрос в настройки; типы и каналы проверяет сервис
+func (p Preferences) ToEntity(userID uuid.UUID) (entity.NotificationPreferences, error) {
+	prefs := entity.NotificationPreferences{
+		UserID:       userID,
+		PushEnabled:  *p.Channels.Push,
+		EmailEnabled: *p.Channels.Email,
+		Types:        make(map[entity.NotificationType]map[entity.Channel]bool, len(p.Types)),
+		Locale:       entity.Locale(p.Locale),
+		Timezone:     p.Timezone,
+		Digest:       entity.DigestMode(p.Digest),
+	}
+
+	for notificationType, channels := range p.Types {
+		overrides := make(map[entity.Channel]bool, len(channels))
+		for ch, enabled := range channels {
+			overrides[entity.Channel(ch)] = enabled
+		}
+		prefs.Types[entity.NotificationType(notificationType)] = overrides
+	}
+
+	if p.QuietHours != nil {
+		start, err := parseMinutes(p.QuietHours.Start)
+		if err != nil {
+			return entity.NotificationPreferences{}, err
+		}
+		end, err := parseMinutes(p.QuietHours.End)
+		if err != nil {
+			return entity.NotificationPreferences{}, err
+		}
+		prefs.QuietHours = &entity.QuietHours{Start: start, End: end}
+	}
+
+	return prefs, nil
+}
+
+func formatMinutes(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func parseMinutes(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+type NotificationTemplate struct {
+	Type       string     `json:"type"`
+	Locale     string     `json:"locale"`
+	Title      string     `json:"title"`
+	Body       string     `json:"body"`
+	Overridden bool       `json:"overridden"`
+	UpdatedBy  *uuid.UUID `json:"updatedBy,omitempty"`
+	UpdatedAt  *time.Time `json:"updatedAt,omitempty"`
+}
+
+func NotificationTemplateFromEntity(t entity.NotificationTemplate) NotificationTemplate {
+	return NotificationTemplate{
+		Type:       string(t.Type),
+		Locale:     string(t.Locale),
+		Title:      t.Title,
+		Body:       t.Body,
+		Overridden: t.Overridden,
+		UpdatedBy:  t.UpdatedBy,
+		UpdatedAt:  t.UpdatedAt,
+	}
+}
+
+type NotificationTemplatesResponse struct {
+	Templates []NotificationTemplate `json:"templates"`
+}
+
+type TemplateKeyRequest struct {
+	Type   string `param:"type" validate:"required"`
+	Locale string `param:"locale" validate:"required"`
+}
+
+type UpdateTemplateRequest struct {
+	Type   string `param:"type" validate:"required"`
+	Locale string `param:"locale" validate:"required"`
+	Title  string `json:"title" validate:"required,max=200"`
+	Body   string `json:"body" validate:"required,max=2000"`
+}
+
+func NotificationFromEntity(n entity.Notification) Notification {
+	return Notification{
+		ID:        n.ID,
+		UserID:    n.UserID,
+		Payload:   n.Payload,
+		Type:      string(n.Type),
+		Title:     n.Title,
+		Body:      n.Body,
+		ActionURL: n.ActionURL,
+		IsRead:    n.IsRead,
+		CreatedAt: n.CreatedAt,
+		ReadAt:    n.ReadAt,
+
+		DeliveredAt: n.DeliveredAt,
+	}
+}
+
+type NotificationDeliveriesRequest struct {
+	NotificationID uuid.UUID `param:"notificationId" validate:"required"`
+}
+
+type RetryDeliveryRequest struct {
+	DeliveryID uuid.UUID `param:"deliveryId" validate:"required"`
+}
+
+type PushDelivery struct {
+	ID       uuid.UUID  `json:"id"`
+	DeviceID *uuid.UUID `json:"deviceId,omitempty"`
+	// Токен целиком не отдаётся, только последние символы для сверки
+	DeviceToken   string     `json:"deviceToken"`
+	Platform      string     `json:"platform"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     *string    `json:"lastError,omitempty"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+	SentAt        *time.Time `json:"sentAt,omitempty"`
+}
+
+func PushDeliveryFromEntity(d entity.PushDelivery) PushDelivery {
+	delivery := PushDelivery{
+		ID:          d.ID,
+		DeviceID:    d.DeviceID,
+		DeviceToken: maskToken(d.DeviceToken),
+		Platform:    d.Platform,
+		Status:      string(d.Status),
+		Attempts:    d.Attempts,
+		LastError:   d.LastError,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+		SentAt:      d.SentAt,
+	}
+
+	// Время следующей попытки имеет смысл только для ожидающих доставок
+	if d.Status == entity.DeliveryQueued || d.Status == entity.DeliveryFailed {
+		delivery.NextAttemptAt = &d.NextAttemptAt
+	}
+
+	return delivery
+}
+
+type NotificationDeliveriesResponse struct {
+	NotificationID uuid.UUID      `json:"notificationId"`
+	DeliveredAt    *time.Time     `json:"deliveredAt,omitempty"`
+	Deliveries     []PushDelivery `json:"deliveries"`
+}
+
+func maskToken(token string) string {
+	const visible = 8
+	if len(token) <= visible {
+		return "…"
+	}
+	return "…" + token[len(token)-visible:]
+}
+
+// Аудитория задаётся target и соответствующим полем:
+// role — role, coworking — coworkingId, users — userIds, all — ни одним
+type CreateAnnouncementRequest struct {
+	Title       string      `json:"title" validate:"required,max=200"`
+	Body        string      `json:"body" validate:"required,max=2000"`
+	ActionURL   *string     `json:"actionUrl,omitempty" validate:"omitempty,max=500"`
+	Target      string      `json:"target" validate:"required,oneof=all role coworking users"`
+	Role        *string     `json:"role,omitempty" validate:"required_if=Target role,omitempty,max=64"`
+	CoworkingID *uuid.UUID  `json:"coworkingId,omitempty" validate:"required_if=Target coworking"`
+	UserIDs     []uuid.UUID `json:"userIds,omitempty" validate:"required_if=Target users,max=10000"`
+	// Не задано или в прошлом — рассылка уходит сразу
+	ScheduledAt *time.Time `json:"scheduledAt,omitempty"`
+}
+
+type AnnouncementRequest struct {
+	AnnouncementID uuid.UUID `param:"announcementId" validate:"required"`
+}
+
+type AnnouncementsQuery struct {
+	Limit  int `query:"limit" validate:"required,min=1,max=100"`
+	Offset int `query:"offset" validate:"min=0"`
+}
+
+type AnnouncementStats struct {
+	Recipients int `json:"recipients"`
+	Delivered  int `json:"delivered"`
+	Read       int `json:"read"`
+}
+
+type Announcement struct {
+	ID          uuid.UUID   `json:"id"`
+	Title       string      `json:"title"`
+	Body        string      `json:"body"`
+	ActionURL   *string     `json:"actionUrl,omitempty"`
+	Target      string      `json:"target"`
+	Role        *string     `json:"role,omitempty"`
+	CoworkingID *uuid.UUID  `json:"coworkingId,omitempty"`
+	UserIDs     []uuid.UUID `json:"userIds,omitempty"`
+	Status      string      `json:"status"`
+	ScheduledAt time.Time   `json:"scheduledAt"`
+	CreatedBy   uuid.UUID   `json:"createdBy"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	SentAt      *time.Time  `json:"sentAt,omitempty"`
+	RetractedAt *time.Time  `json:"retractedAt,omitempty"`
+	// Только в ответе GET /admin/announcements/{announcementId}
+	Stats *AnnouncementStats `json:"stats,omitempty"`
+}
+
+func AnnouncementFromEntity(a entity.Announcement) Announcement {
+	return Announcement{
+		ID:          a.ID,
+		Title:       a.Title,
+		Body:        a.Body,
+		ActionURL:   a.ActionURL,
+		Target:      string(a.Target),
+		Role:        a.TargetRole,
+		CoworkingID: a.TargetCoworkingID,
+		UserIDs:     a.TargetUserIDs,
+		Status:      string(a.Status),
+		ScheduledAt: a.ScheduledAt,
+		CreatedBy:   a.CreatedBy,
+		CreatedAt:   a.CreatedAt,
+		SentAt:      a.SentAt,
+		RetractedAt: a.RetractedAt,
+	}
+}
+
+type AnnouncementsResponse struct {
+	Announcements []Announcement `json:"announcements"`
+	Total         int            `json:"total"`
+}
+
+// NotificationIDsRequest — выбор уведомлений для массовых действий
+type NotificationIDsRequest struct {
+	IDs []uuid.UUID `json:"ids" validate:"required,min=1,max=100"`
+}
+
+type NotificationsReadResponse struct {
+	Updated int `json:"updated"`
+}
+
+type NotificationsDeletedResponse struct {
+	Deleted int `json:"deleted"`
 }
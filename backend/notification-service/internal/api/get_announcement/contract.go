@@ -0,0 +1,12 @@
+package get_announcement
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type AnnouncementService interface {
+	Get(ctx context.Context, id uuid.UUID) (entity.Announcement, entity.AnnouncementStats, error)
+}
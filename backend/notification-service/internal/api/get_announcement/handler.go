@@ -0,0 +1,41 @@
+package get_announcement
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/notification-service/internal/api"
+	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	announcement_service "github.com/4udiwe/coworking/notification-service/internal/service/announcement"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s AnnouncementService
+}
+
+func New(s AnnouncementService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.AnnouncementRequest
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	announcement, stats, err := h.s.Get(ctx.Request().Context(), in.AnnouncementID)
+	if err != nil {
+		if errors.Is(err, announcement_service.ErrAnnouncementNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	response := dto.AnnouncementFromEntity(announcement)
+	response.Stats = &dto.AnnouncementStats{
+		Recipients: stats.Recipients,
+		Delivered:  stats.Delivered,
+		Read:       stats.Read,
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
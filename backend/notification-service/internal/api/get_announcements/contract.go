@@ -0,0 +1,11 @@
+package get_announcements
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+)
+
+type AnnouncementService interface {
+	List(ctx context.Context, limit, offset int) ([]entity.Announcement, int, error)
+}
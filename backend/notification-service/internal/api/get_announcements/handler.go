@@ -0,0 +1,37 @@
+package get_announcements
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/notification-service/internal/api"
+	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s AnnouncementService
+}
+
+func New(s AnnouncementService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.AnnouncementsQuery
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	announcements, total, err := h.s.List(ctx.Request().Context(), in.Limit, in.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	response := dto.AnnouncementsResponse{
+		Announcements: make([]dto.Announcement, 0, len(announcements)),
+		Total:         total,
+	}
+	for _, a := range announcements {
+		response.Announcements = append(response.Announcements, dto.AnnouncementFromEntity(a))
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
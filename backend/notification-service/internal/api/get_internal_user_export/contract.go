@@ -0,0 +1,12 @@
+package get_internal_user_export
+
+import (
+	"context"
+
+	notification_service "github.com/4udiwe/coworking/notification-service/internal/service/notification"
+	"github.com/google/uuid"
+)
+
+type NotificationService interface {
+	ExportUserData(ctx context.Context, userID uuid.UUID) (notification_service.UserData, error)
+}
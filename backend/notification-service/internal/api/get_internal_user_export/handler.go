@@ -0,0 +1,79 @@
+package get_internal_user_export
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/notification-service/internal/api"
+	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s NotificationService
+}
+
+// Выгрузка уведомлений и устройств пользователя для auth-service
+// (GET /users/me/export). Доступна только сервисному токену со scope users.export.
+func New(notificationService NotificationService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: notificationService})
+}
+
+type Request struct {
+	UserID uuid.UUID `param:"userId" validate:"required"`
+}
+
+// Сам push-токен не выгружается: он не нужен пользователю,
+// а файл выгрузки может оказаться у третьих лиц.
+type Device struct {
+	ID        uuid.UUID `json:"id"`
+	Platform  string    `json:"platform"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Response struct {
+	Notifications []dto.Notification `json:"notifications"`
+	// Непрочитанные уведомления, перенесённые в архив по сроку хранения
+	ArchivedNotifications []dto.Notification `json:"archivedNotifications"`
+	Devices               []Device           `json:"devices"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	data, err := h.s.ExportUserData(ctx.Request().Context(), in.UserID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, Response{
+		Notifications:         lo.Map(data.Notifications, toNotificationDTO),
+		ArchivedNotifications: lo.Map(data.ArchivedNotifications, toNotificationDTO),
+		Devices: lo.Map(data.Devices, func(d entity.UserDevice, _ int) Device {
+			return Device{
+				ID:        d.ID,
+				Platform:  d.Platform,
+				CreatedAt: d.CreatedAt,
+			}
+		}),
+	})
+}
+
+func toNotificationDTO(n entity.Notification, _ int) dto.Notification {
+	return dto.Notification{
+		ID:        n.ID,
+		UserID:    n.UserID,
+		Payload:   n.Payload,
+		Type:      string(n.Type),
+		Title:     n.Title,
+		Body:      n.Body,
+		ActionURL: n.ActionURL,
+		IsRead:    n.IsRead,
+		CreatedAt: n.CreatedAt,
+		ReadAt:    n.ReadAt,
+
+		DeliveredAt: n.DeliveredAt,
+	}
+}
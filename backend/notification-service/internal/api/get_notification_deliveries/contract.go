@@ -0,0 +1,12 @@
+package get_notification_deliveries
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type DeliveryService interface {
+	ListByNotification(ctx context.Context, notificationID uuid.UUID) (entity.Notification, []entity.PushDelivery, error)
+}
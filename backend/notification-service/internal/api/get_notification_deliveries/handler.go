@@ -0,0 +1,43 @@
+package get_notification_deliveries
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/notification-service/internal/api"
+	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	delivery_service "github.com/4udiwe/coworking/notification-service/internal/service/delivery"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s DeliveryService
+}
+
+func New(s DeliveryService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.NotificationDeliveriesRequest
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	notification, deliveries, err := h.s.ListByNotification(ctx.Request().Context(), in.NotificationID)
+	if err != nil {
+		if errors.Is(err, delivery_service.ErrNotificationNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	response := dto.NotificationDeliveriesResponse{
+		NotificationID: notification.ID,
+		DeliveredAt:    notification.DeliveredAt,
+		Deliveries:     make([]dto.PushDelivery, 0, len(deliveries)),
+	}
+	for _, d := range deliveries {
+		response.Deliveries = append(response.Deliveries, dto.PushDeliveryFromEntity(d))
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,18 @@
+package get_notification_stream
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/4udiwe/coworking/notification-service/internal/stream"
+	"github.com/google/uuid"
+)
+
+type NotificationService interface {
+	GetNotification(ctx context.Context, userID, notificationID uuid.UUID) (entity.Notification, error)
+	GetUnreadCount(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+type Hub interface {
+	Subscribe(userID uuid.UUID) (*stream.Subscription, error)
+}
@@ -0,0 +1,146 @@
+package get_notification_stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/4udiwe/coworking/notification-service/internal/api"
+	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/4udiwe/coworking/notification-service/internal/stream"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// Через сколько миллисекунд EventSource переподключается после обрыва
+const retryMillis = 5000
+
+type handler struct {
+	s         NotificationService
+	hub       Hub
+	heartbeat time.Duration
+}
+
+func New(notificationService NotificationService, hub Hub, heartbeat time.Duration) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{
+		s:         notificationService,
+		hub:       hub,
+		heartbeat: heartbeat,
+	})
+}
+
+type Request struct{}
+
+/*
+Handle держит поток Server-Sent Events:
+
+	event: unread_count  — {"unreadCount": n}, сразу после подключения и после каждого изменения
+	event: notification  — новое уведомление, формат как в GET /notifications
+
+Раз в heartbeat отправляется комментарий, чтобы прокси не закрывали соединение.
+*/
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	sub, err := h.hub.Subscribe(claims.UserID)
+	if err != nil {
+		if errors.Is(err, stream.ErrTooManyConnections) {
+			return echo.NewHTTPError(http.StatusTooManyRequests, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	defer sub.Close()
+
+	// Таймауты http.Server рассчитаны на обычные запросы, поток живёт дольше
+	rc := http.NewResponseController(ctx.Response().Writer)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w := ctx.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.Header().Set(echo.HeaderConnection, "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	reqCtx := ctx.Request().Context()
+	userID := claims.UserID
+
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", retryMillis); err != nil {
+		return nil
+	}
+	if err := h.sendUnreadCount(reqCtx, w, userID); err != nil {
+		return nil
+	}
+
+	logrus.WithField("user_id", userID.String()).Info("notification stream opened")
+	defer logrus.WithField("user_id", userID.String()).Info("notification stream closed")
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-reqCtx.Done():
+			return nil
+
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return nil
+			}
+			w.Flush()
+
+		case event, ok := <-sub.Events():
+			if !ok {
+				return nil
+			}
+
+			if event.Type == entity.StreamNotificationCreated && event.NotificationID != nil {
+				notification, err := h.s.GetNotification(reqCtx, userID, *event.NotificationID)
+				if err == nil {
+					if err := writeEvent(w, "notification", dto.NotificationFromEntity(notification)); err != nil {
+						return nil
+					}
+				}
+			}
+
+			if err := h.sendUnreadCount(reqCtx, w, userID); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+func (h *handler) sendUnreadCount(ctx context.Context, w *echo.Response, userID uuid.UUID) error {
+	count, err := h.s.GetUnreadCount(ctx, userID)
+	if err != nil {
+		// Счётчик придёт со следующим событием, поток не рвём
+		return nil
+	}
+
+	return writeEvent(w, "unread_count", dto.UnreadCountResponse{UnreadCount: count})
+}
+
+func writeEvent(w *echo.Response, name string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, payload); err != nil {
+		return err
+	}
+	w.Flush()
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package get_notification_stream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/4udiwe/coworking/notification-service/internal/stream"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type fakeService struct {
+	unread atomic.Int32
+}
+
+func (f *fakeService) GetNotification(_ context.Context, userID, notificationID uuid.UUID) (entity.Notification, error) {
+	return entity.Notification{ID: notificationID, UserID: userID, Title: "Бронирование создано"}, nil
+}
+
+func (f *fakeService) GetUnreadCount(context.Context, uuid.UUID) (int, error) {
+	return int(f.unread.Load()), nil
+}
+
+type sseEvent struct {
+	name string
+	data string
+}
+
+// readEvents читает события SSE, пропуская retry и комментарии
+func readEvents(r *bufio.Reader, out chan<- sseEvent) {
+	defer close(out)
+
+	var ev sseEvent
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\n")
+
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			ev.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			ev.data = strings.TrimPrefix(line, "data: ")
+		case line == "" && ev.name != "":
+			out <- ev
+			ev = sseEvent{}
+		}
+	}
+}
+
+func next(t *testing.T, events <-chan sseEvent) sseEvent {
+	t.Helper()
+	select {
+	case ev, ok := <-events:
+		if !ok {
+			t.Fatal("stream closed")
+		}
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event in time")
+	}
+	return sseEvent{}
+}
+
+func TestHandle_Stream(t *testing.T) {
+	userID := uuid.New()
+	// Один поток на пользователя: по Subscribe видно, занято ли место
+	hub := stream.NewHub(1)
+	svc := &fakeService{}
+	svc.unread.Store(2)
+
+	e := echo.New()
+	e.Validator = validator.NewCustomValidator()
+	e.GET("/notifications/stream", New(svc, hub, time.Hour).Handle, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(middleware.USER_CLAIMS_KEY, &jwt_validator.AccessClaims{UserID: userID})
+			return next(c)
+		}
+	})
+
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/notifications/stream", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET stream error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	events := make(chan sseEvent)
+	go readEvents(bufio.NewReader(resp.Body), events)
+
+	// Сразу после подключения — счётчик непрочитанных
+	if ev := next(t, events); ev.name != "unread_count" || ev.data != `{"unreadCount":2}` {
+		t.Fatalf("first event = %+v", ev)
+	}
+
+	// Событие другого пользователя в поток не попадает
+	hub.Publish(entity.StreamEvent{Type: entity.StreamAllRead, UserID: uuid.New()})
+
+	svc.unread.Store(3)
+	notificationID := uuid.New()
+	hub.Publish(entity.StreamEvent{Type: entity.StreamNotificationCreated, UserID: userID, NotificationID: &notificationID})
+
+	ev := next(t, events)
+	if ev.name != "notification" {
+		t.Fatalf("event = %+v, want notification", ev)
+	}
+	var notification dto.Notification
+	if err := json.Unmarshal([]byte(ev.data), &notification); err != nil || notification.ID != notificationID {
+		t.Errorf("notification = %s (%v)", ev.data, err)
+	}
+	if ev := next(t, events); ev.name != "unread_count" || ev.data != `{"unreadCount":3}` {
+		t.Errorf("event after notification = %+v", ev)
+	}
+
+	if _, err := hub.Subscribe(userID); !errors.Is(err, stream.ErrTooManyConnections) {
+		t.Fatalf("Subscribe() while streaming error = %v, want %v", err, stream.ErrTooManyConnections)
+	}
+
+	// Клиент отключился — подписка удаляется из хаба
+	cancel()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		sub, err := hub.Subscribe(userID)
+		if err == nil {
+			sub.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("subscription is not removed after disconnect: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,43 @@
+package get_notifications
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// Курсор — base64url от "created_at|id" последнего уведомления страницы
+func encodeCursor(c entity.NotificationCursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(s string) (entity.NotificationCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return entity.NotificationCursor{}, errInvalidCursor
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return entity.NotificationCursor{}, errInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return entity.NotificationCursor{}, errInvalidCursor
+	}
+
+	notificationID, err := uuid.Parse(id)
+	if err != nil {
+		return entity.NotificationCursor{}, errInvalidCursor
+	}
+
+	return entity.NotificationCursor{CreatedAt: t, ID: notificationID}, nil
+}
@@ -0,0 +1,80 @@
+package get_notifications
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	tests := []struct {
+		name   string
+		cursor entity.NotificationCursor
+	}{
+		{
+			name:   "utc",
+			cursor: entity.NotificationCursor{CreatedAt: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), ID: uuid.New()},
+		},
+		{
+			// Postgres хранит микросекунды: точность не должна теряться,
+			// иначе следующая страница повторит или пропустит записи
+			name:   "sub_second_precision",
+			cursor: entity.NotificationCursor{CreatedAt: time.Date(2026, 10, 19, 8, 30, 0, 123456000, time.UTC), ID: uuid.New()},
+		},
+		{
+			name:   "non_utc_zone",
+			cursor: entity.NotificationCursor{CreatedAt: time.Date(2026, 10, 19, 11, 30, 0, 1000, moscow), ID: uuid.New()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := encodeCursor(tt.cursor)
+
+			got, err := decodeCursor(encoded)
+			if err != nil {
+				t.Fatalf("decodeCursor(%q) error = %v", encoded, err)
+			}
+
+			if !got.CreatedAt.Equal(tt.cursor.CreatedAt) {
+				t.Errorf("CreatedAt = %s, want %s", got.CreatedAt, tt.cursor.CreatedAt)
+			}
+			if got.ID != tt.cursor.ID {
+				t.Errorf("ID = %s, want %s", got.ID, tt.cursor.ID)
+			}
+		})
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "empty", cursor: ""},
+		{name: "not_base64", cursor: "!!!"},
+		{name: "padded_base64", cursor: base64.URLEncoding.EncodeToString([]byte("2026-10-19T08:30:00.5Z|" + uuid.NewString()))},
+		{name: "missing_separator", cursor: encode("2026-10-19T08:30:00Z")},
+		{name: "bad_time", cursor: encode("yesterday|" + uuid.NewString())},
+		{name: "bad_uuid", cursor: encode("2026-10-19T08:30:00Z|not-a-uuid")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeCursor(tt.cursor)
+			if !errors.Is(err, errInvalidCursor) {
+				t.Errorf("decodeCursor(%q) error = %v, want %v", tt.cursor, err, errInvalidCursor)
+			}
+		})
+	}
+}
@@ -4,9 +4,9 @@ import (
 	"net/http"
 
 	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/4udiwe/coworking/notification-service/internal/api"
 	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
-	"github.com/4udiwe/coworking/notification-service/internal/api/middleware"
 	"github.com/4udiwe/coworking/notification-service/internal/entity"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/lo"
@@ -36,32 +36,38 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 		limit = NOTIFICATIONS_LIMIT
 	}
 
-	var notifications []entity.Notification
-
-	// If since parameter is provided, use date-based filtering
-	if in.Since != nil {
-		notifications, err = h.s.FetchNotificationsAfterDate(
-			ctx.Request().Context(),
-			claims.UserID,
-			*in.Since,
-			limit,
-			in.Offset,
-		)
-	} else {
-		// Otherwise use regular filtering
-		notifications, err = h.s.FetchNotifications(
-			ctx.Request().Context(),
-			claims.UserID,
-			limit,
-			in.Offset,
-			in.IsRead,
-		)
+	filter := entity.NotificationFilter{
+		IsRead: in.IsRead,
+		Types:  lo.Map(in.Types, func(t string, _ int) entity.NotificationType { return entity.NotificationType(t) }),
+		From:   in.From,
+		To:     in.To,
+		Limit:  limit,
+		Offset: in.Offset,
 	}
 
+	// since — прежнее имя from, оставлено для совместимости клиентов
+	if filter.From == nil {
+		filter.From = in.Since
+	}
+
+	if in.Cursor != "" {
+		cursor, err := decodeCursor(in.Cursor)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		filter.Cursor = &cursor
+	}
+
+	notifications, next, err := h.s.FetchNotifications(ctx.Request().Context(), claims.UserID, filter)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
+	var nextCursor *string
+	if next != nil {
+		nextCursor = lo.ToPtr(encodeCursor(*next))
+	}
+
 	return ctx.JSON(http.StatusOK, dto.NotificationsResponse{
 		Notifications: lo.Map(notifications, func(n entity.Notification, _ int) dto.Notification {
 			return dto.Notification{
@@ -75,7 +81,10 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 				IsRead:    n.IsRead,
 				CreatedAt: n.CreatedAt,
 				ReadAt:    n.ReadAt,
+
+				DeliveredAt: n.DeliveredAt,
 			}
 		}),
+		NextCursor: nextCursor,
 	})
 }
@@ -0,0 +1,12 @@
+package get_preferences
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type NotificationService interface {
+	GetPreferences(ctx context.Context, userID uuid.UUID) (entity.NotificationPreferences, error)
+}
@@ -0,0 +1,11 @@
+package get_templates
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+)
+
+type TemplateService interface {
+	List(ctx context.Context) ([]entity.NotificationTemplate, error)
+}
@@ -0,0 +1,36 @@
+package get_templates
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/notification-service/internal/api"
+	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s TemplateService
+}
+
+func New(s TemplateService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct{}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	templates, err := h.s.List(ctx.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	response := dto.NotificationTemplatesResponse{
+		Templates: make([]dto.NotificationTemplate, 0, len(templates)),
+	}
+	for _, t := range templates {
+		response.Templates = append(response.Templates, dto.NotificationTemplateFromEntity(t))
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,32 @@
+package get_webpush_public_key
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/notification-service/internal/api"
+	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	"github.com/labstack/echo/v4"
+)
+
+// Публичный ключ VAPID нужен браузеру для pushManager.subscribe.
+// Пустой ключ означает, что Web Push выключен.
+type handler struct {
+	publicKey string
+}
+
+func New(publicKey string) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{publicKey: publicKey})
+}
+
+type Request struct{}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	if h.publicKey == "" {
+		return echo.NewHTTPError(http.StatusNotFound, "web push is disabled")
+	}
+
+	return ctx.JSON(http.StatusOK, dto.WebPushPublicKeyResponse{
+		PublicKey: h.publicKey,
+	})
+}
@@ -1,11 +1,13 @@
 package patch_notification
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
 	"github.com/4udiwe/coworking/notification-service/internal/api"
 	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	notification_service "github.com/4udiwe/coworking/notification-service/internal/service/notification"
 	"github.com/labstack/echo/v4"
 )
 
@@ -24,6 +26,9 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 	err := h.s.MarkRead(ctx.Request().Context(), in.NotificationID)
 
 	if err != nil {
+		if errors.Is(err, notification_service.ErrNotificationNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 	return ctx.NoContent(http.StatusAccepted)
@@ -0,0 +1,11 @@
+package patch_notifications_read
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type NotificationService interface {
+	MarkReadBatch(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int, error)
+}
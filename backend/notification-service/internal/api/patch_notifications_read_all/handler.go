@@ -4,8 +4,8 @@ import (
 	"net/http"
 
 	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/4udiwe/coworking/notification-service/internal/api"
-	"github.com/4udiwe/coworking/notification-service/internal/api/middleware"
 	"github.com/labstack/echo/v4"
 )
 
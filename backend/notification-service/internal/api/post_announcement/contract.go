@@ -0,0 +1,11 @@
+package post_announcement
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+)
+
+type AnnouncementService interface {
+	Create(ctx context.Context, announcement entity.Announcement) (entity.Announcement, error)
+}
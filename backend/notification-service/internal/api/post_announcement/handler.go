@@ -0,0 +1,52 @@
+package post_announcement
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/4udiwe/coworking/notification-service/internal/api"
+	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	announcement_service "github.com/4udiwe/coworking/notification-service/internal/service/announcement"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s AnnouncementService
+}
+
+func New(s AnnouncementService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.CreateAnnouncementRequest
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	announcement, err := h.s.Create(ctx.Request().Context(), entity.Announcement{
+		Title:             in.Title,
+		Body:              in.Body,
+		ActionURL:         in.ActionURL,
+		Target:            entity.AnnouncementTarget(in.Target),
+		TargetRole:        in.Role,
+		TargetCoworkingID: in.CoworkingID,
+		TargetUserIDs:     lo.Uniq(in.UserIDs),
+		ScheduledAt:       lo.FromPtr(in.ScheduledAt),
+		CreatedBy:         claims.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, announcement_service.ErrInvalidAnnouncement) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusCreated, dto.AnnouncementFromEntity(announcement))
+}
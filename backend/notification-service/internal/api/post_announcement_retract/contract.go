@@ -0,0 +1,12 @@
+package post_announcement_retract
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type AnnouncementService interface {
+	Retract(ctx context.Context, id uuid.UUID) (entity.Announcement, error)
+}
@@ -0,0 +1,37 @@
+package post_announcement_retract
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/notification-service/internal/api"
+	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	announcement_service "github.com/4udiwe/coworking/notification-service/internal/service/announcement"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s AnnouncementService
+}
+
+func New(s AnnouncementService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.AnnouncementRequest
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	announcement, err := h.s.Retract(ctx.Request().Context(), in.AnnouncementID)
+	if err != nil {
+		switch {
+		case errors.Is(err, announcement_service.ErrAnnouncementNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, announcement_service.ErrAnnouncementRetracted):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, dto.AnnouncementFromEntity(announcement))
+}
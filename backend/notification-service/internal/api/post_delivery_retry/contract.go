@@ -0,0 +1,12 @@
+package post_delivery_retry
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type DeliveryService interface {
+	Retry(ctx context.Context, deliveryID uuid.UUID) (entity.PushDelivery, error)
+}
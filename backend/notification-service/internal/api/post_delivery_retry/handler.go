@@ -0,0 +1,37 @@
+package post_delivery_retry
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/notification-service/internal/api"
+	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	delivery_service "github.com/4udiwe/coworking/notification-service/internal/service/delivery"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s DeliveryService
+}
+
+func New(s DeliveryService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.RetryDeliveryRequest
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	delivery, err := h.s.Retry(ctx.Request().Context(), in.DeliveryID)
+	if err != nil {
+		switch {
+		case errors.Is(err, delivery_service.ErrDeliveryNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, delivery_service.ErrDeliveryNotDead):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusAccepted, dto.PushDeliveryFromEntity(delivery))
+}
@@ -3,9 +3,11 @@ package post_device
 import (
 	"context"
 
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
 	"github.com/google/uuid"
 )
 
 type NotificationService interface {
 	RegisterDevice(ctx context.Context, userID uuid.UUID, deviceToken string, platform string) error
+	RegisterWebPushSubscription(ctx context.Context, userID uuid.UUID, subscription entity.WebPushSubscription) error
 }
@@ -4,9 +4,10 @@ import (
 	"net/http"
 
 	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/4udiwe/coworking/notification-service/internal/api"
 	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
-	"github.com/4udiwe/coworking/notification-service/internal/api/middleware"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
 	"github.com/labstack/echo/v4"
 )
 
@@ -26,7 +27,15 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
 	}
 
-	err = h.s.RegisterDevice(ctx.Request().Context(), claims.UserID, in.DeviceToken, in.Platform)
+	if in.Platform == entity.PlatformWeb {
+		err = h.s.RegisterWebPushSubscription(ctx.Request().Context(), claims.UserID, entity.WebPushSubscription{
+			Endpoint: in.Subscription.Endpoint,
+			P256dh:   in.Subscription.Keys.P256dh,
+			Auth:     in.Subscription.Keys.Auth,
+		})
+	} else {
+		err = h.s.RegisterDevice(ctx.Request().Context(), claims.UserID, in.DeviceToken, in.Platform)
+	}
 
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
@@ -0,0 +1,11 @@
+package put_preferences
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+)
+
+type NotificationService interface {
+	UpdatePreferences(ctx context.Context, prefs entity.NotificationPreferences) (entity.NotificationPreferences, error)
+}
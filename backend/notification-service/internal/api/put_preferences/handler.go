@@ -0,0 +1,45 @@
+package put_preferences
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/4udiwe/coworking/notification-service/internal/api"
+	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	notification_service "github.com/4udiwe/coworking/notification-service/internal/service/notification"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s NotificationService
+}
+
+func New(notificationService NotificationService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: notificationService})
+}
+
+type Request = dto.Preferences
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	prefs, err := in.ToEntity(claims.UserID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	prefs, err = h.s.UpdatePreferences(ctx.Request().Context(), prefs)
+	if err != nil {
+		if errors.Is(err, notification_service.ErrInvalidPreferences) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, dto.PreferencesFromEntity(prefs))
+}
@@ -0,0 +1,11 @@
+package put_template
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+)
+
+type TemplateService interface {
+	Update(ctx context.Context, template entity.NotificationTemplate) (entity.NotificationTemplate, error)
+}
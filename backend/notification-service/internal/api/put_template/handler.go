@@ -0,0 +1,50 @@
+package put_template
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/4udiwe/coworking/notification-service/internal/api"
+	"github.com/4udiwe/coworking/notification-service/internal/api/dto"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	template_service "github.com/4udiwe/coworking/notification-service/internal/service/template"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s TemplateService
+}
+
+func New(s TemplateService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.UpdateTemplateRequest
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	template, err := h.s.Update(ctx.Request().Context(), entity.NotificationTemplate{
+		Type:      entity.NotificationType(in.Type),
+		Locale:    entity.Locale(in.Locale),
+		Title:     in.Title,
+		Body:      in.Body,
+		UpdatedBy: &claims.UserID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, template_service.ErrUnknownTemplate):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, template_service.ErrInvalidTemplate):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, dto.NotificationTemplateFromEntity(template))
+}
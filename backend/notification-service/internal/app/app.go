@@ -4,25 +4,44 @@ import (
 	"context"
 	"crypto/rsa"
 	"os"
+	"time"
 
 	"github.com/4udiwe/avito-pvz/pkg/httpserver"
 	"github.com/4udiwe/avito-pvz/pkg/postgres"
 	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
 	"github.com/4udiwe/big-bob-pizza/order-service/pkg/outbox"
+	"github.com/4udiwe/coworking/auth-service/pkg/authclient"
+	"github.com/4udiwe/coworking/auth-service/pkg/inbox"
 	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/4udiwe/coworking/notification-service/config"
 	"github.com/4udiwe/coworking/notification-service/internal/api"
-	"github.com/4udiwe/coworking/notification-service/internal/api/middleware"
 	notification_builder "github.com/4udiwe/coworking/notification-service/internal/builder"
+	booking_client "github.com/4udiwe/coworking/notification-service/internal/client/booking"
+	consumer_auth "github.com/4udiwe/coworking/notification-service/internal/consumer/auth"
+	consumer_auth_mail "github.com/4udiwe/coworking/notification-service/internal/consumer/auth_mail"
 	consumer_booking "github.com/4udiwe/coworking/notification-service/internal/consumer/booking"
 	consumer_notification "github.com/4udiwe/coworking/notification-service/internal/consumer/notification"
 	consumer_scheduler "github.com/4udiwe/coworking/notification-service/internal/consumer/scheduler"
 	database "github.com/4udiwe/coworking/notification-service/internal/database/migrations"
+	announcement_repository "github.com/4udiwe/coworking/notification-service/internal/repository/announcement"
+	contact_repository "github.com/4udiwe/coworking/notification-service/internal/repository/contact"
+	delivery_repository "github.com/4udiwe/coworking/notification-service/internal/repository/delivery"
 	device_repository "github.com/4udiwe/coworking/notification-service/internal/repository/device"
+	digest_repository "github.com/4udiwe/coworking/notification-service/internal/repository/digest"
 	notification_repository "github.com/4udiwe/coworking/notification-service/internal/repository/notification"
 	outbox_repository "github.com/4udiwe/coworking/notification-service/internal/repository/outbox"
+	preferences_repository "github.com/4udiwe/coworking/notification-service/internal/repository/preferences"
+	template_repository "github.com/4udiwe/coworking/notification-service/internal/repository/template"
+	email_sender "github.com/4udiwe/coworking/notification-service/internal/sender/email"
 	firebase_sender "github.com/4udiwe/coworking/notification-service/internal/sender/firebase"
+	webpush_sender "github.com/4udiwe/coworking/notification-service/internal/sender/webpush"
+	announcement_service "github.com/4udiwe/coworking/notification-service/internal/service/announcement"
+	delivery_service "github.com/4udiwe/coworking/notification-service/internal/service/delivery"
+	digest_service "github.com/4udiwe/coworking/notification-service/internal/service/digest"
 	notification_service "github.com/4udiwe/coworking/notification-service/internal/service/notification"
+	template_service "github.com/4udiwe/coworking/notification-service/internal/service/template"
+	"github.com/4udiwe/coworking/notification-service/internal/stream"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/log"
 )
@@ -41,35 +60,98 @@ type App struct {
 	deviceRepo       *device_repository.DeviceRepository
 	notificationRepo *notification_repository.NotificationRepository
 	outboxRepo       *outbox_repository.Repository
+	timerOutboxRepo  *outbox_repository.Repository
+	announcementRepo *announcement_repository.AnnouncementRepository
+	digestRepo       *digest_repository.DigestRepository
+
+	contactRepo     *contact_repository.ContactRepository
+	preferencesRepo *preferences_repository.PreferencesRepository
+	templateRepo    *template_repository.TemplateRepository
+	deliveryRepo    *delivery_repository.DeliveryRepository
 
 	// Services
 	notificationService *notification_service.NotificationService
+	templateService     *template_service.TemplateService
+	deliveryService     *delivery_service.DeliveryService
+	announcementService *announcement_service.AnnouncementService
+	digestService       *digest_service.DigestService
 
 	// Handlers
 	getNotificationsHandler  api.Handler
 	patchNotificationHandler api.Handler
 	postDeviceHandler        api.Handler
 
+	getPreferencesHandler api.Handler
+	putPreferencesHandler api.Handler
+
+	getNotificationStreamHandler api.Handler
+
+	getTemplatesHandler   api.Handler
+	putTemplateHandler    api.Handler
+	deleteTemplateHandler api.Handler
+
+	getNotificationDeliveriesHandler api.Handler
+	postDeliveryRetryHandler         api.Handler
+
+	getInternalUserExportHandler api.Handler
+
+	postAnnouncementHandler        api.Handler
+	getAnnouncementsHandler        api.Handler
+	getAnnouncementHandler         api.Handler
+	postAnnouncementRetractHandler api.Handler
+
+	patchNotificationsReadHandler api.Handler
+	deleteNotificationsHandler    api.Handler
+
 	// Consumer
 	schedulerConsumer    *consumer_scheduler.Consumer
 	bookingConsumer      *consumer_booking.Consumer
 	notificationConsumer *consumer_notification.Consumer
+	authConsumer         *consumer_auth.Consumer
+	authMailConsumer     *consumer_auth_mail.Consumer
+
+	// Inbox
+	inbox *inbox.Inbox
+
+	// Stream
+	streamHub       *stream.Hub
+	streamListener  *stream.Listener
+	streamPublisher *stream.Publisher
 
 	// Push sender
-	pushSender *firebase_sender.FirebaseSender
+	pushSender      *firebase_sender.FirebaseSender
+	pushRetryWorker *firebase_sender.RetryWorker
+
+	// Email sender
+	emailDispatcher *email_sender.Dispatcher
+	accountMailer   *email_sender.AccountMailer
+	smtpSender      *email_sender.SMTPSender
+	emailRenderer   *email_sender.Renderer
+	emailLocation   *time.Location
+
+	// Web Push sender
+	webPushDispatcher *webpush_sender.Dispatcher
 
 	// Notification builder
 	notificationBuilder *notification_builder.DefaultBuilder
+	templateRegistry    *notification_builder.TemplateRegistry
 
 	// Outbox
-	OutboxWorker *outbox.Worker
+	OutboxWorker      *outbox.Worker
+	TimerOutboxWorker *outbox.Worker
 
 	// Middleware
 	authMW *middleware.AuthMiddleware
 
 	// Auth
-	PublicKey    *rsa.PublicKey
-	jwtValidator *jwt_validator.Validator
+	PublicKey          *rsa.PublicKey
+	jwtValidator       *jwt_validator.Validator
+	denylist           *jwt_validator.Denylist
+	revocationListener *jwt_validator.RevocationListener
+
+	// Clients
+	authClient    *authclient.Client
+	bookingClient *booking_client.Client
 }
 
 func New(configPath string) *App {
@@ -114,36 +196,62 @@ func (app *App) Start() {
 		log.Errorf("app - Start - PushSender create failed: %v", err)
 	}
 
+	// Публикует outbox и перекладывает сообщения в retry-топики и DLQ
+	kafkaPublisher := kafka.NewKafkaPublisher(app.cfg.Kafka.Brokers)
+
 	// Consumers
-	schedulerKafkaConsumer := kafka.NewConsumer(app.cfg.Kafka.Brokers)
 	app.schedulerConsumer = consumer_scheduler.New(
 		app.NotificationService(),
+		app.DigestService(),
 		app.NotificationBuilder(),
-		schedulerKafkaConsumer,
+		app.Inbox(),
+		app.RetryingConsumer(kafkaPublisher),
 		app.cfg.Kafka.Topics.SchedulerEvents,
 		app.cfg.Kafka.Consumer.GroupID,
 	)
 
-	bookingKafkaConsumer := kafka.NewConsumer(app.cfg.Kafka.Brokers)
 	app.bookingConsumer = consumer_booking.New(
 		app.NotificationService(),
+		app.DigestService(),
 		app.NotificationBuilder(),
-		bookingKafkaConsumer,
+		app.Inbox(),
+		app.RetryingConsumer(kafkaPublisher),
 		app.cfg.Kafka.Topics.BookingEvents,
 		app.cfg.Kafka.Consumer.GroupID,
 	)
 
-	notificationKafkaConsumer := kafka.NewConsumer(app.cfg.Kafka.Brokers)
 	app.notificationConsumer = consumer_notification.New(
 		app.NotificationService(),
-		notificationKafkaConsumer,
+		app.AnnouncementService(),
+		app.DigestService(),
+		app.Inbox(),
+		app.RetryingConsumer(kafkaPublisher),
 		app.cfg.Kafka.Topics.NotificationEvents,
 		app.cfg.Kafka.Consumer.GroupID,
 	)
 
-	// Outbox publisher
-	kafkaPublisher := kafka.NewKafkaPublisher(app.cfg.Kafka.Brokers)
+	app.authConsumer = consumer_auth.New(
+		app.NotificationService(),
+		app.Inbox(),
+		app.RetryingConsumer(kafkaPublisher),
+		app.cfg.Kafka.Topics.AuthEvents,
+		app.cfg.Kafka.Consumer.GroupID,
+	)
+
+	// Письма со ссылками подтверждения из auth-service уходят только по email
+	if app.cfg.Email.Enabled {
+		app.authMailConsumer = consumer_auth_mail.New(
+			app.AccountMailer(),
+			app.Inbox(),
+			app.RetryingConsumer(kafkaPublisher),
+			app.cfg.Kafka.Topics.AuthMail,
+			app.cfg.Kafka.Consumer.GroupID,
+		)
+	} else {
+		log.Warn("app - Start - email disabled, account emails from auth.mail are not delivered")
+	}
 
+	// Outbox publisher
 	app.OutboxWorker = outbox.NewWorker(
 		app.OutboxRepo(),
 		kafkaPublisher,
@@ -154,6 +262,25 @@ func (app *App) Start() {
 		app.cfg.Outbox.RequeInterval,
 	)
 
+	// Запросы таймеров scheduler-service (отложенные рассылки)
+	app.TimerOutboxWorker = outbox.NewWorker(
+		app.TimerOutboxRepo(),
+		kafkaPublisher,
+		app.cfg.Kafka.Topics.SchedulerTimers,
+		app.cfg.Outbox.BatchLimit,
+		app.cfg.Outbox.RequeBatchLimit,
+		app.cfg.Outbox.Interval,
+		app.cfg.Outbox.RequeInterval,
+	)
+
+	// Token revocation: auth.events -> local denylist
+	app.revocationListener = jwt_validator.NewRevocationListener(
+		app.Denylist(),
+		kafka.NewConsumer(app.cfg.Kafka.Brokers),
+		app.cfg.Kafka.Topics.AuthEvents,
+		app.cfg.Kafka.Consumer.GroupID,
+	)
+
 	// App server
 	log.Info("Starting app server...")
 	httpServer := httpserver.New(app.EchoHandler(), httpserver.Port(app.cfg.HTTP.Port))
@@ -164,7 +291,16 @@ func (app *App) Start() {
 	app.schedulerConsumer.Run(ctx)
 	app.bookingConsumer.Run(ctx)
 	app.notificationConsumer.Run(ctx)
+	app.authConsumer.Run(ctx)
+	if app.authMailConsumer != nil {
+		app.authMailConsumer.Run(ctx)
+	}
 	app.OutboxWorker.Run(ctx)
+	app.TimerOutboxWorker.Run(ctx)
+	app.revocationListener.Run(ctx)
+	app.StreamListener().Run(ctx)
+	app.PushRetryWorker().Run(ctx)
+	app.Inbox().Run(ctx, time.Hour, app.cfg.Kafka.Consumer.InboxRetention)
 
 	select {
 	case s := <-app.interrupt:
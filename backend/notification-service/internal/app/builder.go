@@ -1,11 +1,28 @@
 package app
 
-import notification_builder "github.com/4udiwe/coworking/notification-service/internal/builder"
+import (
+	notification_builder "github.com/4udiwe/coworking/notification-service/internal/builder"
+	"github.com/sirupsen/logrus"
+)
 
 func (app *App) NotificationBuilder() *notification_builder.DefaultBuilder {
 	if app.notificationBuilder != nil {
 		return app.notificationBuilder
 	}
-	app.notificationBuilder = notification_builder.New()
+	app.notificationBuilder = notification_builder.New(app.TemplateRegistry(), app.NotificationService())
 	return app.notificationBuilder
 }
+
+func (app *App) TemplateRegistry() *notification_builder.TemplateRegistry {
+	if app.templateRegistry != nil {
+		return app.templateRegistry
+	}
+
+	registry, err := notification_builder.NewTemplateRegistry(app.TemplateRepo(), app.cfg.Templates.RefreshInterval)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to parse notification templates")
+	}
+
+	app.templateRegistry = registry
+	return app.templateRegistry
+}
@@ -2,9 +2,15 @@ package app
 
 import (
 	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	announcement_repository "github.com/4udiwe/coworking/notification-service/internal/repository/announcement"
+	contact_repository "github.com/4udiwe/coworking/notification-service/internal/repository/contact"
+	delivery_repository "github.com/4udiwe/coworking/notification-service/internal/repository/delivery"
 	device_repository "github.com/4udiwe/coworking/notification-service/internal/repository/device"
+	digest_repository "github.com/4udiwe/coworking/notification-service/internal/repository/digest"
 	notification_repository "github.com/4udiwe/coworking/notification-service/internal/repository/notification"
 	outbox_repository "github.com/4udiwe/coworking/notification-service/internal/repository/outbox"
+	preferences_repository "github.com/4udiwe/coworking/notification-service/internal/repository/preferences"
+	template_repository "github.com/4udiwe/coworking/notification-service/internal/repository/template"
 )
 
 func (app *App) Postgres() *postgres.Postgres {
@@ -34,3 +40,60 @@ func (app *App) OutboxRepo() *outbox_repository.Repository {
 	app.outboxRepo = outbox_repository.New(app.Postgres())
 	return app.outboxRepo
 }
+
+// TimerOutboxRepo — события outbox для scheduler.timers, их публикует отдельный воркер
+func (app *App) TimerOutboxRepo() *outbox_repository.Repository {
+	if app.timerOutboxRepo != nil {
+		return app.timerOutboxRepo
+	}
+	app.timerOutboxRepo = outbox_repository.NewForTopic(app.Postgres(), app.cfg.Kafka.Topics.SchedulerTimers)
+	return app.timerOutboxRepo
+}
+
+func (app *App) AnnouncementRepo() *announcement_repository.AnnouncementRepository {
+	if app.announcementRepo != nil {
+		return app.announcementRepo
+	}
+	app.announcementRepo = announcement_repository.New(app.Postgres())
+	return app.announcementRepo
+}
+
+func (app *App) DigestRepo() *digest_repository.DigestRepository {
+	if app.digestRepo != nil {
+		return app.digestRepo
+	}
+	app.digestRepo = digest_repository.New(app.Postgres())
+	return app.digestRepo
+}
+
+func (app *App) ContactRepo() *contact_repository.ContactRepository {
+	if app.contactRepo != nil {
+		return app.contactRepo
+	}
+	app.contactRepo = contact_repository.New(app.Postgres())
+	return app.contactRepo
+}
+
+func (app *App) PreferencesRepo() *preferences_repository.PreferencesRepository {
+	if app.preferencesRepo != nil {
+		return app.preferencesRepo
+	}
+	app.preferencesRepo = preferences_repository.New(app.Postgres())
+	return app.preferencesRepo
+}
+
+func (app *App) TemplateRepo() *template_repository.TemplateRepository {
+	if app.templateRepo != nil {
+		return app.templateRepo
+	}
+	app.templateRepo = template_repository.New(app.Postgres())
+	return app.templateRepo
+}
+
+func (app *App) DeliveryRepo() *delivery_repository.DeliveryRepository {
+	if app.deliveryRepo != nil {
+		return app.deliveryRepo
+	}
+	app.deliveryRepo = delivery_repository.New(app.Postgres())
+	return app.deliveryRepo
+}
@@ -2,11 +2,27 @@ package app
 
 import (
 	"github.com/4udiwe/coworking/notification-service/internal/api"
+	"github.com/4udiwe/coworking/notification-service/internal/api/delete_notifications"
+	"github.com/4udiwe/coworking/notification-service/internal/api/delete_template"
+	"github.com/4udiwe/coworking/notification-service/internal/api/get_announcement"
+	"github.com/4udiwe/coworking/notification-service/internal/api/get_announcements"
+	"github.com/4udiwe/coworking/notification-service/internal/api/get_internal_user_export"
+	"github.com/4udiwe/coworking/notification-service/internal/api/get_notification_deliveries"
+	"github.com/4udiwe/coworking/notification-service/internal/api/get_notification_stream"
 	"github.com/4udiwe/coworking/notification-service/internal/api/get_notifications"
+	"github.com/4udiwe/coworking/notification-service/internal/api/get_preferences"
+	"github.com/4udiwe/coworking/notification-service/internal/api/get_templates"
 	"github.com/4udiwe/coworking/notification-service/internal/api/get_unread_count"
+	"github.com/4udiwe/coworking/notification-service/internal/api/get_webpush_public_key"
 	"github.com/4udiwe/coworking/notification-service/internal/api/patch_notification"
+	"github.com/4udiwe/coworking/notification-service/internal/api/patch_notifications_read"
 	"github.com/4udiwe/coworking/notification-service/internal/api/patch_notifications_read_all"
+	"github.com/4udiwe/coworking/notification-service/internal/api/post_announcement"
+	"github.com/4udiwe/coworking/notification-service/internal/api/post_announcement_retract"
+	"github.com/4udiwe/coworking/notification-service/internal/api/post_delivery_retry"
 	"github.com/4udiwe/coworking/notification-service/internal/api/post_device"
+	"github.com/4udiwe/coworking/notification-service/internal/api/put_preferences"
+	"github.com/4udiwe/coworking/notification-service/internal/api/put_template"
 )
 
 func (app *App) GetNotificationsHandler() api.Handler {
@@ -33,6 +49,22 @@ func (app *App) PatchNotificationsReadAllHandler() api.Handler {
 	return patch_notifications_read_all.New(app.NotificationService())
 }
 
+func (app *App) PatchNotificationsReadHandler() api.Handler {
+	if app.patchNotificationsReadHandler != nil {
+		return app.patchNotificationsReadHandler
+	}
+	app.patchNotificationsReadHandler = patch_notifications_read.New(app.NotificationService())
+	return app.patchNotificationsReadHandler
+}
+
+func (app *App) DeleteNotificationsHandler() api.Handler {
+	if app.deleteNotificationsHandler != nil {
+		return app.deleteNotificationsHandler
+	}
+	app.deleteNotificationsHandler = delete_notifications.New(app.NotificationService())
+	return app.deleteNotificationsHandler
+}
+
 func (app *App) PostDeviceHandler() api.Handler {
 	if app.postDeviceHandler != nil {
 		return app.postDeviceHandler
@@ -40,3 +72,120 @@ func (app *App) PostDeviceHandler() api.Handler {
 	app.postDeviceHandler = post_device.New(app.NotificationService())
 	return app.postDeviceHandler
 }
+
+// Ключ отдаётся, только если Web Push включён
+func (app *App) GetWebPushPublicKeyHandler() api.Handler {
+	var publicKey string
+	if app.cfg.WebPush.Enabled {
+		publicKey = app.cfg.WebPush.VAPIDPublicKey
+	}
+	return get_webpush_public_key.New(publicKey)
+}
+
+func (app *App) GetInternalUserExportHandler() api.Handler {
+	if app.getInternalUserExportHandler != nil {
+		return app.getInternalUserExportHandler
+	}
+	app.getInternalUserExportHandler = get_internal_user_export.New(app.NotificationService())
+	return app.getInternalUserExportHandler
+}
+
+func (app *App) GetPreferencesHandler() api.Handler {
+	if app.getPreferencesHandler != nil {
+		return app.getPreferencesHandler
+	}
+	app.getPreferencesHandler = get_preferences.New(app.NotificationService())
+	return app.getPreferencesHandler
+}
+
+func (app *App) PutPreferencesHandler() api.Handler {
+	if app.putPreferencesHandler != nil {
+		return app.putPreferencesHandler
+	}
+	app.putPreferencesHandler = put_preferences.New(app.NotificationService())
+	return app.putPreferencesHandler
+}
+
+func (app *App) GetNotificationStreamHandler() api.Handler {
+	if app.getNotificationStreamHandler != nil {
+		return app.getNotificationStreamHandler
+	}
+	app.getNotificationStreamHandler = get_notification_stream.New(
+		app.NotificationService(),
+		app.StreamHub(),
+		app.cfg.Stream.HeartbeatInterval,
+	)
+	return app.getNotificationStreamHandler
+}
+
+func (app *App) GetTemplatesHandler() api.Handler {
+	if app.getTemplatesHandler != nil {
+		return app.getTemplatesHandler
+	}
+	app.getTemplatesHandler = get_templates.New(app.TemplateService())
+	return app.getTemplatesHandler
+}
+
+func (app *App) PutTemplateHandler() api.Handler {
+	if app.putTemplateHandler != nil {
+		return app.putTemplateHandler
+	}
+	app.putTemplateHandler = put_template.New(app.TemplateService())
+	return app.putTemplateHandler
+}
+
+func (app *App) DeleteTemplateHandler() api.Handler {
+	if app.deleteTemplateHandler != nil {
+		return app.deleteTemplateHandler
+	}
+	app.deleteTemplateHandler = delete_template.New(app.TemplateService())
+	return app.deleteTemplateHandler
+}
+
+func (app *App) GetNotificationDeliveriesHandler() api.Handler {
+	if app.getNotificationDeliveriesHandler != nil {
+		return app.getNotificationDeliveriesHandler
+	}
+	app.getNotificationDeliveriesHandler = get_notification_deliveries.New(app.DeliveryService())
+	return app.getNotificationDeliveriesHandler
+}
+
+func (app *App) PostDeliveryRetryHandler() api.Handler {
+	if app.postDeliveryRetryHandler != nil {
+		return app.postDeliveryRetryHandler
+	}
+	app.postDeliveryRetryHandler = post_delivery_retry.New(app.DeliveryService())
+	return app.postDeliveryRetryHandler
+}
+
+func (app *App) PostAnnouncementHandler() api.Handler {
+	if app.postAnnouncementHandler != nil {
+		return app.postAnnouncementHandler
+	}
+	app.postAnnouncementHandler = post_announcement.New(app.AnnouncementService())
+	return app.postAnnouncementHandler
+}
+
+func (app *App) GetAnnouncementsHandler() api.Handler {
+	if app.getAnnouncementsHandler != nil {
+		return app.getAnnouncementsHandler
+	}
+	app.getAnnouncementsHandler = get_announcements.New(app.AnnouncementService())
+	return app.getAnnouncementsHandler
+}
+
+func (app *App) GetAnnouncementHandler() api.Handler {
+	if app.getAnnouncementHandler != nil {
+		return app.getAnnouncementHandler
+	}
+	app.getAnnouncementHandler = get_announcement.New(app.AnnouncementService())
+	return app.getAnnouncementHandler
+}
+
+func (app *App) PostAnnouncementRetractHandler() api.Handler {
+	if app.postAnnouncementRetractHandler != nil {
+		return app.postAnnouncementRetractHandler
+	}
+	app.postAnnouncementRetractHandler = post_announcement_retract.New(app.AnnouncementService())
+	return app.postAnnouncementRetractHandler
+}
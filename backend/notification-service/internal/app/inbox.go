@@ -0,0 +1,30 @@
+package app
+
+import (
+	"github.com/4udiwe/coworking/auth-service/pkg/inbox"
+	"github.com/4udiwe/coworking/auth-service/pkg/retry"
+	"github.com/4udiwe/coworking/auth-service/pkg/transactor"
+)
+
+func (app *App) Inbox() *inbox.Inbox {
+	if app.inbox != nil {
+		return app.inbox
+	}
+	app.inbox = inbox.New(app.Postgres())
+	return app.inbox
+}
+
+// TxManager присоединяется к транзакции Inbox, если обработка идёт из consumer'а
+func (app *App) TxManager() *transactor.Joining {
+	return transactor.NewJoining(app.Postgres())
+}
+
+// RetryingConsumer — отдельный экземпляр на каждый топик: у kafka.KafkaConsumer один reader
+func (app *App) RetryingConsumer(publisher retry.Publisher) *retry.Consumer {
+	cfg := app.cfg.Kafka.Consumer
+	return retry.NewConsumer(app.cfg.Kafka.Brokers, publisher, retry.Policy{
+		MaxAttempts: cfg.RetryMaxAttempts,
+		Backoff:     cfg.RetryBackoff,
+		MaxBackoff:  cfg.RetryMaxBackoff,
+	})
+}
@@ -3,8 +3,11 @@ package app
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/4udiwe/avito-pvz/pkg/validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/labstack/echo/v4"
 )
 
@@ -27,16 +30,60 @@ func (app *App) EchoHandler() *echo.Echo {
 }
 
 func (app *App) configureRouter(handler *echo.Echo) {
-	handler.Use(app.AuthMiddleware().Middleware)
+	authMiddleware := app.AuthMiddleware()
+	handler.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// Внутренние маршруты проверяют сервисный токен сами
+			if strings.HasPrefix(c.Request().URL.Path, "/internal/") {
+				return next(c)
+			}
+			return authMiddleware.Middleware(next)(c)
+		}
+	})
 
 	notificationGroup := handler.Group("/notifications")
 	{
 		notificationGroup.GET("", app.GetNotificationsHandler().Handle)
 		notificationGroup.GET("/unread-count", app.GetUnreadCountHandler().Handle)
+		notificationGroup.GET("/stream", app.GetNotificationStreamHandler().Handle)
 		notificationGroup.PATCH("/:notificationID", app.PatchNotificationHandler().Handle)
 		notificationGroup.PATCH("/read-all", app.PatchNotificationsReadAllHandler().Handle)
+		notificationGroup.PATCH("/read", app.PatchNotificationsReadHandler().Handle)
+		notificationGroup.DELETE("", app.DeleteNotificationsHandler().Handle)
 
 		notificationGroup.POST("/device", app.PostDeviceHandler().Handle)
+		notificationGroup.GET("/webpush/public-key", app.GetWebPushPublicKeyHandler().Handle)
+
+		notificationGroup.GET("/preferences", app.GetPreferencesHandler().Handle)
+		notificationGroup.PUT("/preferences", app.PutPreferencesHandler().Handle)
+	}
+
+	templatesGroup := handler.Group("/admin/notifications/templates", middleware.RequirePermission(jwt_validator.PermNotificationsManage))
+	{
+		templatesGroup.GET("", app.GetTemplatesHandler().Handle)
+		templatesGroup.PUT("/:type/:locale", app.PutTemplateHandler().Handle)
+		templatesGroup.DELETE("/:type/:locale", app.DeleteTemplateHandler().Handle)
+	}
+
+	deliveriesGroup := handler.Group("/admin/notifications", middleware.RequirePermission(jwt_validator.PermNotificationsManage))
+	{
+		deliveriesGroup.GET("/:notificationId/deliveries", app.GetNotificationDeliveriesHandler().Handle)
+		deliveriesGroup.POST("/deliveries/:deliveryId/retry", app.PostDeliveryRetryHandler().Handle)
+	}
+
+	announcementsGroup := handler.Group("/admin/announcements", middleware.RequirePermission(jwt_validator.PermNotificationsManage))
+	{
+		announcementsGroup.POST("", app.PostAnnouncementHandler().Handle)
+		announcementsGroup.GET("", app.GetAnnouncementsHandler().Handle)
+		announcementsGroup.GET("/:announcementId", app.GetAnnouncementHandler().Handle)
+		announcementsGroup.POST("/:announcementId/retract", app.PostAnnouncementRetractHandler().Handle)
+	}
+
+	// Вызываются другими сервисами с сервисным токеном, через gateway не проксируются.
+	internalGroup := handler.Group("/internal")
+	{
+		internalGroup.GET("/users/:userId/export", app.GetInternalUserExportHandler().Handle,
+			authMiddleware.ServiceOnly(jwt_validator.ScopeUsersExport))
 	}
 
 	handler.GET("/health", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
@@ -1,7 +1,186 @@
 package app
 
-import firebase_sender "github.com/4udiwe/coworking/notification-service/internal/sender/firebase"
+import (
+	"time"
+	// Часовые пояса для писем: в alpine-образе нет системной базы tzdata
+	_ "time/tzdata"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	composite_sender "github.com/4udiwe/coworking/notification-service/internal/sender/composite"
+	email_sender "github.com/4udiwe/coworking/notification-service/internal/sender/email"
+	firebase_sender "github.com/4udiwe/coworking/notification-service/internal/sender/firebase"
+	webpush_sender "github.com/4udiwe/coworking/notification-service/internal/sender/webpush"
+	"github.com/sirupsen/logrus"
+)
 
 func (app *App) PushSender() *firebase_sender.FirebaseSender {
 	return app.pushSender
 }
+
+// Dispatcher — все каналы доставки; какие из них использовать, решает сервис.
+// Email и Web Push подключаются, только если включены в конфиге.
+// Web Push относится к каналу push: браузер для пользователя — ещё одно устройство.
+func (app *App) Dispatcher() *composite_sender.Dispatcher {
+	channels := []composite_sender.Channel{
+		{Name: entity.ChannelPush, Dispatcher: app.DefaultDispatcher()},
+	}
+
+	if app.cfg.WebPush.Enabled {
+		channels = append(channels, composite_sender.Channel{
+			Name:       entity.ChannelPush,
+			Dispatcher: app.WebPushDispatcher(),
+		})
+	}
+
+	if app.cfg.Email.Enabled {
+		channels = append(channels, composite_sender.Channel{
+			Name:       entity.ChannelEmail,
+			Dispatcher: app.EmailDispatcher(),
+		})
+	}
+
+	return composite_sender.NewDispatcher(channels...)
+}
+
+func (app *App) DefaultDispatcher() *firebase_sender.DefaultDispatcher {
+	return firebase_sender.NewDefaultDispatcher(
+		app.PushSender(),
+		app.DeviceRepo(),
+		app.DeliveryRepo(),
+		app.NotificationRepo(),
+		app.PushRetryPolicy(),
+	)
+}
+
+// PushRetryPolicy — общие правила повторов для FCM и Web Push
+func (app *App) PushRetryPolicy() firebase_sender.RetryPolicy {
+	cfg := app.cfg.Delivery
+
+	return firebase_sender.RetryPolicy{
+		MaxAttempts: cfg.MaxAttempts,
+		BaseDelay:   cfg.BaseDelay,
+		MaxDelay:    cfg.MaxDelay,
+	}
+}
+
+func (app *App) PushRetryWorker() *firebase_sender.RetryWorker {
+	if app.pushRetryWorker != nil {
+		return app.pushRetryWorker
+	}
+	cfg := app.cfg.Delivery
+
+	var webDispatcher firebase_sender.DeliveryAttempter
+	if app.cfg.WebPush.Enabled {
+		webDispatcher = app.WebPushDispatcher()
+	}
+
+	app.pushRetryWorker = firebase_sender.NewRetryWorker(
+		app.DefaultDispatcher(),
+		webDispatcher,
+		app.DeliveryRepo(),
+		app.NotificationRepo(),
+		cfg.RetryBatchLimit,
+		cfg.RetryInterval,
+		cfg.RetryLease,
+	)
+	return app.pushRetryWorker
+}
+
+func (app *App) EmailDispatcher() *email_sender.Dispatcher {
+	if app.emailDispatcher != nil {
+		return app.emailDispatcher
+	}
+
+	app.emailDispatcher = email_sender.NewDispatcher(
+		app.SMTPSender(),
+		app.ContactRepo(),
+		app.DeliveryRepo(),
+		app.EmailRenderer(),
+		app.cfg.Email.AppURL,
+		app.EmailLocation(),
+	)
+	return app.emailDispatcher
+}
+
+// AccountMailer — письма аккаунта (auth.mail) через тот же SMTP и шаблоны
+func (app *App) AccountMailer() *email_sender.AccountMailer {
+	if app.accountMailer != nil {
+		return app.accountMailer
+	}
+
+	app.accountMailer = email_sender.NewAccountMailer(
+		app.SMTPSender(),
+		app.EmailRenderer(),
+		app.EmailLocation(),
+	)
+	return app.accountMailer
+}
+
+func (app *App) SMTPSender() *email_sender.SMTPSender {
+	if app.smtpSender != nil {
+		return app.smtpSender
+	}
+
+	cfg := app.cfg.Email.SMTP
+
+	app.smtpSender = email_sender.NewSMTPSender(email_sender.SMTPConfig{
+		Host:     cfg.Host,
+		Port:     cfg.Port,
+		Username: cfg.Username,
+		Password: cfg.Password,
+		From:     cfg.From,
+		FromName: cfg.FromName,
+		Timeout:  cfg.Timeout,
+	})
+	return app.smtpSender
+}
+
+func (app *App) EmailRenderer() *email_sender.Renderer {
+	if app.emailRenderer != nil {
+		return app.emailRenderer
+	}
+
+	renderer, err := email_sender.NewRenderer()
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to parse email templates")
+	}
+	app.emailRenderer = renderer
+	return app.emailRenderer
+}
+
+// EmailLocation — часовой пояс, в котором время выводится в письмах
+func (app *App) EmailLocation() *time.Location {
+	if app.emailLocation != nil {
+		return app.emailLocation
+	}
+
+	location, err := time.LoadLocation(app.cfg.Email.Timezone)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to load email timezone")
+	}
+	app.emailLocation = location
+	return app.emailLocation
+}
+
+func (app *App) WebPushDispatcher() *webpush_sender.Dispatcher {
+	if app.webPushDispatcher != nil {
+		return app.webPushDispatcher
+	}
+
+	cfg := app.cfg.WebPush
+
+	app.webPushDispatcher = webpush_sender.NewDispatcher(
+		webpush_sender.NewVAPIDSender(webpush_sender.Config{
+			PublicKey:  cfg.VAPIDPublicKey,
+			PrivateKey: cfg.VAPIDPrivateKey,
+			Subscriber: cfg.Subscriber,
+			TTL:        cfg.TTL,
+			Timeout:    cfg.Timeout,
+		}),
+		app.DeviceRepo(),
+		app.DeliveryRepo(),
+		app.NotificationRepo(),
+		app.PushRetryPolicy(),
+	)
+	return app.webPushDispatcher
+}
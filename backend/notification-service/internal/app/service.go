@@ -1,8 +1,13 @@
 package app
 
 import (
-	firebase_sender "github.com/4udiwe/coworking/notification-service/internal/sender/firebase"
+	"time"
+
+	announcement_service "github.com/4udiwe/coworking/notification-service/internal/service/announcement"
+	delivery_service "github.com/4udiwe/coworking/notification-service/internal/service/delivery"
+	digest_service "github.com/4udiwe/coworking/notification-service/internal/service/digest"
 	notification_service "github.com/4udiwe/coworking/notification-service/internal/service/notification"
+	template_service "github.com/4udiwe/coworking/notification-service/internal/service/template"
 )
 
 func (app *App) NotificationService() *notification_service.NotificationService {
@@ -12,17 +17,71 @@ func (app *App) NotificationService() *notification_service.NotificationService
 	app.notificationService = notification_service.New(
 		app.NotificationRepo(),
 		app.DeviceRepo(),
+		app.ContactRepo(),
+		app.PreferencesRepo(),
 		app.OutboxRepo(),
 		app.PushService(),
-		app.Postgres(),
+		app.StreamPublisher(),
+		app.TxManager(),
 	)
 	return app.notificationService
 }
 
 func (app *App) PushService() notification_service.PushService {
-	return notification_service.NewDefaultPushService(app.DefaultDispatcher())
+	return notification_service.NewDefaultPushService(app.Dispatcher())
+}
+
+func (app *App) TemplateService() *template_service.TemplateService {
+	if app.templateService != nil {
+		return app.templateService
+	}
+	app.templateService = template_service.New(app.TemplateRepo(), app.TemplateRegistry())
+	return app.templateService
 }
 
-func (app *App) DefaultDispatcher() *firebase_sender.DefaultDispatcher {
-	return firebase_sender.NewDefaultDispatcher(app.PushSender(), app.DeviceRepo())
+func (app *App) DeliveryService() *delivery_service.DeliveryService {
+	if app.deliveryService != nil {
+		return app.deliveryService
+	}
+	app.deliveryService = delivery_service.New(app.DeliveryRepo(), app.NotificationRepo())
+	return app.deliveryService
+}
+
+func (app *App) AnnouncementService() *announcement_service.AnnouncementService {
+	if app.announcementService != nil {
+		return app.announcementService
+	}
+	app.announcementService = announcement_service.New(
+		app.AnnouncementRepo(),
+		app.NotificationRepo(),
+		app.OutboxRepo(),
+		app.StreamPublisher(),
+		app.AuthClient(),
+		app.BookingClient(),
+		app.TxManager(),
+		announcement_service.Config{
+			BatchSize:   app.cfg.Announcements.BatchSize,
+			EventsTopic: app.cfg.Kafka.Topics.NotificationEvents,
+			TimersTopic: app.cfg.Kafka.Topics.SchedulerTimers,
+		},
+	)
+	return app.announcementService
+}
+
+func (app *App) DigestService() *digest_service.DigestService {
+	if app.digestService != nil {
+		return app.digestService
+	}
+	app.digestService = digest_service.New(
+		app.DigestRepo(),
+		app.NotificationBuilder(),
+		app.NotificationService(),
+		app.TxManager(),
+		digest_service.Config{
+			Hour:      app.cfg.Digest.Hour,
+			Weekday:   time.Weekday(app.cfg.Digest.Weekday),
+			BatchSize: app.cfg.Digest.BatchSize,
+		},
+	)
+	return app.digestService
 }
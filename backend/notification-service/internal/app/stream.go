@@ -0,0 +1,27 @@
+package app
+
+import "github.com/4udiwe/coworking/notification-service/internal/stream"
+
+func (app *App) StreamHub() *stream.Hub {
+	if app.streamHub != nil {
+		return app.streamHub
+	}
+	app.streamHub = stream.NewHub(app.cfg.Stream.MaxConnectionsPerUser)
+	return app.streamHub
+}
+
+func (app *App) StreamListener() *stream.Listener {
+	if app.streamListener != nil {
+		return app.streamListener
+	}
+	app.streamListener = stream.NewListener(app.Postgres().Pool, app.StreamHub())
+	return app.streamListener
+}
+
+func (app *App) StreamPublisher() *stream.Publisher {
+	if app.streamPublisher != nil {
+		return app.streamPublisher
+	}
+	app.streamPublisher = stream.NewPublisher(app.Postgres())
+	return app.streamPublisher
+}
@@ -1,11 +1,15 @@
 package notification_builder
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 )
 
 var ErrUnsupportedEvent = errors.New("unsupported event")
@@ -21,43 +25,71 @@ type StandardPayload struct {
 	Extra      map[string]interface{} `json:"extra,omitempty"`
 }
 
-type DefaultBuilder struct{}
+// PreferencesProvider отдаёт язык и часовой пояс получателя
+type PreferencesProvider interface {
+	GetPreferences(ctx context.Context, userID uuid.UUID) (entity.NotificationPreferences, error)
+}
+
+type DefaultBuilder struct {
+	templates   *TemplateRegistry
+	preferences PreferencesProvider
+}
 
-func New() *DefaultBuilder {
-	return &DefaultBuilder{}
+func New(templates *TemplateRegistry, preferences PreferencesProvider) *DefaultBuilder {
+	return &DefaultBuilder{
+		templates:   templates,
+		preferences: preferences,
+	}
 }
 
-func (b *DefaultBuilder) Build(event Event) (entity.Notification, error) {
+func (b *DefaultBuilder) Build(ctx context.Context, event Event) (entity.Notification, error) {
+
+	prefs, err := b.preferences.GetPreferences(ctx, event.UserID)
+	if err != nil {
+		logrus.WithField("user_id", event.UserID.String()).
+			WithError(err).
+			Warn("failed to fetch preferences, using default locale")
+		prefs = entity.DefaultPreferences(event.UserID)
+	}
+
+	title, body, err := b.templates.Render(ctx, event.Type, prefs.Locale, templateData(event, prefs.Location()))
+	if err != nil {
+		return entity.Notification{}, err
+	}
 
 	switch event.Type {
 
 	case entity.BookingCreatedNotificationType:
-		return b.buildBookingCreated(event)
+		return b.buildBookingCreated(event, title, body)
 
 	case entity.BookingCancelledNotificationType:
-		return b.buildBookingCancelled(event)
+		return b.buildBookingCancelled(event, title, body)
 
 	case entity.BookingReminderNotificationType:
-		return b.buildBookingReminder(event)
+		return b.buildBookingReminder(event, title, body)
 
 	case entity.BookingExpiredNotificationType:
-		return b.buildBookingExpired(event)
+		return b.buildBookingExpired(event, title, body)
+
+	case entity.BookingEndReminderNotificationType:
+		return b.buildBookingEndReminder(event, title, body)
+
+	case entity.BookingDigestNotificationType:
+		return b.buildBookingDigest(event, title, body)
 
 	default:
 		return entity.Notification{}, ErrUnsupportedEvent
 	}
 }
 
-func (b *DefaultBuilder) buildBookingCreated(event Event) (entity.Notification, error) {
+func (b *DefaultBuilder) buildBookingCreated(event Event, title, body string) (entity.Notification, error) {
 
 	place := fmt.Sprintf("%v", event.Payload["placeId"])
 	placeLabel := fmt.Sprintf("%v", event.Payload["placeLabel"])
-	start := fmt.Sprintf("%v", event.Payload["startTime"])
+	start := payloadTimeString(event.Payload, "startTime")
+	end := payloadTimeString(event.Payload, "endTime")
 	bookingID := fmt.Sprintf("%v", event.Payload["bookingId"])
 
-	title := "Бронирование создано"
-	body := fmt.Sprintf("Рабочее место %s забронировано", placeLabel)
-
 	// Create standardized payload
 	payload := StandardPayload{
 		Type:       "booking",
@@ -65,12 +97,13 @@ func (b *DefaultBuilder) buildBookingCreated(event Event) (entity.Notification,
 		PlaceID:    place,
 		PlaceLabel: placeLabel,
 		StartTime:  start,
+		EndTime:    end,
 	}
 
 	// Add any extra fields from original payload
 	extraFields := make(map[string]interface{})
 	for k, v := range event.Payload {
-		if k != "bookingId" && k != "placeId" && k != "placeLabel" && k != "startTime" {
+		if k != "bookingId" && k != "placeId" && k != "placeLabel" && k != "startTime" && k != "endTime" {
 			extraFields[k] = v
 		}
 	}
@@ -96,15 +129,12 @@ func (b *DefaultBuilder) buildBookingCreated(event Event) (entity.Notification,
 	}, nil
 }
 
-func (b *DefaultBuilder) buildBookingCancelled(event Event) (entity.Notification, error) {
+func (b *DefaultBuilder) buildBookingCancelled(event Event, title, body string) (entity.Notification, error) {
 
 	place := fmt.Sprintf("%v", event.Payload["placeId"])
 	placeLabel := fmt.Sprintf("%v", event.Payload["placeLabel"])
 	bookingID := fmt.Sprintf("%v", event.Payload["bookingId"])
 
-	title := "Бронирование отменено"
-	body := fmt.Sprintf("Бронирование рабочего места %s отменено", placeLabel)
-
 	// Create standardized payload
 	payload := StandardPayload{
 		Type:       "booking",
@@ -130,16 +160,13 @@ func (b *DefaultBuilder) buildBookingCancelled(event Event) (entity.Notification
 	}, nil
 }
 
-func (b *DefaultBuilder) buildBookingReminder(event Event) (entity.Notification, error) {
+func (b *DefaultBuilder) buildBookingReminder(event Event, title, body string) (entity.Notification, error) {
 
 	place := fmt.Sprintf("%v", event.Payload["placeId"])
 	placeLabel := fmt.Sprintf("%v", event.Payload["placeLabel"])
-	start := fmt.Sprintf("%v", event.Payload["startTime"])
+	start := payloadTimeString(event.Payload, "startTime")
 	bookingID := fmt.Sprintf("%v", event.Payload["bookingId"])
 
-	title := "Напоминание о бронировании"
-	body := fmt.Sprintf("Через 10 минут начинается бронирование места %s", placeLabel)
-
 	// Create standardized payload
 	payload := StandardPayload{
 		Type:       "booking",
@@ -167,16 +194,13 @@ func (b *DefaultBuilder) buildBookingReminder(event Event) (entity.Notification,
 	}, nil
 }
 
-func (b *DefaultBuilder) buildBookingExpired(event Event) (entity.Notification, error) {
+func (b *DefaultBuilder) buildBookingExpired(event Event, title, body string) (entity.Notification, error) {
 
 	place := fmt.Sprintf("%v", event.Payload["placeId"])
 	placeLabel := fmt.Sprintf("%v", event.Payload["placeLabel"])
-	endTime := fmt.Sprintf("%v", event.Payload["endTime"])
+	endTime := payloadTimeString(event.Payload, "endTime")
 	bookingID := fmt.Sprintf("%v", event.Payload["bookingId"])
 
-	title := "Время бронирования истекло"
-	body := fmt.Sprintf("Бронирование места %s подошло к концу", placeLabel)
-
 	// Create standardized payload
 	payload := StandardPayload{
 		Type:       "booking",
@@ -202,3 +226,158 @@ func (b *DefaultBuilder) buildBookingExpired(event Event) (entity.Notification,
 		ActionURL: &actionURL,
 	}, nil
 }
+
+func (b *DefaultBuilder) buildBookingEndReminder(event Event, title, body string) (entity.Notification, error) {
+
+	place := fmt.Sprintf("%v", event.Payload["placeId"])
+	placeLabel := fmt.Sprintf("%v", event.Payload["placeLabel"])
+	endTime := payloadTimeString(event.Payload, "endTime")
+	bookingID := fmt.Sprintf("%v", event.Payload["bookingId"])
+
+	payload := StandardPayload{
+		Type:       "booking",
+		BookingID:  bookingID,
+		PlaceID:    place,
+		PlaceLabel: placeLabel,
+		EndTime:    endTime,
+	}
+
+	payloadBytes, _ := json.Marshal(payload)
+
+	actionURL := fmt.Sprintf("/bookings?tab=active&bookingId=%s", bookingID)
+
+	return entity.Notification{
+		UserID: event.UserID,
+
+		Type: entity.BookingEndReminderNotificationType,
+
+		Title: title,
+		Body:  body,
+
+		Payload:   payloadBytes,
+		ActionURL: &actionURL,
+	}, nil
+}
+
+// DigestPayload — payload уведомления booking_digest
+type DigestPayload struct {
+	Type        string          `json:"type"`
+	Period      string          `json:"period"`
+	PeriodStart string          `json:"periodStart"`
+	Bookings    []DigestBooking `json:"bookings"`
+}
+
+type DigestBooking struct {
+	BookingID  string `json:"bookingId"`
+	PlaceID    string `json:"placeId,omitempty"`
+	PlaceLabel string `json:"placeLabel"`
+	StartTime  string `json:"startTime"`
+	EndTime    string `json:"endTime"`
+}
+
+func (b *DefaultBuilder) buildBookingDigest(event Event, title, body string) (entity.Notification, error) {
+
+	bookings, _ := event.Payload["bookings"].([]entity.DigestBooking)
+
+	payload := DigestPayload{
+		Type:     "digest",
+		Period:   fmt.Sprintf("%v", event.Payload["period"]),
+		Bookings: make([]DigestBooking, 0, len(bookings)),
+	}
+	if periodStart, ok := payloadTime(event.Payload, "periodStart"); ok {
+		payload.PeriodStart = periodStart.Format(time.DateOnly)
+	}
+
+	for _, booking := range bookings {
+		item := DigestBooking{
+			BookingID:  booking.BookingID.String(),
+			PlaceLabel: booking.PlaceLabel,
+			StartTime:  booking.StartTime.Format(time.RFC3339),
+			EndTime:    booking.EndTime.Format(time.RFC3339),
+		}
+		if booking.PlaceID != nil {
+			item.PlaceID = booking.PlaceID.String()
+		}
+		payload.Bookings = append(payload.Bookings, item)
+	}
+
+	payloadBytes, _ := json.Marshal(payload)
+
+	actionURL := "/bookings?tab=active"
+
+	return entity.Notification{
+		UserID: event.UserID,
+
+		Type: entity.BookingDigestNotificationType,
+
+		Title: title,
+		Body:  body,
+
+		Payload:   payloadBytes,
+		ActionURL: &actionURL,
+	}, nil
+}
+
+// templateData собирает данные шаблона из события, время — в часовом поясе пользователя
+func templateData(event Event, location *time.Location) TemplateData {
+	data := TemplateData{
+		PlaceLabel:    fmt.Sprintf("%v", event.Payload["placeLabel"]),
+		MinutesBefore: minutesBefore(event.Payload),
+	}
+
+	if start, ok := payloadTime(event.Payload, "startTime"); ok {
+		data.StartTime = start.In(location)
+	}
+	if end, ok := payloadTime(event.Payload, "endTime"); ok {
+		data.EndTime = end.In(location)
+	}
+
+	if period, ok := event.Payload["period"]; ok {
+		data.Period = fmt.Sprintf("%v", period)
+	}
+	if bookings, ok := event.Payload["bookings"].([]entity.DigestBooking); ok {
+		data.Bookings = make([]TemplateBooking, 0, len(bookings))
+		for _, booking := range bookings {
+			data.Bookings = append(data.Bookings, TemplateBooking{
+				PlaceLabel: booking.PlaceLabel,
+				StartTime:  booking.StartTime.In(location),
+				EndTime:    booking.EndTime.In(location),
+			})
+		}
+	}
+
+	return data
+}
+
+func payloadTime(payload map[string]any, key string) (time.Time, bool) {
+	switch v := payload[key].(type) {
+	case time.Time:
+		return v, !v.IsZero()
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		return t, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
+
+// payloadTimeString приводит время из события к RFC3339 для payload
+// уведомления; пустая строка — времени в событии нет
+func payloadTimeString(payload map[string]any, key string) string {
+	t, ok := payloadTime(payload, key)
+	if !ok {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func minutesBefore(payload map[string]any) int {
+	switch v := payload["minutesBefore"].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
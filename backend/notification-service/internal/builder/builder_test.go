@@ -0,0 +1,87 @@
+package notification_builder
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type fakePreferences struct{}
+
+func (fakePreferences) GetPreferences(_ context.Context, userID uuid.UUID) (entity.NotificationPreferences, error) {
+	return entity.DefaultPreferences(userID), nil
+}
+
+func TestBuild_PayloadTimesAreRFC3339(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	start := time.Date(2026, 10, 21, 13, 0, 0, 0, moscow)
+	end := time.Date(2026, 10, 21, 15, 0, 0, 0, moscow)
+
+	tests := []struct {
+		name      string
+		typ       entity.NotificationType
+		payload   map[string]any
+		wantStart string
+		wantEnd   string
+	}{
+		{
+			name:      "booking_created_time_values",
+			typ:       entity.BookingCreatedNotificationType,
+			payload:   map[string]any{"bookingId": "b1", "placeLabel": "A-12", "startTime": start, "endTime": end},
+			wantStart: "2026-10-21T13:00:00+03:00",
+			wantEnd:   "2026-10-21T15:00:00+03:00",
+		},
+		{
+			name:      "booking_created_rfc3339_strings",
+			typ:       entity.BookingCreatedNotificationType,
+			payload:   map[string]any{"bookingId": "b1", "startTime": "2026-10-21T10:00:00Z", "endTime": "2026-10-21T12:00:00Z"},
+			wantStart: "2026-10-21T10:00:00Z",
+			wantEnd:   "2026-10-21T12:00:00Z",
+		},
+		{
+			name:      "booking_reminder",
+			typ:       entity.BookingReminderNotificationType,
+			payload:   map[string]any{"bookingId": "b1", "startTime": start, "minutesBefore": 15},
+			wantStart: "2026-10-21T13:00:00+03:00",
+		},
+		{
+			name:    "booking_expired",
+			typ:     entity.BookingExpiredNotificationType,
+			payload: map[string]any{"bookingId": "b1", "endTime": end},
+			wantEnd: "2026-10-21T15:00:00+03:00",
+		},
+		{
+			// Нет времени в событии — поля нет и в payload
+			name:    "missing_time",
+			typ:     entity.BookingCreatedNotificationType,
+			payload: map[string]any{"bookingId": "b1"},
+		},
+	}
+
+	templates, err := NewTemplateRegistry(&fakeTemplateRepository{}, time.Minute)
+	if err != nil {
+		t.Fatalf("NewTemplateRegistry() error = %v", err)
+	}
+	b := New(templates, fakePreferences{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notification, err := b.Build(context.Background(), Event{Type: tt.typ, UserID: uuid.New(), Payload: tt.payload})
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			var payload StandardPayload
+			if err := json.Unmarshal(notification.Payload, &payload); err != nil {
+				t.Fatalf("payload is not JSON: %v", err)
+			}
+			if payload.StartTime != tt.wantStart || payload.EndTime != tt.wantEnd {
+				t.Errorf("startTime, endTime = %q, %q, want %q, %q", payload.StartTime, payload.EndTime, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package notification_builder
+
+import "github.com/4udiwe/coworking/notification-service/internal/entity"
+
+type templateText struct {
+	Title string
+	Body  string
+}
+
+// Встроенные тексты. Для языка без своего текста используется DefaultLocale.
+var defaultTemplates = map[entity.Locale]map[entity.NotificationType]templateText{
+	entity.LocaleRU: {
+		entity.BookingCreatedNotificationType: {
+			Title: "Бронирование создано",
+			Body:  `Рабочее место {{.PlaceLabel}} забронировано{{if not .StartTime.IsZero}} на {{datetime .StartTime}}{{end}}`,
+		},
+		entity.BookingCancelledNotificationType: {
+			Title: "Бронирование отменено",
+			Body:  `Бронирование рабочего места {{.PlaceLabel}} отменено`,
+		},
+		entity.BookingReminderNotificationType: {
+			Title: "Напоминание о бронировании",
+			Body:  `{{if .MinutesBefore}}Через {{duration .MinutesBefore}}{{else}}Скоро{{end}} начинается бронирование места {{.PlaceLabel}}`,
+		},
+		entity.BookingExpiredNotificationType: {
+			Title: "Время бронирования истекло",
+			Body:  `Бронирование места {{.PlaceLabel}} подошло к концу`,
+		},
+		entity.BookingEndReminderNotificationType: {
+			Title: "Бронирование скоро закончится",
+			Body:  `{{if .MinutesBefore}}Через {{duration .MinutesBefore}}{{else}}Скоро{{end}} заканчивается бронирование места {{.PlaceLabel}}`,
+		},
+		entity.BookingDigestNotificationType: {
+			Title: `{{if eq .Period "weekly"}}Бронирования на неделю{{else}}Бронирования на сегодня{{end}}`,
+			Body: `{{$n := len .Bookings}}{{$n}} {{plural $n "бронирование" "бронирования" "бронирований"}}:
+{{- range .Bookings}}
+{{if eq $.Period "weekly"}}{{datetime .StartTime}}{{else}}{{time .StartTime}}{{end}}–{{time .EndTime}}, место {{.PlaceLabel}}
+{{- end}}`,
+		},
+	},
+	entity.LocaleEN: {
+		entity.BookingCreatedNotificationType: {
+			Title: "Booking confirmed",
+			Body:  `Workspace {{.PlaceLabel}} is booked{{if not .StartTime.IsZero}} for {{datetime .StartTime}}{{end}}`,
+		},
+		entity.BookingCancelledNotificationType: {
+			Title: "Booking cancelled",
+			Body:  `Your booking of workspace {{.PlaceLabel}} has been cancelled`,
+		},
+		entity.BookingReminderNotificationType: {
+			Title: "Booking reminder",
+			Body:  `Your booking of {{.PlaceLabel}} starts {{if .MinutesBefore}}in {{duration .MinutesBefore}}{{else}}soon{{end}}`,
+		},
+		entity.BookingExpiredNotificationType: {
+			Title: "Booking ended",
+			Body:  `Your booking of {{.PlaceLabel}} has ended`,
+		},
+		entity.BookingEndReminderNotificationType: {
+			Title: "Booking ends soon",
+			Body:  `Your booking of {{.PlaceLabel}} ends {{if .MinutesBefore}}in {{duration .MinutesBefore}}{{else}}soon{{end}}`,
+		},
+		entity.BookingDigestNotificationType: {
+			Title: `{{if eq .Period "weekly"}}Your bookings this week{{else}}Your bookings today{{end}}`,
+			Body: `{{$n := len .Bookings}}{{$n}} {{plural $n "booking" "bookings"}}:
+{{- range .Bookings}}
+{{if eq $.Period "weekly"}}{{datetime .StartTime}}{{else}}{{time .StartTime}}{{end}}–{{time .EndTime}}, workspace {{.PlaceLabel}}
+{{- end}}`,
+		},
+	},
+}
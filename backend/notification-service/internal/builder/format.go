@@ -0,0 +1,120 @@
+package notification_builder
+
+import (
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+)
+
+var monthsRU = [...]string{
+	"января", "февраля", "марта", "апреля", "мая", "июня",
+	"июля", "августа", "сентября", "октября", "ноября", "декабря",
+}
+
+/*
+templateFuncs — функции, доступные в шаблонах на языке locale:
+
+	plural n "минуту" "минуты" "минут"  — форма слова для числа n
+	duration 90                         — "90 минут", "1 час", "1 день"
+	datetime .StartTime                 — "21 октября, 10:00"
+	date .StartTime / time .StartTime   — только дата / только время
+
+Время в данных шаблона уже переведено в часовой пояс пользователя.
+*/
+func templateFuncs(locale entity.Locale) template.FuncMap {
+	return template.FuncMap{
+		"plural": func(n int, forms ...string) string {
+			return plural(locale, n, forms...)
+		},
+		"duration": func(minutes int) string {
+			return formatDuration(locale, minutes)
+		},
+		"datetime": func(t time.Time) string {
+			return formatDate(locale, t) + ", " + t.Format("15:04")
+		},
+		"date": func(t time.Time) string {
+			return formatDate(locale, t)
+		},
+		"time": func(t time.Time) string {
+			return t.Format("15:04")
+		},
+	}
+}
+
+// plural выбирает форму слова для числа n.
+// ru: одна, две-четыре, пять; en: одна, много.
+func plural(locale entity.Locale, n int, forms ...string) string {
+	if len(forms) == 0 {
+		return ""
+	}
+
+	var index int
+	switch locale {
+	case entity.LocaleRU:
+		index = pluralIndexRU(n)
+	default:
+		if n != 1 {
+			index = 1
+		}
+	}
+
+	if index >= len(forms) {
+		index = len(forms) - 1
+	}
+	return forms[index]
+}
+
+func pluralIndexRU(n int) int {
+	if n < 0 {
+		n = -n
+	}
+	n %= 100
+	if n >= 11 && n <= 14 {
+		return 2
+	}
+	switch n % 10 {
+	case 1:
+		return 0
+	case 2, 3, 4:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// formatDuration: 15 -> "15 минут", 60 -> "1 час", 1440 -> "1 день"
+func formatDuration(locale entity.Locale, minutes int) string {
+	type unit struct{ ru, en []string }
+
+	var (
+		value int
+		u     unit
+	)
+
+	switch {
+	case minutes > 0 && minutes%1440 == 0:
+		value = minutes / 1440
+		u = unit{ru: []string{"день", "дня", "дней"}, en: []string{"day", "days"}}
+	case minutes > 0 && minutes%60 == 0:
+		value = minutes / 60
+		u = unit{ru: []string{"час", "часа", "часов"}, en: []string{"hour", "hours"}}
+	default:
+		value = minutes
+		u = unit{ru: []string{"минуту", "минуты", "минут"}, en: []string{"minute", "minutes"}}
+	}
+
+	forms := u.en
+	if locale == entity.LocaleRU {
+		forms = u.ru
+	}
+	return fmt.Sprintf("%d %s", value, plural(locale, value, forms...))
+}
+
+func formatDate(locale entity.Locale, t time.Time) string {
+	if locale == entity.LocaleRU {
+		return fmt.Sprintf("%d %s", t.Day(), monthsRU[t.Month()-1])
+	}
+	return t.Format("January 2")
+}
@@ -0,0 +1,97 @@
+package notification_builder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+)
+
+func TestPlural(t *testing.T) {
+	ru := []string{"минуту", "минуты", "минут"}
+	en := []string{"minute", "minutes"}
+
+	tests := []struct {
+		locale entity.Locale
+		n      int
+		forms  []string
+		want   string
+	}{
+		{entity.LocaleRU, 0, ru, "минут"},
+		{entity.LocaleRU, 1, ru, "минуту"},
+		{entity.LocaleRU, 2, ru, "минуты"},
+		{entity.LocaleRU, 4, ru, "минуты"},
+		{entity.LocaleRU, 5, ru, "минут"},
+		{entity.LocaleRU, 11, ru, "минут"},
+		{entity.LocaleRU, 12, ru, "минут"},
+		{entity.LocaleRU, 14, ru, "минут"},
+		{entity.LocaleRU, 21, ru, "минуту"},
+		{entity.LocaleRU, 22, ru, "минуты"},
+		{entity.LocaleRU, 25, ru, "минут"},
+		{entity.LocaleRU, 111, ru, "минут"},
+		{entity.LocaleRU, 112, ru, "минут"},
+		{entity.LocaleRU, 101, ru, "минуту"},
+		{entity.LocaleRU, -1, ru, "минуту"},
+		{entity.LocaleEN, 1, en, "minute"},
+		{entity.LocaleEN, 0, en, "minutes"},
+		{entity.LocaleEN, 11, en, "minutes"},
+		// Форм меньше, чем нужно: берётся последняя
+		{entity.LocaleRU, 5, []string{"минуту", "минуты"}, "минуты"},
+		{entity.LocaleRU, 5, nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := plural(tt.locale, tt.n, tt.forms...); got != tt.want {
+			t.Errorf("plural(%s, %d, %v) = %q, want %q", tt.locale, tt.n, tt.forms, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		locale  entity.Locale
+		minutes int
+		want    string
+	}{
+		{entity.LocaleRU, 1, "1 минуту"},
+		{entity.LocaleRU, 15, "15 минут"},
+		{entity.LocaleRU, 90, "90 минут"},
+		{entity.LocaleRU, 60, "1 час"},
+		{entity.LocaleRU, 120, "2 часа"},
+		{entity.LocaleRU, 300, "5 часов"},
+		{entity.LocaleRU, 1440, "1 день"},
+		{entity.LocaleRU, 2880, "2 дня"},
+		{entity.LocaleRU, 0, "0 минут"},
+		{entity.LocaleEN, 1, "1 minute"},
+		{entity.LocaleEN, 15, "15 minutes"},
+		{entity.LocaleEN, 60, "1 hour"},
+		{entity.LocaleEN, 180, "3 hours"},
+		{entity.LocaleEN, 10080, "7 days"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDuration(tt.locale, tt.minutes); got != tt.want {
+			t.Errorf("formatDuration(%s, %d) = %q, want %q", tt.locale, tt.minutes, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	tests := []struct {
+		locale entity.Locale
+		t      time.Time
+		want   string
+	}{
+		{entity.LocaleRU, time.Date(2026, time.October, 21, 10, 0, 0, 0, time.UTC), "21 октября"},
+		{entity.LocaleRU, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), "1 января"},
+		{entity.LocaleRU, time.Date(2026, time.December, 31, 23, 59, 0, 0, time.UTC), "31 декабря"},
+		{entity.LocaleEN, time.Date(2026, time.October, 21, 10, 0, 0, 0, time.UTC), "October 21"},
+		{entity.LocaleEN, time.Date(2026, time.May, 3, 10, 0, 0, 0, time.UTC), "May 3"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDate(tt.locale, tt.t); got != tt.want {
+			t.Errorf("formatDate(%s, %s) = %q, want %q", tt.locale, tt.t, got, tt.want)
+		}
+	}
+}
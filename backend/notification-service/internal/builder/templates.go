@@ -0,0 +1,287 @@
+package notification_builder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/sirupsen/logrus"
+)
+
+// TemplateData — данные, доступные в шаблоне
+type TemplateData struct {
+	PlaceLabel string
+
+	// В часовом поясе пользователя; нулевое значение — время неизвестно
+	StartTime time.Time
+	EndTime   time.Time
+
+	// За сколько минут до начала/окончания отправлено напоминание, 0 — неизвестно
+	MinutesBefore int
+
+	// Только для дайджеста: daily или weekly и бронирования периода по времени начала
+	Period   string
+	Bookings []TemplateBooking
+}
+
+// TemplateBooking — бронирование в дайджесте, время в часовом поясе пользователя
+type TemplateBooking struct {
+	PlaceLabel string
+	StartTime  time.Time
+	EndTime    time.Time
+}
+
+// Данные для пробного рендера при проверке шаблона администратора
+var sampleTemplateData = TemplateData{
+	PlaceLabel:    "A-12",
+	StartTime:     time.Date(2026, time.October, 21, 10, 0, 0, 0, time.UTC),
+	EndTime:       time.Date(2026, time.October, 21, 12, 0, 0, 0, time.UTC),
+	MinutesBefore: 15,
+	Period:        string(entity.DigestDaily),
+	Bookings: []TemplateBooking{
+		{
+			PlaceLabel: "A-12",
+			StartTime:  time.Date(2026, time.October, 21, 10, 0, 0, 0, time.UTC),
+			EndTime:    time.Date(2026, time.October, 21, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			PlaceLabel: "B-3",
+			StartTime:  time.Date(2026, time.October, 21, 15, 0, 0, 0, time.UTC),
+			EndTime:    time.Date(2026, time.October, 21, 17, 30, 0, 0, time.UTC),
+		},
+	},
+}
+
+type TemplateRepository interface {
+	List(ctx context.Context) ([]entity.NotificationTemplate, error)
+}
+
+type templateKey struct {
+	Type   entity.NotificationType
+	Locale entity.Locale
+}
+
+type compiledTemplate struct {
+	source entity.NotificationTemplate
+	title  *template.Template
+	body   *template.Template
+}
+
+/*
+TemplateRegistry — тексты уведомлений по типу и языку.
+
+Переопределения администратора перечитываются из БД не реже раза в
+refreshInterval, поэтому изменение доходит до всех реплик без релиза.
+Реплика, через которую текст изменён, сбрасывает кэш сразу (Invalidate).
+Текст ищется так: переопределение для языка -> встроенный для языка ->
+встроенный для DefaultLocale.
+*/
+type TemplateRegistry struct {
+	repo            TemplateRepository
+	refreshInterval time.Duration
+
+	defaults map[templateKey]*compiledTemplate
+
+	mu        sync.RWMutex
+	overrides map[templateKey]*compiledTemplate
+	loadedAt  time.Time
+}
+
+func NewTemplateRegistry(repo TemplateRepository, refreshInterval time.Duration) (*TemplateRegistry, error) {
+	defaults := make(map[templateKey]*compiledTemplate)
+
+	for locale, texts := range defaultTemplates {
+		for notificationType, text := range texts {
+			compiled, err := compileTemplate(entity.NotificationTemplate{
+				Type:   notificationType,
+				Locale: locale,
+				Title:  text.Title,
+				Body:   text.Body,
+			})
+			if err != nil {
+				return nil, err
+			}
+			defaults[templateKey{Type: notificationType, Locale: locale}] = compiled
+		}
+	}
+
+	return &TemplateRegistry{
+		repo:            repo,
+		refreshInterval: refreshInterval,
+		defaults:        defaults,
+		overrides:       make(map[templateKey]*compiledTemplate),
+	}, nil
+}
+
+// Render возвращает заголовок и текст уведомления на языке locale
+func (r *TemplateRegistry) Render(
+	ctx context.Context,
+	notificationType entity.NotificationType,
+	locale entity.Locale,
+	data TemplateData,
+) (title string, body string, err error) {
+
+	r.refresh(ctx, false)
+
+	compiled, ok := r.lookup(notificationType, locale)
+	if !ok {
+		return "", "", ErrUnsupportedEvent
+	}
+
+	title, body, err = compiled.execute(data)
+	if err != nil && compiled.source.Overridden {
+		// Переопределение проверяется при сохранении, но данные события могут
+		// отличаться от пробных — откатываемся на встроенный текст
+		logrus.WithFields(logrus.Fields{
+			"type":   notificationType,
+			"locale": compiled.source.Locale,
+		}).WithError(err).Error("failed to render template override, using default")
+
+		if fallback, ok := r.lookupDefault(notificationType, locale); ok {
+			return fallback.execute(data)
+		}
+	}
+
+	return title, body, err
+}
+
+// Templates — действующие тексты для всех типов и языков, с учётом переопределений
+func (r *TemplateRegistry) Templates(ctx context.Context) ([]entity.NotificationTemplate, error) {
+	if err := r.refresh(ctx, true); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	templates := make([]entity.NotificationTemplate, 0, len(entity.NotificationTypes)*len(entity.Locales))
+	for _, notificationType := range entity.NotificationTypes {
+		for _, locale := range entity.Locales {
+			key := templateKey{Type: notificationType, Locale: locale}
+			if compiled, ok := r.overrides[key]; ok {
+				templates = append(templates, compiled.source)
+				continue
+			}
+			if compiled, ok := r.lookupDefault(notificationType, locale); ok {
+				source := compiled.source
+				source.Locale = locale
+				templates = append(templates, source)
+			}
+		}
+	}
+
+	return templates, nil
+}
+
+// Validate разбирает шаблон и пробует отрендерить его на примере данных
+func (r *TemplateRegistry) Validate(template entity.NotificationTemplate) error {
+	compiled, err := compileTemplate(template)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := compiled.execute(sampleTemplateData); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Invalidate заставляет перечитать переопределения при следующем рендере
+func (r *TemplateRegistry) Invalidate() {
+	r.mu.Lock()
+	r.loadedAt = time.Time{}
+	r.mu.Unlock()
+}
+
+func (r *TemplateRegistry) lookup(notificationType entity.NotificationType, locale entity.Locale) (*compiledTemplate, bool) {
+	r.mu.RLock()
+	compiled, ok := r.overrides[templateKey{Type: notificationType, Locale: locale}]
+	r.mu.RUnlock()
+
+	if ok {
+		return compiled, true
+	}
+	return r.lookupDefault(notificationType, locale)
+}
+
+func (r *TemplateRegistry) lookupDefault(notificationType entity.NotificationType, locale entity.Locale) (*compiledTemplate, bool) {
+	if compiled, ok := r.defaults[templateKey{Type: notificationType, Locale: locale}]; ok {
+		return compiled, true
+	}
+	compiled, ok := r.defaults[templateKey{Type: notificationType, Locale: entity.DefaultLocale}]
+	return compiled, ok
+}
+
+// refresh перечитывает переопределения, если кэш устарел (или force).
+// При ошибке БД продолжаем работать с прежними текстами.
+func (r *TemplateRegistry) refresh(ctx context.Context, force bool) error {
+	r.mu.RLock()
+	fresh := !r.loadedAt.IsZero() && time.Since(r.loadedAt) < r.refreshInterval
+	r.mu.RUnlock()
+
+	if fresh && !force {
+		return nil
+	}
+
+	templates, err := r.repo.List(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("failed to refresh notification templates")
+		return err
+	}
+
+	overrides := make(map[templateKey]*compiledTemplate, len(templates))
+	for _, t := range templates {
+		compiled, err := compileTemplate(t)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"type":   t.Type,
+				"locale": t.Locale,
+			}).WithError(err).Error("skipping invalid template override")
+			continue
+		}
+		overrides[templateKey{Type: t.Type, Locale: t.Locale}] = compiled
+	}
+
+	r.mu.Lock()
+	r.overrides = overrides
+	r.loadedAt = time.Now()
+	r.mu.Unlock()
+
+	return nil
+}
+
+func compileTemplate(source entity.NotificationTemplate) (*compiledTemplate, error) {
+	funcs := templateFuncs(source.Locale)
+	name := fmt.Sprintf("%s.%s", source.Type, source.Locale)
+
+	title, err := template.New(name + ".title").Option("missingkey=error").Funcs(funcs).Parse(source.Title)
+	if err != nil {
+		return nil, fmt.Errorf("title: %w", err)
+	}
+
+	body, err := template.New(name + ".body").Option("missingkey=error").Funcs(funcs).Parse(source.Body)
+	if err != nil {
+		return nil, fmt.Errorf("body: %w", err)
+	}
+
+	return &compiledTemplate{source: source, title: title, body: body}, nil
+}
+
+func (t *compiledTemplate) execute(data TemplateData) (string, string, error) {
+	var title, body bytes.Buffer
+
+	if err := t.title.Execute(&title, data); err != nil {
+		return "", "", err
+	}
+	if err := t.body.Execute(&body, data); err != nil {
+		return "", "", err
+	}
+
+	return strings.TrimSpace(title.String()), strings.TrimSpace(body.String()), nil
+}
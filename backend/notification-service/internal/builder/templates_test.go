@@ -0,0 +1,227 @@
+package notification_builder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+)
+
+// fakeTemplateRepository отдаёт переопределения администратора и считает чтения
+type fakeTemplateRepository struct {
+	templates []entity.NotificationTemplate
+	err       error
+	calls     int
+}
+
+func (f *fakeTemplateRepository) List(context.Context) ([]entity.NotificationTemplate, error) {
+	f.calls++
+	return f.templates, f.err
+}
+
+func override(typ entity.NotificationType, locale entity.Locale, title, body string) entity.NotificationTemplate {
+	return entity.NotificationTemplate{Type: typ, Locale: locale, Title: title, Body: body, Overridden: true}
+}
+
+func TestTemplateRegistry_Render(t *testing.T) {
+	data := TemplateData{
+		PlaceLabel:    "A-12",
+		StartTime:     time.Date(2026, time.October, 21, 10, 0, 0, 0, time.UTC),
+		MinutesBefore: 15,
+	}
+
+	tests := []struct {
+		name      string
+		overrides []entity.NotificationTemplate
+		typ       entity.NotificationType
+		locale    entity.Locale
+		wantTitle string
+		wantBody  string
+		wantError error
+	}{
+		{
+			name:      "override_for_locale",
+			overrides: []entity.NotificationTemplate{override(entity.BookingCreatedNotificationType, entity.LocaleEN, "Booked", "{{.PlaceLabel}} is yours")},
+			typ:       entity.BookingCreatedNotificationType,
+			locale:    entity.LocaleEN,
+			wantTitle: "Booked",
+			wantBody:  "A-12 is yours",
+		},
+		{
+			// Переопределение для другого языка не влияет на этот
+			name:      "builtin_for_locale",
+			overrides: []entity.NotificationTemplate{override(entity.BookingCreatedNotificationType, entity.LocaleRU, "Готово", "Место {{.PlaceLabel}}")},
+			typ:       entity.BookingCreatedNotificationType,
+			locale:    entity.LocaleEN,
+			wantTitle: "Booking confirmed",
+			wantBody:  "Workspace A-12 is booked for October 21, 10:00",
+		},
+		{
+			name:      "builtin_default_locale_for_unknown_locale",
+			typ:       entity.BookingReminderNotificationType,
+			locale:    entity.Locale("de"),
+			wantTitle: "Напоминание о бронировании",
+			wantBody:  "Через 15 минут начинается бронирование места A-12",
+		},
+		{
+			// Данные события не подошли переопределению — встроенный текст
+			name:      "failed_override_falls_back_to_builtin",
+			overrides: []entity.NotificationTemplate{override(entity.BookingCreatedNotificationType, entity.LocaleRU, "Бронь", "{{.PlaceLabel.Missing}}")},
+			typ:       entity.BookingCreatedNotificationType,
+			locale:    entity.LocaleRU,
+			wantTitle: "Бронирование создано",
+			wantBody:  "Рабочее место A-12 забронировано на 21 октября, 10:00",
+		},
+		{
+			name:      "unknown_type",
+			typ:       entity.NotificationType("unknown"),
+			locale:    entity.LocaleRU,
+			wantError: ErrUnsupportedEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := NewTemplateRegistry(&fakeTemplateRepository{templates: tt.overrides}, time.Hour)
+			if err != nil {
+				t.Fatalf("NewTemplateRegistry() error = %v", err)
+			}
+
+			title, body, err := registry.Render(context.Background(), tt.typ, tt.locale, data)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantError)
+			}
+			if title != tt.wantTitle || body != tt.wantBody {
+				t.Errorf("Render() = %q, %q, want %q, %q", title, body, tt.wantTitle, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestTemplateRegistry_InvalidOverrideIsSkipped(t *testing.T) {
+	repo := &fakeTemplateRepository{templates: []entity.NotificationTemplate{
+		override(entity.BookingCreatedNotificationType, entity.LocaleRU, "{{.PlaceLabel", "сломан"),
+	}}
+
+	registry, err := NewTemplateRegistry(repo, time.Hour)
+	if err != nil {
+		t.Fatalf("NewTemplateRegistry() error = %v", err)
+	}
+
+	title, _, err := registry.Render(context.Background(), entity.BookingCreatedNotificationType, entity.LocaleRU, TemplateData{})
+	if err != nil || title != "Бронирование создано" {
+		t.Errorf("Render() = %q, %v, want builtin title", title, err)
+	}
+}
+
+func TestTemplateRegistry_Refresh(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeTemplateRepository{}
+
+	registry, err := NewTemplateRegistry(repo, time.Hour)
+	if err != nil {
+		t.Fatalf("NewTemplateRegistry() error = %v", err)
+	}
+
+	render := func() string {
+		t.Helper()
+		title, _, err := registry.Render(ctx, entity.BookingCreatedNotificationType, entity.LocaleRU, TemplateData{})
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		return title
+	}
+
+	if title := render(); title != "Бронирование создано" || repo.calls != 1 {
+		t.Fatalf("first render = %q, %d reads", title, repo.calls)
+	}
+
+	// Кэш свежий: изменение в БД не видно до истечения refreshInterval
+	repo.templates = []entity.NotificationTemplate{override(entity.BookingCreatedNotificationType, entity.LocaleRU, "Новый заголовок", "Текст")}
+	if title := render(); title != "Бронирование создано" || repo.calls != 1 {
+		t.Errorf("cached render = %q, %d reads", title, repo.calls)
+	}
+
+	registry.Invalidate()
+	if title := render(); title != "Новый заголовок" || repo.calls != 2 {
+		t.Errorf("render after Invalidate = %q, %d reads", title, repo.calls)
+	}
+
+	// Ошибка БД: остаются прежние тексты, повторное чтение — на следующем рендере
+	registry.Invalidate()
+	repo.err = errors.New("db is down")
+	if title := render(); title != "Новый заголовок" || repo.calls != 3 {
+		t.Errorf("render on db error = %q, %d reads", title, repo.calls)
+	}
+}
+
+func TestTemplateRegistry_Templates(t *testing.T) {
+	repo := &fakeTemplateRepository{templates: []entity.NotificationTemplate{
+		override(entity.BookingCreatedNotificationType, entity.LocaleEN, "Booked", "Done"),
+	}}
+
+	registry, err := NewTemplateRegistry(repo, time.Hour)
+	if err != nil {
+		t.Fatalf("NewTemplateRegistry() error = %v", err)
+	}
+
+	templates, err := registry.Templates(context.Background())
+	if err != nil {
+		t.Fatalf("Templates() error = %v", err)
+	}
+	got := make(map[templateKey]bool, len(templates))
+	for _, tmpl := range templates {
+		got[templateKey{Type: tmpl.Type, Locale: tmpl.Locale}] = true
+	}
+
+	// Для каждого языка — все типы, кроме объявлений: их текст задаёт администратор
+	for _, typ := range entity.NotificationTypes {
+		for _, locale := range entity.Locales {
+			want := typ != entity.AnnouncementNotificationType
+			if got[templateKey{Type: typ, Locale: locale}] != want {
+				t.Errorf("%s/%s present = %v, want %v", typ, locale, !want, want)
+			}
+		}
+	}
+
+	for _, tmpl := range templates {
+		isOverride := tmpl.Type == entity.BookingCreatedNotificationType && tmpl.Locale == entity.LocaleEN
+		if tmpl.Overridden != isOverride {
+			t.Errorf("%s/%s overridden = %v, want %v", tmpl.Type, tmpl.Locale, tmpl.Overridden, isOverride)
+		}
+	}
+}
+
+func TestTemplateRegistry_Validate(t *testing.T) {
+	registry, err := NewTemplateRegistry(&fakeTemplateRepository{}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewTemplateRegistry() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{name: "valid", body: "Через {{duration .MinutesBefore}} — {{.PlaceLabel}}, {{datetime .StartTime}}"},
+		{name: "parse_error", body: "{{.PlaceLabel", wantErr: true},
+		{name: "unknown_field", body: "{{.Unknown}}", wantErr: true},
+		{name: "unknown_func", body: "{{upper .PlaceLabel}}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.Validate(entity.NotificationTemplate{
+				Type:   entity.BookingReminderNotificationType,
+				Locale: entity.LocaleRU,
+				Title:  "Напоминание",
+				Body:   tt.body,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
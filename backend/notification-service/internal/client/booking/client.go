@@ -0,0 +1,70 @@
+package booking_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnexpected = errors.New("unexpected booking-service response")
+
+// TokenSource выдаёт сервисный токен со scope bookings.active_users (authclient.Client)
+type TokenSource interface {
+	AccessToken(ctx context.Context) (string, error)
+}
+
+// Client — HTTP-клиент внутренних маршрутов booking-service
+type Client struct {
+	baseURL    string
+	tokens     TokenSource
+	httpClient *http.Client
+}
+
+func New(baseURL string, tokens TokenSource, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		tokens:     tokens,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// ListActiveCoworkingUsers возвращает пользователей с активными бронированиями в коворкинге
+func (c *Client) ListActiveCoworkingUsers(ctx context.Context, coworkingID uuid.UUID) ([]uuid.UUID, error) {
+	token, err := c.tokens.AccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	path := "/internal/coworkings/" + coworkingID.String() + "/active-users"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("booking-service request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s %s", ErrUnexpected, path, resp.Status)
+	}
+
+	var body struct {
+		UserIDs []uuid.UUID `json:"userIds"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, err)
+	}
+
+	return body.UserIDs, nil
+}
@@ -0,0 +1,81 @@
+package consumer_auth
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/inbox"
+	"github.com/4udiwe/coworking/auth-service/pkg/retry"
+	"github.com/4udiwe/coworking/notification-service/internal/consumer"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	notification_service "github.com/4udiwe/coworking/notification-service/internal/service/notification"
+)
+
+// Обработчик событий жизненного цикла пользователей из топика auth.
+// Отзыв токенов по тем же событиям делает jwt_validator.RevocationListener.
+type Consumer struct {
+	service *notification_service.NotificationService
+	inbox   *inbox.Inbox
+
+	consumer *retry.Consumer
+	topic    string
+	groupID  string
+}
+
+func New(
+	service *notification_service.NotificationService,
+	inbox *inbox.Inbox,
+	consumer *retry.Consumer,
+	topic string,
+	groupID string,
+) *Consumer {
+	return &Consumer{
+		service:  service,
+		inbox:    inbox,
+		consumer: consumer,
+		topic:    topic,
+		groupID:  groupID,
+	}
+}
+
+func (c *Consumer) Run(ctx context.Context) error {
+	logrus.Infof("AuthConsumer: subscribing to topic=%s group=%s", c.topic, c.groupID)
+
+	return c.consumer.Subscribe(ctx, c.topic, c.groupID, func(ctx context.Context, key, value []byte) error {
+		event, err := consumer.ParseOrderEvent(value)
+		if err != nil {
+			logrus.Errorf("AuthConsumer: failed to parse event: %v", err)
+			return retry.Permanent(err)
+		}
+
+		switch event.Type {
+
+		case consumer.UserRegistered, consumer.UserUpdated:
+			return c.inbox.Process(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+				err := c.service.SaveContact(ctx, entity.UserContact{
+					UserID:    event.Payload.UserID,
+					Email:     event.Payload.Email,
+					FirstName: event.Payload.FirstName,
+				})
+				if err != nil {
+					logrus.Errorf("AuthConsumer: SaveContact failed: %v", err)
+				}
+				return err
+			})
+
+		case consumer.UserDeleted:
+			return c.inbox.Process(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+				err := c.service.DeleteUserData(ctx, event.Payload.UserID)
+				if err != nil {
+					logrus.Errorf("AuthConsumer: DeleteUserData failed: %v", err)
+				}
+				return err
+			})
+
+		default:
+			// Остальные события топика notification-service не нужны
+			return nil
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package consumer_auth_mail
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/4udiwe/coworking/auth-service/pkg/inbox"
+	"github.com/4udiwe/coworking/auth-service/pkg/retry"
+	"github.com/4udiwe/coworking/notification-service/internal/consumer"
+	email_sender "github.com/4udiwe/coworking/notification-service/internal/sender/email"
+)
+
+// Обработчик писем аккаунта из топика auth.mail: подтверждение email и
+// приглашения. События несут одноразовые ссылки, поэтому auth-service
+// публикует их отдельно от auth.events.
+type Consumer struct {
+	mailer *email_sender.AccountMailer
+	inbox  *inbox.Inbox
+
+	consumer *retry.Consumer
+	topic    string
+	groupID  string
+}
+
+func New(
+	mailer *email_sender.AccountMailer,
+	inbox *inbox.Inbox,
+	consumer *retry.Consumer,
+	topic string,
+	groupID string,
+) *Consumer {
+	return &Consumer{
+		mailer:   mailer,
+		inbox:    inbox,
+		consumer: consumer,
+		topic:    topic,
+		groupID:  groupID,
+	}
+}
+
+func (c *Consumer) Run(ctx context.Context) error {
+	logrus.Infof("AuthMailConsumer: subscribing to topic=%s group=%s", c.topic, c.groupID)
+
+	return c.consumer.Subscribe(ctx, c.topic, c.groupID, func(ctx context.Context, key, value []byte) error {
+		event, err := consumer.ParseOrderEvent(value)
+		if err != nil {
+			logrus.Errorf("AuthMailConsumer: failed to parse event: %v", err)
+			return retry.Permanent(err)
+		}
+
+		var send func(ctx context.Context, email email_sender.AccountEmail) error
+		var link string
+
+		switch event.Type {
+		case consumer.UserEmailChangeRequested:
+			send, link = c.mailer.SendEmailChange, event.Payload.ConfirmURL
+		case consumer.UserInvited:
+			send, link = c.mailer.SendInvitation, event.Payload.InviteURL
+		default:
+			return nil
+		}
+
+		if event.Payload.Email == "" || link == "" {
+			logrus.WithField("event_id", event.EventID).Warnf("AuthMailConsumer: %s without email or link", event.Type)
+			return nil
+		}
+
+		// Из retry-топика событие может прийти, когда ссылка уже не работает
+		if !event.Payload.ExpiresAt.IsZero() && event.Payload.ExpiresAt.Before(time.Now()) {
+			logrus.WithField("event_id", event.EventID).Infof("AuthMailConsumer: %s link expired, skipped", event.Type)
+			return nil
+		}
+
+		return c.inbox.ProcessExternal(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+			err := send(ctx, email_sender.AccountEmail{
+				To:        event.Payload.Email,
+				FirstName: event.Payload.FirstName,
+				Link:      link,
+				ExpiresAt: event.Payload.ExpiresAt,
+			})
+			if err != nil {
+				logrus.Errorf("AuthMailConsumer: %s send failed: %v", event.Type, err)
+			}
+			return err
+		})
+	})
+}
@@ -3,38 +3,54 @@ package consumer_booking
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
-	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
+	"github.com/4udiwe/coworking/auth-service/pkg/inbox"
+	"github.com/4udiwe/coworking/auth-service/pkg/retry"
 	notification_builder "github.com/4udiwe/coworking/notification-service/internal/builder"
 	"github.com/4udiwe/coworking/notification-service/internal/consumer"
 	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	digest_service "github.com/4udiwe/coworking/notification-service/internal/service/digest"
 	notification_service "github.com/4udiwe/coworking/notification-service/internal/service/notification"
 )
 
+// Тип уведомления для каждого события бронирования
+var notificationTypes = map[consumer.EventType]entity.NotificationType{
+	consumer.BookingCreated:   entity.BookingCreatedNotificationType,
+	consumer.BookingCancelled: entity.BookingCancelledNotificationType,
+	consumer.BookingCompleted: entity.BookingExpiredNotificationType,
+}
+
 // Обработчик событий для топика booking
 type Consumer struct {
-	service *notification_service.NotificationService
-	builder *notification_builder.DefaultBuilder
+	service       *notification_service.NotificationService
+	digestService *digest_service.DigestService
+	builder       *notification_builder.DefaultBuilder
+	inbox         *inbox.Inbox
 
-	consumer *kafka.KafkaConsumer
+	consumer *retry.Consumer
 	topic    string
 	groupID  string
 }
 
 func New(
 	service *notification_service.NotificationService,
+	digestService *digest_service.DigestService,
 	builder *notification_builder.DefaultBuilder,
-	consumer *kafka.KafkaConsumer,
+	inbox *inbox.Inbox,
+	consumer *retry.Consumer,
 	topic string,
 	groupID string,
 ) *Consumer {
 	return &Consumer{
-		service:  service,
-		builder:  builder,
-		consumer: consumer,
-		topic:    topic,
-		groupID:  groupID,
+		service:       service,
+		digestService: digestService,
+		builder:       builder,
+		inbox:         inbox,
+		consumer:      consumer,
+		topic:         topic,
+		groupID:       groupID,
 	}
 }
 
@@ -45,36 +61,23 @@ func (c *Consumer) Run(ctx context.Context) error {
 		event, err := consumer.ParseOrderEvent(value)
 		if err != nil {
 			logrus.Errorf("BookingConsumer: failed to parse event: %v", err)
-			return nil
+			return retry.Permanent(err)
 		}
 
-		switch event.Type {
-
-		case consumer.BookingCreated:
-			builderEvent := notification_builder.Event{
-				Type:   entity.BookingCreatedNotificationType,
-				UserID: event.Payload.UserID,
-				Payload: map[string]any{
-					"bookingId":   event.Payload.BookingID,
-					"placeId":     event.Payload.PlaceID,
-					"placeLabel":  event.Payload.PlaceLabel,
-					"startTime":   event.Payload.StartTime,
-					"endTime":     event.Payload.EndTime,
-				},
-			}
-			notification, err := c.builder.Build(builderEvent)
-			if err != nil {
-				logrus.Errorf("BookingConsumer: BookingCreated.BuildNotification failed: %v", err)
-			}
+		notificationType, ok := notificationTypes[event.Type]
+		if !ok {
+			logrus.Errorf("BookingConsumer: unknown event type %s", event.Type)
+			return nil
+		}
 
-			err = c.service.CreateNotification(ctx, notification)
-			if err != nil {
-				logrus.Errorf("BookingConsumer: BookingCreated.CreateNotification failed: %v", err)
+		return c.inbox.Process(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+			if err := c.trackForDigest(ctx, event); err != nil {
+				logrus.Errorf("BookingConsumer: %s.TrackForDigest failed: %v", event.Type, err)
+				return err
 			}
 
-		case consumer.BookingCancelled:
-			builderEvent := notification_builder.Event{
-				Type:   entity.BookingCancelledNotificationType,
+			notification, err := c.builder.Build(ctx, notification_builder.Event{
+				Type:   notificationType,
 				UserID: event.Payload.UserID,
 				Payload: map[string]any{
 					"bookingId":  event.Payload.BookingID,
@@ -83,44 +86,45 @@ func (c *Consumer) Run(ctx context.Context) error {
 					"startTime":  event.Payload.StartTime,
 					"endTime":    event.Payload.EndTime,
 				},
-			}
-			notification, err := c.builder.Build(builderEvent)
+			})
 			if err != nil {
-				logrus.Errorf("BookingConsumer: BookingCancelled.BuildNotification failed: %v", err)
+				logrus.Errorf("BookingConsumer: %s.BuildNotification failed: %v", event.Type, err)
+				return err
 			}
 
 			err = c.service.CreateNotification(ctx, notification)
 			if err != nil {
-				logrus.Errorf("BookingConsumer: BookingCancelled.CreateNotification failed: %v", err)
-			}
-
-		case consumer.BookingCompleted:
-			builderEvent := notification_builder.Event{
-				Type:   entity.BookingExpiredNotificationType,
-				UserID: event.Payload.UserID,
-				Payload: map[string]any{
-					"bookingId":  event.Payload.BookingID,
-					"placeId":    event.Payload.PlaceID,
-					"placeLabel": event.Payload.PlaceLabel,
-					"startTime":  event.Payload.StartTime,
-					"endTime":    event.Payload.EndTime,
-				},
-			}
-			notification, err := c.builder.Build(builderEvent)
-			if err != nil {
-				logrus.Errorf("BookingConsumer: BookingCompleted.BuildNotification failed: %v", err)
+				logrus.Errorf("BookingConsumer: %s.CreateNotification failed: %v", event.Type, err)
 			}
+			return err
+		})
+	})
+}
 
-			err = c.service.CreateNotification(ctx, notification)
-			if err != nil {
-				logrus.Errorf("BookingConsumer: BookingCompleted.CreateNotification failed: %v", err)
-			}
+// trackForDigest обновляет список предстоящих бронирований для дайджестов
+func (c *Consumer) trackForDigest(ctx context.Context, event *consumer.IncomingEvent) error {
+	switch event.Type {
 
-		default:
-			logrus.Errorf("BookingConsumer: unknown event type %s", event.Type)
+	case consumer.BookingCreated:
+		if event.Payload.StartTime.IsZero() || event.Payload.EndTime.IsZero() {
 			return nil
 		}
+		booking := entity.DigestBooking{
+			BookingID:  event.Payload.BookingID,
+			UserID:     event.Payload.UserID,
+			PlaceLabel: event.Payload.PlaceLabel,
+			StartTime:  event.Payload.StartTime,
+			EndTime:    event.Payload.EndTime,
+		}
+		if event.Payload.PlaceID != uuid.Nil {
+			booking.PlaceID = &event.Payload.PlaceID
+		}
+		return c.digestService.TrackBooking(ctx, booking)
 
-		return err
-	})
+	case consumer.BookingCancelled, consumer.BookingCompleted:
+		return c.digestService.ForgetBooking(ctx, event.Payload.BookingID)
+
+	default:
+		return nil
+	}
 }
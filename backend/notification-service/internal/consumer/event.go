@@ -14,13 +14,29 @@ const (
 	BookingCancelled EventType = "booking.cancelled"
 	BookingCompleted EventType = "booking.completed"
 
-	ReminderTriggered EventType = "reminder.triggered"
+	ReminderTriggered      EventType = "reminder.triggered"
+	ReminderEndApproaching EventType = "reminder.end_approaching"
 
 	NotificationCreated EventType = "notification.created"
+	AnnouncementDue     EventType = "announcement.due"
+
+	// Периодические задачи scheduler-service
+	NotificationsRetention EventType = "notifications.retention"
+	NotificationsDigest    EventType = "notifications.digest"
+
+	UserRegistered EventType = "auth.user.registered"
+	UserUpdated    EventType = "auth.user.updated"
+	UserDeleted    EventType = "auth.user.deleted"
+
+	// Письма аккаунта из топика auth.mail
+	UserEmailChangeRequested EventType = "auth.user.email_change_requested"
+	UserInvited              EventType = "auth.user.invited"
 )
 
 // Тип для обработки входящего события
 type IncomingEvent struct {
+	// Идентификатор из kafka.Envelope, ключ дедупликации
+	EventID    uuid.UUID
 	Type       EventType
 	OccurredAt time.Time
 	Payload    Payload
@@ -38,4 +54,17 @@ type Payload struct {
 	StartTime        time.Time `json:"startTime,omitzero"`
 	EndTime          time.Time `json:"endTime,omitzero"`
 	Reason           string    `json:"reason,omitempty"`
+	MinutesBefore    int       `json:"minutesBefore,omitempty"`
+	Email            string    `json:"email,omitempty"`
+	FirstName        string    `json:"firstName,omitempty"`
+	AnnouncementID   uuid.UUID `json:"announcementId,omitempty"`
+	ConfirmURL       string    `json:"confirmUrl,omitempty"`
+	InviteURL        string    `json:"inviteUrl,omitempty"`
+	ExpiresAt        time.Time `json:"expiresAt,omitzero"`
+
+	ReadRetentionDays int `json:"readRetentionDays,omitempty"`
+	UnreadArchiveDays int `json:"unreadArchiveDays,omitempty"`
+
+	// Плановое время запуска периодической задачи (notifications.digest)
+	ScheduledAt time.Time `json:"scheduledAt,omitzero"`
 }
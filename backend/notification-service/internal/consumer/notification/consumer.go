@@ -2,59 +2,126 @@ package consumer_notification
 
 import (
 	"context"
+	"errors"
 
 	"github.com/sirupsen/logrus"
 
-	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
+	"github.com/4udiwe/coworking/auth-service/pkg/inbox"
+	"github.com/4udiwe/coworking/auth-service/pkg/retry"
 	"github.com/4udiwe/coworking/notification-service/internal/consumer"
+	announcement_service "github.com/4udiwe/coworking/notification-service/internal/service/announcement"
+	digest_service "github.com/4udiwe/coworking/notification-service/internal/service/digest"
 	notification_service "github.com/4udiwe/coworking/notification-service/internal/service/notification"
 )
 
 // Обработчик событий для топика notification
 type Consumer struct {
-	service  *notification_service.NotificationService
-	consumer *kafka.KafkaConsumer
-	topic    string
-	groupID  string
+	service             *notification_service.NotificationService
+	announcementService *announcement_service.AnnouncementService
+	digestService       *digest_service.DigestService
+	inbox               *inbox.Inbox
+	consumer            *retry.Consumer
+	topic               string
+	groupID             string
 }
 
 func New(
 	service *notification_service.NotificationService,
-	consumer *kafka.KafkaConsumer,
+	announcementService *announcement_service.AnnouncementService,
+	digestService *digest_service.DigestService,
+	inbox *inbox.Inbox,
+	consumer *retry.Consumer,
 	topic string,
 	groupID string,
 ) *Consumer {
 	return &Consumer{
-		service:  service,
-		consumer: consumer,
-		topic:    topic,
-		groupID:  groupID,
+		service:             service,
+		announcementService: announcementService,
+		digestService:       digestService,
+		inbox:               inbox,
+		consumer:            consumer,
+		topic:               topic,
+		groupID:             groupID,
 	}
 }
 
 func (c *Consumer) Run(ctx context.Context) error {
-	logrus.Infof("BookingConsumer: subscribing to topic=%s group=%s", c.topic, c.groupID)
+	logrus.Infof("NotificationConsumer: subscribing to topic=%s group=%s", c.topic, c.groupID)
 
 	return c.consumer.Subscribe(ctx, c.topic, c.groupID, func(ctx context.Context, key, value []byte) error {
 		event, err := consumer.ParseOrderEvent(value)
 		if err != nil {
 			logrus.Errorf("NotificationConsumer: failed to parse event: %v", err)
-			return nil
+			return retry.Permanent(err)
 		}
 
 		switch event.Type {
 
 		case consumer.NotificationCreated:
-			err := c.service.NotifyUser(ctx, event.Payload.NotificationID)
-			if err != nil {
-				logrus.Errorf("NotificationConsumer: NotificationCreated.NotifyUser failed: %v", err)
+			// Отправка — внешний вызов: журнал доставок должен сохраниться,
+			// даже если часть каналов не сработала и событие уйдёт на повтор
+			return c.inbox.ProcessExternal(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+				err := c.service.NotifyUser(ctx, event.Payload.NotificationID)
+				// Уведомление удалено вместе с данными пользователя
+				// или при отзыве рассылки — отправлять нечего
+				if errors.Is(err, notification_service.ErrNotificationNotFound) {
+					logrus.Infof("NotificationConsumer: notification %s no longer exists, skipping", event.Payload.NotificationID)
+					return nil
+				}
+				if err != nil {
+					logrus.Errorf("NotificationConsumer: NotificationCreated.NotifyUser failed: %v", err)
+				}
+				return err
+			})
+
+		case consumer.AnnouncementDue:
+			// Рассылка идемпотентна по статусу и сохраняет получателей
+			// собственными транзакциями, поэтому не держит транзакцию inbox
+			return c.inbox.ProcessExternal(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+				err := c.announcementService.Send(ctx, event.Payload.AnnouncementID)
+				if errors.Is(err, announcement_service.ErrAnnouncementNotFound) {
+					return retry.Permanent(err)
+				}
+				if err != nil {
+					logrus.Errorf("NotificationConsumer: AnnouncementDue.Send failed: %v", err)
+				}
+				return err
+			})
+
+		case consumer.NotificationsRetention:
+			// Очистка идёт батчами в отдельных транзакциях и повторяема:
+			// повторный запуск просто не найдёт уже обработанных строк
+			return c.inbox.ProcessExternal(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+				err := c.service.ApplyRetention(
+					ctx,
+					event.Payload.ReadRetentionDays,
+					event.Payload.UnreadArchiveDays,
+				)
+				if err != nil {
+					logrus.Errorf("NotificationConsumer: NotificationsRetention.ApplyRetention failed: %v", err)
+				}
+				return err
+			})
+
+		case consumer.NotificationsDigest:
+			// Час дайджеста — плановое время запуска задачи, а не момент
+			// публикации: задержка outbox или повтор из retry-топика
+			// обработают тот же час, digest_log не даст дублей
+			if event.Payload.ScheduledAt.IsZero() {
+				logrus.Errorf("NotificationConsumer: %s without scheduledAt", event.Type)
+				return retry.Permanent(errors.New("digest event without scheduledAt"))
 			}
+			return c.inbox.ProcessExternal(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+				err := c.digestService.SendDigests(ctx, event.Payload.ScheduledAt)
+				if err != nil {
+					logrus.Errorf("NotificationConsumer: NotificationsDigest.SendDigests failed: %v", err)
+				}
+				return err
+			})
 
 		default:
 			logrus.Errorf("NotificationConsumer: unknown event type %s", event.Type)
 			return nil
 		}
-
-		return err
 	})
 }
@@ -5,36 +5,50 @@ import (
 
 	"github.com/sirupsen/logrus"
 
-	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
+	"github.com/4udiwe/coworking/auth-service/pkg/inbox"
+	"github.com/4udiwe/coworking/auth-service/pkg/retry"
 	notification_builder "github.com/4udiwe/coworking/notification-service/internal/builder"
 	"github.com/4udiwe/coworking/notification-service/internal/consumer"
 	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	digest_service "github.com/4udiwe/coworking/notification-service/internal/service/digest"
 	notification_service "github.com/4udiwe/coworking/notification-service/internal/service/notification"
 )
 
+// Тип уведомления для каждого события планировщика
+var notificationTypes = map[consumer.EventType]entity.NotificationType{
+	consumer.ReminderTriggered:      entity.BookingReminderNotificationType,
+	consumer.ReminderEndApproaching: entity.BookingEndReminderNotificationType,
+}
+
 // Обработчик событий для топика scheduler
 type Consumer struct {
-	service *notification_service.NotificationService
-	builder *notification_builder.DefaultBuilder
+	service       *notification_service.NotificationService
+	digestService *digest_service.DigestService
+	builder       *notification_builder.DefaultBuilder
+	inbox         *inbox.Inbox
 
-	consumer *kafka.KafkaConsumer
+	consumer *retry.Consumer
 	topic    string
 	groupID  string
 }
 
 func New(
 	service *notification_service.NotificationService,
+	digestService *digest_service.DigestService,
 	builder *notification_builder.DefaultBuilder,
-	consumer *kafka.KafkaConsumer,
+	inbox *inbox.Inbox,
+	consumer *retry.Consumer,
 	topic string,
 	groupID string,
 ) *Consumer {
 	return &Consumer{
-		service:  service,
-		builder:  builder,
-		consumer: consumer,
-		topic:    topic,
-		groupID:  groupID,
+		service:       service,
+		digestService: digestService,
+		builder:       builder,
+		inbox:         inbox,
+		consumer:      consumer,
+		topic:         topic,
+		groupID:       groupID,
 	}
 }
 
@@ -45,38 +59,51 @@ func (c *Consumer) Run(ctx context.Context) error {
 		event, err := consumer.ParseOrderEvent(value)
 		if err != nil {
 			logrus.Errorf("SchedulerConsumer: failed to parse event: %v", err)
+			return retry.Permanent(err)
+		}
+
+		notificationType, ok := notificationTypes[event.Type]
+		if !ok {
+			logrus.Errorf("SchedulerConsumer: unknown event type %s", event.Type)
 			return nil
 		}
 
-		switch event.Type {
+		return c.inbox.Process(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+			// Бронирование уже было в утреннем дайджесте пользователя
+			if event.Type == consumer.ReminderTriggered {
+				suppressed, err := c.digestService.ReminderSuppressed(ctx, event.Payload.BookingID)
+				if err != nil {
+					logrus.Errorf("SchedulerConsumer: %s.ReminderSuppressed failed: %v", event.Type, err)
+					return err
+				}
+				if suppressed {
+					logrus.WithField("booking_id", event.Payload.BookingID).Debug("reminder suppressed by digest")
+					return nil
+				}
+			}
 
-		case consumer.ReminderTriggered:
-			builderEvent := notification_builder.Event{
-				Type:   entity.BookingReminderNotificationType,
+			notification, err := c.builder.Build(ctx, notification_builder.Event{
+				Type:   notificationType,
 				UserID: event.Payload.UserID,
 				Payload: map[string]any{
-					"bookingId":   event.Payload.BookingID,
-					"placeId":     event.Payload.PlaceID,
-					"placeLabel":  event.Payload.PlaceLabel,
-					"startTime":   event.Payload.StartTime,
-					"endTime":     event.Payload.EndTime,
+					"bookingId":     event.Payload.BookingID,
+					"placeId":       event.Payload.PlaceID,
+					"placeLabel":    event.Payload.PlaceLabel,
+					"startTime":     event.Payload.StartTime,
+					"endTime":       event.Payload.EndTime,
+					"minutesBefore": event.Payload.MinutesBefore,
 				},
-			}
-			notification, err := c.builder.Build(builderEvent)
+			})
 			if err != nil {
-				logrus.Errorf("SchedulerConsumer: ReminderTriggered.BuildNotification failed: %v", err)
+				logrus.Errorf("SchedulerConsumer: %s.BuildNotification failed: %v", event.Type, err)
+				return err
 			}
 
 			err = c.service.CreateNotification(ctx, notification)
 			if err != nil {
-				logrus.Errorf("SchedulerConsumer: ReminderTriggered.CreateNotification failed: %v", err)
+				logrus.Errorf("SchedulerConsumer: %s.CreateNotification failed: %v", event.Type, err)
 			}
-
-		default:
-			logrus.Errorf("SchedulerConsumer: unknown event type %s", event.Type)
-			return nil
-		}
-
-		return err
+			return err
+		})
 	})
 }
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO notification_type (id, name) VALUES
+(5, 'booking_end_reminder');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM notification WHERE notification_type_id = 5;
+DELETE FROM notification_type WHERE id = 5;
+-- +goose StatementEnd
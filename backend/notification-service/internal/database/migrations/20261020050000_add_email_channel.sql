@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ==============================
+-- USER CONTACTS
+-- ==============================
+
+-- Адрес для email-уведомлений. Заполняется из auth.user.registered и
+-- auth.user.updated, поэтому всегда совпадает с подтверждённым email.
+CREATE TABLE user_contact (
+    user_id UUID PRIMARY KEY,
+
+    email TEXT NOT NULL,
+    first_name TEXT NOT NULL DEFAULT '',
+
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- ==============================
+-- NOTIFICATION PREFERENCES
+-- ==============================
+
+-- Отсутствие строки означает, что включены все каналы.
+CREATE TABLE notification_preferences (
+    user_id UUID PRIMARY KEY,
+
+    push_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    email_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS user_contact;
+-- +goose StatementEnd
@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE notification_preferences
+    ADD COLUMN timezone TEXT NOT NULL DEFAULT 'Europe/Moscow',
+    ADD COLUMN quiet_hours_start TIME NULL,
+    ADD COLUMN quiet_hours_end TIME NULL,
+    ADD COLUMN digest VARCHAR(16) NOT NULL DEFAULT 'off',
+    ADD CONSTRAINT chk_notification_preferences_quiet_hours
+        CHECK ((quiet_hours_start IS NULL) = (quiet_hours_end IS NULL)),
+    ADD CONSTRAINT chk_notification_preferences_digest
+        CHECK (digest IN ('off', 'daily', 'weekly'));
+
+-- ==============================
+-- CHANNELS
+-- ==============================
+
+CREATE TABLE notification_channel (
+    id SMALLINT PRIMARY KEY,
+    name VARCHAR(16) NOT NULL UNIQUE
+);
+
+INSERT INTO notification_channel (id, name) VALUES
+(1, 'push'),
+(2, 'email');
+
+-- ==============================
+-- PER-TYPE OVERRIDES
+-- ==============================
+
+-- Хранятся только явно заданные значения, отсутствие строки — канал включён
+CREATE TABLE notification_type_preference (
+    user_id UUID NOT NULL,
+
+    notification_type_id SMALLINT NOT NULL
+        REFERENCES notification_type(id) ON DELETE CASCADE,
+    channel_id SMALLINT NOT NULL
+        REFERENCES notification_channel(id),
+
+    enabled BOOLEAN NOT NULL,
+
+    PRIMARY KEY (user_id, notification_type_id, channel_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notification_type_preference;
+DROP TABLE IF EXISTS notification_channel;
+
+ALTER TABLE notification_preferences
+    DROP CONSTRAINT IF EXISTS chk_notification_preferences_digest,
+    DROP CONSTRAINT IF EXISTS chk_notification_preferences_quiet_hours,
+    DROP COLUMN IF EXISTS digest,
+    DROP COLUMN IF EXISTS quiet_hours_end,
+    DROP COLUMN IF EXISTS quiet_hours_start,
+    DROP COLUMN IF EXISTS timezone;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Язык текстов уведомлений пользователя
+ALTER TABLE notification_preferences
+    ADD COLUMN locale VARCHAR(8) NOT NULL DEFAULT 'ru';
+
+-- ==============================
+-- TEMPLATE OVERRIDES
+-- ==============================
+
+-- Тексты, заданные администратором. Встроенные тексты лежат в коде,
+-- отсутствие строки — используется встроенный.
+CREATE TABLE notification_template (
+    notification_type_id SMALLINT NOT NULL
+        REFERENCES notification_type(id) ON DELETE CASCADE,
+    locale VARCHAR(8) NOT NULL,
+
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+
+    updated_by UUID NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (notification_type_id, locale)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notification_template;
+
+ALTER TABLE notification_preferences
+    DROP COLUMN IF EXISTS locale;
+-- +goose StatementEnd
@@ -0,0 +1,65 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Момент первой успешной доставки push хотя бы на одно устройство
+ALTER TABLE notification
+    ADD COLUMN delivered_at TIMESTAMPTZ NULL;
+
+-- ==============================
+-- PUSH DELIVERY LOG
+-- ==============================
+
+CREATE TABLE push_delivery_status (
+    id SMALLSERIAL PRIMARY KEY,
+    name VARCHAR(32) NOT NULL UNIQUE
+);
+
+INSERT INTO push_delivery_status (name) VALUES
+('queued'),
+('sent'),
+('failed'),
+('invalid_token'),
+('dead');
+
+-- Одна строка на пару уведомление-устройство. Токен хранится копией:
+-- устройство может быть удалено, а история доставки должна остаться.
+CREATE TABLE push_delivery (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    notification_id UUID NOT NULL
+        REFERENCES notification(id) ON DELETE CASCADE,
+    device_id UUID NULL
+        REFERENCES user_device(id) ON DELETE SET NULL,
+
+    device_token TEXT NOT NULL,
+    platform VARCHAR(32) NOT NULL,
+
+    status_id SMALLINT NOT NULL
+        REFERENCES push_delivery_status(id)
+        DEFAULT 1,
+
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ NULL,
+
+    UNIQUE (notification_id, device_token)
+);
+
+-- Очередь воркера повторов: только queued и failed
+CREATE INDEX idx_push_delivery_due
+    ON push_delivery (next_attempt_at)
+    WHERE status_id IN (1, 3);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS push_delivery;
+DROP TABLE IF EXISTS push_delivery_status;
+
+ALTER TABLE notification
+    DROP COLUMN IF EXISTS delivered_at;
+-- +goose StatementEnd
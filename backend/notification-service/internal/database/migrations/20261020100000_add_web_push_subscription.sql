@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Подписка Web Push для устройств с platform = 'web'.
+-- device_token у них совпадает с endpoint.
+ALTER TABLE user_device
+    ADD COLUMN endpoint TEXT NULL,
+    ADD COLUMN p256dh TEXT NULL,
+    ADD COLUMN auth_secret TEXT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_device
+    DROP COLUMN IF EXISTS endpoint,
+    DROP COLUMN IF EXISTS p256dh,
+    DROP COLUMN IF EXISTS auth_secret;
+-- +goose StatementEnd
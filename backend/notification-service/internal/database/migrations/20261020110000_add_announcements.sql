@@ -0,0 +1,77 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ==============================
+-- OUTBOX TOPIC
+-- ==============================
+
+-- Топик события; NULL — основной топик сервиса.
+-- Запросы таймеров уходят в scheduler.timers отдельным воркером.
+ALTER TABLE outbox
+    ADD COLUMN topic VARCHAR(128) NULL;
+
+-- ==============================
+-- ANNOUNCEMENTS
+-- ==============================
+
+CREATE TABLE announcement_status (
+    id SMALLSERIAL PRIMARY KEY,
+    name VARCHAR(32) NOT NULL UNIQUE
+);
+
+INSERT INTO announcement_status (id, name) VALUES
+(1, 'scheduled'),
+(2, 'sending'),
+(3, 'sent'),
+(4, 'retracted');
+
+CREATE TABLE announcement (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    action_url TEXT NULL,
+
+    -- all | role | coworking | users
+    target VARCHAR(16) NOT NULL,
+    target_role VARCHAR(64) NULL,
+    target_coworking_id UUID NULL,
+    target_user_ids UUID[] NULL,
+
+    status_id SMALLINT NOT NULL REFERENCES announcement_status(id) DEFAULT 1,
+
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ NULL,
+    retracted_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX idx_announcement_created_at ON announcement (created_at DESC);
+
+INSERT INTO notification_type (id, name) VALUES
+(6, 'announcement');
+
+-- Уведомления рассылки: при отзыве удаляются вместе с объявлением,
+-- уникальный индекс делает повторную рассылку батча безопасной
+ALTER TABLE notification
+    ADD COLUMN announcement_id UUID NULL REFERENCES announcement(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX idx_notification_announcement_user
+    ON notification (announcement_id, user_id)
+    WHERE announcement_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_notification_announcement_user;
+ALTER TABLE notification DROP COLUMN IF EXISTS announcement_id;
+
+DELETE FROM notification WHERE notification_type_id = 6;
+DELETE FROM notification_type WHERE id = 6;
+
+DROP TABLE IF EXISTS announcement;
+DROP TABLE IF EXISTS announcement_status;
+
+ALTER TABLE outbox DROP COLUMN IF EXISTS topic;
+-- +goose StatementEnd
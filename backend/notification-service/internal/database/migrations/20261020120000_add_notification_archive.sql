@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Непрочитанные уведомления старше срока хранения переносятся сюда
+-- и больше не показываются в списке и счётчике; остаются в выгрузке данных
+CREATE TABLE notification_archive (
+    id UUID PRIMARY KEY,
+
+    user_id UUID NOT NULL,
+
+    notification_type_id SMALLINT NOT NULL
+        REFERENCES notification_type(id),
+
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    payload JSONB,
+    action_url TEXT NULL,
+
+    created_at TIMESTAMPTZ NOT NULL,
+    delivered_at TIMESTAMPTZ NULL,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_notification_archive_user
+    ON notification_archive (user_id);
+
+-- Курсорная пагинация GET /notifications
+CREATE INDEX idx_notification_user_created_id
+    ON notification (user_id, created_at DESC, id DESC);
+
+-- Удаление прочитанных по сроку хранения
+CREATE INDEX idx_notification_read_at
+    ON notification (read_at)
+    WHERE read_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_notification_read_at;
+DROP INDEX IF EXISTS idx_notification_user_created_id;
+DROP TABLE IF EXISTS notification_archive;
+-- +goose StatementEnd
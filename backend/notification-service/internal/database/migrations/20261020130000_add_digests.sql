@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+
+INSERT INTO notification_type (id, name) VALUES
+(7, 'booking_digest');
+
+-- ==============================
+-- DIGEST BOOKINGS
+-- ==============================
+
+-- Предстоящие бронирования по событиям booking-service: создаются на
+-- booking.created, удаляются на booking.cancelled и booking.completed
+CREATE TABLE digest_booking (
+    booking_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+
+    place_id UUID NULL,
+    place_label TEXT NOT NULL DEFAULT '',
+
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+
+    -- Бронирование вошло в отправленный дайджест, напоминание о начале не нужно
+    digested_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX idx_digest_booking_user_start
+    ON digest_booking (user_id, start_time);
+
+CREATE INDEX idx_digest_booking_end_time
+    ON digest_booking (end_time);
+
+-- ==============================
+-- DIGEST LOG
+-- ==============================
+
+-- Один дайджест на пользователя и период; повторная обработка события
+-- scheduler-service не отправляет его второй раз
+CREATE TABLE digest_log (
+    user_id UUID NOT NULL,
+    mode VARCHAR(16) NOT NULL,
+    -- Первый день периода в часовом поясе пользователя
+    period_start DATE NOT NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, mode, period_start)
+);
+
+CREATE INDEX idx_digest_log_created_at
+    ON digest_log (created_at);
+
+-- Выбор получателей по режиму дайджеста
+CREATE INDEX idx_notification_preferences_digest
+    ON notification_preferences (digest)
+    WHERE digest <> 'off';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_notification_preferences_digest;
+DROP TABLE IF EXISTS digest_log;
+DROP TABLE IF EXISTS digest_booking;
+
+DELETE FROM notification WHERE notification_type_id = 7;
+DELETE FROM notification_archive WHERE notification_type_id = 7;
+DELETE FROM notification_type WHERE id = 7;
+-- +goose StatementEnd
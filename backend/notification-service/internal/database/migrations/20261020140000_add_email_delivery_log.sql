@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ==============================
+-- EMAIL DELIVERY LOG
+-- ==============================
+
+-- Одна строка на отправленное письмо. Если другой канал упал и событие
+-- обрабатывается повторно, письмо по уведомлению второй раз не уходит.
+CREATE TABLE email_delivery (
+    notification_id UUID PRIMARY KEY
+        REFERENCES notification(id) ON DELETE CASCADE,
+
+    email TEXT NOT NULL,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS email_delivery;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Плановое время запуска задачи notification.digest, которым отправлен
+-- дайджест. По нему же считается срок хранения журнала.
+ALTER TABLE digest_log
+    ADD COLUMN scheduled_at TIMESTAMPTZ NULL;
+
+UPDATE digest_log
+SET scheduled_at = date_trunc('hour', created_at);
+
+ALTER TABLE digest_log
+    ALTER COLUMN scheduled_at SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_digest_log_created_at;
+
+CREATE INDEX idx_digest_log_scheduled_at
+    ON digest_log (scheduled_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_digest_log_scheduled_at;
+
+CREATE INDEX idx_digest_log_created_at
+    ON digest_log (created_at);
+
+ALTER TABLE digest_log
+    DROP COLUMN IF EXISTS scheduled_at;
+-- +goose StatementEnd
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const AnnouncementNotificationType NotificationType = "announcement"
+
+// AnnouncementTarget — кому адресована рассылка
+type AnnouncementTarget string
+
+const (
+	AnnouncementTargetAll       AnnouncementTarget = "all"
+	AnnouncementTargetRole      AnnouncementTarget = "role"
+	AnnouncementTargetCoworking AnnouncementTarget = "coworking"
+	AnnouncementTargetUsers     AnnouncementTarget = "users"
+)
+
+type AnnouncementStatus string
+
+const (
+	// Ждёт времени отправки (таймер в scheduler-service или событие в outbox)
+	AnnouncementScheduled AnnouncementStatus = "scheduled"
+	// Получатели сохраняются батчами; повторная рассылка продолжает с того же места
+	AnnouncementSending   AnnouncementStatus = "sending"
+	AnnouncementSent      AnnouncementStatus = "sent"
+	AnnouncementRetracted AnnouncementStatus = "retracted"
+)
+
+/*
+Announcement — рассылка администратора.
+
+Target определяет, какое из полей Target* заполнено:
+role — TargetRole, coworking — TargetCoworkingID (пользователи с активными
+бронями), users — TargetUserIDs, all — ни одно.
+*/
+type Announcement struct {
+	ID uuid.UUID
+
+	Title     string
+	Body      string
+	ActionURL *string
+
+	Target            AnnouncementTarget
+	TargetRole        *string
+	TargetCoworkingID *uuid.UUID
+	TargetUserIDs     []uuid.UUID
+
+	Status AnnouncementStatus
+
+	ScheduledAt time.Time
+	CreatedBy   uuid.UUID
+	CreatedAt   time.Time
+	SentAt      *time.Time
+	RetractedAt *time.Time
+}
+
+// AnnouncementStats — охват рассылки по её уведомлениям
+type AnnouncementStats struct {
+	Recipients int
+	Delivered  int
+	Read       int
+}
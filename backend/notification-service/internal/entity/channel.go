@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Channel string
+
+const (
+	ChannelPush  Channel = "push"
+	ChannelEmail Channel = "email"
+)
+
+// Channels — все каналы доставки в порядке отправки
+var Channels = []Channel{ChannelPush, ChannelEmail}
+
+// UserContact — адрес пользователя для email-канала
+type UserContact struct {
+	UserID uuid.UUID
+
+	Email     string
+	FirstName string
+
+	UpdatedAt time.Time
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeliveryStatus string
+
+const (
+	// Ожидает отправки: первая попытка или повтор по расписанию
+	DeliveryQueued DeliveryStatus = "queued"
+	DeliverySent   DeliveryStatus = "sent"
+	// Попытка не удалась, следующая — в NextAttemptAt
+	DeliveryFailed DeliveryStatus = "failed"
+	// Токен отозван или некорректен, устройство удалено
+	DeliveryInvalidToken DeliveryStatus = "invalid_token"
+	// Попытки исчерпаны; повторить можно только вручную
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+// PushDelivery — доставка push-уведомления на одно устройство
+type PushDelivery struct {
+	ID             uuid.UUID
+	NotificationID uuid.UUID
+
+	// DeviceID == nil — устройство уже удалено
+	DeviceID    *uuid.UUID
+	DeviceToken string
+	Platform    string
+
+	Status        DeliveryStatus
+	Attempts      int
+	LastError     *string
+	NextAttemptAt time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	SentAt    *time.Time
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const BookingDigestNotificationType NotificationType = "booking_digest"
+
+// DigestBooking — предстоящее бронирование пользователя для дайджеста
+type DigestBooking struct {
+	BookingID uuid.UUID
+	UserID    uuid.UUID
+
+	PlaceID    *uuid.UUID
+	PlaceLabel string
+
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// DigestRecipient — пользователь, которому пора отправить дайджест
+type DigestRecipient struct {
+	UserID   uuid.UUID
+	Mode     DigestMode
+	Timezone string
+}
+
+// Location — часовой пояс получателя, как у NotificationPreferences
+func (r DigestRecipient) Location() *time.Location {
+	return NotificationPreferences{Timezone: r.Timezone}.Location()
+}
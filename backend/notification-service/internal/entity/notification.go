@@ -16,10 +16,11 @@ const (
 type NotificationType string
 
 const (
-	BookingCreatedNotificationType   NotificationType = "booking_created"
-	BookingCancelledNotificationType NotificationType = "booking_cancelled"
-	BookingReminderNotificationType  NotificationType = "booking_reminder"
-	BookingExpiredNotificationType   NotificationType = "booking_expired"
+	BookingCreatedNotificationType     NotificationType = "booking_created"
+	BookingCancelledNotificationType   NotificationType = "booking_cancelled"
+	BookingReminderNotificationType    NotificationType = "booking_reminder"
+	BookingExpiredNotificationType     NotificationType = "booking_expired"
+	BookingEndReminderNotificationType NotificationType = "booking_end_reminder"
 )
 
 type Notification struct {
@@ -39,4 +40,28 @@ type Notification struct {
 
 	CreatedAt time.Time
 	ReadAt    *time.Time
+
+	// Момент первой успешной доставки push хотя бы на одно устройство
+	DeliveredAt *time.Time
+}
+
+// NotificationCursor — позиция в списке уведомлений пользователя,
+// отсортированном по (CreatedAt, ID) от новых к старым
+type NotificationCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// NotificationFilter — выборка уведомлений пользователя. Пустые поля не фильтруют.
+type NotificationFilter struct {
+	IsRead *bool
+	Types  []NotificationType
+	// Интервал по CreatedAt: [From, To)
+	From *time.Time
+	To   *time.Time
+
+	Limit int
+	// После Cursor; без курсора — устаревшее смещение Offset
+	Cursor *NotificationCursor
+	Offset int
 }
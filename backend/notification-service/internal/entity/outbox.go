@@ -28,4 +28,7 @@ type OutboxEvent struct {
 	Status        OutboxStatus
 	CreatedAt     time.Time
 	ProcessedAt   *time.Time
+
+	// Пустой — основной топик сервиса (outbox.topic в конфиге)
+	Topic string
 }
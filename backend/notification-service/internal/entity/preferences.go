@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Часовой пояс пользователя, который его не указал
+const DefaultTimezone = "Europe/Moscow"
+
+// Locale — язык текстов уведомлений
+type Locale string
+
+const (
+	LocaleRU Locale = "ru"
+	LocaleEN Locale = "en"
+)
+
+// Язык пользователя, который его не выбрал
+const DefaultLocale = LocaleRU
+
+// Locales — все поддерживаемые языки
+var Locales = []Locale{LocaleRU, LocaleEN}
+
+type DigestMode string
+
+const (
+	DigestOff    DigestMode = "off"
+	DigestDaily  DigestMode = "daily"
+	DigestWeekly DigestMode = "weekly"
+)
+
+// NotificationTypes — все типы уведомлений, доступные для настройки
+var NotificationTypes = []NotificationType{
+	BookingCreatedNotificationType,
+	BookingCancelledNotificationType,
+	BookingReminderNotificationType,
+	BookingExpiredNotificationType,
+	BookingEndReminderNotificationType,
+	AnnouncementNotificationType,
+	BookingDigestNotificationType,
+}
+
+// QuietHours — интервал [Start, End) в минутах от полуночи в часовом поясе
+// пользователя. Start > End — интервал через полночь (23:00–07:00).
+type QuietHours struct {
+	Start int
+	End   int
+}
+
+func (q QuietHours) Contains(minute int) bool {
+	if q.Start <= q.End {
+		return minute >= q.Start && minute < q.End
+	}
+	return minute >= q.Start || minute < q.End
+}
+
+/*
+NotificationPreferences — настройки доставки уведомлений пользователя.
+
+Канал используется для типа уведомления, если он включён глобально
+(PushEnabled/EmailEnabled) и не отключён для этого типа в Types.
+In-app уведомление сохраняется всегда, настройки влияют только на отправку.
+*/
+type NotificationPreferences struct {
+	UserID uuid.UUID
+
+	PushEnabled  bool
+	EmailEnabled bool
+
+	// Переопределения по типам. Отсутствие записи — канал включён.
+	Types map[NotificationType]map[Channel]bool
+
+	Locale     Locale
+	Timezone   string
+	QuietHours *QuietHours
+
+	Digest DigestMode
+
+	UpdatedAt time.Time
+}
+
+// DefaultPreferences — настройки пользователя, который их не менял
+func DefaultPreferences(userID uuid.UUID) NotificationPreferences {
+	return NotificationPreferences{
+		UserID:       userID,
+		PushEnabled:  true,
+		EmailEnabled: true,
+		Types:        map[NotificationType]map[Channel]bool{},
+		Locale:       DefaultLocale,
+		Timezone:     DefaultTimezone,
+		Digest:       DigestOff,
+	}
+}
+
+func (p NotificationPreferences) Enabled(notificationType NotificationType, channel Channel) bool {
+	switch channel {
+	case ChannelPush:
+		if !p.PushEnabled {
+			return false
+		}
+	case ChannelEmail:
+		if !p.EmailEnabled {
+			return false
+		}
+	default:
+		return false
+	}
+
+	enabled, ok := p.Types[notificationType][channel]
+	return !ok || enabled
+}
+
+// Location — часовой пояс пользователя; неизвестный считается UTC
+func (p NotificationPreferences) Location() *time.Location {
+	location, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// InQuietHours проверяет момент now по часовому поясу пользователя
+func (p NotificationPreferences) InQuietHours(now time.Time) bool {
+	if p.QuietHours == nil {
+		return false
+	}
+
+	local := now.In(p.Location())
+	return p.QuietHours.Contains(local.Hour()*60 + local.Minute())
+}
+
+// Channels возвращает каналы, по которым уведомление нужно отправить сейчас.
+// В тихие часы push не отправляется; письмо не будит, поэтому email уходит.
+func (p NotificationPreferences) Channels(notificationType NotificationType, now time.Time) []Channel {
+	quiet := p.InQuietHours(now)
+
+	var channels []Channel
+	for _, ch := range Channels {
+		if !p.Enabled(notificationType, ch) {
+			continue
+		}
+		if ch == ChannelPush && quiet {
+			continue
+		}
+		channels = append(channels, ch)
+	}
+
+	return channels
+}
@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestQuietHours_Contains(t *testing.T) {
+	tests := []struct {
+		name   string
+		quiet  QuietHours
+		minute int
+		want   bool
+	}{
+		{name: "same_day_inside", quiet: QuietHours{Start: 13 * 60, End: 14 * 60}, minute: 13*60 + 30, want: true},
+		{name: "same_day_start_inclusive", quiet: QuietHours{Start: 13 * 60, End: 14 * 60}, minute: 13 * 60, want: true},
+		{name: "same_day_end_exclusive", quiet: QuietHours{Start: 13 * 60, End: 14 * 60}, minute: 14 * 60, want: false},
+		{name: "same_day_before", quiet: QuietHours{Start: 13 * 60, End: 14 * 60}, minute: 12 * 60, want: false},
+		{name: "midnight_wrap_evening", quiet: QuietHours{Start: 23 * 60, End: 7 * 60}, minute: 23*60 + 30, want: true},
+		{name: "midnight_wrap_midnight", quiet: QuietHours{Start: 23 * 60, End: 7 * 60}, minute: 0, want: true},
+		{name: "midnight_wrap_morning", quiet: QuietHours{Start: 23 * 60, End: 7 * 60}, minute: 6*60 + 59, want: true},
+		{name: "midnight_wrap_end_exclusive", quiet: QuietHours{Start: 23 * 60, End: 7 * 60}, minute: 7 * 60, want: false},
+		{name: "midnight_wrap_day", quiet: QuietHours{Start: 23 * 60, End: 7 * 60}, minute: 12 * 60, want: false},
+		{name: "empty_interval", quiet: QuietHours{Start: 8 * 60, End: 8 * 60}, minute: 8 * 60, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.quiet.Contains(tt.minute); got != tt.want {
+				t.Errorf("Contains(%d) = %v, want %v", tt.minute, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationPreferences_Channels(t *testing.T) {
+	// 20:30 UTC = 23:30 по Москве
+	night := time.Date(2026, 10, 19, 20, 30, 0, 0, time.UTC)
+	day := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	quiet := &QuietHours{Start: 23 * 60, End: 7 * 60}
+
+	tests := []struct {
+		name  string
+		prefs func(p *NotificationPreferences)
+		now   time.Time
+		want  []Channel
+	}{
+		{
+			name: "defaults",
+			now:  day,
+			want: []Channel{ChannelPush, ChannelEmail},
+		},
+		{
+			name:  "push_disabled_globally",
+			prefs: func(p *NotificationPreferences) { p.PushEnabled = false },
+			now:   day,
+			want:  []Channel{ChannelEmail},
+		},
+		{
+			name: "email_disabled_for_type",
+			prefs: func(p *NotificationPreferences) {
+				p.Types[BookingReminderNotificationType] = map[Channel]bool{ChannelEmail: false}
+			},
+			now:  day,
+			want: []Channel{ChannelPush},
+		},
+		{
+			name: "override_for_other_type_ignored",
+			prefs: func(p *NotificationPreferences) {
+				p.Types[BookingCreatedNotificationType] = map[Channel]bool{ChannelPush: false, ChannelEmail: false}
+			},
+			now:  day,
+			want: []Channel{ChannelPush, ChannelEmail},
+		},
+		{
+			name: "type_override_cannot_enable_disabled_channel",
+			prefs: func(p *NotificationPreferences) {
+				p.EmailEnabled = false
+				p.Types[BookingReminderNotificationType] = map[Channel]bool{ChannelEmail: true}
+			},
+			now:  day,
+			want: []Channel{ChannelPush},
+		},
+		{
+			name:  "quiet_hours_in_user_timezone_drop_push",
+			prefs: func(p *NotificationPreferences) { p.QuietHours = quiet },
+			now:   night,
+			want:  []Channel{ChannelEmail},
+		},
+		{
+			name: "quiet_hours_outside_in_user_timezone",
+			prefs: func(p *NotificationPreferences) {
+				p.QuietHours = quiet
+				// 20:30 UTC = 13:30 в Лос-Анджелесе
+				p.Timezone = "America/Los_Angeles"
+			},
+			now:  night,
+			want: []Channel{ChannelPush, ChannelEmail},
+		},
+		{
+			name: "everything_disabled",
+			prefs: func(p *NotificationPreferences) {
+				p.PushEnabled = false
+				p.EmailEnabled = false
+			},
+			now: day,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefs := DefaultPreferences(uuid.New())
+			if tt.prefs != nil {
+				tt.prefs(&prefs)
+			}
+
+			got := prefs.Channels(BookingReminderNotificationType, tt.now)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Channels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
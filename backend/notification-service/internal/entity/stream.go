@@ -0,0 +1,25 @@
+package entity
+
+import "github.com/google/uuid"
+
+type StreamEventType string
+
+const (
+	StreamNotificationCreated StreamEventType = "notification.created"
+	StreamNotificationRead    StreamEventType = "notification.read"
+	StreamAllRead             StreamEventType = "notification.read_all"
+
+	// Реплика переподключилась к Postgres и могла пропустить события
+	// или уведомления удалены (отозвана рассылка):
+	// клиенту нужно перечитать счётчик непрочитанных
+	StreamResync StreamEventType = "resync"
+)
+
+// StreamEvent — изменение уведомлений пользователя для открытых потоков
+type StreamEvent struct {
+	Type   StreamEventType
+	UserID uuid.UUID
+
+	// Только для StreamNotificationCreated и StreamNotificationRead
+	NotificationID *uuid.UUID
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+NotificationTemplate — текст уведомления на одном языке.
+
+Title и Body — шаблоны text/template. Встроенные тексты лежат в коде,
+администратор может переопределить их без релиза; переопределение
+хранится в БД и действует, пока его не сбросят.
+*/
+type NotificationTemplate struct {
+	Type   NotificationType
+	Locale Locale
+
+	Title string
+	Body  string
+
+	// true — текст задан администратором, а не встроенный
+	Overridden bool
+	UpdatedBy  *uuid.UUID
+	UpdatedAt  *time.Time
+}
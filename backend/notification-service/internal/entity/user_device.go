@@ -6,6 +6,10 @@ import (
 	"github.com/google/uuid"
 )
 
+// PlatformWeb — браузер с подпиской Web Push. Остальные платформы
+// (android, ios) получают push через FCM по DeviceToken.
+const PlatformWeb = "web"
+
 type UserDevice struct {
 	ID uuid.UUID
 
@@ -14,5 +18,17 @@ type UserDevice struct {
 	DeviceToken string
 	Platform    string
 
+	// Подписка Web Push, только для PlatformWeb. DeviceToken у таких
+	// устройств совпадает с Endpoint.
+	Subscription *WebPushSubscription
+
 	CreatedAt time.Time
 }
+
+// WebPushSubscription — PushSubscription браузера: адрес push-сервиса
+// и ключи шифрования payload (RFC 8291) в base64url.
+type WebPushSubscription struct {
+	Endpoint string
+	P256dh   string
+	Auth     string
+}
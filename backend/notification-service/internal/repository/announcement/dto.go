@@ -0,0 +1,47 @@
+package announcement_repository
+
+import (
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type rawAnnouncement struct {
+	ID        uuid.UUID `db:"id"`
+	Title     string    `db:"title"`
+	Body      string    `db:"body"`
+	ActionURL *string   `db:"action_url"`
+
+	Target            string      `db:"target"`
+	TargetRole        *string     `db:"target_role"`
+	TargetCoworkingID *uuid.UUID  `db:"target_coworking_id"`
+	TargetUserIDs     []uuid.UUID `db:"target_user_ids"`
+
+	Status string `db:"status"`
+
+	ScheduledAt time.Time  `db:"scheduled_at"`
+	CreatedBy   uuid.UUID  `db:"created_by"`
+	CreatedAt   time.Time  `db:"created_at"`
+	SentAt      *time.Time `db:"sent_at"`
+	RetractedAt *time.Time `db:"retracted_at"`
+}
+
+func (r rawAnnouncement) toEntity() entity.Announcement {
+	return entity.Announcement{
+		ID:                r.ID,
+		Title:             r.Title,
+		Body:              r.Body,
+		ActionURL:         r.ActionURL,
+		Target:            entity.AnnouncementTarget(r.Target),
+		TargetRole:        r.TargetRole,
+		TargetCoworkingID: r.TargetCoworkingID,
+		TargetUserIDs:     r.TargetUserIDs,
+		Status:            entity.AnnouncementStatus(r.Status),
+		ScheduledAt:       r.ScheduledAt,
+		CreatedBy:         r.CreatedBy,
+		CreatedAt:         r.CreatedAt,
+		SentAt:            r.SentAt,
+		RetractedAt:       r.RetractedAt,
+	}
+}
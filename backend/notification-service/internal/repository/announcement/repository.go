@@ -0,0 +1,229 @@
+package announcement_repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+var ErrAnnouncementNotFound = errors.New("announcement not found")
+
+// Колонки рассылки для выборок из a с подставленным именем статуса
+const announcementColumns = `
+	a.id,
+	a.title,
+	a.body,
+	a.action_url,
+	a.target,
+	a.target_role,
+	a.target_coworking_id,
+	a.target_user_ids,
+	s.name AS status,
+	a.scheduled_at,
+	a.created_by,
+	a.created_at,
+	a.sent_at,
+	a.retracted_at
+`
+
+type AnnouncementRepository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *AnnouncementRepository {
+	return &AnnouncementRepository{
+		Postgres: pg,
+	}
+}
+
+func (r *AnnouncementRepository) Create(
+	ctx context.Context,
+	announcement entity.Announcement,
+) (entity.Announcement, error) {
+	query := `
+		WITH a AS (
+			INSERT INTO announcement (
+				title,
+				body,
+				action_url,
+				target,
+				target_role,
+				target_coworking_id,
+				target_user_ids,
+				status_id,
+				scheduled_at,
+				created_by
+			) VALUES (
+				$1, $2, $3, $4, $5, $6, $7,
+				(SELECT id FROM announcement_status WHERE name = $8),
+				$9, $10
+			)
+			RETURNING *
+		)
+		SELECT ` + announcementColumns + `
+		FROM a
+		JOIN announcement_status s ON s.id = a.status_id
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query,
+		announcement.Title,
+		announcement.Body,
+		announcement.ActionURL,
+		announcement.Target,
+		announcement.TargetRole,
+		announcement.TargetCoworkingID,
+		announcement.TargetUserIDs,
+		entity.AnnouncementScheduled,
+		announcement.ScheduledAt,
+		announcement.CreatedBy,
+	)
+	if err != nil {
+		logrus.WithField("created_by", announcement.CreatedBy).
+			WithError(err).
+			Error("failed to create announcement")
+		return entity.Announcement{}, err
+	}
+
+	raw, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[rawAnnouncement])
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect announcement")
+		return entity.Announcement{}, err
+	}
+
+	return raw.toEntity(), nil
+}
+
+func (r *AnnouncementRepository) GetByID(ctx context.Context, id uuid.UUID) (entity.Announcement, error) {
+	return r.get(ctx, id, "")
+}
+
+// GetForUpdate блокирует рассылку до конца транзакции, чтобы смена статуса
+// не пересекалась с отправкой или отзывом на другой реплике
+func (r *AnnouncementRepository) GetForUpdate(ctx context.Context, id uuid.UUID) (entity.Announcement, error) {
+	return r.get(ctx, id, "FOR UPDATE OF a")
+}
+
+func (r *AnnouncementRepository) get(ctx context.Context, id uuid.UUID, lock string) (entity.Announcement, error) {
+	query := `
+		SELECT ` + announcementColumns + `
+		FROM announcement a
+		JOIN announcement_status s ON s.id = a.status_id
+		WHERE a.id = $1
+	` + lock
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, id)
+	if err != nil {
+		logrus.WithField("announcement_id", id).WithError(err).Error("failed to fetch announcement")
+		return entity.Announcement{}, err
+	}
+
+	raw, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[rawAnnouncement])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Announcement{}, ErrAnnouncementNotFound
+		}
+		logrus.WithError(err).Error("failed to collect announcement")
+		return entity.Announcement{}, err
+	}
+
+	return raw.toEntity(), nil
+}
+
+// List возвращает страницу рассылок, новые первыми, и их общее количество
+func (r *AnnouncementRepository) List(
+	ctx context.Context,
+	limit, offset int,
+) ([]entity.Announcement, int, error) {
+	query := `
+		SELECT ` + announcementColumns + `
+		FROM announcement a
+		JOIN announcement_status s ON s.id = a.status_id
+		ORDER BY a.created_at DESC, a.id
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, limit, offset)
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch announcements")
+		return nil, 0, err
+	}
+
+	raws, err := pgx.CollectRows(rows, pgx.RowToStructByName[rawAnnouncement])
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect announcements")
+		return nil, 0, err
+	}
+
+	var total int
+	if err := r.GetTxManager(ctx).QueryRow(ctx, "SELECT COUNT(*) FROM announcement").Scan(&total); err != nil {
+		logrus.WithError(err).Error("failed to count announcements")
+		return nil, 0, err
+	}
+
+	announcements := make([]entity.Announcement, 0, len(raws))
+	for _, raw := range raws {
+		announcements = append(announcements, raw.toEntity())
+	}
+
+	return announcements, total, nil
+}
+
+// UpdateStatus меняет статус рассылки; для sent и retracted запоминает момент перехода
+func (r *AnnouncementRepository) UpdateStatus(
+	ctx context.Context,
+	id uuid.UUID,
+	status entity.AnnouncementStatus,
+) error {
+	query := `
+		UPDATE announcement
+		SET
+			status_id    = (SELECT id FROM announcement_status WHERE name = $2),
+			sent_at      = CASE WHEN $2 = 'sent' THEN NOW() ELSE sent_at END,
+			retracted_at = CASE WHEN $2 = 'retracted' THEN NOW() ELSE retracted_at END
+		WHERE id = $1
+	`
+
+	tag, err := r.GetTxManager(ctx).Exec(ctx, query, id, status)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"announcement_id": id,
+			"status":          status,
+		}).WithError(err).Error("failed to update announcement status")
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrAnnouncementNotFound
+	}
+
+	return nil
+}
+
+// GetStats считает охват по уведомлениям рассылки: отозванная рассылка
+// уведомлений не имеет, поэтому её охват нулевой
+func (r *AnnouncementRepository) GetStats(ctx context.Context, id uuid.UUID) (entity.AnnouncementStats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(n.delivered_at),
+			COUNT(*) FILTER (WHERE ns.name = $2)
+		FROM notification n
+		JOIN notification_status ns ON ns.id = n.status_id
+		WHERE n.announcement_id = $1
+	`
+
+	var stats entity.AnnouncementStats
+	err := r.GetTxManager(ctx).QueryRow(ctx, query, id, entity.StatusRead).
+		Scan(&stats.Recipients, &stats.Delivered, &stats.Read)
+	if err != nil {
+		logrus.WithField("announcement_id", id).WithError(err).Error("failed to fetch announcement stats")
+		return entity.AnnouncementStats{}, err
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,26 @@
+package contact_repository
+
+import (
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type rawContact struct {
+	UserID uuid.UUID `db:"user_id"`
+
+	Email     string `db:"email"`
+	FirstName string `db:"first_name"`
+
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (r rawContact) toEntity() entity.UserContact {
+	return entity.UserContact{
+		UserID:    r.UserID,
+		Email:     r.Email,
+		FirstName: r.FirstName,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
@@ -0,0 +1,98 @@
+package contact_repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+var ErrContactNotFound = errors.New("contact not found")
+
+type ContactRepository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *ContactRepository {
+	return &ContactRepository{
+		Postgres: pg,
+	}
+}
+
+func (r *ContactRepository) Upsert(ctx context.Context, contact entity.UserContact) error {
+	query, args, _ := r.Builder.
+		Insert("user_contact").
+		Columns(
+			"user_id",
+			"email",
+			"first_name",
+		).
+		Values(
+			contact.UserID,
+			contact.Email,
+			contact.FirstName,
+		).
+		Suffix(`
+			ON CONFLICT (user_id)
+			DO UPDATE SET
+				email      = EXCLUDED.email,
+				first_name = EXCLUDED.first_name,
+				updated_at = NOW()
+		`).
+		ToSql()
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithField("user_id", contact.UserID.String()).
+			WithError(err).
+			Error("failed to upsert user contact")
+		return err
+	}
+
+	return nil
+}
+
+func (r *ContactRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (entity.UserContact, error) {
+	query, args, _ := r.Builder.
+		Select("*").
+		From("user_contact").
+		Where("user_id = ?", userID).
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch user contact")
+		return entity.UserContact{}, err
+	}
+
+	raw, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[rawContact])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.UserContact{}, ErrContactNotFound
+		}
+		logrus.WithError(err).Error("failed to collect user contact")
+		return entity.UserContact{}, err
+	}
+
+	return raw.toEntity(), nil
+}
+
+func (r *ContactRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	query, args, _ := r.Builder.
+		Delete("user_contact").
+		Where("user_id = ?", userID).
+		ToSql()
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithField("user_id", userID.String()).
+			WithError(err).
+			Error("failed to delete user contact")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package delivery_repository
+
+import (
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type rawDelivery struct {
+	ID             uuid.UUID  `db:"id"`
+	NotificationID uuid.UUID  `db:"notification_id"`
+	DeviceID       *uuid.UUID `db:"device_id"`
+
+	DeviceToken string `db:"device_token"`
+	Platform    string `db:"platform"`
+
+	Status        string    `db:"status"`
+	Attempts      int       `db:"attempts"`
+	LastError     *string   `db:"last_error"`
+	NextAttemptAt time.Time `db:"next_attempt_at"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+	SentAt    *time.Time `db:"sent_at"`
+}
+
+func (r rawDelivery) toEntity() entity.PushDelivery {
+	return entity.PushDelivery{
+		ID:             r.ID,
+		NotificationID: r.NotificationID,
+		DeviceID:       r.DeviceID,
+		DeviceToken:    r.DeviceToken,
+		Platform:       r.Platform,
+		Status:         entity.DeliveryStatus(r.Status),
+		Attempts:       r.Attempts,
+		LastError:      r.LastError,
+		NextAttemptAt:  r.NextAttemptAt,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+		SentAt:         r.SentAt,
+	}
+}
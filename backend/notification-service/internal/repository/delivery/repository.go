@@ -0,0 +1,290 @@
+package delivery_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+var ErrDeliveryNotFound = errors.New("push delivery not found")
+
+// Колонки доставки для выборок из CTE d с подставленным именем статуса
+const deliveryColumns = `
+	d.id,
+	d.notification_id,
+	d.device_id,
+	d.device_token,
+	d.platform,
+	s.name AS status,
+	d.attempts,
+	d.last_error,
+	d.next_attempt_at,
+	d.created_at,
+	d.updated_at,
+	d.sent_at
+`
+
+type DeliveryRepository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *DeliveryRepository {
+	return &DeliveryRepository{
+		Postgres: pg,
+	}
+}
+
+// Enqueue создаёт доставку в статусе queued. Повторный вызов для той же пары
+// уведомление-устройство возвращает существующую запись без изменений,
+// поэтому повторная обработка события не приводит к дублям.
+func (r *DeliveryRepository) Enqueue(
+	ctx context.Context,
+	notificationID uuid.UUID,
+	device entity.UserDevice,
+) (entity.PushDelivery, error) {
+	query := `
+		WITH d AS (
+			INSERT INTO push_delivery (notification_id, device_id, device_token, platform)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (notification_id, device_token)
+			DO UPDATE SET device_token = EXCLUDED.device_token
+			RETURNING *
+		)
+		SELECT ` + deliveryColumns + `
+		FROM d
+		JOIN push_delivery_status s ON s.id = d.status_id
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query,
+		notificationID,
+		device.ID,
+		device.DeviceToken,
+		device.Platform,
+	)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"notification_id": notificationID,
+			"device_id":       device.ID,
+		}).WithError(err).Error("failed to enqueue push delivery")
+		return entity.PushDelivery{}, err
+	}
+
+	raw, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[rawDelivery])
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect push delivery")
+		return entity.PushDelivery{}, err
+	}
+
+	return raw.toEntity(), nil
+}
+
+// ClaimDue забирает доставки, которым пора повторить попытку, и сдвигает их
+// next_attempt_at на lease вперёд. Если реплика упадёт, не записав результат,
+// доставка вернётся в очередь по истечении lease.
+func (r *DeliveryRepository) ClaimDue(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]entity.PushDelivery, error) {
+	query := `
+		WITH d AS (
+			UPDATE push_delivery
+			SET next_attempt_at = NOW() + $2 * INTERVAL '1 millisecond'
+			WHERE id IN (
+				SELECT pd.id
+				FROM push_delivery pd
+				JOIN push_delivery_status ps ON ps.id = pd.status_id
+				WHERE ps.name IN ($3, $4)
+					AND pd.next_attempt_at <= NOW()
+				ORDER BY pd.next_attempt_at
+				LIMIT $1
+				FOR UPDATE OF pd SKIP LOCKED
+			)
+			RETURNING *
+		)
+		SELECT ` + deliveryColumns + `
+		FROM d
+		JOIN push_delivery_status s ON s.id = d.status_id
+		ORDER BY d.next_attempt_at
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query,
+		limit,
+		lease.Milliseconds(),
+		entity.DeliveryQueued,
+		entity.DeliveryFailed,
+	)
+	if err != nil {
+		logrus.WithError(err).Error("failed to claim due push deliveries")
+		return nil, err
+	}
+
+	return collectDeliveries(rows)
+}
+
+// RecordAttempt сохраняет результат очередной попытки отправки
+func (r *DeliveryRepository) RecordAttempt(
+	ctx context.Context,
+	id uuid.UUID,
+	status entity.DeliveryStatus,
+	lastError *string,
+	nextAttemptAt time.Time,
+) error {
+	query := `
+		UPDATE push_delivery
+		SET
+			status_id       = (SELECT id FROM push_delivery_status WHERE name = $2),
+			attempts        = attempts + 1,
+			last_error      = $3,
+			next_attempt_at = $4,
+			updated_at      = NOW(),
+			sent_at         = CASE WHEN $2 = 'sent' THEN NOW() ELSE sent_at END
+		WHERE id = $1
+	`
+
+	tag, err := r.GetTxManager(ctx).Exec(ctx, query, id, status, lastError, nextAttemptAt)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"delivery_id": id,
+			"status":      status,
+		}).WithError(err).Error("failed to record push delivery attempt")
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrDeliveryNotFound
+	}
+
+	return nil
+}
+
+func (r *DeliveryRepository) GetByID(ctx context.Context, id uuid.UUID) (entity.PushDelivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM push_delivery d
+		JOIN push_delivery_status s ON s.id = d.status_id
+		WHERE d.id = $1
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, id)
+	if err != nil {
+		logrus.WithField("delivery_id", id).WithError(err).Error("failed to fetch push delivery")
+		return entity.PushDelivery{}, err
+	}
+
+	raw, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[rawDelivery])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.PushDelivery{}, ErrDeliveryNotFound
+		}
+		logrus.WithError(err).Error("failed to collect push delivery")
+		return entity.PushDelivery{}, err
+	}
+
+	return raw.toEntity(), nil
+}
+
+func (r *DeliveryRepository) ListByNotification(
+	ctx context.Context,
+	notificationID uuid.UUID,
+) ([]entity.PushDelivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM push_delivery d
+		JOIN push_delivery_status s ON s.id = d.status_id
+		WHERE d.notification_id = $1
+		ORDER BY d.created_at, d.id
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, notificationID)
+	if err != nil {
+		logrus.WithField("notification_id", notificationID).
+			WithError(err).
+			Error("failed to fetch push deliveries")
+		return nil, err
+	}
+
+	return collectDeliveries(rows)
+}
+
+// Requeue возвращает доставку из dead в очередь с обнулённым счётчиком попыток
+func (r *DeliveryRepository) Requeue(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE push_delivery
+		SET
+			status_id       = (SELECT id FROM push_delivery_status WHERE name = $2),
+			attempts        = 0,
+			next_attempt_at = NOW(),
+			updated_at      = NOW()
+		WHERE id = $1
+			AND status_id = (SELECT id FROM push_delivery_status WHERE name = $3)
+	`
+
+	tag, err := r.GetTxManager(ctx).Exec(ctx, query, id, entity.DeliveryQueued, entity.DeliveryDead)
+	if err != nil {
+		logrus.WithField("delivery_id", id).WithError(err).Error("failed to requeue push delivery")
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrDeliveryNotFound
+	}
+
+	return nil
+}
+
+// IsEmailSent сообщает, уходило ли уже письмо по уведомлению
+func (r *DeliveryRepository) IsEmailSent(ctx context.Context, notificationID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM email_delivery WHERE notification_id = $1)`
+
+	var sent bool
+	err := r.GetTxManager(ctx).QueryRow(ctx, query, notificationID).Scan(&sent)
+	if err != nil {
+		logrus.WithField("notification_id", notificationID).
+			WithError(err).
+			Error("failed to check email delivery")
+		return false, err
+	}
+
+	return sent, nil
+}
+
+// RecordEmailSent отмечает письмо по уведомлению отправленным
+func (r *DeliveryRepository) RecordEmailSent(ctx context.Context, notificationID uuid.UUID, email string) error {
+	query := `
+		INSERT INTO email_delivery (notification_id, email)
+		VALUES ($1, $2)
+		ON CONFLICT (notification_id) DO NOTHING
+	`
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, notificationID, email)
+	if err != nil {
+		logrus.WithField("notification_id", notificationID).
+			WithError(err).
+			Error("failed to record email delivery")
+		return err
+	}
+
+	return nil
+}
+
+func collectDeliveries(rows pgx.Rows) ([]entity.PushDelivery, error) {
+	raws, err := pgx.CollectRows(rows, pgx.RowToStructByName[rawDelivery])
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect push deliveries")
+		return nil, err
+	}
+
+	deliveries := make([]entity.PushDelivery, 0, len(raws))
+	for _, raw := range raws {
+		deliveries = append(deliveries, raw.toEntity())
+	}
+
+	return deliveries, nil
+}
@@ -15,12 +15,16 @@ type rawDevice struct {
 	DeviceToken string `db:"device_token"`
 	Platform    string `db:"platform"`
 
+	Endpoint   *string `db:"endpoint"`
+	P256dh     *string `db:"p256dh"`
+	AuthSecret *string `db:"auth_secret"`
+
 	CreatedAt time.Time `db:"created_at"`
 }
 
 func (r rawDevice) toEntity() entity.UserDevice {
 
-	return entity.UserDevice{
+	device := entity.UserDevice{
 		ID: r.ID,
 
 		UserID: r.UserID,
@@ -30,4 +34,14 @@ func (r rawDevice) toEntity() entity.UserDevice {
 
 		CreatedAt: r.CreatedAt,
 	}
+
+	if r.Endpoint != nil && r.P256dh != nil && r.AuthSecret != nil {
+		device.Subscription = &entity.WebPushSubscription{
+			Endpoint: *r.Endpoint,
+			P256dh:   *r.P256dh,
+			Auth:     *r.AuthSecret,
+		}
+	}
+
+	return device
 }
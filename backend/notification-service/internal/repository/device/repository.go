@@ -2,6 +2,7 @@ package device_repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/4udiwe/avito-pvz/pkg/postgres"
 	"github.com/4udiwe/coworking/notification-service/internal/entity"
@@ -11,6 +12,8 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+var ErrDeviceNotFound = errors.New("device not found")
+
 type DeviceRepository struct {
 	*postgres.Postgres
 }
@@ -26,23 +29,39 @@ func (r *DeviceRepository) Create(
 	device entity.UserDevice,
 ) (uuid.UUID, error) {
 
+	var endpoint, p256dh, authSecret *string
+	if device.Subscription != nil {
+		endpoint = &device.Subscription.Endpoint
+		p256dh = &device.Subscription.P256dh
+		authSecret = &device.Subscription.Auth
+	}
+
 	query, args, _ := r.Builder.
 		Insert("user_device").
 		Columns(
 			"user_id",
 			"device_token",
 			"platform",
+			"endpoint",
+			"p256dh",
+			"auth_secret",
 		).
 		Values(
 			device.UserID,
 			device.DeviceToken,
 			device.Platform,
+			endpoint,
+			p256dh,
+			authSecret,
 		).
 		Suffix(`
 			ON CONFLICT (device_token)
 			DO UPDATE SET
-				user_id  = EXCLUDED.user_id,
-				platform = EXCLUDED.platform
+				user_id     = EXCLUDED.user_id,
+				platform    = EXCLUDED.platform,
+				endpoint    = EXCLUDED.endpoint,
+				p256dh      = EXCLUDED.p256dh,
+				auth_secret = EXCLUDED.auth_secret
 			RETURNING id
 		`).
 		ToSql()
@@ -96,6 +115,38 @@ func (r *DeviceRepository) FindByUserID(
 	}), nil
 }
 
+// FindByToken возвращает устройство по токену; для Web Push токен — endpoint подписки
+func (r *DeviceRepository) FindByToken(
+	ctx context.Context,
+	token string,
+) (entity.UserDevice, error) {
+
+	query, args, _ := r.Builder.
+		Select("*").
+		From("user_device").
+		Where("device_token = ?", token).
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch device by token")
+		return entity.UserDevice{}, err
+	}
+
+	raw, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[rawDevice])
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.UserDevice{}, ErrDeviceNotFound
+		}
+		logrus.WithError(err).Error("failed to collect device")
+		return entity.UserDevice{}, err
+	}
+
+	return raw.toEntity(), nil
+}
+
 func (r *DeviceRepository) DeleteByToken(ctx context.Context, token string) error {
 	query, args, _ := r.Builder.
 		Delete("user_device").
@@ -108,3 +159,19 @@ func (r *DeviceRepository) DeleteByToken(ctx context.Context, token string) erro
 	}
 	return nil
 }
+
+func (r *DeviceRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	query, args, _ := r.Builder.
+		Delete("user_device").
+		Where("user_id = ?", userID).
+		ToSql()
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithField("user_id", userID.String()).
+			WithError(err).
+			Error("failed to delete user devices")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package digest_repository
+
+import (
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type rawDigestBooking struct {
+	BookingID  uuid.UUID  `db:"booking_id"`
+	UserID     uuid.UUID  `db:"user_id"`
+	PlaceID    *uuid.UUID `db:"place_id"`
+	PlaceLabel string     `db:"place_label"`
+	StartTime  time.Time  `db:"start_time"`
+	EndTime    time.Time  `db:"end_time"`
+}
+
+func (r rawDigestBooking) toEntity() entity.DigestBooking {
+	return entity.DigestBooking{
+		BookingID:  r.BookingID,
+		UserID:     r.UserID,
+		PlaceID:    r.PlaceID,
+		PlaceLabel: r.PlaceLabel,
+		StartTime:  r.StartTime,
+		EndTime:    r.EndTime,
+	}
+}
+
+type rawDigestRecipient struct {
+	UserID   uuid.UUID `db:"user_id"`
+	Digest   string    `db:"digest"`
+	Timezone string    `db:"timezone"`
+}
+
+func (r rawDigestRecipient) toEntity() entity.DigestRecipient {
+	return entity.DigestRecipient{
+		UserID:   r.UserID,
+		Mode:     entity.DigestMode(r.Digest),
+		Timezone: r.Timezone,
+	}
+}
@@ -0,0 +1,225 @@
+package digest_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+type DigestRepository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *DigestRepository {
+	return &DigestRepository{
+		Postgres: pg,
+	}
+}
+
+// UpsertBooking сохраняет бронирование; повтор события обновляет данные,
+// но не сбрасывает отметку о включении в дайджест
+func (r *DigestRepository) UpsertBooking(ctx context.Context, booking entity.DigestBooking) error {
+	query := `
+		INSERT INTO digest_booking (
+			booking_id,
+			user_id,
+			place_id,
+			place_label,
+			start_time,
+			end_time
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (booking_id) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			place_id = EXCLUDED.place_id,
+			place_label = EXCLUDED.place_label,
+			start_time = EXCLUDED.start_time,
+			end_time = EXCLUDED.end_time
+	`
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query,
+		booking.BookingID,
+		booking.UserID,
+		booking.PlaceID,
+		booking.PlaceLabel,
+		booking.StartTime,
+		booking.EndTime,
+	)
+	if err != nil {
+		logrus.WithError(err).Error("failed to upsert digest booking")
+		return err
+	}
+
+	return nil
+}
+
+func (r *DigestRepository) DeleteBooking(ctx context.Context, bookingID uuid.UUID) error {
+	query := `DELETE FROM digest_booking WHERE booking_id = $1`
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, bookingID); err != nil {
+		logrus.WithError(err).Error("failed to delete digest booking")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteStale удаляет бронирования, закончившиеся раньше before (событие
+// booking.completed могло не прийти), и журнал дайджестов старше него
+func (r *DigestRepository) DeleteStale(ctx context.Context, before time.Time) error {
+	if _, err := r.GetTxManager(ctx).Exec(ctx,
+		`DELETE FROM digest_booking WHERE end_time < $1`, before,
+	); err != nil {
+		logrus.WithError(err).Error("failed to delete stale digest bookings")
+		return err
+	}
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx,
+		`DELETE FROM digest_log WHERE scheduled_at < $1`, before,
+	); err != nil {
+		logrus.WithError(err).Error("failed to delete stale digest log")
+		return err
+	}
+
+	return nil
+}
+
+/*
+ListRecipients возвращает страницу пользователей, у которых в момент at
+по их часовому поясу наступил час hour (а для weekly — ещё и день weekday,
+0 — воскресенье). Страницы идут по user_id после afterUserID.
+*/
+func (r *DigestRepository) ListRecipients(
+	ctx context.Context,
+	at time.Time,
+	hour int,
+	weekday int,
+	afterUserID uuid.UUID,
+	limit int,
+) ([]entity.DigestRecipient, error) {
+	query := `
+		SELECT user_id, digest, timezone
+		FROM notification_preferences
+		WHERE digest <> 'off'
+			AND user_id > $4
+			AND EXTRACT(HOUR FROM $1::timestamptz AT TIME ZONE timezone) = $2
+			AND (
+				digest = 'daily'
+				OR EXTRACT(DOW FROM $1::timestamptz AT TIME ZONE timezone) = $3
+			)
+		ORDER BY user_id
+		LIMIT $5
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, at, hour, weekday, afterUserID, limit)
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch digest recipients")
+		return nil, err
+	}
+
+	rawRecipients, err := pgx.CollectRows(rows, pgx.RowToStructByName[rawDigestRecipient])
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect digest recipients")
+		return nil, err
+	}
+
+	return lo.Map(rawRecipients, func(r rawDigestRecipient, _ int) entity.DigestRecipient {
+		return r.toEntity()
+	}), nil
+}
+
+// ListBookings возвращает бронирования пользователя, начинающиеся в [from, to)
+func (r *DigestRepository) ListBookings(
+	ctx context.Context,
+	userID uuid.UUID,
+	from, to time.Time,
+) ([]entity.DigestBooking, error) {
+	query := `
+		SELECT booking_id, user_id, place_id, place_label, start_time, end_time
+		FROM digest_booking
+		WHERE user_id = $1
+			AND start_time >= $2
+			AND start_time < $3
+		ORDER BY start_time, booking_id
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, userID, from, to)
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch digest bookings")
+		return nil, err
+	}
+
+	rawBookings, err := pgx.CollectRows(rows, pgx.RowToStructByName[rawDigestBooking])
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect digest bookings")
+		return nil, err
+	}
+
+	return lo.Map(rawBookings, func(b rawDigestBooking, _ int) entity.DigestBooking {
+		return b.toEntity()
+	}), nil
+}
+
+// CreateLog отмечает дайджест периода отправленным запуском scheduledAt;
+// false — он уже был
+func (r *DigestRepository) CreateLog(
+	ctx context.Context,
+	userID uuid.UUID,
+	mode entity.DigestMode,
+	periodStart time.Time,
+	scheduledAt time.Time,
+) (bool, error) {
+	query := `
+		INSERT INTO digest_log (user_id, mode, period_start, scheduled_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+	`
+
+	// Дата берётся в часовом поясе periodStart, а не в UTC
+	tag, err := r.GetTxManager(ctx).Exec(ctx, query, userID, mode, periodStart.Format(time.DateOnly), scheduledAt)
+	if err != nil {
+		logrus.WithError(err).Error("failed to create digest log")
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *DigestRepository) MarkDigested(ctx context.Context, bookingIDs []uuid.UUID) error {
+	query := `
+		UPDATE digest_booking
+		SET digested_at = NOW()
+		WHERE booking_id = ANY($1)
+	`
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, bookingIDs); err != nil {
+		logrus.WithError(err).Error("failed to mark digest bookings")
+		return err
+	}
+
+	return nil
+}
+
+// IsDigested проверяет, вошло ли бронирование в отправленный дайджест
+func (r *DigestRepository) IsDigested(ctx context.Context, bookingID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM digest_booking
+			WHERE booking_id = $1 AND digested_at IS NOT NULL
+		)
+	`
+
+	var digested bool
+	if err := r.GetTxManager(ctx).QueryRow(ctx, query, bookingID).Scan(&digested); err != nil {
+		logrus.WithError(err).Error("failed to check digest booking")
+		return false, err
+	}
+
+	return digested, nil
+}
@@ -20,6 +20,7 @@ type rawNotification struct {
 	StatusName string    `db:"status_name"`
 	CreatedAt time.Time  `db:"created_at"`
 	ReadAt    *time.Time `db:"read_at"`
+	DeliveredAt *time.Time `db:"delivered_at"`
 }
 
 func (r rawNotification) toEntity() entity.Notification {
@@ -35,5 +36,6 @@ func (r rawNotification) toEntity() entity.Notification {
 		IsRead:    r.StatusName == string(entity.StatusRead),
 		CreatedAt: r.CreatedAt,
 		ReadAt:    r.ReadAt,
+		DeliveredAt: r.DeliveredAt,
 	}
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/4udiwe/avito-pvz/pkg/postgres"
 	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/samber/lo"
@@ -94,6 +95,7 @@ func (r *NotificationRepository) FindByUser(
 			"ns.name as status_name",
 			"n.created_at",
 			"n.read_at",
+			"n.delivered_at",
 		).
 		From("notification n").
 		Join("notification_type nt ON n.notification_type_id = nt.id").
@@ -138,6 +140,7 @@ func (r *NotificationRepository) FetchUnreadByUser(ctx context.Context, userID u
 			"ns.name as status_name",
 			"n.created_at",
 			"n.read_at",
+			"n.delivered_at",
 		).
 		From("notification n").
 		Join("notification_type nt ON n.notification_type_id = nt.id").
@@ -197,6 +200,26 @@ func (r *NotificationRepository) MarkRead(
 	return nil
 }
 
+// MarkDelivered фиксирует первую успешную доставку; повторные вызовы ничего не меняют
+func (r *NotificationRepository) MarkDelivered(ctx context.Context, id uuid.UUID) error {
+	query, args, _ := r.Builder.
+		Update("notification").
+		Set("delivered_at", time.Now()).
+		Where("id = ?", id).
+		Where("delivered_at IS NULL").
+		ToSql()
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithField("notification_id", id.String()).
+			WithError(err).
+			Error("failed to mark notification delivered")
+		return err
+	}
+
+	return nil
+}
+
 func (r *NotificationRepository) GetByID(
 	ctx context.Context,
 	ID uuid.UUID,
@@ -216,6 +239,7 @@ func (r *NotificationRepository) GetByID(
 			"ns.name as status_name",
 			"n.created_at",
 			"n.read_at",
+			"n.delivered_at",
 		).
 		From("notification n").
 		Join("notification_type nt ON n.notification_type_id = nt.id").
@@ -248,8 +272,7 @@ func (r *NotificationRepository) GetByID(
 func (r *NotificationRepository) FetchByUser(
 	ctx context.Context,
 	userID uuid.UUID,
-	limit, offset int,
-	isRead *bool,
+	filter entity.NotificationFilter,
 ) ([]entity.Notification, error) {
 
 	builder := r.Builder.
@@ -266,23 +289,43 @@ func (r *NotificationRepository) FetchByUser(
 			"ns.name as status_name",
 			"n.created_at",
 			"n.read_at",
+			"n.delivered_at",
 		).
 		From("notification n").
 		Join("notification_type nt ON n.notification_type_id = nt.id").
 		Join("notification_status ns ON n.status_id = ns.id").
 		Where("n.user_id = ?", userID)
 
-	if isRead != nil {
+	if filter.IsRead != nil {
 		statusName := entity.StatusRead
-		if !*isRead {
+		if !*filter.IsRead {
 			statusName = entity.StatusUnread
 		}
 		builder = builder.Where("ns.name = ?", statusName)
 	}
 
-	builder = builder.OrderBy("n.created_at DESC").
-		Limit(uint64(limit)).
-		Offset(uint64(offset))
+	if len(filter.Types) > 0 {
+		builder = builder.Where(squirrel.Eq{"nt.name": lo.Map(filter.Types, func(t entity.NotificationType, _ int) string {
+			return string(t)
+		})})
+	}
+
+	if filter.From != nil {
+		builder = builder.Where("n.created_at >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		builder = builder.Where("n.created_at < ?", *filter.To)
+	}
+
+	if filter.Cursor != nil {
+		builder = builder.Where("(n.created_at, n.id) < (?, ?)", filter.Cursor.CreatedAt, filter.Cursor.ID)
+	} else if filter.Offset > 0 {
+		builder = builder.Offset(uint64(filter.Offset))
+	}
+
+	builder = builder.OrderBy("n.created_at DESC", "n.id DESC").
+		Limit(uint64(filter.Limit))
 
 	query, args, _ := builder.ToSql()
 
@@ -362,14 +405,13 @@ func (r *NotificationRepository) GetUnreadCount(
 	return count, nil
 }
 
-func (r *NotificationRepository) FetchAfterDate(
+// Все уведомления пользователя без пагинации — для выгрузки его данных.
+func (r *NotificationRepository) FetchAllByUser(
 	ctx context.Context,
 	userID uuid.UUID,
-	since time.Time,
-	limit, offset int,
 ) ([]entity.Notification, error) {
 
-	builder := r.Builder.
+	query, args, _ := r.Builder.
 		Select(
 			"n.id",
 			"n.user_id",
@@ -383,22 +425,19 @@ func (r *NotificationRepository) FetchAfterDate(
 			"ns.name as status_name",
 			"n.created_at",
 			"n.read_at",
+			"n.delivered_at",
 		).
 		From("notification n").
 		Join("notification_type nt ON n.notification_type_id = nt.id").
 		Join("notification_status ns ON n.status_id = ns.id").
 		Where("n.user_id = ?", userID).
-		Where("n.created_at >= ?", since).
 		OrderBy("n.created_at DESC").
-		Limit(uint64(limit)).
-		Offset(uint64(offset))
-
-	query, args, _ := builder.ToSql()
+		ToSql()
 
 	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
 
 	if err != nil {
-		logrus.WithError(err).Error("failed to fetch notifications after date")
+		logrus.WithError(err).Error("failed to fetch all notifications")
 		return nil, err
 	}
 
@@ -407,7 +446,385 @@ func (r *NotificationRepository) FetchAfterDate(
 	rawNotifications, err := pgx.CollectRows(rows, pgx.RowToStructByName[rawNotification])
 
 	if err != nil {
-		logrus.WithError(err).Error("failed to collect notifications after date")
+		logrus.WithError(err).Error("failed to collect all notifications")
+		return nil, err
+	}
+
+	return lo.Map(rawNotifications, func(r rawNotification, _ int) entity.Notification {
+		return r.toEntity()
+	}), nil
+}
+
+func (r *NotificationRepository) DeleteByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+) error {
+
+	query, args, _ := r.Builder.
+		Delete("notification").
+		Where("user_id = ?", userID).
+		ToSql()
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+
+	if err != nil {
+		logrus.WithField("user_id", userID.String()).
+			WithError(err).
+			Error("failed to delete user notifications")
+
+		return err
+	}
+
+	_, err = r.GetTxManager(ctx).Exec(ctx, "DELETE FROM notification_archive WHERE user_id = $1", userID)
+
+	if err != nil {
+		logrus.WithField("user_id", userID.String()).
+			WithError(err).
+			Error("failed to delete user archived notifications")
+
+		return err
+	}
+
+	// Предстоящие бронирования и журнал дайджестов пользователя
+	for _, query := range []string{
+		"DELETE FROM digest_booking WHERE user_id = $1",
+		"DELETE FROM digest_log WHERE user_id = $1",
+	} {
+		if _, err := r.GetTxManager(ctx).Exec(ctx, query, userID); err != nil {
+			logrus.WithField("user_id", userID.String()).
+				WithError(err).
+				Error("failed to delete user digest data")
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateForAnnouncement сохраняет уведомления рассылки для userIDs и возвращает
+// только созданные: получатели, которым уведомление уже сохранено при прошлой
+// попытке, пропускаются
+func (r *NotificationRepository) CreateForAnnouncement(
+	ctx context.Context,
+	announcement entity.Announcement,
+	userIDs []uuid.UUID,
+	payload []byte,
+) ([]entity.Notification, error) {
+
+	query := `
+		INSERT INTO notification (
+			user_id,
+			notification_type_id,
+			title,
+			body,
+			payload,
+			action_url,
+			status_id,
+			announcement_id
+		)
+		SELECT
+			u.user_id,
+			(SELECT id FROM notification_type WHERE name = $2),
+			$3,
+			$4,
+			$5,
+			$6,
+			1, -- unread
+			$7
+		FROM unnest($1::uuid[]) AS u(user_id)
+		ON CONFLICT (announcement_id, user_id) WHERE announcement_id IS NOT NULL
+		DO NOTHING
+		RETURNING id, user_id, created_at
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(
+		ctx,
+		query,
+		userIDs,
+		entity.AnnouncementNotificationType,
+		announcement.Title,
+		announcement.Body,
+		payload,
+		announcement.ActionURL,
+		announcement.ID,
+	)
+
+	if err != nil {
+		logrus.WithField("announcement_id", announcement.ID.String()).
+			WithError(err).
+			Error("failed to create announcement notifications")
+		return nil, err
+	}
+
+	notifications, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Notification, error) {
+		n := entity.Notification{
+			Type:      entity.AnnouncementNotificationType,
+			Title:     announcement.Title,
+			Body:      announcement.Body,
+			Payload:   payload,
+			ActionURL: announcement.ActionURL,
+		}
+		err := row.Scan(&n.ID, &n.UserID, &n.CreatedAt)
+		return n, err
+	})
+
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect announcement notifications")
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// DeleteByAnnouncement удаляет уведомления рассылки и возвращает пользователей,
+// у которых удалённое уведомление было непрочитанным
+func (r *NotificationRepository) DeleteByAnnouncement(
+	ctx context.Context,
+	announcementID uuid.UUID,
+) ([]uuid.UUID, error) {
+
+	query := `
+		DELETE FROM notification
+		WHERE announcement_id = $1
+		RETURNING user_id, status_id = 1 AS unread
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, announcementID)
+
+	if err != nil {
+		logrus.WithField("announcement_id", announcementID.String()).
+			WithError(err).
+			Error("failed to delete announcement notifications")
+		return nil, err
+	}
+
+	type deleted struct {
+		UserID uuid.UUID `db:"user_id"`
+		Unread bool      `db:"unread"`
+	}
+
+	rawDeleted, err := pgx.CollectRows(rows, pgx.RowToStructByName[deleted])
+
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect deleted announcement notifications")
+		return nil, err
+	}
+
+	return lo.FilterMap(rawDeleted, func(d deleted, _ int) (uuid.UUID, bool) {
+		return d.UserID, d.Unread
+	}), nil
+}
+
+// MarkReadByIDs отмечает прочитанными непрочитанные уведомления пользователя из ids
+// и возвращает изменённые; чужие и уже прочитанные пропускаются
+func (r *NotificationRepository) MarkReadByIDs(
+	ctx context.Context,
+	userID uuid.UUID,
+	ids []uuid.UUID,
+) ([]uuid.UUID, error) {
+
+	query := `
+		UPDATE notification n
+		SET status_id = ns.id,
+			read_at = NOW()
+		FROM notification_status ns
+		WHERE n.user_id = $1
+			AND n.id = ANY($2)
+			AND ns.name = $3
+			AND n.status_id != ns.id
+		RETURNING n.id
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, userID, ids, entity.StatusRead)
+
+	if err != nil {
+		logrus.WithField("user_id", userID.String()).
+			WithError(err).
+			Error("failed to mark notifications as read")
+		return nil, err
+	}
+
+	updated, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect read notifications")
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteByIDs удаляет уведомления пользователя из ids; чужие пропускаются
+func (r *NotificationRepository) DeleteByIDs(
+	ctx context.Context,
+	userID uuid.UUID,
+	ids []uuid.UUID,
+) (int64, error) {
+
+	tag, err := r.GetTxManager(ctx).Exec(ctx,
+		"DELETE FROM notification WHERE user_id = $1 AND id = ANY($2)",
+		userID,
+		ids,
+	)
+
+	if err != nil {
+		logrus.WithField("user_id", userID.String()).
+			WithError(err).
+			Error("failed to delete notifications")
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// DeleteReadBefore удаляет до limit уведомлений, прочитанных раньше before
+func (r *NotificationRepository) DeleteReadBefore(
+	ctx context.Context,
+	before time.Time,
+	limit int,
+) (int64, error) {
+
+	query := `
+		DELETE FROM notification
+		WHERE id IN (
+			SELECT id
+			FROM notification
+			WHERE read_at < $1
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+	`
+
+	tag, err := r.GetTxManager(ctx).Exec(ctx, query, before, limit)
+
+	if err != nil {
+		logrus.WithError(err).Error("failed to delete read notifications")
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// ArchiveUnreadBefore переносит в архив до limit непрочитанных уведомлений,
+// созданных раньше before, и возвращает их число и получателей без повторов
+func (r *NotificationRepository) ArchiveUnreadBefore(
+	ctx context.Context,
+	before time.Time,
+	limit int,
+) (int64, []uuid.UUID, error) {
+
+	query := `
+		WITH moved AS (
+			DELETE FROM notification
+			WHERE id IN (
+				SELECT n.id
+				FROM notification n
+				JOIN notification_status ns ON n.status_id = ns.id
+				WHERE ns.name = $3
+					AND n.created_at < $1
+				LIMIT $2
+				FOR UPDATE OF n SKIP LOCKED
+			)
+			RETURNING *
+		), archived AS (
+			INSERT INTO notification_archive (
+				id,
+				user_id,
+				notification_type_id,
+				title,
+				body,
+				payload,
+				action_url,
+				created_at,
+				delivered_at
+			)
+			SELECT
+				id,
+				user_id,
+				notification_type_id,
+				title,
+				body,
+				payload,
+				action_url,
+				created_at,
+				delivered_at
+			FROM moved
+			ON CONFLICT (id) DO NOTHING
+		)
+		SELECT user_id, COUNT(*) AS archived
+		FROM moved
+		GROUP BY user_id
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, before, limit, entity.StatusUnread)
+
+	if err != nil {
+		logrus.WithError(err).Error("failed to archive unread notifications")
+		return 0, nil, err
+	}
+
+	type archivedByUser struct {
+		UserID   uuid.UUID `db:"user_id"`
+		Archived int64     `db:"archived"`
+	}
+
+	rawArchived, err := pgx.CollectRows(rows, pgx.RowToStructByName[archivedByUser])
+
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect archived notification users")
+		return 0, nil, err
+	}
+
+	var total int64
+	userIDs := make([]uuid.UUID, 0, len(rawArchived))
+	for _, a := range rawArchived {
+		total += a.Archived
+		userIDs = append(userIDs, a.UserID)
+	}
+
+	return total, userIDs, nil
+}
+
+// Архивные уведомления пользователя — для выгрузки его данных
+func (r *NotificationRepository) FetchArchivedByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]entity.Notification, error) {
+
+	query := `
+		SELECT
+			a.id,
+			a.user_id,
+			a.notification_type_id,
+			nt.name AS notification_type_name,
+			a.title,
+			a.body,
+			a.payload,
+			a.action_url,
+			ns.id AS status_id,
+			ns.name AS status_name,
+			a.created_at,
+			NULL::timestamptz AS read_at,
+			a.delivered_at
+		FROM notification_archive a
+		JOIN notification_type nt ON a.notification_type_id = nt.id
+		JOIN notification_status ns ON ns.name = $2
+		WHERE a.user_id = $1
+		ORDER BY a.created_at DESC
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, userID, entity.StatusUnread)
+
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch archived notifications")
+		return nil, err
+	}
+
+	rawNotifications, err := pgx.CollectRows(rows, pgx.RowToStructByName[rawNotification])
+
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect archived notifications")
 		return nil, err
 	}
 
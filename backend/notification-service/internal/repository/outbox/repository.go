@@ -14,22 +14,35 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// Repository отдаёт воркеру события только своего топика: nil — основной топик
+// сервиса (outbox.topic IS NULL), иначе — события с явно заданным топиком
 type Repository struct {
 	*postgres.Postgres
+	topic *string
 }
 
 func New(pg *postgres.Postgres) *Repository {
 	return &Repository{Postgres: pg}
 }
 
+// NewForTopic — репозиторий для отдельного воркера, публикующего в topic
+func NewForTopic(pg *postgres.Postgres, topic string) *Repository {
+	return &Repository{Postgres: pg, topic: &topic}
+}
+
 func (r *Repository) Create(ctx context.Context, ev entity.OutboxEvent) error {
 	logrus.Infof("OutboxRepository.Create: aggregate=%s id=%s type=%s",
 		ev.AggregateType, ev.AggregateID, ev.EventType)
 
+	var topic *string
+	if ev.Topic != "" {
+		topic = &ev.Topic
+	}
+
 	query, args, _ := r.Builder.
 		Insert("outbox").
-		Columns("aggregate_type", "aggregate_id", "event_type", "payload").
-		Values(ev.AggregateType, ev.AggregateID, ev.EventType, ev.Payload).
+		Columns("aggregate_type", "aggregate_id", "event_type", "payload", "topic").
+		Values(ev.AggregateType, ev.AggregateID, ev.EventType, ev.Payload, topic).
 		Suffix("RETURNING id").
 		ToSql()
 
@@ -54,13 +67,13 @@ func (r *Repository) FetchPending(ctx context.Context, limit int) ([]outbox.Even
 			o.created_at, o.processed_at
 		FROM outbox o
 		JOIN outbox_status s ON s.id = o.status_id
-		WHERE s.name = $1
+		WHERE s.name = $1 AND o.topic IS NOT DISTINCT FROM $3
 		ORDER BY o.created_at
 		LIMIT $2
 		FOR UPDATE SKIP LOCKED;
 	`
 
-	rows, err := r.GetTxManager(ctx).Query(ctx, query, entity.OutboxStatusPending, limit)
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, entity.OutboxStatusPending, limit, r.topic)
 	if err != nil {
 		logrus.Errorf("OutboxRepository.FetchPending: query error: %v", err)
 		return nil, err
@@ -132,13 +145,13 @@ func (r *Repository) RequeueFailed(ctx context.Context, limit int) ([]outbox.Eve
 			o.created_at, o.processed_at
 		FROM outbox o
 		JOIN outbox_status s ON s.id = o.status_id
-		WHERE s.name = $1
+		WHERE s.name = $1 AND o.topic IS NOT DISTINCT FROM $3
 		ORDER BY o.created_at
 		LIMIT $2
 		FOR UPDATE SKIP LOCKED;
 	`
 
-	rows, err := r.GetTxManager(ctx).Query(ctx, query, entity.OutboxStatusFailed, limit)
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, entity.OutboxStatusFailed, limit, r.topic)
 	if err != nil {
 		logrus.Errorf("OutboxRepository.RequeueFailed: query error: %v", err)
 		return nil, err
@@ -0,0 +1,70 @@
+package preferences_repository
+
+import (
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const microsecondsPerMinute = int64(time.Minute / time.Microsecond)
+
+type rawPreferences struct {
+	UserID uuid.UUID `db:"user_id"`
+
+	PushEnabled  bool `db:"push_enabled"`
+	EmailEnabled bool `db:"email_enabled"`
+
+	Locale          string      `db:"locale"`
+	Timezone        string      `db:"timezone"`
+	QuietHoursStart pgtype.Time `db:"quiet_hours_start"`
+	QuietHoursEnd   pgtype.Time `db:"quiet_hours_end"`
+
+	Digest string `db:"digest"`
+
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type rawTypePreference struct {
+	NotificationType string `db:"notification_type"`
+	Channel          string `db:"channel"`
+	Enabled          bool   `db:"enabled"`
+}
+
+func (r rawPreferences) toEntity(types []rawTypePreference) entity.NotificationPreferences {
+	prefs := entity.NotificationPreferences{
+		UserID:       r.UserID,
+		PushEnabled:  r.PushEnabled,
+		EmailEnabled: r.EmailEnabled,
+		Types:        make(map[entity.NotificationType]map[entity.Channel]bool),
+		Locale:       entity.Locale(r.Locale),
+		Timezone:     r.Timezone,
+		Digest:       entity.DigestMode(r.Digest),
+		UpdatedAt:    r.UpdatedAt,
+	}
+
+	if r.QuietHoursStart.Valid && r.QuietHoursEnd.Valid {
+		prefs.QuietHours = &entity.QuietHours{
+			Start: int(r.QuietHoursStart.Microseconds / microsecondsPerMinute),
+			End:   int(r.QuietHoursEnd.Microseconds / microsecondsPerMinute),
+		}
+	}
+
+	for _, t := range types {
+		notificationType := entity.NotificationType(t.NotificationType)
+		if prefs.Types[notificationType] == nil {
+			prefs.Types[notificationType] = make(map[entity.Channel]bool)
+		}
+		prefs.Types[notificationType][entity.Channel(t.Channel)] = t.Enabled
+	}
+
+	return prefs
+}
+
+func toPgTime(minute *int) pgtype.Time {
+	if minute == nil {
+		return pgtype.Time{}
+	}
+	return pgtype.Time{Microseconds: int64(*minute) * microsecondsPerMinute, Valid: true}
+}
@@ -0,0 +1,196 @@
+package preferences_repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+var ErrPreferencesNotFound = errors.New("notification preferences not found")
+
+type PreferencesRepository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *PreferencesRepository {
+	return &PreferencesRepository{
+		Postgres: pg,
+	}
+}
+
+func (r *PreferencesRepository) Get(ctx context.Context, userID uuid.UUID) (entity.NotificationPreferences, error) {
+	query, args, _ := r.Builder.
+		Select("*").
+		From("notification_preferences").
+		Where("user_id = ?", userID).
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch notification preferences")
+		return entity.NotificationPreferences{}, err
+	}
+
+	raw, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[rawPreferences])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.NotificationPreferences{}, ErrPreferencesNotFound
+		}
+		logrus.WithError(err).Error("failed to collect notification preferences")
+		return entity.NotificationPreferences{}, err
+	}
+
+	types, err := r.getTypePreferences(ctx, userID)
+	if err != nil {
+		return entity.NotificationPreferences{}, err
+	}
+
+	return raw.toEntity(types), nil
+}
+
+func (r *PreferencesRepository) getTypePreferences(ctx context.Context, userID uuid.UUID) ([]rawTypePreference, error) {
+	query := `
+		SELECT
+			t.name AS notification_type,
+			c.name AS channel,
+			p.enabled
+		FROM notification_type_preference p
+		JOIN notification_type t ON t.id = p.notification_type_id
+		JOIN notification_channel c ON c.id = p.channel_id
+		WHERE p.user_id = $1
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, userID)
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch notification type preferences")
+		return nil, err
+	}
+
+	types, err := pgx.CollectRows(rows, pgx.RowToStructByName[rawTypePreference])
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect notification type preferences")
+		return nil, err
+	}
+
+	return types, nil
+}
+
+// Save сохраняет настройки целиком: переопределения по типам заменяются
+// переданными. Вызывать внутри транзакции.
+func (r *PreferencesRepository) Save(ctx context.Context, prefs entity.NotificationPreferences) error {
+	var quietStart, quietEnd *int
+	if prefs.QuietHours != nil {
+		quietStart, quietEnd = &prefs.QuietHours.Start, &prefs.QuietHours.End
+	}
+
+	query, args, _ := r.Builder.
+		Insert("notification_preferences").
+		Columns(
+			"user_id",
+			"push_enabled",
+			"email_enabled",
+			"locale",
+			"timezone",
+			"quiet_hours_start",
+			"quiet_hours_end",
+			"digest",
+		).
+		Values(
+			prefs.UserID,
+			prefs.PushEnabled,
+			prefs.EmailEnabled,
+			prefs.Locale,
+			prefs.Timezone,
+			toPgTime(quietStart),
+			toPgTime(quietEnd),
+			prefs.Digest,
+		).
+		Suffix(`
+			ON CONFLICT (user_id)
+			DO UPDATE SET
+				push_enabled      = EXCLUDED.push_enabled,
+				email_enabled     = EXCLUDED.email_enabled,
+				locale            = EXCLUDED.locale,
+				timezone          = EXCLUDED.timezone,
+				quiet_hours_start = EXCLUDED.quiet_hours_start,
+				quiet_hours_end   = EXCLUDED.quiet_hours_end,
+				digest            = EXCLUDED.digest,
+				updated_at        = NOW()
+		`).
+		ToSql()
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		logrus.WithField("user_id", prefs.UserID.String()).
+			WithError(err).
+			Error("failed to upsert notification preferences")
+		return err
+	}
+
+	if err := r.deleteTypePreferences(ctx, prefs.UserID); err != nil {
+		return err
+	}
+
+	for notificationType, channels := range prefs.Types {
+		for channel, enabled := range channels {
+			_, err := r.GetTxManager(ctx).Exec(ctx, `
+				INSERT INTO notification_type_preference (user_id, notification_type_id, channel_id, enabled)
+				VALUES (
+					$1,
+					(SELECT id FROM notification_type WHERE name = $2),
+					(SELECT id FROM notification_channel WHERE name = $3),
+					$4
+				)
+			`, prefs.UserID, notificationType, channel, enabled)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{
+					"user_id": prefs.UserID.String(),
+					"type":    notificationType,
+					"channel": channel,
+				}).WithError(err).Error("failed to insert notification type preference")
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *PreferencesRepository) deleteTypePreferences(ctx context.Context, userID uuid.UUID) error {
+	query, args, _ := r.Builder.
+		Delete("notification_type_preference").
+		Where("user_id = ?", userID).
+		ToSql()
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		logrus.WithField("user_id", userID.String()).
+			WithError(err).
+			Error("failed to delete notification type preferences")
+		return err
+	}
+	return nil
+}
+
+func (r *PreferencesRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	if err := r.deleteTypePreferences(ctx, userID); err != nil {
+		return err
+	}
+
+	query, args, _ := r.Builder.
+		Delete("notification_preferences").
+		Where("user_id = ?", userID).
+		ToSql()
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithField("user_id", userID.String()).
+			WithError(err).
+			Error("failed to delete notification preferences")
+		return err
+	}
+	return nil
+}
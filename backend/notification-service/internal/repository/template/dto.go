@@ -0,0 +1,31 @@
+package template_repository
+
+import (
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type rawTemplate struct {
+	NotificationType string `db:"notification_type"`
+	Locale           string `db:"locale"`
+
+	Title string `db:"title"`
+	Body  string `db:"body"`
+
+	UpdatedBy *uuid.UUID `db:"updated_by"`
+	UpdatedAt time.Time  `db:"updated_at"`
+}
+
+func (r rawTemplate) toEntity() entity.NotificationTemplate {
+	return entity.NotificationTemplate{
+		Type:       entity.NotificationType(r.NotificationType),
+		Locale:     entity.Locale(r.Locale),
+		Title:      r.Title,
+		Body:       r.Body,
+		Overridden: true,
+		UpdatedBy:  r.UpdatedBy,
+		UpdatedAt:  &r.UpdatedAt,
+	}
+}
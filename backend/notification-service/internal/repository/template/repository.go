@@ -0,0 +1,132 @@
+package template_repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+var ErrTemplateNotFound = errors.New("notification template not found")
+
+// TemplateRepository хранит только переопределённые администратором тексты
+type TemplateRepository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *TemplateRepository {
+	return &TemplateRepository{
+		Postgres: pg,
+	}
+}
+
+func (r *TemplateRepository) List(ctx context.Context) ([]entity.NotificationTemplate, error) {
+	query := `
+		SELECT
+			t.name AS notification_type,
+			nt.locale,
+			nt.title,
+			nt.body,
+			nt.updated_by,
+			nt.updated_at
+		FROM notification_template nt
+		JOIN notification_type t ON t.id = nt.notification_type_id
+		ORDER BY t.id, nt.locale
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query)
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch notification templates")
+		return nil, err
+	}
+
+	raws, err := pgx.CollectRows(rows, pgx.RowToStructByName[rawTemplate])
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect notification templates")
+		return nil, err
+	}
+
+	templates := make([]entity.NotificationTemplate, 0, len(raws))
+	for _, raw := range raws {
+		templates = append(templates, raw.toEntity())
+	}
+
+	return templates, nil
+}
+
+func (r *TemplateRepository) Upsert(ctx context.Context, template entity.NotificationTemplate) (entity.NotificationTemplate, error) {
+	query := `
+		WITH saved AS (
+			INSERT INTO notification_template (notification_type_id, locale, title, body, updated_by)
+			VALUES (
+				(SELECT id FROM notification_type WHERE name = $1),
+				$2, $3, $4, $5
+			)
+			ON CONFLICT (notification_type_id, locale)
+			DO UPDATE SET
+				title      = EXCLUDED.title,
+				body       = EXCLUDED.body,
+				updated_by = EXCLUDED.updated_by,
+				updated_at = NOW()
+			RETURNING *
+		)
+		SELECT
+			t.name AS notification_type,
+			s.locale,
+			s.title,
+			s.body,
+			s.updated_by,
+			s.updated_at
+		FROM saved s
+		JOIN notification_type t ON t.id = s.notification_type_id
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query,
+		template.Type,
+		template.Locale,
+		template.Title,
+		template.Body,
+		template.UpdatedBy,
+	)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type":   template.Type,
+			"locale": template.Locale,
+		}).WithError(err).Error("failed to upsert notification template")
+		return entity.NotificationTemplate{}, err
+	}
+
+	raw, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[rawTemplate])
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect notification template")
+		return entity.NotificationTemplate{}, err
+	}
+
+	return raw.toEntity(), nil
+}
+
+func (r *TemplateRepository) Delete(ctx context.Context, notificationType entity.NotificationType, locale entity.Locale) error {
+	query := `
+		DELETE FROM notification_template
+		WHERE notification_type_id = (SELECT id FROM notification_type WHERE name = $1)
+			AND locale = $2
+	`
+
+	tag, err := r.GetTxManager(ctx).Exec(ctx, query, notificationType, locale)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type":   notificationType,
+			"locale": locale,
+		}).WithError(err).Error("failed to delete notification template")
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrTemplateNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package composite_sender
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/4udiwe/coworking/notification-service/internal/sender"
+	"github.com/sirupsen/logrus"
+)
+
+// Channel — канал доставки, подключённый к Dispatcher.
+// Один канал могут обслуживать несколько Dispatcher (push: FCM и Web Push).
+type Channel struct {
+	Name       entity.Channel
+	Dispatcher sender.Dispatcher
+}
+
+/*
+Dispatcher рассылает уведомление по выбранным каналам. Какие каналы
+выбрать, решает сервис по настройкам пользователя. Ошибка одного канала
+не мешает остальным: ошибки собираются и возвращаются вместе после
+попытки по каждому каналу. Вызывающий повторяет обработку целиком,
+поэтому каналы сами пропускают то, что уже отправили: push — по журналу
+push_delivery, email — по email_delivery.
+*/
+type Dispatcher struct {
+	channels []Channel
+}
+
+func NewDispatcher(channels ...Channel) *Dispatcher {
+	return &Dispatcher{
+		channels: channels,
+	}
+}
+
+// Dispatch отправляет уведомление во все подключённые каналы
+func (d *Dispatcher) Dispatch(ctx context.Context, notification entity.Notification) error {
+	return d.DispatchChannels(ctx, notification, entity.Channels)
+}
+
+// DispatchChannels отправляет уведомление только в перечисленные каналы
+func (d *Dispatcher) DispatchChannels(
+	ctx context.Context,
+	notification entity.Notification,
+	channels []entity.Channel,
+) error {
+
+	var errs []error
+
+	for _, ch := range d.channels {
+		if !slices.Contains(channels, ch.Name) {
+			continue
+		}
+
+		if err := ch.Dispatcher.Dispatch(ctx, notification); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"notification_id": notification.ID,
+				"channel":         ch.Name,
+			}).WithError(err).Error("channel dispatch failed")
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
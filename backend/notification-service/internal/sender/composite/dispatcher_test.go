@@ -0,0 +1,92 @@
+package composite_sender
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+// fakeDispatcher запоминает, в какие каналы ушло уведомление
+type fakeDispatcher struct {
+	name  string
+	calls *[]string
+	err   error
+}
+
+func (f fakeDispatcher) Dispatch(context.Context, entity.Notification) error {
+	*f.calls = append(*f.calls, f.name)
+	return f.err
+}
+
+func TestDispatchChannels(t *testing.T) {
+	pushErr := errors.New("fcm unavailable")
+	emailErr := errors.New("smtp unavailable")
+
+	tests := []struct {
+		name      string
+		channels  []entity.Channel
+		pushErr   error
+		emailErr  error
+		wantCalls []string
+		wantErrs  []error
+	}{
+		{
+			name:      "all_channels",
+			channels:  entity.Channels,
+			wantCalls: []string{"fcm", "webpush", "email"},
+		},
+		{
+			name:      "push_only",
+			channels:  []entity.Channel{entity.ChannelPush},
+			wantCalls: []string{"fcm", "webpush"},
+		},
+		{
+			name:      "email_only",
+			channels:  []entity.Channel{entity.ChannelEmail},
+			wantCalls: []string{"email"},
+		},
+		{
+			name:     "no_channels",
+			channels: nil,
+		},
+		{
+			// Ошибка одного канала не останавливает остальные
+			name:      "errors_are_joined",
+			channels:  entity.Channels,
+			pushErr:   pushErr,
+			emailErr:  emailErr,
+			wantCalls: []string{"fcm", "webpush", "email"},
+			wantErrs:  []error{pushErr, emailErr},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+
+			d := NewDispatcher(
+				Channel{Name: entity.ChannelPush, Dispatcher: fakeDispatcher{name: "fcm", calls: &calls, err: tt.pushErr}},
+				Channel{Name: entity.ChannelPush, Dispatcher: fakeDispatcher{name: "webpush", calls: &calls}},
+				Channel{Name: entity.ChannelEmail, Dispatcher: fakeDispatcher{name: "email", calls: &calls, err: tt.emailErr}},
+			)
+
+			err := d.DispatchChannels(context.Background(), entity.Notification{ID: uuid.New()}, tt.channels)
+
+			if !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", calls, tt.wantCalls)
+			}
+			if len(tt.wantErrs) == 0 && err != nil {
+				t.Errorf("DispatchChannels() error = %v, want nil", err)
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("DispatchChannels() error = %v, want %v", err, want)
+				}
+			}
+		})
+	}
+}
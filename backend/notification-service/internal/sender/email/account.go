@@ -0,0 +1,91 @@
+package email_sender
+
+import (
+	"context"
+	htmltemplate "html/template"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/4udiwe/coworking/notification-service/internal/sender"
+	"github.com/sirupsen/logrus"
+)
+
+// Шаблоны писем аккаунта. Это не уведомления: в notifications они не
+// сохраняются и не зависят от каналов и тихих часов пользователя.
+const (
+	EmailChangeTemplate entity.NotificationType = "email_change"
+	InvitationTemplate  entity.NotificationType = "invitation"
+)
+
+// AccountEmail — письмо со ссылкой, выданной auth-service
+type AccountEmail struct {
+	To        string
+	FirstName string
+	Link      string
+	ExpiresAt time.Time
+}
+
+// AccountMailer отправляет письма аккаунта из топика auth.mail.
+// Ссылка подставляется как есть: она уже содержит одноразовый токен.
+type AccountMailer struct {
+	emailSender EmailSender
+	renderer    *Renderer
+	location    *time.Location
+}
+
+func NewAccountMailer(emailSender EmailSender, renderer *Renderer, location *time.Location) *AccountMailer {
+	return &AccountMailer{
+		emailSender: emailSender,
+		renderer:    renderer,
+		location:    location,
+	}
+}
+
+// SendEmailChange отправляет на новый адрес ссылку подтверждения смены email
+func (m *AccountMailer) SendEmailChange(ctx context.Context, email AccountEmail) error {
+	return m.send(ctx, EmailChangeTemplate, email, TemplateData{
+		Title:       "Подтвердите новый email",
+		Body:        "Этот адрес указан как новый email аккаунта коворкинга. Чтобы завершить смену, подтвердите его по ссылке.",
+		ActionLabel: "Подтвердить email",
+	})
+}
+
+// SendInvitation отправляет приглашение с импорта списка: по ссылке
+// пользователь задаёт начальный пароль
+func (m *AccountMailer) SendInvitation(ctx context.Context, email AccountEmail) error {
+	return m.send(ctx, InvitationTemplate, email, TemplateData{
+		Title:       "Приглашение в коворкинг",
+		Body:        "Для вас создан аккаунт в коворкинге. Чтобы войти, задайте пароль по ссылке.",
+		ActionLabel: "Задать пароль",
+	})
+}
+
+func (m *AccountMailer) send(ctx context.Context, template entity.NotificationType, email AccountEmail, data TemplateData) error {
+	data.FirstName = email.FirstName
+	data.ActionURL = htmltemplate.URL(email.Link)
+	data.Transactional = true
+	if !email.ExpiresAt.IsZero() {
+		data.ExpiresAt = email.ExpiresAt.In(m.location).Format(displayTimeFormat)
+	}
+
+	html, text, err := m.renderer.Render(template, data)
+	if err != nil {
+		logrus.WithField("template", template).WithError(err).Error("failed to render account email")
+		return err
+	}
+
+	err = m.emailSender.Send(ctx, sender.EmailMessage{
+		To:      email.To,
+		ToName:  email.FirstName,
+		Subject: data.Title,
+		HTML:    html,
+		Text:    text,
+	})
+	if err != nil {
+		logrus.WithField("template", template).WithError(err).Error("failed to send account email")
+		return err
+	}
+
+	logrus.WithField("template", template).Debug("account email sent")
+	return nil
+}
@@ -0,0 +1,186 @@
+package email_sender
+
+//go:generate go tool mockgen -source=dispatcher.go -destination=mocks/mocks.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	htmltemplate "html/template"
+	"strings"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	contact_repository "github.com/4udiwe/coworking/notification-service/internal/repository/contact"
+	"github.com/4udiwe/coworking/notification-service/internal/sender"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Формат времени в письме
+const displayTimeFormat = "02.01.2006 15:04"
+
+// Dispatcher implements the sender.Dispatcher interface using email
+type Dispatcher struct {
+	emailSender  EmailSender
+	contactRepo  ContactRepository
+	deliveryRepo DeliveryRepository
+	renderer     *Renderer
+
+	// appURL — префикс для ActionURL уведомления (deep link или веб-версия)
+	appURL   string
+	location *time.Location
+}
+
+type EmailSender interface {
+	Send(ctx context.Context, msg sender.EmailMessage) error
+}
+
+type ContactRepository interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) (entity.UserContact, error)
+}
+
+// DeliveryRepository — журнал отправленных писем. Событие уведомления
+// обрабатывается повторно, если упал другой канал, а письмо уже ушло.
+type DeliveryRepository interface {
+	IsEmailSent(ctx context.Context, notificationID uuid.UUID) (bool, error)
+	RecordEmailSent(ctx context.Context, notificationID uuid.UUID, email string) error
+}
+
+func NewDispatcher(
+	emailSender EmailSender,
+	contactRepo ContactRepository,
+	deliveryRepo DeliveryRepository,
+	renderer *Renderer,
+	appURL string,
+	location *time.Location,
+) *Dispatcher {
+	return &Dispatcher{
+		emailSender:  emailSender,
+		contactRepo:  contactRepo,
+		deliveryRepo: deliveryRepo,
+		renderer:     renderer,
+		appURL:       strings.TrimRight(appURL, "/"),
+		location:     location,
+	}
+}
+
+type notificationPayload struct {
+	BookingID  string `json:"bookingId"`
+	PlaceLabel string `json:"placeLabel"`
+	StartTime  string `json:"startTime"`
+	EndTime    string `json:"endTime"`
+
+	// Только у booking_digest
+	Bookings []notificationPayload `json:"bookings"`
+}
+
+func (d *Dispatcher) Dispatch(ctx context.Context, notification entity.Notification) error {
+	sent, err := d.deliveryRepo.IsEmailSent(ctx, notification.ID)
+	if err != nil {
+		return err
+	}
+	if sent {
+		logrus.WithField("notification_id", notification.ID).Debug("email already sent")
+		return nil
+	}
+
+	contact, err := d.contactRepo.GetByUserID(ctx, notification.UserID)
+	if err != nil {
+		if errors.Is(err, contact_repository.ErrContactNotFound) {
+			logrus.WithField("user_id", notification.UserID).Debug("no email known for user")
+			return nil
+		}
+		return err
+	}
+
+	var payload notificationPayload
+	if err := json.Unmarshal(notification.Payload, &payload); err != nil {
+		logrus.WithField("notification_id", notification.ID).WithError(err).Warn("failed to unmarshal notification payload")
+	}
+
+	start, hasStart := parsePayloadTime(payload.StartTime)
+	end, hasEnd := parsePayloadTime(payload.EndTime)
+
+	data := TemplateData{
+		FirstName:  contact.FirstName,
+		Title:      notification.Title,
+		Body:       notification.Body,
+		PlaceLabel: payload.PlaceLabel,
+	}
+	if notification.ActionURL != nil {
+		data.ActionURL = htmltemplate.URL(d.appURL + *notification.ActionURL)
+	}
+	if hasStart {
+		data.StartTime = start.In(d.location).Format(displayTimeFormat)
+	}
+	if hasEnd {
+		data.EndTime = end.In(d.location).Format(displayTimeFormat)
+	}
+	for _, booking := range payload.Bookings {
+		details := BookingDetails{PlaceLabel: booking.PlaceLabel}
+		if start, ok := parsePayloadTime(booking.StartTime); ok {
+			details.StartTime = start.In(d.location).Format(displayTimeFormat)
+		}
+		if end, ok := parsePayloadTime(booking.EndTime); ok {
+			details.EndTime = end.In(d.location).Format("15:04")
+		}
+		data.Bookings = append(data.Bookings, details)
+	}
+
+	html, text, err := d.renderer.Render(notification.Type, data)
+	if err != nil {
+		logrus.WithField("type", notification.Type).WithError(err).Error("failed to render email")
+		return err
+	}
+
+	msg := sender.EmailMessage{
+		To:      contact.Email,
+		ToName:  contact.FirstName,
+		Subject: notification.Title,
+		HTML:    html,
+		Text:    text,
+	}
+
+	// Подтверждение бронирования — с файлом календаря
+	if notification.Type == entity.BookingCreatedNotificationType && hasStart && hasEnd {
+		msg.Attachments = append(msg.Attachments, sender.EmailAttachment{
+			Filename:    "booking.ics",
+			ContentType: "text/calendar; charset=UTF-8; method=PUBLISH",
+			Content: buildICS(calendarEvent{
+				UID:         payload.BookingID + "@coworking",
+				Summary:     "Бронирование: " + payload.PlaceLabel,
+				Location:    payload.PlaceLabel,
+				Description: notification.Body,
+				URL:         string(data.ActionURL),
+				Start:       start,
+				End:         end,
+			}, time.Now()),
+		})
+	}
+
+	if err := d.emailSender.Send(ctx, msg); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"notification_id": notification.ID,
+			"user_id":         notification.UserID,
+		}).WithError(err).Error("failed to send email")
+		return err
+	}
+
+	// Письмо уже ушло: ошибка записи в журнал не повод отправлять его снова,
+	// поэтому она только логируется
+	if err := d.deliveryRepo.RecordEmailSent(ctx, notification.ID, contact.Email); err != nil {
+		logrus.WithField("notification_id", notification.ID).WithError(err).Error("failed to record email delivery")
+	}
+
+	logrus.WithField("notification_id", notification.ID).Debug("email sent")
+
+	return nil
+}
+
+// parsePayloadTime читает время из payload уведомления: DefaultBuilder
+// пишет его в RFC3339
+func parsePayloadTime(value string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, value)
+	return t, err == nil && !t.IsZero()
+}
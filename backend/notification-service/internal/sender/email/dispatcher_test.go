@@ -0,0 +1,177 @@
+package email_sender
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	contact_repository "github.com/4udiwe/coworking/notification-service/internal/repository/contact"
+	"github.com/4udiwe/coworking/notification-service/internal/sender"
+	"github.com/4udiwe/coworking/notification-service/internal/sender/email/mocks"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDispatch(t *testing.T) {
+	ctx := context.Background()
+	moscow := time.FixedZone("MSK", 3*60*60)
+	userID := uuid.New()
+	contact := entity.UserContact{UserID: userID, Email: "anna@example.com", FirstName: "Анна"}
+	sendErr := errors.New("smtp unavailable")
+	dbErr := errors.New("db is down")
+
+	bookingCreated := entity.Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      entity.BookingCreatedNotificationType,
+		Title:     "Бронирование создано",
+		Body:      "Рабочее место A-12 забронировано",
+		Payload:   []byte(`{"type":"booking","bookingId":"b1","placeLabel":"A-12","startTime":"2026-10-21T10:00:00Z","endTime":"2026-10-21T12:00:00Z"}`),
+		ActionURL: lo.ToPtr("/bookings?tab=active&bookingId=b1"),
+	}
+
+	tests := []struct {
+		name         string
+		notification entity.Notification
+		alreadySent  bool
+		contactErr   error
+		sendErr      error
+		recordErr    error
+		wantSend     bool
+		wantErr      error
+		check        func(t *testing.T, msg sender.EmailMessage)
+	}{
+		{
+			name:         "booking_created_with_calendar",
+			notification: bookingCreated,
+			wantSend:     true,
+			check: func(t *testing.T, msg sender.EmailMessage) {
+				if msg.To != contact.Email || msg.ToName != contact.FirstName || msg.Subject != bookingCreated.Title {
+					t.Errorf("unexpected message headers %+v", msg)
+				}
+				// Время в письме — в часовом поясе коворкинга
+				if !strings.Contains(msg.Text, "21.10.2026 13:00") || !strings.Contains(msg.Text, "21.10.2026 15:00") {
+					t.Errorf("text has no local times:\n%s", msg.Text)
+				}
+				if !strings.Contains(msg.HTML, "coworking://app/bookings?tab=active&amp;bookingId=b1") {
+					t.Errorf("html has no action URL:\n%s", msg.HTML)
+				}
+				if len(msg.Attachments) != 1 || msg.Attachments[0].Filename != "booking.ics" {
+					t.Fatalf("attachments = %+v, want booking.ics", msg.Attachments)
+				}
+				ics := string(msg.Attachments[0].Content)
+				if !strings.Contains(ics, "UID:b1@coworking") || !strings.Contains(ics, "DTSTART:20261021T100000Z") {
+					t.Errorf("unexpected ICS:\n%s", ics)
+				}
+			},
+		},
+		{
+			// Время не в RFC3339 не разбирается: без времени нет и календаря
+			name: "non_rfc3339_time_is_ignored",
+			notification: func() entity.Notification {
+				n := bookingCreated
+				n.Payload = []byte(`{"placeLabel":"A-12","startTime":"2026-10-21 10:00:00 +0000 UTC","endTime":"2026-10-21 12:00:00 +0000 UTC"}`)
+				return n
+			}(),
+			wantSend: true,
+			check: func(t *testing.T, msg sender.EmailMessage) {
+				if len(msg.Attachments) != 0 {
+					t.Errorf("attachments = %+v, want none", msg.Attachments)
+				}
+				if strings.Contains(msg.Text, "Начало:") {
+					t.Errorf("text has start time:\n%s", msg.Text)
+				}
+			},
+		},
+		{
+			name: "other_types_without_calendar",
+			notification: func() entity.Notification {
+				n := bookingCreated
+				n.Type = entity.BookingReminderNotificationType
+				return n
+			}(),
+			wantSend: true,
+			check: func(t *testing.T, msg sender.EmailMessage) {
+				if len(msg.Attachments) != 0 {
+					t.Errorf("attachments = %+v, want none", msg.Attachments)
+				}
+			},
+		},
+		{
+			// Повторная обработка события после ошибки другого канала
+			name:         "already_sent_is_skipped",
+			notification: bookingCreated,
+			alreadySent:  true,
+		},
+		{
+			// Письмо ушло, ошибка журнала не возвращается, иначе его отправят снова
+			name:         "record_error_is_ignored",
+			notification: bookingCreated,
+			recordErr:    dbErr,
+			wantSend:     true,
+		},
+		{
+			name:         "no_contact_is_skipped",
+			notification: bookingCreated,
+			contactErr:   contact_repository.ErrContactNotFound,
+		},
+		{
+			name:         "contact_error",
+			notification: bookingCreated,
+			contactErr:   dbErr,
+			wantErr:      dbErr,
+		},
+		{
+			name:         "send_error",
+			notification: bookingCreated,
+			sendErr:      sendErr,
+			wantSend:     true,
+			wantErr:      sendErr,
+		},
+	}
+
+	renderer, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			emailSender := mocks.NewMockEmailSender(ctrl)
+			contactRepo := mocks.NewMockContactRepository(ctrl)
+			deliveryRepo := mocks.NewMockDeliveryRepository(ctrl)
+
+			deliveryRepo.EXPECT().IsEmailSent(ctx, tt.notification.ID).Return(tt.alreadySent, nil)
+			if !tt.alreadySent {
+				contactRepo.EXPECT().GetByUserID(ctx, userID).Return(contact, tt.contactErr)
+			}
+
+			var sent sender.EmailMessage
+			if tt.wantSend {
+				emailSender.EXPECT().Send(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg sender.EmailMessage) error {
+						sent = msg
+						return tt.sendErr
+					})
+				if tt.sendErr == nil {
+					deliveryRepo.EXPECT().RecordEmailSent(ctx, tt.notification.ID, contact.Email).Return(tt.recordErr)
+				}
+			}
+
+			d := NewDispatcher(emailSender, contactRepo, deliveryRepo, renderer, "coworking://app/", moscow)
+
+			err := d.Dispatch(ctx, tt.notification)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Dispatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, sent)
+			}
+		})
+	}
+}
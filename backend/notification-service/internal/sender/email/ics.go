@@ -0,0 +1,93 @@
+package email_sender
+
+import (
+	"strings"
+	"time"
+)
+
+const icsTimeFormat = "20060102T150405Z"
+
+type calendarEvent struct {
+	UID         string
+	Summary     string
+	Location    string
+	Description string
+	URL         string
+	Start       time.Time
+	End         time.Time
+}
+
+// buildICS формирует iCalendar (RFC 5545) с одним событием. METHOD:PUBLISH —
+// файл только добавляет событие в календарь, ответа организатору не требуется.
+// Повторная отправка с тем же UID обновляет событие, а не дублирует его.
+func buildICS(ev calendarEvent, now time.Time) []byte {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Coworking//Notification Service//RU",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"BEGIN:VEVENT",
+		"UID:" + escapeICS(ev.UID),
+		"DTSTAMP:" + now.UTC().Format(icsTimeFormat),
+		"DTSTART:" + ev.Start.UTC().Format(icsTimeFormat),
+		"DTEND:" + ev.End.UTC().Format(icsTimeFormat),
+		"SUMMARY:" + escapeICS(ev.Summary),
+	}
+
+	if ev.Location != "" {
+		lines = append(lines, "LOCATION:"+escapeICS(ev.Location))
+	}
+	if ev.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeICS(ev.Description))
+	}
+	if ev.URL != "" {
+		lines = append(lines, "URL:"+ev.URL)
+	}
+
+	lines = append(lines,
+		"END:VEVENT",
+		"END:VCALENDAR",
+	)
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICS(line))
+		b.WriteString("\r\n")
+	}
+
+	return []byte(b.String())
+}
+
+func escapeICS(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// foldICS переносит строки длиннее 75 октетов, не разрывая UTF-8 символы
+func foldICS(line string) string {
+	const limit = 75
+
+	if len(line) <= limit {
+		return line
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range line {
+		size := len(string(r))
+		if n+size > limit {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteRune(r)
+		n += size
+	}
+
+	return b.String()
+}
@@ -0,0 +1,108 @@
+package email_sender
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildICS(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	ev := calendarEvent{
+		UID:         "b1@coworking",
+		Summary:     "Бронирование: A-12",
+		Location:    "Этаж 2; зона A, у окна",
+		Description: "Первая строка\nвторая строка",
+		URL:         "coworking://bookings?bookingId=b1",
+		Start:       time.Date(2026, 10, 21, 13, 0, 0, 0, moscow),
+		End:         time.Date(2026, 10, 21, 15, 0, 0, 0, moscow),
+	}
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	ics := string(buildICS(ev, now))
+
+	if !strings.HasSuffix(ics, "\r\n") || strings.Contains(strings.ReplaceAll(ics, "\r\n", ""), "\n") {
+		t.Fatalf("lines must end with CRLF:\n%q", ics)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n")
+	if lines[0] != "BEGIN:VCALENDAR" || lines[len(lines)-1] != "END:VCALENDAR" {
+		t.Errorf("unexpected calendar boundaries: %q, %q", lines[0], lines[len(lines)-1])
+	}
+
+	for _, want := range []string{
+		"METHOD:PUBLISH",
+		"UID:b1@coworking",
+		"DTSTAMP:20261019T090000Z",
+		// Время всегда в UTC
+		"DTSTART:20261021T100000Z",
+		"DTEND:20261021T120000Z",
+		"SUMMARY:Бронирование: A-12",
+		`LOCATION:Этаж 2\; зона A\, у окна`,
+		`DESCRIPTION:Первая строка\nвторая строка`,
+		"URL:coworking://bookings?bookingId=b1",
+	} {
+		if !containsLine(lines, want) {
+			t.Errorf("ICS has no line %q:\n%s", want, ics)
+		}
+	}
+}
+
+func TestBuildICS_OptionalFields(t *testing.T) {
+	ics := string(buildICS(calendarEvent{
+		UID:     "b1@coworking",
+		Summary: "Бронирование",
+		Start:   time.Date(2026, 10, 21, 10, 0, 0, 0, time.UTC),
+		End:     time.Date(2026, 10, 21, 12, 0, 0, 0, time.UTC),
+	}, time.Now()))
+
+	for _, prefix := range []string{"LOCATION:", "DESCRIPTION:", "URL:"} {
+		if strings.Contains(ics, prefix) {
+			t.Errorf("ICS contains empty %s", prefix)
+		}
+	}
+}
+
+func TestFoldICS(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "short", line: "SUMMARY:A-12"},
+		{name: "ascii", line: "DESCRIPTION:" + strings.Repeat("a", 200)},
+		// Кириллица — 2 октета на символ, перенос не должен разрывать символ
+		{name: "utf8", line: "DESCRIPTION:" + strings.Repeat("бронь ", 40)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded := foldICS(tt.line)
+
+			parts := strings.Split(folded, "\r\n")
+			for i, p := range parts {
+				if len(p) > 75 {
+					t.Errorf("line %d is %d octets long", i, len(p))
+				}
+				if i > 0 && !strings.HasPrefix(p, " ") {
+					t.Errorf("continuation line %d does not start with space: %q", i, p)
+				}
+			}
+
+			if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != tt.line {
+				t.Errorf("unfolded line differs:\n%q\n%q", unfolded, tt.line)
+			}
+		})
+	}
+}
+
+func containsLine(lines []string, want string) bool {
+	// Длинные строки свёрнуты: сравниваем после разворачивания
+	unfolded := strings.ReplaceAll(strings.Join(lines, "\r\n"), "\r\n ", "")
+	for _, line := range strings.Split(unfolded, "\r\n") {
+		if line == want {
+			return true
+		}
+	}
+	return false
+}
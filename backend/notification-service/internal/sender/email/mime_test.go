@@ -0,0 +1,169 @@
+package email_sender
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/sender"
+)
+
+var (
+	testFrom = mail.Address{Name: "Коворкинг", Address: "noreply@coworking.local"}
+	testTo   = mail.Address{Name: "Анна", Address: "anna@example.com"}
+	testNow  = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+)
+
+// mimeParts разбирает multipart-тело: Content-Type части -> декодированное содержимое
+func mimeParts(t *testing.T, contentType string, body io.Reader) map[string][]byte {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		t.Fatalf("unexpected content type %q: %v", contentType, err)
+	}
+
+	parts := map[string][]byte{}
+	r := multipart.NewReader(body, params["boundary"])
+	for {
+		p, err := r.NextRawPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatalf("NextRawPart() error = %v", err)
+		}
+
+		var content io.Reader = p
+		switch p.Header.Get("Content-Transfer-Encoding") {
+		case "quoted-printable":
+			content = quotedprintable.NewReader(p)
+		case "base64":
+			content = base64.NewDecoder(base64.StdEncoding, p)
+		}
+
+		data, err := io.ReadAll(content)
+		if err != nil {
+			t.Fatalf("read part %q: %v", p.Header.Get("Content-Type"), err)
+		}
+		parts[p.Header.Get("Content-Type")] = data
+	}
+}
+
+func TestBuildMIME_Alternative(t *testing.T) {
+	msg := sender.EmailMessage{
+		Subject: "Бронирование создано",
+		Text:    "Место A-12 забронировано",
+		HTML:    "<p>Место A-12 забронировано</p>",
+	}
+
+	raw, err := buildMIME(testFrom, testTo, msg, testNow)
+	if err != nil {
+		t.Fatalf("buildMIME() error = %v", err)
+	}
+
+	m, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("mail.ReadMessage() error = %v", err)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	if err != nil || subject != msg.Subject {
+		t.Errorf("Subject = %q (%v), want %q", subject, err, msg.Subject)
+	}
+	if to, err := m.Header.AddressList("To"); err != nil || to[0].Address != testTo.Address || to[0].Name != testTo.Name {
+		t.Errorf("To = %v (%v)", to, err)
+	}
+	if date, err := m.Header.Date(); err != nil || !date.Equal(testNow) {
+		t.Errorf("Date = %v (%v), want %v", date, err, testNow)
+	}
+	if id := m.Header.Get("Message-ID"); !strings.HasSuffix(id, "@coworking.local>") {
+		t.Errorf("Message-ID = %q", id)
+	}
+
+	// Без вложений верхний уровень — multipart/alternative
+	parts := mimeParts(t, m.Header.Get("Content-Type"), m.Body)
+	if got := string(parts["text/plain; charset=UTF-8"]); got != msg.Text {
+		t.Errorf("text part = %q, want %q", got, msg.Text)
+	}
+	if got := string(parts["text/html; charset=UTF-8"]); got != msg.HTML {
+		t.Errorf("html part = %q, want %q", got, msg.HTML)
+	}
+}
+
+func TestBuildMIME_Attachment(t *testing.T) {
+	// Больше 76 символов base64, чтобы проверить перенос строк
+	ics := []byte(strings.Repeat("BEGIN:VCALENDAR\r\n", 10))
+
+	msg := sender.EmailMessage{
+		Subject: "Бронирование создано",
+		Text:    "Текст",
+		HTML:    "<p>Текст</p>",
+		Attachments: []sender.EmailAttachment{{
+			Filename:    "booking.ics",
+			ContentType: "text/calendar; charset=UTF-8; method=PUBLISH",
+			Content:     ics,
+		}},
+	}
+
+	raw, err := buildMIME(testFrom, testTo, msg, testNow)
+	if err != nil {
+		t.Fatalf("buildMIME() error = %v", err)
+	}
+
+	for _, line := range strings.Split(string(raw), "\r\n") {
+		if len(line) > 998 {
+			t.Fatalf("line longer than 998 octets: %d", len(line))
+		}
+	}
+
+	m, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("mail.ReadMessage() error = %v", err)
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	if mediaType != "multipart/mixed" {
+		t.Fatalf("Content-Type = %q, want multipart/mixed", mediaType)
+	}
+
+	r := multipart.NewReader(m.Body, params["boundary"])
+
+	// Первая часть — текст и HTML
+	first, err := r.NextRawPart()
+	if err != nil {
+		t.Fatalf("NextRawPart() error = %v", err)
+	}
+	alternative := mimeParts(t, first.Header.Get("Content-Type"), first)
+	if string(alternative["text/plain; charset=UTF-8"]) != msg.Text || string(alternative["text/html; charset=UTF-8"]) != msg.HTML {
+		t.Errorf("unexpected alternative parts %q", alternative)
+	}
+
+	// Вторая — вложение
+	second, err := r.NextRawPart()
+	if err != nil {
+		t.Fatalf("NextRawPart() error = %v", err)
+	}
+	if ct := second.Header.Get("Content-Type"); ct != msg.Attachments[0].ContentType {
+		t.Errorf("attachment Content-Type = %q", ct)
+	}
+	_, dispParams, err := mime.ParseMediaType(second.Header.Get("Content-Disposition"))
+	if err != nil || dispParams["filename"] != "booking.ics" {
+		t.Errorf("Content-Disposition = %q", second.Header.Get("Content-Disposition"))
+	}
+	content, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, second))
+	if err != nil || !bytes.Equal(content, ics) {
+		t.Errorf("attachment content = %q (%v)", content, err)
+	}
+
+	if _, err := r.NextRawPart(); err != io.EOF {
+		t.Errorf("unexpected extra part: %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package email_sender
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Шаблон для типов уведомлений, у которых нет своего файла
+const defaultTemplate = "default"
+
+var templateTypes = []entity.NotificationType{
+	entity.BookingCreatedNotificationType,
+	entity.BookingCancelledNotificationType,
+	entity.BookingReminderNotificationType,
+	entity.BookingExpiredNotificationType,
+	entity.BookingEndReminderNotificationType,
+	entity.BookingDigestNotificationType,
+	EmailChangeTemplate,
+	InvitationTemplate,
+	defaultTemplate,
+}
+
+// TemplateData — данные для шаблонов письма. Время уже отформатировано
+// в часовом поясе коворкинга.
+type TemplateData struct {
+	FirstName string
+
+	Title string
+	Body  string
+
+	// app_url из конфига + путь из DefaultBuilder. template.URL — чтобы
+	// html/template не вырезал ссылку с кастомной схемой (coworking://)
+	ActionURL htmltemplate.URL
+	// Текст кнопки; пустой — «Открыть в приложении»
+	ActionLabel string
+
+	PlaceLabel string
+	StartTime  string
+	EndTime    string
+
+	// Бронирования дайджеста
+	Bookings []BookingDetails
+
+	// Письма аккаунта: срок действия ссылки. Transactional убирает из
+	// подвала совет отключить письма — такие письма не отключаются.
+	ExpiresAt     string
+	Transactional bool
+}
+
+type BookingDetails struct {
+	PlaceLabel string
+	StartTime  string
+	EndTime    string
+}
+
+/*
+Renderer рендерит письмо по типу уведомления.
+
+Для каждого типа в templates лежат <type>.html и <type>.txt с блоком
+"content"; общая обёртка (заголовок, приветствие, кнопка) — в layout.*,
+блок "details" с местом и временем бронирования тоже определён там.
+*/
+type Renderer struct {
+	html map[entity.NotificationType]*htmltemplate.Template
+	text map[entity.NotificationType]*texttemplate.Template
+}
+
+func NewRenderer() (*Renderer, error) {
+	r := &Renderer{
+		html: make(map[entity.NotificationType]*htmltemplate.Template, len(templateTypes)),
+		text: make(map[entity.NotificationType]*texttemplate.Template, len(templateTypes)),
+	}
+
+	for _, t := range templateTypes {
+		html, err := htmltemplate.ParseFS(templatesFS, "templates/layout.html", "templates/"+string(t)+".html")
+		if err != nil {
+			return nil, err
+		}
+
+		text, err := texttemplate.ParseFS(templatesFS, "templates/layout.txt", "templates/"+string(t)+".txt")
+		if err != nil {
+			return nil, err
+		}
+
+		r.html[t] = html
+		r.text[t] = text
+	}
+
+	return r, nil
+}
+
+func (r *Renderer) Render(notificationType entity.NotificationType, data TemplateData) (html string, text string, err error) {
+	htmlTmpl, ok := r.html[notificationType]
+	if !ok {
+		htmlTmpl = r.html[defaultTemplate]
+	}
+
+	textTmpl, ok := r.text[notificationType]
+	if !ok {
+		textTmpl = r.text[defaultTemplate]
+	}
+
+	var htmlBuf, textBuf bytes.Buffer
+
+	if err := htmlTmpl.ExecuteTemplate(&htmlBuf, "layout.html", data); err != nil {
+		return "", "", err
+	}
+
+	if err := textTmpl.ExecuteTemplate(&textBuf, "layout.txt", data); err != nil {
+		return "", "", err
+	}
+
+	return htmlBuf.String(), textBuf.String(), nil
+}
@@ -0,0 +1,121 @@
+package email_sender
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+)
+
+func TestRenderer_AllTemplates(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	data := TemplateData{
+		FirstName:  "Анна",
+		Title:      "Бронирование создано",
+		Body:       "Рабочее место A-12 забронировано",
+		ActionURL:  "coworking://bookings?tab=active",
+		PlaceLabel: "A-12",
+		StartTime:  "21.10.2026 13:00",
+		EndTime:    "21.10.2026 15:00",
+		Bookings:   []BookingDetails{{PlaceLabel: "B-3", StartTime: "22.10.2026 10:00", EndTime: "12:00"}},
+		ExpiresAt:  "22.10.2026 13:00",
+	}
+
+	for _, typ := range templateTypes {
+		t.Run(string(typ), func(t *testing.T) {
+			html, text, err := r.Render(typ, data)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			for name, body := range map[string]string{"html": html, "text": text} {
+				if !strings.Contains(body, data.Title) || !strings.Contains(body, "Анна, здравствуйте!") {
+					t.Errorf("%s has no title or greeting:\n%s", name, body)
+				}
+				// Кастомная схема не должна вырезаться html/template
+				if !strings.Contains(body, "coworking://bookings?tab=active") {
+					t.Errorf("%s has no action URL:\n%s", name, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderer_Render(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		typ      entity.NotificationType
+		data     TemplateData
+		wantHTML []string
+		wantText []string
+		notHTML  []string
+		notText  []string
+	}{
+		{
+			name:     "unknown_type_uses_default",
+			typ:      entity.NotificationType("something_new"),
+			data:     TemplateData{Title: "Новое", Body: "Текст уведомления"},
+			wantHTML: []string{"Текст уведомления", "Отключить письма"},
+			wantText: []string{"Текст уведомления", "Отключить письма"},
+			// Без ссылки кнопки нет
+			notHTML: []string{"Открыть в приложении"},
+			notText: []string{"Открыть в приложении"},
+		},
+		{
+			name:     "html_is_escaped",
+			typ:      entity.BookingCancelledNotificationType,
+			data:     TemplateData{Title: "Отмена", Body: `<script>alert(1)</script>`, PlaceLabel: "A-12"},
+			wantHTML: []string{"&lt;script&gt;"},
+			wantText: []string{"<script>alert(1)</script>"},
+			notHTML:  []string{"<script>"},
+		},
+		{
+			name: "transactional_without_hint",
+			typ:  EmailChangeTemplate,
+			data: TemplateData{
+				Title:         "Подтвердите email",
+				ActionURL:     "coworking://account/confirm-email?token=t",
+				ActionLabel:   "Подтвердить",
+				ExpiresAt:     "20.10.2026 12:00",
+				Transactional: true,
+			},
+			wantHTML: []string{"Подтвердить", "20.10.2026 12:00"},
+			wantText: []string{"Подтвердить: coworking://account/confirm-email?token=t"},
+			notHTML:  []string{"Отключить письма", "Открыть в приложении"},
+			notText:  []string{"Отключить письма", "Открыть в приложении"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, text, err := r.Render(tt.typ, tt.data)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			check := func(kind, body string, want, not []string) {
+				for _, s := range want {
+					if !strings.Contains(body, s) {
+						t.Errorf("%s does not contain %q:\n%s", kind, s, body)
+					}
+				}
+				for _, s := range not {
+					if strings.Contains(body, s) {
+						t.Errorf("%s contains %q:\n%s", kind, s, body)
+					}
+				}
+			}
+			check("html", html, tt.wantHTML, tt.notHTML)
+			check("text", text, tt.wantText, tt.notText)
+		})
+	}
+}
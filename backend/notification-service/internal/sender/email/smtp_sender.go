@@ -0,0 +1,121 @@
+package email_sender
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/sender"
+	"github.com/sirupsen/logrus"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+
+	From     string
+	FromName string
+
+	Timeout time.Duration
+}
+
+// SMTPSender отправляет письма через SMTP. STARTTLS включается, если сервер
+// его поддерживает; без Username авторизация не выполняется (локальный
+// SMTP sink вроде mailpit).
+type SMTPSender struct {
+	host    string
+	addr    string
+	auth    smtp.Auth
+	from    mail.Address
+	timeout time.Duration
+}
+
+func NewSMTPSender(cfg SMTPConfig) *SMTPSender {
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return &SMTPSender{
+		host:    cfg.Host,
+		addr:    net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		auth:    auth,
+		from:    mail.Address{Name: cfg.FromName, Address: cfg.From},
+		timeout: cfg.Timeout,
+	}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg sender.EmailMessage) error {
+	logrus.WithField("to", msg.To).Debug("smtp: sending email")
+
+	raw, err := buildMIME(s.from, mail.Address{Name: msg.ToName, Address: msg.To}, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("smtp dial: %w", err)
+	}
+
+	// Дедлайн соединения ограничивает весь SMTP-диалог, а не только dial
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+
+	if s.auth != nil {
+		if err := client.Auth(s.auth); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("smtp mail from: %w", err)
+	}
+
+	if err := client.Rcpt(msg.To); err != nil {
+		return fmt.Errorf("smtp rcpt to: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+
+	if _, err := w.Write(raw); err != nil {
+		return fmt.Errorf("smtp write: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp data close: %w", err)
+	}
+
+	return client.Quit()
+}
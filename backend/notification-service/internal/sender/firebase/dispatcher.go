@@ -1,8 +1,11 @@
 package firebase_sender
 
+//go:generate go tool mockgen -source=dispatcher.go -destination=mocks/mocks.go -package=mocks
+
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"time"
 
 	"github.com/4udiwe/coworking/notification-service/internal/entity"
 	"github.com/4udiwe/coworking/notification-service/internal/sender"
@@ -10,10 +13,15 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-// DefaultDispatcher implements the sender.Dispatcher interface using Firebase Cloud Messaging
+// DefaultDispatcher implements the sender.Dispatcher interface using Firebase Cloud Messaging.
+// Каждая отправка на устройство фиксируется в журнале доставок; неудачные
+// попытки повторяет RetryWorker.
 type DefaultDispatcher struct {
-	pushSender PushSender
-	deviceRepo DeviceRepository
+	pushSender       PushSender
+	deviceRepo       DeviceRepository
+	deliveryRepo     DeliveryRepository
+	notificationRepo NotificationRepository
+	policy           RetryPolicy
 }
 
 type PushSender interface {
@@ -25,13 +33,56 @@ type DeviceRepository interface {
 	DeleteByToken(ctx context.Context, token string) error
 }
 
-func NewDefaultDispatcher(pushSender PushSender, deviceRepo DeviceRepository) *DefaultDispatcher {
+type DeliveryRepository interface {
+	Enqueue(ctx context.Context, notificationID uuid.UUID, device entity.UserDevice) (entity.PushDelivery, error)
+	RecordAttempt(
+		ctx context.Context,
+		id uuid.UUID,
+		status entity.DeliveryStatus,
+		lastError *string,
+		nextAttemptAt time.Time,
+	) error
+}
+
+type NotificationRepository interface {
+	MarkDelivered(ctx context.Context, id uuid.UUID) error
+}
+
+// RetryPolicy — экспоненциальная задержка между попытками: BaseDelay, 2×BaseDelay, …
+// но не больше MaxDelay. После MaxAttempts неудач доставка переходит в dead.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Backoff возвращает задержку перед попыткой, следующей за attempts неудачными
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
+
+func NewDefaultDispatcher(
+	pushSender PushSender,
+	deviceRepo DeviceRepository,
+	deliveryRepo DeliveryRepository,
+	notificationRepo NotificationRepository,
+	policy RetryPolicy,
+) *DefaultDispatcher {
 	return &DefaultDispatcher{
-		pushSender: pushSender,
-		deviceRepo: deviceRepo,
+		pushSender:       pushSender,
+		deviceRepo:       deviceRepo,
+		deliveryRepo:     deliveryRepo,
+		notificationRepo: notificationRepo,
+		policy:           policy,
 	}
 }
 
+// Dispatch ставит доставку на каждое устройство пользователя и сразу делает
+// первую попытку. Ошибки отправки не возвращаются: ими занимается RetryWorker.
 func (d *DefaultDispatcher) Dispatch(ctx context.Context, notification entity.Notification) error {
 	logrus.WithFields(logrus.Fields{
 		"notification_id": notification.ID,
@@ -49,54 +100,78 @@ func (d *DefaultDispatcher) Dispatch(ctx context.Context, notification entity.No
 		return nil
 	}
 
-	var rawMap map[string]interface{}
-	if err := json.Unmarshal(notification.Payload, &rawMap); err != nil {
-		logrus.WithField("payload", notification.Payload).WithError(err).Warn("failed to unmarshal notification payload")
-		rawMap = make(map[string]interface{})
+	var errs []error
+	for _, device := range devices {
+		// Браузерам доставляет webpush_sender.Dispatcher
+		if device.Platform == entity.PlatformWeb {
+			continue
+		}
+
+		delivery, err := d.deliveryRepo.Enqueue(ctx, notification.ID, device)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// Повторная обработка того же события: доставка уже начата
+		if delivery.Status != entity.DeliveryQueued || delivery.Attempts > 0 {
+			continue
+		}
+
+		if err := d.Attempt(ctx, notification, delivery); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	payloadMap := make(map[string]string)
-	for k, v := range rawMap {
-		switch val := v.(type) {
-		case string:
-			payloadMap[k] = val
-		default:
-			b, _ := json.Marshal(val)
-			payloadMap[k] = string(b)
+	return errors.Join(errs...)
+}
+
+// Attempt выполняет одну попытку доставки и записывает её результат.
+// Ошибка возвращается только если результат не удалось сохранить.
+func (d *DefaultDispatcher) Attempt(
+	ctx context.Context,
+	notification entity.Notification,
+	delivery entity.PushDelivery,
+) error {
+	log := logrus.WithFields(logrus.Fields{
+		"notification_id": notification.ID,
+		"delivery_id":     delivery.ID,
+		"attempt":         delivery.Attempts + 1,
+	})
+	log.Debug("sending push to device")
+
+	sendErr := d.pushSender.Send(ctx, sender.NewPushMessage(notification, delivery.DeviceToken))
+	now := time.Now()
+
+	if sendErr == nil {
+		if err := d.deliveryRepo.RecordAttempt(ctx, delivery.ID, entity.DeliverySent, nil, now); err != nil {
+			return err
 		}
+		return d.notificationRepo.MarkDelivered(ctx, notification.ID)
 	}
 
-	for _, device := range devices {
-		logrus.WithFields(logrus.Fields{
-			"notification_id": notification.ID,
-			"device_token":    device.DeviceToken,
-		}).Debug("sending push to device")
-
-		err := d.pushSender.Send(ctx, sender.PushMessage{
-			Token:          device.DeviceToken,
-			Title:          notification.Title,
-			Body:           notification.Body,
-			NotificationID: notification.ID.String(),
-			ActionURL:      *notification.ActionURL,
-			Data:           payloadMap,
-		})
+	lastError := sendErr.Error()
 
-		if err != nil {
-			if isInvalidToken(err) {
-				logrus.WithField("device_token", device.DeviceToken).Warn("invalid token, removing device")
-				deleteErr := d.deviceRepo.DeleteByToken(ctx, device.DeviceToken)
-				if deleteErr != nil {
-					logrus.WithError(deleteErr).Warn("failed to delete invalid token")
-				}
-				continue
-			}
-			logrus.WithError(err).WithField("device_token", device.DeviceToken).Error("failed to dispatch notification")
+	if isInvalidToken(sendErr) {
+		log.Warn("invalid token, removing device")
+		if err := d.deviceRepo.DeleteByToken(ctx, delivery.DeviceToken); err != nil {
+			log.WithError(err).Warn("failed to delete invalid token")
 		}
+		return d.deliveryRepo.RecordAttempt(ctx, delivery.ID, entity.DeliveryInvalidToken, &lastError, now)
 	}
 
-	return nil
+	attempts := delivery.Attempts + 1
+	if attempts >= d.policy.MaxAttempts {
+		log.WithError(sendErr).Error("push delivery attempts exhausted, moving to dead letter")
+		return d.deliveryRepo.RecordAttempt(ctx, delivery.ID, entity.DeliveryDead, &lastError, now)
+	}
+
+	next := now.Add(d.policy.Backoff(attempts))
+	log.WithError(sendErr).Warnf("failed to dispatch notification, retry at %s", next.Format(time.RFC3339))
+
+	return d.deliveryRepo.RecordAttempt(ctx, delivery.ID, entity.DeliveryFailed, &lastError, next)
 }
 
 func isInvalidToken(err error) bool {
-	return err == sender.ErrInvalidToken
+	return errors.Is(err, sender.ErrInvalidToken)
 }
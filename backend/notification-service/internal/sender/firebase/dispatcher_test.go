@@ -0,0 +1,45 @@
+package firebase_sender
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts: 8,
+		BaseDelay:   time.Minute,
+		MaxDelay:    time.Hour,
+	}
+
+	tests := []struct {
+		name     string
+		attempts int
+		want     time.Duration
+	}{
+		{name: "no_failures", attempts: 0, want: time.Minute},
+		{name: "first_failure", attempts: 1, want: time.Minute},
+		{name: "second_failure", attempts: 2, want: 2 * time.Minute},
+		{name: "third_failure", attempts: 3, want: 4 * time.Minute},
+		{name: "sixth_failure", attempts: 6, want: 32 * time.Minute},
+		// 64 минуты упираются в MaxDelay
+		{name: "capped", attempts: 7, want: time.Hour},
+		{name: "far_beyond_cap", attempts: 100, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Backoff(tt.attempts); got != tt.want {
+				t.Errorf("Backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_BackoffBaseAboveMax(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 2 * time.Hour, MaxDelay: time.Hour}
+
+	if got := policy.Backoff(1); got != time.Hour {
+		t.Errorf("Backoff(1) = %s, want %s", got, time.Hour)
+	}
+}
@@ -0,0 +1,134 @@
+package firebase_sender
+
+//go:generate go tool mockgen -source=retry_worker.go -destination=mocks/retry_worker.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type DueDeliveryRepository interface {
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]entity.PushDelivery, error)
+}
+
+type NotificationProvider interface {
+	GetByID(ctx context.Context, id uuid.UUID) (entity.Notification, error)
+}
+
+// DeliveryAttempter выполняет одну попытку доставки из журнала
+type DeliveryAttempter interface {
+	Attempt(ctx context.Context, notification entity.Notification, delivery entity.PushDelivery) error
+}
+
+// RetryWorker периодически повторяет доставки в статусах queued и failed,
+// у которых наступило время следующей попытки. Доставки на браузеры
+// повторяет webDispatcher, остальные — DefaultDispatcher.
+type RetryWorker struct {
+	dispatcher    *DefaultDispatcher
+	webDispatcher DeliveryAttempter
+	deliveryRepo  DueDeliveryRepository
+	notifications NotificationProvider
+
+	batchLimit int
+	interval   time.Duration
+	lease      time.Duration
+}
+
+// webDispatcher может быть nil, если Web Push выключен
+func NewRetryWorker(
+	dispatcher *DefaultDispatcher,
+	webDispatcher DeliveryAttempter,
+	deliveryRepo DueDeliveryRepository,
+	notifications NotificationProvider,
+	batchLimit int,
+	interval time.Duration,
+	lease time.Duration,
+) *RetryWorker {
+	return &RetryWorker{
+		dispatcher:    dispatcher,
+		webDispatcher: webDispatcher,
+		deliveryRepo:  deliveryRepo,
+		notifications: notifications,
+		batchLimit:    batchLimit,
+		interval:      interval,
+		lease:         lease,
+	}
+}
+
+func (w *RetryWorker) Run(ctx context.Context) {
+	go func() {
+		logrus.Infof(
+			"PushRetryWorker started interval=%s batchLimit=%d",
+			w.interval,
+			w.batchLimit,
+		)
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logrus.Info("PushRetryWorker stopped")
+				return
+
+			case <-ticker.C:
+				w.processBatch(ctx)
+			}
+		}
+	}()
+}
+
+func (w *RetryWorker) processBatch(ctx context.Context) {
+	// Захват без общей транзакции: отправка идёт во внешний сервис,
+	// а повторный захват другой репликой исключает lease
+	deliveries, err := w.deliveryRepo.ClaimDue(ctx, w.batchLimit, w.lease)
+	if err != nil {
+		logrus.WithError(err).Error("PushRetryWorker: failed to claim due deliveries")
+		return
+	}
+
+	if len(deliveries) == 0 {
+		return
+	}
+
+	logrus.WithField("count", len(deliveries)).Debug("PushRetryWorker: deliveries claimed")
+
+	// В одной пачке обычно несколько устройств одного уведомления
+	notifications := make(map[uuid.UUID]entity.Notification)
+
+	for _, delivery := range deliveries {
+		notification, ok := notifications[delivery.NotificationID]
+		if !ok {
+			notification, err = w.notifications.GetByID(ctx, delivery.NotificationID)
+			if err != nil {
+				logrus.WithField("delivery_id", delivery.ID).
+					WithError(err).
+					Error("PushRetryWorker: failed to fetch notification")
+				continue
+			}
+			notifications[delivery.NotificationID] = notification
+		}
+
+		var attempter DeliveryAttempter = w.dispatcher
+		if delivery.Platform == entity.PlatformWeb {
+			if w.webDispatcher == nil {
+				// Web Push выключен: доставка вернётся в очередь по истечении lease
+				logrus.WithField("delivery_id", delivery.ID).
+					Warn("PushRetryWorker: web push disabled, skipping delivery")
+				continue
+			}
+			attempter = w.webDispatcher
+		}
+
+		if err := attempter.Attempt(ctx, notification, delivery); err != nil {
+			logrus.WithField("delivery_id", delivery.ID).
+				WithError(err).
+				Error("PushRetryWorker: failed to record delivery attempt")
+		}
+	}
+}
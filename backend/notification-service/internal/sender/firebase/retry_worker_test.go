@@ -0,0 +1,258 @@
+package firebase_sender
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/4udiwe/coworking/notification-service/internal/sender"
+	"github.com/4udiwe/coworking/notification-service/internal/sender/firebase/mocks"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+var testPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Minute,
+	MaxDelay:    time.Hour,
+}
+
+const (
+	testBatchLimit = 10
+	testLease      = time.Minute
+)
+
+func TestRetryWorker_ProcessBatch(t *testing.T) {
+	ctx := context.Background()
+	notification := entity.Notification{ID: uuid.New(), UserID: uuid.New()}
+	sendErr := errors.New("fcm unavailable")
+
+	androidDelivery := func(attempts int) entity.PushDelivery {
+		return entity.PushDelivery{
+			ID:             uuid.New(),
+			NotificationID: notification.ID,
+			DeviceToken:    "fcm-token",
+			Platform:       "android",
+			Status:         entity.DeliveryFailed,
+			Attempts:       attempts,
+		}
+	}
+
+	type MockBehavior func(
+		dueRepo *mocks.MockDueDeliveryRepository,
+		notifications *mocks.MockNotificationProvider,
+		webDispatcher *mocks.MockDeliveryAttempter,
+		pushSender *mocks.MockPushSender,
+		deviceRepo *mocks.MockDeviceRepository,
+		deliveryRepo *mocks.MockDeliveryRepository,
+		notificationRepo *mocks.MockNotificationRepository,
+	)
+
+	tests := []struct {
+		name         string
+		mockBehavior MockBehavior
+		webDisabled  bool
+	}{
+		{
+			name: "success_marks_delivered",
+			mockBehavior: func(
+				dueRepo *mocks.MockDueDeliveryRepository,
+				notifications *mocks.MockNotificationProvider,
+				webDispatcher *mocks.MockDeliveryAttempter,
+				pushSender *mocks.MockPushSender,
+				deviceRepo *mocks.MockDeviceRepository,
+				deliveryRepo *mocks.MockDeliveryRepository,
+				notificationRepo *mocks.MockNotificationRepository,
+			) {
+				delivery := androidDelivery(1)
+
+				dueRepo.EXPECT().ClaimDue(ctx, testBatchLimit, testLease).Return([]entity.PushDelivery{delivery}, nil)
+				notifications.EXPECT().GetByID(ctx, notification.ID).Return(notification, nil)
+				pushSender.EXPECT().Send(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg sender.PushMessage) error {
+						if msg.Token != delivery.DeviceToken {
+							t.Errorf("token = %q, want %q", msg.Token, delivery.DeviceToken)
+						}
+						return nil
+					})
+				deliveryRepo.EXPECT().RecordAttempt(ctx, delivery.ID, entity.DeliverySent, nil, gomock.Any()).Return(nil)
+				notificationRepo.EXPECT().MarkDelivered(ctx, notification.ID).Return(nil)
+			},
+		},
+		{
+			name: "failure_is_rescheduled_with_backoff",
+			mockBehavior: func(
+				dueRepo *mocks.MockDueDeliveryRepository,
+				notifications *mocks.MockNotificationProvider,
+				webDispatcher *mocks.MockDeliveryAttempter,
+				pushSender *mocks.MockPushSender,
+				deviceRepo *mocks.MockDeviceRepository,
+				deliveryRepo *mocks.MockDeliveryRepository,
+				notificationRepo *mocks.MockNotificationRepository,
+			) {
+				delivery := androidDelivery(1)
+
+				dueRepo.EXPECT().ClaimDue(ctx, testBatchLimit, testLease).Return([]entity.PushDelivery{delivery}, nil)
+				notifications.EXPECT().GetByID(ctx, notification.ID).Return(notification, nil)
+				pushSender.EXPECT().Send(ctx, gomock.Any()).Return(sendErr)
+				deliveryRepo.EXPECT().
+					RecordAttempt(ctx, delivery.ID, entity.DeliveryFailed, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ entity.DeliveryStatus, lastError *string, next time.Time) error {
+						if lastError == nil || *lastError != sendErr.Error() {
+							t.Errorf("lastError = %v, want %q", lastError, sendErr.Error())
+						}
+						// Вторая неудача — задержка 2×BaseDelay
+						if wait := time.Until(next); wait < 2*time.Minute-time.Second || wait > 2*time.Minute {
+							t.Errorf("next attempt in %s, want 2m", wait)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "last_attempt_moves_to_dead",
+			mockBehavior: func(
+				dueRepo *mocks.MockDueDeliveryRepository,
+				notifications *mocks.MockNotificationProvider,
+				webDispatcher *mocks.MockDeliveryAttempter,
+				pushSender *mocks.MockPushSender,
+				deviceRepo *mocks.MockDeviceRepository,
+				deliveryRepo *mocks.MockDeliveryRepository,
+				notificationRepo *mocks.MockNotificationRepository,
+			) {
+				delivery := androidDelivery(testPolicy.MaxAttempts - 1)
+
+				dueRepo.EXPECT().ClaimDue(ctx, testBatchLimit, testLease).Return([]entity.PushDelivery{delivery}, nil)
+				notifications.EXPECT().GetByID(ctx, notification.ID).Return(notification, nil)
+				pushSender.EXPECT().Send(ctx, gomock.Any()).Return(sendErr)
+				deliveryRepo.EXPECT().
+					RecordAttempt(ctx, delivery.ID, entity.DeliveryDead, gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name: "invalid_token_drops_device",
+			mockBehavior: func(
+				dueRepo *mocks.MockDueDeliveryRepository,
+				notifications *mocks.MockNotificationProvider,
+				webDispatcher *mocks.MockDeliveryAttempter,
+				pushSender *mocks.MockPushSender,
+				deviceRepo *mocks.MockDeviceRepository,
+				deliveryRepo *mocks.MockDeliveryRepository,
+				notificationRepo *mocks.MockNotificationRepository,
+			) {
+				delivery := androidDelivery(1)
+
+				dueRepo.EXPECT().ClaimDue(ctx, testBatchLimit, testLease).Return([]entity.PushDelivery{delivery}, nil)
+				notifications.EXPECT().GetByID(ctx, notification.ID).Return(notification, nil)
+				pushSender.EXPECT().Send(ctx, gomock.Any()).Return(sender.ErrInvalidToken)
+				deviceRepo.EXPECT().DeleteByToken(ctx, delivery.DeviceToken).Return(nil)
+				deliveryRepo.EXPECT().
+					RecordAttempt(ctx, delivery.ID, entity.DeliveryInvalidToken, gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			// Несколько доставок одного уведомления: уведомление читается один раз,
+			// браузерная доставка уходит в webDispatcher
+			name: "web_delivery_goes_to_web_dispatcher",
+			mockBehavior: func(
+				dueRepo *mocks.MockDueDeliveryRepository,
+				notifications *mocks.MockNotificationProvider,
+				webDispatcher *mocks.MockDeliveryAttempter,
+				pushSender *mocks.MockPushSender,
+				deviceRepo *mocks.MockDeviceRepository,
+				deliveryRepo *mocks.MockDeliveryRepository,
+				notificationRepo *mocks.MockNotificationRepository,
+			) {
+				android := androidDelivery(1)
+				web := androidDelivery(1)
+				web.Platform = entity.PlatformWeb
+
+				dueRepo.EXPECT().ClaimDue(ctx, testBatchLimit, testLease).Return([]entity.PushDelivery{android, web}, nil)
+				notifications.EXPECT().GetByID(ctx, notification.ID).Return(notification, nil).Times(1)
+				pushSender.EXPECT().Send(ctx, gomock.Any()).Return(nil)
+				deliveryRepo.EXPECT().RecordAttempt(ctx, android.ID, entity.DeliverySent, nil, gomock.Any()).Return(nil)
+				notificationRepo.EXPECT().MarkDelivered(ctx, notification.ID).Return(nil)
+				webDispatcher.EXPECT().Attempt(ctx, notification, web).Return(nil)
+			},
+		},
+		{
+			// Web Push выключен: доставка не трогается и вернётся по истечении lease
+			name:        "web_delivery_skipped_when_disabled",
+			webDisabled: true,
+			mockBehavior: func(
+				dueRepo *mocks.MockDueDeliveryRepository,
+				notifications *mocks.MockNotificationProvider,
+				webDispatcher *mocks.MockDeliveryAttempter,
+				pushSender *mocks.MockPushSender,
+				deviceRepo *mocks.MockDeviceRepository,
+				deliveryRepo *mocks.MockDeliveryRepository,
+				notificationRepo *mocks.MockNotificationRepository,
+			) {
+				web := androidDelivery(1)
+				web.Platform = entity.PlatformWeb
+
+				dueRepo.EXPECT().ClaimDue(ctx, testBatchLimit, testLease).Return([]entity.PushDelivery{web}, nil)
+				notifications.EXPECT().GetByID(ctx, notification.ID).Return(notification, nil)
+			},
+		},
+		{
+			name: "notification_fetch_error_skips_delivery",
+			mockBehavior: func(
+				dueRepo *mocks.MockDueDeliveryRepository,
+				notifications *mocks.MockNotificationProvider,
+				webDispatcher *mocks.MockDeliveryAttempter,
+				pushSender *mocks.MockPushSender,
+				deviceRepo *mocks.MockDeviceRepository,
+				deliveryRepo *mocks.MockDeliveryRepository,
+				notificationRepo *mocks.MockNotificationRepository,
+			) {
+				dueRepo.EXPECT().ClaimDue(ctx, testBatchLimit, testLease).Return([]entity.PushDelivery{androidDelivery(1)}, nil)
+				notifications.EXPECT().GetByID(ctx, notification.ID).Return(entity.Notification{}, errors.New("db down"))
+			},
+		},
+		{
+			name: "claim_error",
+			mockBehavior: func(
+				dueRepo *mocks.MockDueDeliveryRepository,
+				notifications *mocks.MockNotificationProvider,
+				webDispatcher *mocks.MockDeliveryAttempter,
+				pushSender *mocks.MockPushSender,
+				deviceRepo *mocks.MockDeviceRepository,
+				deliveryRepo *mocks.MockDeliveryRepository,
+				notificationRepo *mocks.MockNotificationRepository,
+			) {
+				dueRepo.EXPECT().ClaimDue(ctx, testBatchLimit, testLease).Return(nil, errors.New("db down"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			dueRepo := mocks.NewMockDueDeliveryRepository(ctrl)
+			notifications := mocks.NewMockNotificationProvider(ctrl)
+			webDispatcher := mocks.NewMockDeliveryAttempter(ctrl)
+			pushSender := mocks.NewMockPushSender(ctrl)
+			deviceRepo := mocks.NewMockDeviceRepository(ctrl)
+			deliveryRepo := mocks.NewMockDeliveryRepository(ctrl)
+			notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+
+			tt.mockBehavior(dueRepo, notifications, webDispatcher, pushSender, deviceRepo, deliveryRepo, notificationRepo)
+
+			dispatcher := NewDefaultDispatcher(pushSender, deviceRepo, deliveryRepo, notificationRepo, testPolicy)
+
+			var web DeliveryAttempter = webDispatcher
+			if tt.webDisabled {
+				web = nil
+			}
+
+			w := NewRetryWorker(dispatcher, web, dueRepo, notifications, testBatchLimit, time.Second, testLease)
+			w.processBatch(ctx)
+		})
+	}
+}
@@ -1,5 +1,12 @@
 package sender
 
+import (
+	"encoding/json"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/sirupsen/logrus"
+)
+
 type PushMessage struct {
 	Token          string
 	Title          string
@@ -8,3 +15,55 @@ type PushMessage struct {
 	ActionURL      string
 	Data           map[string]string
 }
+
+// NewPushMessage собирает push-сообщение уведомления для устройства с token.
+// Payload уведомления разворачивается в плоский Data со строковыми значениями.
+func NewPushMessage(notification entity.Notification, token string) PushMessage {
+	var rawMap map[string]interface{}
+	if err := json.Unmarshal(notification.Payload, &rawMap); err != nil {
+		logrus.WithField("payload", notification.Payload).WithError(err).Warn("failed to unmarshal notification payload")
+		rawMap = make(map[string]interface{})
+	}
+
+	payloadMap := make(map[string]string)
+	for k, v := range rawMap {
+		switch val := v.(type) {
+		case string:
+			payloadMap[k] = val
+		default:
+			b, _ := json.Marshal(val)
+			payloadMap[k] = string(b)
+		}
+	}
+
+	var actionURL string
+	if notification.ActionURL != nil {
+		actionURL = *notification.ActionURL
+	}
+
+	return PushMessage{
+		Token:          token,
+		Title:          notification.Title,
+		Body:           notification.Body,
+		NotificationID: notification.ID.String(),
+		ActionURL:      actionURL,
+		Data:           payloadMap,
+	}
+}
+
+type EmailMessage struct {
+	To     string
+	ToName string
+
+	Subject string
+	HTML    string
+	Text    string
+
+	Attachments []EmailAttachment
+}
+
+type EmailAttachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
@@ -0,0 +1,174 @@
+package webpush_sender
+
+//go:generate go tool mockgen -source=dispatcher.go -destination=mocks/mocks.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	device_repository "github.com/4udiwe/coworking/notification-service/internal/repository/device"
+	"github.com/4udiwe/coworking/notification-service/internal/sender"
+	firebase_sender "github.com/4udiwe/coworking/notification-service/internal/sender/firebase"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Dispatcher implements the sender.Dispatcher interface using Web Push.
+// Доставляет уведомление во все браузеры пользователя (устройства с
+// platform = web). Как и для FCM, каждая отправка фиксируется в журнале
+// доставок, неудачные попытки повторяет RetryWorker, а истёкшие подписки
+// удаляются так же, как невалидные FCM-токены.
+type Dispatcher struct {
+	pushSender       PushSender
+	deviceRepo       DeviceRepository
+	deliveryRepo     DeliveryRepository
+	notificationRepo NotificationRepository
+	policy           firebase_sender.RetryPolicy
+}
+
+type PushSender interface {
+	Send(ctx context.Context, subscription entity.WebPushSubscription, msg sender.PushMessage) error
+}
+
+type DeviceRepository interface {
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.UserDevice, error)
+	FindByToken(ctx context.Context, token string) (entity.UserDevice, error)
+	DeleteByToken(ctx context.Context, token string) error
+}
+
+type DeliveryRepository interface {
+	Enqueue(ctx context.Context, notificationID uuid.UUID, device entity.UserDevice) (entity.PushDelivery, error)
+	RecordAttempt(
+		ctx context.Context,
+		id uuid.UUID,
+		status entity.DeliveryStatus,
+		lastError *string,
+		nextAttemptAt time.Time,
+	) error
+}
+
+type NotificationRepository interface {
+	MarkDelivered(ctx context.Context, id uuid.UUID) error
+}
+
+func NewDispatcher(
+	pushSender PushSender,
+	deviceRepo DeviceRepository,
+	deliveryRepo DeliveryRepository,
+	notificationRepo NotificationRepository,
+	policy firebase_sender.RetryPolicy,
+) *Dispatcher {
+	return &Dispatcher{
+		pushSender:       pushSender,
+		deviceRepo:       deviceRepo,
+		deliveryRepo:     deliveryRepo,
+		notificationRepo: notificationRepo,
+		policy:           policy,
+	}
+}
+
+// Dispatch ставит доставку на каждый браузер пользователя и сразу делает
+// первую попытку. Ошибки отправки не возвращаются: ими занимается RetryWorker.
+func (d *Dispatcher) Dispatch(ctx context.Context, notification entity.Notification) error {
+	devices, err := d.deviceRepo.FindByUserID(ctx, notification.UserID)
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch user devices for web push")
+		return err
+	}
+
+	var errs []error
+	for _, device := range devices {
+		if device.Platform != entity.PlatformWeb || device.Subscription == nil {
+			continue
+		}
+
+		delivery, err := d.deliveryRepo.Enqueue(ctx, notification.ID, device)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// Повторная обработка того же события: доставка уже начата
+		if delivery.Status != entity.DeliveryQueued || delivery.Attempts > 0 {
+			continue
+		}
+
+		if err := d.attempt(ctx, notification, delivery, *device.Subscription); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Attempt выполняет одну попытку доставки из журнала. Подписка берётся из
+// user_device по токену: если браузер уже отписался, доставка закрывается
+// как invalid_token. Ошибка возвращается только если результат не удалось сохранить.
+func (d *Dispatcher) Attempt(
+	ctx context.Context,
+	notification entity.Notification,
+	delivery entity.PushDelivery,
+) error {
+	device, err := d.deviceRepo.FindByToken(ctx, delivery.DeviceToken)
+	if err != nil && !errors.Is(err, device_repository.ErrDeviceNotFound) {
+		return err
+	}
+
+	if err != nil || device.Subscription == nil {
+		lastError := "web push subscription removed"
+		return d.deliveryRepo.RecordAttempt(ctx, delivery.ID, entity.DeliveryInvalidToken, &lastError, time.Now())
+	}
+
+	return d.attempt(ctx, notification, delivery, *device.Subscription)
+}
+
+func (d *Dispatcher) attempt(
+	ctx context.Context,
+	notification entity.Notification,
+	delivery entity.PushDelivery,
+	subscription entity.WebPushSubscription,
+) error {
+	log := logrus.WithFields(logrus.Fields{
+		"notification_id": notification.ID,
+		"delivery_id":     delivery.ID,
+		"attempt":         delivery.Attempts + 1,
+	})
+	log.Debug("sending web push to browser")
+
+	sendErr := d.pushSender.Send(ctx, subscription, sender.NewPushMessage(notification, delivery.DeviceToken))
+	now := time.Now()
+
+	if sendErr == nil {
+		if err := d.deliveryRepo.RecordAttempt(ctx, delivery.ID, entity.DeliverySent, nil, now); err != nil {
+			return err
+		}
+		return d.notificationRepo.MarkDelivered(ctx, notification.ID)
+	}
+
+	lastError := sendErr.Error()
+
+	if isInvalidToken(sendErr) {
+		log.Warn("web push subscription expired, removing device")
+		if err := d.deviceRepo.DeleteByToken(ctx, delivery.DeviceToken); err != nil {
+			log.WithError(err).Warn("failed to delete expired subscription")
+		}
+		return d.deliveryRepo.RecordAttempt(ctx, delivery.ID, entity.DeliveryInvalidToken, &lastError, now)
+	}
+
+	attempts := delivery.Attempts + 1
+	if attempts >= d.policy.MaxAttempts {
+		log.WithError(sendErr).Error("web push delivery attempts exhausted, moving to dead letter")
+		return d.deliveryRepo.RecordAttempt(ctx, delivery.ID, entity.DeliveryDead, &lastError, now)
+	}
+
+	next := now.Add(d.policy.Backoff(attempts))
+	log.WithError(sendErr).Warnf("failed to send web push, retry at %s", next.Format(time.RFC3339))
+
+	return d.deliveryRepo.RecordAttempt(ctx, delivery.ID, entity.DeliveryFailed, &lastError, next)
+}
+
+func isInvalidToken(err error) bool {
+	return errors.Is(err, sender.ErrInvalidToken)
+}
@@ -0,0 +1,239 @@
+package webpush_sender
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	device_repository "github.com/4udiwe/coworking/notification-service/internal/repository/device"
+	"github.com/4udiwe/coworking/notification-service/internal/sender"
+	firebase_sender "github.com/4udiwe/coworking/notification-service/internal/sender/firebase"
+	"github.com/4udiwe/coworking/notification-service/internal/sender/webpush/mocks"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+var testPolicy = firebase_sender.RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Minute,
+	MaxDelay:    time.Hour,
+}
+
+func webDevice(endpoint string) entity.UserDevice {
+	return entity.UserDevice{
+		ID:          uuid.New(),
+		DeviceToken: endpoint,
+		Platform:    entity.PlatformWeb,
+		Subscription: &entity.WebPushSubscription{
+			Endpoint: endpoint,
+			P256dh:   "p256dh",
+			Auth:     "auth",
+		},
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	ctx := context.Background()
+	notification := entity.Notification{ID: uuid.New(), UserID: uuid.New()}
+	sendErr := errors.New("push service unavailable")
+
+	type MockBehavior func(
+		pushSender *mocks.MockPushSender,
+		deviceRepo *mocks.MockDeviceRepository,
+		deliveryRepo *mocks.MockDeliveryRepository,
+		notificationRepo *mocks.MockNotificationRepository,
+	)
+
+	tests := []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantErr      bool
+	}{
+		{
+			name: "send_failure_is_scheduled_for_retry",
+			mockBehavior: func(
+				pushSender *mocks.MockPushSender,
+				deviceRepo *mocks.MockDeviceRepository,
+				deliveryRepo *mocks.MockDeliveryRepository,
+				notificationRepo *mocks.MockNotificationRepository,
+			) {
+				failing := webDevice("https://push.example/failing")
+				working := webDevice("https://push.example/working")
+				android := entity.UserDevice{ID: uuid.New(), DeviceToken: "fcm", Platform: "android"}
+
+				deviceRepo.EXPECT().FindByUserID(ctx, notification.UserID).
+					Return([]entity.UserDevice{android, failing, working}, nil)
+
+				failingDelivery := entity.PushDelivery{ID: uuid.New(), DeviceToken: failing.DeviceToken, Status: entity.DeliveryQueued}
+				workingDelivery := entity.PushDelivery{ID: uuid.New(), DeviceToken: working.DeviceToken, Status: entity.DeliveryQueued}
+
+				deliveryRepo.EXPECT().Enqueue(ctx, notification.ID, failing).Return(failingDelivery, nil)
+				deliveryRepo.EXPECT().Enqueue(ctx, notification.ID, working).Return(workingDelivery, nil)
+
+				pushSender.EXPECT().Send(ctx, *failing.Subscription, gomock.Any()).Return(sendErr)
+				pushSender.EXPECT().Send(ctx, *working.Subscription, gomock.Any()).Return(nil)
+
+				deliveryRepo.EXPECT().
+					RecordAttempt(ctx, failingDelivery.ID, entity.DeliveryFailed, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ entity.DeliveryStatus, lastError *string, next time.Time) error {
+						if lastError == nil || *lastError != sendErr.Error() {
+							t.Errorf("lastError = %v, want %q", lastError, sendErr.Error())
+						}
+						if time.Until(next) < testPolicy.BaseDelay-time.Second {
+							t.Errorf("next attempt %s is earlier than base delay", next)
+						}
+						return nil
+					})
+				deliveryRepo.EXPECT().
+					RecordAttempt(ctx, workingDelivery.ID, entity.DeliverySent, nil, gomock.Any()).
+					Return(nil)
+				notificationRepo.EXPECT().MarkDelivered(ctx, notification.ID).Return(nil)
+			},
+		},
+		{
+			name: "redelivered_event_does_not_resend",
+			mockBehavior: func(
+				pushSender *mocks.MockPushSender,
+				deviceRepo *mocks.MockDeviceRepository,
+				deliveryRepo *mocks.MockDeliveryRepository,
+				notificationRepo *mocks.MockNotificationRepository,
+			) {
+				device := webDevice("https://push.example/a")
+				deviceRepo.EXPECT().FindByUserID(ctx, notification.UserID).Return([]entity.UserDevice{device}, nil)
+				deliveryRepo.EXPECT().Enqueue(ctx, notification.ID, device).
+					Return(entity.PushDelivery{ID: uuid.New(), Status: entity.DeliveryFailed, Attempts: 1}, nil)
+			},
+		},
+		{
+			name: "expired_subscription_is_removed",
+			mockBehavior: func(
+				pushSender *mocks.MockPushSender,
+				deviceRepo *mocks.MockDeviceRepository,
+				deliveryRepo *mocks.MockDeliveryRepository,
+				notificationRepo *mocks.MockNotificationRepository,
+			) {
+				device := webDevice("https://push.example/gone")
+				delivery := entity.PushDelivery{ID: uuid.New(), DeviceToken: device.DeviceToken, Status: entity.DeliveryQueued}
+
+				deviceRepo.EXPECT().FindByUserID(ctx, notification.UserID).Return([]entity.UserDevice{device}, nil)
+				deliveryRepo.EXPECT().Enqueue(ctx, notification.ID, device).Return(delivery, nil)
+				pushSender.EXPECT().Send(ctx, *device.Subscription, gomock.Any()).Return(sender.ErrInvalidToken)
+				deviceRepo.EXPECT().DeleteByToken(ctx, device.DeviceToken).Return(nil)
+				deliveryRepo.EXPECT().
+					RecordAttempt(ctx, delivery.ID, entity.DeliveryInvalidToken, gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name: "enqueue_error",
+			mockBehavior: func(
+				pushSender *mocks.MockPushSender,
+				deviceRepo *mocks.MockDeviceRepository,
+				deliveryRepo *mocks.MockDeliveryRepository,
+				notificationRepo *mocks.MockNotificationRepository,
+			) {
+				device := webDevice("https://push.example/a")
+				deviceRepo.EXPECT().FindByUserID(ctx, notification.UserID).Return([]entity.UserDevice{device}, nil)
+				deliveryRepo.EXPECT().Enqueue(ctx, notification.ID, device).
+					Return(entity.PushDelivery{}, errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			pushSender := mocks.NewMockPushSender(ctrl)
+			deviceRepo := mocks.NewMockDeviceRepository(ctrl)
+			deliveryRepo := mocks.NewMockDeliveryRepository(ctrl)
+			notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+
+			tt.mockBehavior(pushSender, deviceRepo, deliveryRepo, notificationRepo)
+
+			d := NewDispatcher(pushSender, deviceRepo, deliveryRepo, notificationRepo, testPolicy)
+
+			err := d.Dispatch(ctx, notification)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Dispatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAttempt(t *testing.T) {
+	ctx := context.Background()
+	notification := entity.Notification{ID: uuid.New(), UserID: uuid.New()}
+	device := webDevice("https://push.example/a")
+
+	t.Run("resends_to_stored_subscription", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		pushSender := mocks.NewMockPushSender(ctrl)
+		deviceRepo := mocks.NewMockDeviceRepository(ctrl)
+		deliveryRepo := mocks.NewMockDeliveryRepository(ctrl)
+		notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+
+		delivery := entity.PushDelivery{ID: uuid.New(), DeviceToken: device.DeviceToken, Status: entity.DeliveryFailed, Attempts: 1}
+
+		deviceRepo.EXPECT().FindByToken(ctx, device.DeviceToken).Return(device, nil)
+		pushSender.EXPECT().Send(ctx, *device.Subscription, gomock.Any()).Return(nil)
+		deliveryRepo.EXPECT().RecordAttempt(ctx, delivery.ID, entity.DeliverySent, nil, gomock.Any()).Return(nil)
+		notificationRepo.EXPECT().MarkDelivered(ctx, notification.ID).Return(nil)
+
+		d := NewDispatcher(pushSender, deviceRepo, deliveryRepo, notificationRepo, testPolicy)
+		if err := d.Attempt(ctx, notification, delivery); err != nil {
+			t.Fatalf("Attempt() error = %v", err)
+		}
+	})
+
+	t.Run("last_attempt_moves_to_dead", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		pushSender := mocks.NewMockPushSender(ctrl)
+		deviceRepo := mocks.NewMockDeviceRepository(ctrl)
+		deliveryRepo := mocks.NewMockDeliveryRepository(ctrl)
+		notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+
+		delivery := entity.PushDelivery{
+			ID:          uuid.New(),
+			DeviceToken: device.DeviceToken,
+			Status:      entity.DeliveryFailed,
+			Attempts:    testPolicy.MaxAttempts - 1,
+		}
+
+		deviceRepo.EXPECT().FindByToken(ctx, device.DeviceToken).Return(device, nil)
+		pushSender.EXPECT().Send(ctx, *device.Subscription, gomock.Any()).Return(errors.New("timeout"))
+		deliveryRepo.EXPECT().RecordAttempt(ctx, delivery.ID, entity.DeliveryDead, gomock.Any(), gomock.Any()).Return(nil)
+
+		d := NewDispatcher(pushSender, deviceRepo, deliveryRepo, notificationRepo, testPolicy)
+		if err := d.Attempt(ctx, notification, delivery); err != nil {
+			t.Fatalf("Attempt() error = %v", err)
+		}
+	})
+
+	t.Run("removed_device_closes_delivery", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		pushSender := mocks.NewMockPushSender(ctrl)
+		deviceRepo := mocks.NewMockDeviceRepository(ctrl)
+		deliveryRepo := mocks.NewMockDeliveryRepository(ctrl)
+		notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+
+		delivery := entity.PushDelivery{ID: uuid.New(), DeviceToken: device.DeviceToken, Status: entity.DeliveryFailed, Attempts: 1}
+
+		deviceRepo.EXPECT().FindByToken(ctx, device.DeviceToken).
+			Return(entity.UserDevice{}, device_repository.ErrDeviceNotFound)
+		deliveryRepo.EXPECT().
+			RecordAttempt(ctx, delivery.ID, entity.DeliveryInvalidToken, gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		d := NewDispatcher(pushSender, deviceRepo, deliveryRepo, notificationRepo, testPolicy)
+		if err := d.Attempt(ctx, notification, delivery); err != nil {
+			t.Fatalf("Attempt() error = %v", err)
+		}
+	})
+}
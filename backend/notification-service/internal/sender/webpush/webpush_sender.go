@@ -0,0 +1,104 @@
+package webpush_sender
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/4udiwe/coworking/notification-service/internal/sender"
+	"github.com/SherClockHolmes/webpush-go"
+	"github.com/sirupsen/logrus"
+)
+
+type Config struct {
+	// Пара ключей VAPID (RFC 8292) в base64url, как выдаёт webpush.GenerateVAPIDKeys
+	PublicKey  string
+	PrivateKey string
+	// Контакт отправителя для push-сервиса: mailto: или https: адрес
+	Subscriber string
+	// Сколько push-сервис хранит сообщение, пока браузер офлайн
+	TTL     time.Duration
+	Timeout time.Duration
+}
+
+// VAPIDSender отправляет сообщения в push-сервис браузера по подписке.
+// Payload шифруется по RFC 8291, запрос подписывается VAPID-токеном.
+type VAPIDSender struct {
+	cfg    Config
+	client *http.Client
+}
+
+func NewVAPIDSender(cfg Config) *VAPIDSender {
+	return &VAPIDSender{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// Тело push-события, которое получает service worker
+type payload struct {
+	Title          string            `json:"title"`
+	Body           string            `json:"body"`
+	NotificationID string            `json:"notificationId"`
+	ActionURL      string            `json:"actionUrl,omitempty"`
+	Data           map[string]string `json:"data,omitempty"`
+}
+
+func (s *VAPIDSender) Send(
+	ctx context.Context,
+	subscription entity.WebPushSubscription,
+	msg sender.PushMessage,
+) error {
+	logrus.Debug("webpush: sending notification")
+
+	body, err := json.Marshal(payload{
+		Title:          msg.Title,
+		Body:           msg.Body,
+		NotificationID: msg.NotificationID,
+		ActionURL:      msg.ActionURL,
+		Data:           msg.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := webpush.SendNotificationWithContext(ctx, body,
+		&webpush.Subscription{
+			Endpoint: subscription.Endpoint,
+			Keys: webpush.Keys{
+				P256dh: subscription.P256dh,
+				Auth:   subscription.Auth,
+			},
+		},
+		&webpush.Options{
+			HTTPClient:      s.client,
+			Subscriber:      s.cfg.Subscriber,
+			VAPIDPublicKey:  s.cfg.PublicKey,
+			VAPIDPrivateKey: s.cfg.PrivateKey,
+			TTL:             int(s.cfg.TTL.Seconds()),
+			Urgency:         webpush.UrgencyHigh,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	// Подписка отозвана или истекла — браузер больше не примет сообщения
+	case resp.StatusCode == http.StatusGone || resp.StatusCode == http.StatusNotFound:
+		logrus.Warn("webpush: subscription expired")
+		return sender.ErrInvalidToken
+
+	case resp.StatusCode >= http.StatusBadRequest:
+		text, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webpush: push service responded %d: %s", resp.StatusCode, text)
+	}
+
+	logrus.Debug("webpush: notification sent")
+	return nil
+}
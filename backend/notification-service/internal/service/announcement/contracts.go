@@ -0,0 +1,48 @@
+package announcement_service
+
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type AnnouncementRepository interface {
+	Create(ctx context.Context, announcement entity.Announcement) (entity.Announcement, error)
+	GetByID(ctx context.Context, id uuid.UUID) (entity.Announcement, error)
+	GetForUpdate(ctx context.Context, id uuid.UUID) (entity.Announcement, error)
+	List(ctx context.Context, limit, offset int) ([]entity.Announcement, int, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status entity.AnnouncementStatus) error
+	GetStats(ctx context.Context, id uuid.UUID) (entity.AnnouncementStats, error)
+}
+
+type NotificationRepository interface {
+	CreateForAnnouncement(
+		ctx context.Context,
+		announcement entity.Announcement,
+		userIDs []uuid.UUID,
+		payload []byte,
+	) ([]entity.Notification, error)
+	DeleteByAnnouncement(ctx context.Context, announcementID uuid.UUID) ([]uuid.UUID, error)
+}
+
+type OutboxRepository interface {
+	Create(ctx context.Context, event entity.OutboxEvent) error
+}
+
+// StreamPublisher сообщает открытым потокам клиентов об изменениях
+type StreamPublisher interface {
+	Publish(ctx context.Context, event entity.StreamEvent) error
+}
+
+// UserDirectory — активные пользователи из auth-service (authclient.Client)
+type UserDirectory interface {
+	ListActiveUserIDs(ctx context.Context, role string, after uuid.UUID, limit int) ([]uuid.UUID, *uuid.UUID, error)
+}
+
+// BookingDirectory — пользователи с активными бронированиями из booking-service
+type BookingDirectory interface {
+	ListActiveCoworkingUsers(ctx context.Context, coworkingID uuid.UUID) ([]uuid.UUID, error)
+}
@@ -0,0 +1,13 @@
+package announcement_service
+
+import "errors"
+
+var (
+	ErrAnnouncementNotFound      = errors.New("announcement not found")
+	ErrInvalidAnnouncement       = errors.New("invalid announcement target")
+	ErrAnnouncementRetracted     = errors.New("announcement already retracted")
+	ErrCannotCreateAnnouncement  = errors.New("cannot create announcement")
+	ErrCannotFetchAnnouncements  = errors.New("cannot fetch announcements")
+	ErrCannotSendAnnouncement    = errors.New("cannot send announcement")
+	ErrCannotRetractAnnouncement = errors.New("cannot retract announcement")
+)
@@ -0,0 +1,460 @@
+package announcement_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/transactor"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	announcement_repository "github.com/4udiwe/coworking/notification-service/internal/repository/announcement"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// Событие, которое запускает отправку рассылки
+const dueEventType = "announcement.due"
+
+// Рассылка отозвана, пока сохранялись получатели
+var errAnnouncementStopped = errors.New("announcement is no longer sending")
+
+type Config struct {
+	// Сколько получателей сохраняется в одной транзакции
+	BatchSize int
+	// Топик событий сервиса, в него scheduler-service вернёт announcement.due
+	EventsTopic string
+	// Топик запросов таймеров scheduler-service
+	TimersTopic string
+}
+
+/*
+AnnouncementService — рассылки администратора.
+
+Рассылка создаётся в статусе scheduled вместе с событием announcement.due:
+сразу в outbox, если время отправки наступило, иначе — через таймер
+scheduler-service. Получив событие, Send сохраняет уведомления получателей
+батчами; каждое уведомление дальше проходит обычный путь notification.created
+(push, email, web push с учётом настроек пользователя).
+*/
+type AnnouncementService struct {
+	announcementRepo AnnouncementRepository
+	notificationRepo NotificationRepository
+	outboxRepo       OutboxRepository
+	streamPublisher  StreamPublisher
+	users            UserDirectory
+	bookings         BookingDirectory
+
+	txManager transactor.Transactor
+	cfg       Config
+}
+
+func New(
+	announcementRepo AnnouncementRepository,
+	notificationRepo NotificationRepository,
+	outboxRepo OutboxRepository,
+	streamPublisher StreamPublisher,
+	users UserDirectory,
+	bookings BookingDirectory,
+	txManager transactor.Transactor,
+	cfg Config,
+) *AnnouncementService {
+	return &AnnouncementService{
+		announcementRepo: announcementRepo,
+		notificationRepo: notificationRepo,
+		outboxRepo:       outboxRepo,
+		streamPublisher:  streamPublisher,
+		users:            users,
+		bookings:         bookings,
+		txManager:        txManager,
+		cfg:              cfg,
+	}
+}
+
+// Create сохраняет рассылку и планирует её отправку. Нулевой ScheduledAt — отправить сейчас.
+func (s *AnnouncementService) Create(
+	ctx context.Context,
+	announcement entity.Announcement,
+) (entity.Announcement, error) {
+	if err := validateTarget(announcement); err != nil {
+		return entity.Announcement{}, err
+	}
+
+	now := time.Now()
+	if announcement.ScheduledAt.IsZero() {
+		announcement.ScheduledAt = now
+	}
+
+	var created entity.Announcement
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.announcementRepo.Create(ctx, announcement)
+		if err != nil {
+			return err
+		}
+
+		if created.ScheduledAt.After(now) {
+			return s.outboxRepo.Create(ctx, s.timerRequest(created))
+		}
+		return s.outboxRepo.Create(ctx, dueEvent(created.ID))
+	})
+
+	if err != nil {
+		logrus.WithError(err).Error("failed to create announcement")
+		return entity.Announcement{}, ErrCannotCreateAnnouncement
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"announcement_id": created.ID,
+		"target":          created.Target,
+		"scheduled_at":    created.ScheduledAt,
+		"created_by":      created.CreatedBy,
+	}).Info("announcement created")
+
+	return created, nil
+}
+
+// Get возвращает рассылку и её охват
+func (s *AnnouncementService) Get(
+	ctx context.Context,
+	id uuid.UUID,
+) (entity.Announcement, entity.AnnouncementStats, error) {
+	announcement, err := s.announcementRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, announcement_repository.ErrAnnouncementNotFound) {
+			return entity.Announcement{}, entity.AnnouncementStats{}, ErrAnnouncementNotFound
+		}
+		return entity.Announcement{}, entity.AnnouncementStats{}, ErrCannotFetchAnnouncements
+	}
+
+	stats, err := s.announcementRepo.GetStats(ctx, id)
+	if err != nil {
+		return entity.Announcement{}, entity.AnnouncementStats{}, ErrCannotFetchAnnouncements
+	}
+
+	return announcement, stats, nil
+}
+
+func (s *AnnouncementService) List(
+	ctx context.Context,
+	limit, offset int,
+) ([]entity.Announcement, int, error) {
+	announcements, total, err := s.announcementRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, ErrCannotFetchAnnouncements
+	}
+	return announcements, total, nil
+}
+
+/*
+Send отправляет рассылку по событию announcement.due.
+
+Повторная доставка события безопасна: отправленная или отозванная рассылка
+пропускается, а прерванная (статус sending) рассылается заново — уведомления
+уже сохранённых получателей не дублируются.
+*/
+func (s *AnnouncementService) Send(ctx context.Context, id uuid.UUID) error {
+	var announcement entity.Announcement
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		announcement, err = s.announcementRepo.GetForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if announcement.Status != entity.AnnouncementScheduled {
+			return nil
+		}
+
+		announcement.Status = entity.AnnouncementSending
+		return s.announcementRepo.UpdateStatus(ctx, id, entity.AnnouncementSending)
+	})
+
+	if err != nil {
+		if errors.Is(err, announcement_repository.ErrAnnouncementNotFound) {
+			return ErrAnnouncementNotFound
+		}
+		return ErrCannotSendAnnouncement
+	}
+
+	if announcement.Status != entity.AnnouncementSending {
+		logrus.WithFields(logrus.Fields{
+			"announcement_id": id,
+			"status":          announcement.Status,
+		}).Info("announcement already handled, skipping")
+		return nil
+	}
+
+	payload, err := json.Marshal(map[string]any{"announcementId": id})
+	if err != nil {
+		return ErrCannotSendAnnouncement
+	}
+
+	created := 0
+
+	err = s.forEachRecipientBatch(ctx, announcement, func(userIDs []uuid.UUID) error {
+		return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			current, err := s.announcementRepo.GetForUpdate(ctx, id)
+			if err != nil {
+				return err
+			}
+			if current.Status != entity.AnnouncementSending {
+				return errAnnouncementStopped
+			}
+
+			notifications, err := s.notificationRepo.CreateForAnnouncement(ctx, announcement, userIDs, payload)
+			if err != nil {
+				return err
+			}
+
+			for _, notification := range notifications {
+				if err := s.publishCreated(ctx, notification); err != nil {
+					return err
+				}
+			}
+
+			created += len(notifications)
+			return nil
+		})
+	})
+
+	if errors.Is(err, errAnnouncementStopped) {
+		logrus.WithField("announcement_id", id).Info("announcement retracted while sending")
+		return nil
+	}
+
+	if err != nil {
+		logrus.WithField("announcement_id", id).WithError(err).Error("failed to send announcement")
+		return ErrCannotSendAnnouncement
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.announcementRepo.GetForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if current.Status != entity.AnnouncementSending {
+			return nil
+		}
+		return s.announcementRepo.UpdateStatus(ctx, id, entity.AnnouncementSent)
+	})
+
+	if err != nil {
+		return ErrCannotSendAnnouncement
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"announcement_id": id,
+		"notifications":   created,
+	}).Info("announcement sent")
+
+	return nil
+}
+
+/*
+Retract отзывает рассылку: удаляет её уведомления у получателей и отменяет
+таймер, если рассылка ещё не отправлялась. Уже ушедшие push и письма
+отозвать нельзя, но недоставленные push больше не повторяются.
+*/
+func (s *AnnouncementService) Retract(ctx context.Context, id uuid.UUID) (entity.Announcement, error) {
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		announcement, err := s.announcementRepo.GetForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if announcement.Status == entity.AnnouncementRetracted {
+			return ErrAnnouncementRetracted
+		}
+
+		if err := s.announcementRepo.UpdateStatus(ctx, id, entity.AnnouncementRetracted); err != nil {
+			return err
+		}
+
+		// Для рассылки без таймера scheduler-service просто не найдёт ключ
+		if announcement.Status == entity.AnnouncementScheduled {
+			if err := s.outboxRepo.Create(ctx, s.timerCancel(id)); err != nil {
+				return err
+			}
+		}
+
+		userIDs, err := s.notificationRepo.DeleteByAnnouncement(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		// Счётчик непрочитанных у получателей изменился
+		for _, userID := range userIDs {
+			err := s.streamPublisher.Publish(ctx, entity.StreamEvent{
+				Type:   entity.StreamResync,
+				UserID: userID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, announcement_repository.ErrAnnouncementNotFound):
+			return entity.Announcement{}, ErrAnnouncementNotFound
+		case errors.Is(err, ErrAnnouncementRetracted):
+			return entity.Announcement{}, ErrAnnouncementRetracted
+		}
+		logrus.WithField("announcement_id", id).WithError(err).Error("failed to retract announcement")
+		return entity.Announcement{}, ErrCannotRetractAnnouncement
+	}
+
+	logrus.WithField("announcement_id", id).Info("announcement retracted")
+
+	announcement, err := s.announcementRepo.GetByID(ctx, id)
+	if err != nil {
+		return entity.Announcement{}, ErrCannotFetchAnnouncements
+	}
+
+	return announcement, nil
+}
+
+// forEachRecipientBatch передаёт fn получателей рассылки порциями по BatchSize.
+// Получатели coworking определяются в момент отправки.
+func (s *AnnouncementService) forEachRecipientBatch(
+	ctx context.Context,
+	announcement entity.Announcement,
+	fn func(userIDs []uuid.UUID) error,
+) error {
+	switch announcement.Target {
+
+	case entity.AnnouncementTargetAll, entity.AnnouncementTargetRole:
+		role := lo.FromPtr(announcement.TargetRole)
+		after := uuid.Nil
+
+		for {
+			userIDs, next, err := s.users.ListActiveUserIDs(ctx, role, after, s.cfg.BatchSize)
+			if err != nil {
+				return err
+			}
+			if len(userIDs) > 0 {
+				if err := fn(userIDs); err != nil {
+					return err
+				}
+			}
+			if next == nil {
+				return nil
+			}
+			after = *next
+		}
+
+	case entity.AnnouncementTargetCoworking:
+		userIDs, err := s.bookings.ListActiveCoworkingUsers(ctx, lo.FromPtr(announcement.TargetCoworkingID))
+		if err != nil {
+			return err
+		}
+		return eachChunk(userIDs, s.cfg.BatchSize, fn)
+
+	case entity.AnnouncementTargetUsers:
+		return eachChunk(announcement.TargetUserIDs, s.cfg.BatchSize, fn)
+	}
+
+	return ErrInvalidAnnouncement
+}
+
+func (s *AnnouncementService) publishCreated(ctx context.Context, notification entity.Notification) error {
+	// Формат совпадает с NotificationService.CreateNotification
+	err := s.outboxRepo.Create(ctx, entity.OutboxEvent{
+		AggregateType: "notification",
+		AggregateID:   notification.ID,
+		EventType:     "created",
+		Payload: map[string]any{
+			"notificataionId":  notification.ID,
+			"notificationType": notification.Type,
+			"userId":           notification.UserID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.streamPublisher.Publish(ctx, entity.StreamEvent{
+		Type:           entity.StreamNotificationCreated,
+		UserID:         notification.UserID,
+		NotificationID: &notification.ID,
+	})
+}
+
+func (s *AnnouncementService) timerRequest(announcement entity.Announcement) entity.OutboxEvent {
+	return entity.OutboxEvent{
+		AggregateType: "scheduler",
+		AggregateID:   announcement.ID,
+		EventType:     "timer.requested",
+		Topic:         s.cfg.TimersTopic,
+		Payload: map[string]any{
+			"key":       timerKey(announcement.ID),
+			"topic":     s.cfg.EventsTopic,
+			"eventType": dueEventType,
+			"triggerAt": announcement.ScheduledAt,
+			"payload":   map[string]any{"announcementId": announcement.ID},
+		},
+	}
+}
+
+func (s *AnnouncementService) timerCancel(id uuid.UUID) entity.OutboxEvent {
+	return entity.OutboxEvent{
+		AggregateType: "scheduler",
+		AggregateID:   id,
+		EventType:     "timer.cancel",
+		Topic:         s.cfg.TimersTopic,
+		Payload:       map[string]any{"key": timerKey(id)},
+	}
+}
+
+func dueEvent(id uuid.UUID) entity.OutboxEvent {
+	return entity.OutboxEvent{
+		AggregateType: "announcement",
+		AggregateID:   id,
+		EventType:     "due",
+		Payload:       map[string]any{"announcementId": id},
+	}
+}
+
+func timerKey(id uuid.UUID) string {
+	return "notification-service:announcement:" + id.String()
+}
+
+func eachChunk(userIDs []uuid.UUID, size int, fn func(userIDs []uuid.UUID) error) error {
+	for _, chunk := range lo.Chunk(userIDs, size) {
+		if err := fn(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateTarget проверяет, что заполнено ровно поле выбранной аудитории
+func validateTarget(announcement entity.Announcement) error {
+	hasRole := announcement.TargetRole != nil && *announcement.TargetRole != ""
+	hasCoworking := announcement.TargetCoworkingID != nil && *announcement.TargetCoworkingID != uuid.Nil
+	hasUsers := len(announcement.TargetUserIDs) > 0
+
+	var ok bool
+	switch announcement.Target {
+	case entity.AnnouncementTargetAll:
+		ok = !hasRole && !hasCoworking && !hasUsers
+	case entity.AnnouncementTargetRole:
+		ok = hasRole && !hasCoworking && !hasUsers
+	case entity.AnnouncementTargetCoworking:
+		ok = !hasRole && hasCoworking && !hasUsers
+	case entity.AnnouncementTargetUsers:
+		ok = !hasRole && !hasCoworking && hasUsers
+	}
+
+	if !ok {
+		return ErrInvalidAnnouncement
+	}
+	return nil
+}
@@ -0,0 +1,442 @@
+package announcement_service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	announcement_repository "github.com/4udiwe/coworking/notification-service/internal/repository/announcement"
+	"github.com/4udiwe/coworking/notification-service/internal/service/announcement/mocks"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/mock/gomock"
+)
+
+type dummyTransactor struct{}
+
+func (d dummyTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+var testConfig = Config{
+	BatchSize:   2,
+	EventsTopic: "notification.events",
+	TimersTopic: "scheduler.timers",
+}
+
+type testMocks struct {
+	announcements *mocks.MockAnnouncementRepository
+	notifications *mocks.MockNotificationRepository
+	outbox        *mocks.MockOutboxRepository
+	stream        *mocks.MockStreamPublisher
+	users         *mocks.MockUserDirectory
+	bookings      *mocks.MockBookingDirectory
+}
+
+func newTestService(t *testing.T) (*AnnouncementService, testMocks) {
+	ctrl := gomock.NewController(t)
+	m := testMocks{
+		announcements: mocks.NewMockAnnouncementRepository(ctrl),
+		notifications: mocks.NewMockNotificationRepository(ctrl),
+		outbox:        mocks.NewMockOutboxRepository(ctrl),
+		stream:        mocks.NewMockStreamPublisher(ctrl),
+		users:         mocks.NewMockUserDirectory(ctrl),
+		bookings:      mocks.NewMockBookingDirectory(ctrl),
+	}
+	svc := New(m.announcements, m.notifications, m.outbox, m.stream, m.users, m.bookings, dummyTransactor{}, testConfig)
+	return svc, m
+}
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	tests := []struct {
+		name         string
+		announcement entity.Announcement
+		repoErr      error
+		wantEvent    func(t *testing.T, event entity.OutboxEvent)
+		wantError    error
+	}{
+		{
+			// Без ScheduledAt событие announcement.due сразу уходит в outbox сервиса
+			name:         "send_now",
+			announcement: entity.Announcement{Target: entity.AnnouncementTargetAll},
+			wantEvent: func(t *testing.T, event entity.OutboxEvent) {
+				if event.AggregateType != "announcement" || event.EventType != "due" || event.Topic != "" {
+					t.Errorf("unexpected due event %+v", event)
+				}
+				if event.Payload["announcementId"] != id {
+					t.Errorf("announcementId = %v, want %s", event.Payload["announcementId"], id)
+				}
+			},
+		},
+		{
+			// Отложенная рассылка заводит таймер в scheduler-service
+			name: "scheduled",
+			announcement: entity.Announcement{
+				Target:      entity.AnnouncementTargetRole,
+				TargetRole:  lo.ToPtr("student"),
+				ScheduledAt: time.Now().Add(time.Hour),
+			},
+			wantEvent: func(t *testing.T, event entity.OutboxEvent) {
+				if event.EventType != "timer.requested" || event.Topic != testConfig.TimersTopic {
+					t.Errorf("unexpected timer request %+v", event)
+				}
+				if event.Payload["key"] != timerKey(id) ||
+					event.Payload["topic"] != testConfig.EventsTopic ||
+					event.Payload["eventType"] != dueEventType {
+					t.Errorf("unexpected timer payload %v", event.Payload)
+				}
+			},
+		},
+		{
+			name: "role_without_value",
+			announcement: entity.Announcement{
+				Target: entity.AnnouncementTargetRole,
+			},
+			wantError: ErrInvalidAnnouncement,
+		},
+		{
+			name: "users_with_extra_target",
+			announcement: entity.Announcement{
+				Target:            entity.AnnouncementTargetUsers,
+				TargetUserIDs:     []uuid.UUID{uuid.New()},
+				TargetCoworkingID: lo.ToPtr(uuid.New()),
+			},
+			wantError: ErrInvalidAnnouncement,
+		},
+		{
+			name:         "repository_error",
+			announcement: entity.Announcement{Target: entity.AnnouncementTargetAll},
+			repoErr:      errors.New("db is down"),
+			wantError:    ErrCannotCreateAnnouncement,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestService(t)
+
+			if !errors.Is(tt.wantError, ErrInvalidAnnouncement) {
+				m.announcements.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, a entity.Announcement) (entity.Announcement, error) {
+						if a.ScheduledAt.IsZero() {
+							t.Error("ScheduledAt is not set")
+						}
+						a.ID = id
+						a.Status = entity.AnnouncementScheduled
+						return a, tt.repoErr
+					})
+			}
+			if tt.wantEvent != nil {
+				m.outbox.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, event entity.OutboxEvent) error {
+						tt.wantEvent(t, event)
+						return nil
+					})
+			}
+
+			created, err := svc.Create(ctx, tt.announcement)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantError)
+			}
+			if tt.wantError == nil && created.ID != id {
+				t.Errorf("Create() = %+v", created)
+			}
+		})
+	}
+}
+
+func TestSend_Targets(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	coworkingID := uuid.New()
+	u1, u2, u3 := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name         string
+		announcement entity.Announcement
+		directory    func(m testMocks)
+		wantBatches  [][]uuid.UUID
+	}{
+		{
+			// Активные пользователи читаются страницами по BatchSize
+			name:         "all_users",
+			announcement: entity.Announcement{Target: entity.AnnouncementTargetAll},
+			directory: func(m testMocks) {
+				gomock.InOrder(
+					m.users.EXPECT().ListActiveUserIDs(ctx, "", uuid.Nil, 2).Return([]uuid.UUID{u1, u2}, &u2, nil),
+					m.users.EXPECT().ListActiveUserIDs(ctx, "", u2, 2).Return([]uuid.UUID{u3}, nil, nil),
+				)
+			},
+			wantBatches: [][]uuid.UUID{{u1, u2}, {u3}},
+		},
+		{
+			name: "role",
+			announcement: entity.Announcement{
+				Target:     entity.AnnouncementTargetRole,
+				TargetRole: lo.ToPtr("admin"),
+			},
+			directory: func(m testMocks) {
+				m.users.EXPECT().ListActiveUserIDs(ctx, "admin", uuid.Nil, 2).Return([]uuid.UUID{u1}, nil, nil)
+			},
+			wantBatches: [][]uuid.UUID{{u1}},
+		},
+		{
+			// Пустая страница не создаёт пустой батч
+			name:         "no_active_users",
+			announcement: entity.Announcement{Target: entity.AnnouncementTargetAll},
+			directory: func(m testMocks) {
+				m.users.EXPECT().ListActiveUserIDs(ctx, "", uuid.Nil, 2).Return(nil, nil, nil)
+			},
+		},
+		{
+			// Пользователи коворкинга определяются в момент отправки
+			name: "coworking_active_users",
+			announcement: entity.Announcement{
+				Target:            entity.AnnouncementTargetCoworking,
+				TargetCoworkingID: &coworkingID,
+			},
+			directory: func(m testMocks) {
+				m.bookings.EXPECT().ListActiveCoworkingUsers(ctx, coworkingID).Return([]uuid.UUID{u1, u2, u3}, nil)
+			},
+			wantBatches: [][]uuid.UUID{{u1, u2}, {u3}},
+		},
+		{
+			name: "explicit_users",
+			announcement: entity.Announcement{
+				Target:        entity.AnnouncementTargetUsers,
+				TargetUserIDs: []uuid.UUID{u3, u1},
+			},
+			directory:   func(m testMocks) {},
+			wantBatches: [][]uuid.UUID{{u3, u1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestService(t)
+
+			announcement := tt.announcement
+			announcement.ID = id
+			announcement.Status = entity.AnnouncementScheduled
+
+			sending := announcement
+			sending.Status = entity.AnnouncementSending
+
+			// Перевод в sending, проверка статуса перед каждым батчем и в конце
+			m.announcements.EXPECT().GetForUpdate(ctx, id).Return(announcement, nil)
+			m.announcements.EXPECT().UpdateStatus(ctx, id, entity.AnnouncementSending).Return(nil)
+			m.announcements.EXPECT().GetForUpdate(ctx, id).Return(sending, nil).Times(len(tt.wantBatches) + 1)
+			m.announcements.EXPECT().UpdateStatus(ctx, id, entity.AnnouncementSent).Return(nil)
+
+			tt.directory(m)
+
+			var batches [][]uuid.UUID
+			total := 0
+			for _, batch := range tt.wantBatches {
+				total += len(batch)
+			}
+
+			m.notifications.EXPECT().CreateForAnnouncement(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, a entity.Announcement, userIDs []uuid.UUID, _ []byte) ([]entity.Notification, error) {
+					if a.ID != id {
+						t.Errorf("announcement = %s, want %s", a.ID, id)
+					}
+					batches = append(batches, userIDs)
+					return lo.Map(userIDs, func(userID uuid.UUID, _ int) entity.Notification {
+						return entity.Notification{ID: uuid.New(), UserID: userID, Type: entity.AnnouncementNotificationType}
+					}), nil
+				}).
+				Times(len(tt.wantBatches))
+
+			// Каждое уведомление проходит обычный путь notification.created
+			m.outbox.EXPECT().Create(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, event entity.OutboxEvent) error {
+					if event.AggregateType != "notification" || event.EventType != "created" {
+						t.Errorf("unexpected outbox event %+v", event)
+					}
+					return nil
+				}).
+				Times(total)
+			m.stream.EXPECT().Publish(ctx, gomock.Any()).Return(nil).Times(total)
+
+			if err := svc.Send(ctx, id); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			if !reflect.DeepEqual(batches, tt.wantBatches) {
+				t.Errorf("batches = %v, want %v", batches, tt.wantBatches)
+			}
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	userIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+	announcement := entity.Announcement{
+		ID:            id,
+		Target:        entity.AnnouncementTargetUsers,
+		TargetUserIDs: userIDs,
+		Status:        entity.AnnouncementScheduled,
+	}
+
+	withStatus := func(status entity.AnnouncementStatus) entity.Announcement {
+		a := announcement
+		a.Status = status
+		return a
+	}
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m testMocks)
+		wantError    error
+	}{
+		{
+			// Повторная доставка announcement.due для отправленной рассылки
+			name: "already_sent",
+			mockBehavior: func(m testMocks) {
+				m.announcements.EXPECT().GetForUpdate(ctx, id).Return(withStatus(entity.AnnouncementSent), nil)
+			},
+		},
+		{
+			name: "retracted_before_send",
+			mockBehavior: func(m testMocks) {
+				m.announcements.EXPECT().GetForUpdate(ctx, id).Return(withStatus(entity.AnnouncementRetracted), nil)
+			},
+		},
+		{
+			// Рассылку отозвали после первого батча: остальные батчи и статус sent не пишутся
+			name: "retracted_while_sending",
+			mockBehavior: func(m testMocks) {
+				gomock.InOrder(
+					m.announcements.EXPECT().GetForUpdate(ctx, id).Return(announcement, nil),
+					m.announcements.EXPECT().UpdateStatus(ctx, id, entity.AnnouncementSending).Return(nil),
+					m.announcements.EXPECT().GetForUpdate(ctx, id).Return(withStatus(entity.AnnouncementSending), nil),
+					m.notifications.EXPECT().CreateForAnnouncement(ctx, gomock.Any(), userIDs[:2], gomock.Any()).Return(nil, nil),
+					m.announcements.EXPECT().GetForUpdate(ctx, id).Return(withStatus(entity.AnnouncementRetracted), nil),
+				)
+			},
+		},
+		{
+			// Прерванная рассылка (статус sending) проходит заново, дубли отсекает репозиторий
+			name: "interrupted_sending",
+			mockBehavior: func(m testMocks) {
+				m.announcements.EXPECT().GetForUpdate(ctx, id).Return(withStatus(entity.AnnouncementSending), nil).Times(4)
+				m.notifications.EXPECT().CreateForAnnouncement(ctx, gomock.Any(), userIDs[:2], gomock.Any()).Return(nil, nil)
+				m.notifications.EXPECT().CreateForAnnouncement(ctx, gomock.Any(), userIDs[2:], gomock.Any()).Return(nil, nil)
+				m.announcements.EXPECT().UpdateStatus(ctx, id, entity.AnnouncementSent).Return(nil)
+			},
+		},
+		{
+			name: "not_found",
+			mockBehavior: func(m testMocks) {
+				m.announcements.EXPECT().GetForUpdate(ctx, id).
+					Return(entity.Announcement{}, announcement_repository.ErrAnnouncementNotFound)
+			},
+			wantError: ErrAnnouncementNotFound,
+		},
+		{
+			name: "batch_error",
+			mockBehavior: func(m testMocks) {
+				m.announcements.EXPECT().GetForUpdate(ctx, id).Return(announcement, nil)
+				m.announcements.EXPECT().UpdateStatus(ctx, id, entity.AnnouncementSending).Return(nil)
+				m.announcements.EXPECT().GetForUpdate(ctx, id).Return(withStatus(entity.AnnouncementSending), nil)
+				m.notifications.EXPECT().CreateForAnnouncement(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("db is down"))
+			},
+			wantError: ErrCannotSendAnnouncement,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestService(t)
+			tt.mockBehavior(m)
+
+			if err := svc.Send(ctx, id); !errors.Is(err, tt.wantError) {
+				t.Fatalf("Send() error = %v, wantErr %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestRetract(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	recipients := []uuid.UUID{uuid.New(), uuid.New()}
+
+	tests := []struct {
+		name       string
+		status     entity.AnnouncementStatus
+		getErr     error
+		wantCancel bool
+		wantError  error
+	}{
+		{
+			// Ещё не отправлена — таймер в scheduler-service отменяется
+			name:       "scheduled",
+			status:     entity.AnnouncementScheduled,
+			wantCancel: true,
+		},
+		{
+			name:   "sent",
+			status: entity.AnnouncementSent,
+		},
+		{
+			name:      "already_retracted",
+			status:    entity.AnnouncementRetracted,
+			wantError: ErrAnnouncementRetracted,
+		},
+		{
+			name:      "not_found",
+			getErr:    announcement_repository.ErrAnnouncementNotFound,
+			wantError: ErrAnnouncementNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestService(t)
+
+			m.announcements.EXPECT().GetForUpdate(ctx, id).
+				Return(entity.Announcement{ID: id, Status: tt.status}, tt.getErr)
+
+			if tt.wantError == nil {
+				m.announcements.EXPECT().UpdateStatus(ctx, id, entity.AnnouncementRetracted).Return(nil)
+				m.notifications.EXPECT().DeleteByAnnouncement(ctx, id).Return(recipients, nil)
+
+				// Получателям нужно пересчитать непрочитанные
+				for _, userID := range recipients {
+					m.stream.EXPECT().Publish(ctx, entity.StreamEvent{Type: entity.StreamResync, UserID: userID}).Return(nil)
+				}
+				m.announcements.EXPECT().GetByID(ctx, id).
+					Return(entity.Announcement{ID: id, Status: entity.AnnouncementRetracted}, nil)
+			}
+			if tt.wantCancel {
+				m.outbox.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, event entity.OutboxEvent) error {
+						if event.EventType != "timer.cancel" || event.Topic != testConfig.TimersTopic ||
+							event.Payload["key"] != timerKey(id) {
+							t.Errorf("unexpected timer cancel %+v", event)
+						}
+						return nil
+					})
+			}
+
+			retracted, err := svc.Retract(ctx, id)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("Retract() error = %v, wantErr %v", err, tt.wantError)
+			}
+			if tt.wantError == nil && retracted.Status != entity.AnnouncementRetracted {
+				t.Errorf("Retract() status = %s", retracted.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package delivery_service
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type DeliveryRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (entity.PushDelivery, error)
+	ListByNotification(ctx context.Context, notificationID uuid.UUID) ([]entity.PushDelivery, error)
+	Requeue(ctx context.Context, id uuid.UUID) error
+}
+
+type NotificationRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (entity.Notification, error)
+}
@@ -0,0 +1,11 @@
+package delivery_service
+
+import "errors"
+
+var (
+	ErrNotificationNotFound  = errors.New("notification not found")
+	ErrDeliveryNotFound      = errors.New("push delivery not found")
+	ErrDeliveryNotDead       = errors.New("only dead-lettered deliveries can be retried")
+	ErrCannotFetchDeliveries = errors.New("cannot fetch push deliveries")
+	ErrCannotRequeueDelivery = errors.New("cannot requeue push delivery")
+)
@@ -0,0 +1,86 @@
+package delivery_service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	delivery_repository "github.com/4udiwe/coworking/notification-service/internal/repository/delivery"
+	notification_repository "github.com/4udiwe/coworking/notification-service/internal/repository/notification"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+DeliveryService — просмотр журнала push-доставок администратором.
+
+Сами попытки выполняют DefaultDispatcher и RetryWorker, здесь только
+чтение журнала и ручной повтор доставок, исчерпавших попытки.
+*/
+type DeliveryService struct {
+	deliveryRepo     DeliveryRepository
+	notificationRepo NotificationRepository
+}
+
+func New(deliveryRepo DeliveryRepository, notificationRepo NotificationRepository) *DeliveryService {
+	return &DeliveryService{
+		deliveryRepo:     deliveryRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// ListByNotification возвращает уведомление и все попытки его доставки по устройствам
+func (s *DeliveryService) ListByNotification(
+	ctx context.Context,
+	notificationID uuid.UUID,
+) (entity.Notification, []entity.PushDelivery, error) {
+	notification, err := s.notificationRepo.GetByID(ctx, notificationID)
+	if err != nil {
+		if errors.Is(err, notification_repository.ErrNotificationNotFound) {
+			return entity.Notification{}, nil, ErrNotificationNotFound
+		}
+		return entity.Notification{}, nil, ErrCannotFetchDeliveries
+	}
+
+	deliveries, err := s.deliveryRepo.ListByNotification(ctx, notificationID)
+	if err != nil {
+		return entity.Notification{}, nil, ErrCannotFetchDeliveries
+	}
+
+	return notification, deliveries, nil
+}
+
+// Retry возвращает доставку из dead в очередь; её подхватит RetryWorker
+func (s *DeliveryService) Retry(ctx context.Context, deliveryID uuid.UUID) (entity.PushDelivery, error) {
+	delivery, err := s.deliveryRepo.GetByID(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, delivery_repository.ErrDeliveryNotFound) {
+			return entity.PushDelivery{}, ErrDeliveryNotFound
+		}
+		return entity.PushDelivery{}, ErrCannotFetchDeliveries
+	}
+
+	if delivery.Status != entity.DeliveryDead {
+		return entity.PushDelivery{}, ErrDeliveryNotDead
+	}
+
+	if err := s.deliveryRepo.Requeue(ctx, deliveryID); err != nil {
+		// Статус сменился между чтением и обновлением
+		if errors.Is(err, delivery_repository.ErrDeliveryNotFound) {
+			return entity.PushDelivery{}, ErrDeliveryNotDead
+		}
+		return entity.PushDelivery{}, ErrCannotRequeueDelivery
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"delivery_id":     deliveryID,
+		"notification_id": delivery.NotificationID,
+	}).Info("dead-lettered push delivery requeued")
+
+	delivery, err = s.deliveryRepo.GetByID(ctx, deliveryID)
+	if err != nil {
+		return entity.PushDelivery{}, ErrCannotFetchDeliveries
+	}
+
+	return delivery, nil
+}
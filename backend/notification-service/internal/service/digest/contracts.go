@@ -0,0 +1,46 @@
+package digest_service
+
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	notification_builder "github.com/4udiwe/coworking/notification-service/internal/builder"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type DigestRepository interface {
+	UpsertBooking(ctx context.Context, booking entity.DigestBooking) error
+	DeleteBooking(ctx context.Context, bookingID uuid.UUID) error
+	DeleteStale(ctx context.Context, before time.Time) error
+	ListRecipients(
+		ctx context.Context,
+		at time.Time,
+		hour int,
+		weekday int,
+		afterUserID uuid.UUID,
+		limit int,
+	) ([]entity.DigestRecipient, error)
+	ListBookings(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]entity.DigestBooking, error)
+	CreateLog(
+		ctx context.Context,
+		userID uuid.UUID,
+		mode entity.DigestMode,
+		periodStart time.Time,
+		scheduledAt time.Time,
+	) (bool, error)
+	MarkDigested(ctx context.Context, bookingIDs []uuid.UUID) error
+	IsDigested(ctx context.Context, bookingID uuid.UUID) (bool, error)
+}
+
+// NotificationBuilder собирает текст дайджеста по шаблону (DefaultBuilder)
+type NotificationBuilder interface {
+	Build(ctx context.Context, event notification_builder.Event) (entity.Notification, error)
+}
+
+// NotificationCreator сохраняет уведомление вместе с событием notification.created
+type NotificationCreator interface {
+	CreateNotification(ctx context.Context, notification entity.Notification) error
+}
@@ -0,0 +1,8 @@
+package digest_service
+
+import "errors"
+
+var (
+	ErrCannotTrackBooking = errors.New("cannot track booking for digest")
+	ErrCannotSendDigest   = errors.New("cannot send digest")
+)
@@ -0,0 +1,197 @@
+package digest_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/transactor"
+	notification_builder "github.com/4udiwe/coworking/notification-service/internal/builder"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// Сколько хранятся закончившиеся бронирования и журнал дайджестов
+const staleRetention = 14 * 24 * time.Hour
+
+type Config struct {
+	// Час отправки в часовом поясе пользователя
+	Hour int
+	// День недели еженедельного дайджеста
+	Weekday time.Weekday
+	// Сколько получателей выбирается за раз
+	BatchSize int
+}
+
+/*
+DigestService — утренняя сводка предстоящих бронирований вместо отдельных напоминаний.
+
+Бронирования берутся из событий booking-service и хранятся в digest_booking.
+Раз в час scheduler-service присылает notifications.digest; пользователи, у
+которых по их часовому поясу наступил Config.Hour (для weekly — ещё и
+Config.Weekday), получают одно уведомление booking_digest с бронированиями
+на день или на 7 дней. Дальше оно проходит обычный путь notification.created.
+Напоминание о начале бронирования, вошедшего в дайджест, не отправляется.
+*/
+type DigestService struct {
+	digestRepo    DigestRepository
+	builder       NotificationBuilder
+	notifications NotificationCreator
+
+	txManager transactor.Transactor
+	cfg       Config
+}
+
+func New(
+	digestRepo DigestRepository,
+	builder NotificationBuilder,
+	notifications NotificationCreator,
+	txManager transactor.Transactor,
+	cfg Config,
+) *DigestService {
+	return &DigestService{
+		digestRepo:    digestRepo,
+		builder:       builder,
+		notifications: notifications,
+		txManager:     txManager,
+		cfg:           cfg,
+	}
+}
+
+// TrackBooking запоминает созданное бронирование для будущих дайджестов
+func (s *DigestService) TrackBooking(ctx context.Context, booking entity.DigestBooking) error {
+	if err := s.digestRepo.UpsertBooking(ctx, booking); err != nil {
+		return ErrCannotTrackBooking
+	}
+	return nil
+}
+
+// ForgetBooking убирает отменённое или завершённое бронирование
+func (s *DigestService) ForgetBooking(ctx context.Context, bookingID uuid.UUID) error {
+	if err := s.digestRepo.DeleteBooking(ctx, bookingID); err != nil {
+		return ErrCannotTrackBooking
+	}
+	return nil
+}
+
+// ReminderSuppressed — бронирование уже было в дайджесте, напоминание о начале не нужно
+func (s *DigestService) ReminderSuppressed(ctx context.Context, bookingID uuid.UUID) (bool, error) {
+	return s.digestRepo.IsDigested(ctx, bookingID)
+}
+
+/*
+SendDigests отправляет дайджесты за час, в который попадает scheduledAt —
+плановое время запуска задачи scheduler-service. Час и период дайджеста
+не зависят от того, когда событие дошло до сервиса.
+
+Повторный вызов за тот же час безопасен: дайджест периода фиксируется
+в digest_log в одной транзакции с уведомлением. Ошибка для одного
+пользователя не прерывает остальных, но возвращается, чтобы событие
+ушло на повтор.
+*/
+func (s *DigestService) SendDigests(ctx context.Context, scheduledAt time.Time) error {
+	at := scheduledAt.Truncate(time.Hour)
+
+	if err := s.digestRepo.DeleteStale(ctx, at.Add(-staleRetention)); err != nil {
+		return ErrCannotSendDigest
+	}
+
+	var (
+		after        uuid.UUID
+		sent, failed int
+	)
+
+	for {
+		recipients, err := s.digestRepo.ListRecipients(ctx, at, s.cfg.Hour, int(s.cfg.Weekday), after, s.cfg.BatchSize)
+		if err != nil {
+			return ErrCannotSendDigest
+		}
+
+		for _, recipient := range recipients {
+			ok, err := s.sendDigest(ctx, recipient, at)
+			if err != nil {
+				logrus.WithField("user_id", recipient.UserID.String()).
+					WithError(err).
+					Error("failed to send digest")
+				failed++
+				continue
+			}
+			if ok {
+				sent++
+			}
+		}
+
+		if len(recipients) < s.cfg.BatchSize {
+			break
+		}
+		after = recipients[len(recipients)-1].UserID
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"at":     at,
+		"sent":   sent,
+		"failed": failed,
+	}).Info("digests processed")
+
+	if failed > 0 {
+		return ErrCannotSendDigest
+	}
+	return nil
+}
+
+// sendDigest отправляет дайджест одному пользователю; false — отправлять нечего
+// или дайджест за этот период уже был
+func (s *DigestService) sendDigest(ctx context.Context, recipient entity.DigestRecipient, at time.Time) (bool, error) {
+	local := at.In(recipient.Location())
+	periodStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+
+	days := 1
+	if recipient.Mode == entity.DigestWeekly {
+		days = 7
+	}
+	periodEnd := periodStart.AddDate(0, 0, days)
+
+	bookings, err := s.digestRepo.ListBookings(ctx, recipient.UserID, periodStart, periodEnd)
+	if err != nil {
+		return false, err
+	}
+	if len(bookings) == 0 {
+		return false, nil
+	}
+
+	notification, err := s.builder.Build(ctx, notification_builder.Event{
+		Type:   entity.BookingDigestNotificationType,
+		UserID: recipient.UserID,
+		Payload: map[string]any{
+			"period":      recipient.Mode,
+			"periodStart": periodStart,
+			"bookings":    bookings,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var created bool
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.digestRepo.CreateLog(ctx, recipient.UserID, recipient.Mode, periodStart, at)
+		if err != nil || !created {
+			return err
+		}
+
+		bookingIDs := lo.Map(bookings, func(b entity.DigestBooking, _ int) uuid.UUID { return b.BookingID })
+		if err := s.digestRepo.MarkDigested(ctx, bookingIDs); err != nil {
+			return err
+		}
+
+		return s.notifications.CreateNotification(ctx, notification)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return created, nil
+}
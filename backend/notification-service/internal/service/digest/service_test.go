@@ -0,0 +1,261 @@
+package digest_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	notification_builder "github.com/4udiwe/coworking/notification-service/internal/builder"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/4udiwe/coworking/notification-service/internal/service/digest/mocks"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+type dummyTransactor struct{}
+
+func (d dummyTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestSendDigest(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatalf("failed to load timezone: %v", err)
+	}
+
+	// 01:00 по Москве 19 октября, но ещё 18 октября по UTC
+	at := time.Date(2026, 10, 18, 22, 0, 0, 0, time.UTC)
+	periodStart := time.Date(2026, 10, 19, 0, 0, 0, 0, moscow)
+
+	bookings := []entity.DigestBooking{
+		{BookingID: uuid.New(), UserID: userID, StartTime: periodStart.Add(9 * time.Hour)},
+		{BookingID: uuid.New(), UserID: userID, StartTime: periodStart.Add(14 * time.Hour)},
+	}
+	bookingIDs := []uuid.UUID{bookings[0].BookingID, bookings[1].BookingID}
+	notification := entity.Notification{ID: uuid.New(), UserID: userID, Type: entity.BookingDigestNotificationType}
+
+	type MockBehavior func(
+		digestRepo *mocks.MockDigestRepository,
+		builder *mocks.MockNotificationBuilder,
+		notifications *mocks.MockNotificationCreator,
+	)
+
+	// expectBuild проверяет, что шаблон получает режим, начало периода и бронирования
+	expectBuild := func(builder *mocks.MockNotificationBuilder, mode entity.DigestMode) {
+		builder.EXPECT().Build(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, event notification_builder.Event) (entity.Notification, error) {
+				if event.Type != entity.BookingDigestNotificationType || event.UserID != userID {
+					t.Errorf("unexpected event %s for %s", event.Type, event.UserID)
+				}
+				if event.Payload["period"] != mode {
+					t.Errorf("period = %v, want %v", event.Payload["period"], mode)
+				}
+				if start, _ := event.Payload["periodStart"].(time.Time); !start.Equal(periodStart) {
+					t.Errorf("periodStart = %v, want %s", event.Payload["periodStart"], periodStart)
+				}
+				if got, _ := event.Payload["bookings"].([]entity.DigestBooking); len(got) != len(bookings) {
+					t.Errorf("bookings = %v, want %d", event.Payload["bookings"], len(bookings))
+				}
+				return notification, nil
+			})
+	}
+
+	tests := []struct {
+		name         string
+		mode         entity.DigestMode
+		mockBehavior MockBehavior
+		wantSent     bool
+		wantErr      bool
+	}{
+		{
+			name: "daily",
+			mode: entity.DigestDaily,
+			mockBehavior: func(
+				digestRepo *mocks.MockDigestRepository,
+				builder *mocks.MockNotificationBuilder,
+				notifications *mocks.MockNotificationCreator,
+			) {
+				gomock.InOrder(
+					digestRepo.EXPECT().ListBookings(ctx, userID, periodStart, periodStart.AddDate(0, 0, 1)).Return(bookings, nil),
+					digestRepo.EXPECT().CreateLog(ctx, userID, entity.DigestDaily, periodStart, at).Return(true, nil),
+					digestRepo.EXPECT().MarkDigested(ctx, bookingIDs).Return(nil),
+					notifications.EXPECT().CreateNotification(ctx, notification).Return(nil),
+				)
+				expectBuild(builder, entity.DigestDaily)
+			},
+			wantSent: true,
+		},
+		{
+			name: "weekly_covers_seven_days",
+			mode: entity.DigestWeekly,
+			mockBehavior: func(
+				digestRepo *mocks.MockDigestRepository,
+				builder *mocks.MockNotificationBuilder,
+				notifications *mocks.MockNotificationCreator,
+			) {
+				gomock.InOrder(
+					digestRepo.EXPECT().ListBookings(ctx, userID, periodStart, periodStart.AddDate(0, 0, 7)).Return(bookings, nil),
+					digestRepo.EXPECT().CreateLog(ctx, userID, entity.DigestWeekly, periodStart, at).Return(true, nil),
+					digestRepo.EXPECT().MarkDigested(ctx, bookingIDs).Return(nil),
+					notifications.EXPECT().CreateNotification(ctx, notification).Return(nil),
+				)
+				expectBuild(builder, entity.DigestWeekly)
+			},
+			wantSent: true,
+		},
+		{
+			name: "no_bookings_skips_digest",
+			mode: entity.DigestDaily,
+			mockBehavior: func(
+				digestRepo *mocks.MockDigestRepository,
+				builder *mocks.MockNotificationBuilder,
+				notifications *mocks.MockNotificationCreator,
+			) {
+				digestRepo.EXPECT().ListBookings(ctx, userID, periodStart, periodStart.AddDate(0, 0, 1)).Return(nil, nil)
+			},
+		},
+		{
+			name: "already_digested",
+			mode: entity.DigestDaily,
+			mockBehavior: func(
+				digestRepo *mocks.MockDigestRepository,
+				builder *mocks.MockNotificationBuilder,
+				notifications *mocks.MockNotificationCreator,
+			) {
+				digestRepo.EXPECT().ListBookings(ctx, userID, periodStart, periodStart.AddDate(0, 0, 1)).Return(bookings, nil)
+				expectBuild(builder, entity.DigestDaily)
+				digestRepo.EXPECT().CreateLog(ctx, userID, entity.DigestDaily, periodStart, at).Return(false, nil)
+			},
+		},
+		{
+			name: "list_bookings_error",
+			mode: entity.DigestDaily,
+			mockBehavior: func(
+				digestRepo *mocks.MockDigestRepository,
+				builder *mocks.MockNotificationBuilder,
+				notifications *mocks.MockNotificationCreator,
+			) {
+				digestRepo.EXPECT().ListBookings(ctx, userID, periodStart, periodStart.AddDate(0, 0, 1)).
+					Return(nil, errors.New("db down"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "create_notification_error",
+			mode: entity.DigestDaily,
+			mockBehavior: func(
+				digestRepo *mocks.MockDigestRepository,
+				builder *mocks.MockNotificationBuilder,
+				notifications *mocks.MockNotificationCreator,
+			) {
+				digestRepo.EXPECT().ListBookings(ctx, userID, periodStart, periodStart.AddDate(0, 0, 1)).Return(bookings, nil)
+				expectBuild(builder, entity.DigestDaily)
+				digestRepo.EXPECT().CreateLog(ctx, userID, entity.DigestDaily, periodStart, at).Return(true, nil)
+				digestRepo.EXPECT().MarkDigested(ctx, bookingIDs).Return(nil)
+				notifications.EXPECT().CreateNotification(ctx, notification).Return(errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			digestRepo := mocks.NewMockDigestRepository(ctrl)
+			builder := mocks.NewMockNotificationBuilder(ctrl)
+			notifications := mocks.NewMockNotificationCreator(ctrl)
+
+			tt.mockBehavior(digestRepo, builder, notifications)
+
+			s := New(digestRepo, builder, notifications, dummyTransactor{}, Config{Hour: 1, BatchSize: 100})
+
+			recipient := entity.DigestRecipient{UserID: userID, Mode: tt.mode, Timezone: "Europe/Moscow"}
+
+			sent, err := s.sendDigest(ctx, recipient, at)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("sendDigest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if sent != tt.wantSent {
+				t.Errorf("sendDigest() = %v, want %v", sent, tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestReminderSuppressed(t *testing.T) {
+	ctx := context.Background()
+	bookingID := uuid.New()
+	repoErr := errors.New("db down")
+
+	tests := []struct {
+		name      string
+		digested  bool
+		repoErr   error
+		want      bool
+		wantError error
+	}{
+		{name: "digested", digested: true, want: true},
+		{name: "not_digested", digested: false, want: false},
+		{name: "repository_error", repoErr: repoErr, wantError: repoErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			digestRepo := mocks.NewMockDigestRepository(ctrl)
+
+			digestRepo.EXPECT().IsDigested(ctx, bookingID).Return(tt.digested, tt.repoErr)
+
+			s := New(digestRepo, nil, nil, dummyTransactor{}, Config{})
+
+			got, err := s.ReminderSuppressed(ctx, bookingID)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("ReminderSuppressed() error = %v, want %v", err, tt.wantError)
+			}
+			if got != tt.want {
+				t.Errorf("ReminderSuppressed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendDigests_UsesScheduledHour(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	// Плановый запуск 10:00 UTC, scheduler отработал с задержкой в полсекунды
+	scheduledAt := time.Date(2026, 10, 19, 10, 0, 0, 500_000_000, time.UTC)
+	at := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	periodStart := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	digestRepo := mocks.NewMockDigestRepository(ctrl)
+	builder := mocks.NewMockNotificationBuilder(ctrl)
+	notifications := mocks.NewMockNotificationCreator(ctrl)
+
+	bookings := []entity.DigestBooking{{BookingID: uuid.New(), UserID: userID, StartTime: at.Add(time.Hour)}}
+	notification := entity.Notification{ID: uuid.New(), UserID: userID, Type: entity.BookingDigestNotificationType}
+
+	gomock.InOrder(
+		digestRepo.EXPECT().DeleteStale(ctx, at.Add(-staleRetention)).Return(nil),
+		digestRepo.EXPECT().ListRecipients(ctx, at, 10, int(time.Monday), uuid.Nil, 100).
+			Return([]entity.DigestRecipient{{UserID: userID, Mode: entity.DigestDaily, Timezone: "UTC"}}, nil),
+		digestRepo.EXPECT().ListBookings(ctx, userID, periodStart, periodStart.AddDate(0, 0, 1)).Return(bookings, nil),
+		digestRepo.EXPECT().CreateLog(ctx, userID, entity.DigestDaily, periodStart, at).Return(true, nil),
+		digestRepo.EXPECT().MarkDigested(ctx, []uuid.UUID{bookings[0].BookingID}).Return(nil),
+		notifications.EXPECT().CreateNotification(ctx, notification).Return(nil),
+	)
+	builder.EXPECT().Build(ctx, gomock.Any()).Return(notification, nil)
+
+	s := New(digestRepo, builder, notifications, dummyTransactor{}, Config{Hour: 10, Weekday: time.Monday, BatchSize: 100})
+
+	if err := s.SendDigests(ctx, scheduledAt); err != nil {
+		t.Fatalf("SendDigests() error = %v", err)
+	}
+}
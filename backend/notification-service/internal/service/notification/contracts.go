@@ -1,5 +1,7 @@
 package notification_service
 
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
 import (
 	"context"
 	"time"
@@ -14,21 +16,44 @@ type NotificationRepository interface {
 	MarkAllRead(ctx context.Context, userID uuid.UUID) error
 	GetByID(ctx context.Context, id uuid.UUID) (entity.Notification, error)
 	FetchUnreadByUser(ctx context.Context, userID uuid.UUID, limit int) ([]entity.Notification, error)
-	FetchByUser(ctx context.Context, userID uuid.UUID, limit, offset int, isRead *bool) ([]entity.Notification, error)
-	FetchAfterDate(ctx context.Context, userID uuid.UUID, since time.Time, limit, offset int) ([]entity.Notification, error)
+	FetchByUser(ctx context.Context, userID uuid.UUID, filter entity.NotificationFilter) ([]entity.Notification, error)
 	GetUnreadCount(ctx context.Context, userID uuid.UUID) (int, error)
+	FetchAllByUser(ctx context.Context, userID uuid.UUID) ([]entity.Notification, error)
+	FetchArchivedByUser(ctx context.Context, userID uuid.UUID) ([]entity.Notification, error)
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+	MarkReadByIDs(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) ([]uuid.UUID, error)
+	DeleteByIDs(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int64, error)
+	DeleteReadBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	ArchiveUnreadBefore(ctx context.Context, before time.Time, limit int) (int64, []uuid.UUID, error)
 }
 
 type DeviceRepository interface {
 	Create(ctx context.Context, device entity.UserDevice) (uuid.UUID, error)
 	FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.UserDevice, error)
 	DeleteByToken(ctx context.Context, token string) error
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
+
+type ContactRepository interface {
+	Upsert(ctx context.Context, contact entity.UserContact) error
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
+
+type PreferencesRepository interface {
+	Get(ctx context.Context, userID uuid.UUID) (entity.NotificationPreferences, error)
+	Save(ctx context.Context, prefs entity.NotificationPreferences) error
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
 }
 
 type OutboxRepository interface {
 	Create(ctx context.Context, event entity.OutboxEvent) error
 }
 
+// StreamPublisher сообщает открытым потокам клиентов об изменениях
+type StreamPublisher interface {
+	Publish(ctx context.Context, event entity.StreamEvent) error
+}
+
 type PushService interface {
-	SendToUser(ctx context.Context, userID uuid.UUID, notification entity.Notification) error
+	SendToUser(ctx context.Context, userID uuid.UUID, notification entity.Notification, channels []entity.Channel) error
 }
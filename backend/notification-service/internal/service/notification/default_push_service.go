@@ -4,17 +4,21 @@ import (
 	"context"
 
 	"github.com/4udiwe/coworking/notification-service/internal/entity"
-	"github.com/4udiwe/coworking/notification-service/internal/sender"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
+// ChannelDispatcher sends a notification through the selected delivery channels
+type ChannelDispatcher interface {
+	DispatchChannels(ctx context.Context, notification entity.Notification, channels []entity.Channel) error
+}
+
 // DefaultPushService is the default implementation of PushService
 type DefaultPushService struct {
-	dispatcher sender.Dispatcher
+	dispatcher ChannelDispatcher
 }
 
-func NewDefaultPushService(dispatcher sender.Dispatcher) *DefaultPushService {
+func NewDefaultPushService(dispatcher ChannelDispatcher) *DefaultPushService {
 	return &DefaultPushService{
 		dispatcher: dispatcher,
 	}
@@ -24,13 +28,15 @@ func (s *DefaultPushService) SendToUser(
 	ctx context.Context,
 	userID uuid.UUID,
 	notification entity.Notification,
+	channels []entity.Channel,
 ) error {
 	logrus.WithFields(logrus.Fields{
 		"notification_id": notification.ID,
 		"user_id":         userID,
+		"channels":        channels,
 	}).Info("sending push notification to user")
 
-	err := s.dispatcher.Dispatch(ctx, notification)
+	err := s.dispatcher.DispatchChannels(ctx, notification, channels)
 	if err != nil {
 		logrus.WithError(err).Error("failed to dispatch notification")
 		return err
@@ -8,4 +8,12 @@ var (
 	ErrCannotMarkRead           = errors.New("cannot mark notification as read")
 	ErrNotificationNotFound     = errors.New("notification not found")
 	ErrCannotFetchNotification  = errors.New("cannot fetch notification")
+	ErrCannotExportUserData     = errors.New("cannot export user data")
+	ErrCannotDeleteUserData     = errors.New("cannot delete user data")
+	ErrCannotSaveContact        = errors.New("cannot save user contact")
+	ErrCannotFetchPreferences   = errors.New("cannot fetch notification preferences")
+	ErrCannotUpdatePreferences  = errors.New("cannot update notification preferences")
+	ErrInvalidPreferences       = errors.New("invalid notification preferences")
+	ErrCannotDeleteNotification = errors.New("cannot delete notifications")
+	ErrCannotApplyRetention     = errors.New("cannot apply notification retention")
 )
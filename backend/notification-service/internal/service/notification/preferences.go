@@ -0,0 +1,49 @@
+package notification_service
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+)
+
+const minutesPerDay = 24 * 60
+
+func validatePreferences(prefs entity.NotificationPreferences) error {
+	if _, err := time.LoadLocation(prefs.Timezone); err != nil || prefs.Timezone == "" {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidPreferences, prefs.Timezone)
+	}
+
+	if !slices.Contains(entity.Locales, prefs.Locale) {
+		return fmt.Errorf("%w: unsupported locale %q", ErrInvalidPreferences, prefs.Locale)
+	}
+
+	switch prefs.Digest {
+	case entity.DigestOff, entity.DigestDaily, entity.DigestWeekly:
+	default:
+		return fmt.Errorf("%w: unknown digest mode %q", ErrInvalidPreferences, prefs.Digest)
+	}
+
+	if q := prefs.QuietHours; q != nil {
+		if q.Start < 0 || q.Start >= minutesPerDay || q.End < 0 || q.End >= minutesPerDay {
+			return fmt.Errorf("%w: quiet hours out of range", ErrInvalidPreferences)
+		}
+		if q.Start == q.End {
+			return fmt.Errorf("%w: quiet hours start equals end", ErrInvalidPreferences)
+		}
+	}
+
+	for notificationType, channels := range prefs.Types {
+		if !slices.Contains(entity.NotificationTypes, notificationType) {
+			return fmt.Errorf("%w: unknown notification type %q", ErrInvalidPreferences, notificationType)
+		}
+		for channel := range channels {
+			if !slices.Contains(entity.Channels, channel) {
+				return fmt.Errorf("%w: unknown channel %q", ErrInvalidPreferences, channel)
+			}
+		}
+	}
+
+	return nil
+}
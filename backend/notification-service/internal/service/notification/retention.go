@@ -0,0 +1,98 @@
+package notification_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Сколько уведомлений удаляется или архивируется одной транзакцией,
+// чтобы не держать долгих блокировок на таблице notification
+const retentionBatchSize = 1000
+
+/*
+ApplyRetention — очистка по событию notifications.retention от scheduler-service.
+
+Прочитанные уведомления удаляются через readRetentionDays дней после прочтения,
+непрочитанные переносятся в notification_archive через unreadArchiveDays дней
+после создания. Ноль отключает соответствующий шаг.
+*/
+func (s *NotificationService) ApplyRetention(ctx context.Context, readRetentionDays, unreadArchiveDays int) error {
+	now := time.Now()
+
+	var deleted, archived int64
+
+	if readRetentionDays > 0 {
+		before := now.AddDate(0, 0, -readRetentionDays)
+		for {
+			n, err := s.notificationRepo.DeleteReadBefore(ctx, before, retentionBatchSize)
+			if err != nil {
+				return ErrCannotApplyRetention
+			}
+			deleted += n
+			if n < retentionBatchSize {
+				break
+			}
+		}
+	}
+
+	if unreadArchiveDays > 0 {
+		before := now.AddDate(0, 0, -unreadArchiveDays)
+		for {
+			n, err := s.archiveBatch(ctx, before)
+			if err != nil {
+				return ErrCannotApplyRetention
+			}
+			archived += n
+			if n < retentionBatchSize {
+				break
+			}
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"read_retention_days": readRetentionDays,
+		"unread_archive_days": unreadArchiveDays,
+		"deleted":             deleted,
+		"archived":            archived,
+	}).Info("notification retention applied")
+
+	return nil
+}
+
+// archiveBatch архивирует одну порцию и возвращает число перенесённых уведомлений
+func (s *NotificationService) archiveBatch(ctx context.Context, before time.Time) (int64, error) {
+	var archived int64
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var (
+			userIDs []uuid.UUID
+			err     error
+		)
+		archived, userIDs, err = s.notificationRepo.ArchiveUnreadBefore(ctx, before, retentionBatchSize)
+		if err != nil {
+			return err
+		}
+
+		// Архивные уведомления не входят в счётчик непрочитанных
+		for _, userID := range userIDs {
+			err := s.streamPublisher.Publish(ctx, entity.StreamEvent{
+				Type:   entity.StreamResync,
+				UserID: userID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logrus.WithError(err).Error("failed to archive unread notifications")
+		return 0, err
+	}
+
+	return archived, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/4udiwe/avito-pvz/pkg/transactor"
 	"github.com/4udiwe/coworking/notification-service/internal/entity"
 	notification_repository "github.com/4udiwe/coworking/notification-service/internal/repository/notification"
+	preferences_repository "github.com/4udiwe/coworking/notification-service/internal/repository/preferences"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
@@ -15,8 +16,11 @@ import (
 type NotificationService struct {
 	notificationRepo NotificationRepository
 	deviceRepo       DeviceRepository
+	contactRepo      ContactRepository
+	preferencesRepo  PreferencesRepository
 	outboxRepo       OutboxRepository
 	pushService      PushService
+	streamPublisher  StreamPublisher
 
 	txManager transactor.Transactor
 }
@@ -24,16 +28,22 @@ type NotificationService struct {
 func New(
 	notificationRepo NotificationRepository,
 	deviceRepo DeviceRepository,
+	contactRepo ContactRepository,
+	preferencesRepo PreferencesRepository,
 	outboxRepo OutboxRepository,
 	pushService PushService,
+	streamPublisher StreamPublisher,
 	txManager transactor.Transactor,
 ) *NotificationService {
 
 	return &NotificationService{
 		notificationRepo: notificationRepo,
 		deviceRepo:       deviceRepo,
+		contactRepo:      contactRepo,
+		preferencesRepo:  preferencesRepo,
 		outboxRepo:       outboxRepo,
 		pushService:      pushService,
+		streamPublisher:  streamPublisher,
 		txManager:        txManager,
 	}
 }
@@ -65,6 +75,34 @@ func (s *NotificationService) RegisterDevice(
 	return nil
 }
 
+// RegisterWebPushSubscription сохраняет подписку браузера как устройство
+// platform = web. Endpoint подписки служит токеном устройства, поэтому
+// повторная подписка того же браузера обновляет ключи.
+func (s *NotificationService) RegisterWebPushSubscription(
+	ctx context.Context,
+	userID uuid.UUID,
+	subscription entity.WebPushSubscription,
+) error {
+
+	logrus.WithField("user_id", userID.String()).Info("registering web push subscription")
+
+	device := entity.UserDevice{
+		UserID:       userID,
+		DeviceToken:  subscription.Endpoint,
+		Platform:     entity.PlatformWeb,
+		Subscription: &subscription,
+	}
+
+	_, err := s.deviceRepo.Create(ctx, device)
+	if err != nil {
+		return ErrCannotRegisterDevice
+	}
+
+	logrus.WithField("user_id", userID.String()).Info("web push subscription registered")
+
+	return nil
+}
+
 func (s *NotificationService) NotifyUser(ctx context.Context, notificationID uuid.UUID) error {
 	logrus.WithField("notification_id", notificationID).Info("notifying user")
 
@@ -77,7 +115,22 @@ func (s *NotificationService) NotifyUser(ctx context.Context, notificationID uui
 		return ErrCannotFetchNotification
 	}
 
-	err = s.pushService.SendToUser(ctx, notification.UserID, notification)
+	// In-app уведомление уже сохранено, настройки решают только, куда его отправить
+	prefs, err := s.GetPreferences(ctx, notification.UserID)
+	if err != nil {
+		return err
+	}
+
+	channels := prefs.Channels(notification.Type, time.Now())
+	if len(channels) == 0 {
+		logrus.WithFields(logrus.Fields{
+			"notification_id": notificationID,
+			"type":            notification.Type,
+		}).Info("delivery suppressed by user preferences")
+		return nil
+	}
+
+	err = s.pushService.SendToUser(ctx, notification.UserID, notification, channels)
 	if err != nil {
 		logrus.WithError(err).Error("failed to send push notification")
 		return err
@@ -130,7 +183,12 @@ func (s *NotificationService) CreateNotification(
 			return err
 		}
 
-		return nil
+		// Открытые потоки получат уведомление после commit
+		return s.streamPublisher.Publish(ctx, entity.StreamEvent{
+			Type:           entity.StreamNotificationCreated,
+			UserID:         notification.UserID,
+			NotificationID: &id,
+		})
 	})
 
 	if err != nil {
@@ -150,7 +208,25 @@ func (s *NotificationService) MarkRead(
 
 	logrus.WithField("notification_id", notificationID.String()).Info("marking notification as read")
 
-	err := s.notificationRepo.MarkRead(ctx, notificationID)
+	notification, err := s.notificationRepo.GetByID(ctx, notificationID)
+	if err != nil {
+		if errors.Is(err, notification_repository.ErrNotificationNotFound) {
+			return ErrNotificationNotFound
+		}
+		return ErrCannotMarkRead
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.notificationRepo.MarkRead(ctx, notificationID); err != nil {
+			return err
+		}
+
+		return s.streamPublisher.Publish(ctx, entity.StreamEvent{
+			Type:           entity.StreamNotificationRead,
+			UserID:         notification.UserID,
+			NotificationID: &notificationID,
+		})
+	})
 	if err != nil {
 
 		logrus.WithError(err).
@@ -180,33 +256,124 @@ func (s *NotificationService) FetchUnreadNotifications(ctx context.Context, user
 	return notifications, nil
 }
 
+// FetchNotifications возвращает страницу уведомлений и курсор следующей;
+// nil — страница последняя
 func (s *NotificationService) FetchNotifications(
 	ctx context.Context,
 	userID uuid.UUID,
-	limit, offset int,
-	isRead *bool,
-) ([]entity.Notification, error) {
+	filter entity.NotificationFilter,
+) ([]entity.Notification, *entity.NotificationCursor, error) {
 	logrus.WithFields(logrus.Fields{
 		"user_id": userID.String(),
-		"limit":   limit,
-		"offset":  offset,
-		"isRead":  isRead,
+		"limit":   filter.Limit,
+		"cursor":  filter.Cursor != nil,
+		"offset":  filter.Offset,
+		"isRead":  filter.IsRead,
+		"types":   filter.Types,
 	}).Info("fetching notifications for user")
 
-	notifications, err := s.notificationRepo.FetchByUser(ctx, userID, limit, offset, isRead)
+	// Лишняя запись показывает, есть ли следующая страница
+	limit := filter.Limit
+	filter.Limit++
+
+	notifications, err := s.notificationRepo.FetchByUser(ctx, userID, filter)
 	if err != nil {
 		logrus.WithError(err).Error("failed to fetch notifications")
-		return nil, ErrCannotFetchNotification
+		return nil, nil, ErrCannotFetchNotification
+	}
+
+	var next *entity.NotificationCursor
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+		last := notifications[limit-1]
+		next = &entity.NotificationCursor{CreatedAt: last.CreatedAt, ID: last.ID}
 	}
 
 	logrus.WithField("number", len(notifications)).Info("notifications fetched")
-	return notifications, nil
+	return notifications, next, nil
+}
+
+// MarkReadBatch отмечает прочитанными выбранные уведомления пользователя
+// и возвращает, сколько из них было непрочитанными
+func (s *NotificationService) MarkReadBatch(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int, error) {
+	logrus.WithFields(logrus.Fields{
+		"user_id": userID.String(),
+		"count":   len(ids),
+	}).Info("marking selected notifications as read")
+
+	var updated []uuid.UUID
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.notificationRepo.MarkReadByIDs(ctx, userID, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range updated {
+			err := s.streamPublisher.Publish(ctx, entity.StreamEvent{
+				Type:           entity.StreamNotificationRead,
+				UserID:         userID,
+				NotificationID: &id,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logrus.WithError(err).Error("failed to mark selected notifications as read")
+		return 0, ErrCannotMarkRead
+	}
+
+	return len(updated), nil
+}
+
+// DeleteNotifications удаляет выбранные уведомления пользователя и возвращает,
+// сколько удалено; чужие ID пропускаются
+func (s *NotificationService) DeleteNotifications(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int, error) {
+	logrus.WithFields(logrus.Fields{
+		"user_id": userID.String(),
+		"count":   len(ids),
+	}).Info("deleting selected notifications")
+
+	var deleted int64
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		deleted, err = s.notificationRepo.DeleteByIDs(ctx, userID, ids)
+		if err != nil || deleted == 0 {
+			return err
+		}
+
+		// Среди удалённых могли быть непрочитанные
+		return s.streamPublisher.Publish(ctx, entity.StreamEvent{
+			Type:   entity.StreamResync,
+			UserID: userID,
+		})
+	})
+	if err != nil {
+		logrus.WithError(err).Error("failed to delete notifications")
+		return 0, ErrCannotDeleteNotification
+	}
+
+	return int(deleted), nil
 }
 
 func (s *NotificationService) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
 	logrus.WithField("user_id", userID.String()).Info("marking all notifications as read")
 
-	err := s.notificationRepo.MarkAllRead(ctx, userID)
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.notificationRepo.MarkAllRead(ctx, userID); err != nil {
+			return err
+		}
+
+		return s.streamPublisher.Publish(ctx, entity.StreamEvent{
+			Type:   entity.StreamAllRead,
+			UserID: userID,
+		})
+	})
 	if err != nil {
 		logrus.WithError(err).Error("failed to mark all notifications as read")
 		return ErrCannotMarkRead
@@ -216,6 +383,24 @@ func (s *NotificationService) MarkAllRead(ctx context.Context, userID uuid.UUID)
 	return nil
 }
 
+// GetNotification возвращает уведомление пользователя; чужое считается ненайденным
+func (s *NotificationService) GetNotification(ctx context.Context, userID, notificationID uuid.UUID) (entity.Notification, error) {
+	notification, err := s.notificationRepo.GetByID(ctx, notificationID)
+	if err != nil {
+		if errors.Is(err, notification_repository.ErrNotificationNotFound) {
+			return entity.Notification{}, ErrNotificationNotFound
+		}
+		logrus.WithError(err).Error("failed to fetch notification")
+		return entity.Notification{}, ErrCannotFetchNotification
+	}
+
+	if notification.UserID != userID {
+		return entity.Notification{}, ErrNotificationNotFound
+	}
+
+	return notification, nil
+}
+
 func (s *NotificationService) GetUnreadCount(ctx context.Context, userID uuid.UUID) (int, error) {
 	logrus.WithField("user_id", userID.String()).Info("fetching unread notification count")
 
@@ -233,25 +418,133 @@ func (s *NotificationService) GetUnreadCount(ctx context.Context, userID uuid.UU
 	return count, nil
 }
 
-func (s *NotificationService) FetchNotificationsAfterDate(
+// UserData — данные пользователя в notification-service для выгрузки.
+type UserData struct {
+	Notifications         []entity.Notification
+	ArchivedNotifications []entity.Notification
+	Devices               []entity.UserDevice
+}
+
+// ExportUserData собирает уведомления и устройства пользователя
+// (GDPR export в auth-service).
+func (s *NotificationService) ExportUserData(ctx context.Context, userID uuid.UUID) (UserData, error) {
+	logrus.WithField("user_id", userID.String()).Info("exporting user data")
+
+	notifications, err := s.notificationRepo.FetchAllByUser(ctx, userID)
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch user notifications")
+		return UserData{}, ErrCannotExportUserData
+	}
+
+	archived, err := s.notificationRepo.FetchArchivedByUser(ctx, userID)
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch user archived notifications")
+		return UserData{}, ErrCannotExportUserData
+	}
+
+	devices, err := s.deviceRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch user devices")
+		return UserData{}, ErrCannotExportUserData
+	}
+
+	return UserData{
+		Notifications:         notifications,
+		ArchivedNotifications: archived,
+		Devices:               devices,
+	}, nil
+}
+
+// DeleteUserData удаляет уведомления, push-токены, email и настройки уведомлений
+// удалённого пользователя. В отличие от бронирований агрегировать здесь
+// нечего, поэтому данные не анонимизируются, а удаляются целиком.
+func (s *NotificationService) DeleteUserData(ctx context.Context, userID uuid.UUID) error {
+	logrus.WithField("user_id", userID.String()).Info("deleting user data")
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.notificationRepo.DeleteByUser(ctx, userID); err != nil {
+			return err
+		}
+		if err := s.contactRepo.DeleteByUser(ctx, userID); err != nil {
+			return err
+		}
+		if err := s.preferencesRepo.DeleteByUser(ctx, userID); err != nil {
+			return err
+		}
+		return s.deviceRepo.DeleteByUser(ctx, userID)
+	})
+
+	if err != nil {
+		logrus.WithError(err).Error("failed to delete user data")
+		return ErrCannotDeleteUserData
+	}
+
+	logrus.WithField("user_id", userID.String()).Info("user data deleted")
+	return nil
+}
+
+// SaveContact запоминает email пользователя из событий auth-service
+// (регистрация, изменение профиля, подтверждение нового адреса).
+func (s *NotificationService) SaveContact(ctx context.Context, contact entity.UserContact) error {
+	if contact.Email == "" {
+		return nil
+	}
+
+	if err := s.contactRepo.Upsert(ctx, contact); err != nil {
+		return ErrCannotSaveContact
+	}
+
+	logrus.WithField("user_id", contact.UserID.String()).Debug("user contact saved")
+	return nil
+}
+
+// GetPreferences возвращает настройки уведомлений; если пользователь
+// их не менял — все каналы включены, тихих часов и дайджеста нет.
+func (s *NotificationService) GetPreferences(ctx context.Context, userID uuid.UUID) (entity.NotificationPreferences, error) {
+	prefs, err := s.preferencesRepo.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, preferences_repository.ErrPreferencesNotFound) {
+			return entity.DefaultPreferences(userID), nil
+		}
+		return entity.NotificationPreferences{}, ErrCannotFetchPreferences
+	}
+
+	return prefs, nil
+}
+
+// UpdatePreferences заменяет настройки пользователя целиком
+func (s *NotificationService) UpdatePreferences(
 	ctx context.Context,
-	userID uuid.UUID,
-	since time.Time,
-	limit, offset int,
-) ([]entity.Notification, error) {
-	logrus.WithFields(logrus.Fields{
-		"user_id": userID.String(),
-		"since":   since,
-		"limit":   limit,
-		"offset":  offset,
-	}).Info("fetching notifications after date")
+	prefs entity.NotificationPreferences,
+) (entity.NotificationPreferences, error) {
+
+	if err := validatePreferences(prefs); err != nil {
+		logrus.WithField("user_id", prefs.UserID.String()).WithError(err).Warn("invalid notification preferences")
+		return entity.NotificationPreferences{}, err
+	}
+
+	var saved entity.NotificationPreferences
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.preferencesRepo.Save(ctx, prefs); err != nil {
+			return err
+		}
+
+		var err error
+		saved, err = s.preferencesRepo.Get(ctx, prefs.UserID)
+		return err
+	})
 
-	notifications, err := s.notificationRepo.FetchAfterDate(ctx, userID, since, limit, offset)
 	if err != nil {
-		logrus.WithError(err).Error("failed to fetch notifications after date")
-		return nil, ErrCannotFetchNotification
+		return entity.NotificationPreferences{}, ErrCannotUpdatePreferences
 	}
 
-	logrus.WithField("number", len(notifications)).Info("notifications after date fetched")
-	return notifications, nil
+	logrus.WithFields(logrus.Fields{
+		"user_id": prefs.UserID.String(),
+		"push":    saved.PushEnabled,
+		"email":   saved.EmailEnabled,
+		"digest":  saved.Digest,
+	}).Info("notification preferences updated")
+
+	return saved, nil
 }
@@ -0,0 +1,210 @@
+package notification_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	preferences_repository "github.com/4udiwe/coworking/notification-service/internal/repository/preferences"
+	composite_sender "github.com/4udiwe/coworking/notification-service/internal/sender/composite"
+	email_sender "github.com/4udiwe/coworking/notification-service/internal/sender/email"
+	email_mocks "github.com/4udiwe/coworking/notification-service/internal/sender/email/mocks"
+	"github.com/4udiwe/coworking/notification-service/internal/service/notification/mocks"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+type dummyTransactor struct{}
+
+func (d dummyTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// page возвращает n уведомлений по убыванию (created_at, id), как их отдаёт репозиторий
+func page(n int) []entity.Notification {
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	notifications := make([]entity.Notification, 0, n)
+	for i := range n {
+		notifications = append(notifications, entity.Notification{
+			ID:        uuid.New(),
+			CreatedAt: start.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+	return notifications
+}
+
+func TestFetchNotifications(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	cursor := &entity.NotificationCursor{CreatedAt: time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC), ID: uuid.New()}
+
+	tests := []struct {
+		name      string
+		filter    entity.NotificationFilter
+		fetched   []entity.Notification
+		fetchErr  error
+		wantLen   int
+		wantNext  bool
+		wantError error
+	}{
+		{
+			name:     "more_than_limit_returns_next_cursor",
+			filter:   entity.NotificationFilter{Limit: 3, Cursor: cursor},
+			fetched:  page(4),
+			wantLen:  3,
+			wantNext: true,
+		},
+		{
+			name:    "exactly_limit_is_last_page",
+			filter:  entity.NotificationFilter{Limit: 3},
+			fetched: page(3),
+			wantLen: 3,
+		},
+		{
+			name:    "short_page",
+			filter:  entity.NotificationFilter{Limit: 3, Cursor: cursor},
+			fetched: page(1),
+			wantLen: 1,
+		},
+		{
+			name:    "empty",
+			filter:  entity.NotificationFilter{Limit: 3},
+			wantLen: 0,
+		},
+		{
+			name:      "repository_error",
+			filter:    entity.NotificationFilter{Limit: 3},
+			fetchErr:  errors.New("db down"),
+			wantError: ErrCannotFetchNotification,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+
+			// Сервис запрашивает на одну запись больше, курсор передаётся как есть
+			wantFilter := tt.filter
+			wantFilter.Limit = tt.filter.Limit + 1
+			notificationRepo.EXPECT().FetchByUser(ctx, userID, wantFilter).Return(tt.fetched, tt.fetchErr)
+
+			s := New(notificationRepo, nil, nil, nil, nil, nil, nil, dummyTransactor{})
+
+			notifications, next, err := s.FetchNotifications(ctx, userID, tt.filter)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("FetchNotifications() error = %v, want %v", err, tt.wantError)
+			}
+			if err != nil {
+				return
+			}
+
+			if len(notifications) != tt.wantLen {
+				t.Fatalf("len(notifications) = %d, want %d", len(notifications), tt.wantLen)
+			}
+
+			if !tt.wantNext {
+				if next != nil {
+					t.Errorf("next = %+v, want nil", next)
+				}
+				return
+			}
+
+			// Курсор указывает на последнюю возвращённую запись, а не на лишнюю
+			last := notifications[len(notifications)-1]
+			if next == nil {
+				t.Fatal("next = nil, want cursor")
+			}
+			if next.ID != last.ID || !next.CreatedAt.Equal(last.CreatedAt) {
+				t.Errorf("next = %+v, want {%s %s}", *next, last.CreatedAt, last.ID)
+			}
+			if next.ID == tt.fetched[len(tt.fetched)-1].ID {
+				t.Error("next cursor points at the look-ahead record")
+			}
+		})
+	}
+}
+
+// emailLog — журнал писем в памяти, переживает повторную обработку события
+type emailLog map[uuid.UUID]string
+
+func (l emailLog) IsEmailSent(_ context.Context, notificationID uuid.UUID) (bool, error) {
+	_, ok := l[notificationID]
+	return ok, nil
+}
+
+func (l emailLog) RecordEmailSent(_ context.Context, notificationID uuid.UUID, email string) error {
+	l[notificationID] = email
+	return nil
+}
+
+// failingDispatcher — push-канал, который не может поставить доставку в очередь
+type failingDispatcher struct {
+	calls *int
+	err   error
+}
+
+func (f failingDispatcher) Dispatch(context.Context, entity.Notification) error {
+	*f.calls++
+	return f.err
+}
+
+func TestNotifyUserRedeliveryDoesNotResendEmail(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pushErr := errors.New("push delivery log unavailable")
+
+	notification := entity.Notification{
+		ID:      uuid.New(),
+		UserID:  userID,
+		Type:    entity.BookingReminderNotificationType,
+		Title:   "Скоро начало бронирования",
+		Body:    "Рабочее место A-12",
+		Payload: []byte(`{}`),
+	}
+
+	ctrl := gomock.NewController(t)
+	notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+	preferencesRepo := mocks.NewMockPreferencesRepository(ctrl)
+	contactRepo := email_mocks.NewMockContactRepository(ctrl)
+	emailSender := email_mocks.NewMockEmailSender(ctrl)
+
+	notificationRepo.EXPECT().GetByID(ctx, notification.ID).Return(notification, nil).Times(2)
+	preferencesRepo.EXPECT().Get(ctx, userID).
+		Return(entity.NotificationPreferences{}, preferences_repository.ErrPreferencesNotFound).Times(2)
+	contactRepo.EXPECT().GetByUserID(ctx, userID).
+		Return(entity.UserContact{UserID: userID, Email: "anna@example.com", FirstName: "Анна"}, nil)
+	emailSender.EXPECT().Send(ctx, gomock.Any()).Return(nil).Times(1)
+
+	renderer, err := email_sender.NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	var pushCalls int
+	dispatcher := composite_sender.NewDispatcher(
+		composite_sender.Channel{Name: entity.ChannelPush, Dispatcher: failingDispatcher{calls: &pushCalls, err: pushErr}},
+		composite_sender.Channel{
+			Name:       entity.ChannelEmail,
+			Dispatcher: email_sender.NewDispatcher(emailSender, contactRepo, emailLog{}, renderer, "coworking://app", time.UTC),
+		},
+	)
+
+	s := New(notificationRepo, nil, nil, preferencesRepo, nil, NewDefaultPushService(dispatcher), nil, dummyTransactor{})
+
+	// Первая обработка: письмо ушло, push упал — событие вернётся на повтор
+	if err := s.NotifyUser(ctx, notification.ID); !errors.Is(err, pushErr) {
+		t.Fatalf("first NotifyUser() error = %v, want %v", err, pushErr)
+	}
+	// Повтор: push пробуется снова, письмо второй раз не отправляется
+	if err := s.NotifyUser(ctx, notification.ID); !errors.Is(err, pushErr) {
+		t.Fatalf("second NotifyUser() error = %v, want %v", err, pushErr)
+	}
+
+	if pushCalls != 2 {
+		t.Errorf("push dispatched %d times, want 2", pushCalls)
+	}
+}
@@ -0,0 +1,18 @@
+package template_service
+
+import (
+	"context"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+)
+
+type TemplateRepository interface {
+	Upsert(ctx context.Context, template entity.NotificationTemplate) (entity.NotificationTemplate, error)
+	Delete(ctx context.Context, notificationType entity.NotificationType, locale entity.Locale) error
+}
+
+type TemplateRegistry interface {
+	Templates(ctx context.Context) ([]entity.NotificationTemplate, error)
+	Validate(template entity.NotificationTemplate) error
+	Invalidate()
+}
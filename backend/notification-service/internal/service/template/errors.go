@@ -0,0 +1,11 @@
+package template_service
+
+import "errors"
+
+var (
+	ErrTemplateNotFound     = errors.New("notification template override not found")
+	ErrUnknownTemplate      = errors.New("unknown notification type or locale")
+	ErrInvalidTemplate      = errors.New("invalid notification template")
+	ErrCannotFetchTemplates = errors.New("cannot fetch notification templates")
+	ErrCannotUpdateTemplate = errors.New("cannot update notification template")
+)
@@ -0,0 +1,96 @@
+package template_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	template_repository "github.com/4udiwe/coworking/notification-service/internal/repository/template"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+TemplateService — управление текстами уведомлений администратором.
+
+Переопределение сохраняется в БД и сразу действует на этой реплике,
+остальные подхватывают его при следующем обновлении реестра шаблонов.
+Уже созданные уведомления не меняются.
+*/
+type TemplateService struct {
+	templateRepo TemplateRepository
+	registry     TemplateRegistry
+}
+
+func New(templateRepo TemplateRepository, registry TemplateRegistry) *TemplateService {
+	return &TemplateService{
+		templateRepo: templateRepo,
+		registry:     registry,
+	}
+}
+
+// List возвращает действующие тексты для всех типов и языков
+func (s *TemplateService) List(ctx context.Context) ([]entity.NotificationTemplate, error) {
+	templates, err := s.registry.Templates(ctx)
+	if err != nil {
+		return nil, ErrCannotFetchTemplates
+	}
+	return templates, nil
+}
+
+// Update переопределяет текст для типа и языка
+func (s *TemplateService) Update(ctx context.Context, template entity.NotificationTemplate) (entity.NotificationTemplate, error) {
+	if !known(template.Type, template.Locale) {
+		return entity.NotificationTemplate{}, ErrUnknownTemplate
+	}
+
+	if err := s.registry.Validate(template); err != nil {
+		return entity.NotificationTemplate{}, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	saved, err := s.templateRepo.Upsert(ctx, template)
+	if err != nil {
+		return entity.NotificationTemplate{}, ErrCannotUpdateTemplate
+	}
+
+	s.registry.Invalidate()
+
+	logrus.WithFields(logrus.Fields{
+		"type":       template.Type,
+		"locale":     template.Locale,
+		"updated_by": template.UpdatedBy,
+	}).Info("notification template overridden")
+
+	return saved, nil
+}
+
+// Reset удаляет переопределение, возвращая встроенный текст
+func (s *TemplateService) Reset(ctx context.Context, notificationType entity.NotificationType, locale entity.Locale) error {
+	if !known(notificationType, locale) {
+		return ErrUnknownTemplate
+	}
+
+	if err := s.templateRepo.Delete(ctx, notificationType, locale); err != nil {
+		if errors.Is(err, template_repository.ErrTemplateNotFound) {
+			return ErrTemplateNotFound
+		}
+		return ErrCannotUpdateTemplate
+	}
+
+	s.registry.Invalidate()
+
+	logrus.WithFields(logrus.Fields{
+		"type":   notificationType,
+		"locale": locale,
+	}).Info("notification template reset to default")
+
+	return nil
+}
+
+// Текст объявления задаёт администратор при создании рассылки, шаблона у него нет
+func known(notificationType entity.NotificationType, locale entity.Locale) bool {
+	return slices.Contains(entity.NotificationTypes, notificationType) &&
+		notificationType != entity.AnnouncementNotificationType &&
+		slices.Contains(entity.Locales, locale)
+}
@@ -0,0 +1,40 @@
+package stream
+
+import (
+	"encoding/json"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+// Канал Postgres LISTEN/NOTIFY, через который реплики обмениваются событиями
+const pgChannel = "notification_stream"
+
+// rawEvent — payload NOTIFY. Ограничение Postgres — 8000 байт,
+// поэтому передаётся только идентификатор уведомления.
+type rawEvent struct {
+	Type           string     `json:"type"`
+	UserID         uuid.UUID  `json:"userId"`
+	NotificationID *uuid.UUID `json:"notificationId,omitempty"`
+}
+
+func encodeEvent(event entity.StreamEvent) ([]byte, error) {
+	return json.Marshal(rawEvent{
+		Type:           string(event.Type),
+		UserID:         event.UserID,
+		NotificationID: event.NotificationID,
+	})
+}
+
+func decodeEvent(payload string) (entity.StreamEvent, error) {
+	var raw rawEvent
+	if err := json.Unmarshal([]byte(payload), &raw); err != nil {
+		return entity.StreamEvent{}, err
+	}
+
+	return entity.StreamEvent{
+		Type:           entity.StreamEventType(raw.Type),
+		UserID:         raw.UserID,
+		NotificationID: raw.NotificationID,
+	}, nil
+}
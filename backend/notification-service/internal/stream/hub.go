@@ -0,0 +1,122 @@
+package stream
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var ErrTooManyConnections = errors.New("too many stream connections")
+
+// Сколько событий копится для медленного клиента, прежде чем новые начнут теряться.
+// Потеря некритична: каждое событие заканчивается актуальным счётчиком непрочитанных.
+const subscriptionBuffer = 16
+
+/*
+Hub раздаёт события открытым потокам этой реплики.
+
+У пользователя может быть несколько потоков (телефон, вкладки браузера):
+событие получает каждый из них.
+*/
+type Hub struct {
+	maxPerUser int
+
+	mu   sync.RWMutex
+	subs map[uuid.UUID]map[*Subscription]struct{}
+}
+
+func NewHub(maxPerUser int) *Hub {
+	return &Hub{
+		maxPerUser: maxPerUser,
+		subs:       make(map[uuid.UUID]map[*Subscription]struct{}),
+	}
+}
+
+type Subscription struct {
+	hub    *Hub
+	userID uuid.UUID
+	events chan entity.StreamEvent
+	once   sync.Once
+}
+
+// Events закрывается после Close
+func (s *Subscription) Events() <-chan entity.StreamEvent {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.hub.remove(s)
+		close(s.events)
+	})
+}
+
+func (h *Hub) Subscribe(userID uuid.UUID) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.maxPerUser > 0 && len(h.subs[userID]) >= h.maxPerUser {
+		return nil, ErrTooManyConnections
+	}
+
+	sub := &Subscription{
+		hub:    h,
+		userID: userID,
+		events: make(chan entity.StreamEvent, subscriptionBuffer),
+	}
+
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[*Subscription]struct{})
+	}
+	h.subs[userID][sub] = struct{}{}
+
+	return sub, nil
+}
+
+// Publish отправляет событие всем потокам пользователя
+func (h *Hub) Publish(event entity.StreamEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subs[event.UserID] {
+		h.deliver(sub, event)
+	}
+}
+
+// Resync просит все потоки перечитать состояние
+func (h *Hub) Resync() {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for userID, subs := range h.subs {
+		for sub := range subs {
+			h.deliver(sub, entity.StreamEvent{Type: entity.StreamResync, UserID: userID})
+		}
+	}
+}
+
+// deliver вызывается под RLock: Close удаляет подписку под Lock
+// до закрытия канала, поэтому запись в закрытый канал невозможна
+func (h *Hub) deliver(sub *Subscription, event entity.StreamEvent) {
+	select {
+	case sub.events <- event:
+	default:
+		logrus.WithFields(logrus.Fields{
+			"user_id": event.UserID.String(),
+			"type":    event.Type,
+		}).Warn("stream subscriber is slow, event dropped")
+	}
+}
+
+func (h *Hub) remove(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.subs[sub.userID], sub)
+	if len(h.subs[sub.userID]) == 0 {
+		delete(h.subs, sub.userID)
+	}
+}
@@ -0,0 +1,190 @@
+package stream
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+// receive возвращает события, уже лежащие в канале подписки
+func receive(sub *Subscription) []entity.StreamEvent {
+	var events []entity.StreamEvent
+	for {
+		select {
+		case ev, ok := <-sub.Events():
+			if !ok {
+				return events
+			}
+			events = append(events, ev)
+		default:
+			return events
+		}
+	}
+}
+
+func subscribers(h *Hub, userID uuid.UUID) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subs[userID])
+}
+
+func TestHub_SubscribeAndClose(t *testing.T) {
+	h := NewHub(2)
+	userID := uuid.New()
+
+	first, err := h.Subscribe(userID)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	second, err := h.Subscribe(userID)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if n := subscribers(h, userID); n != 2 {
+		t.Fatalf("subscribers = %d, want 2", n)
+	}
+
+	// Лимит на пользователя
+	if _, err := h.Subscribe(userID); !errors.Is(err, ErrTooManyConnections) {
+		t.Fatalf("Subscribe() over limit error = %v, want %v", err, ErrTooManyConnections)
+	}
+	// Другого пользователя лимит не касается
+	if _, err := h.Subscribe(uuid.New()); err != nil {
+		t.Fatalf("Subscribe() other user error = %v", err)
+	}
+
+	first.Close()
+	if n := subscribers(h, userID); n != 1 {
+		t.Errorf("subscribers after Close = %d, want 1", n)
+	}
+	if _, ok := <-first.Events(); ok {
+		t.Error("events channel is not closed after Close")
+	}
+
+	// Освободившееся место можно занять снова
+	if _, err := h.Subscribe(userID); err != nil {
+		t.Errorf("Subscribe() after Close error = %v", err)
+	}
+
+	second.Close()
+	second.Close() // повторный Close безопасен
+
+	// Закрытая подписка не получает событий и не паникует на записи
+	h.Publish(entity.StreamEvent{Type: entity.StreamAllRead, UserID: userID})
+}
+
+func TestHub_RemovesEmptyUser(t *testing.T) {
+	h := NewHub(0)
+	userID := uuid.New()
+
+	sub, err := h.Subscribe(userID)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	sub.Close()
+
+	h.mu.RLock()
+	_, ok := h.subs[userID]
+	h.mu.RUnlock()
+	if ok {
+		t.Error("user without subscriptions is kept in the hub")
+	}
+}
+
+func TestHub_PublishToMatchingUser(t *testing.T) {
+	h := NewHub(0)
+	alice, bob := uuid.New(), uuid.New()
+
+	phone, _ := h.Subscribe(alice)
+	browser, _ := h.Subscribe(alice)
+	other, _ := h.Subscribe(bob)
+
+	notificationID := uuid.New()
+	h.Publish(entity.StreamEvent{Type: entity.StreamNotificationCreated, UserID: alice, NotificationID: &notificationID})
+
+	// Событие получает каждый поток пользователя
+	for name, sub := range map[string]*Subscription{"phone": phone, "browser": browser} {
+		events := receive(sub)
+		if len(events) != 1 || events[0].UserID != alice || *events[0].NotificationID != notificationID {
+			t.Errorf("%s events = %+v, want one created event", name, events)
+		}
+	}
+
+	// И только он
+	if events := receive(other); len(events) != 0 {
+		t.Errorf("other user received %+v", events)
+	}
+
+	// Событие пользователя без потоков никуда не уходит
+	h.Publish(entity.StreamEvent{Type: entity.StreamAllRead, UserID: uuid.New()})
+	for _, sub := range []*Subscription{phone, browser, other} {
+		if events := receive(sub); len(events) != 0 {
+			t.Errorf("unexpected events %+v", events)
+		}
+	}
+}
+
+func TestHub_Resync(t *testing.T) {
+	h := NewHub(0)
+	alice, bob := uuid.New(), uuid.New()
+
+	a, _ := h.Subscribe(alice)
+	b, _ := h.Subscribe(bob)
+
+	h.Resync()
+
+	for userID, sub := range map[uuid.UUID]*Subscription{alice: a, bob: b} {
+		events := receive(sub)
+		if len(events) != 1 || events[0].Type != entity.StreamResync || events[0].UserID != userID {
+			t.Errorf("events = %+v, want resync for %s", events, userID)
+		}
+	}
+}
+
+func TestHub_SlowSubscriberDoesNotBlock(t *testing.T) {
+	h := NewHub(0)
+	userID := uuid.New()
+
+	sub, _ := h.Subscribe(userID)
+
+	// Буфер переполнен: лишние события отбрасываются, Publish не блокируется
+	for range subscriptionBuffer + 5 {
+		h.Publish(entity.StreamEvent{Type: entity.StreamAllRead, UserID: userID})
+	}
+
+	if events := receive(sub); len(events) != subscriptionBuffer {
+		t.Errorf("received %d events, want %d", len(events), subscriptionBuffer)
+	}
+}
+
+func TestEvent_RoundTrip(t *testing.T) {
+	notificationID := uuid.New()
+
+	tests := []entity.StreamEvent{
+		{Type: entity.StreamNotificationCreated, UserID: uuid.New(), NotificationID: &notificationID},
+		{Type: entity.StreamAllRead, UserID: uuid.New()},
+	}
+
+	for _, want := range tests {
+		payload, err := encodeEvent(want)
+		if err != nil {
+			t.Fatalf("encodeEvent() error = %v", err)
+		}
+
+		got, err := decodeEvent(string(payload))
+		if err != nil {
+			t.Fatalf("decodeEvent(%s) error = %v", payload, err)
+		}
+		if got.Type != want.Type || got.UserID != want.UserID ||
+			(got.NotificationID == nil) != (want.NotificationID == nil) ||
+			(got.NotificationID != nil && *got.NotificationID != *want.NotificationID) {
+			t.Errorf("decodeEvent() = %+v, want %+v", got, want)
+		}
+	}
+
+	if _, err := decodeEvent("not json"); err == nil {
+		t.Error("decodeEvent() accepted invalid payload")
+	}
+}
@@ -0,0 +1,91 @@
+package stream
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// Listener держит отдельное соединение с LISTEN и передаёт события в Hub.
+// Каждая реплика слушает сама, так что событие с любой реплики доходит
+// до всех открытых потоков пользователя.
+type Listener struct {
+	pool *pgxpool.Pool
+	hub  *Hub
+}
+
+func NewListener(pool *pgxpool.Pool, hub *Hub) *Listener {
+	return &Listener{
+		pool: pool,
+		hub:  hub,
+	}
+}
+
+func (l *Listener) Run(ctx context.Context) {
+	logrus.Infof("StreamListener: listening to channel=%s", pgChannel)
+
+	go func() {
+		delay := minReconnectDelay
+		connected := false
+
+		for ctx.Err() == nil {
+			err := l.listen(ctx, func() {
+				// После переподключения часть событий могла потеряться
+				if connected {
+					l.hub.Resync()
+				}
+				connected = true
+				delay = minReconnectDelay
+			})
+			if ctx.Err() != nil {
+				return
+			}
+
+			logrus.WithError(err).Errorf("StreamListener: connection lost, reconnecting in %s", delay)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, maxReconnectDelay)
+		}
+	}()
+}
+
+func (l *Listener) listen(ctx context.Context, onListen func()) error {
+	pooled, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// Соединение с LISTEN не возвращается в пул: забираем его насовсем
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgChannel); err != nil {
+		return err
+	}
+	onListen()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		event, err := decodeEvent(notification.Payload)
+		if err != nil {
+			logrus.WithError(err).Error("StreamListener: invalid event payload")
+			continue
+		}
+
+		l.hub.Publish(event)
+	}
+}
@@ -0,0 +1,40 @@
+package stream
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/coworking/notification-service/internal/entity"
+	"github.com/sirupsen/logrus"
+)
+
+// Publisher рассылает событие всем репликам через pg_notify.
+// Внутри транзакции Postgres доставляет NOTIFY только после commit,
+// поэтому клиенты не увидят уведомление, которое откатилось.
+type Publisher struct {
+	*postgres.Postgres
+}
+
+func NewPublisher(pg *postgres.Postgres) *Publisher {
+	return &Publisher{
+		Postgres: pg,
+	}
+}
+
+func (p *Publisher) Publish(ctx context.Context, event entity.StreamEvent) error {
+	payload, err := encodeEvent(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.GetTxManager(ctx).Exec(ctx, "SELECT pg_notify($1, $2)", pgChannel, string(payload))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id": event.UserID.String(),
+			"type":    event.Type,
+		}).WithError(err).Error("failed to publish stream event")
+		return err
+	}
+
+	return nil
+}
@@ -9,14 +9,16 @@ import (
 
 type (
 	Config struct {
-		App                   App        `yaml:"app"`
-		Postgres              Postgres   `yaml:"postgres"`
-		Log                   Log        `yaml:"logger"`
-		Kafka                 Kafka      `yaml:"kafka"`
-		Outbox                Outbox     `yaml:"outbox"`
-		Worker                Worker     `yaml:"worker"`
-		Scheduler             Scheduler  `yaml:"scheduler"`
-		SessionCleanupWorker  SessionCleanupWorker `yaml:"session_cleanup_worker"`
+		App       App       `yaml:"app"`
+		HTTP      HTTP      `yaml:"http"`
+		Postgres  Postgres  `yaml:"postgres"`
+		Log       Log       `yaml:"logger"`
+		Kafka     Kafka     `yaml:"kafka"`
+		Outbox    Outbox    `yaml:"outbox"`
+		Worker    Worker    `yaml:"worker"`
+		Scheduler Scheduler `yaml:"scheduler"`
+		Cron      Cron      `yaml:"cron"`
+		Auth      Auth      `yaml:"auth"`
 	}
 
 	App struct {
@@ -24,6 +26,14 @@ type (
 		Version string `env-required:"true" yaml:"version" env:"APP_VERSION"`
 	}
 
+	HTTP struct {
+		Port string `env-required:"true" yaml:"port" env:"SERVER_PORT"`
+	}
+
+	Auth struct {
+		PublicKeyPath string `env-required:"true" yaml:"public_key_path" env:"AUTH_PUBLIC_KEY_PATH"`
+	}
+
 	Log struct {
 		Level string `env-required:"true" yaml:"level" env:"LOG_LEVEL"`
 	}
@@ -37,6 +47,8 @@ type (
 		Brokers []string `env-required:"true" yaml:"brokers" env:"KAFKA_BROKERS"`
 		Topics  struct {
 			SchedulerEvents string `env-required:"true" yaml:"booking_events" env:"KAFKA_BOOKING_EVENTS"`
+			AuthEvents      string `yaml:"auth_events" env:"KAFKA_AUTH_EVENTS" env-default:"auth.events"`
+			TimerRequests   string `yaml:"timer_requests" env:"KAFKA_TIMER_REQUESTS" env-default:"scheduler.timers"`
 		} `env-required:"true" yaml:"topics" env:"KAFKA_TOPICS"`
 		Producer KafkaProducer `yaml:"producer"`
 		Consumer KafkaConsumer `yaml:"consumer"`
@@ -55,6 +67,13 @@ type (
 		SessionTimeout    time.Duration `yaml:"session_timeout" env:"KAFKA_CONSUMER_SESSION_TIMEOUT"`
 		HeartbeatInterval time.Duration `yaml:"heartbeat_interval" env:"KAFKA_CONSUMER_HEARTBEAT_INTERVAL"`
 		CommitInterval    time.Duration `yaml:"commit_interval" env:"KAFKA_CONSUMER_COMMIT_INTERVAL"`
+
+		// Повторы через <topic>.<group>.retry, после исчерпания — <topic>.<group>.dlq
+		RetryMaxAttempts int           `yaml:"retry_max_attempts" env:"KAFKA_CONSUMER_RETRY_MAX_ATTEMPTS" env-default:"5"`
+		RetryBackoff     time.Duration `yaml:"retry_backoff" env:"KAFKA_CONSUMER_RETRY_BACKOFF" env-default:"5s"`
+		RetryMaxBackoff  time.Duration `yaml:"retry_max_backoff" env:"KAFKA_CONSUMER_RETRY_MAX_BACKOFF" env-default:"5m"`
+		// Сколько хранить отметки об обработанных событиях
+		InboxRetention time.Duration `yaml:"inbox_retention" env:"KAFKA_CONSUMER_INBOX_RETENTION" env-default:"168h"`
 	}
 
 	Outbox struct {
@@ -71,11 +90,24 @@ type (
 	}
 	Scheduler struct {
 		RemindBefore time.Duration `env-required:"true" yaml:"remind_before" env:"REMIND_BEFORE"`
+		// Топики, в которые можно запланировать событие через scheduler.timer.requested.
+		// Для каждого запускается outbox-воркер; пустой список запрещает универсальные таймеры.
+		AllowedTimerTopics []string `yaml:"allowed_timer_topics" env:"ALLOWED_TIMER_TOPICS" env-separator:","`
+	}
+	Cron struct {
+		Interval   time.Duration `yaml:"interval" env:"CRON_INTERVAL" env-default:"10s"`
+		BatchLimit int           `yaml:"batch_limit" env:"CRON_BATCH_LIMIT" env-default:"10"`
+		Jobs       []CronJob     `yaml:"jobs"`
 	}
-	SessionCleanupWorker struct {
-		Interval      time.Duration `env-required:"true" yaml:"interval" env:"SESSION_CLEANUP_INTERVAL"`
-		RetentionDays int           `env-required:"true" yaml:"retention_days" env:"SESSION_CLEANUP_RETENTION_DAYS"`
-		Topic         string        `env-required:"true" yaml:"topic" env:"SESSION_CLEANUP_TOPIC"`
+	// CronJob — определение периодической задачи. Payload — text/template,
+	// результат которого должен быть валидным JSON.
+	CronJob struct {
+		Name      string `yaml:"name"`
+		Schedule  string `yaml:"schedule"`
+		Topic     string `yaml:"topic"`
+		EventType string `yaml:"event_type"`
+		Payload   string `yaml:"payload"`
+		Enabled   bool   `yaml:"enabled"`
 	}
 )
 
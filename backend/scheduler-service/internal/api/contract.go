@@ -0,0 +1,7 @@
+package api
+
+import "github.com/labstack/echo/v4"
+
+type Handler interface {
+	Handle(c echo.Context) error
+}
@@ -0,0 +1,138 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type JobByIDRequest struct {
+	JobID uuid.UUID `param:"jobId" validate:"required"`
+}
+
+type CronJob struct {
+	ID              uuid.UUID  `json:"id"`
+	Name            string     `json:"name"`
+	Schedule        string     `json:"schedule"`
+	Topic           string     `json:"topic"`
+	EventType       string     `json:"eventType"`
+	PayloadTemplate string     `json:"payloadTemplate"`
+	Enabled         bool       `json:"enabled"`
+	NextRunAt       time.Time  `json:"nextRunAt"`
+	LastRunAt       *time.Time `json:"lastRunAt,omitempty"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+type CronJobRun struct {
+	ID          uuid.UUID `json:"id"`
+	JobID       uuid.UUID `json:"jobId"`
+	Trigger     string    `json:"trigger"`
+	Status      string    `json:"status"`
+	Error       *string   `json:"error,omitempty"`
+	ScheduledAt time.Time `json:"scheduledAt"`
+	StartedAt   time.Time `json:"startedAt"`
+	FinishedAt  time.Time `json:"finishedAt"`
+}
+
+func CronJobFromEntity(job entity.CronJob) CronJob {
+	return CronJob{
+		ID:              job.ID,
+		Name:            job.Name,
+		Schedule:        job.Schedule,
+		Topic:           job.Topic,
+		EventType:       job.EventType,
+		PayloadTemplate: job.PayloadTemplate,
+		Enabled:         job.Enabled,
+		NextRunAt:       job.NextRunAt,
+		LastRunAt:       job.LastRunAt,
+		UpdatedAt:       job.UpdatedAt,
+	}
+}
+
+func CronJobRunFromEntity(run entity.CronJobRun) CronJobRun {
+	return CronJobRun{
+		ID:          run.ID,
+		JobID:       run.JobID,
+		Trigger:     string(run.Trigger),
+		Status:      string(run.Status),
+		Error:       run.Error,
+		ScheduledAt: run.ScheduledAt,
+		StartedAt:   run.StartedAt,
+		FinishedAt:  run.FinishedAt,
+	}
+}
+
+// Смещения в минутах: за сколько до начала и до конца бронирования напоминать
+type ReminderPreferences struct {
+	StartOffsets []int `json:"startOffsets"`
+	EndOffset    *int  `json:"endOffset"`
+}
+
+func ReminderPreferencesFromEntity(prefs entity.ReminderPreferences) ReminderPreferences {
+	return ReminderPreferences{
+		StartOffsets: prefs.StartOffsets,
+		EndOffset:    prefs.EndOffset,
+	}
+}
+
+type TimerByIDRequest struct {
+	TimerID uuid.UUID `param:"timerId" validate:"required"`
+}
+
+type Timer struct {
+	ID             uuid.UUID  `json:"id"`
+	Type           string     `json:"type"`
+	Status         string     `json:"status"`
+	BookingID      *uuid.UUID `json:"bookingId,omitempty"`
+	UserID         *uuid.UUID `json:"userId,omitempty"`
+	OffsetMinutes  *int       `json:"offsetMinutes,omitempty"`
+	CorrelationKey *string    `json:"correlationKey,omitempty"`
+	TargetTopic    *string    `json:"targetTopic,omitempty"`
+	EventType      *string    `json:"eventType,omitempty"`
+	TriggerAt      time.Time  `json:"triggerAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	TriggeredAt    *time.Time `json:"triggeredAt,omitempty"`
+	CancelledAt    *time.Time `json:"cancelledAt,omitempty"`
+}
+
+type OutboxEvent struct {
+	ID          uuid.UUID      `json:"id"`
+	EventType   string         `json:"eventType"`
+	Payload     map[string]any `json:"payload"`
+	Topic       *string        `json:"topic,omitempty"`
+	Status      string         `json:"status"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	ProcessedAt *time.Time     `json:"processedAt,omitempty"`
+}
+
+func TimerFromEntity(timer entity.Timer) Timer {
+	return Timer{
+		ID:             timer.ID,
+		Type:           string(timer.Type.Name),
+		Status:         string(timer.Status),
+		BookingID:      lo.EmptyableToPtr(timer.BookingID),
+		UserID:         timer.UserID,
+		OffsetMinutes:  timer.OffsetMinutes,
+		CorrelationKey: timer.CorrelationKey,
+		TargetTopic:    timer.TargetTopic,
+		EventType:      timer.EventType,
+		TriggerAt:      timer.TriggerAt,
+		CreatedAt:      timer.CreatedAt,
+		TriggeredAt:    timer.TriggeredAt,
+		CancelledAt:    timer.CancelledAt,
+	}
+}
+
+func OutboxEventFromEntity(ev entity.OutboxEvent) OutboxEvent {
+	return OutboxEvent{
+		ID:          ev.ID,
+		EventType:   ev.EventType,
+		Payload:     ev.Payload,
+		Topic:       lo.EmptyableToPtr(ev.Topic),
+		Status:      string(ev.Status.Name),
+		CreatedAt:   ev.CreatedAt,
+		ProcessedAt: ev.ProcessedAt,
+	}
+}
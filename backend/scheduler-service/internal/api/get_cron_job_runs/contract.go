@@ -0,0 +1,12 @@
+package get_cron_job_runs
+
+import (
+	"context"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type CronService interface {
+	ListRuns(ctx context.Context, jobID uuid.UUID, limit int) ([]entity.CronJobRun, error)
+}
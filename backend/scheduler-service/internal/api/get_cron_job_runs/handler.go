@@ -0,0 +1,45 @@
+package get_cron_job_runs
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/dto"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+const DEFAULT_LIMIT = 50
+
+type handler struct {
+	s CronService
+}
+
+func New(s CronService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct {
+	JobID uuid.UUID `param:"jobId" validate:"required"`
+	Limit *int      `query:"limit" validate:"omitempty,min=1,max=200"`
+}
+
+type Response struct {
+	Runs []dto.CronJobRun `json:"runs"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	runs, err := h.s.ListRuns(ctx.Request().Context(), in.JobID, lo.FromPtrOr(in.Limit, DEFAULT_LIMIT))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, Response{
+		Runs: lo.Map(runs, func(run entity.CronJobRun, _ int) dto.CronJobRun {
+			return dto.CronJobRunFromEntity(run)
+		}),
+	})
+}
@@ -0,0 +1,11 @@
+package get_cron_jobs
+
+import (
+	"context"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+)
+
+type CronService interface {
+	ListJobs(ctx context.Context) ([]entity.CronJob, error)
+}
@@ -0,0 +1,39 @@
+package get_cron_jobs
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/dto"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s CronService
+}
+
+func New(s CronService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct{}
+
+type Response struct {
+	Jobs []dto.CronJob `json:"jobs"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	jobs, err := h.s.ListJobs(ctx.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, Response{
+		Jobs: lo.Map(jobs, func(job entity.CronJob, _ int) dto.CronJob {
+			return dto.CronJobFromEntity(job)
+		}),
+	})
+}
@@ -0,0 +1,12 @@
+package get_reminder_preferences
+
+import (
+	"context"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type SchedulerService interface {
+	GetReminderPreferences(ctx context.Context, userID uuid.UUID) (entity.ReminderPreferences, error)
+}
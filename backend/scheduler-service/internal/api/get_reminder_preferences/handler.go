@@ -0,0 +1,35 @@
+package get_reminder_preferences
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/dto"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s SchedulerService
+}
+
+func New(s SchedulerService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct{}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	prefs, err := h.s.GetReminderPreferences(ctx.Request().Context(), claims.UserID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, dto.ReminderPreferencesFromEntity(prefs))
+}
@@ -0,0 +1,12 @@
+package get_timer
+
+import (
+	"context"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type TimerAdminService interface {
+	GetTimer(ctx context.Context, id uuid.UUID) (entity.Timer, []entity.OutboxEvent, error)
+}
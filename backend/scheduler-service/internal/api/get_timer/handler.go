@@ -0,0 +1,48 @@
+package get_timer
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/dto"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	timer_admin_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/timer_admin"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s TimerAdminService
+}
+
+func New(s TimerAdminService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.TimerByIDRequest
+
+// Универсальные таймеры публикуются напрямую в свой топик, поэтому
+// outboxEvents у них пустой.
+type Response struct {
+	Timer        dto.Timer         `json:"timer"`
+	OutboxEvents []dto.OutboxEvent `json:"outboxEvents"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	timer, events, err := h.s.GetTimer(ctx.Request().Context(), in.TimerID)
+	if err != nil {
+		if errors.Is(err, timer_admin_service.ErrTimerNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, Response{
+		Timer: dto.TimerFromEntity(timer),
+		OutboxEvents: lo.Map(events, func(ev entity.OutboxEvent, _ int) dto.OutboxEvent {
+			return dto.OutboxEventFromEntity(ev)
+		}),
+	})
+}
@@ -0,0 +1,11 @@
+package get_timers
+
+import (
+	"context"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+)
+
+type TimerAdminService interface {
+	Search(ctx context.Context, filter entity.TimerFilter, limit, offset int) ([]entity.Timer, error)
+}
@@ -0,0 +1,65 @@
+package get_timers
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/dto"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+const DEFAULT_LIMIT = 50
+
+type handler struct {
+	s TimerAdminService
+}
+
+func New(s TimerAdminService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request struct {
+	BookingID *uuid.UUID `query:"bookingId"`
+	UserID    *uuid.UUID `query:"userId"`
+	Status    *string    `query:"status" validate:"omitempty,oneof=pending triggered cancelled"`
+	Type      *string    `query:"type" validate:"omitempty,oneof=booking_reminder booking_expire generic booking_end_reminder"`
+	Limit     *int       `query:"limit" validate:"omitempty,min=1,max=200"`
+	Offset    *int       `query:"offset" validate:"omitempty,min=0"`
+}
+
+type Response struct {
+	Timers []dto.Timer `json:"timers"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	filter := entity.TimerFilter{
+		BookingID: in.BookingID,
+		UserID:    in.UserID,
+	}
+	if in.Status != nil {
+		filter.Status = lo.ToPtr(entity.TimerStatus(*in.Status))
+	}
+	if in.Type != nil {
+		filter.Type = lo.ToPtr(entity.TimerName(*in.Type))
+	}
+
+	timers, err := h.s.Search(
+		ctx.Request().Context(),
+		filter,
+		lo.FromPtrOr(in.Limit, DEFAULT_LIMIT),
+		lo.FromPtr(in.Offset),
+	)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, Response{
+		Timers: lo.Map(timers, func(timer entity.Timer, _ int) dto.Timer {
+			return dto.TimerFromEntity(timer)
+		}),
+	})
+}
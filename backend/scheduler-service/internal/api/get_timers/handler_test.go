@@ -0,0 +1,111 @@
+package get_timers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/4udiwe/avito-pvz/pkg/validator"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type fakeService struct {
+	filter        entity.TimerFilter
+	limit, offset int
+	err           error
+}
+
+func (f *fakeService) Search(_ context.Context, filter entity.TimerFilter, limit, offset int) ([]entity.Timer, error) {
+	f.filter, f.limit, f.offset = filter, limit, offset
+	return []entity.Timer{{ID: uuid.New(), Status: entity.TimerStatusPending}}, f.err
+}
+
+func TestHandle(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		query      string
+		err        error
+		wantStatus int
+		wantFilter entity.TimerFilter
+		wantLimit  int
+		wantOffset int
+	}{
+		{
+			name:       "defaults",
+			wantStatus: http.StatusOK,
+			wantLimit:  DEFAULT_LIMIT,
+		},
+		{
+			name:       "filters",
+			query:      "?userId=" + userID.String() + "&status=pending&type=generic&limit=10&offset=20",
+			wantStatus: http.StatusOK,
+			wantFilter: entity.TimerFilter{
+				UserID: &userID,
+				Status: lo.ToPtr(entity.TimerStatusPending),
+				Type:   lo.ToPtr(entity.TimerTypeGenericName),
+			},
+			wantLimit:  10,
+			wantOffset: 20,
+		},
+		{
+			name:       "unknown_status",
+			query:      "?status=done",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "limit_too_large",
+			query:      "?limit=500",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "service_error",
+			err:        errors.New("cannot fetch timers"),
+			wantStatus: http.StatusInternalServerError,
+			wantLimit:  DEFAULT_LIMIT,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakeService{err: tt.err}
+
+			e := echo.New()
+			e.Validator = validator.NewCustomValidator()
+			e.GET("/admin/scheduler/timers", New(svc).Handle)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/scheduler/timers"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus == http.StatusBadRequest {
+				return
+			}
+
+			if svc.limit != tt.wantLimit || svc.offset != tt.wantOffset {
+				t.Errorf("limit, offset = %d, %d, want %d, %d", svc.limit, svc.offset, tt.wantLimit, tt.wantOffset)
+			}
+			if !reflect.DeepEqual(svc.filter, tt.wantFilter) {
+				t.Errorf("filter = %+v, want %+v", svc.filter, tt.wantFilter)
+			}
+
+			if rec.Code == http.StatusOK {
+				var resp Response
+				if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || len(resp.Timers) != 1 {
+					t.Errorf("response = %s", rec.Body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package post_cron_job_pause
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type CronService interface {
+	PauseJob(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,11 @@
+package post_cron_job_resume
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type CronService interface {
+	ResumeJob(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,33 @@
+package post_cron_job_resume
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/dto"
+	cron_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/cron"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s CronService
+}
+
+func New(s CronService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.JobByIDRequest
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	if err := h.s.ResumeJob(ctx.Request().Context(), in.JobID); err != nil {
+		if errors.Is(err, cron_service.ErrJobNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,12 @@
+package post_cron_job_trigger
+
+import (
+	"context"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type CronService interface {
+	TriggerJob(ctx context.Context, id uuid.UUID) (entity.CronJobRun, error)
+}
@@ -0,0 +1,36 @@
+package post_cron_job_trigger
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/dto"
+	cron_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/cron"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s CronService
+}
+
+func New(s CronService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.JobByIDRequest
+
+// Handle запускает задачу немедленно. Ошибка публикации не превращается в 5xx:
+// запуск записан в историю со статусом failed и возвращается в ответе.
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	run, err := h.s.TriggerJob(ctx.Request().Context(), in.JobID)
+	if err != nil {
+		if errors.Is(err, cron_service.ErrJobNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, dto.CronJobRunFromEntity(run))
+}
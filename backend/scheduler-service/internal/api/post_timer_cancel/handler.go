@@ -0,0 +1,37 @@
+package post_timer_cancel
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/dto"
+	timer_admin_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/timer_admin"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s TimerAdminService
+}
+
+func New(s TimerAdminService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.TimerByIDRequest
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	timer, err := h.s.Cancel(ctx.Request().Context(), in.TimerID)
+	if err != nil {
+		switch {
+		case errors.Is(err, timer_admin_service.ErrTimerNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, timer_admin_service.ErrTimerNotPending):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, dto.TimerFromEntity(timer))
+}
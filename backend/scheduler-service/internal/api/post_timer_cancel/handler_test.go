@@ -0,0 +1,53 @@
+package post_timer_cancel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/4udiwe/avito-pvz/pkg/validator"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	timer_admin_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/timer_admin"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type fakeService struct {
+	err error
+}
+
+func (f fakeService) Cancel(_ context.Context, id uuid.UUID) (entity.Timer, error) {
+	return entity.Timer{ID: id, Status: entity.TimerStatusCancelled}, f.err
+}
+
+func TestHandle(t *testing.T) {
+	tests := []struct {
+		name       string
+		timerID    string
+		err        error
+		wantStatus int
+	}{
+		{name: "cancelled", timerID: uuid.NewString(), wantStatus: http.StatusOK},
+		{name: "not_found", timerID: uuid.NewString(), err: timer_admin_service.ErrTimerNotFound, wantStatus: http.StatusNotFound},
+		{name: "not_pending", timerID: uuid.NewString(), err: timer_admin_service.ErrTimerNotPending, wantStatus: http.StatusConflict},
+		{name: "service_error", timerID: uuid.NewString(), err: timer_admin_service.ErrCannotUpdateTimer, wantStatus: http.StatusInternalServerError},
+		{name: "bad_id", timerID: "not-a-uuid", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = validator.NewCustomValidator()
+			e.POST("/admin/scheduler/timers/:timerId/cancel", New(fakeService{err: tt.err}).Handle)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/scheduler/timers/"+tt.timerID+"/cancel", nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package post_timer_trigger
+
+import (
+	"context"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type TimerAdminService interface {
+	Retrigger(ctx context.Context, id uuid.UUID) (entity.Timer, error)
+}
@@ -0,0 +1,36 @@
+package post_timer_trigger
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/dto"
+	timer_admin_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/timer_admin"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s TimerAdminService
+}
+
+func New(s TimerAdminService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+type Request = dto.TimerByIDRequest
+
+// Handle ставит таймер на немедленное срабатывание. Событие создаёт воркер
+// на ближайшем тике, поэтому ответ — таймер в статусе pending.
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	timer, err := h.s.Retrigger(ctx.Request().Context(), in.TimerID)
+	if err != nil {
+		if errors.Is(err, timer_admin_service.ErrTimerNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusAccepted, dto.TimerFromEntity(timer))
+}
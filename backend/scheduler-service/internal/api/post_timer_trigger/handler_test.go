@@ -0,0 +1,51 @@
+package post_timer_trigger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/4udiwe/avito-pvz/pkg/validator"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	timer_admin_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/timer_admin"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type fakeService struct {
+	err error
+}
+
+func (f fakeService) Retrigger(_ context.Context, id uuid.UUID) (entity.Timer, error) {
+	return entity.Timer{ID: id, Status: entity.TimerStatusPending}, f.err
+}
+
+func TestHandle(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		// Событие создаёт воркер, поэтому ответ — 202, а не 200
+		{name: "retriggered", wantStatus: http.StatusAccepted},
+		{name: "not_found", err: timer_admin_service.ErrTimerNotFound, wantStatus: http.StatusNotFound},
+		{name: "service_error", err: timer_admin_service.ErrCannotUpdateTimer, wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = validator.NewCustomValidator()
+			e.POST("/admin/scheduler/timers/:timerId/trigger", New(fakeService{err: tt.err}).Handle)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/scheduler/timers/"+uuid.NewString()+"/trigger", nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package put_reminder_preferences
+
+import (
+	"context"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type SchedulerService interface {
+	UpdateReminderPreferences(
+		ctx context.Context,
+		userID uuid.UUID,
+		startOffsets []int,
+		endOffset *int,
+	) (entity.ReminderPreferences, error)
+}
@@ -0,0 +1,45 @@
+package put_reminder_preferences
+
+import (
+	"net/http"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/dto"
+	"github.com/4udiwe/coworking/auth-service/pkg/decorator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s SchedulerService
+}
+
+func New(s SchedulerService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: s})
+}
+
+// StartOffsets — до 5 напоминаний не раньше чем за неделю до начала,
+// пустой список отключает напоминания. EndOffset — не больше суток.
+type Request struct {
+	StartOffsets []int `json:"startOffsets" validate:"max=5,dive,min=1,max=10080"`
+	EndOffset    *int  `json:"endOffset" validate:"omitempty,min=1,max=1440"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	prefs, err := h.s.UpdateReminderPreferences(
+		ctx.Request().Context(),
+		claims.UserID,
+		in.StartOffsets,
+		in.EndOffset,
+	)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, dto.ReminderPreferencesFromEntity(prefs))
+}
@@ -5,17 +5,30 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/4udiwe/avito-pvz/pkg/httpserver"
 	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
 	"github.com/4udiwe/big-bob-pizza/order-service/pkg/outbox"
 	"github.com/4udiwe/cowoking/scheduler-service/config"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api"
+	consumer_auth "github.com/4udiwe/cowoking/scheduler-service/internal/consumer/auth"
 	consumer_booking "github.com/4udiwe/cowoking/scheduler-service/internal/consumer/booking"
+	consumer_timer "github.com/4udiwe/cowoking/scheduler-service/internal/consumer/timer"
 	"github.com/4udiwe/cowoking/scheduler-service/internal/database"
-	auth_session_cleaner_producer "github.com/4udiwe/cowoking/scheduler-service/internal/auth_session_cleaner_producer"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/metrics"
+	cron_job_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/cron_job"
 	outbox_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/outbox"
+	reminder_preferences_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/reminder_preferences"
 	timer_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/timer"
+	cron_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/cron"
 	scheduler_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/scheduler"
+	timer_admin_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/timer_admin"
 	"github.com/4udiwe/cowoking/scheduler-service/internal/worker"
+	"github.com/4udiwe/coworking/auth-service/pkg/inbox"
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/log"
 )
@@ -31,23 +44,59 @@ type App struct {
 	echoHandler *echo.Echo
 
 	// Repositories
-	timerRepo  *timer_repository.TimerRepository
-	outboxRepo *outbox_repository.Repository
+	timerRepo   *timer_repository.TimerRepository
+	outboxRepo  *outbox_repository.Repository
+	cronJobRepo *cron_job_repository.CronJobRepository
+	prefsRepo   *reminder_preferences_repository.ReminderPreferencesRepository
 
 	// Services
 	schedulerService *scheduler_service.SchedulerService
+	cronService      *cron_service.CronService
+	timerAdmin       *timer_admin_service.TimerAdminService
+
+	// Handlers
+	getCronJobsHandler        api.Handler
+	getCronJobRunsHandler     api.Handler
+	postCronJobPauseHandler   api.Handler
+	postCronJobResumeHandler  api.Handler
+	postCronJobTriggerHandler api.Handler
+
+	getReminderPreferencesHandler api.Handler
+	putReminderPreferencesHandler api.Handler
+
+	getTimersHandler        api.Handler
+	getTimerHandler         api.Handler
+	postTimerTriggerHandler api.Handler
+	postTimerCancelHandler  api.Handler
 
 	// Consumer
 	bookingConsumer *consumer_booking.Consumer
+	timerConsumer   *consumer_timer.Consumer
+	authConsumer    *consumer_auth.Consumer
+
+	// Inbox
+	inbox *inbox.Inbox
 
 	// Outbox
-	outboxWorker *outbox.Worker
+	outboxWorker       *outbox.Worker
+	topicOutboxWorkers []*outbox.Worker
 
 	// Scheduler worker
 	scheduerWorker *worker.Worker
 
-	// Session Cleanup Worker
-	sessionCleanupWorker *auth_session_cleaner_producer.SessionCleanupWorker
+	// Cron worker
+	cronWorker *worker.CronWorker
+
+	// Metrics
+	metrics *metrics.Metrics
+
+	// Middleware
+	authMW *middleware.AuthMiddleware
+
+	// Auth
+	jwtValidator       *jwt_validator.Validator
+	denylist           *jwt_validator.Denylist
+	revocationListener *jwt_validator.RevocationListener
 }
 
 func New(configPath string) *App {
@@ -90,21 +139,50 @@ func (app *App) Start() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Cron jobs: определения из конфига -> cron_job
+	if err := app.CronService().Register(ctx, app.cronJobs()); err != nil {
+		log.Fatalf("app - Start - Cron jobs registration failed: %v", err)
+	}
+
+	// Token revocation: auth.events -> local denylist
+	app.revocationListener = jwt_validator.NewRevocationListener(
+		app.Denylist(),
+		kafka.NewConsumer(app.cfg.Kafka.Brokers),
+		app.cfg.Kafka.Topics.AuthEvents,
+		app.cfg.Kafka.Consumer.GroupID,
+	)
+
 	// App server
-	log.Info("Starting app...")
+	log.Info("Starting app server...")
+	httpServer := httpserver.New(app.EchoHandler(), httpserver.Port(app.cfg.HTTP.Port))
+	httpServer.Start()
+	log.Debugf("Server port: %s", app.cfg.HTTP.Port)
 
 	// Run consumers and workers
 	app.BookingConsumer().Run(ctx)
+	app.TimerConsumer().Run(ctx)
+	app.AuthConsumer().Run(ctx)
 	app.OutboxWorker().Run(ctx)
+	for _, w := range app.TopicOutboxWorkers() {
+		w.Run(ctx)
+	}
 	app.ScheduerWorker().Run(ctx)
-	app.SessionCleanupWorker().Run(ctx)
+	app.CronWorker().Run(ctx)
+	app.revocationListener.Run(ctx)
+	app.Inbox().Run(ctx, time.Hour, app.cfg.Kafka.Consumer.InboxRetention)
 
 	select {
 	case s := <-app.interrupt:
 		log.Infof("app - Start - signal: %v", s)
+	case err := <-httpServer.Notify():
+		log.Errorf("app - Start - server error: %v", err)
 	}
 
 	cancel()
 
+	if err := httpServer.Shutdown(); err != nil {
+		log.Errorf("HTTP server shutdown error: %v", err)
+	}
+
 	log.Info("Shutting down...")
 }
@@ -2,20 +2,79 @@ package app
 
 import (
 	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
+	consumer_auth "github.com/4udiwe/cowoking/scheduler-service/internal/consumer/auth"
 	consumer_booking "github.com/4udiwe/cowoking/scheduler-service/internal/consumer/booking"
+	consumer_timer "github.com/4udiwe/cowoking/scheduler-service/internal/consumer/timer"
+	"github.com/4udiwe/coworking/auth-service/pkg/inbox"
+	"github.com/4udiwe/coworking/auth-service/pkg/retry"
+	"github.com/4udiwe/coworking/auth-service/pkg/transactor"
 )
 
 func (app *App) BookingConsumer() *consumer_booking.Consumer {
 	if app.bookingConsumer != nil {
 		return app.bookingConsumer
 	}
-	bookingKafkaConsumer := kafka.NewConsumer(app.cfg.Kafka.Brokers)
-
 	app.bookingConsumer = consumer_booking.New(
 		app.SchedulerService(),
-		bookingKafkaConsumer,
+		app.Inbox(),
+		app.RetryingConsumer(),
 		app.cfg.Kafka.Topics.SchedulerEvents,
 		app.cfg.Kafka.Consumer.GroupID,
 	)
 	return app.bookingConsumer
 }
+
+func (app *App) TimerConsumer() *consumer_timer.Consumer {
+	if app.timerConsumer != nil {
+		return app.timerConsumer
+	}
+	app.timerConsumer = consumer_timer.New(
+		app.SchedulerService(),
+		app.Inbox(),
+		app.RetryingConsumer(),
+		app.cfg.Kafka.Topics.TimerRequests,
+		app.cfg.Kafka.Consumer.GroupID,
+	)
+	return app.timerConsumer
+}
+
+func (app *App) AuthConsumer() *consumer_auth.Consumer {
+	if app.authConsumer != nil {
+		return app.authConsumer
+	}
+	app.authConsumer = consumer_auth.New(
+		app.SchedulerService(),
+		app.Inbox(),
+		app.RetryingConsumer(),
+		app.cfg.Kafka.Topics.AuthEvents,
+		app.cfg.Kafka.Consumer.GroupID,
+	)
+	return app.authConsumer
+}
+
+func (app *App) Inbox() *inbox.Inbox {
+	if app.inbox != nil {
+		return app.inbox
+	}
+	app.inbox = inbox.New(app.Postgres())
+	return app.inbox
+}
+
+// TxManager присоединяется к транзакции Inbox, если обработка идёт из consumer'а
+func (app *App) TxManager() *transactor.Joining {
+	return transactor.NewJoining(app.Postgres())
+}
+
+// RetryingConsumer — отдельный экземпляр на каждый топик: у kafka.KafkaConsumer один reader
+func (app *App) RetryingConsumer() *retry.Consumer {
+	cfg := app.cfg.Kafka.Consumer
+	return retry.NewConsumer(
+		app.cfg.Kafka.Brokers,
+		kafka.NewKafkaPublisher(app.cfg.Kafka.Brokers),
+		retry.Policy{
+			MaxAttempts: cfg.RetryMaxAttempts,
+			Backoff:     cfg.RetryBackoff,
+			MaxBackoff:  cfg.RetryMaxBackoff,
+		},
+	)
+}
@@ -2,7 +2,9 @@ package app
 
 import (
 	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	cron_job_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/cron_job"
 	outbox_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/outbox"
+	reminder_preferences_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/reminder_preferences"
 	timer_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/timer"
 )
 
@@ -25,3 +27,19 @@ func (app *App) OutboxRepo() *outbox_repository.Repository {
 	app.outboxRepo = outbox_repository.New(app.Postgres())
 	return app.outboxRepo
 }
+
+func (app *App) CronJobRepo() *cron_job_repository.CronJobRepository {
+	if app.cronJobRepo != nil {
+		return app.cronJobRepo
+	}
+	app.cronJobRepo = cron_job_repository.New(app.Postgres())
+	return app.cronJobRepo
+}
+
+func (app *App) ReminderPreferencesRepo() *reminder_preferences_repository.ReminderPreferencesRepository {
+	if app.prefsRepo != nil {
+		return app.prefsRepo
+	}
+	app.prefsRepo = reminder_preferences_repository.New(app.Postgres())
+	return app.prefsRepo
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/get_cron_job_runs"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/get_cron_jobs"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/get_reminder_preferences"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/get_timer"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/get_timers"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/post_cron_job_pause"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/post_cron_job_resume"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/post_cron_job_trigger"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/post_timer_cancel"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/post_timer_trigger"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/api/put_reminder_preferences"
+)
+
+func (app *App) GetCronJobsHandler() api.Handler {
+	if app.getCronJobsHandler != nil {
+		return app.getCronJobsHandler
+	}
+	app.getCronJobsHandler = get_cron_jobs.New(app.CronService())
+	return app.getCronJobsHandler
+}
+
+func (app *App) GetCronJobRunsHandler() api.Handler {
+	if app.getCronJobRunsHandler != nil {
+		return app.getCronJobRunsHandler
+	}
+	app.getCronJobRunsHandler = get_cron_job_runs.New(app.CronService())
+	return app.getCronJobRunsHandler
+}
+
+func (app *App) PostCronJobPauseHandler() api.Handler {
+	if app.postCronJobPauseHandler != nil {
+		return app.postCronJobPauseHandler
+	}
+	app.postCronJobPauseHandler = post_cron_job_pause.New(app.CronService())
+	return app.postCronJobPauseHandler
+}
+
+func (app *App) PostCronJobResumeHandler() api.Handler {
+	if app.postCronJobResumeHandler != nil {
+		return app.postCronJobResumeHandler
+	}
+	app.postCronJobResumeHandler = post_cron_job_resume.New(app.CronService())
+	return app.postCronJobResumeHandler
+}
+
+func (app *App) PostCronJobTriggerHandler() api.Handler {
+	if app.postCronJobTriggerHandler != nil {
+		return app.postCronJobTriggerHandler
+	}
+	app.postCronJobTriggerHandler = post_cron_job_trigger.New(app.CronService())
+	return app.postCronJobTriggerHandler
+}
+
+func (app *App) GetReminderPreferencesHandler() api.Handler {
+	if app.getReminderPreferencesHandler != nil {
+		return app.getReminderPreferencesHandler
+	}
+	app.getReminderPreferencesHandler = get_reminder_preferences.New(app.SchedulerService())
+	return app.getReminderPreferencesHandler
+}
+
+func (app *App) PutReminderPreferencesHandler() api.Handler {
+	if app.putReminderPreferencesHandler != nil {
+		return app.putReminderPreferencesHandler
+	}
+	app.putReminderPreferencesHandler = put_reminder_preferences.New(app.SchedulerService())
+	return app.putReminderPreferencesHandler
+}
+
+func (app *App) GetTimersHandler() api.Handler {
+	if app.getTimersHandler != nil {
+		return app.getTimersHandler
+	}
+	app.getTimersHandler = get_timers.New(app.TimerAdminService())
+	return app.getTimersHandler
+}
+
+func (app *App) GetTimerHandler() api.Handler {
+	if app.getTimerHandler != nil {
+		return app.getTimerHandler
+	}
+	app.getTimerHandler = get_timer.New(app.TimerAdminService())
+	return app.getTimerHandler
+}
+
+func (app *App) PostTimerTriggerHandler() api.Handler {
+	if app.postTimerTriggerHandler != nil {
+		return app.postTimerTriggerHandler
+	}
+	app.postTimerTriggerHandler = post_timer_trigger.New(app.TimerAdminService())
+	return app.postTimerTriggerHandler
+}
+
+func (app *App) PostTimerCancelHandler() api.Handler {
+	if app.postTimerCancelHandler != nil {
+		return app.postTimerCancelHandler
+	}
+	app.postTimerCancelHandler = post_timer_cancel.New(app.TimerAdminService())
+	return app.postTimerCancelHandler
+}
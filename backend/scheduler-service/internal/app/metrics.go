@@ -0,0 +1,11 @@
+package app
+
+import "github.com/4udiwe/cowoking/scheduler-service/internal/metrics"
+
+func (app *App) Metrics() *metrics.Metrics {
+	if app.metrics != nil {
+		return app.metrics
+	}
+	app.metrics = metrics.New(app.TimerRepo())
+	return app.metrics
+}
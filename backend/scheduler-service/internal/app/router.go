@@ -0,0 +1,76 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/4udiwe/avito-pvz/pkg/validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/jwt_validator"
+	"github.com/4udiwe/coworking/auth-service/pkg/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func (app *App) EchoHandler() *echo.Echo {
+	if app.echoHandler != nil {
+		return app.echoHandler
+	}
+
+	handler := echo.New()
+	handler.Validator = validator.NewCustomValidator()
+
+	app.configureRouter(handler)
+
+	for _, r := range handler.Routes() {
+		fmt.Printf("%s %s\n", r.Method, r.Path)
+	}
+
+	app.echoHandler = handler
+	return app.echoHandler
+}
+
+func (app *App) configureRouter(handler *echo.Echo) {
+	// Health check endpoint (no auth required)
+	handler.GET("/health", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	// Prometheus metrics (no auth required, scraped inside the cluster)
+	handler.GET("/metrics", echo.WrapHandler(app.Metrics().Handler()))
+
+	// Auth middleware with skipper for /health and /metrics
+	authMiddleware := app.AuthMiddleware()
+	handler.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			path := c.Request().URL.Path
+			if strings.HasPrefix(path, "/health") || strings.HasPrefix(path, "/metrics") {
+				return next(c)
+			}
+			return authMiddleware.Middleware(next)(c)
+		}
+	})
+
+	// User reminder preferences
+	remindersGroup := handler.Group("/reminders")
+	{
+		remindersGroup.GET("/preferences", app.GetReminderPreferencesHandler().Handle)
+		remindersGroup.PUT("/preferences", app.PutReminderPreferencesHandler().Handle)
+	}
+
+	// Admin cron job endpoints
+	cronJobsGroup := handler.Group("/admin/scheduler/jobs", middleware.RequirePermission(jwt_validator.PermSchedulerManage))
+	{
+		cronJobsGroup.GET("", app.GetCronJobsHandler().Handle)
+		cronJobsGroup.GET("/:jobId/runs", app.GetCronJobRunsHandler().Handle)
+		cronJobsGroup.POST("/:jobId/pause", app.PostCronJobPauseHandler().Handle)
+		cronJobsGroup.POST("/:jobId/resume", app.PostCronJobResumeHandler().Handle)
+		cronJobsGroup.POST("/:jobId/trigger", app.PostCronJobTriggerHandler().Handle)
+	}
+
+	// Admin timer endpoints
+	timersGroup := handler.Group("/admin/scheduler/timers", middleware.RequirePermission(jwt_validator.PermSchedulerManage))
+	{
+		timersGroup.GET("", app.GetTimersHandler().Handle)
+		timersGroup.GET("/:timerId", app.GetTimerHandler().Handle)
+		timersGroup.POST("/:timerId/trigger", app.PostTimerTriggerHandler().Handle)
+		timersGroup.POST("/:timerId/cancel", app.PostTimerCancelHandler().Handle)
+	}
+}
@@ -1,6 +1,13 @@
 package app
 
-import scheduler_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/scheduler"
+import (
+	"github.com/4udiwe/cowoking/scheduler-service/config"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	cron_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/cron"
+	scheduler_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/scheduler"
+	timer_admin_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/timer_admin"
+	"github.com/samber/lo"
+)
 
 func (app *App) SchedulerService() *scheduler_service.SchedulerService {
 	if app.schedulerService != nil {
@@ -8,8 +15,56 @@ func (app *App) SchedulerService() *scheduler_service.SchedulerService {
 	}
 	app.schedulerService = scheduler_service.New(
 		app.TimerRepo(),
-		app.Postgres(),
+		app.ReminderPreferencesRepo(),
+		app.TxManager(),
 		app.cfg.Scheduler.RemindBefore,
+		app.cfg.Scheduler.AllowedTimerTopics,
 	)
 	return app.schedulerService
 }
+
+func (app *App) CronService() *cron_service.CronService {
+	if app.cronService != nil {
+		return app.cronService
+	}
+	app.cronService = cron_service.New(
+		app.CronJobRepo(),
+		app.OutboxRepo(),
+		app.Postgres(),
+	)
+	return app.cronService
+}
+
+func (app *App) TimerAdminService() *timer_admin_service.TimerAdminService {
+	if app.timerAdmin != nil {
+		return app.timerAdmin
+	}
+	app.timerAdmin = timer_admin_service.New(
+		app.TimerRepo(),
+		app.OutboxRepo(),
+		app.Postgres(),
+	)
+	return app.timerAdmin
+}
+
+// outboxTopics — топики, в которые сервис пишет события через outbox помимо
+// основного: топики cron-задач и разрешённые топики универсальных таймеров.
+func (app *App) outboxTopics() []string {
+	topics := lo.Map(app.cfg.Cron.Jobs, func(job config.CronJob, _ int) string {
+		return job.Topic
+	})
+	return lo.Uniq(append(topics, app.cfg.Scheduler.AllowedTimerTopics...))
+}
+
+func (app *App) cronJobs() []entity.CronJob {
+	return lo.Map(app.cfg.Cron.Jobs, func(job config.CronJob, _ int) entity.CronJob {
+		return entity.CronJob{
+			Name:            job.Name,
+			Schedule:        job.Schedule,
+			Topic:           job.Topic,
+			EventType:       job.EventType,
+			PayloadTemplate: job.Payload,
+			Enabled:         job.Enabled,
+		}
+	})
+}
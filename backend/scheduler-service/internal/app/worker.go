@@ -3,8 +3,8 @@ package app
 import (
 	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
 	"github.com/4udiwe/big-bob-pizza/order-service/pkg/outbox"
+	outbox_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/outbox"
 	"github.com/4udiwe/cowoking/scheduler-service/internal/worker"
-	auth_session_cleaner_producer "github.com/4udiwe/cowoking/scheduler-service/internal/auth_session_cleaner_producer"
 )
 
 func (app *App) ScheduerWorker() *worker.Worker {
@@ -15,6 +15,7 @@ func (app *App) ScheduerWorker() *worker.Worker {
 		app.TimerRepo(),
 		app.OutboxRepo(),
 		app.Postgres(),
+		app.Metrics(),
 		app.cfg.Worker.WorkerBatchLimit,
 		app.cfg.Worker.WorkerInterval,
 	)
@@ -38,16 +39,34 @@ func (app *App) OutboxWorker() *outbox.Worker {
 	return app.outboxWorker
 }
 
-func (app *App) SessionCleanupWorker() *auth_session_cleaner_producer.SessionCleanupWorker {
-	if app.sessionCleanupWorker != nil {
-		return app.sessionCleanupWorker
+// TopicOutboxWorkers публикует outbox-события с явным топиком: по воркеру на топик.
+func (app *App) TopicOutboxWorkers() []*outbox.Worker {
+	if app.topicOutboxWorkers != nil {
+		return app.topicOutboxWorkers
 	}
-	kafkaProducer := kafka.NewKafkaPublisher(app.cfg.Kafka.Brokers)
-	app.sessionCleanupWorker = auth_session_cleaner_producer.New(
-		kafkaProducer,
-		app.cfg.SessionCleanupWorker.Topic,
-		app.cfg.SessionCleanupWorker.RetentionDays,
-		app.cfg.SessionCleanupWorker.Interval,
+	kafkaPublisher := kafka.NewKafkaPublisher(app.cfg.Kafka.Brokers)
+	for _, topic := range app.outboxTopics() {
+		app.topicOutboxWorkers = append(app.topicOutboxWorkers, outbox.NewWorker(
+			outbox_repository.NewForTopic(app.Postgres(), topic),
+			kafkaPublisher,
+			topic,
+			app.cfg.Outbox.BatchLimit,
+			app.cfg.Outbox.RequeBatchLimit,
+			app.cfg.Outbox.Interval,
+			app.cfg.Outbox.RequeInterval,
+		))
+	}
+	return app.topicOutboxWorkers
+}
+
+func (app *App) CronWorker() *worker.CronWorker {
+	if app.cronWorker != nil {
+		return app.cronWorker
+	}
+	app.cronWorker = worker.NewCronWorker(
+		app.CronService(),
+		app.cfg.Cron.BatchLimit,
+		app.cfg.Cron.Interval,
 	)
-	return app.sessionCleanupWorker
-}
\ No newline at end of file
+	return app.cronWorker
+}
@@ -0,0 +1,68 @@
+package consumer_auth
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/consumer"
+	scheduler_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/scheduler"
+	"github.com/4udiwe/coworking/auth-service/pkg/inbox"
+	"github.com/4udiwe/coworking/auth-service/pkg/retry"
+)
+
+// Обработчик событий жизненного цикла пользователей из топика auth.
+// Отзыв токенов по тем же событиям делает jwt_validator.RevocationListener.
+type Consumer struct {
+	service  *scheduler_service.SchedulerService
+	inbox    *inbox.Inbox
+	consumer *retry.Consumer
+	topic    string
+	groupID  string
+}
+
+func New(
+	service *scheduler_service.SchedulerService,
+	inbox *inbox.Inbox,
+	consumer *retry.Consumer,
+	topic string,
+	groupID string,
+) *Consumer {
+	return &Consumer{
+		service:  service,
+		inbox:    inbox,
+		consumer: consumer,
+		topic:    topic,
+		groupID:  groupID,
+	}
+}
+
+func (c *Consumer) Run(ctx context.Context) error {
+	logrus.Infof("AuthConsumer: subscribing to topic=%s group=%s", c.topic, c.groupID)
+
+	return c.consumer.Subscribe(ctx, c.topic, c.groupID, func(ctx context.Context, key, value []byte) error {
+		event, err := consumer.ParseOrderEvent(value)
+		if err != nil {
+			logrus.Errorf("AuthConsumer: failed to parse event: %v", err)
+			return retry.Permanent(err)
+		}
+
+		switch event.Type {
+
+		case consumer.UserDeleted:
+			return c.inbox.Process(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+				err := c.service.DeleteUser(ctx, event.Payload.UserID, event.Payload.AnonymousID)
+				if err != nil {
+					logrus.Errorf("AuthConsumer: DeleteUser failed: %v", err)
+				}
+				return err
+			})
+
+		default:
+			// Напоминания деактивированного пользователя отменяются вместе с
+			// бронированиями (booking.cancelled от booking-service).
+			// Остальные события топика (сессии, права) обрабатывает RevocationListener
+			return nil
+		}
+	})
+}
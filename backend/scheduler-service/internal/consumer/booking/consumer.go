@@ -5,27 +5,31 @@ import (
 
 	"github.com/sirupsen/logrus"
 
-	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
 	"github.com/4udiwe/cowoking/scheduler-service/internal/consumer"
 	scheduler_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/scheduler"
+	"github.com/4udiwe/coworking/auth-service/pkg/inbox"
+	"github.com/4udiwe/coworking/auth-service/pkg/retry"
 )
 
 // Обработчик событий для топика scheduler
 type Consumer struct {
 	service  *scheduler_service.SchedulerService
-	consumer *kafka.KafkaConsumer
+	inbox    *inbox.Inbox
+	consumer *retry.Consumer
 	topic    string
 	groupID  string
 }
 
 func New(
 	service *scheduler_service.SchedulerService,
-	consumer *kafka.KafkaConsumer,
+	inbox *inbox.Inbox,
+	consumer *retry.Consumer,
 	topic string,
 	groupID string,
 ) *Consumer {
 	return &Consumer{
 		service:  service,
+		inbox:    inbox,
 		consumer: consumer,
 		topic:    topic,
 		groupID:  groupID,
@@ -39,39 +43,43 @@ func (c *Consumer) Run(ctx context.Context) error {
 		event, err := consumer.ParseOrderEvent(value)
 		if err != nil {
 			logrus.Errorf("BookingConsumer: failed to parse event: %v", err)
-			return nil
+			return retry.Permanent(err)
 		}
 
 		switch event.Type {
 
 		case consumer.BookingCreated:
-			err = c.service.HandleCreatedBooking(
-				ctx,
-				event.Payload.BookingID,
-				event.Payload.UserID,
-				event.Payload.PlaceID,
-				event.Payload.PlaceLabel,
-				event.Payload.StartTime,
-				event.Payload.EndTime,
-			)
-			if err != nil {
-				logrus.Errorf("BookingConsumer: HandleCreatedBooking failed: %v", err)
-			}
+			return c.inbox.Process(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+				err := c.service.HandleCreatedBooking(
+					ctx,
+					event.Payload.BookingID,
+					event.Payload.UserID,
+					event.Payload.PlaceID,
+					event.Payload.PlaceLabel,
+					event.Payload.StartTime,
+					event.Payload.EndTime,
+				)
+				if err != nil {
+					logrus.Errorf("BookingConsumer: HandleCreatedBooking failed: %v", err)
+				}
+				return err
+			})
 
 		case consumer.BookingCancelled:
-			err = c.service.HandleCancelledBooking(
-				ctx,
-				event.Payload.BookingID,
-			)
-			if err != nil {
-				logrus.Errorf("BookingConsumer: HandleCancelledBooking failed: %v", err)
-			}
+			return c.inbox.Process(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+				err := c.service.HandleCancelledBooking(
+					ctx,
+					event.Payload.BookingID,
+				)
+				if err != nil {
+					logrus.Errorf("BookingConsumer: HandleCancelledBooking failed: %v", err)
+				}
+				return err
+			})
 
 		default:
 			logrus.Errorf("BookingConsumer: unknown event type %s", event.Type)
 			return nil
 		}
-
-		return err
 	})
 }
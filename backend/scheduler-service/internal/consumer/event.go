@@ -1,6 +1,7 @@
 package consumer
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,10 +13,18 @@ type EventType string
 const (
 	BookingCreated   EventType = "booking.created"
 	BookingCancelled EventType = "booking.cancelled"
+
+	TimerRequested EventType = "scheduler.timer.requested"
+	TimerCancel    EventType = "scheduler.timer.cancel"
+
+	// auth.events
+	UserDeleted EventType = "auth.user.deleted"
 )
 
 // Тип для обработки входящего события
 type IncomingEvent struct {
+	// Идентификатор из kafka.Envelope, ключ дедупликации
+	EventID    uuid.UUID
 	Type       EventType
 	OccurredAt time.Time
 	Payload    Payload
@@ -31,4 +40,26 @@ type Payload struct {
 	StartTime  time.Time `json:"startTime,omitempty"`
 	EndTime    time.Time `json:"endTime,omitempty"`
 	Reason     string    `json:"reason,omitempty"`
+
+	// Только у auth.user.deleted
+	AnonymousID uuid.UUID `json:"anonymousId,omitempty"`
+}
+
+// Запрос универсального таймера от любого сервиса
+type IncomingTimerEvent struct {
+	// Идентификатор из kafka.Envelope, ключ дедупликации
+	EventID    uuid.UUID
+	Type       EventType
+	OccurredAt time.Time
+	Payload    TimerPayload
+}
+
+// Key — ключ идемпотентности, по нему же таймер отменяется.
+// Для scheduler.timer.cancel заполняется только Key.
+type TimerPayload struct {
+	Key       string          `json:"key"`
+	Topic     string          `json:"topic,omitempty"`
+	EventType string          `json:"eventType,omitempty"`
+	TriggerAt time.Time       `json:"triggerAt,omitempty"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/4udiwe/big-bob-pizza/order-service/pkg/kafka"
+	"github.com/4udiwe/coworking/auth-service/pkg/eventid"
 )
 
 var ErrUnknownEventType = errors.New("unknown event.type")
@@ -30,6 +31,32 @@ func ParseOrderEvent(data []byte) (*IncomingEvent, error) {
 	}
 
 	return &IncomingEvent{
+		EventID:    eventid.FromEnvelope(env),
+		Type:       EventType(env.EventType),
+		OccurredAt: env.OccurredAt,
+		Payload:    p,
+	}, nil
+}
+
+func ParseTimerEvent(data []byte) (*IncomingTimerEvent, error) {
+	var env kafka.Envelope
+
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("invalid envelope: %w", err)
+	}
+
+	var p TimerPayload
+
+	if env.Data == nil {
+		return nil, fmt.Errorf("invalid payload for %s: empty data", env.EventType)
+	}
+
+	if err := json.Unmarshal(env.Data, &p); err != nil {
+		return nil, fmt.Errorf("invalid payload for %s: %w", env.EventType, err)
+	}
+
+	return &IncomingTimerEvent{
+		EventID:    eventid.FromEnvelope(env),
 		Type:       EventType(env.EventType),
 		OccurredAt: env.OccurredAt,
 		Payload:    p,
@@ -0,0 +1,88 @@
+package consumer_timer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/consumer"
+	scheduler_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/scheduler"
+	"github.com/4udiwe/coworking/auth-service/pkg/inbox"
+	"github.com/4udiwe/coworking/auth-service/pkg/retry"
+)
+
+// Обработчик запросов универсальных таймеров от других сервисов
+type Consumer struct {
+	service  *scheduler_service.SchedulerService
+	inbox    *inbox.Inbox
+	consumer *retry.Consumer
+	topic    string
+	groupID  string
+}
+
+func New(
+	service *scheduler_service.SchedulerService,
+	inbox *inbox.Inbox,
+	consumer *retry.Consumer,
+	topic string,
+	groupID string,
+) *Consumer {
+	return &Consumer{
+		service:  service,
+		inbox:    inbox,
+		consumer: consumer,
+		topic:    topic,
+		groupID:  groupID,
+	}
+}
+
+func (c *Consumer) Run(ctx context.Context) error {
+	logrus.Infof("TimerConsumer: subscribing to topic=%s group=%s", c.topic, c.groupID)
+
+	return c.consumer.Subscribe(ctx, c.topic, c.groupID, func(ctx context.Context, key, value []byte) error {
+		event, err := consumer.ParseTimerEvent(value)
+		if err != nil {
+			logrus.Errorf("TimerConsumer: failed to parse event: %v", err)
+			return retry.Permanent(err)
+		}
+
+		if event.Type != consumer.TimerRequested && event.Type != consumer.TimerCancel {
+			logrus.Errorf("TimerConsumer: unknown event type %s", event.Type)
+			return nil
+		}
+
+		return c.inbox.Process(ctx, c.groupID, event.EventID, func(ctx context.Context) error {
+			var err error
+
+			switch event.Type {
+
+			case consumer.TimerRequested:
+				err = c.service.ScheduleTimer(
+					ctx,
+					event.Payload.Key,
+					event.Payload.Topic,
+					event.Payload.EventType,
+					event.Payload.TriggerAt,
+					event.Payload.Payload,
+				)
+
+			case consumer.TimerCancel:
+				err = c.service.CancelTimer(ctx, event.Payload.Key)
+			}
+
+			// Невалидный запрос повторять бессмысленно
+			if errors.Is(err, scheduler_service.ErrInvalidTimer) ||
+				errors.Is(err, scheduler_service.ErrTopicNotAllowed) {
+				logrus.WithField("key", event.Payload.Key).Errorf("TimerConsumer: request rejected: %v", err)
+				return retry.Permanent(err)
+			}
+
+			if err != nil {
+				logrus.WithField("key", event.Payload.Key).Errorf("TimerConsumer: %s failed: %v", event.Type, err)
+			}
+
+			return err
+		})
+	})
+}
@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ==============================
+-- CRON JOBS
+-- ==============================
+-- Реестр периодических задач. Определения приходят из конфига (cron.jobs)
+-- и обновляются при старте; enabled меняется только через admin API,
+-- поэтому пауза переживает перезапуск.
+
+CREATE TABLE cron_job (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    name VARCHAR(128) NOT NULL UNIQUE,
+    schedule VARCHAR(128) NOT NULL,
+
+    topic VARCHAR(128) NOT NULL,
+    event_type VARCHAR(128) NOT NULL,
+    payload_template TEXT NOT NULL DEFAULT '{}',
+
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+
+    next_run_at TIMESTAMPTZ NOT NULL,
+    last_run_at TIMESTAMPTZ NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_cron_job_due
+    ON cron_job (next_run_at)
+    WHERE enabled;
+
+-- ==============================
+-- CRON JOB RUNS
+-- ==============================
+
+CREATE TABLE cron_job_run (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    job_id UUID NOT NULL REFERENCES cron_job(id) ON DELETE CASCADE,
+
+    trigger VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    error TEXT NULL,
+
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_cron_job_run_job
+    ON cron_job_run (job_id, started_at DESC);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS cron_job_run CASCADE;
+DROP TABLE IF EXISTS cron_job CASCADE;
+
+-- +goose StatementEnd
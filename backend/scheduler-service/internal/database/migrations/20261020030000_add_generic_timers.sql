@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Универсальные таймеры: любой сервис присылает топик, тип события и payload,
+-- scheduler публикует их как есть в момент trigger_at.
+INSERT INTO timer_type (id, name) VALUES
+(3, 'generic');
+
+ALTER TABLE timer
+ALTER COLUMN booking_id DROP NOT NULL;
+ALTER TABLE timer
+ADD COLUMN correlation_key VARCHAR(255);
+ALTER TABLE timer
+ADD COLUMN target_topic VARCHAR(128);
+ALTER TABLE timer
+ADD COLUMN event_type VARCHAR(128);
+
+-- Ключ уникален среди всех таймеров, в том числе сработавших и отменённых:
+-- повторная доставка запроса не создаст таймер заново.
+CREATE UNIQUE INDEX uq_timer_correlation_key
+    ON timer (correlation_key)
+    WHERE correlation_key IS NOT NULL;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM timer WHERE timer_type_id = 3;
+
+DROP INDEX IF EXISTS uq_timer_correlation_key;
+
+ALTER TABLE timer DROP COLUMN correlation_key;
+ALTER TABLE timer DROP COLUMN target_topic;
+ALTER TABLE timer DROP COLUMN event_type;
+ALTER TABLE timer
+ALTER COLUMN booking_id SET NOT NULL;
+
+DELETE FROM timer_type WHERE id = 3;
+
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+
+INSERT INTO timer_type (id, name) VALUES
+(4, 'booking_end_reminder');
+
+-- За сколько минут до начала / конца бронирования сработал напоминатель
+ALTER TABLE timer
+ADD COLUMN offset_minutes INTEGER;
+
+CREATE INDEX idx_timers_user_pending
+    ON timer (user_id, status_id);
+
+-- ==============================
+-- REMINDER PREFERENCES
+-- ==============================
+-- Нет строки — действуют настройки по умолчанию (scheduler.remind_before).
+
+CREATE TABLE reminder_preference (
+    user_id UUID PRIMARY KEY,
+
+    start_offsets INTEGER[] NOT NULL DEFAULT '{}',
+    end_offset INTEGER NULL,
+
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS reminder_preference CASCADE;
+
+DROP INDEX IF EXISTS idx_timers_user_pending;
+
+DELETE FROM timer WHERE timer_type_id = 4;
+
+ALTER TABLE timer DROP COLUMN offset_minutes;
+
+DELETE FROM timer_type WHERE id = 4;
+
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ==============================
+-- INBOX
+-- ==============================
+
+-- Обработанные события Kafka (inbox.Inbox): повторная доставка
+-- события с тем же eventId пропускается
+CREATE TABLE processed_event (
+    consumer_group VARCHAR(128) NOT NULL,
+    event_id UUID NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (consumer_group, event_id)
+);
+
+CREATE INDEX idx_processed_event_processed_at
+    ON processed_event (processed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS processed_event;
+-- +goose StatementEnd
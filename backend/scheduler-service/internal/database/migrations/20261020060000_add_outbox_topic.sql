@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Топик события; NULL — основной топик сервиса (scheduler.events).
+-- Задачи cron и универсальные таймеры публикуются в свой топик отдельными
+-- воркерами, тип события — как есть, без префикса aggregate_type.
+ALTER TABLE outbox
+    ADD COLUMN topic VARCHAR(128) NULL;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE outbox DROP COLUMN IF EXISTS topic;
+
+-- +goose StatementEnd
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CronRunTrigger string
+
+const (
+	CronRunTriggerSchedule CronRunTrigger = "schedule"
+	CronRunTriggerManual   CronRunTrigger = "manual"
+)
+
+type CronRunStatus string
+
+const (
+	CronRunStatusSucceeded CronRunStatus = "succeeded"
+	CronRunStatusFailed    CronRunStatus = "failed"
+)
+
+// CronJob — периодическая задача: по расписанию публикует событие EventType
+// в Topic с payload, собранным из PayloadTemplate.
+type CronJob struct {
+	ID uuid.UUID
+
+	Name     string
+	Schedule string // cron-выражение (5 полей) или дескриптор: @daily, @every 12h
+
+	Topic           string
+	EventType       string
+	PayloadTemplate string // text/template, результат должен быть JSON
+
+	Enabled bool
+
+	NextRunAt time.Time
+	LastRunAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CronJobRun — запись истории запусков задачи.
+type CronJobRun struct {
+	ID    uuid.UUID
+	JobID uuid.UUID
+
+	Trigger CronRunTrigger
+	Status  CronRunStatus
+	Error   *string
+
+	ScheduledAt time.Time
+	StartedAt   time.Time
+	FinishedAt  time.Time
+}
@@ -28,4 +28,8 @@ type OutboxEvent struct {
 	Status        OutboxStatus
 	CreatedAt     time.Time
 	ProcessedAt   *time.Time
+
+	// Пустой — основной топик сервиса (outbox.topic в конфиге).
+	// Событие с явным топиком публикуется с EventType как есть.
+	Topic string
 }
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReminderPreferences — когда напоминать пользователю о бронированиях.
+// StartOffsets — за сколько минут до начала (пустой список — без напоминаний),
+// EndOffset — за сколько минут до конца (nil — не напоминать).
+type ReminderPreferences struct {
+	UserID uuid.UUID
+
+	StartOffsets []int
+	EndOffset    *int
+
+	UpdatedAt time.Time
+}
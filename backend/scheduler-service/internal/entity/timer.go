@@ -9,15 +9,19 @@ import (
 type TimerID int16
 
 const (
-	TimerTypeBookingReminderID TimerID = 1
-	TimerTypeBookingExpireID   TimerID = 2
+	TimerTypeBookingReminderID    TimerID = 1
+	TimerTypeBookingExpireID      TimerID = 2
+	TimerTypeGenericID            TimerID = 3
+	TimerTypeBookingEndReminderID TimerID = 4
 )
 
 type TimerName string
 
 const (
-	TimerTypeBookingReminderName TimerName = "booking_reminder"
-	TimerTypeBoookingExpireName  TimerName = "booking_expire"
+	TimerTypeBookingReminderName    TimerName = "booking_reminder"
+	TimerTypeBoookingExpireName     TimerName = "booking_expire"
+	TimerTypeGenericName            TimerName = "generic"
+	TimerTypeBookingEndReminderName TimerName = "booking_end_reminder"
 )
 
 type TimerStatus string
@@ -45,6 +49,15 @@ type Timer struct {
 	StartTime  *time.Time
 	EndTime    *time.Time
 
+	// За сколько минут до начала (конца) бронирования срабатывает напоминание
+	OffsetMinutes *int
+
+	// Поля универсального таймера (TimerTypeGenericID): по ключу запрос
+	// идемпотентен и по нему же таймер отменяется.
+	CorrelationKey *string
+	TargetTopic    *string
+	EventType      *string
+
 	TriggerAt time.Time
 
 	Payload []byte
@@ -55,3 +68,20 @@ type Timer struct {
 	TriggeredAt *time.Time
 	CancelledAt *time.Time
 }
+
+// TimerFilter — параметры поиска таймеров в admin API. nil — без фильтра.
+type TimerFilter struct {
+	BookingID *uuid.UUID
+	UserID    *uuid.UUID
+	Status    *TimerStatus
+	Type      *TimerName
+}
+
+// TimerPendingStats — срез ожидающих таймеров одного типа для метрик.
+// Overdue — время срабатывания уже прошло, но воркер их ещё не обработал.
+type TimerPendingStats struct {
+	Type            TimerName
+	Pending         int
+	Overdue         int
+	OldestOverdueAt *time.Time
+}
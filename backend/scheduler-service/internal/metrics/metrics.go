@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+// Время на запрос статистики из БД при сборе метрик
+const statsTimeout = 5 * time.Second
+
+type StatsProvider interface {
+	PendingStats(ctx context.Context) ([]entity.TimerPendingStats, error)
+}
+
+/*
+Metrics — метрики планировщика в формате Prometheus.
+
+Счётчики срабатываний обновляет воркер после коммита батча, поэтому
+rate(scheduler_timers_triggered_total[1m]) * 60 — срабатывания в минуту.
+Показатели отставания (ожидающие, просроченные, возраст самого старого
+просроченного таймера) считаются запросом к БД в момент сбора и одинаковы
+для всех реплик.
+*/
+type Metrics struct {
+	registry *prometheus.Registry
+
+	triggered     *prometheus.CounterVec
+	publishErrors *prometheus.CounterVec
+}
+
+func New(stats StatsProvider) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		triggered: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "scheduler_timers_triggered_total",
+			Help: "Number of timers triggered by the scheduler worker.",
+		}, []string{"type"}),
+		publishErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "scheduler_timer_publish_errors_total",
+			Help: "Number of generic timers that could not be written to the outbox.",
+		}, []string{"type"}),
+	}
+
+	m.registry.MustRegister(
+		m.triggered,
+		m.publishErrors,
+		newLagCollector(stats),
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+func (m *Metrics) TimerTriggered(timerType entity.TimerName) {
+	m.triggered.WithLabelValues(string(timerType)).Inc()
+}
+
+func (m *Metrics) TimerPublishFailed(timerType entity.TimerName) {
+	m.publishErrors.WithLabelValues(string(timerType)).Inc()
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+type lagCollector struct {
+	stats StatsProvider
+
+	pending       *prometheus.Desc
+	overdue       *prometheus.Desc
+	oldestOverdue *prometheus.Desc
+}
+
+func newLagCollector(stats StatsProvider) *lagCollector {
+	return &lagCollector{
+		stats: stats,
+		pending: prometheus.NewDesc(
+			"scheduler_timers_pending",
+			"Number of pending timers.",
+			[]string{"type"}, nil,
+		),
+		overdue: prometheus.NewDesc(
+			"scheduler_timers_overdue",
+			"Number of pending timers whose trigger time has passed.",
+			[]string{"type"}, nil,
+		),
+		oldestOverdue: prometheus.NewDesc(
+			"scheduler_timer_oldest_overdue_seconds",
+			"Age of the oldest overdue pending timer, 0 if none.",
+			[]string{"type"}, nil,
+		),
+	}
+}
+
+func (c *lagCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.pending
+	ch <- c.overdue
+	ch <- c.oldestOverdue
+}
+
+// Collect при ошибке БД не отдаёт показатели отставания: пропавшая серия
+// лучше нулей, которые выглядят как «очередь пуста».
+func (c *lagCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), statsTimeout)
+	defer cancel()
+
+	stats, err := c.stats.PendingStats(ctx)
+	if err != nil {
+		logrus.WithError(err).Warn("metrics: failed to collect timer stats")
+		return
+	}
+
+	now := time.Now()
+
+	for _, s := range stats {
+		var oldest float64
+		if s.OldestOverdueAt != nil {
+			oldest = now.Sub(*s.OldestOverdueAt).Seconds()
+		}
+
+		label := string(s.Type)
+		ch <- prometheus.MustNewConstMetric(c.pending, prometheus.GaugeValue, float64(s.Pending), label)
+		ch <- prometheus.MustNewConstMetric(c.overdue, prometheus.GaugeValue, float64(s.Overdue), label)
+		ch <- prometheus.MustNewConstMetric(c.oldestOverdue, prometheus.GaugeValue, oldest, label)
+	}
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type fakeStats struct {
+	stats []entity.TimerPendingStats
+	err   error
+}
+
+func (f fakeStats) PendingStats(context.Context) ([]entity.TimerPendingStats, error) {
+	return f.stats, f.err
+}
+
+func TestLagCollector(t *testing.T) {
+	oldest := time.Now().Add(-90 * time.Second)
+
+	c := newLagCollector(fakeStats{stats: []entity.TimerPendingStats{
+		{Type: entity.TimerTypeGenericName, Pending: 5, Overdue: 2, OldestOverdueAt: &oldest},
+		{Type: entity.TimerTypeBookingReminderName, Pending: 3},
+	}})
+
+	want := `
+		# HELP scheduler_timers_overdue Number of pending timers whose trigger time has passed.
+		# TYPE scheduler_timers_overdue gauge
+		scheduler_timers_overdue{type="booking_reminder"} 0
+		scheduler_timers_overdue{type="generic"} 2
+		# HELP scheduler_timers_pending Number of pending timers.
+		# TYPE scheduler_timers_pending gauge
+		scheduler_timers_pending{type="booking_reminder"} 3
+		scheduler_timers_pending{type="generic"} 5
+	`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(want),
+		"scheduler_timers_pending", "scheduler_timers_overdue"); err != nil {
+		t.Error(err)
+	}
+
+	// Возраст считается от времени сбора: без просроченных — 0
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(c)
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ages := map[string]float64{}
+	for _, mf := range families {
+		if mf.GetName() != "scheduler_timer_oldest_overdue_seconds" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			ages[m.GetLabel()[0].GetValue()] = m.GetGauge().GetValue()
+		}
+	}
+
+	if age := ages["generic"]; age < 90 || age > 100 {
+		t.Errorf("generic oldest overdue = %v, want ~90s", age)
+	}
+	if age, ok := ages["booking_reminder"]; !ok || age != 0 {
+		t.Errorf("booking_reminder oldest overdue = %v (present %v), want 0", age, ok)
+	}
+}
+
+func TestLagCollector_StatsError(t *testing.T) {
+	c := newLagCollector(fakeStats{err: errors.New("db is down")})
+
+	// При ошибке БД серии пропадают, а не обнуляются
+	if n := testutil.CollectAndCount(c); n != 0 {
+		t.Errorf("collected %d metrics, want 0", n)
+	}
+}
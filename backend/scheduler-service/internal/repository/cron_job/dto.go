@@ -0,0 +1,69 @@
+package cron_job_repository
+
+import (
+	"time"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type rawCronJob struct {
+	ID uuid.UUID `db:"id"`
+
+	Name     string `db:"name"`
+	Schedule string `db:"schedule"`
+
+	Topic           string `db:"topic"`
+	EventType       string `db:"event_type"`
+	PayloadTemplate string `db:"payload_template"`
+
+	Enabled bool `db:"enabled"`
+
+	NextRunAt time.Time  `db:"next_run_at"`
+	LastRunAt *time.Time `db:"last_run_at"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (r rawCronJob) toEntity() entity.CronJob {
+	return entity.CronJob{
+		ID:              r.ID,
+		Name:            r.Name,
+		Schedule:        r.Schedule,
+		Topic:           r.Topic,
+		EventType:       r.EventType,
+		PayloadTemplate: r.PayloadTemplate,
+		Enabled:         r.Enabled,
+		NextRunAt:       r.NextRunAt,
+		LastRunAt:       r.LastRunAt,
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+	}
+}
+
+type rawCronJobRun struct {
+	ID    uuid.UUID `db:"id"`
+	JobID uuid.UUID `db:"job_id"`
+
+	Trigger string  `db:"trigger"`
+	Status  string  `db:"status"`
+	Error   *string `db:"error"`
+
+	ScheduledAt time.Time `db:"scheduled_at"`
+	StartedAt   time.Time `db:"started_at"`
+	FinishedAt  time.Time `db:"finished_at"`
+}
+
+func (r rawCronJobRun) toEntity() entity.CronJobRun {
+	return entity.CronJobRun{
+		ID:          r.ID,
+		JobID:       r.JobID,
+		Trigger:     entity.CronRunTrigger(r.Trigger),
+		Status:      entity.CronRunStatus(r.Status),
+		Error:       r.Error,
+		ScheduledAt: r.ScheduledAt,
+		StartedAt:   r.StartedAt,
+		FinishedAt:  r.FinishedAt,
+	}
+}
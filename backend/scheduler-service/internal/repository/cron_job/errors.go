@@ -0,0 +1,5 @@
+package cron_job_repository
+
+import "errors"
+
+var ErrJobNotFound = errors.New("cron job not found")
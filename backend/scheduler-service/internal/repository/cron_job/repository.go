@@ -0,0 +1,278 @@
+package cron_job_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+type CronJobRepository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *CronJobRepository {
+	return &CronJobRepository{
+		Postgres: pg,
+	}
+}
+
+// Upsert создаёт задачу или обновляет определение существующей (по name).
+// enabled берётся из job только при создании: пауза из admin API сохраняется.
+// next_run_at пересчитывается, только если изменилось расписание.
+func (r *CronJobRepository) Upsert(
+	ctx context.Context,
+	job entity.CronJob,
+) (entity.CronJob, error) {
+
+	query := `
+		INSERT INTO cron_job (name, schedule, topic, event_type, payload_template, enabled, next_run_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (name) DO UPDATE SET
+			schedule = EXCLUDED.schedule,
+			topic = EXCLUDED.topic,
+			event_type = EXCLUDED.event_type,
+			payload_template = EXCLUDED.payload_template,
+			next_run_at = CASE
+				WHEN cron_job.schedule <> EXCLUDED.schedule THEN EXCLUDED.next_run_at
+				ELSE cron_job.next_run_at
+			END,
+			updated_at = NOW()
+		RETURNING *
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query,
+		job.Name,
+		job.Schedule,
+		job.Topic,
+		job.EventType,
+		job.PayloadTemplate,
+		job.Enabled,
+		job.NextRunAt,
+	)
+	if err != nil {
+		logrus.WithField("job", job.Name).WithError(err).Error("failed to upsert cron job")
+		return entity.CronJob{}, err
+	}
+
+	raw, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[rawCronJob])
+	if err != nil {
+		logrus.WithField("job", job.Name).WithError(err).Error("failed to collect cron job")
+		return entity.CronJob{}, err
+	}
+
+	return raw.toEntity(), nil
+}
+
+func (r *CronJobRepository) List(ctx context.Context) ([]entity.CronJob, error) {
+
+	query := `SELECT * FROM cron_job ORDER BY name`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query)
+	if err != nil {
+		logrus.WithError(err).Error("failed to list cron jobs")
+		return nil, err
+	}
+
+	rawJobs, err := pgx.CollectRows(rows, pgx.RowToStructByName[rawCronJob])
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect cron jobs")
+		return nil, err
+	}
+
+	return lo.Map(rawJobs, func(r rawCronJob, _ int) entity.CronJob {
+		return r.toEntity()
+	}), nil
+}
+
+// GetForUpdate блокирует задачу до конца транзакции: ручной запуск
+// не пересечётся с запуском по расписанию на другой реплике.
+func (r *CronJobRepository) GetForUpdate(
+	ctx context.Context,
+	id uuid.UUID,
+) (entity.CronJob, error) {
+
+	query := `SELECT * FROM cron_job WHERE id = $1 FOR UPDATE`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, id)
+	if err != nil {
+		logrus.WithField("job_id", id.String()).WithError(err).Error("failed to get cron job")
+		return entity.CronJob{}, err
+	}
+
+	raw, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[rawCronJob])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.CronJob{}, ErrJobNotFound
+		}
+		return entity.CronJob{}, err
+	}
+
+	return raw.toEntity(), nil
+}
+
+// FindDueJobs выбирает включённые задачи, время которых пришло.
+// SKIP LOCKED, как в FindDueTimers: несколько реплик не запустят одну задачу дважды.
+func (r *CronJobRepository) FindDueJobs(
+	ctx context.Context,
+	limit int,
+) ([]entity.CronJob, error) {
+
+	query := `
+		SELECT *
+		FROM cron_job
+		WHERE enabled
+		AND next_run_at <= NOW()
+		ORDER BY next_run_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, limit)
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch due cron jobs")
+		return nil, err
+	}
+
+	rawJobs, err := pgx.CollectRows(rows, pgx.RowToStructByName[rawCronJob])
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect due cron jobs")
+		return nil, err
+	}
+
+	return lo.Map(rawJobs, func(r rawCronJob, _ int) entity.CronJob {
+		return r.toEntity()
+	}), nil
+}
+
+func (r *CronJobRepository) SetEnabled(
+	ctx context.Context,
+	id uuid.UUID,
+	enabled bool,
+	nextRunAt time.Time,
+) error {
+
+	query, args, _ := r.Builder.
+		Update("cron_job").
+		Set("enabled", enabled).
+		Set("next_run_at", nextRunAt).
+		Set("updated_at", time.Now()).
+		Where("id = ?", id).
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithField("job_id", id.String()).WithError(err).Error("failed to update cron job")
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ErrJobNotFound
+	}
+
+	return nil
+}
+
+// MarkRun фиксирует запуск по расписанию и переносит задачу на следующее время.
+func (r *CronJobRepository) MarkRun(
+	ctx context.Context,
+	id uuid.UUID,
+	lastRunAt time.Time,
+	nextRunAt time.Time,
+) error {
+
+	query, args, _ := r.Builder.
+		Update("cron_job").
+		Set("last_run_at", lastRunAt).
+		Set("next_run_at", nextRunAt).
+		Where("id = ?", id).
+		ToSql()
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithField("job_id", id.String()).WithError(err).Error("failed to mark cron job run")
+		return err
+	}
+
+	return nil
+}
+
+func (r *CronJobRepository) CreateRun(
+	ctx context.Context,
+	run entity.CronJobRun,
+) (uuid.UUID, error) {
+
+	query, args, _ := r.Builder.
+		Insert("cron_job_run").
+		Columns(
+			"job_id",
+			"trigger",
+			"status",
+			"error",
+			"scheduled_at",
+			"started_at",
+			"finished_at",
+		).
+		Values(
+			run.JobID,
+			run.Trigger,
+			run.Status,
+			run.Error,
+			run.ScheduledAt,
+			run.StartedAt,
+			run.FinishedAt,
+		).
+		Suffix("RETURNING id").
+		ToSql()
+
+	var id uuid.UUID
+
+	err := r.GetTxManager(ctx).
+		QueryRow(ctx, query, args...).
+		Scan(&id)
+
+	if err != nil {
+		logrus.WithField("job_id", run.JobID.String()).WithError(err).Error("failed to create cron job run")
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+// ListRuns возвращает последние запуски задачи, новые первыми.
+func (r *CronJobRepository) ListRuns(
+	ctx context.Context,
+	jobID uuid.UUID,
+	limit int,
+) ([]entity.CronJobRun, error) {
+
+	query := `
+		SELECT *
+		FROM cron_job_run
+		WHERE job_id = $1
+		ORDER BY started_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, jobID, limit)
+	if err != nil {
+		logrus.WithField("job_id", jobID.String()).WithError(err).Error("failed to list cron job runs")
+		return nil, err
+	}
+
+	rawRuns, err := pgx.CollectRows(rows, pgx.RowToStructByName[rawCronJobRun])
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect cron job runs")
+		return nil, err
+	}
+
+	return lo.Map(rawRuns, func(r rawCronJobRun, _ int) entity.CronJobRun {
+		return r.toEntity()
+	}), nil
+}
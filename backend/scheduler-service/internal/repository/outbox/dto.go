@@ -2,11 +2,14 @@ package outbox_repository
 
 import (
 	"encoding/json"
+	"maps"
 	"time"
 
 	"github.com/4udiwe/big-bob-pizza/order-service/pkg/outbox"
 	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/4udiwe/coworking/auth-service/pkg/eventid"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 )
 
 type RowOutbox struct {
@@ -19,6 +22,7 @@ type RowOutbox struct {
 	StatusName    string         `db:"status_name"`
 	CreatedAt     time.Time      `db:"created_at"`
 	ProcessedAt   *time.Time     `db:"processed_at"`
+	Topic         *string        `db:"topic"`
 }
 
 func (r RowOutbox) ToEntity() entity.OutboxEvent {
@@ -31,17 +35,31 @@ func (r RowOutbox) ToEntity() entity.OutboxEvent {
 		Status:        entity.OutboxStatus{ID: r.StatusID, Name: entity.OutboxStatusName(r.StatusName)},
 		CreatedAt:     r.CreatedAt,
 		ProcessedAt:   r.ProcessedAt,
+		Topic:         lo.FromPtr(r.Topic),
 	}
 }
 
 func (r RowOutbox) ToEvent() outbox.Event {
-	payloadBytes, err := json.Marshal(r.Payload)
+	// ID строки — стабильный eventId: по нему потребители отбрасывают
+	// повторные публикации той же записи
+	payload := make(map[string]any, len(r.Payload)+1)
+	maps.Copy(payload, r.Payload)
+	payload[eventid.Field] = r.ID
+
+	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
 		payloadBytes = []byte("{}")
 	}
+	// Событие для чужого топика (cron, универсальный таймер) несёт тип,
+	// который ждут его потребители
+	eventType := r.AggregateType + "." + r.EventType
+	if r.Topic != nil {
+		eventType = r.EventType
+	}
+
 	return outbox.Event{
 		ID:        r.ID,
-		EventType: r.AggregateType + "." + r.EventType,
+		EventType: eventType,
 		Payload:   payloadBytes,
 	}
 }
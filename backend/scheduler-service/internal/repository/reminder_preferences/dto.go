@@ -0,0 +1,36 @@
+package reminder_preferences_repository
+
+import (
+	"time"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type rawPreferences struct {
+	UserID uuid.UUID `db:"user_id"`
+
+	StartOffsets []int32 `db:"start_offsets"`
+	EndOffset    *int32  `db:"end_offset"`
+
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (r rawPreferences) toEntity() entity.ReminderPreferences {
+	prefs := entity.ReminderPreferences{
+		UserID:       r.UserID,
+		StartOffsets: make([]int, 0, len(r.StartOffsets)),
+		UpdatedAt:    r.UpdatedAt,
+	}
+
+	for _, offset := range r.StartOffsets {
+		prefs.StartOffsets = append(prefs.StartOffsets, int(offset))
+	}
+
+	if r.EndOffset != nil {
+		endOffset := int(*r.EndOffset)
+		prefs.EndOffset = &endOffset
+	}
+
+	return prefs
+}
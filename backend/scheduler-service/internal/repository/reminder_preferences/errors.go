@@ -0,0 +1,5 @@
+package reminder_preferences_repository
+
+import "errors"
+
+var ErrPreferencesNotFound = errors.New("reminder preferences not found")
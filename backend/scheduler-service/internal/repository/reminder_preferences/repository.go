@@ -0,0 +1,96 @@
+package reminder_preferences_repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4udiwe/avito-pvz/pkg/postgres"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type ReminderPreferencesRepository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *ReminderPreferencesRepository {
+	return &ReminderPreferencesRepository{
+		Postgres: pg,
+	}
+}
+
+func (r *ReminderPreferencesRepository) Get(
+	ctx context.Context,
+	userID uuid.UUID,
+) (entity.ReminderPreferences, error) {
+
+	query := `SELECT * FROM reminder_preference WHERE user_id = $1`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, userID)
+	if err != nil {
+		logrus.WithField("user_id", userID.String()).WithError(err).Error("failed to get reminder preferences")
+		return entity.ReminderPreferences{}, err
+	}
+
+	raw, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[rawPreferences])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ReminderPreferences{}, ErrPreferencesNotFound
+		}
+		logrus.WithField("user_id", userID.String()).WithError(err).Error("failed to collect reminder preferences")
+		return entity.ReminderPreferences{}, err
+	}
+
+	return raw.toEntity(), nil
+}
+
+func (r *ReminderPreferencesRepository) Upsert(
+	ctx context.Context,
+	prefs entity.ReminderPreferences,
+) (entity.ReminderPreferences, error) {
+
+	query := `
+		INSERT INTO reminder_preference (user_id, start_offsets, end_offset)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+			start_offsets = EXCLUDED.start_offsets,
+			end_offset = EXCLUDED.end_offset,
+			updated_at = NOW()
+		RETURNING *
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query,
+		prefs.UserID,
+		prefs.StartOffsets,
+		prefs.EndOffset,
+	)
+	if err != nil {
+		logrus.WithField("user_id", prefs.UserID.String()).WithError(err).Error("failed to upsert reminder preferences")
+		return entity.ReminderPreferences{}, err
+	}
+
+	raw, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[rawPreferences])
+	if err != nil {
+		logrus.WithField("user_id", prefs.UserID.String()).WithError(err).Error("failed to collect reminder preferences")
+		return entity.ReminderPreferences{}, err
+	}
+
+	return raw.toEntity(), nil
+}
+
+func (r *ReminderPreferencesRepository) DeleteByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+) error {
+
+	query := `DELETE FROM reminder_preference WHERE user_id = $1`
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, userID); err != nil {
+		logrus.WithField("user_id", userID.String()).WithError(err).Error("failed to delete reminder preferences")
+		return err
+	}
+
+	return nil
+}
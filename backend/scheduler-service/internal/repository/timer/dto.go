@@ -5,6 +5,7 @@ import (
 
 	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 )
 
 type rawTimer struct {
@@ -12,7 +13,7 @@ type rawTimer struct {
 
 	TimerTypeID int16 `db:"timer_type_id"`
 
-	BookingID  uuid.UUID  `db:"booking_id"`
+	BookingID  *uuid.UUID `db:"booking_id"`
 	UserID     *uuid.UUID `db:"user_id"`
 	PlaceID    *uuid.UUID `db:"place_id"`
 	PlaceLabel *string    `db:"place_label"`
@@ -20,6 +21,12 @@ type rawTimer struct {
 	StartTime *time.Time `db:"start_time"`
 	EndTime   *time.Time `db:"end_time"`
 
+	OffsetMinutes *int `db:"offset_minutes"`
+
+	CorrelationKey *string `db:"correlation_key"`
+	TargetTopic    *string `db:"target_topic"`
+	EventType      *string `db:"event_type"`
+
 	TriggerAt time.Time `db:"trigger_at"`
 
 	Payload []byte `db:"payload"`
@@ -31,27 +38,68 @@ type rawTimer struct {
 	CancelledAt *time.Time `db:"cancelled_at"`
 }
 
+// Соответствуют справочникам timer_type и timer_status
+var (
+	timerTypeNames = map[entity.TimerID]entity.TimerName{
+		entity.TimerTypeBookingReminderID:    entity.TimerTypeBookingReminderName,
+		entity.TimerTypeBookingExpireID:      entity.TimerTypeBoookingExpireName,
+		entity.TimerTypeGenericID:            entity.TimerTypeGenericName,
+		entity.TimerTypeBookingEndReminderID: entity.TimerTypeBookingEndReminderName,
+	}
+
+	timerStatuses = map[int16]entity.TimerStatus{
+		1: entity.TimerStatusPending,
+		2: entity.TimerStatusTriggered,
+		3: entity.TimerStatusCancelled,
+	}
+)
+
 func (r rawTimer) toEntity() entity.Timer {
 
 	return entity.Timer{
 		ID: r.ID,
 
-		BookingID:  r.BookingID,
+		BookingID:  lo.FromPtr(r.BookingID),
 		UserID:     r.UserID,
 		PlaceID:    r.PlaceID,
 		PlaceLabel: r.PlaceLabel,
 		StartTime:  r.StartTime,
 		EndTime:    r.EndTime,
 
+		OffsetMinutes: r.OffsetMinutes,
+
+		CorrelationKey: r.CorrelationKey,
+		TargetTopic:    r.TargetTopic,
+		EventType:      r.EventType,
+
 		Type: entity.TimerType{
-			ID: entity.TimerID(r.TimerTypeID),
+			ID:   entity.TimerID(r.TimerTypeID),
+			Name: timerTypeNames[entity.TimerID(r.TimerTypeID)],
 		},
 
 		TriggerAt: r.TriggerAt,
 		Payload:   r.Payload,
 
+		Status: timerStatuses[r.StatusID],
+
 		CreatedAt:   r.CreatedAt,
 		TriggeredAt: r.TriggeredAt,
 		CancelledAt: r.CancelledAt,
 	}
 }
+
+type rawPendingStats struct {
+	TimerTypeID     int16      `db:"timer_type_id"`
+	Pending         int        `db:"pending"`
+	Overdue         int        `db:"overdue"`
+	OldestOverdueAt *time.Time `db:"oldest_overdue_at"`
+}
+
+func (r rawPendingStats) toEntity() entity.TimerPendingStats {
+	return entity.TimerPendingStats{
+		Type:            timerTypeNames[entity.TimerID(r.TimerTypeID)],
+		Pending:         r.Pending,
+		Overdue:         r.Overdue,
+		OldestOverdueAt: r.OldestOverdueAt,
+	}
+}
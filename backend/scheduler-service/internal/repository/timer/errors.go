@@ -0,0 +1,5 @@
+package timer_repository
+
+import "errors"
+
+var ErrTimerNotFound = errors.New("timer not found")
@@ -2,10 +2,12 @@ package timer_repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/4udiwe/avito-pvz/pkg/postgres"
 	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/samber/lo"
@@ -37,6 +39,7 @@ func (r *TimerRepository) Create(
 			"place_label",
 			"start_time",
 			"end_time",
+			"offset_minutes",
 			"trigger_at",
 			"payload",
 		).
@@ -48,6 +51,7 @@ func (r *TimerRepository) Create(
 			timer.PlaceLabel,
 			timer.StartTime,
 			timer.EndTime,
+			timer.OffsetMinutes,
 			timer.TriggerAt,
 			timer.Payload,
 		).
@@ -111,6 +115,163 @@ func (r *TimerRepository) CancelByBooking(
 	return nil
 }
 
+// FindPendingByUser возвращает ожидающие таймеры пользователя заданного типа.
+func (r *TimerRepository) FindPendingByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	timerType entity.TimerID,
+) ([]entity.Timer, error) {
+
+	query := `
+		SELECT *
+		FROM timer
+		WHERE user_id = $1
+		AND timer_type_id = $2
+		AND status_id = 1
+		ORDER BY trigger_at
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, userID, timerType)
+
+	if err != nil {
+		logrus.WithField("user_id", userID.String()).WithError(err).Error("failed to fetch user timers")
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rawTimers, err := pgx.CollectRows(rows, pgx.RowToStructByName[rawTimer])
+
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect timers")
+		return nil, err
+	}
+
+	return lo.Map(rawTimers, func(r rawTimer, _ int) entity.Timer {
+		return r.toEntity()
+	}), nil
+}
+
+// CancelRemindersByUser отменяет все ожидающие напоминания пользователя
+// (о начале и о конце бронирования).
+func (r *TimerRepository) CancelRemindersByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+) error {
+
+	query, args, _ := r.Builder.
+		Update("timer").
+		Set("status_id", 3). // cancelled
+		Set("cancelled_at", time.Now()).
+		Where("user_id = ?", userID).
+		Where("status_id = ?", 1).
+		Where(squirrel.Eq{"timer_type_id": []entity.TimerID{
+			entity.TimerTypeBookingReminderID,
+			entity.TimerTypeBookingEndReminderID,
+		}}).
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+
+	if err != nil {
+
+		logrus.WithField("user_id", userID.String()).
+			WithError(err).
+			Error("failed to cancel reminders")
+
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":  userID.String(),
+		"affected": cmd.RowsAffected(),
+	}).Info("reminders cancelled")
+
+	return nil
+}
+
+// UpsertByKey создаёт универсальный таймер. Если таймер с таким ключом ещё
+// не сработал, обновляет его (перенос времени, новый payload); сработавший
+// или отменённый таймер не трогается — повторный запрос игнорируется.
+// created = false, если таймер не создан и не обновлён.
+func (r *TimerRepository) UpsertByKey(
+	ctx context.Context,
+	timer entity.Timer,
+) (id uuid.UUID, created bool, err error) {
+
+	query := `
+		INSERT INTO timer (timer_type_id, correlation_key, target_topic, event_type, trigger_at, payload)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (correlation_key) WHERE correlation_key IS NOT NULL DO UPDATE SET
+			target_topic = EXCLUDED.target_topic,
+			event_type = EXCLUDED.event_type,
+			trigger_at = EXCLUDED.trigger_at,
+			payload = EXCLUDED.payload
+		WHERE timer.status_id = 1
+		RETURNING id
+	`
+
+	err = r.GetTxManager(ctx).
+		QueryRow(ctx, query,
+			entity.TimerTypeGenericID,
+			timer.CorrelationKey,
+			timer.TargetTopic,
+			timer.EventType,
+			timer.TriggerAt,
+			timer.Payload,
+		).
+		Scan(&id)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, false, nil
+		}
+
+		logrus.WithField("key", lo.FromPtr(timer.CorrelationKey)).
+			WithError(err).
+			Error("failed to upsert timer")
+
+		return uuid.Nil, false, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"timer_id":   id.String(),
+		"key":        lo.FromPtr(timer.CorrelationKey),
+		"trigger_at": timer.TriggerAt,
+	}).Info("generic timer scheduled")
+
+	return id, true, nil
+}
+
+// CancelByKey отменяет ожидающий таймер с ключом key. Возвращает false,
+// если такого таймера нет или он уже сработал.
+func (r *TimerRepository) CancelByKey(
+	ctx context.Context,
+	key string,
+) (bool, error) {
+
+	query, args, _ := r.Builder.
+		Update("timer").
+		Set("status_id", 3). // cancelled
+		Set("cancelled_at", time.Now()).
+		Where("correlation_key = ?", key).
+		Where("status_id = ?", 1).
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+
+	if err != nil {
+
+		logrus.WithField("key", key).
+			WithError(err).
+			Error("failed to cancel timer")
+
+		return false, err
+	}
+
+	return cmd.RowsAffected() > 0, nil
+}
+
 func (r *TimerRepository) FindDueTimers(
 	ctx context.Context,
 	limit int,
@@ -173,3 +334,215 @@ func (r *TimerRepository) MarkTriggered(
 
 	return nil
 }
+
+func (r *TimerRepository) GetByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (entity.Timer, error) {
+
+	query := `SELECT * FROM timer WHERE id = $1`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, id)
+	if err != nil {
+		logrus.WithField("timer_id", id.String()).WithError(err).Error("failed to get timer")
+		return entity.Timer{}, err
+	}
+
+	raw, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[rawTimer])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Timer{}, ErrTimerNotFound
+		}
+		logrus.WithField("timer_id", id.String()).WithError(err).Error("failed to collect timer")
+		return entity.Timer{}, err
+	}
+
+	return raw.toEntity(), nil
+}
+
+// Search ищет таймеры по фильтру, новые (по времени срабатывания) первыми.
+func (r *TimerRepository) Search(
+	ctx context.Context,
+	filter entity.TimerFilter,
+	limit, offset int,
+) ([]entity.Timer, error) {
+
+	q := r.Builder.
+		Select("*").
+		From("timer").
+		OrderBy("trigger_at DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	if filter.BookingID != nil {
+		q = q.Where("booking_id = ?", *filter.BookingID)
+	}
+	if filter.UserID != nil {
+		q = q.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.Status != nil {
+		q = q.Where("status_id = (SELECT id FROM timer_status WHERE name = ?)", *filter.Status)
+	}
+	if filter.Type != nil {
+		q = q.Where("timer_type_id = (SELECT id FROM timer_type WHERE name = ?)", *filter.Type)
+	}
+
+	query, args, _ := q.ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithError(err).Error("failed to search timers")
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rawTimers, err := pgx.CollectRows(rows, pgx.RowToStructByName[rawTimer])
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect timers")
+		return nil, err
+	}
+
+	return lo.Map(rawTimers, func(r rawTimer, _ int) entity.Timer {
+		return r.toEntity()
+	}), nil
+}
+
+// Retrigger возвращает таймер в pending со временем срабатывания NOW():
+// воркер опубликует его событие ещё раз на ближайшем тике.
+func (r *TimerRepository) Retrigger(
+	ctx context.Context,
+	id uuid.UUID,
+) (entity.Timer, error) {
+
+	query := `
+		UPDATE timer SET
+			status_id = 1,
+			trigger_at = NOW(),
+			triggered_at = NULL,
+			cancelled_at = NULL
+		WHERE id = $1
+		RETURNING *
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, id)
+	if err != nil {
+		logrus.WithField("timer_id", id.String()).WithError(err).Error("failed to retrigger timer")
+		return entity.Timer{}, err
+	}
+
+	raw, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[rawTimer])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Timer{}, ErrTimerNotFound
+		}
+		logrus.WithField("timer_id", id.String()).WithError(err).Error("failed to collect timer")
+		return entity.Timer{}, err
+	}
+
+	return raw.toEntity(), nil
+}
+
+// CancelByID отменяет ожидающий таймер. Возвращает false, если таймер
+// не в статусе pending.
+func (r *TimerRepository) CancelByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (bool, error) {
+
+	query, args, _ := r.Builder.
+		Update("timer").
+		Set("status_id", 3). // cancelled
+		Set("cancelled_at", time.Now()).
+		Where("id = ?", id).
+		Where("status_id = ?", 1).
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithField("timer_id", id.String()).WithError(err).Error("failed to cancel timer")
+		return false, err
+	}
+
+	return cmd.RowsAffected() > 0, nil
+}
+
+// DeletePendingByUser удаляет ещё не сработавшие таймеры пользователя
+// (напоминания и завершение бронирований).
+func (r *TimerRepository) DeletePendingByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+) error {
+
+	query, args, _ := r.Builder.
+		Delete("timer").
+		Where("user_id = ?", userID).
+		Where("status_id = ?", 1).
+		ToSql()
+
+	cmd, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithField("user_id", userID.String()).WithError(err).Error("failed to delete user timers")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":  userID.String(),
+		"affected": cmd.RowsAffected(),
+	}).Info("user timers deleted")
+
+	return nil
+}
+
+// AnonymizeUser заменяет user_id в истории таймеров на anonymousID.
+func (r *TimerRepository) AnonymizeUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	anonymousID uuid.UUID,
+) error {
+
+	query, args, _ := r.Builder.
+		Update("timer").
+		Set("user_id", anonymousID).
+		Where("user_id = ?", userID).
+		ToSql()
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.WithField("user_id", userID.String()).WithError(err).Error("failed to anonymize user timers")
+		return err
+	}
+
+	return nil
+}
+
+// PendingStats считает ожидающие и просроченные таймеры по типам.
+func (r *TimerRepository) PendingStats(ctx context.Context) ([]entity.TimerPendingStats, error) {
+
+	query := `
+		SELECT
+			timer_type_id,
+			COUNT(*) AS pending,
+			COUNT(*) FILTER (WHERE trigger_at <= NOW()) AS overdue,
+			MIN(trigger_at) FILTER (WHERE trigger_at <= NOW()) AS oldest_overdue_at
+		FROM timer
+		WHERE status_id = 1
+		GROUP BY timer_type_id
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query)
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch timer stats")
+		return nil, err
+	}
+
+	rawStats, err := pgx.CollectRows(rows, pgx.RowToStructByName[rawPendingStats])
+	if err != nil {
+		logrus.WithError(err).Error("failed to collect timer stats")
+		return nil, err
+	}
+
+	return lo.Map(rawStats, func(r rawPendingStats, _ int) entity.TimerPendingStats {
+		return r.toEntity()
+	}), nil
+}
@@ -0,0 +1,26 @@
+package cron_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
+type CronJobRepository interface {
+	Upsert(ctx context.Context, job entity.CronJob) (entity.CronJob, error)
+	List(ctx context.Context) ([]entity.CronJob, error)
+	GetForUpdate(ctx context.Context, id uuid.UUID) (entity.CronJob, error)
+	FindDueJobs(ctx context.Context, limit int) ([]entity.CronJob, error)
+	SetEnabled(ctx context.Context, id uuid.UUID, enabled bool, nextRunAt time.Time) error
+	MarkRun(ctx context.Context, id uuid.UUID, lastRunAt time.Time, nextRunAt time.Time) error
+	CreateRun(ctx context.Context, run entity.CronJobRun) (uuid.UUID, error)
+	ListRuns(ctx context.Context, jobID uuid.UUID, limit int) ([]entity.CronJobRun, error)
+}
+
+type OutboxRepository interface {
+	Create(ctx context.Context, ev entity.OutboxEvent) error
+}
@@ -0,0 +1,13 @@
+package cron_service
+
+import "errors"
+
+var (
+	ErrJobNotFound            = errors.New("cron job not found")
+	ErrInvalidSchedule        = errors.New("invalid cron schedule")
+	ErrInvalidPayloadTemplate = errors.New("invalid cron payload template")
+	ErrCannotRegisterJobs     = errors.New("cannot register cron jobs")
+	ErrCannotRunJob           = errors.New("cannot run cron job")
+	ErrCannotUpdateJob        = errors.New("cannot update cron job")
+	ErrCannotFetchJobs        = errors.New("cannot fetch cron jobs")
+)
@@ -0,0 +1,52 @@
+package cron_service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/robfig/cron/v3"
+)
+
+// PayloadData — данные, доступные в payload_template задачи:
+//
+//	{"retentionDays": 3, "firedAt": "{{ .ScheduledAt.Format \"2006-01-02T15:04:05Z07:00\" }}"}
+type PayloadData struct {
+	JobName     string
+	ScheduledAt time.Time
+	Manual      bool
+}
+
+// renderPayload собирает payload события. Результат должен быть JSON-объектом:
+// он сохраняется в outbox и дополняется eventId.
+func renderPayload(job entity.CronJob, data PayloadData) (map[string]any, error) {
+	tmpl, err := template.New(job.Name).Option("missingkey=error").Parse(job.PayloadTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayloadTemplate, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayloadTemplate, err)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil || payload == nil {
+		return nil, fmt.Errorf("%w: rendered payload is not a JSON object", ErrInvalidPayloadTemplate)
+	}
+
+	return payload, nil
+}
+
+// nextRun — ближайшее время запуска после after. Поддерживаются стандартные
+// cron-выражения из 5 полей и дескрипторы (@hourly, @daily, @every 12h).
+func nextRun(schedule string, after time.Time) (time.Time, error) {
+	s, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+	return s.Next(after), nil
+}
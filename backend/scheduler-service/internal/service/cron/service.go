@@ -0,0 +1,272 @@
+package cron_service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4udiwe/avito-pvz/pkg/transactor"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	cron_job_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/cron_job"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+CronService — реестр периодических задач.
+
+Определения задач (расписание, топик, тип события, шаблон payload) приходят
+из конфига и сохраняются в БД при старте. Запуск по расписанию выполняется
+в транзакции с блокировкой строк FOR UPDATE SKIP LOCKED, поэтому при
+нескольких репликах каждая задача срабатывает один раз.
+
+Событие записывается в outbox с топиком задачи в той же транзакции, что и
+запуск, и публикуется воркером этого топика. Если запись не удалась,
+транзакция откатывается: next_run_at не меняется и задача повторяется на
+следующем тике. Пропущенные за время простоя запуски не догоняются — задача
+срабатывает один раз и переносится на следующее время.
+*/
+type CronService struct {
+	repo       CronJobRepository
+	outboxRepo OutboxRepository
+	txManager  transactor.Transactor
+}
+
+func New(
+	repo CronJobRepository,
+	outboxRepo OutboxRepository,
+	txManager transactor.Transactor,
+) *CronService {
+	return &CronService{
+		repo:       repo,
+		outboxRepo: outboxRepo,
+		txManager:  txManager,
+	}
+}
+
+// Register сохраняет определения задач из конфига. Невалидное расписание или
+// шаблон payload — ошибка: сервис не должен стартовать с задачей, которая
+// никогда не сработает.
+func (s *CronService) Register(ctx context.Context, jobs []entity.CronJob) error {
+	now := time.Now()
+
+	for _, job := range jobs {
+		next, err := nextRun(job.Schedule, now)
+		if err != nil {
+			logrus.WithField("job", job.Name).WithError(err).Error("cron job has invalid schedule")
+			return err
+		}
+
+		if _, err := renderPayload(job, PayloadData{JobName: job.Name, ScheduledAt: now}); err != nil {
+			logrus.WithField("job", job.Name).WithError(err).Error("cron job has invalid payload template")
+			return err
+		}
+
+		job.NextRunAt = next
+
+		saved, err := s.repo.Upsert(ctx, job)
+		if err != nil {
+			return ErrCannotRegisterJobs
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"job":         saved.Name,
+			"schedule":    saved.Schedule,
+			"enabled":     saved.Enabled,
+			"next_run_at": saved.NextRunAt,
+		}).Info("cron job registered")
+	}
+
+	return nil
+}
+
+// RunDueJobs запускает задачи, время которых пришло, и возвращает их количество.
+func (s *CronService) RunDueJobs(ctx context.Context, limit int) (int, error) {
+	var count int
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		jobs, err := s.repo.FindDueJobs(ctx, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, job := range jobs {
+			run, err := s.execute(ctx, job, entity.CronRunTriggerSchedule, job.NextRunAt)
+			if err != nil {
+				return err
+			}
+
+			if _, err := s.repo.CreateRun(ctx, run); err != nil {
+				return err
+			}
+
+			next, err := nextRun(job.Schedule, run.FinishedAt)
+			if err != nil {
+				return err
+			}
+
+			if err := s.repo.MarkRun(ctx, job.ID, run.StartedAt, next); err != nil {
+				return err
+			}
+		}
+
+		count = len(jobs)
+		return nil
+	})
+
+	if err != nil {
+		logrus.WithError(err).Error("failed to run due cron jobs")
+		return 0, ErrCannotRunJob
+	}
+
+	return count, nil
+}
+
+// TriggerJob запускает задачу вне расписания (в том числе приостановленную).
+// Время следующего запуска по расписанию не меняется.
+func (s *CronService) TriggerJob(ctx context.Context, id uuid.UUID) (entity.CronJobRun, error) {
+	var run entity.CronJobRun
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		job, err := s.repo.GetForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		run, err = s.execute(ctx, job, entity.CronRunTriggerManual, time.Now())
+		if err != nil {
+			return err
+		}
+
+		run.ID, err = s.repo.CreateRun(ctx, run)
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, cron_job_repository.ErrJobNotFound) {
+			return entity.CronJobRun{}, ErrJobNotFound
+		}
+		logrus.WithField("job_id", id).WithError(err).Error("failed to trigger cron job")
+		return entity.CronJobRun{}, ErrCannotRunJob
+	}
+
+	return run, nil
+}
+
+func (s *CronService) PauseJob(ctx context.Context, id uuid.UUID) error {
+	return s.setEnabled(ctx, id, false)
+}
+
+// ResumeJob включает задачу; следующий запуск считается от текущего момента,
+// без догоняющих запусков за время паузы.
+func (s *CronService) ResumeJob(ctx context.Context, id uuid.UUID) error {
+	return s.setEnabled(ctx, id, true)
+}
+
+func (s *CronService) setEnabled(ctx context.Context, id uuid.UUID, enabled bool) error {
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		job, err := s.repo.GetForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		next := job.NextRunAt
+		if enabled && !job.Enabled {
+			next, err = nextRun(job.Schedule, time.Now())
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.repo.SetEnabled(ctx, id, enabled, next)
+	})
+
+	if err != nil {
+		if errors.Is(err, cron_job_repository.ErrJobNotFound) {
+			return ErrJobNotFound
+		}
+		logrus.WithField("job_id", id).WithError(err).Error("failed to update cron job")
+		return ErrCannotUpdateJob
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":  id,
+		"enabled": enabled,
+	}).Info("cron job updated")
+
+	return nil
+}
+
+func (s *CronService) ListJobs(ctx context.Context) ([]entity.CronJob, error) {
+	jobs, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, ErrCannotFetchJobs
+	}
+	return jobs, nil
+}
+
+func (s *CronService) ListRuns(ctx context.Context, jobID uuid.UUID, limit int) ([]entity.CronJobRun, error) {
+	runs, err := s.repo.ListRuns(ctx, jobID, limit)
+	if err != nil {
+		return nil, ErrCannotFetchJobs
+	}
+	return runs, nil
+}
+
+// execute собирает payload и записывает событие в outbox. Невалидный payload
+// попадает в историю запусков как ошибка и не прерывает остальные задачи:
+// повтор его не исправит. Ошибка outbox возвращается — транзакция запуска
+// откатывается.
+func (s *CronService) execute(
+	ctx context.Context,
+	job entity.CronJob,
+	trigger entity.CronRunTrigger,
+	scheduledAt time.Time,
+) (entity.CronJobRun, error) {
+
+	run := entity.CronJobRun{
+		JobID:       job.ID,
+		Trigger:     trigger,
+		ScheduledAt: scheduledAt,
+		StartedAt:   time.Now(),
+	}
+
+	fields := logrus.Fields{
+		"job":        job.Name,
+		"topic":      job.Topic,
+		"event_type": job.EventType,
+		"trigger":    trigger,
+	}
+
+	payload, err := renderPayload(job, PayloadData{
+		JobName:     job.Name,
+		ScheduledAt: scheduledAt,
+		Manual:      trigger == entity.CronRunTriggerManual,
+	})
+	if err != nil {
+		run.FinishedAt = time.Now()
+		run.Status = entity.CronRunStatusFailed
+		run.Error = lo.ToPtr(err.Error())
+		logrus.WithFields(fields).WithError(err).Error("cron job failed")
+		return run, nil
+	}
+
+	err = s.outboxRepo.Create(ctx, entity.OutboxEvent{
+		AggregateType: "cron",
+		AggregateID:   job.ID,
+		EventType:     job.EventType,
+		Payload:       payload,
+		Topic:         job.Topic,
+	})
+	if err != nil {
+		logrus.WithFields(fields).WithError(err).Error("cron job: failed to create outbox event")
+		return entity.CronJobRun{}, err
+	}
+
+	run.FinishedAt = time.Now()
+	run.Status = entity.CronRunStatusSucceeded
+	logrus.WithFields(fields).Info("cron job event queued")
+
+	return run, nil
+}
@@ -0,0 +1,278 @@
+package cron_service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/service/cron/mocks"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+type dummyTransactor struct{}
+
+func (d dummyTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestNextRun(t *testing.T) {
+	after := time.Date(2026, 10, 19, 10, 15, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		schedule  string
+		want      time.Time
+		wantError error
+	}{
+		{
+			name:     "five_fields",
+			schedule: "30 3 * * *",
+			want:     time.Date(2026, 10, 20, 3, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "every_hour",
+			schedule: "0 * * * *",
+			want:     time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "descriptor",
+			schedule: "@daily",
+			want:     time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "every_interval",
+			schedule: "@every 12h",
+			want:     after.Add(12 * time.Hour),
+		},
+		{
+			name:      "six_fields_not_supported",
+			schedule:  "0 30 3 * * *",
+			wantError: ErrInvalidSchedule,
+		},
+		{
+			name:      "garbage",
+			schedule:  "sometimes",
+			wantError: ErrInvalidSchedule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextRun(tt.schedule, after)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("nextRun() error = %v, wantErr %v", err, tt.wantError)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("nextRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderPayload(t *testing.T) {
+	scheduledAt := time.Date(2026, 10, 19, 3, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		template  string
+		want      map[string]any
+		wantError error
+	}{
+		{
+			name:     "static",
+			template: `{"retentionDays": 3}`,
+			want:     map[string]any{"retentionDays": float64(3)},
+		},
+		{
+			name:     "template_fields",
+			template: `{"job": "{{ .JobName }}", "at": "{{ .ScheduledAt.Format "2006-01-02" }}", "manual": {{ .Manual }}}`,
+			want:     map[string]any{"job": "cleanup", "at": "2026-10-19", "manual": true},
+		},
+		{
+			name:      "unknown_field",
+			template:  `{"x": "{{ .Unknown }}"}`,
+			wantError: ErrInvalidPayloadTemplate,
+		},
+		{
+			name:      "broken_template",
+			template:  `{"x": "{{ .JobName "}`,
+			wantError: ErrInvalidPayloadTemplate,
+		},
+		{
+			name:      "not_json",
+			template:  `retention={{ .JobName }}`,
+			wantError: ErrInvalidPayloadTemplate,
+		},
+		{
+			name:      "not_object",
+			template:  `["{{ .JobName }}"]`,
+			wantError: ErrInvalidPayloadTemplate,
+		},
+		{
+			name:      "null",
+			template:  `null`,
+			wantError: ErrInvalidPayloadTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := entity.CronJob{Name: "cleanup", PayloadTemplate: tt.template}
+
+			got, err := renderPayload(job, PayloadData{JobName: job.Name, ScheduledAt: scheduledAt, Manual: true})
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("renderPayload() error = %v, wantErr %v", err, tt.wantError)
+			}
+			if tt.wantError == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("renderPayload() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunDueJobs(t *testing.T) {
+	jobID := uuid.New()
+	scheduledAt := time.Now().Add(-time.Minute).Truncate(time.Minute)
+
+	job := entity.CronJob{
+		ID:              jobID,
+		Name:            "auth.session.cleanup",
+		Schedule:        "@every 1h",
+		Topic:           "scheduler.events",
+		EventType:       "auth.sessions.cleanup",
+		PayloadTemplate: `{"firedAt": "{{ .ScheduledAt.Format "2006-01-02T15:04:05Z07:00" }}"}`,
+		Enabled:         true,
+		NextRunAt:       scheduledAt,
+	}
+
+	t.Run("queued", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockCronJobRepository(ctrl)
+		outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+
+		repo.EXPECT().FindDueJobs(gomock.Any(), 10).Return([]entity.CronJob{job}, nil)
+		outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, ev entity.OutboxEvent) error {
+				if ev.Topic != job.Topic || ev.EventType != job.EventType || ev.AggregateID != jobID {
+					t.Errorf("unexpected outbox event %+v", ev)
+				}
+				firedAt, err := time.Parse(time.RFC3339, ev.Payload["firedAt"].(string))
+				if err != nil {
+					t.Fatalf("firedAt: %v", err)
+				}
+				// Payload строится от запланированного времени, а не от фактического запуска
+				if !firedAt.Equal(scheduledAt) {
+					t.Errorf("firedAt = %v, want %v", firedAt, scheduledAt)
+				}
+				return nil
+			})
+
+		var run entity.CronJobRun
+		repo.EXPECT().CreateRun(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, r entity.CronJobRun) (uuid.UUID, error) {
+				run = r
+				return uuid.New(), nil
+			})
+		repo.EXPECT().MarkRun(gomock.Any(), jobID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, lastRunAt, nextRunAt time.Time) error {
+				// Следующий запуск считается от окончания текущего, пропущенные не догоняются
+				if want := run.FinishedAt.Truncate(time.Second).Add(time.Hour); !nextRunAt.Equal(want) {
+					t.Errorf("nextRunAt = %v, want %v", nextRunAt, want)
+				}
+				if !lastRunAt.Equal(run.StartedAt) {
+					t.Errorf("lastRunAt = %v, want %v", lastRunAt, run.StartedAt)
+				}
+				return nil
+			})
+
+		svc := New(repo, outboxRepo, dummyTransactor{})
+
+		count, err := svc.RunDueJobs(context.Background(), 10)
+		if err != nil {
+			t.Fatalf("RunDueJobs() error = %v", err)
+		}
+		if count != 1 {
+			t.Errorf("RunDueJobs() = %d, want 1", count)
+		}
+		if run.Status != entity.CronRunStatusSucceeded || run.Error != nil {
+			t.Errorf("run status = %s, error = %v", run.Status, run.Error)
+		}
+		if run.Trigger != entity.CronRunTriggerSchedule || !run.ScheduledAt.Equal(scheduledAt) {
+			t.Errorf("unexpected run %+v", run)
+		}
+	})
+
+	t.Run("invalid_payload_is_recorded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockCronJobRepository(ctrl)
+		outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+
+		broken := job
+		broken.PayloadTemplate = `not json`
+
+		repo.EXPECT().FindDueJobs(gomock.Any(), 10).Return([]entity.CronJob{broken}, nil)
+
+		var run entity.CronJobRun
+		repo.EXPECT().CreateRun(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, r entity.CronJobRun) (uuid.UUID, error) {
+				run = r
+				return uuid.New(), nil
+			})
+		repo.EXPECT().MarkRun(gomock.Any(), jobID, gomock.Any(), gomock.Any()).Return(nil)
+
+		svc := New(repo, outboxRepo, dummyTransactor{})
+
+		if _, err := svc.RunDueJobs(context.Background(), 10); err != nil {
+			t.Fatalf("RunDueJobs() error = %v", err)
+		}
+		if run.Status != entity.CronRunStatusFailed || run.Error == nil {
+			t.Errorf("run status = %s, error = %v", run.Status, run.Error)
+		}
+	})
+
+	t.Run("outbox_failed_keeps_next_run", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockCronJobRepository(ctrl)
+		outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+
+		outboxErr := errors.New("db unavailable")
+
+		// Ни CreateRun, ни MarkRun: транзакция откатывается, задача повторится на следующем тике
+		repo.EXPECT().FindDueJobs(gomock.Any(), 10).Return([]entity.CronJob{job}, nil)
+		outboxRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(outboxErr)
+
+		svc := New(repo, outboxRepo, dummyTransactor{})
+
+		if _, err := svc.RunDueJobs(context.Background(), 10); !errors.Is(err, ErrCannotRunJob) {
+			t.Fatalf("RunDueJobs() error = %v, want %v", err, ErrCannotRunJob)
+		}
+	})
+}
+
+func TestResumeJob_RecalculatesNextRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockCronJobRepository(ctrl)
+
+	jobID := uuid.New()
+	stale := time.Now().Add(-24 * time.Hour)
+
+	repo.EXPECT().GetForUpdate(gomock.Any(), jobID).
+		Return(entity.CronJob{ID: jobID, Schedule: "@every 1h", Enabled: false, NextRunAt: stale}, nil)
+	repo.EXPECT().SetEnabled(gomock.Any(), jobID, true, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, _ bool, next time.Time) error {
+			if !next.After(time.Now()) {
+				t.Errorf("next run %v is not in the future", next)
+			}
+			return nil
+		})
+
+	svc := New(repo, nil, dummyTransactor{})
+
+	if err := svc.ResumeJob(context.Background(), jobID); err != nil {
+		t.Fatalf("ResumeJob() error = %v", err)
+	}
+}
@@ -7,7 +7,21 @@ import (
 	"github.com/google/uuid"
 )
 
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
 type TimerRepository interface {
 	Create(ctx context.Context, timer entity.Timer) (uuid.UUID, error)
 	CancelByBooking(ctx context.Context, bookingID uuid.UUID) error
+	UpsertByKey(ctx context.Context, timer entity.Timer) (uuid.UUID, bool, error)
+	CancelByKey(ctx context.Context, key string) (bool, error)
+	FindPendingByUser(ctx context.Context, userID uuid.UUID, timerType entity.TimerID) ([]entity.Timer, error)
+	CancelRemindersByUser(ctx context.Context, userID uuid.UUID) error
+	DeletePendingByUser(ctx context.Context, userID uuid.UUID) error
+	AnonymizeUser(ctx context.Context, userID uuid.UUID, anonymousID uuid.UUID) error
+}
+
+type ReminderPreferencesRepository interface {
+	Get(ctx context.Context, userID uuid.UUID) (entity.ReminderPreferences, error)
+	Upsert(ctx context.Context, prefs entity.ReminderPreferences) (entity.ReminderPreferences, error)
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
 }
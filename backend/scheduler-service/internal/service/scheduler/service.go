@@ -2,35 +2,56 @@ package scheduler_service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"sort"
 	"time"
 
 	"github.com/4udiwe/avito-pvz/pkg/transactor"
 	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	reminder_preferences_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/reminder_preferences"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 )
 
 var (
 	ErrCannotCreateTimer = errors.New("cannot create timer")
+	ErrCannotCancelTimer = errors.New("cannot cancel timer")
+	ErrInvalidTimer      = errors.New("invalid timer request")
+	ErrTopicNotAllowed   = errors.New("timer target topic is not allowed")
+
+	ErrCannotFetchPreferences  = errors.New("cannot fetch reminder preferences")
+	ErrCannotUpdatePreferences = errors.New("cannot update reminder preferences")
+
+	ErrCannotDeleteUser = errors.New("cannot delete user data")
 )
 
 type SchedulerService struct {
 	timerRepo TimerRepository
+	prefsRepo ReminderPreferencesRepository
 	txManager transactor.Transactor
 
 	remindBefore time.Duration
+
+	// Топики, в которые разрешено публиковать универсальные таймеры.
+	// Пустой список запрещает универсальные таймеры.
+	allowedTopics []string
 }
 
 func New(
 	timerRepo TimerRepository,
+	prefsRepo ReminderPreferencesRepository,
 	txManager transactor.Transactor,
 	remindBefore time.Duration,
+	allowedTopics []string,
 ) *SchedulerService {
 	return &SchedulerService{
-		timerRepo:    timerRepo,
-		txManager:    txManager,
-		remindBefore: remindBefore,
+		timerRepo:     timerRepo,
+		prefsRepo:     prefsRepo,
+		txManager:     txManager,
+		remindBefore:  remindBefore,
+		allowedTopics: allowedTopics,
 	}
 }
 
@@ -43,44 +64,37 @@ func (s *SchedulerService) HandleCreatedBooking(
 
 	logrus.Infof("Handling booking created: %s", bookingID)
 
-	reminderTimer := entity.Timer{
+	booking := entity.Timer{
 		BookingID:  bookingID,
 		UserID:     &userID,
 		PlaceID:    &placeID,
 		PlaceLabel: &placeLabel,
 		StartTime:  &startTime,
 		EndTime:    &endTime,
-
-		Type: entity.TimerType{
-			ID:   entity.TimerTypeBookingReminderID,
-			Name: entity.TimerTypeBookingReminderName,
-		},
-		TriggerAt: startTime.Add(-s.remindBefore),
 	}
 
-	expireTimer := entity.Timer{
-		BookingID:  bookingID,
-		UserID:     &userID,
-		PlaceID:    &placeID,
-		PlaceLabel: &placeLabel,
-		StartTime:  &startTime,
-		EndTime:    &endTime,
-
-		Type: entity.TimerType{
-			ID:   entity.TimerTypeBookingExpireID,
-			Name: entity.TimerTypeBoookingExpireName,
-		},
-		TriggerAt: endTime,
+	expireTimer := booking
+	expireTimer.Type = entity.TimerType{
+		ID:   entity.TimerTypeBookingExpireID,
+		Name: entity.TimerTypeBoookingExpireName,
 	}
+	expireTimer.TriggerAt = endTime
 
 	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
 
-		_, err := s.timerRepo.Create(ctx, reminderTimer)
+		prefs, err := s.preferences(ctx, userID)
 		if err != nil {
-			logrus.Errorf("failed to create reminder timer: %v", err)
 			return err
 		}
 
+		for _, reminder := range s.reminderTimers(booking, prefs, time.Now()) {
+			_, err = s.timerRepo.Create(ctx, reminder)
+			if err != nil {
+				logrus.Errorf("failed to create reminder timer: %v", err)
+				return err
+			}
+		}
+
 		_, err = s.timerRepo.Create(ctx, expireTimer)
 		if err != nil {
 			logrus.Errorf("failed to create expire timer: %v", err)
@@ -125,3 +139,254 @@ func (s *SchedulerService) HandleCancelledBooking(
 
 	return nil
 }
+
+// ScheduleTimer сохраняет универсальный таймер: в момент triggerAt событие
+// eventType с payload будет записано в outbox и опубликовано в topic. Топик
+// должен входить в allowedTopics — только для них запущены outbox-воркеры.
+// Повторный запрос с тем же ключом переносит ещё не сработавший таймер.
+func (s *SchedulerService) ScheduleTimer(
+	ctx context.Context,
+	key, topic, eventType string,
+	triggerAt time.Time,
+	payload json.RawMessage,
+) error {
+
+	if key == "" || topic == "" || eventType == "" || triggerAt.IsZero() {
+		return ErrInvalidTimer
+	}
+
+	if len(payload) == 0 {
+		payload = json.RawMessage("{}")
+	}
+	var object map[string]any
+	if err := json.Unmarshal(payload, &object); err != nil || object == nil {
+		return ErrInvalidTimer
+	}
+
+	if !lo.Contains(s.allowedTopics, topic) {
+		return ErrTopicNotAllowed
+	}
+
+	timer := entity.Timer{
+		Type: entity.TimerType{
+			ID:   entity.TimerTypeGenericID,
+			Name: entity.TimerTypeGenericName,
+		},
+		CorrelationKey: &key,
+		TargetTopic:    &topic,
+		EventType:      &eventType,
+		TriggerAt:      triggerAt,
+		Payload:        payload,
+	}
+
+	_, scheduled, err := s.timerRepo.UpsertByKey(ctx, timer)
+	if err != nil {
+		return ErrCannotCreateTimer
+	}
+
+	if !scheduled {
+		logrus.WithField("key", key).Info("timer already triggered or cancelled, request ignored")
+	}
+
+	return nil
+}
+
+// CancelTimer отменяет ожидающий универсальный таймер. Отмена несуществующего
+// или уже сработавшего таймера — не ошибка.
+func (s *SchedulerService) CancelTimer(ctx context.Context, key string) error {
+	if key == "" {
+		return ErrInvalidTimer
+	}
+
+	cancelled, err := s.timerRepo.CancelByKey(ctx, key)
+	if err != nil {
+		return ErrCannotCancelTimer
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"key":       key,
+		"cancelled": cancelled,
+	}).Info("Handling timer cancel")
+
+	return nil
+}
+
+// GetReminderPreferences возвращает настройки напоминаний пользователя или
+// настройки по умолчанию, если пользователь их не менял.
+func (s *SchedulerService) GetReminderPreferences(
+	ctx context.Context,
+	userID uuid.UUID,
+) (entity.ReminderPreferences, error) {
+
+	prefs, err := s.preferences(ctx, userID)
+	if err != nil {
+		return entity.ReminderPreferences{}, ErrCannotFetchPreferences
+	}
+	return prefs, nil
+}
+
+// UpdateReminderPreferences сохраняет настройки и пересоздаёт ещё не
+// сработавшие напоминания для уже запланированных бронирований пользователя.
+// Напоминания, время которых по новым настройкам уже прошло, не создаются.
+func (s *SchedulerService) UpdateReminderPreferences(
+	ctx context.Context,
+	userID uuid.UUID,
+	startOffsets []int,
+	endOffset *int,
+) (entity.ReminderPreferences, error) {
+
+	startOffsets = lo.Uniq(startOffsets)
+	sort.Sort(sort.Reverse(sort.IntSlice(startOffsets)))
+
+	var saved entity.ReminderPreferences
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		saved, err = s.prefsRepo.Upsert(ctx, entity.ReminderPreferences{
+			UserID:       userID,
+			StartOffsets: startOffsets,
+			EndOffset:    endOffset,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := s.timerRepo.CancelRemindersByUser(ctx, userID); err != nil {
+			return err
+		}
+
+		// Ожидающий таймер завершения = бронирование ещё не закончилось
+		bookings, err := s.timerRepo.FindPendingByUser(ctx, userID, entity.TimerTypeBookingExpireID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, booking := range bookings {
+			for _, reminder := range s.reminderTimers(booking, saved, now) {
+				if _, err := s.timerRepo.Create(ctx, reminder); err != nil {
+					return err
+				}
+			}
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"user_id":  userID,
+			"bookings": len(bookings),
+		}).Info("reminders rescheduled")
+
+		return nil
+	})
+
+	if err != nil {
+		logrus.WithField("user_id", userID).WithError(err).Error("failed to update reminder preferences")
+		return entity.ReminderPreferences{}, ErrCannotUpdatePreferences
+	}
+
+	return saved, nil
+}
+
+// DeleteUser удаляет данные пользователя, удалившего аккаунт: настройки
+// напоминаний и ещё не сработавшие таймеры. В истории таймеров user_id
+// заменяется на anonymousID, как и в остальных сервисах.
+func (s *SchedulerService) DeleteUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	anonymousID uuid.UUID,
+) error {
+
+	logrus.Infof("Deleting scheduler data of user: %s", userID)
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.prefsRepo.DeleteByUser(ctx, userID); err != nil {
+			return err
+		}
+
+		if err := s.timerRepo.DeletePendingByUser(ctx, userID); err != nil {
+			return err
+		}
+
+		return s.timerRepo.AnonymizeUser(ctx, userID, anonymousID)
+	})
+
+	if err != nil {
+		logrus.WithField("user_id", userID).WithError(err).Error("failed to delete user data")
+		return ErrCannotDeleteUser
+	}
+
+	return nil
+}
+
+func (s *SchedulerService) preferences(
+	ctx context.Context,
+	userID uuid.UUID,
+) (entity.ReminderPreferences, error) {
+
+	prefs, err := s.prefsRepo.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, reminder_preferences_repository.ErrPreferencesNotFound) {
+			return entity.ReminderPreferences{
+				UserID:       userID,
+				StartOffsets: []int{int(s.remindBefore.Minutes())},
+			}, nil
+		}
+		return entity.ReminderPreferences{}, err
+	}
+
+	return prefs, nil
+}
+
+// reminderTimers строит напоминания для бронирования по настройкам prefs.
+// booking — любой таймер бронирования: из него берутся данные бронирования.
+func (s *SchedulerService) reminderTimers(
+	booking entity.Timer,
+	prefs entity.ReminderPreferences,
+	now time.Time,
+) []entity.Timer {
+
+	base := entity.Timer{
+		BookingID:  booking.BookingID,
+		UserID:     booking.UserID,
+		PlaceID:    booking.PlaceID,
+		PlaceLabel: booking.PlaceLabel,
+		StartTime:  booking.StartTime,
+		EndTime:    booking.EndTime,
+	}
+
+	var timers []entity.Timer
+
+	if booking.StartTime != nil {
+		for _, offset := range prefs.StartOffsets {
+			triggerAt := booking.StartTime.Add(-time.Duration(offset) * time.Minute)
+			if triggerAt.Before(now) {
+				continue
+			}
+
+			timer := base
+			timer.Type = entity.TimerType{
+				ID:   entity.TimerTypeBookingReminderID,
+				Name: entity.TimerTypeBookingReminderName,
+			}
+			timer.OffsetMinutes = lo.ToPtr(offset)
+			timer.TriggerAt = triggerAt
+			timers = append(timers, timer)
+		}
+	}
+
+	if booking.EndTime != nil && prefs.EndOffset != nil {
+		triggerAt := booking.EndTime.Add(-time.Duration(*prefs.EndOffset) * time.Minute)
+		if !triggerAt.Before(now) {
+			timer := base
+			timer.Type = entity.TimerType{
+				ID:   entity.TimerTypeBookingEndReminderID,
+				Name: entity.TimerTypeBookingEndReminderName,
+			}
+			timer.OffsetMinutes = lo.ToPtr(*prefs.EndOffset)
+			timer.TriggerAt = triggerAt
+			timers = append(timers, timer)
+		}
+	}
+
+	return timers
+}
@@ -0,0 +1,445 @@
+package scheduler_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	reminder_preferences_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/reminder_preferences"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/service/scheduler/mocks"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/mock/gomock"
+)
+
+type dummyTransactor struct{}
+
+func (d dummyTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestScheduleTimer_Validations(t *testing.T) {
+	triggerAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		key       string
+		topic     string
+		eventType string
+		triggerAt time.Time
+		payload   json.RawMessage
+		wantError error
+	}{
+		{
+			name:      "empty_key",
+			topic:     "notification.events",
+			eventType: "notification.requested",
+			triggerAt: triggerAt,
+			wantError: ErrInvalidTimer,
+		},
+		{
+			name:      "empty_topic",
+			key:       "k",
+			eventType: "notification.requested",
+			triggerAt: triggerAt,
+			wantError: ErrInvalidTimer,
+		},
+		{
+			name:      "empty_event_type",
+			key:       "k",
+			topic:     "notification.events",
+			triggerAt: triggerAt,
+			wantError: ErrInvalidTimer,
+		},
+		{
+			name:      "zero_trigger_at",
+			key:       "k",
+			topic:     "notification.events",
+			eventType: "notification.requested",
+			wantError: ErrInvalidTimer,
+		},
+		{
+			name:      "invalid_payload",
+			key:       "k",
+			topic:     "notification.events",
+			eventType: "notification.requested",
+			triggerAt: triggerAt,
+			payload:   json.RawMessage(`{"broken"`),
+			wantError: ErrInvalidTimer,
+		},
+		{
+			name:      "payload_not_object",
+			key:       "k",
+			topic:     "notification.events",
+			eventType: "notification.requested",
+			triggerAt: triggerAt,
+			payload:   json.RawMessage(`["u1"]`),
+			wantError: ErrInvalidTimer,
+		},
+		{
+			name:      "topic_not_allowed",
+			key:       "k",
+			topic:     "auth.events",
+			eventType: "auth.user.deleted",
+			triggerAt: triggerAt,
+			wantError: ErrTopicNotAllowed,
+		},
+	}
+
+	svc := New(nil, nil, dummyTransactor{}, time.Hour, []string{"notification.events"})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.ScheduleTimer(context.Background(), tt.key, tt.topic, tt.eventType, tt.triggerAt, tt.payload)
+			if !errors.Is(err, tt.wantError) {
+				t.Errorf("ScheduleTimer() error = %v, wantErr %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestScheduleTimer(t *testing.T) {
+	triggerAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		payload     json.RawMessage
+		wantPayload string
+		repoErr     error
+		wantError   error
+	}{
+		{
+			name:        "payload_passed_as_is",
+			payload:     json.RawMessage(`{"userId":"u1"}`),
+			wantPayload: `{"userId":"u1"}`,
+		},
+		{
+			name:        "empty_payload_becomes_object",
+			wantPayload: `{}`,
+		},
+		{
+			name:        "repository_error",
+			wantPayload: `{}`,
+			repoErr:     errors.New("db is down"),
+			wantError:   ErrCannotCreateTimer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockTimerRepository(ctrl)
+
+			repo.EXPECT().UpsertByKey(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, timer entity.Timer) (uuid.UUID, bool, error) {
+					if timer.Type.ID != entity.TimerTypeGenericID {
+						t.Errorf("timer type = %v, want generic", timer.Type.ID)
+					}
+					if *timer.CorrelationKey != "k" || *timer.TargetTopic != "notification.events" ||
+						*timer.EventType != "notification.requested" || !timer.TriggerAt.Equal(triggerAt) {
+						t.Errorf("unexpected timer %+v", timer)
+					}
+					if string(timer.Payload) != tt.wantPayload {
+						t.Errorf("payload = %s, want %s", timer.Payload, tt.wantPayload)
+					}
+					return uuid.New(), true, tt.repoErr
+				})
+
+			svc := New(repo, nil, dummyTransactor{}, time.Hour, []string{"notification.events"})
+
+			err := svc.ScheduleTimer(context.Background(), "k", "notification.events", "notification.requested", triggerAt, tt.payload)
+			if !errors.Is(err, tt.wantError) {
+				t.Errorf("ScheduleTimer() error = %v, wantErr %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestReminderTimers(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	start := now.Add(2 * time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	booking := entity.Timer{
+		BookingID:  uuid.New(),
+		UserID:     lo.ToPtr(uuid.New()),
+		PlaceID:    lo.ToPtr(uuid.New()),
+		PlaceLabel: lo.ToPtr("A-12"),
+		StartTime:  &start,
+		EndTime:    &end,
+		// Тип и время исходного таймера не должны попасть в напоминания
+		Type:      entity.TimerType{ID: entity.TimerTypeBookingExpireID},
+		TriggerAt: end,
+	}
+
+	type reminder struct {
+		typeID    entity.TimerID
+		offset    int
+		triggerAt time.Time
+	}
+
+	tests := []struct {
+		name    string
+		booking entity.Timer
+		prefs   entity.ReminderPreferences
+		want    []reminder
+	}{
+		{
+			name:    "start_offsets",
+			booking: booking,
+			prefs:   entity.ReminderPreferences{StartOffsets: []int{60, 15}},
+			want: []reminder{
+				{entity.TimerTypeBookingReminderID, 60, start.Add(-time.Hour)},
+				{entity.TimerTypeBookingReminderID, 15, start.Add(-15 * time.Minute)},
+			},
+		},
+		{
+			name:    "past_offsets_skipped",
+			booking: booking,
+			prefs:   entity.ReminderPreferences{StartOffsets: []int{1440, 120, 30}},
+			want: []reminder{
+				// 120 минут до начала — ровно сейчас, ещё не прошло
+				{entity.TimerTypeBookingReminderID, 120, now},
+				{entity.TimerTypeBookingReminderID, 30, start.Add(-30 * time.Minute)},
+			},
+		},
+		{
+			name:    "end_reminder",
+			booking: booking,
+			prefs:   entity.ReminderPreferences{EndOffset: lo.ToPtr(10)},
+			want: []reminder{
+				{entity.TimerTypeBookingEndReminderID, 10, end.Add(-10 * time.Minute)},
+			},
+		},
+		{
+			name:    "reminders_disabled",
+			booking: booking,
+			prefs:   entity.ReminderPreferences{},
+		},
+		{
+			name: "booking_without_times",
+			booking: entity.Timer{
+				BookingID: booking.BookingID,
+			},
+			prefs: entity.ReminderPreferences{StartOffsets: []int{15}, EndOffset: lo.ToPtr(10)},
+		},
+	}
+
+	svc := New(nil, nil, dummyTransactor{}, time.Hour, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := svc.reminderTimers(tt.booking, tt.prefs, now)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("reminderTimers() returned %d timers, want %d", len(got), len(tt.want))
+			}
+			for i, w := range tt.want {
+				timer := got[i]
+				if timer.Type.ID != w.typeID || *timer.OffsetMinutes != w.offset || !timer.TriggerAt.Equal(w.triggerAt) {
+					t.Errorf("timer %d = {%v %d %v}, want %+v", i, timer.Type.ID, *timer.OffsetMinutes, timer.TriggerAt, w)
+				}
+				if timer.BookingID != tt.booking.BookingID || timer.UserID != tt.booking.UserID ||
+					timer.PlaceLabel != tt.booking.PlaceLabel || timer.StartTime != tt.booking.StartTime {
+					t.Errorf("timer %d does not carry booking data: %+v", i, timer)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleCreatedBooking_DefaultPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	timerRepo := mocks.NewMockTimerRepository(ctrl)
+	prefsRepo := mocks.NewMockReminderPreferencesRepository(ctrl)
+
+	userID := uuid.New()
+	start := time.Now().Add(3 * time.Hour).Truncate(time.Hour)
+	end := start.Add(time.Hour)
+
+	prefsRepo.EXPECT().Get(gomock.Any(), userID).
+		Return(entity.ReminderPreferences{}, reminder_preferences_repository.ErrPreferencesNotFound)
+
+	var created []entity.Timer
+	timerRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, timer entity.Timer) (uuid.UUID, error) {
+			created = append(created, timer)
+			return uuid.New(), nil
+		}).Times(2)
+
+	svc := New(timerRepo, prefsRepo, dummyTransactor{}, 30*time.Minute, nil)
+
+	err := svc.HandleCreatedBooking(context.Background(), uuid.New(), userID, uuid.New(), "A-12", start, end)
+	if err != nil {
+		t.Fatalf("HandleCreatedBooking() error = %v", err)
+	}
+
+	// Без сохранённых настроек — одно напоминание за remindBefore и таймер завершения
+	if created[0].Type.ID != entity.TimerTypeBookingReminderID || !created[0].TriggerAt.Equal(start.Add(-30*time.Minute)) {
+		t.Errorf("unexpected reminder %+v", created[0])
+	}
+	if created[1].Type.ID != entity.TimerTypeBookingExpireID || !created[1].TriggerAt.Equal(end) {
+		t.Errorf("unexpected expire timer %+v", created[1])
+	}
+}
+
+func TestUpdateReminderPreferences(t *testing.T) {
+	userID := uuid.New()
+	start := time.Now().Add(3 * time.Hour).Truncate(time.Hour)
+	end := start.Add(time.Hour)
+
+	pending := entity.Timer{
+		BookingID: uuid.New(),
+		UserID:    &userID,
+		StartTime: &start,
+		EndTime:   &end,
+		Type:      entity.TimerType{ID: entity.TimerTypeBookingExpireID},
+		TriggerAt: end,
+	}
+
+	tests := []struct {
+		name         string
+		startOffsets []int
+		endOffset    *int
+		mockBehavior func(tr *mocks.MockTimerRepository, pr *mocks.MockReminderPreferencesRepository)
+		wantOffsets  []int
+		wantError    error
+	}{
+		{
+			name:         "offsets_normalized_and_reminders_rescheduled",
+			startOffsets: []int{15, 60, 15, 1440},
+			endOffset:    lo.ToPtr(10),
+			mockBehavior: func(tr *mocks.MockTimerRepository, pr *mocks.MockReminderPreferencesRepository) {
+				pr.EXPECT().Upsert(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, prefs entity.ReminderPreferences) (entity.ReminderPreferences, error) {
+						return prefs, nil
+					})
+				tr.EXPECT().CancelRemindersByUser(gomock.Any(), userID).Return(nil)
+				tr.EXPECT().FindPendingByUser(gomock.Any(), userID, entity.TimerTypeBookingExpireID).
+					Return([]entity.Timer{pending}, nil)
+				// 1440 минут до начала уже прошло: 60, 15 и напоминание перед концом
+				tr.EXPECT().Create(gomock.Any(), gomock.Cond(func(timer entity.Timer) bool {
+					return timer.BookingID == pending.BookingID && timer.Type.ID == entity.TimerTypeBookingReminderID
+				})).Return(uuid.New(), nil).Times(2)
+				tr.EXPECT().Create(gomock.Any(), gomock.Cond(func(timer entity.Timer) bool {
+					return timer.Type.ID == entity.TimerTypeBookingEndReminderID &&
+						timer.TriggerAt.Equal(end.Add(-10*time.Minute))
+				})).Return(uuid.New(), nil)
+			},
+			wantOffsets: []int{1440, 60, 15},
+		},
+		{
+			name:         "reminders_disabled",
+			startOffsets: []int{},
+			mockBehavior: func(tr *mocks.MockTimerRepository, pr *mocks.MockReminderPreferencesRepository) {
+				pr.EXPECT().Upsert(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, prefs entity.ReminderPreferences) (entity.ReminderPreferences, error) {
+						return prefs, nil
+					})
+				tr.EXPECT().CancelRemindersByUser(gomock.Any(), userID).Return(nil)
+				tr.EXPECT().FindPendingByUser(gomock.Any(), userID, entity.TimerTypeBookingExpireID).
+					Return([]entity.Timer{pending}, nil)
+			},
+			wantOffsets: []int{},
+		},
+		{
+			name:         "cancel_failed",
+			startOffsets: []int{15},
+			mockBehavior: func(tr *mocks.MockTimerRepository, pr *mocks.MockReminderPreferencesRepository) {
+				pr.EXPECT().Upsert(gomock.Any(), gomock.Any()).
+					Return(entity.ReminderPreferences{UserID: userID, StartOffsets: []int{15}}, nil)
+				tr.EXPECT().CancelRemindersByUser(gomock.Any(), userID).Return(errors.New("db is down"))
+			},
+			wantError: ErrCannotUpdatePreferences,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			timerRepo := mocks.NewMockTimerRepository(ctrl)
+			prefsRepo := mocks.NewMockReminderPreferencesRepository(ctrl)
+			tt.mockBehavior(timerRepo, prefsRepo)
+
+			svc := New(timerRepo, prefsRepo, dummyTransactor{}, time.Hour, nil)
+
+			got, err := svc.UpdateReminderPreferences(context.Background(), userID, tt.startOffsets, tt.endOffset)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("UpdateReminderPreferences() error = %v, wantErr %v", err, tt.wantError)
+			}
+			if tt.wantError != nil {
+				return
+			}
+			if !slices.Equal(got.StartOffsets, tt.wantOffsets) {
+				t.Errorf("StartOffsets = %v, want %v", got.StartOffsets, tt.wantOffsets)
+			}
+		})
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	userID := uuid.New()
+	anonymousID := uuid.New()
+	dbErr := errors.New("db is down")
+
+	tests := []struct {
+		name         string
+		mockBehavior func(tr *mocks.MockTimerRepository, pr *mocks.MockReminderPreferencesRepository)
+		wantError    error
+	}{
+		{
+			name: "preferences_and_pending_timers_deleted",
+			mockBehavior: func(tr *mocks.MockTimerRepository, pr *mocks.MockReminderPreferencesRepository) {
+				gomock.InOrder(
+					pr.EXPECT().DeleteByUser(gomock.Any(), userID).Return(nil),
+					tr.EXPECT().DeletePendingByUser(gomock.Any(), userID).Return(nil),
+					tr.EXPECT().AnonymizeUser(gomock.Any(), userID, anonymousID).Return(nil),
+				)
+			},
+		},
+		{
+			name: "preferences_delete_failed",
+			mockBehavior: func(tr *mocks.MockTimerRepository, pr *mocks.MockReminderPreferencesRepository) {
+				pr.EXPECT().DeleteByUser(gomock.Any(), userID).Return(dbErr)
+			},
+			wantError: ErrCannotDeleteUser,
+		},
+		{
+			name: "timers_delete_failed",
+			mockBehavior: func(tr *mocks.MockTimerRepository, pr *mocks.MockReminderPreferencesRepository) {
+				pr.EXPECT().DeleteByUser(gomock.Any(), userID).Return(nil)
+				tr.EXPECT().DeletePendingByUser(gomock.Any(), userID).Return(dbErr)
+			},
+			wantError: ErrCannotDeleteUser,
+		},
+		{
+			name: "anonymize_failed",
+			mockBehavior: func(tr *mocks.MockTimerRepository, pr *mocks.MockReminderPreferencesRepository) {
+				pr.EXPECT().DeleteByUser(gomock.Any(), userID).Return(nil)
+				tr.EXPECT().DeletePendingByUser(gomock.Any(), userID).Return(nil)
+				tr.EXPECT().AnonymizeUser(gomock.Any(), userID, anonymousID).Return(dbErr)
+			},
+			wantError: ErrCannotDeleteUser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			timerRepo := mocks.NewMockTimerRepository(ctrl)
+			prefsRepo := mocks.NewMockReminderPreferencesRepository(ctrl)
+			tt.mockBehavior(timerRepo, prefsRepo)
+
+			svc := New(timerRepo, prefsRepo, dummyTransactor{}, time.Hour, nil)
+
+			err := svc.DeleteUser(context.Background(), userID, anonymousID)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("DeleteUser() error = %v, wantErr %v", err, tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package timer_admin_service
+
+import (
+	"context"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
+type TimerRepository interface {
+	Search(ctx context.Context, filter entity.TimerFilter, limit, offset int) ([]entity.Timer, error)
+	GetByID(ctx context.Context, id uuid.UUID) (entity.Timer, error)
+	Retrigger(ctx context.Context, id uuid.UUID) (entity.Timer, error)
+	CancelByID(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+type OutboxRepository interface {
+	ListByAggregate(ctx context.Context, aggregateID uuid.UUID) ([]entity.OutboxEvent, error)
+}
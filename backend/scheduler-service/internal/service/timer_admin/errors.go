@@ -0,0 +1,10 @@
+package timer_admin_service
+
+import "errors"
+
+var (
+	ErrTimerNotFound     = errors.New("timer not found")
+	ErrTimerNotPending   = errors.New("timer is not pending")
+	ErrCannotFetchTimers = errors.New("cannot fetch timers")
+	ErrCannotUpdateTimer = errors.New("cannot update timer")
+)
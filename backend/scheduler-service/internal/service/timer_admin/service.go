@@ -0,0 +1,124 @@
+package timer_admin_service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4udiwe/avito-pvz/pkg/transactor"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	timer_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/timer"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+TimerAdminService — операции администратора над таймерами: поиск,
+просмотр созданных таймером событий outbox, повторный запуск и отмена.
+
+Повторный запуск не публикует событие сам: таймер возвращается в pending
+со временем срабатывания NOW(), и событие создаёт воркер на ближайшем тике
+тем же путём, что и при обычном срабатывании.
+*/
+type TimerAdminService struct {
+	timerRepo  TimerRepository
+	outboxRepo OutboxRepository
+	txManager  transactor.Transactor
+}
+
+func New(
+	timerRepo TimerRepository,
+	outboxRepo OutboxRepository,
+	txManager transactor.Transactor,
+) *TimerAdminService {
+	return &TimerAdminService{
+		timerRepo:  timerRepo,
+		outboxRepo: outboxRepo,
+		txManager:  txManager,
+	}
+}
+
+func (s *TimerAdminService) Search(
+	ctx context.Context,
+	filter entity.TimerFilter,
+	limit, offset int,
+) ([]entity.Timer, error) {
+	timers, err := s.timerRepo.Search(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, ErrCannotFetchTimers
+	}
+	return timers, nil
+}
+
+// GetTimer возвращает таймер вместе с событиями outbox, которые он создал.
+func (s *TimerAdminService) GetTimer(ctx context.Context, id uuid.UUID) (entity.Timer, []entity.OutboxEvent, error) {
+	timer, err := s.timerRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, timer_repository.ErrTimerNotFound) {
+			return entity.Timer{}, nil, ErrTimerNotFound
+		}
+		return entity.Timer{}, nil, ErrCannotFetchTimers
+	}
+
+	events, err := s.outboxRepo.ListByAggregate(ctx, id)
+	if err != nil {
+		return entity.Timer{}, nil, ErrCannotFetchTimers
+	}
+
+	return timer, events, nil
+}
+
+// Retrigger ставит таймер на немедленное срабатывание в любом статусе:
+// в том числе уже сработавший (повторная доставка) и отменённый.
+func (s *TimerAdminService) Retrigger(ctx context.Context, id uuid.UUID) (entity.Timer, error) {
+	timer, err := s.timerRepo.Retrigger(ctx, id)
+	if err != nil {
+		if errors.Is(err, timer_repository.ErrTimerNotFound) {
+			return entity.Timer{}, ErrTimerNotFound
+		}
+		return entity.Timer{}, ErrCannotUpdateTimer
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"timer_id": id.String(),
+		"type":     timer.Type.Name,
+	}).Info("timer retriggered by admin")
+
+	return timer, nil
+}
+
+// Cancel отменяет ожидающий таймер. Сработавший или уже отменённый таймер —
+// ErrTimerNotPending.
+func (s *TimerAdminService) Cancel(ctx context.Context, id uuid.UUID) (entity.Timer, error) {
+	var timer entity.Timer
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		cancelled, err := s.timerRepo.CancelByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		timer, err = s.timerRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if !cancelled {
+			return ErrTimerNotPending
+		}
+		return nil
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, timer_repository.ErrTimerNotFound):
+			return entity.Timer{}, ErrTimerNotFound
+		case errors.Is(err, ErrTimerNotPending):
+			return entity.Timer{}, ErrTimerNotPending
+		}
+		return entity.Timer{}, ErrCannotUpdateTimer
+	}
+
+	logrus.WithField("timer_id", id.String()).Info("timer cancelled by admin")
+
+	return timer, nil
+}
@@ -0,0 +1,214 @@
+package timer_admin_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	timer_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/timer"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/service/timer_admin/mocks"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/mock/gomock"
+)
+
+type dummyTransactor struct{}
+
+func (d dummyTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestSearch(t *testing.T) {
+	filter := entity.TimerFilter{
+		UserID: lo.ToPtr(uuid.New()),
+		Status: lo.ToPtr(entity.TimerStatusPending),
+	}
+
+	tests := []struct {
+		name      string
+		repoErr   error
+		wantCount int
+		wantError error
+	}{
+		{
+			name:      "found",
+			wantCount: 2,
+		},
+		{
+			name:      "repository_error",
+			repoErr:   errors.New("db is down"),
+			wantError: ErrCannotFetchTimers,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			timerRepo := mocks.NewMockTimerRepository(ctrl)
+
+			// Фильтр и пагинация передаются в репозиторий без изменений
+			timerRepo.EXPECT().Search(gomock.Any(), filter, 20, 40).
+				Return([]entity.Timer{{ID: uuid.New()}, {ID: uuid.New()}}, tt.repoErr)
+
+			svc := New(timerRepo, nil, dummyTransactor{})
+
+			timers, err := svc.Search(context.Background(), filter, 20, 40)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("Search() error = %v, wantErr %v", err, tt.wantError)
+			}
+			if tt.wantError == nil && len(timers) != tt.wantCount {
+				t.Errorf("Search() returned %d timers, want %d", len(timers), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestGetTimer(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name      string
+		getErr    error
+		listErr   error
+		wantError error
+	}{
+		{
+			name: "with_outbox_events",
+		},
+		{
+			name:      "not_found",
+			getErr:    timer_repository.ErrTimerNotFound,
+			wantError: ErrTimerNotFound,
+		},
+		{
+			name:      "outbox_error",
+			listErr:   errors.New("db is down"),
+			wantError: ErrCannotFetchTimers,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			timerRepo := mocks.NewMockTimerRepository(ctrl)
+			outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+
+			timerRepo.EXPECT().GetByID(gomock.Any(), id).Return(entity.Timer{ID: id}, tt.getErr)
+			if tt.getErr == nil {
+				outboxRepo.EXPECT().ListByAggregate(gomock.Any(), id).
+					Return([]entity.OutboxEvent{{AggregateID: id, Topic: "notification.events"}}, tt.listErr)
+			}
+
+			svc := New(timerRepo, outboxRepo, dummyTransactor{})
+
+			timer, events, err := svc.GetTimer(context.Background(), id)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("GetTimer() error = %v, wantErr %v", err, tt.wantError)
+			}
+			if tt.wantError == nil && (timer.ID != id || len(events) != 1) {
+				t.Errorf("GetTimer() = %+v, %d events", timer, len(events))
+			}
+		})
+	}
+}
+
+func TestRetrigger(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name      string
+		repoErr   error
+		wantError error
+	}{
+		{
+			name: "retriggered",
+		},
+		{
+			name:      "not_found",
+			repoErr:   timer_repository.ErrTimerNotFound,
+			wantError: ErrTimerNotFound,
+		},
+		{
+			name:      "repository_error",
+			repoErr:   errors.New("db is down"),
+			wantError: ErrCannotUpdateTimer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			timerRepo := mocks.NewMockTimerRepository(ctrl)
+
+			timerRepo.EXPECT().Retrigger(gomock.Any(), id).
+				Return(entity.Timer{ID: id, Status: entity.TimerStatusPending}, tt.repoErr)
+
+			svc := New(timerRepo, nil, dummyTransactor{})
+
+			timer, err := svc.Retrigger(context.Background(), id)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("Retrigger() error = %v, wantErr %v", err, tt.wantError)
+			}
+			if tt.wantError == nil && timer.ID != id {
+				t.Errorf("Retrigger() = %+v", timer)
+			}
+		})
+	}
+}
+
+func TestCancel(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name      string
+		cancelled bool
+		cancelErr error
+		getErr    error
+		wantError error
+	}{
+		{
+			name:      "cancelled",
+			cancelled: true,
+		},
+		{
+			name:      "already_triggered",
+			cancelled: false,
+			wantError: ErrTimerNotPending,
+		},
+		{
+			// CancelByID не различает «нет таймера» и «не pending», это делает GetByID
+			name:      "not_found",
+			getErr:    timer_repository.ErrTimerNotFound,
+			wantError: ErrTimerNotFound,
+		},
+		{
+			name:      "repository_error",
+			cancelErr: errors.New("db is down"),
+			wantError: ErrCannotUpdateTimer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			timerRepo := mocks.NewMockTimerRepository(ctrl)
+
+			timerRepo.EXPECT().CancelByID(gomock.Any(), id).Return(tt.cancelled, tt.cancelErr)
+			if tt.cancelErr == nil {
+				timerRepo.EXPECT().GetByID(gomock.Any(), id).
+					Return(entity.Timer{ID: id, Status: entity.TimerStatusCancelled}, tt.getErr)
+			}
+
+			svc := New(timerRepo, nil, dummyTransactor{})
+
+			timer, err := svc.Cancel(context.Background(), id)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("Cancel() error = %v, wantErr %v", err, tt.wantError)
+			}
+			if tt.wantError == nil && timer.ID != id {
+				t.Errorf("Cancel() = %+v", timer)
+			}
+		})
+	}
+}
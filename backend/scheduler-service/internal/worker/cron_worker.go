@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	cron_service "github.com/4udiwe/cowoking/scheduler-service/internal/service/cron"
+	"github.com/sirupsen/logrus"
+)
+
+// CronWorker периодически запускает задачи из реестра, время которых пришло.
+// interval определяет точность срабатывания, а не расписание задач.
+type CronWorker struct {
+	service *cron_service.CronService
+
+	batchLimit int
+	interval   time.Duration
+}
+
+func NewCronWorker(
+	service *cron_service.CronService,
+	batchLimit int,
+	interval time.Duration,
+) *CronWorker {
+	return &CronWorker{
+		service:    service,
+		batchLimit: batchLimit,
+		interval:   interval,
+	}
+}
+
+func (w *CronWorker) Run(ctx context.Context) {
+	go func() {
+		logrus.Infof(
+			"CronWorker started interval=%s batchLimit=%d",
+			w.interval,
+			w.batchLimit,
+		)
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logrus.Info("CronWorker stopped")
+				return
+
+			case <-ticker.C:
+				count, err := w.service.RunDueJobs(ctx, w.batchLimit)
+				if err != nil {
+					logrus.WithError(err).Error("CronWorker: batch failed")
+					continue
+				}
+				if count > 0 {
+					logrus.WithField("count", count).Info("CronWorker: jobs executed")
+				}
+			}
+		}
+	}()
+}
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
 	"github.com/sirupsen/logrus"
@@ -23,6 +24,8 @@ func (m *TimerToEventMapper) Map(timer entity.Timer) entity.OutboxEvent {
 			PlaceLabel: timer.PlaceLabel,
 			StartTime:  timer.StartTime,
 			EndTime:    timer.EndTime,
+
+			MinutesBefore: timer.OffsetMinutes,
 		}
 		data, _ := json.Marshal(payload)
 		var payloadMap map[string]any
@@ -34,6 +37,30 @@ func (m *TimerToEventMapper) Map(timer entity.Timer) entity.OutboxEvent {
 			Payload:       payloadMap,
 		}
 
+	case entity.TimerTypeBookingEndReminderID:
+		if timer.UserID == nil {
+			logrus.Error("booking end reminder timer has nil userID")
+		}
+		payload := ReminderPayload{
+			BookingID:  timer.BookingID,
+			UserID:     *timer.UserID,
+			PlaceID:    timer.PlaceID,
+			PlaceLabel: timer.PlaceLabel,
+			StartTime:  timer.StartTime,
+			EndTime:    timer.EndTime,
+
+			MinutesBefore: timer.OffsetMinutes,
+		}
+		data, _ := json.Marshal(payload)
+		var payloadMap map[string]any
+		json.Unmarshal(data, &payloadMap)
+		return entity.OutboxEvent{
+			AggregateType: "reminder",
+			AggregateID:   timer.ID,
+			EventType:     "end_approaching",
+			Payload:       payloadMap,
+		}
+
 	case entity.TimerTypeBookingExpireID:
 		if timer.UserID == nil {
 			logrus.Error("booking expire timer has nil userID")
@@ -55,3 +82,20 @@ func (m *TimerToEventMapper) Map(timer entity.Timer) entity.OutboxEvent {
 		return entity.OutboxEvent{}
 	}
 }
+
+// MapGeneric конвертирует универсальный таймер в событие outbox с его целевым
+// топиком. Payload таймера должен быть JSON-объектом.
+func (m *TimerToEventMapper) MapGeneric(timer entity.Timer) (entity.OutboxEvent, error) {
+	var payload map[string]any
+	if err := json.Unmarshal(timer.Payload, &payload); err != nil || payload == nil {
+		return entity.OutboxEvent{}, fmt.Errorf("generic timer %s: payload is not a JSON object", timer.ID)
+	}
+
+	return entity.OutboxEvent{
+		AggregateType: "timer",
+		AggregateID:   timer.ID,
+		EventType:     *timer.EventType,
+		Payload:       payload,
+		Topic:         *timer.TargetTopic,
+	}, nil
+}
@@ -6,7 +6,8 @@ import (
 	"github.com/google/uuid"
 )
 
-// ReminderPayload для события scheduler.reminder.triggered
+// ReminderPayload для событий reminder.triggered и reminder.end_approaching.
+// MinutesBefore — за сколько минут до начала (конца) бронирования.
 type ReminderPayload struct {
 	BookingID  uuid.UUID  `json:"bookingId"`
 	UserID     uuid.UUID  `json:"userId"`
@@ -14,6 +15,8 @@ type ReminderPayload struct {
 	PlaceLabel *string    `json:"placeLabel,omitempty"`
 	StartTime  *time.Time `json:"startTime,omitempty"`
 	EndTime    *time.Time `json:"endTime,omitempty"`
+
+	MinutesBefore *int `json:"minutesBefore,omitempty"`
 }
 
 type ExpirePayload struct {
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/4udiwe/avito-pvz/pkg/transactor"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/entity"
+	"github.com/4udiwe/cowoking/scheduler-service/internal/metrics"
 	outbox_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/outbox"
 	timer_repository "github.com/4udiwe/cowoking/scheduler-service/internal/repository/timer"
 
@@ -17,6 +19,8 @@ type Worker struct {
 	outboxRepo *outbox_repository.Repository
 	txManager  transactor.Transactor
 
+	metrics *metrics.Metrics
+
 	batchLimit int
 	interval   time.Duration
 }
@@ -25,6 +29,7 @@ func NewWorker(
 	timerRepo *timer_repository.TimerRepository,
 	outboxRepo *outbox_repository.Repository,
 	txManager transactor.Transactor,
+	metrics *metrics.Metrics,
 	batchLimit int,
 	interval time.Duration,
 ) *Worker {
@@ -32,6 +37,7 @@ func NewWorker(
 		timerRepo:  timerRepo,
 		outboxRepo: outboxRepo,
 		txManager:  txManager,
+		metrics:    metrics,
 		batchLimit: batchLimit,
 		interval:   interval,
 	}
@@ -63,6 +69,9 @@ func (w *Worker) Run(ctx context.Context) {
 
 func (w *Worker) processBatch(ctx context.Context) {
 
+	// типы сработавших таймеров: метрики обновляются только после коммита
+	var triggeredTypes []entity.TimerName
+
 	err := w.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
 
 		// 1️⃣ выбираем готовые таймеры
@@ -88,7 +97,25 @@ func (w *Worker) processBatch(ctx context.Context) {
 		// 2️⃣ создаем outbox события
 		for _, timer := range timers {
 
-			ev := mapper.Map(timer)
+			var ev entity.OutboxEvent
+
+			// Универсальный таймер уходит в outbox со своим целевым топиком.
+			// Таймер с невалидным payload остаётся pending.
+			if timer.Type.ID == entity.TimerTypeGenericID {
+				ev, err = mapper.MapGeneric(timer)
+				if err != nil {
+					logrus.WithFields(logrus.Fields{
+						"timer_id": timer.ID,
+						"topic":    *timer.TargetTopic,
+					}).WithError(err).
+						Error("SchedulerWorker: failed to map generic timer")
+
+					w.metrics.TimerPublishFailed(timer.Type.Name)
+					continue
+				}
+			} else {
+				ev = mapper.Map(timer)
+			}
 
 			err := w.outboxRepo.Create(ctx, ev)
 			if err != nil {
@@ -102,6 +129,7 @@ func (w *Worker) processBatch(ctx context.Context) {
 			}
 
 			triggeredIDs = append(triggeredIDs, timer.ID)
+			triggeredTypes = append(triggeredTypes, timer.Type.Name)
 
 			logrus.WithFields(logrus.Fields{
 				"timer_id": timer.ID,
@@ -109,6 +137,10 @@ func (w *Worker) processBatch(ctx context.Context) {
 			}).Info("SchedulerWorker: outbox event created")
 		}
 
+		if len(triggeredIDs) == 0 {
+			return nil
+		}
+
 		// 3️⃣ обновляем таймеры
 		err = w.timerRepo.MarkTriggered(ctx, triggeredIDs)
 		if err != nil {
@@ -127,5 +159,10 @@ func (w *Worker) processBatch(ctx context.Context) {
 	if err != nil {
 		logrus.WithError(err).
 			Error("SchedulerWorker: batch failed")
+		return
+	}
+
+	for _, t := range triggeredTypes {
+		w.metrics.TimerTriggered(t)
 	}
 }